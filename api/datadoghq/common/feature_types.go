@@ -0,0 +1,376 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// LogCollectionFeatureConfig contains Logs configuration.
+// Logs collection is run in the Agent.
+// +kubebuilder:object:generate=true
+type LogCollectionFeatureConfig struct {
+	// Enabled enables Log collection.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ContainerCollectAll enables Log collection from all containers.
+	// Default: false
+	// +optional
+	ContainerCollectAll *bool `json:"containerCollectAll,omitempty"`
+
+	// ContainerCollectUsingFiles enables log collection from files in `/var/log/pods instead` of using the container runtime API.
+	// Collecting logs from files is usually the most efficient way of collecting logs.
+	// See also: https://docs.datadoghq.com/agent/basic_agent_usage/kubernetes/#log-collection-setup
+	// Default: true
+	// +optional
+	ContainerCollectUsingFiles *bool `json:"containerCollectUsingFiles,omitempty"`
+
+	// ContainerLogsPath allows log collection from the container log path.
+	// Set to a different path if you are not using the Docker runtime.
+	// See also: https://docs.datadoghq.com/agent/kubernetes/daemonset_setup/?tab=k8sfile#create-manifest
+	// Default: `/var/lib/docker/containers`
+	// +optional
+	ContainerLogsPath *string `json:"containerLogsPath,omitempty"`
+
+	// PodLogsPath allows log collection from a pod log path.
+	// Default: `/var/log/pods`
+	// +optional
+	PodLogsPath *string `json:"podLogsPath,omitempty"`
+
+	// ContainerSymlinksPath allows log collection to use symbolic links in this directory to validate container ID -> pod.
+	// Default: `/var/log/containers`
+	// +optional
+	ContainerSymlinksPath *string `json:"containerSymlinksPath,omitempty"`
+
+	// TempStoragePath (always mounted from the host) is used by the Agent to store information about processed log files.
+	// If the Agent is restarted, it starts tailing the log files immediately.
+	// Default: `/var/lib/datadog-agent/logs`
+	// +optional
+	TempStoragePath *string `json:"tempStoragePath,omitempty"`
+
+	// OpenFilesLimit sets the maximum number of log files that the Datadog Agent tails.
+	// Increasing this limit can increase resource consumption of the Agent.
+	// See also: https://docs.datadoghq.com/agent/basic_agent_usage/kubernetes/#log-collection-setup
+	// Default: 100
+	// +optional
+	OpenFilesLimit *int32 `json:"openFilesLimit,omitempty"`
+
+	// ProcessingRules are global processing rules applied to all the logs collected by the Agent.
+	// Rules are applied in the order they are defined.
+	// See also: https://docs.datadoghq.com/agent/logs/advanced_log_collection/#global-processing-rules
+	// +optional
+	// +listType=atomic
+	ProcessingRules []LogsProcessingRule `json:"processingRules,omitempty"`
+
+	// AutoMultiLineDetection configures the automatic aggregation of multi-line logs.
+	// See also: https://docs.datadoghq.com/agent/logs/auto_multiline_detection/
+	// +optional
+	AutoMultiLineDetection *AutoMultiLineDetectionConfig `json:"autoMultiLineDetection,omitempty"`
+}
+
+// LogsProcessingRuleType is the type of a logs processing rule.
+// +kubebuilder:validation:Enum=exclude_at_match;include_at_match;mask_sequences
+type LogsProcessingRuleType string
+
+const (
+	// LogsProcessingRuleExcludeAtMatch drops the logs matching the pattern.
+	LogsProcessingRuleExcludeAtMatch LogsProcessingRuleType = "exclude_at_match"
+	// LogsProcessingRuleIncludeAtMatch only keeps the logs matching the pattern.
+	LogsProcessingRuleIncludeAtMatch LogsProcessingRuleType = "include_at_match"
+	// LogsProcessingRuleMaskSequences replaces the sequences matching the pattern with a placeholder.
+	LogsProcessingRuleMaskSequences LogsProcessingRuleType = "mask_sequences"
+	// LogsProcessingRuleMultiLine aggregates the lines following a line matching the pattern into a single log.
+	// It is only applied by the Agent in the log configurations of the integrations, not in the global processing rules:
+	// it is rejected in ProcessingRules, use AutoMultiLineDetection instead.
+	LogsProcessingRuleMultiLine LogsProcessingRuleType = "multi_line"
+)
+
+// LogsProcessingRule defines a logs processing rule.
+// +k8s:openapi-gen=true
+// +kubebuilder:object:generate=true
+type LogsProcessingRule struct {
+	// Type is the type of the rule: `exclude_at_match`, `include_at_match` or `mask_sequences`.
+	// `multi_line` rules are only applied in the log configurations of the integrations, use AutoMultiLineDetection instead.
+	Type LogsProcessingRuleType `json:"type"`
+
+	// Name is the name of the rule.
+	Name string `json:"name"`
+
+	// Pattern is the regular expression the rule applies to.
+	Pattern string `json:"pattern"`
+
+	// ReplacePlaceholder is the string replacing the matched sequences.
+	// Only used by `mask_sequences` rules.
+	// +optional
+	ReplacePlaceholder *string `json:"replacePlaceholder,omitempty"`
+}
+
+// AutoMultiLineDetectionConfig contains the automatic multi-line detection configuration.
+// +k8s:openapi-gen=true
+// +kubebuilder:object:generate=true
+type AutoMultiLineDetectionConfig struct {
+	// Enabled enables automatic multi-line detection for all the logs collected by the Agent.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ExtraPatterns are regular expressions matching the first line of multi-line logs, in addition to the built-in ones.
+	// Patterns must not contain whitespace characters, use the whitespace character class instead.
+	// +optional
+	// +listType=set
+	ExtraPatterns []string `json:"extraPatterns,omitempty"`
+
+	// DefaultSampleSize is the number of log lines used to detect a multi-line pattern.
+	// Default: 500
+	// +optional
+	DefaultSampleSize *int32 `json:"defaultSampleSize,omitempty"`
+
+	// DefaultMatchThreshold is the ratio of the sampled log lines that must match a pattern, between `0` and `1`.
+	// Default: `0.48`
+	// +optional
+	DefaultMatchThreshold *string `json:"defaultMatchThreshold,omitempty"`
+}
+
+// LiveProcessCollectionFeatureConfig contains Process Collection configuration.
+// Process Collection is run in the Process Agent.
+// +kubebuilder:object:generate=true
+type LiveProcessCollectionFeatureConfig struct {
+	// Enabled enables Process monitoring.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ScrubProcessArguments enables scrubbing of sensitive data in process command-lines (passwords, tokens, etc. ).
+	// Default: true
+	// +optional
+	ScrubProcessArguments *bool `json:"scrubProcessArguments,omitempty"`
+
+	// StripProcessArguments enables stripping of all process arguments.
+	// Default: false
+	// +optional
+	StripProcessArguments *bool `json:"stripProcessArguments,omitempty"`
+}
+
+// LiveContainerCollectionFeatureConfig contains Container Collection configuration.
+// Container Collection is run in the Process Agent.
+// +kubebuilder:object:generate=true
+type LiveContainerCollectionFeatureConfig struct {
+	// Enables container collection for the Live Container View.
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ProcessDiscoveryFeatureConfig contains the configuration for the process discovery check
+// ProcessDiscovery is run in the ProcessAgent
+// +kubebuilder:object:generate=true
+type ProcessDiscoveryFeatureConfig struct {
+	// Enabled enables the Process Discovery check in the Agent.
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// OOMKillFeatureConfig configures the OOM Kill monitoring feature.
+// +kubebuilder:object:generate=true
+type OOMKillFeatureConfig struct {
+	// Enables the OOMKill eBPF-based check.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// TCPQueueLengthFeatureConfig configures the TCP queue length monitoring feature.
+// +kubebuilder:object:generate=true
+type TCPQueueLengthFeatureConfig struct {
+	// Enables the TCP queue length eBPF-based check.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// EBPFCheckFeatureConfig configures the eBPF check feature.
+// +kubebuilder:object:generate=true
+type EBPFCheckFeatureConfig struct {
+	// Enables the eBPF check.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// NPMFeatureConfig contains NPM (Network Performance Monitoring) feature configuration.
+// Network Performance Monitoring runs in the System Probe and Process Agent.
+// +kubebuilder:object:generate=true
+type NPMFeatureConfig struct {
+	// Enabled enables Network Performance Monitoring.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// EnableConntrack enables the system-probe agent to connect to the netlink/conntrack subsystem to add NAT information to connection data.
+	// See also: http://conntrack-tools.netfilter.org/
+	// Default: false
+	// +optional
+	EnableConntrack *bool `json:"enableConntrack,omitempty"`
+
+	// CollectDNSStats enables DNS stat collection.
+	// Default: false
+	// +optional
+	CollectDNSStats *bool `json:"collectDNSStats,omitempty"`
+}
+
+// USMFeatureConfig contains USM (Universal Service Monitoring) feature configuration.
+// Universal Service Monitoring runs in the Process Agent and System Probe.
+// +kubebuilder:object:generate=true
+type USMFeatureConfig struct {
+	// Enabled enables Universal Service Monitoring.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ServiceDiscoveryFeatureConfig configures the service discovery check feature.
+// +kubebuilder:object:generate=true
+type ServiceDiscoveryFeatureConfig struct {
+	// Enables the service discovery check.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// GPUFeatureConfig contains the GPU monitoring configuration.
+// +kubebuilder:object:generate=true
+type GPUFeatureConfig struct {
+	// Enabled enables GPU monitoring.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// PodRuntimeClassName specifies the runtime class name required for the GPU monitoring feature.
+	// If the value is an empty string, the runtime class is not set.
+	// Default: nvidia
+	// +optional
+	PodRuntimeClassName *string `json:"requiredRuntimeClassName"`
+}
+
+// DogstatsdFeatureConfig contains the Dogstatsd configuration parameters.
+// +k8s:openapi-gen=true
+// +kubebuilder:object:generate=true
+type DogstatsdFeatureConfig struct {
+	// OriginDetectionEnabled enables origin detection for container tagging.
+	// See also: https://docs.datadoghq.com/developers/dogstatsd/unix_socket/#using-origin-detection-for-container-tagging
+	// +optional
+	OriginDetectionEnabled *bool `json:"originDetectionEnabled,omitempty"`
+
+	// TagCardinality configures tag cardinality for the metrics collected using origin detection (`low`, `orchestrator` or `high`).
+	// See also: https://docs.datadoghq.com/getting_started/tagging/assigning_tags/?tab=containerizedenvironments#environment-variables
+	// Cardinality default: low
+	// +optional
+	TagCardinality *string `json:"tagCardinality,omitempty"`
+
+	// HostPortConfig contains host port configuration.
+	// Enabled Default: false
+	// Port Default: 8125
+	// +optional
+	HostPortConfig *HostPortConfig `json:"hostPortConfig,omitempty"`
+
+	// UnixDomainSocketConfig contains socket configuration.
+	// See also: https://docs.datadoghq.com/agent/kubernetes/apm/?tab=helm#agent-environment-variables
+	// Enabled Default: true
+	// Path Default: `/var/run/datadog/dsd.socket`
+	// +optional
+	UnixDomainSocketConfig *UnixDomainSocketConfig `json:"unixDomainSocketConfig,omitempty"`
+
+	// Configure the Dogstasd Mapper Profiles.
+	// Can be passed as raw data or via a json encoded string in a config map.
+	// See also: https://docs.datadoghq.com/developers/dogstatsd/dogstatsd_mapper/
+	// +optional
+	MapperProfiles *CustomConfig `json:"mapperProfiles,omitempty"`
+}
+
+// ConfigMapConfig contains ConfigMap information used to store a configuration file.
+// +kubebuilder:object:generate=true
+type ConfigMapConfig struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name,omitempty"`
+
+	// Items maps a ConfigMap data `key` to a file `path` mount.
+	// +listType=map
+	// +listMapKey=key
+	// +optional
+	Items []corev1.KeyToPath `json:"items,omitempty"`
+}
+
+// CustomConfig provides a place for custom configuration of the Agent or Cluster Agent, corresponding to datadog.yaml,
+// system-probe.yaml, security-agent.yaml or datadog-cluster.yaml.
+// The configuration can be provided in the ConfigData field as raw data, or referenced in a ConfigMap.
+// Note: `ConfigData` and `ConfigMap` cannot be set together.
+// +k8s:openapi-gen=true
+// +kubebuilder:object:generate=true
+type CustomConfig struct {
+	// ConfigData corresponds to the configuration file content.
+	ConfigData *string `json:"configData,omitempty"`
+
+	// ConfigMap references an existing ConfigMap with the configuration file content.
+	ConfigMap *ConfigMapConfig `json:"configMap,omitempty"`
+}
+
+// MultiCustomConfig provides a place for custom configuration of the Agent or Cluster Agent, corresponding to /confd/*.yaml.
+// The configuration can be provided in the ConfigDataMap field as raw data, or referenced in a single ConfigMap.
+// Note: `ConfigDataMap` and `ConfigMap` cannot be set together.
+// +k8s:openapi-gen=true
+// +kubebuilder:object:generate=true
+type MultiCustomConfig struct {
+	// ConfigDataMap corresponds to the content of the configuration files.
+	// The key should be the filename the contents get mounted to; for instance check.py or check.yaml.
+	ConfigDataMap map[string]string `json:"configDataMap,omitempty"`
+
+	// ConfigMap references an existing ConfigMap with the content of the configuration files.
+	ConfigMap *ConfigMapConfig `json:"configMap,omitempty"`
+}
+
+// HostPortConfig contains host port configuration.
+// +kubebuilder:object:generate=true
+type HostPortConfig struct {
+	// Enabled enables host port configuration
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Port takes a port number (0 < x < 65536) to expose on the host. (Most containers do not need this.)
+	// If HostNetwork is enabled, this value must match the ContainerPort.
+	// +optional
+	Port *int32 `json:"hostPort,omitempty"`
+}
+
+// UnixDomainSocketConfig contains the Unix Domain Socket configuration.
+// +k8s:openapi-gen=true
+// +kubebuilder:object:generate=true
+type UnixDomainSocketConfig struct {
+	// Enabled enables Unix Domain Socket.
+	// Default: true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Path defines the socket path used when enabled.
+	// +optional
+	Path *string `json:"path,omitempty"`
+}
+
+// AgentConfigFileName is the list of known Agent config files
+type AgentConfigFileName string
+
+const (
+	// AgentGeneralConfigFile is the name of the main Agent config file
+	AgentGeneralConfigFile AgentConfigFileName = "datadog.yaml"
+	// SystemProbeConfigFile is the name of the of System Probe config file
+	SystemProbeConfigFile AgentConfigFileName = "system-probe.yaml"
+	// SecurityAgentConfigFile is the name of the Security Agent config file
+	SecurityAgentConfigFile AgentConfigFileName = "security-agent.yaml"
+	// ClusterAgentConfigFile is the name of the Cluster Agent config file
+	ClusterAgentConfigFile AgentConfigFileName = "datadog-cluster.yaml"
+)
@@ -14,6 +14,88 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoMultiLineDetectionConfig) DeepCopyInto(out *AutoMultiLineDetectionConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExtraPatterns != nil {
+		in, out := &in.ExtraPatterns, &out.ExtraPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultSampleSize != nil {
+		in, out := &in.DefaultSampleSize, &out.DefaultSampleSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DefaultMatchThreshold != nil {
+		in, out := &in.DefaultMatchThreshold, &out.DefaultMatchThreshold
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoMultiLineDetectionConfig.
+func (in *AutoMultiLineDetectionConfig) DeepCopy() *AutoMultiLineDetectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoMultiLineDetectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapConfig) DeepCopyInto(out *ConfigMapConfig) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1.KeyToPath, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapConfig.
+func (in *ConfigMapConfig) DeepCopy() *ConfigMapConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomConfig) DeepCopyInto(out *CustomConfig) {
+	*out = *in
+	if in.ConfigData != nil {
+		in, out := &in.ConfigData, &out.ConfigData
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomConfig.
+func (in *CustomConfig) DeepCopy() *CustomConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatadogPodAutoscalerCondition) DeepCopyInto(out *DatadogPodAutoscalerCondition) {
 	*out = *in
@@ -449,6 +531,350 @@ func (in *DatadogPodAutoscalerVerticalTargetStatus) DeepCopy() *DatadogPodAutosc
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DogstatsdFeatureConfig) DeepCopyInto(out *DogstatsdFeatureConfig) {
+	*out = *in
+	if in.OriginDetectionEnabled != nil {
+		in, out := &in.OriginDetectionEnabled, &out.OriginDetectionEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TagCardinality != nil {
+		in, out := &in.TagCardinality, &out.TagCardinality
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostPortConfig != nil {
+		in, out := &in.HostPortConfig, &out.HostPortConfig
+		*out = new(HostPortConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnixDomainSocketConfig != nil {
+		in, out := &in.UnixDomainSocketConfig, &out.UnixDomainSocketConfig
+		*out = new(UnixDomainSocketConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MapperProfiles != nil {
+		in, out := &in.MapperProfiles, &out.MapperProfiles
+		*out = new(CustomConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DogstatsdFeatureConfig.
+func (in *DogstatsdFeatureConfig) DeepCopy() *DogstatsdFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DogstatsdFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EBPFCheckFeatureConfig) DeepCopyInto(out *EBPFCheckFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EBPFCheckFeatureConfig.
+func (in *EBPFCheckFeatureConfig) DeepCopy() *EBPFCheckFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EBPFCheckFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUFeatureConfig) DeepCopyInto(out *GPUFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PodRuntimeClassName != nil {
+		in, out := &in.PodRuntimeClassName, &out.PodRuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUFeatureConfig.
+func (in *GPUFeatureConfig) DeepCopy() *GPUFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPortConfig) DeepCopyInto(out *HostPortConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPortConfig.
+func (in *HostPortConfig) DeepCopy() *HostPortConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPortConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LiveContainerCollectionFeatureConfig) DeepCopyInto(out *LiveContainerCollectionFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LiveContainerCollectionFeatureConfig.
+func (in *LiveContainerCollectionFeatureConfig) DeepCopy() *LiveContainerCollectionFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LiveContainerCollectionFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LiveProcessCollectionFeatureConfig) DeepCopyInto(out *LiveProcessCollectionFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ScrubProcessArguments != nil {
+		in, out := &in.ScrubProcessArguments, &out.ScrubProcessArguments
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StripProcessArguments != nil {
+		in, out := &in.StripProcessArguments, &out.StripProcessArguments
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LiveProcessCollectionFeatureConfig.
+func (in *LiveProcessCollectionFeatureConfig) DeepCopy() *LiveProcessCollectionFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LiveProcessCollectionFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogCollectionFeatureConfig) DeepCopyInto(out *LogCollectionFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ContainerCollectAll != nil {
+		in, out := &in.ContainerCollectAll, &out.ContainerCollectAll
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ContainerCollectUsingFiles != nil {
+		in, out := &in.ContainerCollectUsingFiles, &out.ContainerCollectUsingFiles
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ContainerLogsPath != nil {
+		in, out := &in.ContainerLogsPath, &out.ContainerLogsPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodLogsPath != nil {
+		in, out := &in.PodLogsPath, &out.PodLogsPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.ContainerSymlinksPath != nil {
+		in, out := &in.ContainerSymlinksPath, &out.ContainerSymlinksPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.TempStoragePath != nil {
+		in, out := &in.TempStoragePath, &out.TempStoragePath
+		*out = new(string)
+		**out = **in
+	}
+	if in.OpenFilesLimit != nil {
+		in, out := &in.OpenFilesLimit, &out.OpenFilesLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProcessingRules != nil {
+		in, out := &in.ProcessingRules, &out.ProcessingRules
+		*out = make([]LogsProcessingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AutoMultiLineDetection != nil {
+		in, out := &in.AutoMultiLineDetection, &out.AutoMultiLineDetection
+		*out = new(AutoMultiLineDetectionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectionFeatureConfig.
+func (in *LogCollectionFeatureConfig) DeepCopy() *LogCollectionFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LogCollectionFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogsProcessingRule) DeepCopyInto(out *LogsProcessingRule) {
+	*out = *in
+	if in.ReplacePlaceholder != nil {
+		in, out := &in.ReplacePlaceholder, &out.ReplacePlaceholder
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogsProcessingRule.
+func (in *LogsProcessingRule) DeepCopy() *LogsProcessingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LogsProcessingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiCustomConfig) DeepCopyInto(out *MultiCustomConfig) {
+	*out = *in
+	if in.ConfigDataMap != nil {
+		in, out := &in.ConfigDataMap, &out.ConfigDataMap
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiCustomConfig.
+func (in *MultiCustomConfig) DeepCopy() *MultiCustomConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiCustomConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPMFeatureConfig) DeepCopyInto(out *NPMFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableConntrack != nil {
+		in, out := &in.EnableConntrack, &out.EnableConntrack
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CollectDNSStats != nil {
+		in, out := &in.CollectDNSStats, &out.CollectDNSStats
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPMFeatureConfig.
+func (in *NPMFeatureConfig) DeepCopy() *NPMFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NPMFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OOMKillFeatureConfig) DeepCopyInto(out *OOMKillFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OOMKillFeatureConfig.
+func (in *OOMKillFeatureConfig) DeepCopy() *OOMKillFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OOMKillFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessDiscoveryFeatureConfig) DeepCopyInto(out *ProcessDiscoveryFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessDiscoveryFeatureConfig.
+func (in *ProcessDiscoveryFeatureConfig) DeepCopy() *ProcessDiscoveryFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessDiscoveryFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RollingUpdate) DeepCopyInto(out *RollingUpdate) {
 	*out = *in
@@ -474,6 +900,91 @@ func (in *RollingUpdate) DeepCopy() *RollingUpdate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceDiscoveryFeatureConfig) DeepCopyInto(out *ServiceDiscoveryFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceDiscoveryFeatureConfig.
+func (in *ServiceDiscoveryFeatureConfig) DeepCopy() *ServiceDiscoveryFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceDiscoveryFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPQueueLengthFeatureConfig) DeepCopyInto(out *TCPQueueLengthFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPQueueLengthFeatureConfig.
+func (in *TCPQueueLengthFeatureConfig) DeepCopy() *TCPQueueLengthFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPQueueLengthFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *USMFeatureConfig) DeepCopyInto(out *USMFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new USMFeatureConfig.
+func (in *USMFeatureConfig) DeepCopy() *USMFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(USMFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnixDomainSocketConfig) DeepCopyInto(out *UnixDomainSocketConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnixDomainSocketConfig.
+func (in *UnixDomainSocketConfig) DeepCopy() *UnixDomainSocketConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UnixDomainSocketConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpdateStrategy) DeepCopyInto(out *UpdateStrategy) {
 	*out = *in
@@ -7,7 +7,6 @@ package v1alpha1
 
 import (
 	"github.com/DataDog/datadog-operator/api/datadoghq/common"
-
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -28,6 +27,13 @@ const (
 type DatadogAgentProfileSpec struct {
 	ProfileAffinity *ProfileAffinity `json:"profileAffinity,omitempty"`
 	Config          *Config          `json:"config,omitempty"`
+
+	// Priority defines which profile is applied to a node matched by several profiles.
+	// The profile with the highest priority is applied. Profiles with the same priority
+	// are ordered by creation timestamp, then by name.
+	// Default: 0
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
 }
 
 type ProfileAffinity struct {
@@ -35,10 +41,58 @@ type ProfileAffinity struct {
 }
 
 type Config struct {
+	// Features overrides the configuration of the features of the DatadogAgent on the nodes
+	// targeted by the profile. Each feature set in the profile replaces the configuration of the
+	// same feature in the DatadogAgent.
+	// +optional
+	Features *ProfileFeatures `json:"features,omitempty"`
+
 	// Override the default configurations of the node agent.
 	Override map[ComponentName]*Override `json:"override,omitempty"`
 }
 
+// ProfileFeatures is the subset of the DatadogAgent features that can be configured per profile.
+// Only the features running in the node agent are supported: the Cluster Agent and the Cluster
+// Checks Runners are shared by all the profiles.
+type ProfileFeatures struct {
+	// LogCollection configuration.
+	// +optional
+	LogCollection *common.LogCollectionFeatureConfig `json:"logCollection,omitempty"`
+	// LiveProcessCollection configuration.
+	// +optional
+	LiveProcessCollection *common.LiveProcessCollectionFeatureConfig `json:"liveProcessCollection,omitempty"`
+	// LiveContainerCollection configuration.
+	// +optional
+	LiveContainerCollection *common.LiveContainerCollectionFeatureConfig `json:"liveContainerCollection,omitempty"`
+	// ProcessDiscovery configuration.
+	// +optional
+	ProcessDiscovery *common.ProcessDiscoveryFeatureConfig `json:"processDiscovery,omitempty"`
+	// OOMKill configuration.
+	// +optional
+	OOMKill *common.OOMKillFeatureConfig `json:"oomKill,omitempty"`
+	// TCPQueueLength configuration.
+	// +optional
+	TCPQueueLength *common.TCPQueueLengthFeatureConfig `json:"tcpQueueLength,omitempty"`
+	// EBPFCheck configuration.
+	// +optional
+	EBPFCheck *common.EBPFCheckFeatureConfig `json:"ebpfCheck,omitempty"`
+	// NPM (Network Performance Monitoring) configuration.
+	// +optional
+	NPM *common.NPMFeatureConfig `json:"npm,omitempty"`
+	// USM (Universal Service Monitoring) configuration.
+	// +optional
+	USM *common.USMFeatureConfig `json:"usm,omitempty"`
+	// Dogstatsd configuration.
+	// +optional
+	Dogstatsd *common.DogstatsdFeatureConfig `json:"dogstatsd,omitempty"`
+	// ServiceDiscovery configuration.
+	// +optional
+	ServiceDiscovery *common.ServiceDiscoveryFeatureConfig `json:"serviceDiscovery,omitempty"`
+	// GPU monitoring configuration.
+	// +optional
+	GPU *common.GPUFeatureConfig `json:"gpu,omitempty"`
+}
+
 type Override struct {
 	// Configure the basic configurations for an Agent container
 	// Valid Agent container names are: `agent`
@@ -68,6 +122,28 @@ type Override struct {
 	// Labels provide labels that are added to the Datadog Agent pods.
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// Configure the node agent pods tolerations.
+	// +optional
+	// +listType=atomic
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Specify additional volumes in the node agent pods.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// CustomConfiguration allows to specify custom configuration files for `datadog.yaml`, `datadog-cluster.yaml`, `security-agent.yaml`, and `system-probe.yaml`.
+	// The content is merged with configuration generated by the Datadog Operator, with priority given to custom configuration.
+	// WARNING: It is possible to override values set in the `DatadogAgent`.
+	// +optional
+	CustomConfigurations map[common.AgentConfigFileName]common.CustomConfig `json:"customConfigurations,omitempty"`
+
+	// Confd configuration allowing to specify config files for custom checks placed under /etc/datadog-agent/conf.d/.
+	// See https://docs.datadoghq.com/developers/integrations/ for more details.
+	// +optional
+	ExtraConfd *common.MultiCustomConfig `json:"extraConfd,omitempty"`
 }
 
 type Container struct {
@@ -114,6 +190,33 @@ type DatadogAgentProfileStatus struct {
 	// CreateStrategy is the state of the create strategy feature.
 	// +optional
 	CreateStrategy *CreateStrategy `json:"createStrategy,omitempty"`
+
+	// MatchingNodes is the number of nodes matching the profile affinity, including the nodes
+	// that another profile with a higher precedence is applied to.
+	// +optional
+	MatchingNodes int32 `json:"matchingNodes,omitempty"`
+
+	// WonNodes is the list of the nodes matching the DatadogAgentProfile that it is applied to.
+	// +optional
+	// +listType=set
+	WonNodes []string `json:"wonNodes,omitempty"`
+
+	// LostNodes is the list of the nodes matching the DatadogAgentProfile that another profile
+	// with a higher precedence is applied to.
+	// +optional
+	// +listType=map
+	// +listMapKey=node
+	LostNodes []LostNode `json:"lostNodes,omitempty"`
+}
+
+// LostNode is a node matching a DatadogAgentProfile that another profile is applied to.
+// +k8s:openapi-gen=true
+type LostNode struct {
+	// Node is the name of the node.
+	Node string `json:"node"`
+
+	// Profile is the namespaced name of the profile applied to the node.
+	Profile string `json:"profile"`
 }
 
 // CreateStrategy defines the observed state of the create strategy feature based on the agent deployment.
@@ -147,6 +250,7 @@ type CreateStrategy struct {
 // +kubebuilder:resource:path=datadogagentprofiles,shortName=dap
 // +kubebuilder:printcolumn:name="valid",type="string",JSONPath=".status.valid"
 // +kubebuilder:printcolumn:name="applied",type="string",JSONPath=".status.applied"
+// +kubebuilder:printcolumn:name="matching nodes",type="integer",JSONPath=".status.matchingNodes"
 // +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
 // +k8s:openapi-gen=true
 type DatadogAgentProfile struct {
@@ -26,8 +26,9 @@ func ValidateDatadogAgentProfileSpec(spec *DatadogAgentProfileSpec) error {
 	if spec.Config == nil {
 		return fmt.Errorf("config must be defined")
 	}
-	if spec.Config.Override == nil {
-		return fmt.Errorf("config override must be defined")
+	// A profile can only configure features, without overriding the Agent components
+	if spec.Config.Override == nil && (spec.Config.Features == nil || *spec.Config.Features == (ProfileFeatures{})) {
+		return fmt.Errorf("config override or features must be defined")
 	}
 
 	return nil
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/DataDog/datadog-operator/api/datadoghq/common"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -82,6 +83,38 @@ func TestIsValidDatadogAgentProfile(t *testing.T) {
 		},
 		Config: &Config{},
 	}
+	validFeaturesOnly := &DatadogAgentProfileSpec{
+		ProfileAffinity: &ProfileAffinity{
+			ProfileNodeAffinity: []corev1.NodeSelectorRequirement{
+				{
+					Key:      "foo",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"bar"},
+				},
+			},
+		},
+		Config: &Config{
+			Features: &ProfileFeatures{
+				NPM: &common.NPMFeatureConfig{
+					Enabled: apiutils.NewBoolPointer(false),
+				},
+			},
+		},
+	}
+	emptyFeatures := &DatadogAgentProfileSpec{
+		ProfileAffinity: &ProfileAffinity{
+			ProfileNodeAffinity: []corev1.NodeSelectorRequirement{
+				{
+					Key:      "foo",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"bar"},
+				},
+			},
+		},
+		Config: &Config{
+			Features: &ProfileFeatures{},
+		},
+	}
 	missingConfig := &DatadogAgentProfileSpec{
 		ProfileAffinity: &ProfileAffinity{
 			ProfileNodeAffinity: []corev1.NodeSelectorRequirement{
@@ -116,10 +149,20 @@ func TestIsValidDatadogAgentProfile(t *testing.T) {
 			name: "valid dap, resources specified in one container only",
 			spec: validResourceOverrideInOneContainerOnly,
 		},
+		{
+			// Profiles can change features without overriding the components
+			name: "valid dap, features only",
+			spec: validFeaturesOnly,
+		},
+		{
+			name:    "empty features",
+			spec:    emptyFeatures,
+			wantErr: "config override or features must be defined",
+		},
 		{
 			name:    "missing override",
 			spec:    missingOverride,
-			wantErr: "config override must be defined",
+			wantErr: "config override or features must be defined",
 		},
 		{
 			name:    "missing config",
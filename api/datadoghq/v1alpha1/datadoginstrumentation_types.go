@@ -0,0 +1,90 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DatadogInstrumentationSpec defines the desired state of DatadogInstrumentation
+// +k8s:openapi-gen=true
+type DatadogInstrumentationSpec struct {
+	// PodSelector selects the pods of the DatadogInstrumentation namespace to apply the auto instrumentation to.
+	// An empty selector matches all the pods of the namespace.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// DDTraceVersions is a map of tracer versions to inject for workloads that match the selector. The key is the tracer
+	// name and the value is the version to inject.
+	// ex: "java": "v1.18.0"
+	// +optional
+	DDTraceVersions map[string]string `json:"ddTraceVersions,omitempty"`
+
+	// DDTraceConfigs is a list of configuration options to use for the installed tracers. These options will be added
+	// as environment variables in addition to the injected tracer.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	DDTraceConfigs []corev1.EnvVar `json:"ddTraceConfigs,omitempty"`
+}
+
+// DatadogInstrumentationStatus defines the observed state of DatadogInstrumentation
+// +k8s:openapi-gen=true
+type DatadogInstrumentationStatus struct {
+	// Conditions represents the latest available observations of a DatadogInstrumentation's current state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Valid shows if the DatadogInstrumentation has a valid spec.
+	// +optional
+	Valid metav1.ConditionStatus `json:"valid,omitempty"`
+
+	// Accepted shows if the DatadogInstrumentation was merged into the Single Step Instrumentation targets of the Cluster Agent.
+	// +optional
+	Accepted metav1.ConditionStatus `json:"accepted,omitempty"`
+
+	// Target is the name of the Single Step Instrumentation target generated for this DatadogInstrumentation.
+	// +optional
+	Target string `json:"target,omitempty"`
+
+	// LastUpdate is the last time the status was updated.
+	// +optional
+	LastUpdate *metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// DatadogInstrumentation configures APM Single Step Instrumentation for the workloads of a namespace.
+// It is merged by the Datadog Operator into the `apm` feature targets of the DatadogAgent.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=datadoginstrumentations,scope=Namespaced,shortName=ddi
+// +kubebuilder:printcolumn:name="valid",type="string",JSONPath=".status.valid"
+// +kubebuilder:printcolumn:name="accepted",type="string",JSONPath=".status.accepted"
+// +kubebuilder:printcolumn:name="target",type="string",JSONPath=".status.target"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+// +genclient
+type DatadogInstrumentation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatadogInstrumentationSpec   `json:"spec,omitempty"`
+	Status DatadogInstrumentationStatus `json:"status,omitempty"`
+}
+
+// DatadogInstrumentationList contains a list of DatadogInstrumentation
+// +kubebuilder:object:root=true
+type DatadogInstrumentationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatadogInstrumentation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatadogInstrumentation{}, &DatadogInstrumentationList{})
+}
@@ -0,0 +1,42 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// IsValidDatadogInstrumentation is used to check if a DatadogInstrumentationSpec is valid
+func IsValidDatadogInstrumentation(spec *DatadogInstrumentationSpec) error {
+	var errs []error
+
+	if spec.PodSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(spec.PodSelector); err != nil {
+			errs = append(errs, fmt.Errorf("spec.podSelector is invalid: %w", err))
+		}
+	}
+
+	if len(spec.DDTraceVersions) == 0 && len(spec.DDTraceConfigs) == 0 {
+		errs = append(errs, fmt.Errorf("at least one of spec.ddTraceVersions or spec.ddTraceConfigs must be defined"))
+	}
+
+	for lang, version := range spec.DDTraceVersions {
+		if version == "" {
+			errs = append(errs, fmt.Errorf("spec.ddTraceVersions[%s] must not be empty", lang))
+		}
+	}
+
+	for _, env := range spec.DDTraceConfigs {
+		if env.Name == "" {
+			errs = append(errs, fmt.Errorf("spec.ddTraceConfigs entries must have a name"))
+		}
+	}
+
+	return utilserrors.NewAggregate(errs)
+}
@@ -0,0 +1,73 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsValidDatadogInstrumentation(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *DatadogInstrumentationSpec
+		wantErr string
+	}{
+		{
+			name: "valid with versions",
+			spec: &DatadogInstrumentationSpec{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "web"},
+				},
+				DDTraceVersions: map[string]string{"java": "v1.18.0"},
+			},
+		},
+		{
+			name: "valid with configs only",
+			spec: &DatadogInstrumentationSpec{
+				DDTraceConfigs: []corev1.EnvVar{{Name: "DD_PROFILING_ENABLED", Value: "true"}},
+			},
+		},
+		{
+			name:    "empty spec",
+			spec:    &DatadogInstrumentationSpec{},
+			wantErr: "at least one of spec.ddTraceVersions or spec.ddTraceConfigs must be defined",
+		},
+		{
+			name: "invalid selector",
+			spec: &DatadogInstrumentationSpec{
+				PodSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "app", Operator: metav1.LabelSelectorOpIn},
+					},
+				},
+				DDTraceVersions: map[string]string{"java": "v1"},
+			},
+			wantErr: "spec.podSelector is invalid",
+		},
+		{
+			name: "empty version",
+			spec: &DatadogInstrumentationSpec{
+				DDTraceVersions: map[string]string{"python": ""},
+			},
+			wantErr: "spec.ddTraceVersions[python] must not be empty",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := IsValidDatadogInstrumentation(test.spec)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.wantErr)
+			}
+		})
+	}
+}
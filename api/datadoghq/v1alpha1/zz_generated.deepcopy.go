@@ -20,6 +20,11 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = new(ProfileFeatures)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Override != nil {
 		in, out := &in.Override, &out.Override
 		*out = make(map[ComponentName]*Override, len(*in))
@@ -252,6 +257,11 @@ func (in *DatadogAgentProfileSpec) DeepCopyInto(out *DatadogAgentProfileSpec) {
 		*out = new(Config)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogAgentProfileSpec.
@@ -283,6 +293,16 @@ func (in *DatadogAgentProfileStatus) DeepCopyInto(out *DatadogAgentProfileStatus
 		*out = new(CreateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WonNodes != nil {
+		in, out := &in.WonNodes, &out.WonNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LostNodes != nil {
+		in, out := &in.LostNodes, &out.LostNodes
+		*out = make([]LostNode, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogAgentProfileStatus.
@@ -532,6 +552,125 @@ func (in *DatadogGenericResourceStatus) DeepCopy() *DatadogGenericResourceStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogInstrumentation) DeepCopyInto(out *DatadogInstrumentation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogInstrumentation.
+func (in *DatadogInstrumentation) DeepCopy() *DatadogInstrumentation {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogInstrumentation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogInstrumentation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogInstrumentationList) DeepCopyInto(out *DatadogInstrumentationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatadogInstrumentation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogInstrumentationList.
+func (in *DatadogInstrumentationList) DeepCopy() *DatadogInstrumentationList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogInstrumentationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogInstrumentationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogInstrumentationSpec) DeepCopyInto(out *DatadogInstrumentationSpec) {
+	*out = *in
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DDTraceVersions != nil {
+		in, out := &in.DDTraceVersions, &out.DDTraceVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DDTraceConfigs != nil {
+		in, out := &in.DDTraceConfigs, &out.DDTraceConfigs
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogInstrumentationSpec.
+func (in *DatadogInstrumentationSpec) DeepCopy() *DatadogInstrumentationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogInstrumentationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogInstrumentationStatus) DeepCopyInto(out *DatadogInstrumentationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUpdate != nil {
+		in, out := &in.LastUpdate, &out.LastUpdate
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogInstrumentationStatus.
+func (in *DatadogInstrumentationStatus) DeepCopy() *DatadogInstrumentationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogInstrumentationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatadogMetric) DeepCopyInto(out *DatadogMetric) {
 	*out = *in
@@ -1324,6 +1463,21 @@ func (in *DatadogSLOStatus) DeepCopy() *DatadogSLOStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LostNode) DeepCopyInto(out *LostNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LostNode.
+func (in *LostNode) DeepCopy() *LostNode {
+	if in == nil {
+		return nil
+	}
+	out := new(LostNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Override) DeepCopyInto(out *Override) {
 	*out = *in
@@ -1365,6 +1519,32 @@ func (in *Override) DeepCopyInto(out *Override) {
 			(*out)[key] = val
 		}
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CustomConfigurations != nil {
+		in, out := &in.CustomConfigurations, &out.CustomConfigurations
+		*out = make(map[common.AgentConfigFileName]common.CustomConfig, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ExtraConfd != nil {
+		in, out := &in.ExtraConfd, &out.ExtraConfd
+		*out = new(common.MultiCustomConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Override.
@@ -1398,3 +1578,78 @@ func (in *ProfileAffinity) DeepCopy() *ProfileAffinity {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileFeatures) DeepCopyInto(out *ProfileFeatures) {
+	*out = *in
+	if in.LogCollection != nil {
+		in, out := &in.LogCollection, &out.LogCollection
+		*out = new(common.LogCollectionFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LiveProcessCollection != nil {
+		in, out := &in.LiveProcessCollection, &out.LiveProcessCollection
+		*out = new(common.LiveProcessCollectionFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LiveContainerCollection != nil {
+		in, out := &in.LiveContainerCollection, &out.LiveContainerCollection
+		*out = new(common.LiveContainerCollectionFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProcessDiscovery != nil {
+		in, out := &in.ProcessDiscovery, &out.ProcessDiscovery
+		*out = new(common.ProcessDiscoveryFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OOMKill != nil {
+		in, out := &in.OOMKill, &out.OOMKill
+		*out = new(common.OOMKillFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TCPQueueLength != nil {
+		in, out := &in.TCPQueueLength, &out.TCPQueueLength
+		*out = new(common.TCPQueueLengthFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EBPFCheck != nil {
+		in, out := &in.EBPFCheck, &out.EBPFCheck
+		*out = new(common.EBPFCheckFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NPM != nil {
+		in, out := &in.NPM, &out.NPM
+		*out = new(common.NPMFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.USM != nil {
+		in, out := &in.USM, &out.USM
+		*out = new(common.USMFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Dogstatsd != nil {
+		in, out := &in.Dogstatsd, &out.Dogstatsd
+		*out = new(common.DogstatsdFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceDiscovery != nil {
+		in, out := &in.ServiceDiscovery, &out.ServiceDiscovery
+		*out = new(common.ServiceDiscoveryFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(common.GPUFeatureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileFeatures.
+func (in *ProfileFeatures) DeepCopy() *ProfileFeatures {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileFeatures)
+	in.DeepCopyInto(out)
+	return out
+}
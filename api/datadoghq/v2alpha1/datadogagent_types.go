@@ -8,6 +8,7 @@ package v2alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/DataDog/datadog-operator/api/datadoghq/common"
 )
@@ -37,6 +38,11 @@ type DatadogAgentSpec struct {
 	// Override the default configurations of the agents
 	// +optional
 	Override map[ComponentName]*DatadogAgentComponentOverride `json:"override,omitempty"`
+
+	// RolloutPolicy defines when a rollout of the agents is considered complete.
+	// It is used by the `check-operator upgrade` command, for instance run as a Helm test or an Argo CD hook.
+	// +optional
+	RolloutPolicy *RolloutPolicy `json:"rolloutPolicy,omitempty"`
 }
 
 // DatadogFeatures are features running on the Agent and Cluster Agent.
@@ -47,19 +53,19 @@ type DatadogFeatures struct {
 	// OtelCollector configuration.
 	OtelCollector *OtelCollectorFeatureConfig `json:"otelCollector,omitempty"`
 	// LogCollection configuration.
-	LogCollection *LogCollectionFeatureConfig `json:"logCollection,omitempty"`
+	LogCollection *common.LogCollectionFeatureConfig `json:"logCollection,omitempty"`
 	// LiveProcessCollection configuration.
-	LiveProcessCollection *LiveProcessCollectionFeatureConfig `json:"liveProcessCollection,omitempty"`
+	LiveProcessCollection *common.LiveProcessCollectionFeatureConfig `json:"liveProcessCollection,omitempty"`
 	// LiveContainerCollection configuration.
-	LiveContainerCollection *LiveContainerCollectionFeatureConfig `json:"liveContainerCollection,omitempty"`
+	LiveContainerCollection *common.LiveContainerCollectionFeatureConfig `json:"liveContainerCollection,omitempty"`
 	// ProcessDiscovery configuration.
-	ProcessDiscovery *ProcessDiscoveryFeatureConfig `json:"processDiscovery,omitempty"`
+	ProcessDiscovery *common.ProcessDiscoveryFeatureConfig `json:"processDiscovery,omitempty"`
 	// OOMKill configuration.
-	OOMKill *OOMKillFeatureConfig `json:"oomKill,omitempty"`
+	OOMKill *common.OOMKillFeatureConfig `json:"oomKill,omitempty"`
 	// TCPQueueLength configuration.
-	TCPQueueLength *TCPQueueLengthFeatureConfig `json:"tcpQueueLength,omitempty"`
+	TCPQueueLength *common.TCPQueueLengthFeatureConfig `json:"tcpQueueLength,omitempty"`
 	// EBPFCheck configuration.
-	EBPFCheck *EBPFCheckFeatureConfig `json:"ebpfCheck,omitempty"`
+	EBPFCheck *common.EBPFCheckFeatureConfig `json:"ebpfCheck,omitempty"`
 	// APM (Application Performance Monitoring) configuration.
 	APM *APMFeatureConfig `json:"apm,omitempty"`
 	// ASM (Application Security Management) configuration.
@@ -69,11 +75,11 @@ type DatadogFeatures struct {
 	// CWS (Cloud Workload Security) configuration.
 	CWS *CWSFeatureConfig `json:"cws,omitempty"`
 	// NPM (Network Performance Monitoring) configuration.
-	NPM *NPMFeatureConfig `json:"npm,omitempty"`
+	NPM *common.NPMFeatureConfig `json:"npm,omitempty"`
 	// USM (Universal Service Monitoring) configuration.
-	USM *USMFeatureConfig `json:"usm,omitempty"`
+	USM *common.USMFeatureConfig `json:"usm,omitempty"`
 	// Dogstatsd configuration.
-	Dogstatsd *DogstatsdFeatureConfig `json:"dogstatsd,omitempty"`
+	Dogstatsd *common.DogstatsdFeatureConfig `json:"dogstatsd,omitempty"`
 	// OTLP ingest configuration
 	OTLP *OTLPFeatureConfig `json:"otlp,omitempty"`
 	// Remote Configuration configuration.
@@ -81,9 +87,9 @@ type DatadogFeatures struct {
 	// SBOM collection configuration.
 	SBOM *SBOMFeatureConfig `json:"sbom,omitempty"`
 	// ServiceDiscovery
-	ServiceDiscovery *ServiceDiscoveryFeatureConfig `json:"serviceDiscovery,omitempty"`
+	ServiceDiscovery *common.ServiceDiscoveryFeatureConfig `json:"serviceDiscovery,omitempty"`
 	// GPU monitoring
-	GPU *GPUFeatureConfig `json:"gpu,omitempty"`
+	GPU *common.GPUFeatureConfig `json:"gpu,omitempty"`
 
 	// Cluster-level features
 
@@ -122,14 +128,14 @@ type APMFeatureConfig struct {
 	// Enabled Default: false
 	// Port Default: 8126
 	// +optional
-	HostPortConfig *HostPortConfig `json:"hostPortConfig,omitempty"`
+	HostPortConfig *common.HostPortConfig `json:"hostPortConfig,omitempty"`
 
 	// UnixDomainSocketConfig contains socket configuration.
 	// See also: https://docs.datadoghq.com/agent/kubernetes/apm/?tab=helm#agent-environment-variables
 	// Enabled Default: true
 	// Path Default: `/var/run/datadog/apm.socket`
 	// +optional
-	UnixDomainSocketConfig *UnixDomainSocketConfig `json:"unixDomainSocketConfig,omitempty"`
+	UnixDomainSocketConfig *common.UnixDomainSocketConfig `json:"unixDomainSocketConfig,omitempty"`
 
 	// SingleStepInstrumentation allows the agent to inject the Datadog APM libraries into all pods in the cluster.
 	// Feature is in beta.
@@ -187,6 +193,7 @@ type SingleStepInstrumentation struct {
 
 	// Targets is a list of targets to apply the auto instrumentation to. The first target that matches the pod will be
 	// used. If no target matches, the auto instrumentation will not be applied.
+	// Targets generated from DatadogInstrumentation objects are evaluated before the ones listed here.
 	// (Requires Cluster Agent 7.64.0+)
 	// +optional
 	Targets []SSITarget `json:"targets,omitempty"`
@@ -290,118 +297,6 @@ type ASMIASTConfig struct {
 	Enabled *bool `json:"enabled,omitempty"`
 }
 
-// LogCollectionFeatureConfig contains Logs configuration.
-// Logs collection is run in the Agent.
-type LogCollectionFeatureConfig struct {
-	// Enabled enables Log collection.
-	// Default: false
-	// +optional
-	Enabled *bool `json:"enabled,omitempty"`
-
-	// ContainerCollectAll enables Log collection from all containers.
-	// Default: false
-	// +optional
-	ContainerCollectAll *bool `json:"containerCollectAll,omitempty"`
-
-	// ContainerCollectUsingFiles enables log collection from files in `/var/log/pods instead` of using the container runtime API.
-	// Collecting logs from files is usually the most efficient way of collecting logs.
-	// See also: https://docs.datadoghq.com/agent/basic_agent_usage/kubernetes/#log-collection-setup
-	// Default: true
-	// +optional
-	ContainerCollectUsingFiles *bool `json:"containerCollectUsingFiles,omitempty"`
-
-	// ContainerLogsPath allows log collection from the container log path.
-	// Set to a different path if you are not using the Docker runtime.
-	// See also: https://docs.datadoghq.com/agent/kubernetes/daemonset_setup/?tab=k8sfile#create-manifest
-	// Default: `/var/lib/docker/containers`
-	// +optional
-	ContainerLogsPath *string `json:"containerLogsPath,omitempty"`
-
-	// PodLogsPath allows log collection from a pod log path.
-	// Default: `/var/log/pods`
-	// +optional
-	PodLogsPath *string `json:"podLogsPath,omitempty"`
-
-	// ContainerSymlinksPath allows log collection to use symbolic links in this directory to validate container ID -> pod.
-	// Default: `/var/log/containers`
-	// +optional
-	ContainerSymlinksPath *string `json:"containerSymlinksPath,omitempty"`
-
-	// TempStoragePath (always mounted from the host) is used by the Agent to store information about processed log files.
-	// If the Agent is restarted, it starts tailing the log files immediately.
-	// Default: `/var/lib/datadog-agent/logs`
-	// +optional
-	TempStoragePath *string `json:"tempStoragePath,omitempty"`
-
-	// OpenFilesLimit sets the maximum number of log files that the Datadog Agent tails.
-	// Increasing this limit can increase resource consumption of the Agent.
-	// See also: https://docs.datadoghq.com/agent/basic_agent_usage/kubernetes/#log-collection-setup
-	// Default: 100
-	// +optional
-	OpenFilesLimit *int32 `json:"openFilesLimit,omitempty"`
-}
-
-// LiveProcessCollectionFeatureConfig contains Process Collection configuration.
-// Process Collection is run in the Process Agent.
-type LiveProcessCollectionFeatureConfig struct {
-	// Enabled enables Process monitoring.
-	// Default: false
-	// +optional
-	Enabled *bool `json:"enabled,omitempty"`
-
-	// ScrubProcessArguments enables scrubbing of sensitive data in process command-lines (passwords, tokens, etc. ).
-	// Default: true
-	// +optional
-	ScrubProcessArguments *bool `json:"scrubProcessArguments,omitempty"`
-
-	// StripProcessArguments enables stripping of all process arguments.
-	// Default: false
-	// +optional
-	StripProcessArguments *bool `json:"stripProcessArguments,omitempty"`
-}
-
-// LiveContainerCollectionFeatureConfig contains Container Collection configuration.
-// Container Collection is run in the Process Agent.
-type LiveContainerCollectionFeatureConfig struct {
-	// Enables container collection for the Live Container View.
-	// Default: true
-	// +optional
-	Enabled *bool `json:"enabled,omitempty"`
-}
-
-// ProcessDiscoveryFeatureConfig contains the configuration for the process discovery check
-// ProcessDiscovery is run in the ProcessAgent
-type ProcessDiscoveryFeatureConfig struct {
-	// Enabled enables the Process Discovery check in the Agent.
-	// Default: true
-	// +optional
-	Enabled *bool `json:"enabled,omitempty"`
-}
-
-// OOMKillFeatureConfig configures the OOM Kill monitoring feature.
-type OOMKillFeatureConfig struct {
-	// Enables the OOMKill eBPF-based check.
-	// Default: false
-	// +optional
-	Enabled *bool `json:"enabled,omitempty"`
-}
-
-// TCPQueueLengthFeatureConfig configures the TCP queue length monitoring feature.
-type TCPQueueLengthFeatureConfig struct {
-	// Enables the TCP queue length eBPF-based check.
-	// Default: false
-	// +optional
-	Enabled *bool `json:"enabled,omitempty"`
-}
-
-// EBPFCheckFeatureConfig configures the eBPF check feature.
-type EBPFCheckFeatureConfig struct {
-	// Enables the eBPF check.
-	// Default: false
-	// +optional
-	Enabled *bool `json:"enabled,omitempty"`
-}
-
 // CSPMFeatureConfig contains CSPM (Cloud Security Posture Management) configuration.
 // CSPM runs in the Security Agent and Cluster Agent.
 type CSPMFeatureConfig struct {
@@ -418,7 +313,7 @@ type CSPMFeatureConfig struct {
 	// The content of the ConfigMap will be merged with the benchmarks bundled with the agent.
 	// Any benchmarks with the same name as those existing in the agent will take precedence.
 	// +optional
-	CustomBenchmarks *CustomConfig `json:"customBenchmarks,omitempty"`
+	CustomBenchmarks *common.CustomConfig `json:"customBenchmarks,omitempty"`
 
 	// HostBenchmarks contains configuration for host benchmarks.
 	// +optional
@@ -455,7 +350,7 @@ type CWSFeatureConfig struct {
 	// The content of the ConfigMap will be merged with the policies bundled with the agent.
 	// Any policies with the same name as those existing in the agent will take precedence.
 	// +optional
-	CustomPolicies *CustomConfig `json:"customPolicies,omitempty"`
+	CustomPolicies *common.CustomConfig `json:"customPolicies,omitempty"`
 }
 
 type CWSNetworkConfig struct {
@@ -486,8 +381,62 @@ type RemoteConfigurationFeatureConfig struct {
 	// Default: true
 	// +optional
 	Enabled *bool `json:"enabled,omitempty"`
+
+	// AllowedFeatures lists the features that Remote Configuration is allowed to configure, by their name
+	// in the DatadogAgent features (for example `sbom`, `apm` or `logCollection`).
+	// Configurations received for other features are rejected. `remoteConfiguration` can't be allowed.
+	// When not set, Remote Configuration can configure `sbom`, `cws`, `usm`, `cspm` and `orchestratorExplorer`.
+	// +optional
+	// +listType=set
+	AllowedFeatures []RemoteConfigFeature `json:"allowedFeatures,omitempty"`
+
+	// ApprovalMode defines how the configurations received from Remote Configuration are applied.
+	// Automatic: configurations are applied as soon as they are received.
+	// Manual: configurations are staged in the status as a proposal, and applied once the
+	// `agent.datadoghq.com/remote-config-approved-id` annotation of the DatadogAgent is set to the proposal config ID.
+	// Default: Automatic
+	// +optional
+	ApprovalMode *RemoteConfigApprovalMode `json:"approvalMode,omitempty"`
+
+	// HistoryLimit is the number of applied configurations kept in the `remoteConfigHistory` status, the oldest
+	// ones are dropped first. The audit trail of the applied configurations is the operator logs.
+	// Default: 10
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
 }
 
+// RemoteConfigFeature is the name of a feature that can be configured by Remote Configuration,
+// as defined in the DatadogAgent features, for example `sbom` or `logCollection`.
+type RemoteConfigFeature string
+
+const (
+	// RemoteConfigFeatureSBOM is the SBOM feature.
+	RemoteConfigFeatureSBOM RemoteConfigFeature = "sbom"
+	// RemoteConfigFeatureCWS is the Cloud Workload Security feature.
+	RemoteConfigFeatureCWS RemoteConfigFeature = "cws"
+	// RemoteConfigFeatureUSM is the Universal Service Monitoring feature.
+	RemoteConfigFeatureUSM RemoteConfigFeature = "usm"
+	// RemoteConfigFeatureCSPM is the Cloud Security Posture Management feature.
+	RemoteConfigFeatureCSPM RemoteConfigFeature = "cspm"
+	// RemoteConfigFeatureOrchestratorExplorer is the Orchestrator Explorer feature.
+	RemoteConfigFeatureOrchestratorExplorer RemoteConfigFeature = "orchestratorExplorer"
+	// RemoteConfigFeatureRemoteConfiguration is the Remote Configuration feature, it can't be configured by Remote Configuration.
+	RemoteConfigFeatureRemoteConfiguration RemoteConfigFeature = "remoteConfiguration"
+)
+
+// RemoteConfigApprovalMode defines how the configurations received from Remote Configuration are applied.
+// +kubebuilder:validation:Enum=Automatic;Manual
+type RemoteConfigApprovalMode string
+
+const (
+	// RemoteConfigApprovalModeAutomatic applies the configurations as soon as they are received.
+	RemoteConfigApprovalModeAutomatic RemoteConfigApprovalMode = "Automatic"
+	// RemoteConfigApprovalModeManual stages the configurations until they are approved.
+	RemoteConfigApprovalModeManual RemoteConfigApprovalMode = "Manual"
+)
+
 // SBOMFeatureConfig contains SBOM (Software Bill of Materials) collection configuration.
 // SBOM runs in the Agent.
 type SBOMFeatureConfig struct {
@@ -536,91 +485,6 @@ type SBOMContainerImageConfig struct {
 	OverlayFSDirectScan bool `json:"overlayFSDirectScan,omitempty"`
 }
 
-// NPMFeatureConfig contains NPM (Network Performance Monitoring) feature configuration.
-// Network Performance Monitoring runs in the System Probe and Process Agent.
-type NPMFeatureConfig struct {
-	// Enabled enables Network Performance Monitoring.
-	// Default: false
-	// +optional
-	Enabled *bool `json:"enabled,omitempty"`
-
-	// EnableConntrack enables the system-probe agent to connect to the netlink/conntrack subsystem to add NAT information to connection data.
-	// See also: http://conntrack-tools.netfilter.org/
-	// Default: false
-	// +optional
-	EnableConntrack *bool `json:"enableConntrack,omitempty"`
-
-	// CollectDNSStats enables DNS stat collection.
-	// Default: false
-	// +optional
-	CollectDNSStats *bool `json:"collectDNSStats,omitempty"`
-}
-
-// USMFeatureConfig contains USM (Universal Service Monitoring) feature configuration.
-// Universal Service Monitoring runs in the Process Agent and System Probe.
-type USMFeatureConfig struct {
-	// Enabled enables Universal Service Monitoring.
-	// Default: false
-	// +optional
-	Enabled *bool `json:"enabled,omitempty"`
-}
-
-// ServiceDiscoveryFeatureConfig configures the service discovery check feature.
-type ServiceDiscoveryFeatureConfig struct {
-	// Enables the service discovery check.
-	// Default: false
-	// +optional
-	Enabled *bool `json:"enabled,omitempty"`
-}
-
-// GPUFeatureConfig contains the GPU monitoring configuration.
-type GPUFeatureConfig struct {
-	// Enabled enables GPU monitoring.
-	// Default: false
-	// +optional
-	Enabled *bool `json:"enabled,omitempty"`
-
-	// PodRuntimeClassName specifies the runtime class name required for the GPU monitoring feature.
-	// If the value is an empty string, the runtime class is not set.
-	// Default: nvidia
-	// +optional
-	PodRuntimeClassName *string `json:"requiredRuntimeClassName"`
-}
-
-// DogstatsdFeatureConfig contains the Dogstatsd configuration parameters.
-// +k8s:openapi-gen=true
-type DogstatsdFeatureConfig struct {
-	// OriginDetectionEnabled enables origin detection for container tagging.
-	// See also: https://docs.datadoghq.com/developers/dogstatsd/unix_socket/#using-origin-detection-for-container-tagging
-	// +optional
-	OriginDetectionEnabled *bool `json:"originDetectionEnabled,omitempty"`
-
-	// TagCardinality configures tag cardinality for the metrics collected using origin detection (`low`, `orchestrator` or `high`).
-	// See also: https://docs.datadoghq.com/getting_started/tagging/assigning_tags/?tab=containerizedenvironments#environment-variables
-	// Cardinality default: low
-	// +optional
-	TagCardinality *string `json:"tagCardinality,omitempty"`
-
-	// HostPortConfig contains host port configuration.
-	// Enabled Default: false
-	// Port Default: 8125
-	// +optional
-	HostPortConfig *HostPortConfig `json:"hostPortConfig,omitempty"`
-
-	// UnixDomainSocketConfig contains socket configuration.
-	// See also: https://docs.datadoghq.com/agent/kubernetes/apm/?tab=helm#agent-environment-variables
-	// Enabled Default: true
-	// Path Default: `/var/run/datadog/dsd.socket`
-	// +optional
-	UnixDomainSocketConfig *UnixDomainSocketConfig `json:"unixDomainSocketConfig,omitempty"`
-
-	// Configure the Dogstasd Mapper Profiles.
-	// Can be passed as raw data or via a json encoded string in a config map.
-	// See also: https://docs.datadoghq.com/developers/dogstatsd/dogstatsd_mapper/
-	// +optional
-	MapperProfiles *CustomConfig `json:"mapperProfiles,omitempty"`
-}
-
 // OTLPFeatureConfig contains configuration for OTLP ingest.
 // +k8s:openapi-gen=true
 type OTLPFeatureConfig struct {
@@ -657,7 +521,7 @@ type OTLPGRPCConfig struct {
 	// Enable hostPort for OTLP/gRPC
 	// Default: true
 	// +optional
-	HostPortConfig *HostPortConfig `json:"hostPortConfig,omitempty"`
+	HostPortConfig *common.HostPortConfig `json:"hostPortConfig,omitempty"`
 
 	// Endpoint for OTLP/gRPC.
 	// gRPC supports several naming schemes: https://github.com/grpc/grpc/blob/master/doc/naming.md
@@ -677,7 +541,7 @@ type OTLPHTTPConfig struct {
 	// Enable hostPorts for OTLP/HTTP
 	// Default: true
 	// +optional
-	HostPortConfig *HostPortConfig `json:"hostPortConfig,omitempty"`
+	HostPortConfig *common.HostPortConfig `json:"hostPortConfig,omitempty"`
 
 	// Endpoint for OTLP/HTTP.
 	// Default: '0.0.0.0:4318'.
@@ -707,6 +571,56 @@ type EventCollectionFeatureConfig struct {
 	// +optional
 	// +listType=atomic
 	CollectedEventTypes []EventTypes `json:"collectedEventTypes,omitempty"`
+
+	// Filter restricts the collected events to the ones matching all its conditions.
+	// Default: all the events are collected
+	// +optional
+	Filter *EventFilter `json:"filter,omitempty"`
+
+	// MaxEventsPerRun is the maximum number of events sent by the Cluster Agent at each run of the check.
+	// Default: 300
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxEventsPerRun *int32 `json:"maxEventsPerRun,omitempty"`
+}
+
+// EventType is the type of a Kubernetes event.
+// +kubebuilder:validation:Enum=Normal;Warning
+type EventType string
+
+const (
+	// EventTypeNormal is the type of the informational events.
+	EventTypeNormal EventType = "Normal"
+	// EventTypeWarning is the type of the events reporting a problem.
+	EventTypeWarning EventType = "Warning"
+)
+
+// EventFilter selects Kubernetes events. The Cluster Agent applies it as field selectors when listing the events:
+// an event is collected only if it matches all the conditions, and conditions cannot express alternatives.
+// Field selectors cannot select events by the labels of their involved object, nor include several namespaces:
+// involved-object label selectors and multiple included namespaces are not supported.
+// +k8s:openapi-gen=true
+type EventFilter struct {
+	// Namespace restricts the collection to the events whose involved object is in this namespace.
+	// Only one namespace can be included, use `excludedNamespaces` to collect the events of all the other ones.
+	// Default: all the namespaces
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+
+	// ExcludedNamespaces ignores the events whose involved object is in one of these namespaces.
+	// +optional
+	// +listType=set
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+
+	// Type restricts the collection to the events of this type.
+	// Default: all the types
+	// +optional
+	Type *EventType `json:"type,omitempty"`
+
+	// Tags are added to the events collected through this filter, in the `key:value` format.
+	// +optional
+	// +listType=set
+	Tags []string `json:"tags,omitempty"`
 }
 
 // EventTypes defines the kind and reasons of events to collect.
@@ -732,7 +646,7 @@ type OrchestratorExplorerFeatureConfig struct {
 	// Conf overrides the configuration for the default Orchestrator Explorer check.
 	// This must point to a ConfigMap containing a valid cluster check configuration.
 	// +optional
-	Conf *CustomConfig `json:"conf,omitempty"`
+	Conf *common.CustomConfig `json:"conf,omitempty"`
 
 	// ScrubContainers enables scrubbing of sensitive container data (passwords, tokens, etc. ).
 	// Default: true
@@ -770,7 +684,7 @@ type KubeStateMetricsCoreFeatureConfig struct {
 	// Conf overrides the configuration for the default Kubernetes State Metrics Core check.
 	// This must point to a ConfigMap containing a valid cluster check configuration.
 	// +optional
-	Conf *CustomConfig `json:"conf,omitempty"`
+	Conf *common.CustomConfig `json:"conf,omitempty"`
 }
 
 // OtelCollectorFeatureConfig contains the configuration for the otel-agent.
@@ -785,7 +699,7 @@ type OtelCollectorFeatureConfig struct {
 	// This must point to a ConfigMap containing a valid cluster check configuration.
 	// When passing a configmap, file name *must* be otel-config.yaml.
 	// +optional
-	Conf *CustomConfig `json:"conf,omitempty"`
+	Conf *common.CustomConfig `json:"conf,omitempty"`
 
 	// Ports contains the ports for the otel-agent.
 	// Defaults: otel-grpc:4317 / otel-http:4318. Note: setting 4317
@@ -847,7 +761,7 @@ type AdmissionControllerFeatureConfig struct {
 	// AgentCommunicationMode corresponds to the mode used by the Datadog application libraries to communicate with the Agent.
 	// It can be "hostip", "service", or "socket".
 	// +optional
-	AgentCommunicationMode *string `json:"agentCommunicationMode,omitempty"`
+	AgentCommunicationMode *AgentCommunicationMode `json:"agentCommunicationMode,omitempty"`
 
 	// FailurePolicy determines how unrecognized and timeout errors are handled.
 	// +optional
@@ -961,6 +875,19 @@ type KubernetesAdmissionEventsConfig struct {
 	Enabled *bool `json:"enabled,omitempty"`
 }
 
+// AgentCommunicationMode is the mode used by the Datadog application libraries to communicate with the Agent.
+// +kubebuilder:validation:Enum=hostip;service;socket
+type AgentCommunicationMode string
+
+const (
+	// AgentCommunicationModeHostIP uses the IP of the host.
+	AgentCommunicationModeHostIP AgentCommunicationMode = "hostip"
+	// AgentCommunicationModeService uses the local Agent service.
+	AgentCommunicationModeService AgentCommunicationMode = "service"
+	// AgentCommunicationModeSocket uses the Agent unix domain sockets.
+	AgentCommunicationModeSocket AgentCommunicationMode = "socket"
+)
+
 // CWSInstrumentationConfig contains the configuration of the CWS Instrumentation admission controller endpoint.
 type CWSInstrumentationConfig struct {
 	// Enable the CWS Instrumentation admission controller endpoint.
@@ -971,9 +898,20 @@ type CWSInstrumentationConfig struct {
 	// Mode defines the behavior of the CWS Instrumentation endpoint, and can be either "init_container" or "remote_copy".
 	// Default: "remote_copy"
 	// +optional
-	Mode *string `json:"mode,omitempty"`
+	Mode *CWSInstrumentationMode `json:"mode,omitempty"`
 }
 
+// CWSInstrumentationMode is the behavior of the CWS Instrumentation admission controller endpoint.
+// +kubebuilder:validation:Enum=init_container;remote_copy
+type CWSInstrumentationMode string
+
+const (
+	// CWSInstrumentationModeInitContainer copies the CWS instrumentation binary with an init container.
+	CWSInstrumentationModeInitContainer CWSInstrumentationMode = "init_container"
+	// CWSInstrumentationModeRemoteCopy copies the CWS instrumentation binary with a remote copy.
+	CWSInstrumentationModeRemoteCopy CWSInstrumentationMode = "remote_copy"
+)
+
 // ExternalMetricsServerFeatureConfig contains the External Metrics Server feature configuration.
 // The External Metrics Server runs in the Cluster Agent.
 type ExternalMetricsServerFeatureConfig struct {
@@ -1061,6 +999,144 @@ type PrometheusScrapeFeatureConfig struct {
 	// Default: 2
 	// +optional
 	Version *int `json:"version,omitempty"`
+
+	// Configs are typed OpenMetrics check configurations with custom discovery rules.
+	// They are added to the ones defined in `additionalConfigs`.
+	// +optional
+	// +listType=atomic
+	Configs []PrometheusScrapeConfig `json:"configs,omitempty"`
+
+	// PrometheusOperator configures the translation of Prometheus Operator `ServiceMonitor` objects into checks.
+	// +optional
+	PrometheusOperator *PrometheusOperatorConfig `json:"prometheusOperator,omitempty"`
+}
+
+// PrometheusScrapeConfig is an OpenMetrics check configuration with its discovery rules.
+// +k8s:openapi-gen=true
+type PrometheusScrapeConfig struct {
+	// Autodiscovery defines the containers or service endpoints the configuration applies to.
+	// When not set, the configuration applies to all the containers with the `prometheus.io/scrape: "true"` annotation.
+	// +optional
+	Autodiscovery *PrometheusScrapeAutodiscovery `json:"autodiscovery,omitempty"`
+
+	// Endpoint defines where the metrics are exposed. It is required when `autodiscovery.kubernetesEndpoints` is set.
+	// When not set, the `prometheus.io/port` and `prometheus.io/path` annotations are used.
+	// +optional
+	Endpoint *PrometheusScrapeEndpoint `json:"endpoint,omitempty"`
+
+	// Metrics is the list of regular expressions of the metrics to collect.
+	// Default: all the metrics
+	// +optional
+	// +listType=atomic
+	Metrics []string `json:"metrics,omitempty"`
+
+	// ExcludeMetrics is the list of regular expressions of the metrics to ignore.
+	// +optional
+	// +listType=atomic
+	ExcludeMetrics []string `json:"excludeMetrics,omitempty"`
+
+	// Namespace is the prefix added to all the metrics.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+
+	// RawMetricPrefix is removed from the exposed metric names.
+	// +optional
+	RawMetricPrefix *string `json:"rawMetricPrefix,omitempty"`
+
+	// RenameLabels renames the labels of the exposed metrics before they are converted to tags.
+	// +optional
+	RenameLabels map[string]string `json:"renameLabels,omitempty"`
+
+	// Tags are added to all the metrics.
+	// +optional
+	// +listType=atomic
+	Tags []string `json:"tags,omitempty"`
+
+	// BearerTokenSecret is a Secret key containing the bearer token used to authenticate to the endpoint.
+	// The Secret must be in the namespace of the DatadogAgent.
+	// +optional
+	BearerTokenSecret *corev1.SecretKeySelector `json:"bearerTokenSecret,omitempty"`
+
+	// Timeout is the timeout of the requests to the endpoint, in seconds.
+	// +optional
+	Timeout *int32 `json:"timeout,omitempty"`
+}
+
+// PrometheusScrapeAutodiscovery defines the discovery rules of an OpenMetrics check configuration.
+// +k8s:openapi-gen=true
+type PrometheusScrapeAutodiscovery struct {
+	// KubernetesAnnotations selects the pods and services by annotations.
+	// +optional
+	KubernetesAnnotations *PrometheusScrapeAnnotations `json:"kubernetesAnnotations,omitempty"`
+
+	// KubernetesContainerNames restricts the configuration to the containers with these names.
+	// +optional
+	// +listType=set
+	KubernetesContainerNames []string `json:"kubernetesContainerNames,omitempty"`
+
+	// KubernetesEndpoints runs the configuration as endpoints checks on the pods backing these services.
+	// It requires the cluster checks feature.
+	// It cannot be combined with `kubernetesAnnotations` and `kubernetesContainerNames`.
+	// +optional
+	// +listType=atomic
+	KubernetesEndpoints []PrometheusScrapeService `json:"kubernetesEndpoints,omitempty"`
+}
+
+// PrometheusScrapeAnnotations selects pods and services by annotations.
+// +k8s:openapi-gen=true
+type PrometheusScrapeAnnotations struct {
+	// Include selects the pods and services having all these annotations.
+	// Default: `prometheus.io/scrape: "true"`
+	// +optional
+	Include map[string]string `json:"include,omitempty"`
+
+	// Exclude ignores the pods and services having any of these annotations.
+	// +optional
+	Exclude map[string]string `json:"exclude,omitempty"`
+}
+
+// PrometheusScrapeService references a Kubernetes service.
+// +k8s:openapi-gen=true
+type PrometheusScrapeService struct {
+	// Name is the name of the service.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the service.
+	Namespace string `json:"namespace"`
+}
+
+// PrometheusScrapeEndpoint defines where the metrics are exposed.
+// +k8s:openapi-gen=true
+type PrometheusScrapeEndpoint struct {
+	// Port is the name or the number of the port exposing the metrics.
+	Port intstr.IntOrString `json:"port"`
+
+	// Path is the HTTP path exposing the metrics.
+	// Default: `/metrics`
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// Scheme is the HTTP scheme used to scrape the metrics, `http` or `https`.
+	// Default: `http`
+	// +optional
+	Scheme *string `json:"scheme,omitempty"`
+}
+
+// PrometheusOperatorConfig configures the translation of Prometheus Operator ServiceMonitors into checks.
+// +k8s:openapi-gen=true
+type PrometheusOperatorConfig struct {
+	// Enabled enables the translation of the `ServiceMonitor` objects of the cluster into endpoints checks.
+	// Bearer token Secrets of other namespaces are copied in the namespace of the DatadogAgent.
+	// `PodMonitor` objects select pods by labels, which the Agent autodiscovery cannot express:
+	// they are not translated and are reported in the operator logs.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ServiceMonitorSelector restricts the translation to the `ServiceMonitor` objects matching this selector.
+	// Default: all the `ServiceMonitor` objects
+	// +optional
+	ServiceMonitorSelector *metav1.LabelSelector `json:"serviceMonitorSelector,omitempty"`
 }
 
 // HelmCheckFeatureConfig allows configuration of the Helm check feature.
@@ -1082,58 +1158,80 @@ type HelmCheckFeatureConfig struct {
 	// Default: {}
 	// +optional
 	ValuesAsTags map[string]string `json:"valuesAsTags,omitempty"`
-}
-
-// Generic support structs
 
-// SecretConfig contains a secret name and an included key.
-// +kubebuilder:object:generate=true
-type SecretConfig struct {
-	// SecretName is the name of the secret.
-	SecretName string `json:"secretName"`
+	// ChartAnnotationsAsTags collects annotations from the chart of a release and uses them as tags.
+	// The key is the chart annotation and the value is the tag name.
+	// It is rendered as the `helm_chart_annotations_as_tags` option of the check, which the Agent Helm check
+	// does not implement as of Agent 7.63: these Agent versions ignore it.
+	// Default: {}
+	// +optional
+	ChartAnnotationsAsTags map[string]string `json:"chartAnnotationsAsTags,omitempty"`
 
-	// KeyName is the key of the secret to use.
+	// Namespaces filters the monitored releases by namespace.
+	// It is rendered as the `namespaces` and `excluded_namespaces` options of the check, which the Agent Helm
+	// check does not implement as of Agent 7.63: these Agent versions ignore them.
+	// When namespaces are included, the Helm check is only granted access to these namespaces, through Roles
+	// instead of a ClusterRole. The Helm check of Agent 7.63 and below watches the releases of all the
+	// namespaces and cannot list them with these narrowed permissions.
+	// Default: all the namespaces
 	// +optional
-	KeyName string `json:"keyName,omitempty"`
-}
+	Namespaces *HelmCheckFilter `json:"namespaces,omitempty"`
 
-// ConfigMapConfig contains ConfigMap information used to store a configuration file.
-// +kubebuilder:object:generate=true
-type ConfigMapConfig struct {
-	// Name is the name of the ConfigMap.
-	Name string `json:"name,omitempty"`
+	// Releases filters the monitored releases by name.
+	// It is rendered as the `releases` and `excluded_releases` options of the check, which the Agent Helm
+	// check does not implement as of Agent 7.63: these Agent versions ignore them.
+	// Default: all the releases
+	// +optional
+	Releases *HelmCheckFilter `json:"releases,omitempty"`
 
-	// Items maps a ConfigMap data `key` to a file `path` mount.
-	// +listType=map
-	// +listMapKey=key
+	// StorageDriver restricts the monitored releases to the ones stored by this Helm 3 storage driver,
+	// `secret` or `configmap`.
+	// It is rendered as the `storage_driver` option of the check, which the Agent Helm check does not
+	// implement as of Agent 7.63: these Agent versions ignore it.
+	// The Helm check is only granted access to the Secrets or ConfigMaps storing the releases. The Helm check
+	// of Agent 7.63 and below watches both and cannot list the other ones with these narrowed permissions.
+	// Default: both
 	// +optional
-	Items []corev1.KeyToPath `json:"items,omitempty"`
+	StorageDriver *HelmStorageDriver `json:"storageDriver,omitempty"`
 }
 
-// CustomConfig provides a place for custom configuration of the Agent or Cluster Agent, corresponding to datadog.yaml,
-// system-probe.yaml, security-agent.yaml or datadog-cluster.yaml.
-// The configuration can be provided in the ConfigData field as raw data, or referenced in a ConfigMap.
-// Note: `ConfigData` and `ConfigMap` cannot be set together.
+// HelmCheckFilter includes and excludes values from the Helm check.
 // +k8s:openapi-gen=true
-type CustomConfig struct {
-	// ConfigData corresponds to the configuration file content.
-	ConfigData *string `json:"configData,omitempty"`
+type HelmCheckFilter struct {
+	// Include is the list of values to monitor.
+	// Default: all the values
+	// +optional
+	// +listType=set
+	Include []string `json:"include,omitempty"`
 
-	// ConfigMap references an existing ConfigMap with the configuration file content.
-	ConfigMap *ConfigMapConfig `json:"configMap,omitempty"`
+	// Exclude is the list of values to ignore.
+	// +optional
+	// +listType=set
+	Exclude []string `json:"exclude,omitempty"`
 }
 
-// MultiCustomConfig provides a place for custom configuration of the Agent or Cluster Agent, corresponding to /confd/*.yaml.
-// The configuration can be provided in the ConfigDataMap field as raw data, or referenced in a single ConfigMap.
-// Note: `ConfigDataMap` and `ConfigMap` cannot be set together.
-// +k8s:openapi-gen=true
-type MultiCustomConfig struct {
-	// ConfigDataMap corresponds to the content of the configuration files.
-	// The key should be the filename the contents get mounted to; for instance check.py or check.yaml.
-	ConfigDataMap map[string]string `json:"configDataMap,omitempty"`
+// HelmStorageDriver is the storage driver used by Helm 3 to store the releases.
+// +kubebuilder:validation:Enum=secret;configmap
+type HelmStorageDriver string
+
+const (
+	// HelmStorageDriverSecret stores the releases in Secrets, the Helm 3 default.
+	HelmStorageDriverSecret HelmStorageDriver = "secret"
+	// HelmStorageDriverConfigMap stores the releases in ConfigMaps.
+	HelmStorageDriverConfigMap HelmStorageDriver = "configmap"
+)
 
-	// ConfigMap references an existing ConfigMap with the content of the configuration files.
-	ConfigMap *ConfigMapConfig `json:"configMap,omitempty"`
+// Generic support structs
+
+// SecretConfig contains a secret name and an included key.
+// +kubebuilder:object:generate=true
+type SecretConfig struct {
+	// SecretName is the name of the secret.
+	SecretName string `json:"secretName"`
+
+	// KeyName is the key of the secret to use.
+	// +optional
+	KeyName string `json:"keyName,omitempty"`
 }
 
 // KubeletConfig contains the kubelet configuration parameters.
@@ -1163,38 +1261,179 @@ type KubeletConfig struct {
 	PodResourcesSocketPath string `json:"podResourcesSocketPath,omitempty"`
 }
 
-// HostPortConfig contains host port configuration.
-type HostPortConfig struct {
-	// Enabled enables host port configuration
+// Endpoint configures an endpoint and its associated Datadog credentials.
+type Endpoint struct {
+	// URL defines the endpoint URL.
+	URL *string `json:"url,omitempty"`
+
+	// Credentials defines the Datadog credentials used to submit data to/query data from Datadog.
+	Credentials *DatadogCredentials `json:"credentials,omitempty"`
+}
+
+// AdditionalEndpointsConfig defines the additional intake endpoints of each product.
+// +k8s:openapi-gen=true
+type AdditionalEndpointsConfig struct {
+	// Metrics is the list of additional endpoints for metrics, for example `https://app.datadoghq.eu`.
+	// +optional
+	// +listType=atomic
+	Metrics []AdditionalEndpoint `json:"metrics,omitempty"`
+
+	// Logs is the list of additional endpoints for logs, for example `agent-http-intake.logs.datadoghq.eu:443`.
+	// +optional
+	// +listType=atomic
+	Logs []AdditionalEndpoint `json:"logs,omitempty"`
+
+	// APM is the list of additional endpoints for traces, for example `https://trace.agent.datadoghq.eu`.
+	// +optional
+	// +listType=atomic
+	APM []AdditionalEndpoint `json:"apm,omitempty"`
+
+	// Process is the list of additional endpoints for processes and containers, for example `https://process.datadoghq.eu`.
+	// +optional
+	// +listType=atomic
+	Process []AdditionalEndpoint `json:"process,omitempty"`
+
+	// Orchestrator is the list of additional endpoints for the Orchestrator Explorer, for example `https://orchestrator.datadoghq.eu`.
+	// +optional
+	// +listType=atomic
+	Orchestrator []AdditionalEndpoint `json:"orchestrator,omitempty"`
+}
+
+// AdditionalEndpoint defines an additional intake endpoint and the API key used to send data to it.
+// +k8s:openapi-gen=true
+type AdditionalEndpoint struct {
+	// URL is the intake URL.
+	URL string `json:"url"`
+
+	// APIKeySecret is the secret containing the API key of the Datadog organization.
+	APIKeySecret SecretConfig `json:"apiKeySecret"`
+}
+
+// ProxyConfig defines the HTTP(S) proxy configuration.
+// +k8s:openapi-gen=true
+type ProxyConfig struct {
+	// HTTP is the proxy URL used for HTTP requests, for example `http://proxy.example.com:3128`.
+	// +optional
+	HTTP *string `json:"http,omitempty"`
+
+	// HTTPS is the proxy URL used for HTTPS requests, for example `http://proxy.example.com:3128`.
+	// +optional
+	HTTPS *string `json:"https,omitempty"`
+
+	// NoProxy is the list of hosts that should bypass the proxy.
+	// +optional
+	// +listType=set
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// UsernameSecret is the secret containing the username used to authenticate against the proxy.
+	// The username is inserted as is in the proxy URLs, it must be URL-encoded (for example `j%40doe` for `j@doe`).
+	// A ProxyCredentialsNotEncoded warning event is recorded on the DatadogAgent when it is not.
+	// +optional
+	UsernameSecret *SecretConfig `json:"usernameSecret,omitempty"`
+
+	// PasswordSecret is the secret containing the password used to authenticate against the proxy.
+	// The password is inserted as is in the proxy URLs, it must be URL-encoded (for example `p%40ss` for `p@ss`).
+	// A ProxyCredentialsNotEncoded warning event is recorded on the DatadogAgent when it is not.
+	// +optional
+	PasswordSecret *SecretConfig `json:"passwordSecret,omitempty"`
+
+	// Logs overrides the intake URL the logs are sent to (`DD_LOGS_CONFIG_LOGS_DD_URL`),
+	// for example a proxy relaying them to Datadog.
+	// +optional
+	Logs *ProductIntakeConfig `json:"logs,omitempty"`
+
+	// APM overrides the intake URL the traces are sent to (`DD_APM_DD_URL`),
+	// for example a proxy relaying them to Datadog.
+	// +optional
+	APM *ProductIntakeConfig `json:"apm,omitempty"`
+
+	// Process overrides the intake URL the processes and containers are sent to (`DD_PROCESS_CONFIG_PROCESS_DD_URL`),
+	// for example a proxy relaying them to Datadog.
+	// +optional
+	Process *ProductIntakeConfig `json:"process,omitempty"`
+}
+
+// ProductIntakeConfig defines the intake of a single product.
+// +k8s:openapi-gen=true
+type ProductIntakeConfig struct {
+	// URL replaces the Datadog intake URL of the product, the data are sent to it directly instead of
+	// through the HTTP(S) proxy. For example `proxy.example.com:3129`.
+	// +optional
+	URL *string `json:"url,omitempty"`
+}
+
+// ClusterAgentTokenRotationConfig defines the rotation of the Cluster Agent token.
+// +k8s:openapi-gen=true
+type ClusterAgentTokenRotationConfig struct {
+	// Enabled enables the periodic rotation of the generated Cluster Agent token.
+	// The Cluster Agent is first restarted to accept the new token along with the current one, then the node Agents
+	// and the Cluster Checks Runners are restarted to use it once the Cluster Agent API serves a request authenticated
+	// with it, and finally the Cluster Agent stops accepting the former token.
+	// It requires the Cluster Agent 7.63.0 or later, no rotation is started with older images.
+	// Default: false
 	// +optional
 	Enabled *bool `json:"enabled,omitempty"`
 
-	// Port takes a port number (0 < x < 65536) to expose on the host. (Most containers do not need this.)
-	// If HostNetwork is enabled, this value must match the ContainerPort.
+	// Interval is the time between two rotations of the token.
+	// Default: 720h (30 days)
 	// +optional
-	Port *int32 `json:"hostPort,omitempty"`
+	Interval *metav1.Duration `json:"interval,omitempty"`
 }
 
-// UnixDomainSocketConfig contains the Unix Domain Socket configuration.
+// ClusterAgentTLSConfig defines the certificates of the Cluster Agent.
 // +k8s:openapi-gen=true
-type UnixDomainSocketConfig struct {
-	// Enabled enables Unix Domain Socket.
-	// Default: true
+type ClusterAgentTLSConfig struct {
+	// Enabled enables mutual TLS between the node Agents, the Cluster Checks Runners and the Cluster Agent.
+	// The operator manages a certificate authority, issues the serving certificate of the Cluster Agent and the client
+	// certificate of the Agents, and renews them before they expire. The authority also signs the certificate
+	// of the external metrics server, it is set as the CA bundle of its APIService.
+	// It requires the Agent and Cluster Agent 7.63.0 or later, it is ignored with older images.
+	// The node Agents and the Cluster Checks Runners only connect to the Cluster Agent URL over TLS once the operator
+	// verified that the Cluster Agent serves the issued certificate, they keep their default connection until then.
+	// Default: false
 	// +optional
 	Enabled *bool `json:"enabled,omitempty"`
 
-	// Path defines the socket path used when enabled.
+	// CertificateValidity is the validity of the certificates issued by the operator.
+	// They are renewed once two thirds of their validity elapsed.
+	// Default: 2160h (90 days)
 	// +optional
-	Path *string `json:"path,omitempty"`
+	CertificateValidity *metav1.Duration `json:"certificateValidity,omitempty"`
+
+	// CertManager issues the certificates with cert-manager `Certificate` resources instead of the operator.
+	// It is ignored when the cert-manager CRDs are not installed in the cluster.
+	// +optional
+	CertManager *CertManagerConfig `json:"certManager,omitempty"`
 }
 
-// Endpoint configures an endpoint and its associated Datadog credentials.
-type Endpoint struct {
-	// URL defines the endpoint URL.
-	URL *string `json:"url,omitempty"`
+// CertManagerConfig defines how the certificates are issued with cert-manager.
+// +k8s:openapi-gen=true
+type CertManagerConfig struct {
+	// Enabled enables the issuance of the certificates with cert-manager.
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
 
-	// Credentials defines the Datadog credentials used to submit data to/query data from Datadog.
-	Credentials *DatadogCredentials `json:"credentials,omitempty"`
+	// IssuerRef is the cert-manager issuer of the certificates.
+	// When not set, the operator creates a self-signed certificate authority issuer.
+	// +optional
+	IssuerRef *CertManagerIssuerReference `json:"issuerRef,omitempty"`
+}
+
+// CertManagerIssuerReference references a cert-manager issuer.
+// +k8s:openapi-gen=true
+type CertManagerIssuerReference struct {
+	// Name is the name of the issuer.
+	Name string `json:"name"`
+
+	// Kind is the kind of the issuer: `Issuer` (default) or `ClusterIssuer`.
+	// +optional
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	Kind string `json:"kind,omitempty"`
+
+	// Group is the API group of the issuer. Default: cert-manager.io
+	// +optional
+	Group string `json:"group,omitempty"`
 }
 
 // OriginDetectionUnified defines the origin detection unified mechanism behavior.
@@ -1329,6 +1568,16 @@ type GlobalConfig struct {
 	// ClusterAgentTokenSecret is the secret containing the Cluster Agent token.
 	ClusterAgentTokenSecret *SecretConfig `json:"clusterAgentTokenSecret,omitempty"`
 
+	// ClusterAgentTokenRotation configures the periodic rotation of the Cluster Agent token generated by the operator.
+	// It is ignored when the token is set with ClusterAgentToken or ClusterAgentTokenSecret.
+	// +optional
+	ClusterAgentTokenRotation *ClusterAgentTokenRotationConfig `json:"clusterAgentTokenRotation,omitempty"`
+
+	// ClusterAgentTLS configures the certificates securing the Cluster Agent API, used by the node Agents
+	// and the Cluster Checks Runners, and the external metrics server.
+	// +optional
+	ClusterAgentTLS *ClusterAgentTLSConfig `json:"clusterAgentTLS,omitempty"`
+
 	// ClusterName sets a unique cluster name for the deployment to easily scope monitoring data in the Datadog app.
 	// +optional
 	ClusterName *string `json:"clusterName,omitempty"`
@@ -1350,6 +1599,16 @@ type GlobalConfig struct {
 	// +optional
 	Endpoint *Endpoint `json:"endpoint,omitempty"`
 
+	// AdditionalEndpoints configures the intake endpoints the Agent data are sent to, on top of `Endpoint` or `Site`.
+	// It is used to send the same data to several Datadog organizations.
+	// +optional
+	AdditionalEndpoints *AdditionalEndpointsConfig `json:"additionalEndpoints,omitempty"`
+
+	// Proxy configures the HTTP(S) proxy used by all the Agent components to reach Datadog.
+	// The Operator also uses it to send its own metrics and to query the Datadog API.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+
 	// Registry is the image registry to use for all Agent images.
 	// Use 'public.ecr.aws/datadog' for AWS ECR.
 	// Use 'datadoghq.azurecr.io' for Azure Container Registry.
@@ -1549,6 +1808,12 @@ const (
 
 	// NetworkPolicyFlavorCilium refers to `cilium.io/v2/CiliumNetworkPolicy`
 	NetworkPolicyFlavorCilium NetworkPolicyFlavor = "cilium"
+
+	// NetworkPolicyFlavorCalico refers to `projectcalico.org/v3/GlobalNetworkPolicy`
+	NetworkPolicyFlavorCalico NetworkPolicyFlavor = "calico"
+
+	// NetworkPolicyFlavorAdminNetworkPolicy refers to `policy.networking.k8s.io/v1alpha1/AdminNetworkPolicy`
+	NetworkPolicyFlavorAdminNetworkPolicy NetworkPolicyFlavor = "adminnetworkpolicy"
 )
 
 // NetworkPolicyConfig provides Network Policy configuration for the agents.
@@ -1558,7 +1823,10 @@ type NetworkPolicyConfig struct {
 	// +optional
 	Create *bool `json:"create,omitempty"`
 
-	// Flavor defines Which network policy to use.
+	// Flavor defines Which network policy to use: `kubernetes` (default), `cilium`, `calico` or `adminnetworkpolicy`.
+	// The `calico` flavor creates Calico GlobalNetworkPolicies through the `projectcalico.org/v3` API.
+	// The `adminnetworkpolicy` flavor restricts the egress traffic with AdminNetworkPolicies, and the ingress
+	// traffic with Kubernetes NetworkPolicies.
 	// +optional
 	Flavor NetworkPolicyFlavor `json:"flavor,omitempty"`
 
@@ -1568,6 +1836,67 @@ type NetworkPolicyConfig struct {
 	DNSSelectorEndpoints []metav1.LabelSelector `json:"dnsSelectorEndpoints,omitempty"`
 }
 
+// NetworkPolicyRules defines the rules added to the network policy generated for a component.
+// +k8s:openapi-gen=true
+type NetworkPolicyRules struct {
+	// AdditionalEgress lists the egress rules added to the network policy.
+	// +optional
+	// +listType=atomic
+	AdditionalEgress []NetworkPolicyRule `json:"additionalEgress,omitempty"`
+
+	// AdditionalIngress lists the ingress rules added to the network policy.
+	// +optional
+	// +listType=atomic
+	AdditionalIngress []NetworkPolicyRule `json:"additionalIngress,omitempty"`
+}
+
+// NetworkPolicyRule defines a network policy rule, translated to the configured network policy flavor.
+// The traffic is allowed to (egress) or from (ingress) any of the peers of the rule, on the ports of the rule.
+// When no peer is defined, the traffic is allowed to or from anywhere.
+// +k8s:openapi-gen=true
+type NetworkPolicyRule struct {
+	// Ports lists the allowed ports. When empty, all the ports are allowed.
+	// +optional
+	// +listType=atomic
+	Ports []NetworkPolicyRulePort `json:"ports,omitempty"`
+
+	// IPBlocks lists the allowed IP blocks, in CIDR notation.
+	// +optional
+	// +listType=set
+	IPBlocks []string `json:"ipBlocks,omitempty"`
+
+	// DomainNames lists the allowed fully qualified domain names. They are only supported by egress rules, and are not
+	// enforced by the kubernetes flavor: Kubernetes network policies can't select domain names, so the rule is restricted
+	// to its IP blocks and selectors, and skipped when it has none.
+	// +optional
+	// +listType=set
+	DomainNames []string `json:"domainNames,omitempty"`
+
+	// PodSelector selects the allowed pods. When NamespaceSelector is not set, the pods are selected
+	// in the namespace of the DatadogAgent.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// NamespaceSelector selects the namespaces of the allowed pods.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// NetworkPolicyRulePort defines a port allowed by a network policy rule.
+// +k8s:openapi-gen=true
+type NetworkPolicyRulePort struct {
+	// Port is the port number.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Protocol is the port protocol.
+	// Default: TCP
+	// +optional
+	// +kubebuilder:validation:Enum=TCP;UDP
+	Protocol *corev1.Protocol `json:"protocol,omitempty"`
+}
+
 // LocalService provides the internal traffic policy service configuration.
 // +k8s:openapi-gen=true
 type LocalService struct {
@@ -1594,23 +1923,9 @@ type SeccompConfig struct {
 	// ConfigMap data must either have the key `system-probe-seccomp.json` or CustomProfile.Items
 	// must include a corev1.KeytoPath that maps the key to the path `system-probe-seccomp.json`.
 	// +optional
-	CustomProfile *CustomConfig `json:"customProfile,omitempty"`
+	CustomProfile *common.CustomConfig `json:"customProfile,omitempty"`
 }
 
-// AgentConfigFileName is the list of known Agent config files
-type AgentConfigFileName string
-
-const (
-	// AgentGeneralConfigFile is the name of the main Agent config file
-	AgentGeneralConfigFile AgentConfigFileName = "datadog.yaml"
-	// SystemProbeConfigFile is the name of the of System Probe config file
-	SystemProbeConfigFile AgentConfigFileName = "system-probe.yaml"
-	// SecurityAgentConfigFile is the name of the Security Agent config file
-	SecurityAgentConfigFile AgentConfigFileName = "security-agent.yaml"
-	// ClusterAgentConfigFile is the name of the Cluster Agent config file
-	ClusterAgentConfigFile AgentConfigFileName = "datadog-cluster.yaml"
-)
-
 // DatadogAgentComponentOverride is the generic description equivalent to a subset of the PodTemplate for a component.
 type DatadogAgentComponentOverride struct {
 	// Name overrides the default name for the resource
@@ -1662,17 +1977,17 @@ type DatadogAgentComponentOverride struct {
 	// The content is merged with configuration generated by the Datadog Operator, with priority given to custom configuration.
 	// WARNING: It is possible to override values set in the `DatadogAgent`.
 	// +optional
-	CustomConfigurations map[AgentConfigFileName]CustomConfig `json:"customConfigurations,omitempty"`
+	CustomConfigurations map[common.AgentConfigFileName]common.CustomConfig `json:"customConfigurations,omitempty"`
 
 	// Confd configuration allowing to specify config files for custom checks placed under /etc/datadog-agent/conf.d/.
 	// See https://docs.datadoghq.com/agent/guide/agent-configuration-files/?tab=agentv6 for more details.
 	// +optional
-	ExtraConfd *MultiCustomConfig `json:"extraConfd,omitempty"`
+	ExtraConfd *common.MultiCustomConfig `json:"extraConfd,omitempty"`
 
 	// Checksd configuration allowing to specify custom checks placed under /etc/datadog-agent/checks.d/
 	// See https://docs.datadoghq.com/agent/guide/agent-configuration-files/?tab=agentv6 for more details.
 	// +optional
-	ExtraChecksd *MultiCustomConfig `json:"extraChecksd,omitempty"`
+	ExtraChecksd *common.MultiCustomConfig `json:"extraChecksd,omitempty"`
 
 	// Configure the basic configurations for each Agent container. Valid Agent container names are:
 	// `agent`, `cluster-agent`, `init-config`, `init-volume`, `process-agent`, `seccomp-setup`,
@@ -1741,6 +2056,12 @@ type DatadogAgentComponentOverride struct {
 	// AdditionalLabels provide labels that are added to the different component (Datadog Agent, Cluster Agent, Cluster Check Runner) pods.
 	Labels map[string]string `json:"labels,omitempty"`
 
+	// NetworkPolicy defines the rules added to the network policy of the component,
+	// when `global.networkPolicy.create` is true. Egress to the `global.proxy` proxies, to `global.additionalEndpoints`, to the
+	// `DD_PROXY_HTTP` and `DD_PROXY_HTTPS` proxies and to `global.endpoint.url` is allowed automatically.
+	// +optional
+	NetworkPolicy *NetworkPolicyRules `json:"networkPolicy,omitempty"`
+
 	// Host networking requested for this pod. Use the host's network namespace.
 	// +optional
 	HostNetwork *bool `json:"hostNetwork,omitempty"`
@@ -1870,7 +2191,7 @@ type FIPSConfig struct {
 	// (/etc/datadog-fips-proxy/datadog-fips-proxy.cfg). If empty, the default FIPS
 	// proxy sidecar container config is used.
 	// +optional
-	CustomFIPSConfig *CustomConfig `json:"customFIPSConfig,omitempty"`
+	CustomFIPSConfig *common.CustomConfig `json:"customFIPSConfig,omitempty"`
 }
 
 // RemoteConfigConfiguration stores the configuration received from RemoteConfig.
@@ -1879,6 +2200,90 @@ type RemoteConfigConfiguration struct {
 	Features *DatadogFeatures `json:"features,omitempty"`
 }
 
+// RemoteConfigProposal stores a configuration received from RemoteConfig that is waiting for approval.
+// +k8s:openapi-gen=true
+type RemoteConfigProposal struct {
+	// ConfigID is the ID of the received configuration.
+	// +optional
+	ConfigID string `json:"configID,omitempty"`
+	// ReceivedAt is the time the configuration was received.
+	ReceivedAt metav1.Time `json:"receivedAt"`
+	// Changes lists the feature fields the received configuration sets differently than the applied configuration.
+	// They are applied on top of the applied configuration once the proposal is approved.
+	// +optional
+	// +listType=map
+	// +listMapKey=path
+	Changes []RemoteConfigChange `json:"changes,omitempty"`
+}
+
+// RemoteConfigChange is a feature field set by a configuration received from RemoteConfig.
+// +k8s:openapi-gen=true
+type RemoteConfigChange struct {
+	// Path is the path of the field in the DatadogAgent features, for example `sbom.containerImage.enabled`.
+	Path string `json:"path"`
+	// Value is the JSON encoded value of the field, `null` when the configuration unsets it.
+	Value string `json:"value"`
+}
+
+// RemoteConfigHistoryEntry records a configuration received from RemoteConfig that was applied.
+// +k8s:openapi-gen=true
+type RemoteConfigHistoryEntry struct {
+	// ConfigID is the ID of the applied configuration.
+	// +optional
+	ConfigID string `json:"configID,omitempty"`
+	// AppliedAt is the time the configuration was applied.
+	AppliedAt metav1.Time `json:"appliedAt"`
+	// ApprovalMode is the approval mode the configuration was applied with.
+	// +optional
+	ApprovalMode RemoteConfigApprovalMode `json:"approvalMode,omitempty"`
+	// Features lists the features changed by the configuration.
+	// +optional
+	// +listType=set
+	Features []RemoteConfigFeature `json:"features,omitempty"`
+}
+
+// RolloutPolicy defines the thresholds used to consider a rollout of the agents complete.
+// +k8s:openapi-gen=true
+type RolloutPolicy struct {
+	// AgentCompletionPercent is the percentage of up-to-date pods above which the rollout of an Agent DaemonSet is complete.
+	// Default: 95
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	AgentCompletionPercent *int32 `json:"agentCompletionPercent,omitempty"`
+
+	// AgentMaxPodsNotUpToDate is the number of pods not up to date below which the rollout of an Agent DaemonSet is complete,
+	// regardless of AgentCompletionPercent.
+	// Default: 10
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AgentMaxPodsNotUpToDate *int32 `json:"agentMaxPodsNotUpToDate,omitempty"`
+
+	// ClusterAgentMinUpToDate is the number of up-to-date Cluster Agent pods above which its rollout is complete.
+	// Default: 1
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ClusterAgentMinUpToDate *int32 `json:"clusterAgentMinUpToDate,omitempty"`
+
+	// ClusterChecksRunnerMinUpToDate is the number of up-to-date Cluster Checks Runner pods above which its rollout is complete.
+	// Default: 2
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ClusterChecksRunnerMinUpToDate *int32 `json:"clusterChecksRunnerMinUpToDate,omitempty"`
+
+	// MaxContainerRestarts is the maximum number of restarts of a container of the agent pods during the rollout.
+	// The rollout fails when a container restarts more often. The restarts that happened before the check started
+	// are not counted. Restarts are not checked when unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxContainerRestarts *int32 `json:"maxContainerRestarts,omitempty"`
+
+	// Timeout is the duration after which an incomplete rollout fails.
+	// Default: 2h
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
 // DatadogAgentStatus defines the observed state of DatadogAgent.
 // +k8s:openapi-gen=true
 type DatadogAgentStatus struct {
@@ -1903,6 +2308,75 @@ type DatadogAgentStatus struct {
 	// RemoteConfigConfiguration stores the configuration received from RemoteConfig.
 	// +optional
 	RemoteConfigConfiguration *RemoteConfigConfiguration `json:"remoteConfigConfiguration,omitempty"`
+	// RemoteConfigProposal stores the configuration received from RemoteConfig that is waiting for approval,
+	// when the Remote Configuration approval mode is Manual.
+	// +optional
+	RemoteConfigProposal *RemoteConfigProposal `json:"remoteConfigProposal,omitempty"`
+	// RemoteConfigHistory lists the last configurations received from RemoteConfig that were applied, oldest first.
+	// It is bounded by `features.remoteConfiguration.historyLimit`.
+	// +optional
+	// +listType=atomic
+	RemoteConfigHistory []RemoteConfigHistoryEntry `json:"remoteConfigHistory,omitempty"`
+	// InstrumentationTargets lists the Single Step Instrumentation targets generated from DatadogInstrumentation
+	// objects that were accepted and merged into the Cluster Agent configuration.
+	// +optional
+	// +listType=set
+	InstrumentationTargets []string `json:"instrumentationTargets,omitempty"`
+	// ClusterAgentTLS is the state of the certificates of the Cluster Agent, when its TLS is enabled.
+	// +optional
+	ClusterAgentTLS *ClusterAgentTLSStatus `json:"clusterAgentTLS,omitempty"`
+	// ClusterAgentToken is the state of the Cluster Agent token generated by the operator.
+	// +optional
+	ClusterAgentToken *ClusterAgentTokenStatus `json:"clusterAgentToken,omitempty"`
+}
+
+// ClusterAgentTokenRotationPhase is the step of a rotation of the Cluster Agent token.
+type ClusterAgentTokenRotationPhase string
+
+const (
+	// ClusterAgentTokenRotationPhaseClusterAgentRollout is the step restarting the Cluster Agent to accept the new token
+	// along with the current one.
+	ClusterAgentTokenRotationPhaseClusterAgentRollout ClusterAgentTokenRotationPhase = "ClusterAgentRollout"
+	// ClusterAgentTokenRotationPhaseAgentRollout is the step restarting the node Agents and the Cluster Checks Runners
+	// to use the new token. The Cluster Agent still accepts the former token.
+	ClusterAgentTokenRotationPhaseAgentRollout ClusterAgentTokenRotationPhase = "AgentRollout"
+)
+
+// ClusterAgentTokenStatus defines the state of the Cluster Agent token generated by the operator.
+// +k8s:openapi-gen=true
+type ClusterAgentTokenStatus struct {
+	// Generation is incremented each time the Agents switch to a new token.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+	// LastRotationTime is the last time the Agents switched to a new token.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+	// Phase is the step of the rotation in progress, empty when no rotation is in progress.
+	// +optional
+	Phase ClusterAgentTokenRotationPhase `json:"phase,omitempty"`
+	// AdditionalTokenHash is the SHA-256 hash of the token accepted by the Cluster Agent along with the current one
+	// during a rotation: the new token before the Agents use it, then the former one while the Agents are restarted.
+	// The token itself is only stored in the token secret.
+	// +optional
+	AdditionalTokenHash string `json:"additionalTokenHash,omitempty"`
+}
+
+// ClusterAgentTLSStatus defines the state of the certificates of the Cluster Agent.
+// +k8s:openapi-gen=true
+type ClusterAgentTLSStatus struct {
+	// Generation is incremented each time new certificates are issued, the pods using them are then restarted.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+	// NotAfter is the expiration time of the current certificates.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+	// LastRotationTime is the last time new certificates were issued.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+	// ClusterAgentVerified is true once the Cluster Agent served a certificate issued by the certificate authorities.
+	// The Agents connect to the Cluster Agent over TLS only then.
+	// +optional
+	ClusterAgentVerified bool `json:"clusterAgentVerified,omitempty"`
 }
 
 // DatadogAgent Deployment with the Datadog Operator.
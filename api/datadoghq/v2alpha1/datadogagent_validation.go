@@ -0,0 +1,342 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v2alpha1
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/common"
+)
+
+// IsValidDatadogAgent is used to check if a DatadogAgentSpec is valid before
+// rolling out its configuration to the Agents.
+func IsValidDatadogAgent(spec *DatadogAgentSpec) error {
+	var errs []error
+
+	if spec.Features != nil && spec.Features.LogCollection != nil {
+		errs = append(errs, isValidLogCollection(spec.Features.LogCollection)...)
+	}
+
+	if spec.Features != nil && spec.Features.PrometheusScrape != nil {
+		errs = append(errs, isValidPrometheusScrape(spec.Features.PrometheusScrape)...)
+	}
+
+	if spec.Features != nil && spec.Features.EventCollection != nil {
+		errs = append(errs, isValidEventCollection(spec.Features.EventCollection)...)
+	}
+
+	if spec.Features != nil && spec.Features.HelmCheck != nil {
+		errs = append(errs, isValidHelmCheck(spec.Features.HelmCheck)...)
+	}
+
+	if spec.Features != nil && spec.Features.RemoteConfiguration != nil {
+		errs = append(errs, isValidRemoteConfiguration(spec.Features.RemoteConfiguration)...)
+	}
+
+	if spec.Global != nil && spec.Global.AdditionalEndpoints != nil {
+		errs = append(errs, isValidAdditionalEndpoints(spec.Global.AdditionalEndpoints)...)
+	}
+
+	if spec.Global != nil && spec.Global.Proxy != nil {
+		errs = append(errs, isValidProxy(spec.Global.Proxy)...)
+	}
+
+	for _, componentName := range []ComponentName{NodeAgentComponentName, ClusterAgentComponentName, ClusterChecksRunnerComponentName} {
+		if override, found := spec.Override[componentName]; found && override != nil && override.NetworkPolicy != nil {
+			errs = append(errs, isValidNetworkPolicyRules(override.NetworkPolicy, fmt.Sprintf("spec.override.%s.networkPolicy", componentName))...)
+		}
+	}
+
+	return utilserrors.NewAggregate(errs)
+}
+
+func isValidLogCollection(logCollection *common.LogCollectionFeatureConfig) []error {
+	var errs []error
+
+	for i, rule := range logCollection.ProcessingRules {
+		field := fmt.Sprintf("spec.features.logCollection.processingRules[%d]", i)
+		if rule.Name == "" {
+			errs = append(errs, fmt.Errorf("%s.name must be defined", field))
+		}
+
+		switch rule.Type {
+		case common.LogsProcessingRuleExcludeAtMatch, common.LogsProcessingRuleIncludeAtMatch:
+			if rule.ReplacePlaceholder != nil {
+				errs = append(errs, fmt.Errorf("%s.replacePlaceholder is only supported by %s rules", field, common.LogsProcessingRuleMaskSequences))
+			}
+		case common.LogsProcessingRuleMaskSequences:
+		case common.LogsProcessingRuleMultiLine:
+			// The Agent only applies multi_line rules in the log configurations of the integrations
+			errs = append(errs, fmt.Errorf("%s.type %s is not supported in the global processing rules, use autoMultiLineDetection instead", field, rule.Type))
+		default:
+			errs = append(errs, fmt.Errorf("%s.type %q is not supported", field, rule.Type))
+		}
+
+		if rule.Pattern == "" {
+			errs = append(errs, fmt.Errorf("%s.pattern must be defined", field))
+		} else if _, err := regexp.Compile(rule.Pattern); err != nil {
+			errs = append(errs, fmt.Errorf("%s.pattern is not a valid regular expression: %w", field, err))
+		}
+	}
+
+	if autoMultiLine := logCollection.AutoMultiLineDetection; autoMultiLine != nil {
+		field := "spec.features.logCollection.autoMultiLineDetection"
+		for i, pattern := range autoMultiLine.ExtraPatterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				errs = append(errs, fmt.Errorf("%s.extraPatterns[%d] is not a valid regular expression: %w", field, i, err))
+			}
+			if strings.ContainsAny(pattern, " \t\n") {
+				errs = append(errs, fmt.Errorf("%s.extraPatterns[%d] must not contain whitespace characters", field, i))
+			}
+		}
+
+		if autoMultiLine.DefaultSampleSize != nil && *autoMultiLine.DefaultSampleSize <= 0 {
+			errs = append(errs, fmt.Errorf("%s.defaultSampleSize must be greater than 0", field))
+		}
+
+		if autoMultiLine.DefaultMatchThreshold != nil {
+			threshold, err := strconv.ParseFloat(*autoMultiLine.DefaultMatchThreshold, 64)
+			if err != nil || threshold < 0 || threshold > 1 {
+				errs = append(errs, fmt.Errorf("%s.defaultMatchThreshold must be a number between 0 and 1", field))
+			}
+		}
+	}
+
+	return errs
+}
+
+func isValidPrometheusScrape(prometheusScrape *PrometheusScrapeFeatureConfig) []error {
+	var errs []error
+
+	for i, config := range prometheusScrape.Configs {
+		field := fmt.Sprintf("spec.features.prometheusScrape.configs[%d]", i)
+
+		for j, pattern := range config.Metrics {
+			if _, err := regexp.Compile(pattern); err != nil {
+				errs = append(errs, fmt.Errorf("%s.metrics[%d] is not a valid regular expression: %w", field, j, err))
+			}
+		}
+		for j, pattern := range config.ExcludeMetrics {
+			if _, err := regexp.Compile(pattern); err != nil {
+				errs = append(errs, fmt.Errorf("%s.excludeMetrics[%d] is not a valid regular expression: %w", field, j, err))
+			}
+		}
+
+		if config.BearerTokenSecret != nil && (config.BearerTokenSecret.Name == "" || config.BearerTokenSecret.Key == "") {
+			errs = append(errs, fmt.Errorf("%s.bearerTokenSecret name and key must be defined", field))
+		}
+
+		if config.Endpoint != nil {
+			if config.Endpoint.Port.IntValue() == 0 && config.Endpoint.Port.StrVal == "" {
+				errs = append(errs, fmt.Errorf("%s.endpoint.port must be defined", field))
+			}
+			if scheme := config.Endpoint.Scheme; scheme != nil && *scheme != "http" && *scheme != "https" {
+				errs = append(errs, fmt.Errorf("%s.endpoint.scheme must be http or https", field))
+			}
+		}
+
+		if ad := config.Autodiscovery; ad != nil && len(ad.KubernetesEndpoints) > 0 {
+			if ad.KubernetesAnnotations != nil || len(ad.KubernetesContainerNames) > 0 {
+				errs = append(errs, fmt.Errorf("%s.autodiscovery.kubernetesEndpoints cannot be combined with kubernetesAnnotations or kubernetesContainerNames", field))
+			}
+			if config.Endpoint == nil {
+				errs = append(errs, fmt.Errorf("%s.endpoint must be defined when autodiscovery.kubernetesEndpoints is set", field))
+			}
+			for j, service := range ad.KubernetesEndpoints {
+				if service.Name == "" || service.Namespace == "" {
+					errs = append(errs, fmt.Errorf("%s.autodiscovery.kubernetesEndpoints[%d] name and namespace must be defined", field, j))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+func isValidEventCollection(eventCollection *EventCollectionFeatureConfig) []error {
+	var errs []error
+
+	if eventCollection.MaxEventsPerRun != nil && *eventCollection.MaxEventsPerRun <= 0 {
+		errs = append(errs, fmt.Errorf("spec.features.eventCollection.maxEventsPerRun must be greater than 0"))
+	}
+
+	if filter := eventCollection.Filter; filter != nil {
+		if filter.Namespace != nil && len(filter.ExcludedNamespaces) > 0 {
+			errs = append(errs, fmt.Errorf("spec.features.eventCollection.filter: namespace and excludedNamespaces cannot be both set"))
+		}
+		if filter.Type != nil && *filter.Type != EventTypeNormal && *filter.Type != EventTypeWarning {
+			errs = append(errs, fmt.Errorf("spec.features.eventCollection.filter.type: event type %q is not supported", *filter.Type))
+		}
+	}
+
+	return errs
+}
+
+func isValidHelmCheck(helmCheck *HelmCheckFeatureConfig) []error {
+	var errs []error
+
+	errs = append(errs, isValidHelmCheckFilter("spec.features.helmCheck.namespaces", helmCheck.Namespaces)...)
+	errs = append(errs, isValidHelmCheckFilter("spec.features.helmCheck.releases", helmCheck.Releases)...)
+
+	if helmCheck.StorageDriver != nil {
+		switch *helmCheck.StorageDriver {
+		case HelmStorageDriverSecret, HelmStorageDriverConfigMap:
+		default:
+			errs = append(errs, fmt.Errorf("spec.features.helmCheck.storageDriver %q is not supported", *helmCheck.StorageDriver))
+		}
+	}
+
+	return errs
+}
+
+func isValidHelmCheckFilter(field string, filter *HelmCheckFilter) []error {
+	if filter == nil {
+		return nil
+	}
+
+	var errs []error
+	included := make(map[string]struct{}, len(filter.Include))
+	for _, value := range filter.Include {
+		included[value] = struct{}{}
+	}
+	for _, value := range filter.Exclude {
+		if _, found := included[value]; found {
+			errs = append(errs, fmt.Errorf("%s: %q cannot be both included and excluded", field, value))
+		}
+	}
+
+	return errs
+}
+
+func isValidRemoteConfiguration(remoteConfiguration *RemoteConfigurationFeatureConfig) []error {
+	var errs []error
+
+	features := map[RemoteConfigFeature]bool{}
+	t := reflect.TypeOf(DatadogFeatures{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		features[RemoteConfigFeature(name)] = true
+	}
+
+	for i, feature := range remoteConfiguration.AllowedFeatures {
+		field := fmt.Sprintf("spec.features.remoteConfiguration.allowedFeatures[%d]", i)
+		if feature == RemoteConfigFeatureRemoteConfiguration {
+			errs = append(errs, fmt.Errorf("%s %q can't be configured by Remote Configuration", field, feature))
+		} else if !features[feature] {
+			errs = append(errs, fmt.Errorf("%s %q is not a DatadogAgent feature", field, feature))
+		}
+	}
+
+	if remoteConfiguration.HistoryLimit != nil && (*remoteConfiguration.HistoryLimit < 1 || *remoteConfiguration.HistoryLimit > 100) {
+		errs = append(errs, fmt.Errorf("spec.features.remoteConfiguration.historyLimit must be between 1 and 100"))
+	}
+
+	return errs
+}
+
+func isValidNetworkPolicyRules(rules *NetworkPolicyRules, field string) []error {
+	var errs []error
+
+	validateRule := func(rule NetworkPolicyRule, ruleField string) {
+		for j, cidr := range rule.IPBlocks {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				errs = append(errs, fmt.Errorf("%s.ipBlocks[%d] %q is not a valid CIDR", ruleField, j, cidr))
+			}
+		}
+		for _, selector := range []*metav1.LabelSelector{rule.PodSelector, rule.NamespaceSelector} {
+			if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+				errs = append(errs, fmt.Errorf("%s has an invalid selector: %w", ruleField, err))
+			}
+		}
+	}
+
+	for i, rule := range rules.AdditionalEgress {
+		validateRule(rule, fmt.Sprintf("%s.additionalEgress[%d]", field, i))
+	}
+	for i, rule := range rules.AdditionalIngress {
+		ruleField := fmt.Sprintf("%s.additionalIngress[%d]", field, i)
+		validateRule(rule, ruleField)
+		if len(rule.DomainNames) > 0 {
+			errs = append(errs, fmt.Errorf("%s.domainNames is only supported by egress rules", ruleField))
+		}
+	}
+
+	return errs
+}
+
+func isValidProxy(proxy *ProxyConfig) []error {
+	var errs []error
+	field := "spec.global.proxy"
+
+	for _, name := range []string{"http", "https"} {
+		proxyURL := proxy.HTTP
+		if name == "https" {
+			proxyURL = proxy.HTTPS
+		}
+		if proxyURL == nil {
+			continue
+		}
+		u, err := url.Parse(*proxyURL)
+		if err != nil || u.Host == "" {
+			errs = append(errs, fmt.Errorf("%s.%s %q is not a valid proxy URL", field, name, *proxyURL))
+		} else if u.User != nil && (proxy.UsernameSecret != nil || proxy.PasswordSecret != nil) {
+			errs = append(errs, fmt.Errorf("%s.%s cannot contain credentials when usernameSecret or passwordSecret is set", field, name))
+		}
+	}
+
+	if secret := proxy.UsernameSecret; secret != nil && (secret.SecretName == "" || secret.KeyName == "") {
+		errs = append(errs, fmt.Errorf("%s.usernameSecret name and key must be defined", field))
+	}
+	if secret := proxy.PasswordSecret; secret != nil && (secret.SecretName == "" || secret.KeyName == "") {
+		errs = append(errs, fmt.Errorf("%s.passwordSecret name and key must be defined", field))
+	}
+	if proxy.PasswordSecret != nil && proxy.UsernameSecret == nil {
+		errs = append(errs, fmt.Errorf("%s.passwordSecret requires usernameSecret", field))
+	}
+	if (proxy.UsernameSecret != nil || proxy.PasswordSecret != nil) && proxy.HTTP == nil && proxy.HTTPS == nil {
+		errs = append(errs, fmt.Errorf("%s credentials require http or https to be defined", field))
+	}
+
+	return errs
+}
+
+func isValidAdditionalEndpoints(additionalEndpoints *AdditionalEndpointsConfig) []error {
+	var errs []error
+
+	for _, product := range []struct {
+		name      string
+		endpoints []AdditionalEndpoint
+	}{
+		{name: "metrics", endpoints: additionalEndpoints.Metrics},
+		{name: "logs", endpoints: additionalEndpoints.Logs},
+		{name: "apm", endpoints: additionalEndpoints.APM},
+		{name: "process", endpoints: additionalEndpoints.Process},
+		{name: "orchestrator", endpoints: additionalEndpoints.Orchestrator},
+	} {
+		for i, endpoint := range product.endpoints {
+			field := fmt.Sprintf("spec.global.additionalEndpoints.%s[%d]", product.name, i)
+			if endpoint.URL == "" {
+				errs = append(errs, fmt.Errorf("%s.url must be defined", field))
+			} else if _, err := url.Parse(endpoint.URL); err != nil {
+				errs = append(errs, fmt.Errorf("%s.url %q is not a valid URL", field, endpoint.URL))
+			}
+			if endpoint.APIKeySecret.SecretName == "" || endpoint.APIKeySecret.KeyName == "" {
+				errs = append(errs, fmt.Errorf("%s.apiKeySecret name and key must be defined", field))
+			}
+		}
+	}
+
+	return errs
+}
@@ -26,12 +26,12 @@ func (in *APMFeatureConfig) DeepCopyInto(out *APMFeatureConfig) {
 	}
 	if in.HostPortConfig != nil {
 		in, out := &in.HostPortConfig, &out.HostPortConfig
-		*out = new(HostPortConfig)
+		*out = new(common.HostPortConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.UnixDomainSocketConfig != nil {
 		in, out := &in.UnixDomainSocketConfig, &out.UnixDomainSocketConfig
-		*out = new(UnixDomainSocketConfig)
+		*out = new(common.UnixDomainSocketConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SingleStepInstrumentation != nil {
@@ -146,6 +146,62 @@ func (in *ASMThreatsConfig) DeepCopy() *ASMThreatsConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalEndpoint) DeepCopyInto(out *AdditionalEndpoint) {
+	*out = *in
+	out.APIKeySecret = in.APIKeySecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalEndpoint.
+func (in *AdditionalEndpoint) DeepCopy() *AdditionalEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalEndpointsConfig) DeepCopyInto(out *AdditionalEndpointsConfig) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]AdditionalEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = make([]AdditionalEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.APM != nil {
+		in, out := &in.APM, &out.APM
+		*out = make([]AdditionalEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.Process != nil {
+		in, out := &in.Process, &out.Process
+		*out = make([]AdditionalEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.Orchestrator != nil {
+		in, out := &in.Orchestrator, &out.Orchestrator
+		*out = make([]AdditionalEndpoint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalEndpointsConfig.
+func (in *AdditionalEndpointsConfig) DeepCopy() *AdditionalEndpointsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalEndpointsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdmissionControllerFeatureConfig) DeepCopyInto(out *AdmissionControllerFeatureConfig) {
 	*out = *in
@@ -176,7 +232,7 @@ func (in *AdmissionControllerFeatureConfig) DeepCopyInto(out *AdmissionControlle
 	}
 	if in.AgentCommunicationMode != nil {
 		in, out := &in.AgentCommunicationMode, &out.AgentCommunicationMode
-		*out = new(string)
+		*out = new(AgentCommunicationMode)
 		**out = **in
 	}
 	if in.FailurePolicy != nil {
@@ -387,7 +443,7 @@ func (in *CSPMFeatureConfig) DeepCopyInto(out *CSPMFeatureConfig) {
 	}
 	if in.CustomBenchmarks != nil {
 		in, out := &in.CustomBenchmarks, &out.CustomBenchmarks
-		*out = new(CustomConfig)
+		*out = new(common.CustomConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.HostBenchmarks != nil {
@@ -457,7 +513,7 @@ func (in *CWSFeatureConfig) DeepCopyInto(out *CWSFeatureConfig) {
 	}
 	if in.CustomPolicies != nil {
 		in, out := &in.CustomPolicies, &out.CustomPolicies
-		*out = new(CustomConfig)
+		*out = new(common.CustomConfig)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -482,7 +538,7 @@ func (in *CWSInstrumentationConfig) DeepCopyInto(out *CWSInstrumentationConfig)
 	}
 	if in.Mode != nil {
 		in, out := &in.Mode, &out.Mode
-		*out = new(string)
+		*out = new(CWSInstrumentationMode)
 		**out = **in
 	}
 }
@@ -558,103 +614,193 @@ func (in *CWSSecurityProfilesConfig) DeepCopy() *CWSSecurityProfilesConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterChecksFeatureConfig) DeepCopyInto(out *ClusterChecksFeatureConfig) {
+func (in *CertManagerConfig) DeepCopyInto(out *CertManagerConfig) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.UseClusterChecksRunners != nil {
-		in, out := &in.UseClusterChecksRunners, &out.UseClusterChecksRunners
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertManagerIssuerReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerConfig.
+func (in *CertManagerConfig) DeepCopy() *CertManagerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAgentTLSConfig) DeepCopyInto(out *ClusterAgentTLSConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
+	if in.CertificateValidity != nil {
+		in, out := &in.CertificateValidity, &out.CertificateValidity
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterChecksFeatureConfig.
-func (in *ClusterChecksFeatureConfig) DeepCopy() *ClusterChecksFeatureConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAgentTLSConfig.
+func (in *ClusterAgentTLSConfig) DeepCopy() *ClusterAgentTLSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterChecksFeatureConfig)
+	out := new(ClusterAgentTLSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConfigMapConfig) DeepCopyInto(out *ConfigMapConfig) {
+func (in *ClusterAgentTLSStatus) DeepCopyInto(out *ClusterAgentTLSStatus) {
 	*out = *in
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]corev1.KeyToPath, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapConfig.
-func (in *ConfigMapConfig) DeepCopy() *ConfigMapConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAgentTLSStatus.
+func (in *ClusterAgentTLSStatus) DeepCopy() *ClusterAgentTLSStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ConfigMapConfig)
+	out := new(ClusterAgentTLSStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CoreConfig) DeepCopyInto(out *CoreConfig) {
+func (in *ClusterAgentTokenRotationConfig) DeepCopyInto(out *ClusterAgentTokenRotationConfig) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.ExtensionURL != nil {
-		in, out := &in.ExtensionURL, &out.ExtensionURL
-		*out = new(string)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
 		**out = **in
 	}
-	if in.ExtensionTimeout != nil {
-		in, out := &in.ExtensionTimeout, &out.ExtensionTimeout
-		*out = new(int)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAgentTokenRotationConfig.
+func (in *ClusterAgentTokenRotationConfig) DeepCopy() *ClusterAgentTokenRotationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAgentTokenRotationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAgentTokenStatus) DeepCopyInto(out *ClusterAgentTokenStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAgentTokenStatus.
+func (in *ClusterAgentTokenStatus) DeepCopy() *ClusterAgentTokenStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAgentTokenStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterChecksFeatureConfig) DeepCopyInto(out *ClusterChecksFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseClusterChecksRunners != nil {
+		in, out := &in.UseClusterChecksRunners, &out.UseClusterChecksRunners
+		*out = new(bool)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreConfig.
-func (in *CoreConfig) DeepCopy() *CoreConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterChecksFeatureConfig.
+func (in *ClusterChecksFeatureConfig) DeepCopy() *ClusterChecksFeatureConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(CoreConfig)
+	out := new(ClusterChecksFeatureConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomConfig) DeepCopyInto(out *CustomConfig) {
+func (in *CoreConfig) DeepCopyInto(out *CoreConfig) {
 	*out = *in
-	if in.ConfigData != nil {
-		in, out := &in.ConfigData, &out.ConfigData
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExtensionURL != nil {
+		in, out := &in.ExtensionURL, &out.ExtensionURL
 		*out = new(string)
 		**out = **in
 	}
-	if in.ConfigMap != nil {
-		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(ConfigMapConfig)
-		(*in).DeepCopyInto(*out)
+	if in.ExtensionTimeout != nil {
+		in, out := &in.ExtensionTimeout, &out.ExtensionTimeout
+		*out = new(int)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomConfig.
-func (in *CustomConfig) DeepCopy() *CustomConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreConfig.
+func (in *CoreConfig) DeepCopy() *CoreConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomConfig)
+	out := new(CoreConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -761,19 +907,19 @@ func (in *DatadogAgentComponentOverride) DeepCopyInto(out *DatadogAgentComponent
 	}
 	if in.CustomConfigurations != nil {
 		in, out := &in.CustomConfigurations, &out.CustomConfigurations
-		*out = make(map[AgentConfigFileName]CustomConfig, len(*in))
+		*out = make(map[common.AgentConfigFileName]common.CustomConfig, len(*in))
 		for key, val := range *in {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	if in.ExtraConfd != nil {
 		in, out := &in.ExtraConfd, &out.ExtraConfd
-		*out = new(MultiCustomConfig)
+		*out = new(common.MultiCustomConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ExtraChecksd != nil {
 		in, out := &in.ExtraChecksd, &out.ExtraChecksd
-		*out = new(MultiCustomConfig)
+		*out = new(common.MultiCustomConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Containers != nil {
@@ -862,6 +1008,11 @@ func (in *DatadogAgentComponentOverride) DeepCopyInto(out *DatadogAgentComponent
 			(*out)[key] = val
 		}
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicyRules)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.HostNetwork != nil {
 		in, out := &in.HostNetwork, &out.HostNetwork
 		*out = new(bool)
@@ -1039,6 +1190,11 @@ func (in *DatadogAgentSpec) DeepCopyInto(out *DatadogAgentSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.RolloutPolicy != nil {
+		in, out := &in.RolloutPolicy, &out.RolloutPolicy
+		*out = new(RolloutPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogAgentSpec.
@@ -1092,6 +1248,33 @@ func (in *DatadogAgentStatus) DeepCopyInto(out *DatadogAgentStatus) {
 		*out = new(RemoteConfigConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RemoteConfigProposal != nil {
+		in, out := &in.RemoteConfigProposal, &out.RemoteConfigProposal
+		*out = new(RemoteConfigProposal)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoteConfigHistory != nil {
+		in, out := &in.RemoteConfigHistory, &out.RemoteConfigHistory
+		*out = make([]RemoteConfigHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InstrumentationTargets != nil {
+		in, out := &in.InstrumentationTargets, &out.InstrumentationTargets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterAgentTLS != nil {
+		in, out := &in.ClusterAgentTLS, &out.ClusterAgentTLS
+		*out = new(ClusterAgentTLSStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterAgentToken != nil {
+		in, out := &in.ClusterAgentToken, &out.ClusterAgentToken
+		*out = new(ClusterAgentTokenStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogAgentStatus.
@@ -1149,37 +1332,37 @@ func (in *DatadogFeatures) DeepCopyInto(out *DatadogFeatures) {
 	}
 	if in.LogCollection != nil {
 		in, out := &in.LogCollection, &out.LogCollection
-		*out = new(LogCollectionFeatureConfig)
+		*out = new(common.LogCollectionFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.LiveProcessCollection != nil {
 		in, out := &in.LiveProcessCollection, &out.LiveProcessCollection
-		*out = new(LiveProcessCollectionFeatureConfig)
+		*out = new(common.LiveProcessCollectionFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.LiveContainerCollection != nil {
 		in, out := &in.LiveContainerCollection, &out.LiveContainerCollection
-		*out = new(LiveContainerCollectionFeatureConfig)
+		*out = new(common.LiveContainerCollectionFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ProcessDiscovery != nil {
 		in, out := &in.ProcessDiscovery, &out.ProcessDiscovery
-		*out = new(ProcessDiscoveryFeatureConfig)
+		*out = new(common.ProcessDiscoveryFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.OOMKill != nil {
 		in, out := &in.OOMKill, &out.OOMKill
-		*out = new(OOMKillFeatureConfig)
+		*out = new(common.OOMKillFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.TCPQueueLength != nil {
 		in, out := &in.TCPQueueLength, &out.TCPQueueLength
-		*out = new(TCPQueueLengthFeatureConfig)
+		*out = new(common.TCPQueueLengthFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.EBPFCheck != nil {
 		in, out := &in.EBPFCheck, &out.EBPFCheck
-		*out = new(EBPFCheckFeatureConfig)
+		*out = new(common.EBPFCheckFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.APM != nil {
@@ -1204,17 +1387,17 @@ func (in *DatadogFeatures) DeepCopyInto(out *DatadogFeatures) {
 	}
 	if in.NPM != nil {
 		in, out := &in.NPM, &out.NPM
-		*out = new(NPMFeatureConfig)
+		*out = new(common.NPMFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.USM != nil {
 		in, out := &in.USM, &out.USM
-		*out = new(USMFeatureConfig)
+		*out = new(common.USMFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Dogstatsd != nil {
 		in, out := &in.Dogstatsd, &out.Dogstatsd
-		*out = new(DogstatsdFeatureConfig)
+		*out = new(common.DogstatsdFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.OTLP != nil {
@@ -1234,12 +1417,12 @@ func (in *DatadogFeatures) DeepCopyInto(out *DatadogFeatures) {
 	}
 	if in.ServiceDiscovery != nil {
 		in, out := &in.ServiceDiscovery, &out.ServiceDiscovery
-		*out = new(ServiceDiscoveryFeatureConfig)
+		*out = new(common.ServiceDiscoveryFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.GPU != nil {
 		in, out := &in.GPU, &out.GPU
-		*out = new(GPUFeatureConfig)
+		*out = new(common.GPUFeatureConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.EventCollection != nil {
@@ -1318,66 +1501,6 @@ func (in *DeploymentStatus) DeepCopy() *DeploymentStatus {
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DogstatsdFeatureConfig) DeepCopyInto(out *DogstatsdFeatureConfig) {
-	*out = *in
-	if in.OriginDetectionEnabled != nil {
-		in, out := &in.OriginDetectionEnabled, &out.OriginDetectionEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.TagCardinality != nil {
-		in, out := &in.TagCardinality, &out.TagCardinality
-		*out = new(string)
-		**out = **in
-	}
-	if in.HostPortConfig != nil {
-		in, out := &in.HostPortConfig, &out.HostPortConfig
-		*out = new(HostPortConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.UnixDomainSocketConfig != nil {
-		in, out := &in.UnixDomainSocketConfig, &out.UnixDomainSocketConfig
-		*out = new(UnixDomainSocketConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.MapperProfiles != nil {
-		in, out := &in.MapperProfiles, &out.MapperProfiles
-		*out = new(CustomConfig)
-		(*in).DeepCopyInto(*out)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DogstatsdFeatureConfig.
-func (in *DogstatsdFeatureConfig) DeepCopy() *DogstatsdFeatureConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(DogstatsdFeatureConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EBPFCheckFeatureConfig) DeepCopyInto(out *EBPFCheckFeatureConfig) {
-	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EBPFCheckFeatureConfig.
-func (in *EBPFCheckFeatureConfig) DeepCopy() *EBPFCheckFeatureConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(EBPFCheckFeatureConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 	*out = *in
@@ -1443,6 +1566,16 @@ func (in *EventCollectionFeatureConfig) DeepCopyInto(out *EventCollectionFeature
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(EventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxEventsPerRun != nil {
+		in, out := &in.MaxEventsPerRun, &out.MaxEventsPerRun
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventCollectionFeatureConfig.
@@ -1455,6 +1588,41 @@ func (in *EventCollectionFeatureConfig) DeepCopy() *EventCollectionFeatureConfig
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventFilter) DeepCopyInto(out *EventFilter) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExcludedNamespaces != nil {
+		in, out := &in.ExcludedNamespaces, &out.ExcludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(EventType)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventFilter.
+func (in *EventFilter) DeepCopy() *EventFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(EventFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EventTypes) DeepCopyInto(out *EventTypes) {
 	*out = *in
@@ -1560,7 +1728,7 @@ func (in *FIPSConfig) DeepCopyInto(out *FIPSConfig) {
 	}
 	if in.CustomFIPSConfig != nil {
 		in, out := &in.CustomFIPSConfig, &out.CustomFIPSConfig
-		*out = new(CustomConfig)
+		*out = new(common.CustomConfig)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -1576,40 +1744,15 @@ func (in *FIPSConfig) DeepCopy() *FIPSConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GPUFeatureConfig) DeepCopyInto(out *GPUFeatureConfig) {
+func (in *GlobalConfig) DeepCopyInto(out *GlobalConfig) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(DatadogCredentials)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.PodRuntimeClassName != nil {
-		in, out := &in.PodRuntimeClassName, &out.PodRuntimeClassName
-		*out = new(string)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUFeatureConfig.
-func (in *GPUFeatureConfig) DeepCopy() *GPUFeatureConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(GPUFeatureConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalConfig) DeepCopyInto(out *GlobalConfig) {
-	*out = *in
-	if in.Credentials != nil {
-		in, out := &in.Credentials, &out.Credentials
-		*out = new(DatadogCredentials)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ClusterAgentToken != nil {
-		in, out := &in.ClusterAgentToken, &out.ClusterAgentToken
+	if in.ClusterAgentToken != nil {
+		in, out := &in.ClusterAgentToken, &out.ClusterAgentToken
 		*out = new(string)
 		**out = **in
 	}
@@ -1618,6 +1761,16 @@ func (in *GlobalConfig) DeepCopyInto(out *GlobalConfig) {
 		*out = new(SecretConfig)
 		**out = **in
 	}
+	if in.ClusterAgentTokenRotation != nil {
+		in, out := &in.ClusterAgentTokenRotation, &out.ClusterAgentTokenRotation
+		*out = new(ClusterAgentTokenRotationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterAgentTLS != nil {
+		in, out := &in.ClusterAgentTLS, &out.ClusterAgentTLS
+		*out = new(ClusterAgentTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ClusterName != nil {
 		in, out := &in.ClusterName, &out.ClusterName
 		*out = new(string)
@@ -1633,6 +1786,16 @@ func (in *GlobalConfig) DeepCopyInto(out *GlobalConfig) {
 		*out = new(Endpoint)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalEndpoints != nil {
+		in, out := &in.AdditionalEndpoints, &out.AdditionalEndpoints
+		*out = new(AdditionalEndpointsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Registry != nil {
 		in, out := &in.Registry, &out.Registry
 		*out = new(string)
@@ -1818,6 +1981,28 @@ func (in *HelmCheckFeatureConfig) DeepCopyInto(out *HelmCheckFeatureConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.ChartAnnotationsAsTags != nil {
+		in, out := &in.ChartAnnotationsAsTags, &out.ChartAnnotationsAsTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(HelmCheckFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Releases != nil {
+		in, out := &in.Releases, &out.Releases
+		*out = new(HelmCheckFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageDriver != nil {
+		in, out := &in.StorageDriver, &out.StorageDriver
+		*out = new(HelmStorageDriver)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmCheckFeatureConfig.
@@ -1831,26 +2016,26 @@ func (in *HelmCheckFeatureConfig) DeepCopy() *HelmCheckFeatureConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostPortConfig) DeepCopyInto(out *HostPortConfig) {
+func (in *HelmCheckFilter) DeepCopyInto(out *HelmCheckFilter) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Port != nil {
-		in, out := &in.Port, &out.Port
-		*out = new(int32)
-		**out = **in
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPortConfig.
-func (in *HostPortConfig) DeepCopy() *HostPortConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmCheckFilter.
+func (in *HelmCheckFilter) DeepCopy() *HelmCheckFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(HostPortConfig)
+	out := new(HelmCheckFilter)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1880,7 +2065,7 @@ func (in *KubeStateMetricsCoreFeatureConfig) DeepCopyInto(out *KubeStateMetricsC
 	}
 	if in.Conf != nil {
 		in, out := &in.Conf, &out.Conf
-		*out = new(CustomConfig)
+		*out = new(common.CustomConfig)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -1960,56 +2145,6 @@ func (in *LanguageDetectionConfig) DeepCopy() *LanguageDetectionConfig {
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LiveContainerCollectionFeatureConfig) DeepCopyInto(out *LiveContainerCollectionFeatureConfig) {
-	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LiveContainerCollectionFeatureConfig.
-func (in *LiveContainerCollectionFeatureConfig) DeepCopy() *LiveContainerCollectionFeatureConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(LiveContainerCollectionFeatureConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LiveProcessCollectionFeatureConfig) DeepCopyInto(out *LiveProcessCollectionFeatureConfig) {
-	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.ScrubProcessArguments != nil {
-		in, out := &in.ScrubProcessArguments, &out.ScrubProcessArguments
-		*out = new(bool)
-		**out = **in
-	}
-	if in.StripProcessArguments != nil {
-		in, out := &in.StripProcessArguments, &out.StripProcessArguments
-		*out = new(bool)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LiveProcessCollectionFeatureConfig.
-func (in *LiveProcessCollectionFeatureConfig) DeepCopy() *LiveProcessCollectionFeatureConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(LiveProcessCollectionFeatureConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalService) DeepCopyInto(out *LocalService) {
 	*out = *in
@@ -2035,118 +2170,6 @@ func (in *LocalService) DeepCopy() *LocalService {
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogCollectionFeatureConfig) DeepCopyInto(out *LogCollectionFeatureConfig) {
-	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.ContainerCollectAll != nil {
-		in, out := &in.ContainerCollectAll, &out.ContainerCollectAll
-		*out = new(bool)
-		**out = **in
-	}
-	if in.ContainerCollectUsingFiles != nil {
-		in, out := &in.ContainerCollectUsingFiles, &out.ContainerCollectUsingFiles
-		*out = new(bool)
-		**out = **in
-	}
-	if in.ContainerLogsPath != nil {
-		in, out := &in.ContainerLogsPath, &out.ContainerLogsPath
-		*out = new(string)
-		**out = **in
-	}
-	if in.PodLogsPath != nil {
-		in, out := &in.PodLogsPath, &out.PodLogsPath
-		*out = new(string)
-		**out = **in
-	}
-	if in.ContainerSymlinksPath != nil {
-		in, out := &in.ContainerSymlinksPath, &out.ContainerSymlinksPath
-		*out = new(string)
-		**out = **in
-	}
-	if in.TempStoragePath != nil {
-		in, out := &in.TempStoragePath, &out.TempStoragePath
-		*out = new(string)
-		**out = **in
-	}
-	if in.OpenFilesLimit != nil {
-		in, out := &in.OpenFilesLimit, &out.OpenFilesLimit
-		*out = new(int32)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectionFeatureConfig.
-func (in *LogCollectionFeatureConfig) DeepCopy() *LogCollectionFeatureConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(LogCollectionFeatureConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiCustomConfig) DeepCopyInto(out *MultiCustomConfig) {
-	*out = *in
-	if in.ConfigDataMap != nil {
-		in, out := &in.ConfigDataMap, &out.ConfigDataMap
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.ConfigMap != nil {
-		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(ConfigMapConfig)
-		(*in).DeepCopyInto(*out)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiCustomConfig.
-func (in *MultiCustomConfig) DeepCopy() *MultiCustomConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(MultiCustomConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NPMFeatureConfig) DeepCopyInto(out *NPMFeatureConfig) {
-	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.EnableConntrack != nil {
-		in, out := &in.EnableConntrack, &out.EnableConntrack
-		*out = new(bool)
-		**out = **in
-	}
-	if in.CollectDNSStats != nil {
-		in, out := &in.CollectDNSStats, &out.CollectDNSStats
-		*out = new(bool)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPMFeatureConfig.
-func (in *NPMFeatureConfig) DeepCopy() *NPMFeatureConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(NPMFeatureConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceSelector) DeepCopyInto(out *NamespaceSelector) {
 	*out = *in
@@ -2209,21 +2232,92 @@ func (in *NetworkPolicyConfig) DeepCopy() *NetworkPolicyConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OOMKillFeatureConfig) DeepCopyInto(out *OOMKillFeatureConfig) {
+func (in *NetworkPolicyRule) DeepCopyInto(out *NetworkPolicyRule) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]NetworkPolicyRulePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IPBlocks != nil {
+		in, out := &in.IPBlocks, &out.IPBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DomainNames != nil {
+		in, out := &in.DomainNames, &out.DomainNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyRule.
+func (in *NetworkPolicyRule) DeepCopy() *NetworkPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyRulePort) DeepCopyInto(out *NetworkPolicyRulePort) {
+	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(corev1.Protocol)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OOMKillFeatureConfig.
-func (in *OOMKillFeatureConfig) DeepCopy() *OOMKillFeatureConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyRulePort.
+func (in *NetworkPolicyRulePort) DeepCopy() *NetworkPolicyRulePort {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyRulePort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyRules) DeepCopyInto(out *NetworkPolicyRules) {
+	*out = *in
+	if in.AdditionalEgress != nil {
+		in, out := &in.AdditionalEgress, &out.AdditionalEgress
+		*out = make([]NetworkPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalIngress != nil {
+		in, out := &in.AdditionalIngress, &out.AdditionalIngress
+		*out = make([]NetworkPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyRules.
+func (in *NetworkPolicyRules) DeepCopy() *NetworkPolicyRules {
 	if in == nil {
 		return nil
 	}
-	out := new(OOMKillFeatureConfig)
+	out := new(NetworkPolicyRules)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2254,7 +2348,7 @@ func (in *OTLPGRPCConfig) DeepCopyInto(out *OTLPGRPCConfig) {
 	}
 	if in.HostPortConfig != nil {
 		in, out := &in.HostPortConfig, &out.HostPortConfig
-		*out = new(HostPortConfig)
+		*out = new(common.HostPortConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Endpoint != nil {
@@ -2284,7 +2378,7 @@ func (in *OTLPHTTPConfig) DeepCopyInto(out *OTLPHTTPConfig) {
 	}
 	if in.HostPortConfig != nil {
 		in, out := &in.HostPortConfig, &out.HostPortConfig
-		*out = new(HostPortConfig)
+		*out = new(common.HostPortConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Endpoint != nil {
@@ -2355,7 +2449,7 @@ func (in *OrchestratorExplorerFeatureConfig) DeepCopyInto(out *OrchestratorExplo
 	}
 	if in.Conf != nil {
 		in, out := &in.Conf, &out.Conf
-		*out = new(CustomConfig)
+		*out = new(common.CustomConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ScrubContainers != nil {
@@ -2420,7 +2514,7 @@ func (in *OtelCollectorFeatureConfig) DeepCopyInto(out *OtelCollectorFeatureConf
 	}
 	if in.Conf != nil {
 		in, out := &in.Conf, &out.Conf
-		*out = new(CustomConfig)
+		*out = new(common.CustomConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Ports != nil {
@@ -2452,21 +2546,21 @@ func (in *OtelCollectorFeatureConfig) DeepCopy() *OtelCollectorFeatureConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProcessDiscoveryFeatureConfig) DeepCopyInto(out *ProcessDiscoveryFeatureConfig) {
+func (in *ProductIntakeConfig) DeepCopyInto(out *ProductIntakeConfig) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessDiscoveryFeatureConfig.
-func (in *ProcessDiscoveryFeatureConfig) DeepCopy() *ProcessDiscoveryFeatureConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProductIntakeConfig.
+func (in *ProductIntakeConfig) DeepCopy() *ProductIntakeConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ProcessDiscoveryFeatureConfig)
+	out := new(ProductIntakeConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2504,52 +2598,326 @@ func (in *Profile) DeepCopy() *Profile {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrometheusScrapeFeatureConfig) DeepCopyInto(out *PrometheusScrapeFeatureConfig) {
+func (in *PrometheusOperatorConfig) DeepCopyInto(out *PrometheusOperatorConfig) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.EnableServiceEndpoints != nil {
-		in, out := &in.EnableServiceEndpoints, &out.EnableServiceEndpoints
-		*out = new(bool)
-		**out = **in
-	}
-	if in.AdditionalConfigs != nil {
-		in, out := &in.AdditionalConfigs, &out.AdditionalConfigs
-		*out = new(string)
-		**out = **in
-	}
-	if in.Version != nil {
-		in, out := &in.Version, &out.Version
-		*out = new(int)
-		**out = **in
+	if in.ServiceMonitorSelector != nil {
+		in, out := &in.ServiceMonitorSelector, &out.ServiceMonitorSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeFeatureConfig.
-func (in *PrometheusScrapeFeatureConfig) DeepCopy() *PrometheusScrapeFeatureConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusOperatorConfig.
+func (in *PrometheusOperatorConfig) DeepCopy() *PrometheusOperatorConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PrometheusScrapeFeatureConfig)
+	out := new(PrometheusOperatorConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RemoteConfigConfiguration) DeepCopyInto(out *RemoteConfigConfiguration) {
+func (in *PrometheusScrapeAnnotations) DeepCopyInto(out *PrometheusScrapeAnnotations) {
 	*out = *in
-	if in.Features != nil {
-		in, out := &in.Features, &out.Features
-		*out = new(DatadogFeatures)
-		(*in).DeepCopyInto(*out)
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteConfigConfiguration.
-func (in *RemoteConfigConfiguration) DeepCopy() *RemoteConfigConfiguration {
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeAnnotations.
+func (in *PrometheusScrapeAnnotations) DeepCopy() *PrometheusScrapeAnnotations {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeAnnotations)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusScrapeAutodiscovery) DeepCopyInto(out *PrometheusScrapeAutodiscovery) {
+	*out = *in
+	if in.KubernetesAnnotations != nil {
+		in, out := &in.KubernetesAnnotations, &out.KubernetesAnnotations
+		*out = new(PrometheusScrapeAnnotations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubernetesContainerNames != nil {
+		in, out := &in.KubernetesContainerNames, &out.KubernetesContainerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KubernetesEndpoints != nil {
+		in, out := &in.KubernetesEndpoints, &out.KubernetesEndpoints
+		*out = make([]PrometheusScrapeService, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeAutodiscovery.
+func (in *PrometheusScrapeAutodiscovery) DeepCopy() *PrometheusScrapeAutodiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeAutodiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusScrapeConfig) DeepCopyInto(out *PrometheusScrapeConfig) {
+	*out = *in
+	if in.Autodiscovery != nil {
+		in, out := &in.Autodiscovery, &out.Autodiscovery
+		*out = new(PrometheusScrapeAutodiscovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(PrometheusScrapeEndpoint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeMetrics != nil {
+		in, out := &in.ExcludeMetrics, &out.ExcludeMetrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.RawMetricPrefix != nil {
+		in, out := &in.RawMetricPrefix, &out.RawMetricPrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.RenameLabels != nil {
+		in, out := &in.RenameLabels, &out.RenameLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BearerTokenSecret != nil {
+		in, out := &in.BearerTokenSecret, &out.BearerTokenSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeConfig.
+func (in *PrometheusScrapeConfig) DeepCopy() *PrometheusScrapeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusScrapeEndpoint) DeepCopyInto(out *PrometheusScrapeEndpoint) {
+	*out = *in
+	out.Port = in.Port
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.Scheme != nil {
+		in, out := &in.Scheme, &out.Scheme
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeEndpoint.
+func (in *PrometheusScrapeEndpoint) DeepCopy() *PrometheusScrapeEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusScrapeFeatureConfig) DeepCopyInto(out *PrometheusScrapeFeatureConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableServiceEndpoints != nil {
+		in, out := &in.EnableServiceEndpoints, &out.EnableServiceEndpoints
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdditionalConfigs != nil {
+		in, out := &in.AdditionalConfigs, &out.AdditionalConfigs
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(int)
+		**out = **in
+	}
+	if in.Configs != nil {
+		in, out := &in.Configs, &out.Configs
+		*out = make([]PrometheusScrapeConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PrometheusOperator != nil {
+		in, out := &in.PrometheusOperator, &out.PrometheusOperator
+		*out = new(PrometheusOperatorConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeFeatureConfig.
+func (in *PrometheusScrapeFeatureConfig) DeepCopy() *PrometheusScrapeFeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeFeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusScrapeService) DeepCopyInto(out *PrometheusScrapeService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusScrapeService.
+func (in *PrometheusScrapeService) DeepCopy() *PrometheusScrapeService {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusScrapeService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPS != nil {
+		in, out := &in.HTTPS, &out.HTTPS
+		*out = new(string)
+		**out = **in
+	}
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UsernameSecret != nil {
+		in, out := &in.UsernameSecret, &out.UsernameSecret
+		*out = new(SecretConfig)
+		**out = **in
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(SecretConfig)
+		**out = **in
+	}
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = new(ProductIntakeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APM != nil {
+		in, out := &in.APM, &out.APM
+		*out = new(ProductIntakeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Process != nil {
+		in, out := &in.Process, &out.Process
+		*out = new(ProductIntakeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteConfigChange) DeepCopyInto(out *RemoteConfigChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteConfigChange.
+func (in *RemoteConfigChange) DeepCopy() *RemoteConfigChange {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteConfigChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteConfigConfiguration) DeepCopyInto(out *RemoteConfigConfiguration) {
+	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = new(DatadogFeatures)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteConfigConfiguration.
+func (in *RemoteConfigConfiguration) DeepCopy() *RemoteConfigConfiguration {
 	if in == nil {
 		return nil
 	}
@@ -2558,6 +2926,48 @@ func (in *RemoteConfigConfiguration) DeepCopy() *RemoteConfigConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteConfigHistoryEntry) DeepCopyInto(out *RemoteConfigHistoryEntry) {
+	*out = *in
+	in.AppliedAt.DeepCopyInto(&out.AppliedAt)
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]RemoteConfigFeature, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteConfigHistoryEntry.
+func (in *RemoteConfigHistoryEntry) DeepCopy() *RemoteConfigHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteConfigHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteConfigProposal) DeepCopyInto(out *RemoteConfigProposal) {
+	*out = *in
+	in.ReceivedAt.DeepCopyInto(&out.ReceivedAt)
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]RemoteConfigChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteConfigProposal.
+func (in *RemoteConfigProposal) DeepCopy() *RemoteConfigProposal {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteConfigProposal)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteConfigurationFeatureConfig) DeepCopyInto(out *RemoteConfigurationFeatureConfig) {
 	*out = *in
@@ -2566,6 +2976,21 @@ func (in *RemoteConfigurationFeatureConfig) DeepCopyInto(out *RemoteConfiguratio
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AllowedFeatures != nil {
+		in, out := &in.AllowedFeatures, &out.AllowedFeatures
+		*out = make([]RemoteConfigFeature, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApprovalMode != nil {
+		in, out := &in.ApprovalMode, &out.ApprovalMode
+		*out = new(RemoteConfigApprovalMode)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteConfigurationFeatureConfig.
@@ -2578,6 +3003,51 @@ func (in *RemoteConfigurationFeatureConfig) DeepCopy() *RemoteConfigurationFeatu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutPolicy) DeepCopyInto(out *RolloutPolicy) {
+	*out = *in
+	if in.AgentCompletionPercent != nil {
+		in, out := &in.AgentCompletionPercent, &out.AgentCompletionPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AgentMaxPodsNotUpToDate != nil {
+		in, out := &in.AgentMaxPodsNotUpToDate, &out.AgentMaxPodsNotUpToDate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClusterAgentMinUpToDate != nil {
+		in, out := &in.ClusterAgentMinUpToDate, &out.ClusterAgentMinUpToDate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClusterChecksRunnerMinUpToDate != nil {
+		in, out := &in.ClusterChecksRunnerMinUpToDate, &out.ClusterChecksRunnerMinUpToDate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxContainerRestarts != nil {
+		in, out := &in.MaxContainerRestarts, &out.MaxContainerRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPolicy.
+func (in *RolloutPolicy) DeepCopy() *RolloutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SBOMContainerImageConfig) DeepCopyInto(out *SBOMContainerImageConfig) {
 	*out = *in
@@ -2707,7 +3177,7 @@ func (in *SeccompConfig) DeepCopyInto(out *SeccompConfig) {
 	}
 	if in.CustomProfile != nil {
 		in, out := &in.CustomProfile, &out.CustomProfile
-		*out = new(CustomConfig)
+		*out = new(common.CustomConfig)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -2833,26 +3303,6 @@ func (in *Selector) DeepCopy() *Selector {
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceDiscoveryFeatureConfig) DeepCopyInto(out *ServiceDiscoveryFeatureConfig) {
-	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceDiscoveryFeatureConfig.
-func (in *ServiceDiscoveryFeatureConfig) DeepCopy() *ServiceDiscoveryFeatureConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(ServiceDiscoveryFeatureConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SingleStepInstrumentation) DeepCopyInto(out *SingleStepInstrumentation) {
 	*out = *in
@@ -2907,71 +3357,6 @@ func (in *SingleStepInstrumentation) DeepCopy() *SingleStepInstrumentation {
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TCPQueueLengthFeatureConfig) DeepCopyInto(out *TCPQueueLengthFeatureConfig) {
-	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPQueueLengthFeatureConfig.
-func (in *TCPQueueLengthFeatureConfig) DeepCopy() *TCPQueueLengthFeatureConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(TCPQueueLengthFeatureConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *USMFeatureConfig) DeepCopyInto(out *USMFeatureConfig) {
-	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new USMFeatureConfig.
-func (in *USMFeatureConfig) DeepCopy() *USMFeatureConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(USMFeatureConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *UnixDomainSocketConfig) DeepCopyInto(out *UnixDomainSocketConfig) {
-	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Path != nil {
-		in, out := &in.Path, &out.Path
-		*out = new(string)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnixDomainSocketConfig.
-func (in *UnixDomainSocketConfig) DeepCopy() *UnixDomainSocketConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(UnixDomainSocketConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadAutoscalingFeatureConfig) DeepCopyInto(out *WorkloadAutoscalingFeatureConfig) {
 	*out = *in
@@ -17,9 +17,16 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AdditionalEndpoint":                schema_datadog_operator_api_datadoghq_v2alpha1_AdditionalEndpoint(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AdditionalEndpointsConfig":         schema_datadog_operator_api_datadoghq_v2alpha1_AdditionalEndpointsConfig(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CSPMHostBenchmarksConfig":          schema_datadog_operator_api_datadoghq_v2alpha1_CSPMHostBenchmarksConfig(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CertManagerConfig":                 schema_datadog_operator_api_datadoghq_v2alpha1_CertManagerConfig(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CertManagerIssuerReference":        schema_datadog_operator_api_datadoghq_v2alpha1_CertManagerIssuerReference(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ClusterAgentTLSConfig":             schema_datadog_operator_api_datadoghq_v2alpha1_ClusterAgentTLSConfig(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ClusterAgentTLSStatus":             schema_datadog_operator_api_datadoghq_v2alpha1_ClusterAgentTLSStatus(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ClusterAgentTokenRotationConfig":   schema_datadog_operator_api_datadoghq_v2alpha1_ClusterAgentTokenRotationConfig(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ClusterAgentTokenStatus":           schema_datadog_operator_api_datadoghq_v2alpha1_ClusterAgentTokenStatus(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CoreConfig":                        schema_datadog_operator_api_datadoghq_v2alpha1_CoreConfig(ref),
-		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CustomConfig":                      schema_datadog_operator_api_datadoghq_v2alpha1_CustomConfig(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DaemonSetStatus":                   schema_datadog_operator_api_datadoghq_v2alpha1_DaemonSetStatus(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogAgent":                      schema_datadog_operator_api_datadoghq_v2alpha1_DatadogAgent(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogAgentGenericContainer":      schema_datadog_operator_api_datadoghq_v2alpha1_DatadogAgentGenericContainer(ref),
@@ -27,15 +34,18 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogCredentials":                schema_datadog_operator_api_datadoghq_v2alpha1_DatadogCredentials(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogFeatures":                   schema_datadog_operator_api_datadoghq_v2alpha1_DatadogFeatures(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DeploymentStatus":                  schema_datadog_operator_api_datadoghq_v2alpha1_DeploymentStatus(ref),
-		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DogstatsdFeatureConfig":            schema_datadog_operator_api_datadoghq_v2alpha1_DogstatsdFeatureConfig(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ErrorTrackingStandalone":           schema_datadog_operator_api_datadoghq_v2alpha1_ErrorTrackingStandalone(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.EventCollectionFeatureConfig":      schema_datadog_operator_api_datadoghq_v2alpha1_EventCollectionFeatureConfig(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.EventFilter":                       schema_datadog_operator_api_datadoghq_v2alpha1_EventFilter(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.FIPSConfig":                        schema_datadog_operator_api_datadoghq_v2alpha1_FIPSConfig(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.HelmCheckFeatureConfig":            schema_datadog_operator_api_datadoghq_v2alpha1_HelmCheckFeatureConfig(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.HelmCheckFilter":                   schema_datadog_operator_api_datadoghq_v2alpha1_HelmCheckFilter(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.KubeStateMetricsCoreFeatureConfig": schema_datadog_operator_api_datadoghq_v2alpha1_KubeStateMetricsCoreFeatureConfig(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.LocalService":                      schema_datadog_operator_api_datadoghq_v2alpha1_LocalService(ref),
-		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.MultiCustomConfig":                 schema_datadog_operator_api_datadoghq_v2alpha1_MultiCustomConfig(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.NetworkPolicyConfig":               schema_datadog_operator_api_datadoghq_v2alpha1_NetworkPolicyConfig(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.NetworkPolicyRule":                 schema_datadog_operator_api_datadoghq_v2alpha1_NetworkPolicyRule(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.NetworkPolicyRulePort":             schema_datadog_operator_api_datadoghq_v2alpha1_NetworkPolicyRulePort(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.NetworkPolicyRules":                schema_datadog_operator_api_datadoghq_v2alpha1_NetworkPolicyRules(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPFeatureConfig":                 schema_datadog_operator_api_datadoghq_v2alpha1_OTLPFeatureConfig(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPGRPCConfig":                    schema_datadog_operator_api_datadoghq_v2alpha1_OTLPGRPCConfig(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPHTTPConfig":                    schema_datadog_operator_api_datadoghq_v2alpha1_OTLPHTTPConfig(ref),
@@ -43,180 +53,319 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPReceiverConfig":                schema_datadog_operator_api_datadoghq_v2alpha1_OTLPReceiverConfig(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OrchestratorExplorerFeatureConfig": schema_datadog_operator_api_datadoghq_v2alpha1_OrchestratorExplorerFeatureConfig(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OtelCollectorFeatureConfig":        schema_datadog_operator_api_datadoghq_v2alpha1_OtelCollectorFeatureConfig(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ProductIntakeConfig":               schema_datadog_operator_api_datadoghq_v2alpha1_ProductIntakeConfig(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusOperatorConfig":          schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusOperatorConfig(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeAnnotations":       schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusScrapeAnnotations(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeAutodiscovery":     schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusScrapeAutodiscovery(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeConfig":            schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusScrapeConfig(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeEndpoint":          schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusScrapeEndpoint(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeFeatureConfig":     schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusScrapeFeatureConfig(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeService":           schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusScrapeService(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ProxyConfig":                       schema_datadog_operator_api_datadoghq_v2alpha1_ProxyConfig(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigChange":                schema_datadog_operator_api_datadoghq_v2alpha1_RemoteConfigChange(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigConfiguration":         schema_datadog_operator_api_datadoghq_v2alpha1_RemoteConfigConfiguration(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigHistoryEntry":          schema_datadog_operator_api_datadoghq_v2alpha1_RemoteConfigHistoryEntry(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigProposal":              schema_datadog_operator_api_datadoghq_v2alpha1_RemoteConfigProposal(ref),
+		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RolloutPolicy":                     schema_datadog_operator_api_datadoghq_v2alpha1_RolloutPolicy(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.SeccompConfig":                     schema_datadog_operator_api_datadoghq_v2alpha1_SeccompConfig(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.SecretBackendConfig":               schema_datadog_operator_api_datadoghq_v2alpha1_SecretBackendConfig(ref),
 		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.SecretBackendRolesConfig":          schema_datadog_operator_api_datadoghq_v2alpha1_SecretBackendRolesConfig(ref),
-		"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.UnixDomainSocketConfig":            schema_datadog_operator_api_datadoghq_v2alpha1_UnixDomainSocketConfig(ref),
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_CSPMHostBenchmarksConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_AdditionalEndpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "CSPMHostBenchmarksConfig contains configuration for host benchmarks.",
+				Description: "AdditionalEndpoint defines an additional intake endpoint and the API key used to send data to it.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enabled": {
+					"url": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enabled enables host benchmarks. Default: true",
-							Type:        []string{"boolean"},
+							Description: "URL is the intake URL.",
+							Default:     "",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"apiKeySecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIKeySecret is the secret containing the API key of the Datadog organization.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.SecretConfig"),
+						},
+					},
 				},
+				Required: []string{"url", "apiKeySecret"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.SecretConfig"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_CoreConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_AdditionalEndpointsConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "CoreConfig exposes the otel collector configs relevant to the core agent.",
+				Description: "AdditionalEndpointsConfig defines the additional intake endpoints of each product.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enabled": {
+					"metrics": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Enabled marks otelcollector as enabled in core agent.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Metrics is the list of additional endpoints for metrics, for example `https://app.datadoghq.eu`.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AdditionalEndpoint"),
+									},
+								},
+							},
 						},
 					},
-					"extensionURL": {
+					"logs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Extension URL provides the URL of the ddflareextension to the core agent.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Logs is the list of additional endpoints for logs, for example `agent-http-intake.logs.datadoghq.eu:443`.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AdditionalEndpoint"),
+									},
+								},
+							},
 						},
 					},
-					"extensionTimeout": {
+					"apm": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Extension URL provides the timout of the ddflareextension to the core agent.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "APM is the list of additional endpoints for traces, for example `https://trace.agent.datadoghq.eu`.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AdditionalEndpoint"),
+									},
+								},
+							},
+						},
+					},
+					"process": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Process is the list of additional endpoints for processes and containers, for example `https://process.datadoghq.eu`.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AdditionalEndpoint"),
+									},
+								},
+							},
+						},
+					},
+					"orchestrator": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Orchestrator is the list of additional endpoints for the Orchestrator Explorer, for example `https://orchestrator.datadoghq.eu`.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AdditionalEndpoint"),
+									},
+								},
+							},
 						},
 					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AdditionalEndpoint"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_CustomConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_CSPMHostBenchmarksConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "CustomConfig provides a place for custom configuration of the Agent or Cluster Agent, corresponding to datadog.yaml, system-probe.yaml, security-agent.yaml or datadog-cluster.yaml. The configuration can be provided in the ConfigData field as raw data, or referenced in a ConfigMap. Note: `ConfigData` and `ConfigMap` cannot be set together.",
+				Description: "CSPMHostBenchmarksConfig contains configuration for host benchmarks.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"configData": {
+					"enabled": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ConfigData corresponds to the configuration file content.",
-							Type:        []string{"string"},
+							Description: "Enabled enables host benchmarks. Default: true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_CertManagerConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CertManagerConfig defines how the certificates are issued with cert-manager.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled enables the issuance of the certificates with cert-manager. Default: false",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"configMap": {
+					"issuerRef": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ConfigMap references an existing ConfigMap with the configuration file content.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ConfigMapConfig"),
+							Description: "IssuerRef is the cert-manager issuer of the certificates. When not set, the operator creates a self-signed certificate authority issuer.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CertManagerIssuerReference"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ConfigMapConfig"},
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CertManagerIssuerReference"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_DaemonSetStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_CertManagerIssuerReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "DaemonSetStatus defines the observed state of Agent running as DaemonSet.",
+				Description: "CertManagerIssuerReference references a cert-manager issuer.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"desired": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Number of desired pods in the DaemonSet.",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Name is the name of the issuer.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"current": {
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Number of current pods in the DaemonSet.",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Kind is the kind of the issuer: `Issuer` (default) or `ClusterIssuer`.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"ready": {
+					"group": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Number of ready pods in the DaemonSet.",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Group is the API group of the issuer. Default: cert-manager.io",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"available": {
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_ClusterAgentTLSConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterAgentTLSConfig defines the certificates of the Cluster Agent.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Number of available pods in the DaemonSet.",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Enabled enables mutual TLS between the node Agents, the Cluster Checks Runners and the Cluster Agent. The operator manages a certificate authority, issues the serving certificate of the Cluster Agent and the client certificate of the Agents, and renews them before they expire. The authority also signs the certificate of the external metrics server, it is set as the CA bundle of its APIService. It requires the Agent and Cluster Agent 7.63.0 or later, it is ignored with older images. The node Agents and the Cluster Checks Runners only connect to the Cluster Agent URL over TLS once the operator verified that the Cluster Agent serves the issued certificate, they keep their default connection until then. Default: false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"upToDate": {
+					"certificateValidity": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Number of up to date pods in the DaemonSet.",
-							Default:     0,
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "CertificateValidity is the validity of the certificates issued by the operator. They are renewed once two thirds of their validity elapsed. Default: 2160h (90 days)",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"lastUpdate": {
+					"certManager": {
 						SchemaProps: spec.SchemaProps{
-							Description: "LastUpdate is the last time the status was updated.",
-							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							Description: "CertManager issues the certificates with cert-manager `Certificate` resources instead of the operator. It is ignored when the cert-manager CRDs are not installed in the cluster.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CertManagerConfig"),
 						},
 					},
-					"currentHash": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CertManagerConfig", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_ClusterAgentTLSStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterAgentTLSStatus defines the state of the certificates of the Cluster Agent.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"generation": {
 						SchemaProps: spec.SchemaProps{
-							Description: "CurrentHash is the stored hash of the DaemonSet.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Generation is incremented each time new certificates are issued, the pods using them are then restarted.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"status": {
+					"notAfter": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Status corresponds to the DaemonSet computed status.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "NotAfter is the expiration time of the current certificates.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"state": {
+					"lastRotationTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "State corresponds to the DaemonSet state.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "LastRotationTime is the last time new certificates were issued.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"daemonsetName": {
+					"clusterAgentVerified": {
 						SchemaProps: spec.SchemaProps{
-							Description: "DaemonsetName corresponds to the name of the created DaemonSet.",
-							Type:        []string{"string"},
+							Description: "ClusterAgentVerified is true once the Cluster Agent served a certificate issued by the certificate authorities. The Agents connect to the Cluster Agent over TLS only then.",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
 				},
-				Required: []string{"desired", "current", "ready", "available", "upToDate"},
 			},
 		},
 		Dependencies: []string{
@@ -224,79 +373,273 @@ func schema_datadog_operator_api_datadoghq_v2alpha1_DaemonSetStatus(ref common.R
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_DatadogAgent(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_ClusterAgentTokenRotationConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "DatadogAgent Deployment with the Datadog Operator.",
+				Description: "ClusterAgentTokenRotationConfig defines the rotation of the Cluster Agent token.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-					"apiVersion": {
+					"enabled": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
-							Type:        []string{"string"},
+							Description: "Enabled enables the periodic rotation of the generated Cluster Agent token. The Cluster Agent is first restarted to accept the new token along with the current one, then the node Agents and the Cluster Checks Runners are restarted to use it once the Cluster Agent API serves a request authenticated with it, and finally the Cluster Agent stops accepting the former token. It requires the Cluster Agent 7.63.0 or later, no rotation is started with older images. Default: false",
+							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"metadata": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
-						},
-					},
-					"spec": {
-						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogAgentSpec"),
-						},
-					},
-					"status": {
+					"interval": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogAgentStatus"),
+							Description: "Interval is the time between two rotations of the token. Default: 720h (30 days)",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogAgentSpec", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogAgentStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_DatadogAgentGenericContainer(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_ClusterAgentTokenStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "DatadogAgentGenericContainer is the generic structure describing any container's common configuration.",
+				Description: "ClusterAgentTokenStatus defines the state of the Cluster Agent token generated by the operator.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"name": {
+					"generation": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Name of the container that is overridden",
+							Description: "Generation is incremented each time the Agents switch to a new token.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastRotationTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastRotationTime is the last time the Agents switched to a new token.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the step of the rotation in progress, empty when no rotation is in progress.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"logLevel": {
+					"additionalTokenHash": {
 						SchemaProps: spec.SchemaProps{
-							Description: "LogLevel sets logging verbosity (overrides global setting). Valid log levels are: trace, debug, info, warn, error, critical, and off. Default: 'info'",
+							Description: "AdditionalTokenHash is the SHA-256 hash of the token accepted by the Cluster Agent along with the current one during a rotation: the new token before the Agents use it, then the former one while the Agents are restarted. The token itself is only stored in the token secret.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"env": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-map-keys": []interface{}{
-									"name",
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_CoreConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CoreConfig exposes the otel collector configs relevant to the core agent.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled marks otelcollector as enabled in core agent.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"extensionURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Extension URL provides the URL of the ddflareextension to the core agent.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"extensionTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Extension URL provides the timout of the ddflareextension to the core agent.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_DaemonSetStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DaemonSetStatus defines the observed state of Agent running as DaemonSet.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"desired": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of desired pods in the DaemonSet.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"current": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of current pods in the DaemonSet.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"ready": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of ready pods in the DaemonSet.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"available": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of available pods in the DaemonSet.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"upToDate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of up to date pods in the DaemonSet.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"lastUpdate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastUpdate is the last time the status was updated.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"currentHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentHash is the stored hash of the DaemonSet.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status corresponds to the DaemonSet computed status.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "State corresponds to the DaemonSet state.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"daemonsetName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DaemonsetName corresponds to the name of the created DaemonSet.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"desired", "current", "ready", "available", "upToDate"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_DatadogAgent(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogAgent Deployment with the Datadog Operator.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogAgentSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogAgentStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogAgentSpec", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogAgentStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_DatadogAgentGenericContainer(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DatadogAgentGenericContainer is the generic structure describing any container's common configuration.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the container that is overridden",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"logLevel": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LogLevel sets logging verbosity (overrides global setting). Valid log levels are: trace, debug, info, warn, error, critical, and off. Default: 'info'",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"env": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
 								},
 								"x-kubernetes-list-type": "map",
 							},
@@ -506,11 +849,68 @@ func schema_datadog_operator_api_datadoghq_v2alpha1_DatadogAgentStatus(ref commo
 							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigConfiguration"),
 						},
 					},
+					"remoteConfigProposal": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemoteConfigProposal stores the configuration received from RemoteConfig that is waiting for approval, when the Remote Configuration approval mode is Manual.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigProposal"),
+						},
+					},
+					"remoteConfigHistory": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "RemoteConfigHistory lists the last configurations received from RemoteConfig that were applied, oldest first. It is bounded by `features.remoteConfiguration.historyLimit`.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigHistoryEntry"),
+									},
+								},
+							},
+						},
+					},
+					"instrumentationTargets": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "InstrumentationTargets lists the Single Step Instrumentation targets generated from DatadogInstrumentation objects that were accepted and merged into the Cluster Agent configuration.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"clusterAgentTLS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterAgentTLS is the state of the certificates of the Cluster Agent, when its TLS is enabled.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ClusterAgentTLSStatus"),
+						},
+					},
+					"clusterAgentToken": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterAgentToken is the state of the Cluster Agent token generated by the operator.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ClusterAgentTokenStatus"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DaemonSetStatus", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DeploymentStatus", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigConfiguration", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ClusterAgentTLSStatus", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ClusterAgentTokenStatus", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DaemonSetStatus", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DeploymentStatus", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigConfiguration", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigHistoryEntry", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigProposal", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
 	}
 }
 
@@ -571,43 +971,43 @@ func schema_datadog_operator_api_datadoghq_v2alpha1_DatadogFeatures(ref common.R
 					"logCollection": {
 						SchemaProps: spec.SchemaProps{
 							Description: "LogCollection configuration.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.LogCollectionFeatureConfig"),
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.LogCollectionFeatureConfig"),
 						},
 					},
 					"liveProcessCollection": {
 						SchemaProps: spec.SchemaProps{
 							Description: "LiveProcessCollection configuration.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.LiveProcessCollectionFeatureConfig"),
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.LiveProcessCollectionFeatureConfig"),
 						},
 					},
 					"liveContainerCollection": {
 						SchemaProps: spec.SchemaProps{
 							Description: "LiveContainerCollection configuration.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.LiveContainerCollectionFeatureConfig"),
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.LiveContainerCollectionFeatureConfig"),
 						},
 					},
 					"processDiscovery": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ProcessDiscovery configuration.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ProcessDiscoveryFeatureConfig"),
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.ProcessDiscoveryFeatureConfig"),
 						},
 					},
 					"oomKill": {
 						SchemaProps: spec.SchemaProps{
 							Description: "OOMKill configuration.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OOMKillFeatureConfig"),
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.OOMKillFeatureConfig"),
 						},
 					},
 					"tcpQueueLength": {
 						SchemaProps: spec.SchemaProps{
 							Description: "TCPQueueLength configuration.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.TCPQueueLengthFeatureConfig"),
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.TCPQueueLengthFeatureConfig"),
 						},
 					},
 					"ebpfCheck": {
 						SchemaProps: spec.SchemaProps{
 							Description: "EBPFCheck configuration.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.EBPFCheckFeatureConfig"),
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.EBPFCheckFeatureConfig"),
 						},
 					},
 					"apm": {
@@ -637,19 +1037,19 @@ func schema_datadog_operator_api_datadoghq_v2alpha1_DatadogFeatures(ref common.R
 					"npm": {
 						SchemaProps: spec.SchemaProps{
 							Description: "NPM (Network Performance Monitoring) configuration.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.NPMFeatureConfig"),
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.NPMFeatureConfig"),
 						},
 					},
 					"usm": {
 						SchemaProps: spec.SchemaProps{
 							Description: "USM (Universal Service Monitoring) configuration.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.USMFeatureConfig"),
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.USMFeatureConfig"),
 						},
 					},
 					"dogstatsd": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Dogstatsd configuration.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DogstatsdFeatureConfig"),
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.DogstatsdFeatureConfig"),
 						},
 					},
 					"otlp": {
@@ -673,13 +1073,13 @@ func schema_datadog_operator_api_datadoghq_v2alpha1_DatadogFeatures(ref common.R
 					"serviceDiscovery": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ServiceDiscovery",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ServiceDiscoveryFeatureConfig"),
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.ServiceDiscoveryFeatureConfig"),
 						},
 					},
 					"gpu": {
 						SchemaProps: spec.SchemaProps{
 							Description: "GPU monitoring",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.GPUFeatureConfig"),
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.GPUFeatureConfig"),
 						},
 					},
 					"eventCollection": {
@@ -740,7 +1140,7 @@ func schema_datadog_operator_api_datadoghq_v2alpha1_DatadogFeatures(ref common.R
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.APMFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ASMFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AdmissionControllerFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AutoscalingFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CSPMFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CWSFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ClusterChecksFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DogstatsdFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.EBPFCheckFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.EventCollectionFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ExternalMetricsServerFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.GPUFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.HelmCheckFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.KubeStateMetricsCoreFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.LiveContainerCollectionFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.LiveProcessCollectionFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.LogCollectionFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.NPMFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OOMKillFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OrchestratorExplorerFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OtelCollectorFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ProcessDiscoveryFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigurationFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.SBOMFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ServiceDiscoveryFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.TCPQueueLengthFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.USMFeatureConfig"},
+			"github.com/DataDog/datadog-operator/api/datadoghq/common.DogstatsdFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/common.EBPFCheckFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/common.GPUFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/common.LiveContainerCollectionFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/common.LiveProcessCollectionFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/common.LogCollectionFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/common.NPMFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/common.OOMKillFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/common.ProcessDiscoveryFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/common.ServiceDiscoveryFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/common.TCPQueueLengthFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/common.USMFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.APMFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ASMFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AdmissionControllerFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AutoscalingFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CSPMFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CWSFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ClusterChecksFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.EventCollectionFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ExternalMetricsServerFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.HelmCheckFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.KubeStateMetricsCoreFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OrchestratorExplorerFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OtelCollectorFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigurationFeatureConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.SBOMFeatureConfig"},
 	}
 }
 
@@ -835,65 +1235,986 @@ func schema_datadog_operator_api_datadoghq_v2alpha1_DeploymentStatus(ref common.
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_DogstatsdFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_ErrorTrackingStandalone(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "DogstatsdFeatureConfig contains the Dogstatsd configuration parameters.",
+				Description: "ErrorTrackingStandalone contains the configuration for the Error Tracking standalone feature.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"originDetectionEnabled": {
+					"enabled": {
 						SchemaProps: spec.SchemaProps{
-							Description: "OriginDetectionEnabled enables origin detection for container tagging. See also: https://docs.datadoghq.com/developers/dogstatsd/unix_socket/#using-origin-detection-for-container-tagging",
+							Description: "Enables Error Tracking for backend services. Default: false",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
-					"tagCardinality": {
-						SchemaProps: spec.SchemaProps{
-							Description: "TagCardinality configures tag cardinality for the metrics collected using origin detection (`low`, `orchestrator` or `high`). See also: https://docs.datadoghq.com/getting_started/tagging/assigning_tags/?tab=containerizedenvironments#environment-variables Cardinality default: low",
-							Type:        []string{"string"},
-							Format:      "",
-						},
+				},
+			},
+		},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_EventCollectionFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EventCollectionFeatureConfig contains the Event Collection configuration.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"collectKubernetesEvents": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CollectKubernetesEvents enables Kubernetes event collection. Default: true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"unbundleEvents": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UnbundleEvents enables collection of Kubernetes events as individual events. Default: false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"collectedEventTypes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "CollectedEventTypes defines the list of events to collect when UnbundleEvents is enabled. Default: [ {\"kind\":\"Pod\",\"reasons\":[\"Failed\",\"BackOff\",\"Unhealthy\",\"FailedScheduling\",\"FailedMount\",\"FailedAttachVolume\"]}, {\"kind\":\"Node\",\"reasons\":[\"TerminatingEvictedPod\",\"NodeNotReady\",\"Rebooted\",\"HostPortConflict\"]}, {\"kind\":\"CronJob\",\"reasons\":[\"SawCompletedJob\"]} ]",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.EventTypes"),
+									},
+								},
+							},
+						},
+					},
+					"filter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Filter restricts the collected events to the ones matching all its conditions. Default: all the events are collected",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.EventFilter"),
+						},
+					},
+					"maxEventsPerRun": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxEventsPerRun is the maximum number of events sent by the Cluster Agent at each run of the check. Default: 300",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.EventFilter", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.EventTypes"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_EventFilter(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EventFilter selects Kubernetes events. The Cluster Agent applies it as field selectors when listing the events: an event is collected only if it matches all the conditions, and conditions cannot express alternatives. Field selectors cannot select events by the labels of their involved object, nor include several namespaces: involved-object label selectors and multiple included namespaces are not supported.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace restricts the collection to the events whose involved object is in this namespace. Only one namespace can be included, use `excludedNamespaces` to collect the events of all the other ones. Default: all the namespaces",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"excludedNamespaces": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ExcludedNamespaces ignores the events whose involved object is in one of these namespaces.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type restricts the collection to the events of this type. Default: all the types",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tags": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Tags are added to the events collected through this filter, in the `key:value` format.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_FIPSConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FIPSConfig contains the FIPS configuration.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enable FIPS sidecar.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The container image of the FIPS sidecar.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AgentImageConfig"),
+						},
+					},
+					"localAddress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Set the local IP address. Default: `127.0.0.1`",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port specifies which port is used by the containers to communicate to the FIPS sidecar. Default: 9803",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"portRange": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PortRange specifies the number of ports used. Default: 15",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources is the requests and limits for the FIPS sidecar container.",
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+						},
+					},
+					"useHTTPS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UseHTTPS enables HTTPS. Default: false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"customFIPSConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CustomFIPSConfig configures a custom configMap to provide the FIPS configuration. Specify custom contents for the FIPS proxy sidecar container config (/etc/datadog-fips-proxy/datadog-fips-proxy.cfg). If empty, the default FIPS proxy sidecar container config is used.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.CustomConfig"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/common.CustomConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AgentImageConfig", "k8s.io/api/core/v1.ResourceRequirements"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_HelmCheckFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HelmCheckFeatureConfig allows configuration of the Helm check feature.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled enables the Helm check. Default: false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"collectEvents": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CollectEvents set to `true` enables event collection in the Helm check (Requires Agent 7.36.0+ and Cluster Agent 1.20.0+) Default: false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"valuesAsTags": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ValuesAsTags collects Helm values from a release and uses them as tags (Requires Agent and Cluster Agent 7.40.0+). Default: {}",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"chartAnnotationsAsTags": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ChartAnnotationsAsTags collects annotations from the chart of a release and uses them as tags. The key is the chart annotation and the value is the tag name. It is rendered as the `helm_chart_annotations_as_tags` option of the check, which the Agent Helm check does not implement as of Agent 7.63: these Agent versions ignore it. Default: {}",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces filters the monitored releases by namespace. It is rendered as the `namespaces` and `excluded_namespaces` options of the check, which the Agent Helm check does not implement as of Agent 7.63: these Agent versions ignore them. When namespaces are included, the Helm check is only granted access to these namespaces, through Roles instead of a ClusterRole. The Helm check of Agent 7.63 and below watches the releases of all the namespaces and cannot list them with these narrowed permissions. Default: all the namespaces",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.HelmCheckFilter"),
+						},
+					},
+					"releases": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Releases filters the monitored releases by name. It is rendered as the `releases` and `excluded_releases` options of the check, which the Agent Helm check does not implement as of Agent 7.63: these Agent versions ignore them. Default: all the releases",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.HelmCheckFilter"),
+						},
+					},
+					"storageDriver": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageDriver restricts the monitored releases to the ones stored by this Helm 3 storage driver, `secret` or `configmap`. It is rendered as the `storage_driver` option of the check, which the Agent Helm check does not implement as of Agent 7.63: these Agent versions ignore it. The Helm check is only granted access to the Secrets or ConfigMaps storing the releases. The Helm check of Agent 7.63 and below watches both and cannot list the other ones with these narrowed permissions. Default: both",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.HelmCheckFilter"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_HelmCheckFilter(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HelmCheckFilter includes and excludes values from the Helm check.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"include": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Include is the list of values to monitor. Default: all the values",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"exclude": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Exclude is the list of values to ignore.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_KubeStateMetricsCoreFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "KubeStateMetricsCoreFeatureConfig contains the Kube State Metrics Core check feature configuration. The Kube State Metrics Core check runs in the Cluster Agent (or Cluster Check Runners). See also: https://docs.datadoghq.com/integrations/kubernetes_state_core",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled enables Kube State Metrics Core. Default: true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"conf": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conf overrides the configuration for the default Kubernetes State Metrics Core check. This must point to a ConfigMap containing a valid cluster check configuration.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.CustomConfig"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/common.CustomConfig"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_LocalService(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LocalService provides the internal traffic policy service configuration.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nameOverride": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NameOverride defines the name of the internal traffic service to target the agent running on the local node.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"forceEnableLocalService": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ForceEnableLocalService forces the creation of the internal traffic policy service to target the agent running on the local node. This parameter only applies to Kubernetes 1.21, where the feature is in alpha and is disabled by default. (On Kubernetes 1.22+, the feature entered beta and the internal traffic service is created by default, so this parameter is ignored.) Default: false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_NetworkPolicyConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyConfig provides Network Policy configuration for the agents.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"create": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Create defines whether to create a NetworkPolicy for the current deployment.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"flavor": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Flavor defines Which network policy to use: `kubernetes` (default), `cilium`, `calico` or `adminnetworkpolicy`. The `calico` flavor creates Calico GlobalNetworkPolicies through the `projectcalico.org/v3` API. The `adminnetworkpolicy` flavor restricts the egress traffic with AdminNetworkPolicies, and the ingress traffic with Kubernetes NetworkPolicies.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dnsSelectorEndpoints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "DNSSelectorEndpoints defines the cilium selector of the DNS server entity.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_NetworkPolicyRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyRule defines a network policy rule, translated to the configured network policy flavor. The traffic is allowed to (egress) or from (ingress) any of the peers of the rule, on the ports of the rule. When no peer is defined, the traffic is allowed to or from anywhere.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ports": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Ports lists the allowed ports. When empty, all the ports are allowed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.NetworkPolicyRulePort"),
+									},
+								},
+							},
+						},
+					},
+					"ipBlocks": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "IPBlocks lists the allowed IP blocks, in CIDR notation.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"domainNames": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "DomainNames lists the allowed fully qualified domain names. They are only supported by egress rules, and are not enforced by the kubernetes flavor: Kubernetes network policies can't select domain names, so the rule is restricted to its IP blocks and selectors, and skipped when it has none.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"podSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodSelector selects the allowed pods. When NamespaceSelector is not set, the pods are selected in the namespace of the DatadogAgent.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NamespaceSelector selects the namespaces of the allowed pods.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.NetworkPolicyRulePort", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_NetworkPolicyRulePort(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyRulePort defines a port allowed by a network policy rule.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port is the port number.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"protocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol is the port protocol. Default: TCP",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"port"},
+			},
+		},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_NetworkPolicyRules(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkPolicyRules defines the rules added to the network policy generated for a component.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"additionalEgress": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "AdditionalEgress lists the egress rules added to the network policy.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.NetworkPolicyRule"),
+									},
+								},
+							},
+						},
+					},
+					"additionalIngress": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "AdditionalIngress lists the ingress rules added to the network policy.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.NetworkPolicyRule"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.NetworkPolicyRule"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_OTLPFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OTLPFeatureConfig contains configuration for OTLP ingest.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"receiver": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Receiver contains configuration for the OTLP ingest receiver.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPReceiverConfig"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPReceiverConfig"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_OTLPGRPCConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OTLPGRPCConfig contains configuration for the OTLP ingest OTLP/gRPC receiver.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enable the OTLP/gRPC endpoint. Host port is enabled by default and can be disabled.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"hostPortConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enable hostPort for OTLP/gRPC Default: true",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.HostPortConfig"),
+						},
+					},
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Endpoint for OTLP/gRPC. gRPC supports several naming schemes: https://github.com/grpc/grpc/blob/master/doc/naming.md The Datadog Operator supports only 'host:port' (usually `0.0.0.0:port`). Default: `0.0.0.0:4317`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/common.HostPortConfig"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_OTLPHTTPConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OTLPHTTPConfig contains configuration for the OTLP ingest OTLP/HTTP receiver.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enable the OTLP/HTTP endpoint. Host port is enabled by default and can be disabled.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
 					},
 					"hostPortConfig": {
 						SchemaProps: spec.SchemaProps{
-							Description: "HostPortConfig contains host port configuration. Enabled Default: false Port Default: 8125",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.HostPortConfig"),
+							Description: "Enable hostPorts for OTLP/HTTP Default: true",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.HostPortConfig"),
+						},
+					},
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Endpoint for OTLP/HTTP. Default: '0.0.0.0:4318'.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/common.HostPortConfig"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_OTLPProtocolsConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OTLPProtocolsConfig contains configuration for the OTLP ingest receiver protocols.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"grpc": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GRPC contains configuration for the OTLP ingest OTLP/gRPC receiver.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPGRPCConfig"),
+						},
+					},
+					"http": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HTTP contains configuration for the OTLP ingest OTLP/HTTP receiver.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPHTTPConfig"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPGRPCConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPHTTPConfig"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_OTLPReceiverConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OTLPReceiverConfig contains configuration for the OTLP ingest receiver.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"protocols": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocols contains configuration for the OTLP ingest receiver protocols.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPProtocolsConfig"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPProtocolsConfig"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_OrchestratorExplorerFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OrchestratorExplorerFeatureConfig contains the Orchestrator Explorer check feature configuration. The Orchestrator Explorer check runs in the Process and Cluster Agents (or Cluster Check Runners). See also: https://docs.datadoghq.com/infrastructure/livecontainers/#kubernetes-resources",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled enables the Orchestrator Explorer. Default: true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"conf": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conf overrides the configuration for the default Orchestrator Explorer check. This must point to a ConfigMap containing a valid cluster check configuration.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.CustomConfig"),
+						},
+					},
+					"scrubContainers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScrubContainers enables scrubbing of sensitive container data (passwords, tokens, etc. ). Default: true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"customResources": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "`CustomResources` defines custom resources for the orchestrator explorer to collect. Each item should follow the convention `group/version/kind`. For example, `datadoghq.com/v1alpha1/datadogmetrics`.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"extraTags": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional tags to associate with the collected data in the form of `a b c`. This is a Cluster Agent option distinct from DD_TAGS that is used in the Orchestrator Explorer.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"ddUrl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Override the API endpoint for the Orchestrator Explorer. URL Default: \"https://orchestrator.datadoghq.com\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/common.CustomConfig"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_OtelCollectorFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OtelCollectorFeatureConfig contains the configuration for the otel-agent.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled enables the OTel Agent. Default: true",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"conf": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conf overrides the configuration for the default Kubernetes State Metrics Core check. This must point to a ConfigMap containing a valid cluster check configuration. When passing a configmap, file name *must* be otel-config.yaml.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.CustomConfig"),
+						},
+					},
+					"ports": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Ports contains the ports for the otel-agent. Defaults: otel-grpc:4317 / otel-http:4318. Note: setting 4317 or 4318 manually is *only* supported if name match default names (otel-grpc, otel-http). If not, this will lead to a port conflict. This limitation will be lifted once annotations support is removed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.ContainerPort"),
+									},
+								},
+							},
+						},
+					},
+					"coreConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OTelCollector Config Relevant to the Core agent",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CoreConfig"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/DataDog/datadog-operator/api/datadoghq/common.CustomConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CoreConfig", "k8s.io/api/core/v1.ContainerPort"},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_ProductIntakeConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ProductIntakeConfig defines the intake of a single product.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL replaces the Datadog intake URL of the product, the data are sent to it directly instead of through the HTTP(S) proxy. For example `proxy.example.com:3129`.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"unixDomainSocketConfig": {
+				},
+			},
+		},
+	}
+}
+
+func schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusOperatorConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PrometheusOperatorConfig configures the translation of Prometheus Operator ServiceMonitors into checks.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
 						SchemaProps: spec.SchemaProps{
-							Description: "UnixDomainSocketConfig contains socket configuration. See also: https://docs.datadoghq.com/agent/kubernetes/apm/?tab=helm#agent-environment-variables Enabled Default: true Path Default: `/var/run/datadog/dsd.socket`",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.UnixDomainSocketConfig"),
+							Description: "Enabled enables the translation of the `ServiceMonitor` objects of the cluster into endpoints checks. Bearer token Secrets of other namespaces are copied in the namespace of the DatadogAgent. `PodMonitor` objects select pods by labels, which the Agent autodiscovery cannot express: they are not translated and are reported in the operator logs. Default: false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"mapperProfiles": {
+					"serviceMonitorSelector": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Configure the Dogstasd Mapper Profiles. Can be passed as raw data or via a json encoded string in a config map. See also: https://docs.datadoghq.com/developers/dogstatsd/dogstatsd_mapper/",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CustomConfig"),
+							Description: "ServiceMonitorSelector restricts the translation to the `ServiceMonitor` objects matching this selector. Default: all the `ServiceMonitor` objects",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CustomConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.HostPortConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.UnixDomainSocketConfig"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_ErrorTrackingStandalone(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusScrapeAnnotations(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ErrorTrackingStandalone contains the configuration for the Error Tracking standalone feature.",
+				Description: "PrometheusScrapeAnnotations selects pods and services by annotations.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enabled": {
+					"include": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enables Error Tracking for backend services. Default: false",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Include selects the pods and services having all these annotations. Default: `prometheus.io/scrape: \"true\"`",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"exclude": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Exclude ignores the pods and services having any of these annotations.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
 				},
@@ -902,41 +2223,53 @@ func schema_datadog_operator_api_datadoghq_v2alpha1_ErrorTrackingStandalone(ref
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_EventCollectionFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusScrapeAutodiscovery(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "EventCollectionFeatureConfig contains the Event Collection configuration.",
+				Description: "PrometheusScrapeAutodiscovery defines the discovery rules of an OpenMetrics check configuration.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"collectKubernetesEvents": {
+					"kubernetesAnnotations": {
 						SchemaProps: spec.SchemaProps{
-							Description: "CollectKubernetesEvents enables Kubernetes event collection. Default: true",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "KubernetesAnnotations selects the pods and services by annotations.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeAnnotations"),
 						},
 					},
-					"unbundleEvents": {
+					"kubernetesContainerNames": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "UnbundleEvents enables collection of Kubernetes events as individual events. Default: false",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "KubernetesContainerNames restricts the configuration to the containers with these names.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"collectedEventTypes": {
+					"kubernetesEndpoints": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
 								"x-kubernetes-list-type": "atomic",
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "CollectedEventTypes defines the list of events to collect when UnbundleEvents is enabled. Default: [ {\"kind\":\"Pod\",\"reasons\":[\"Failed\",\"BackOff\",\"Unhealthy\",\"FailedScheduling\",\"FailedMount\",\"FailedAttachVolume\"]}, {\"kind\":\"Node\",\"reasons\":[\"TerminatingEvictedPod\",\"NodeNotReady\",\"Rebooted\",\"HostPortConflict\"]}, {\"kind\":\"CronJob\",\"reasons\":[\"SawCompletedJob\"]} ]",
+							Description: "KubernetesEndpoints runs the configuration as endpoints checks on the pods backing these services. It requires the cluster checks feature. It cannot be combined with `kubernetesAnnotations` and `kubernetesContainerNames`.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.EventTypes"),
+										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeService"),
 									},
 								},
 							},
@@ -946,102 +2279,86 @@ func schema_datadog_operator_api_datadoghq_v2alpha1_EventCollectionFeatureConfig
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.EventTypes"},
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeAnnotations", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeService"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_FIPSConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusScrapeConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "FIPSConfig contains the FIPS configuration.",
+				Description: "PrometheusScrapeConfig is an OpenMetrics check configuration with its discovery rules.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enabled": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Enable FIPS sidecar.",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"image": {
-						SchemaProps: spec.SchemaProps{
-							Description: "The container image of the FIPS sidecar.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AgentImageConfig"),
-						},
-					},
-					"localAddress": {
+					"autodiscovery": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Set the local IP address. Default: `127.0.0.1`",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Autodiscovery defines the containers or service endpoints the configuration applies to. When not set, the configuration applies to all the containers with the `prometheus.io/scrape: \"true\"` annotation.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeAutodiscovery"),
 						},
 					},
-					"port": {
+					"endpoint": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Port specifies which port is used by the containers to communicate to the FIPS sidecar. Default: 9803",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Endpoint defines where the metrics are exposed. It is required when `autodiscovery.kubernetesEndpoints` is set. When not set, the `prometheus.io/port` and `prometheus.io/path` annotations are used.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeEndpoint"),
 						},
 					},
-					"portRange": {
-						SchemaProps: spec.SchemaProps{
-							Description: "PortRange specifies the number of ports used. Default: 15",
-							Type:        []string{"integer"},
-							Format:      "int32",
+					"metrics": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
 						},
-					},
-					"resources": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Resources is the requests and limits for the FIPS sidecar container.",
-							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+							Description: "Metrics is the list of regular expressions of the metrics to collect. Default: all the metrics",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"useHTTPS": {
-						SchemaProps: spec.SchemaProps{
-							Description: "UseHTTPS enables HTTPS. Default: false",
-							Type:        []string{"boolean"},
-							Format:      "",
+					"excludeMetrics": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
 						},
-					},
-					"customFIPSConfig": {
 						SchemaProps: spec.SchemaProps{
-							Description: "CustomFIPSConfig configures a custom configMap to provide the FIPS configuration. Specify custom contents for the FIPS proxy sidecar container config (/etc/datadog-fips-proxy/datadog-fips-proxy.cfg). If empty, the default FIPS proxy sidecar container config is used.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CustomConfig"),
+							Description: "ExcludeMetrics is the list of regular expressions of the metrics to ignore.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.AgentImageConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CustomConfig", "k8s.io/api/core/v1.ResourceRequirements"},
-	}
-}
-
-func schema_datadog_operator_api_datadoghq_v2alpha1_HelmCheckFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "HelmCheckFeatureConfig allows configuration of the Helm check feature.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"enabled": {
+					"namespace": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enabled enables the Helm check. Default: false",
-							Type:        []string{"boolean"},
+							Description: "Namespace is the prefix added to all the metrics.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"collectEvents": {
+					"rawMetricPrefix": {
 						SchemaProps: spec.SchemaProps{
-							Description: "CollectEvents set to `true` enables event collection in the Helm check (Requires Agent 7.36.0+ and Cluster Agent 1.20.0+) Default: false",
-							Type:        []string{"boolean"},
+							Description: "RawMetricPrefix is removed from the exposed metric names.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"valuesAsTags": {
+					"renameLabels": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ValuesAsTags collects Helm values from a release and uses them as tags (Requires Agent and Cluster Agent 7.40.0+). Default: {}",
+							Description: "RenameLabels renames the labels of the exposed metrics before they are converted to tags.",
 							Type:        []string{"object"},
 							AdditionalProperties: &spec.SchemaOrBool{
 								Allows: true,
@@ -1055,348 +2372,345 @@ func schema_datadog_operator_api_datadoghq_v2alpha1_HelmCheckFeatureConfig(ref c
 							},
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_datadog_operator_api_datadoghq_v2alpha1_KubeStateMetricsCoreFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "KubeStateMetricsCoreFeatureConfig contains the Kube State Metrics Core check feature configuration. The Kube State Metrics Core check runs in the Cluster Agent (or Cluster Check Runners). See also: https://docs.datadoghq.com/integrations/kubernetes_state_core",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"enabled": {
+					"tags": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Enabled enables Kube State Metrics Core. Default: true",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Tags are added to all the metrics.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"conf": {
+					"bearerTokenSecret": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Conf overrides the configuration for the default Kubernetes State Metrics Core check. This must point to a ConfigMap containing a valid cluster check configuration.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CustomConfig"),
+							Description: "BearerTokenSecret is a Secret key containing the bearer token used to authenticate to the endpoint. The Secret must be in the namespace of the DatadogAgent.",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout is the timeout of the requests to the endpoint, in seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CustomConfig"},
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeAutodiscovery", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeEndpoint", "k8s.io/api/core/v1.SecretKeySelector"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_LocalService(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusScrapeEndpoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "LocalService provides the internal traffic policy service configuration.",
+				Description: "PrometheusScrapeEndpoint defines where the metrics are exposed.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"nameOverride": {
+					"port": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NameOverride defines the name of the internal traffic service to target the agent running on the local node.",
+							Description: "Port is the name or the number of the port exposing the metrics.",
+							Ref:         ref("k8s.io/apimachinery/pkg/util/intstr.IntOrString"),
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the HTTP path exposing the metrics. Default: `/metrics`",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"forceEnableLocalService": {
+					"scheme": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ForceEnableLocalService forces the creation of the internal traffic policy service to target the agent running on the local node. This parameter only applies to Kubernetes 1.21, where the feature is in alpha and is disabled by default. (On Kubernetes 1.22+, the feature entered beta and the internal traffic service is created by default, so this parameter is ignored.) Default: false",
-							Type:        []string{"boolean"},
+							Description: "Scheme is the HTTP scheme used to scrape the metrics, `http` or `https`. Default: `http`",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
+				Required: []string{"port"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/util/intstr.IntOrString"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_MultiCustomConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusScrapeFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "MultiCustomConfig provides a place for custom configuration of the Agent or Cluster Agent, corresponding to /confd/*.yaml. The configuration can be provided in the ConfigDataMap field as raw data, or referenced in a single ConfigMap. Note: `ConfigDataMap` and `ConfigMap` cannot be set together.",
+				Description: "PrometheusScrapeFeatureConfig allows configuration of the Prometheus Autodiscovery feature.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"configDataMap": {
+					"enabled": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ConfigDataMap corresponds to the content of the configuration files. The key should be the filename the contents get mounted to; for instance check.py or check.yaml.",
-							Type:        []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
+							Description: "Enable autodiscovery of pods and services exposing Prometheus metrics. Default: false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"configMap": {
+					"enableServiceEndpoints": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ConfigMap references an existing ConfigMap with the content of the configuration files.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ConfigMapConfig"),
+							Description: "EnableServiceEndpoints enables generating dedicated checks for service endpoints. Default: false",
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ConfigMapConfig"},
-	}
-}
-
-func schema_datadog_operator_api_datadoghq_v2alpha1_NetworkPolicyConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "NetworkPolicyConfig provides Network Policy configuration for the agents.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"create": {
+					"additionalConfigs": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Create defines whether to create a NetworkPolicy for the current deployment.",
-							Type:        []string{"boolean"},
+							Description: "AdditionalConfigs allows adding advanced Prometheus check configurations with custom discovery rules.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"flavor": {
+					"version": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Flavor defines Which network policy to use.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Version specifies the version of the OpenMetrics check. Default: 2",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"dnsSelectorEndpoints": {
+					"configs": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
 								"x-kubernetes-list-type": "atomic",
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "DNSSelectorEndpoints defines the cilium selector of the DNS server entity.",
+							Description: "Configs are typed OpenMetrics check configurations with custom discovery rules. They are added to the ones defined in `additionalConfigs`.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeConfig"),
 									},
 								},
 							},
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
-	}
-}
-
-func schema_datadog_operator_api_datadoghq_v2alpha1_OTLPFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "OTLPFeatureConfig contains configuration for OTLP ingest.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"receiver": {
+					"prometheusOperator": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Receiver contains configuration for the OTLP ingest receiver.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPReceiverConfig"),
+							Description: "PrometheusOperator configures the translation of Prometheus Operator `ServiceMonitor` objects into checks.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusOperatorConfig"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPReceiverConfig"},
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusOperatorConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.PrometheusScrapeConfig"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_OTLPGRPCConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusScrapeService(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "OTLPGRPCConfig contains configuration for the OTLP ingest OTLP/gRPC receiver.",
+				Description: "PrometheusScrapeService references a Kubernetes service.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enabled": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enable the OTLP/gRPC endpoint. Host port is enabled by default and can be disabled.",
-							Type:        []string{"boolean"},
+							Description: "Name is the name of the service.",
+							Default:     "",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"hostPortConfig": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Enable hostPort for OTLP/gRPC Default: true",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.HostPortConfig"),
-						},
-					},
-					"endpoint": {
+					"namespace": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Endpoint for OTLP/gRPC. gRPC supports several naming schemes: https://github.com/grpc/grpc/blob/master/doc/naming.md The Datadog Operator supports only 'host:port' (usually `0.0.0.0:port`). Default: `0.0.0.0:4317`.",
+							Description: "Namespace is the namespace of the service.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
+				Required: []string{"name", "namespace"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.HostPortConfig"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_OTLPHTTPConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_ProxyConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "OTLPHTTPConfig contains configuration for the OTLP ingest OTLP/HTTP receiver.",
+				Description: "ProxyConfig defines the HTTP(S) proxy configuration.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enabled": {
+					"http": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enable the OTLP/HTTP endpoint. Host port is enabled by default and can be disabled.",
-							Type:        []string{"boolean"},
+							Description: "HTTP is the proxy URL used for HTTP requests, for example `http://proxy.example.com:3128`.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"hostPortConfig": {
+					"https": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enable hostPorts for OTLP/HTTP Default: true",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.HostPortConfig"),
+							Description: "HTTPS is the proxy URL used for HTTPS requests, for example `http://proxy.example.com:3128`.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"endpoint": {
+					"noProxy": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Endpoint for OTLP/HTTP. Default: '0.0.0.0:4318'.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "NoProxy is the list of hosts that should bypass the proxy.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"usernameSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UsernameSecret is the secret containing the username used to authenticate against the proxy. The username is inserted as is in the proxy URLs, it must be URL-encoded (for example `j%40doe` for `j@doe`). A ProxyCredentialsNotEncoded warning event is recorded on the DatadogAgent when it is not.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.SecretConfig"),
+						},
+					},
+					"passwordSecret": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PasswordSecret is the secret containing the password used to authenticate against the proxy. The password is inserted as is in the proxy URLs, it must be URL-encoded (for example `p%40ss` for `p@ss`). A ProxyCredentialsNotEncoded warning event is recorded on the DatadogAgent when it is not.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.SecretConfig"),
+						},
+					},
+					"logs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Logs overrides the intake URL the logs are sent to (`DD_LOGS_CONFIG_LOGS_DD_URL`), for example a proxy relaying them to Datadog.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ProductIntakeConfig"),
+						},
+					},
+					"apm": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APM overrides the intake URL the traces are sent to (`DD_APM_DD_URL`), for example a proxy relaying them to Datadog.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ProductIntakeConfig"),
+						},
+					},
+					"process": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Process overrides the intake URL the processes and containers are sent to (`DD_PROCESS_CONFIG_PROCESS_DD_URL`), for example a proxy relaying them to Datadog.",
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ProductIntakeConfig"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.HostPortConfig"},
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.ProductIntakeConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.SecretConfig"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_OTLPProtocolsConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_RemoteConfigChange(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "OTLPProtocolsConfig contains configuration for the OTLP ingest receiver protocols.",
+				Description: "RemoteConfigChange is a feature field set by a configuration received from RemoteConfig.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"grpc": {
+					"path": {
 						SchemaProps: spec.SchemaProps{
-							Description: "GRPC contains configuration for the OTLP ingest OTLP/gRPC receiver.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPGRPCConfig"),
+							Description: "Path is the path of the field in the DatadogAgent features, for example `sbom.containerImage.enabled`.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"http": {
+					"value": {
 						SchemaProps: spec.SchemaProps{
-							Description: "HTTP contains configuration for the OTLP ingest OTLP/HTTP receiver.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPHTTPConfig"),
+							Description: "Value is the JSON encoded value of the field, `null` when the configuration unsets it.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"path", "value"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPGRPCConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPHTTPConfig"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_OTLPReceiverConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_RemoteConfigConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "OTLPReceiverConfig contains configuration for the OTLP ingest receiver.",
+				Description: "RemoteConfigConfiguration stores the configuration received from RemoteConfig.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"protocols": {
+					"features": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Protocols contains configuration for the OTLP ingest receiver protocols.",
-							Default:     map[string]interface{}{},
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPProtocolsConfig"),
+							Ref: ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogFeatures"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.OTLPProtocolsConfig"},
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogFeatures"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_OrchestratorExplorerFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_RemoteConfigHistoryEntry(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "OrchestratorExplorerFeatureConfig contains the Orchestrator Explorer check feature configuration. The Orchestrator Explorer check runs in the Process and Cluster Agents (or Cluster Check Runners). See also: https://docs.datadoghq.com/infrastructure/livecontainers/#kubernetes-resources",
+				Description: "RemoteConfigHistoryEntry records a configuration received from RemoteConfig that was applied.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enabled": {
+					"configID": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enabled enables the Orchestrator Explorer. Default: true",
-							Type:        []string{"boolean"},
+							Description: "ConfigID is the ID of the applied configuration.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"conf": {
+					"appliedAt": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Conf overrides the configuration for the default Orchestrator Explorer check. This must point to a ConfigMap containing a valid cluster check configuration.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CustomConfig"),
+							Description: "AppliedAt is the time the configuration was applied.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"scrubContainers": {
+					"approvalMode": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ScrubContainers enables scrubbing of sensitive container data (passwords, tokens, etc. ). Default: true",
-							Type:        []string{"boolean"},
+							Description: "ApprovalMode is the approval mode the configuration was applied with.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"customResources": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-list-type": "set",
-							},
-						},
-						SchemaProps: spec.SchemaProps{
-							Description: "`CustomResources` defines custom resources for the orchestrator explorer to collect. Each item should follow the convention `group/version/kind`. For example, `datadoghq.com/v1alpha1/datadogmetrics`.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: "",
-										Type:    []string{"string"},
-										Format:  "",
-									},
-								},
-							},
-						},
-					},
-					"extraTags": {
+					"features": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
 								"x-kubernetes-list-type": "set",
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Additional tags to associate with the collected data in the form of `a b c`. This is a Cluster Agent option distinct from DD_TAGS that is used in the Orchestrator Explorer.",
+							Description: "Features lists the features changed by the configuration.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -1409,131 +2723,119 @@ func schema_datadog_operator_api_datadoghq_v2alpha1_OrchestratorExplorerFeatureC
 							},
 						},
 					},
-					"ddUrl": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Override the API endpoint for the Orchestrator Explorer. URL Default: \"https://orchestrator.datadoghq.com\".",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
 				},
+				Required: []string{"appliedAt"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CustomConfig"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_OtelCollectorFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_RemoteConfigProposal(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "OtelCollectorFeatureConfig contains the configuration for the otel-agent.",
+				Description: "RemoteConfigProposal stores a configuration received from RemoteConfig that is waiting for approval.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enabled": {
+					"configID": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enabled enables the OTel Agent. Default: true",
-							Type:        []string{"boolean"},
+							Description: "ConfigID is the ID of the received configuration.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"conf": {
+					"receivedAt": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Conf overrides the configuration for the default Kubernetes State Metrics Core check. This must point to a ConfigMap containing a valid cluster check configuration. When passing a configmap, file name *must* be otel-config.yaml.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CustomConfig"),
+							Description: "ReceivedAt is the time the configuration was received.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"ports": {
+					"changes": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
-								"x-kubernetes-list-type": "atomic",
+								"x-kubernetes-list-map-keys": []interface{}{
+									"path",
+								},
+								"x-kubernetes-list-type": "map",
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Ports contains the ports for the otel-agent. Defaults: otel-grpc:4317 / otel-http:4318. Note: setting 4317 or 4318 manually is *only* supported if name match default names (otel-grpc, otel-http). If not, this will lead to a port conflict. This limitation will be lifted once annotations support is removed.",
+							Description: "Changes lists the feature fields the received configuration sets differently than the applied configuration. They are applied on top of the applied configuration once the proposal is approved.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("k8s.io/api/core/v1.ContainerPort"),
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigChange"),
 									},
 								},
 							},
 						},
 					},
-					"coreConfig": {
-						SchemaProps: spec.SchemaProps{
-							Description: "OTelCollector Config Relevant to the Core agent",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CoreConfig"),
-						},
-					},
 				},
+				Required: []string{"receivedAt"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CoreConfig", "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CustomConfig", "k8s.io/api/core/v1.ContainerPort"},
+			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.RemoteConfigChange", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_datadog_operator_api_datadoghq_v2alpha1_PrometheusScrapeFeatureConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_datadog_operator_api_datadoghq_v2alpha1_RolloutPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "PrometheusScrapeFeatureConfig allows configuration of the Prometheus Autodiscovery feature.",
+				Description: "RolloutPolicy defines the thresholds used to consider a rollout of the agents complete.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"enabled": {
+					"agentCompletionPercent": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Enable autodiscovery of pods and services exposing Prometheus metrics. Default: false",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "AgentCompletionPercent is the percentage of up-to-date pods above which the rollout of an Agent DaemonSet is complete. Default: 95",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"enableServiceEndpoints": {
+					"agentMaxPodsNotUpToDate": {
 						SchemaProps: spec.SchemaProps{
-							Description: "EnableServiceEndpoints enables generating dedicated checks for service endpoints. Default: false",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "AgentMaxPodsNotUpToDate is the number of pods not up to date below which the rollout of an Agent DaemonSet is complete, regardless of AgentCompletionPercent. Default: 10",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"additionalConfigs": {
+					"clusterAgentMinUpToDate": {
 						SchemaProps: spec.SchemaProps{
-							Description: "AdditionalConfigs allows adding advanced Prometheus check configurations with custom discovery rules.",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "ClusterAgentMinUpToDate is the number of up-to-date Cluster Agent pods above which its rollout is complete. Default: 1",
+							Type:        []string{"integer"},
+							Format:      "int32",
 						},
 					},
-					"version": {
+					"clusterChecksRunnerMinUpToDate": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Version specifies the version of the OpenMetrics check. Default: 2",
+							Description: "ClusterChecksRunnerMinUpToDate is the number of up-to-date Cluster Checks Runner pods above which its rollout is complete. Default: 2",
 							Type:        []string{"integer"},
 							Format:      "int32",
 						},
 					},
-				},
-			},
-		},
-	}
-}
-
-func schema_datadog_operator_api_datadoghq_v2alpha1_RemoteConfigConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "RemoteConfigConfiguration stores the configuration received from RemoteConfig.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"features": {
+					"maxContainerRestarts": {
 						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogFeatures"),
+							Description: "MaxContainerRestarts is the maximum number of restarts of a container of the agent pods during the rollout. The rollout fails when a container restarts more often. The restarts that happened before the check started are not counted. Restarts are not checked when unset.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout is the duration after which an incomplete rollout fails. Default: 2h",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.DatadogFeatures"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -1554,14 +2856,14 @@ func schema_datadog_operator_api_datadoghq_v2alpha1_SeccompConfig(ref common.Ref
 					"customProfile": {
 						SchemaProps: spec.SchemaProps{
 							Description: "CustomProfile specifies a ConfigMap containing a custom Seccomp Profile. ConfigMap data must either have the key `system-probe-seccomp.json` or CustomProfile.Items must include a corev1.KeytoPath that maps the key to the path `system-probe-seccomp.json`.",
-							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CustomConfig"),
+							Ref:         ref("github.com/DataDog/datadog-operator/api/datadoghq/common.CustomConfig"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1.CustomConfig"},
+			"github.com/DataDog/datadog-operator/api/datadoghq/common.CustomConfig"},
 	}
 }
 
@@ -1666,30 +2968,3 @@ func schema_datadog_operator_api_datadoghq_v2alpha1_SecretBackendRolesConfig(ref
 		},
 	}
 }
-
-func schema_datadog_operator_api_datadoghq_v2alpha1_UnixDomainSocketConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "UnixDomainSocketConfig contains the Unix Domain Socket configuration.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"enabled": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Enabled enables Unix Domain Socket. Default: true",
-							Type:        []string{"boolean"},
-							Format:      "",
-						},
-					},
-					"path": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Path defines the socket path used when enabled.",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
-				},
-			},
-		},
-	}
-}
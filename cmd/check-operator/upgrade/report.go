@@ -0,0 +1,158 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package upgrade
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+const (
+	reportFormatJUnit = "junit"
+	reportFormatJSON  = "json"
+
+	junitSuiteName = "check-operator upgrade"
+)
+
+// checkResult is the result of a rollout check
+type checkResult struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+// report is the outcome of the rollout checks
+type report struct {
+	Namespace    string        `json:"namespace"`
+	DatadogAgent string        `json:"datadogAgent"`
+	Passed       bool          `json:"passed"`
+	Error        string        `json:"error,omitempty"`
+	Duration     string        `json:"duration"`
+	Checks       []checkResult `json:"checks"`
+
+	duration time.Duration
+}
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// newReport builds the report from the results of the last checks
+func (o *Options) newReport(err error, duration time.Duration) report {
+	r := report{
+		Namespace:    o.UserNamespace,
+		DatadogAgent: o.datadogAgentName,
+		Passed:       err == nil,
+		Duration:     duration.Round(time.Second).String(),
+		Checks:       o.results,
+		duration:     duration,
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	if r.Checks == nil {
+		r.Checks = []checkResult{}
+	}
+	return r
+}
+
+// writeReport writes the report in the requested format, if any
+func (o *Options) writeReport(r report) error {
+	if o.reportFormat == "" {
+		return nil
+	}
+
+	out := o.Out
+	if o.reportFile != "" {
+		f, err := os.Create(o.reportFile)
+		if err != nil {
+			return fmt.Errorf("unable to create the report file, err: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	return renderReport(out, r, o.reportFormat)
+}
+
+func renderReport(out io.Writer, r report, format string) error {
+	var data []byte
+	var err error
+	switch format {
+	case reportFormatJUnit:
+		data, err = xml.MarshalIndent(toJUnit(r), "", "  ")
+		data = append([]byte(xml.Header), data...)
+	case reportFormatJSON:
+		data, err = json.MarshalIndent(r, "", "  ")
+	default:
+		return fmt.Errorf("invalid report format %s", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+// toJUnit converts the report to a JUnit test suite, with a test case per check.
+// A failure without failed check, for instance a timeout, is reported on a dedicated test case.
+func toJUnit(r report) junitTestSuites {
+	className := fmt.Sprintf("%s.%s", r.Namespace, r.DatadogAgent)
+	suite := junitTestSuite{
+		Name: junitSuiteName,
+		Time: fmt.Sprintf("%.3f", r.duration.Seconds()),
+	}
+
+	for _, check := range r.Checks {
+		testCase := junitTestCase{Name: check.Name, ClassName: className}
+		if check.Passed {
+			testCase.SystemOut = check.Message
+		} else {
+			testCase.Failure = &junitFailure{Message: check.Message, Text: check.Message}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	if !r.Passed && suite.Failures == 0 {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      "Rollout",
+			ClassName: className,
+			Failure:   &junitFailure{Message: r.Error, Text: r.Error},
+		})
+		suite.Failures++
+	}
+	suite.Tests = len(suite.TestCases)
+
+	return junitTestSuites{Suites: []junitTestSuite{suite}}
+}
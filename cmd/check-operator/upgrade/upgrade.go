@@ -13,12 +13,14 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	"github.com/DataDog/datadog-operator/pkg/plugin/common"
 )
@@ -27,14 +29,19 @@ import (
 type Options struct {
 	genericclioptions.IOStreams
 	common.Options
-	args               []string
-	datadogAgentName   string
-	checkPeriod        time.Duration
-	checkTimeout       time.Duration
-	agentCompletionPct float64
-	agentCompletionMin int32
-	dcaMinUpToDate     int32
-	clcMinUpToDate     int32
+	args                 []string
+	datadogAgentName     string
+	checkPeriod          time.Duration
+	checkTimeout         time.Duration
+	agentCompletionPct   float64
+	agentCompletionMin   int32
+	dcaMinUpToDate       int32
+	clcMinUpToDate       int32
+	maxContainerRestarts *int32
+	restartBaseline      map[string]int32
+	reportFormat         string
+	reportFile           string
+	results              []checkResult
 }
 
 // NewOptions provides an instance of Options with default values.
@@ -51,6 +58,7 @@ func NewOptions(streams genericclioptions.IOStreams) *Options {
 
 	opts.SetConfigFlags()
 
+	// Deprecated: the thresholds should be set in the DatadogAgent `spec.rolloutPolicy`, which takes precedence.
 	if val, found := os.LookupEnv("AGENT_COMPLETION_PCT"); found {
 		if iVal, err := strconv.ParseFloat(val, 64); err == nil {
 			opts.agentCompletionPct = iVal / 100
@@ -91,7 +99,7 @@ func NewCmdUpgrade(streams genericclioptions.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "upgrade [DatadogAgent name]",
 		Short:        "Wait until the rolling-update of all agent components is finished",
-		Example:      "./check-operator upgrade datadog-agent",
+		Example:      "./check-operator upgrade datadog-agent --report-format junit --report-file /tmp/report.xml",
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
 			if err := o.Complete(c, args); err != nil {
@@ -105,6 +113,9 @@ func NewCmdUpgrade(streams genericclioptions.IOStreams) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&o.reportFormat, "report-format", "", "Format of the rollout report, one of junit or json. No report is written when empty")
+	cmd.Flags().StringVar(&o.reportFile, "report-file", "", "Path of the rollout report file. The report is written to the standard output when empty")
+
 	o.ConfigFlags.AddFlags(cmd.Flags())
 
 	return cmd
@@ -126,10 +137,16 @@ func (o *Options) Validate() error {
 		return fmt.Errorf("the DatadogAgent name is required")
 	}
 
+	switch o.reportFormat {
+	case "", reportFormatJUnit, reportFormatJSON:
+	default:
+		return fmt.Errorf("invalid report format %s, must be one of junit or json", o.reportFormat)
+	}
+
 	return nil
 }
 
-func (o *Options) getV2Status() (common.StatusWrapper, error) {
+func (o *Options) getDatadogAgent() (*v2alpha1.DatadogAgent, error) {
 	datadogAgent := &v2alpha1.DatadogAgent{}
 	err := o.Client.Get(context.TODO(), client.ObjectKey{Namespace: o.UserNamespace, Name: o.datadogAgentName}, datadogAgent)
 	if err != nil {
@@ -140,7 +157,43 @@ func (o *Options) getV2Status() (common.StatusWrapper, error) {
 
 		return nil, fmt.Errorf("unable to get DatadogAgent, err: %w", err)
 	}
-	return common.NewV2StatusWrapper(datadogAgent), nil
+	return datadogAgent, nil
+}
+
+// getAgentPods returns the pods of all the components of the DatadogAgent
+func (o *Options) getAgentPods() ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	err := o.Client.List(context.TODO(), podList, client.InNamespace(o.UserNamespace), client.MatchingLabels{apicommon.AgentDeploymentNameLabelKey: o.datadogAgentName})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the DatadogAgent pods, err: %w", err)
+	}
+	return podList.Items, nil
+}
+
+// applyRolloutPolicy overrides the default thresholds with the ones set in the DatadogAgent
+func (o *Options) applyRolloutPolicy(policy *v2alpha1.RolloutPolicy) {
+	if policy == nil {
+		return
+	}
+
+	if policy.AgentCompletionPercent != nil {
+		o.agentCompletionPct = float64(*policy.AgentCompletionPercent) / 100
+	}
+	if policy.AgentMaxPodsNotUpToDate != nil {
+		o.agentCompletionMin = *policy.AgentMaxPodsNotUpToDate
+	}
+	if policy.ClusterAgentMinUpToDate != nil {
+		o.dcaMinUpToDate = *policy.ClusterAgentMinUpToDate
+	}
+	if policy.ClusterChecksRunnerMinUpToDate != nil {
+		o.clcMinUpToDate = *policy.ClusterChecksRunnerMinUpToDate
+	}
+	if policy.MaxContainerRestarts != nil {
+		o.maxContainerRestarts = policy.MaxContainerRestarts
+	}
+	if policy.Timeout != nil {
+		o.checkTimeout = policy.Timeout.Duration
+	}
 }
 
 func isReconcileError(conditions []metav1.Condition) error {
@@ -161,45 +214,72 @@ func isReconcileError(conditions []metav1.Condition) error {
 
 // Run use to run the command.
 func (o *Options) Run() error {
+	start := time.Now()
+	err := o.run()
+
+	if reportErr := o.writeReport(o.newReport(err, time.Since(start))); reportErr != nil {
+		if err == nil {
+			return reportErr
+		}
+		o.printOutf("unable to write the report: %v", reportErr)
+	}
+
+	return err
+}
+
+func (o *Options) run() error {
 	o.printOutf("Start checking rolling-update status")
-	checkFunc := func() (bool, error) {
-		var agentDone, dcaDone, ccrDone, reconcileError bool
-		var status common.StatusWrapper
-		o.printOutf("v2alpha1 is available")
-		status, err := o.getV2Status()
 
+	datadogAgent, err := o.getDatadogAgent()
+	if errors.IsNotFound(err) {
+		o.printOutf("Got a not found error while getting %s/%s. Assuming this DatadogAgent CR has never been deployed in this environment", o.UserNamespace, o.datadogAgentName)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to get the DatadogAgent, err:%w", err)
+	}
+	o.applyRolloutPolicy(datadogAgent.Spec.RolloutPolicy)
+
+	if o.maxContainerRestarts != nil {
+		// Only the restarts happening during the rollout are checked
+		pods, err := o.getAgentPods()
+		if err != nil {
+			return err
+		}
+		o.setRestartBaseline(pods)
+	}
+
+	checkFunc := func() (bool, error) {
+		datadogAgent, err := o.getDatadogAgent()
 		if errors.IsNotFound(err) {
-			o.printOutf("Got a not found error while getting %s/%s. Assuming this DatadogAgent CR has never been deployed in this environment", o.UserNamespace, o.datadogAgentName)
+			o.printOutf("Got a not found error while getting %s/%s. Assuming this DatadogAgent CR has been deleted", o.UserNamespace, o.datadogAgentName)
 			return true, nil
 		} else if err != nil {
 			return false, fmt.Errorf("unable to get the DatadogAgent.status, err:%w", err)
 		}
+		status := common.NewV2StatusWrapper(datadogAgent)
 
-		if err = isReconcileError(status.GetStatusCondition()); err != nil {
-			o.printOutf("received a reconcile error: %v", err)
-			reconcileError = true
-		}
-
-		if !agentDone {
-			agentDone = o.isAgentDone(status.GetAgentStatus())
-		}
-
-		if !dcaDone {
-			dcaDone = o.isDeploymentDone(status.GetClusterAgentStatus(), o.dcaMinUpToDate, "Cluster Agent")
-		}
+		o.results = o.checkComponents(status)
 
-		if !ccrDone {
-			ccrDone = o.isDeploymentDone(status.GetClusterChecksRunnerStatus(), o.clcMinUpToDate, "Cluster Check Runner")
+		if o.maxContainerRestarts != nil {
+			pods, err := o.getAgentPods()
+			if err != nil {
+				return false, err
+			}
+			result := o.checkRestarts(pods)
+			o.results = append(o.results, result)
+			if !result.Passed {
+				return false, fmt.Errorf("%s", result.Message)
+			}
 		}
 
-		if agentDone && dcaDone && ccrDone && !reconcileError {
+		if allPassed(o.results) {
 			return true, nil
 		}
 
 		o.printOutf("One or multiple components are still upgrading...")
 
-		if status.GetAgentStatus() != nil {
-			o.printOutf("[Agent] nb pods: %d, nb updated pods: %d", status.GetAgentStatus().Current, status.GetAgentStatus().UpToDate)
+		for _, agentStatus := range agentStatuses(status) {
+			o.printOutf("[Agent %s] nb pods: %d, nb updated pods: %d", agentStatus.DaemonsetName, agentStatus.Current, agentStatus.UpToDate)
 		}
 
 		if status.GetClusterAgentStatus() != nil {
@@ -216,32 +296,111 @@ func (o *Options) Run() error {
 	return wait.Poll(o.checkPeriod, o.checkTimeout, checkFunc)
 }
 
-func (o *Options) isAgentDone(status *v2alpha1.DaemonSetStatus) bool {
-	if status == nil {
-		return true
+// checkComponents checks the reconcile conditions and the rollout of each component
+func (o *Options) checkComponents(status common.StatusWrapper) []checkResult {
+	results := []checkResult{{Name: "Reconcile", Passed: true}}
+	if err := isReconcileError(status.GetStatusCondition()); err != nil {
+		o.printOutf("received a reconcile error: %v", err)
+		results[0] = checkResult{Name: "Reconcile", Message: err.Error()}
 	}
 
-	if float64(status.UpToDate) > float64(status.Current)*o.agentCompletionPct || status.Current-status.UpToDate <= o.agentCompletionMin {
-		o.printOutf("[Agent] upgrade is now finished (reached threshold): %d, nb updated pods: %d, threshold pct: %f, min threshold: %d", status.Current, status.UpToDate, o.agentCompletionPct, o.agentCompletionMin)
+	// The Agent runs one DaemonSet per DatadogAgentProfile, each of them must be rolled out
+	for _, agentStatus := range agentStatuses(status) {
+		results = append(results, o.isAgentDone(agentStatus))
+	}
 
-		return true
+	if status.GetClusterAgentStatus() != nil {
+		results = append(results, o.isDeploymentDone(status.GetClusterAgentStatus(), o.dcaMinUpToDate, "Cluster Agent"))
 	}
 
-	return false
+	if status.GetClusterChecksRunnerStatus() != nil {
+		results = append(results, o.isDeploymentDone(status.GetClusterChecksRunnerStatus(), o.clcMinUpToDate, "Cluster Check Runner"))
+	}
+
+	return results
 }
 
-func (o *Options) isDeploymentDone(status *v2alpha1.DeploymentStatus, minUpToDate int32, component string) bool {
-	if status == nil {
-		return true
+// agentStatuses returns the status of each Agent DaemonSet, or the combined status when they are not available
+func agentStatuses(status common.StatusWrapper) []*v2alpha1.DaemonSetStatus {
+	var statuses []*v2alpha1.DaemonSetStatus
+	for _, agentStatus := range status.GetAgentListStatus() {
+		if agentStatus != nil {
+			statuses = append(statuses, agentStatus)
+		}
 	}
+	if len(statuses) == 0 && status.GetAgentStatus() != nil {
+		statuses = append(statuses, status.GetAgentStatus())
+	}
+	return statuses
+}
+
+func (o *Options) isAgentDone(status *v2alpha1.DaemonSetStatus) checkResult {
+	component := "Agent"
+	if status.DaemonsetName != "" {
+		component = fmt.Sprintf("Agent %s", status.DaemonsetName)
+	}
+	message := fmt.Sprintf("nb pods: %d, nb updated pods: %d, threshold pct: %f, min threshold: %d", status.Current, status.UpToDate, o.agentCompletionPct, o.agentCompletionMin)
+
+	if float64(status.UpToDate) > float64(status.Current)*o.agentCompletionPct || status.Current-status.UpToDate <= o.agentCompletionMin {
+		o.printOutf("[%s] upgrade is now finished (reached threshold): %s", component, message)
+
+		return checkResult{Name: component, Passed: true, Message: message}
+	}
+
+	return checkResult{Name: component, Message: message}
+}
+
+func (o *Options) isDeploymentDone(status *v2alpha1.DeploymentStatus, minUpToDate int32, component string) checkResult {
+	message := fmt.Sprintf("nb pods: %d, nb updated pods: %d, min up-to-date threshold: %d", status.Replicas, status.UpdatedReplicas, minUpToDate)
 
 	if status.UpdatedReplicas >= minUpToDate {
-		o.printOutf("[%s] upgrade is now finished (reached threshold): %d, nb updated pods: %d, min up-to-date threshold: %d", component, status.Replicas, status.UpdatedReplicas, o.dcaMinUpToDate)
+		o.printOutf("[%s] upgrade is now finished (reached threshold): %s", component, message)
 
-		return true
+		return checkResult{Name: component, Passed: true, Message: message}
 	}
 
-	return false
+	return checkResult{Name: component, Message: message}
+}
+
+// setRestartBaseline records the restart count of the containers of the DatadogAgent pods when the check starts
+func (o *Options) setRestartBaseline(pods []corev1.Pod) {
+	o.restartBaseline = map[string]int32{}
+	for _, pod := range pods {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			o.restartBaseline[containerKey(pod, containerStatus.Name)] = containerStatus.RestartCount
+		}
+	}
+}
+
+// checkRestarts checks that no container of the DatadogAgent pods restarted more than allowed since the check started
+func (o *Options) checkRestarts(pods []corev1.Pod) checkResult {
+	result := checkResult{Name: "Container restarts", Passed: true, Message: fmt.Sprintf("max restarts threshold: %d", *o.maxContainerRestarts)}
+	for _, pod := range pods {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			// the pods created during the rollout have no baseline
+			restarts := containerStatus.RestartCount - o.restartBaseline[containerKey(pod, containerStatus.Name)]
+			if restarts > *o.maxContainerRestarts {
+				o.printOutf("[%s] container %s restarted %d times", pod.Name, containerStatus.Name, restarts)
+				result.Passed = false
+				result.Message = fmt.Sprintf("container %s of pod %s restarted %d times, max restarts threshold: %d", containerStatus.Name, pod.Name, restarts, *o.maxContainerRestarts)
+			}
+		}
+	}
+
+	return result
+}
+
+func containerKey(pod corev1.Pod, containerName string) string {
+	return fmt.Sprintf("%s/%s", pod.UID, containerName)
+}
+
+func allPassed(results []checkResult) bool {
+	for _, result := range results {
+		if !result.Passed {
+			return false
+		}
+	}
+	return true
 }
 
 func (o *Options) printOutf(format string, a ...interface{}) {
@@ -0,0 +1,201 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package upgrade
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/utils/ptr"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/pkg/plugin/common"
+)
+
+func newTestOptions() *Options {
+	return NewOptions(genericclioptions.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}})
+}
+
+func Test_applyRolloutPolicy(t *testing.T) {
+	o := newTestOptions()
+	o.applyRolloutPolicy(&v2alpha1.RolloutPolicy{
+		AgentCompletionPercent:  ptr.To[int32](80),
+		ClusterAgentMinUpToDate: ptr.To[int32](3),
+		MaxContainerRestarts:    ptr.To[int32](2),
+		Timeout:                 &metav1.Duration{Duration: 10 * time.Minute},
+	})
+
+	assert.Equal(t, 0.8, o.agentCompletionPct)
+	assert.Equal(t, int32(10), o.agentCompletionMin)
+	assert.Equal(t, int32(3), o.dcaMinUpToDate)
+	assert.Equal(t, int32(2), o.clcMinUpToDate)
+	assert.Equal(t, ptr.To[int32](2), o.maxContainerRestarts)
+	assert.Equal(t, 10*time.Minute, o.checkTimeout)
+}
+
+func Test_checkComponents(t *testing.T) {
+	tests := []struct {
+		name   string
+		status v2alpha1.DatadogAgentStatus
+		want   []checkResult
+	}{
+		{
+			name: "profile daemonset not rolled out",
+			status: v2alpha1.DatadogAgentStatus{
+				Agent: &v2alpha1.DaemonSetStatus{Current: 130, UpToDate: 110},
+				AgentList: []*v2alpha1.DaemonSetStatus{
+					{DaemonsetName: "datadog-agent", Current: 100, UpToDate: 100},
+					{DaemonsetName: "datadog-agent-profile", Current: 30, UpToDate: 10},
+				},
+				ClusterAgent: &v2alpha1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2},
+			},
+			want: []checkResult{
+				{Name: "Reconcile", Passed: true},
+				{Name: "Agent datadog-agent", Passed: true},
+				{Name: "Agent datadog-agent-profile"},
+				{Name: "Cluster Agent", Passed: true},
+			},
+		},
+		{
+			name: "combined agent status only",
+			status: v2alpha1.DatadogAgentStatus{
+				Agent: &v2alpha1.DaemonSetStatus{Current: 100, UpToDate: 96},
+				Conditions: []metav1.Condition{
+					{Type: "ClusterAgentReconcile", Status: metav1.ConditionFalse, Message: "invalid spec"},
+				},
+				ClusterChecksRunner: &v2alpha1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 1},
+			},
+			want: []checkResult{
+				{Name: "Reconcile"},
+				{Name: "Agent", Passed: true},
+				{Name: "Cluster Check Runner"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newTestOptions()
+			results := o.checkComponents(common.NewV2StatusWrapper(&v2alpha1.DatadogAgent{Status: tt.status}))
+
+			require.Len(t, results, len(tt.want))
+			for i := range results {
+				assert.Equal(t, tt.want[i].Name, results[i].Name)
+				assert.Equal(t, tt.want[i].Passed, results[i].Passed, results[i].Message)
+			}
+		})
+	}
+}
+
+func Test_checkRestarts(t *testing.T) {
+	pod := func(name string, restarts int32) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name)},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "agent", RestartCount: restarts}},
+			},
+		}
+	}
+
+	o := newTestOptions()
+	o.maxContainerRestarts = ptr.To[int32](3)
+
+	assert.True(t, o.checkRestarts([]corev1.Pod{pod("agent-a", 0), pod("agent-b", 3)}).Passed)
+
+	result := o.checkRestarts([]corev1.Pod{pod("agent-a", 0), pod("agent-b", 4)})
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.Message, "agent-b")
+}
+
+func Test_checkRestarts_preExistingRestarts(t *testing.T) {
+	pod := func(name string, restarts int32) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name)},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "agent", RestartCount: restarts}},
+			},
+		}
+	}
+
+	o := newTestOptions()
+	o.maxContainerRestarts = ptr.To[int32](3)
+	// agent-a restarted before the rollout started
+	o.setRestartBaseline([]corev1.Pod{pod("agent-a", 10)})
+
+	assert.True(t, o.checkRestarts([]corev1.Pod{pod("agent-a", 10), pod("agent-b", 0)}).Passed)
+	assert.True(t, o.checkRestarts([]corev1.Pod{pod("agent-a", 13), pod("agent-b", 3)}).Passed)
+
+	result := o.checkRestarts([]corev1.Pod{pod("agent-a", 14), pod("agent-b", 3)})
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.Message, "container agent of pod agent-a restarted 4 times")
+
+	// the pods replacing agent-a during the rollout are checked from their creation
+	result = o.checkRestarts([]corev1.Pod{pod("agent-c", 4)})
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.Message, "agent-c")
+}
+
+func Test_renderReport(t *testing.T) {
+	o := newTestOptions()
+	o.UserNamespace = "datadog"
+	o.datadogAgentName = "datadog-agent"
+	o.results = []checkResult{
+		{Name: "Reconcile", Passed: true},
+		{Name: "Agent datadog-agent", Message: "nb pods: 10, nb updated pods: 2"},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		err      error
+		contains []string
+	}{
+		{
+			name:   "junit with failed check",
+			format: reportFormatJUnit,
+			err:    fmt.Errorf("timed out waiting for the condition"),
+			contains: []string{
+				`<testsuite name="check-operator upgrade" tests="2" failures="1"`,
+				`<testcase name="Agent datadog-agent" classname="datadog.datadog-agent">`,
+				`<failure message="nb pods: 10, nb updated pods: 2">`,
+			},
+		},
+		{
+			name:   "json",
+			format: reportFormatJSON,
+			contains: []string{
+				`"datadogAgent": "datadog-agent"`,
+				`"passed": true`,
+				`"name": "Agent datadog-agent"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			require.NoError(t, renderReport(out, o.newReport(tt.err, time.Minute), tt.format))
+			for _, s := range tt.contains {
+				assert.Contains(t, out.String(), s)
+			}
+		})
+	}
+}
+
+func Test_toJUnit_timeoutWithoutFailedCheck(t *testing.T) {
+	suites := toJUnit(report{Namespace: "datadog", DatadogAgent: "datadog-agent", Error: "unable to get the DatadogAgent"})
+
+	require.Len(t, suites.Suites, 1)
+	assert.Equal(t, 1, suites.Suites[0].Tests)
+	assert.Equal(t, 1, suites.Suites[0].Failures)
+	assert.Equal(t, "Rollout", suites.Suites[0].TestCases[0].Name)
+}
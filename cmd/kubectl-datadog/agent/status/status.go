@@ -0,0 +1,516 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/yaml"
+
+	"github.com/DataDog/datadog-operator/pkg/agentprofile"
+	"github.com/DataDog/datadog-operator/pkg/plugin/common"
+)
+
+const (
+	maxParallel = 10
+
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+
+	// Anomalies reported by the Agents
+	anomalyForwarderErrors    = "forwarder-errors"
+	anomalyInvalidAPIKey      = "invalid-api-key"
+	anomalyCheckErrors        = "check-errors"
+	anomalyDogStatsDDrops     = "dogstatsd-drops"
+	anomalyLogsErrors         = "logs-errors"
+	anomalyAPMDrops           = "apm-drops"
+	anomalyStatusNotCollected = "status-not-collected"
+
+	noneValue = "<none>"
+)
+
+// nodePoolLabelKeys are the node labels holding the node pool name, by order of precedence
+var nodePoolLabelKeys = []string{
+	"cloud.google.com/gke-nodepool",
+	"eks.amazonaws.com/nodegroup",
+	"alpha.eksctl.io/nodegroup-name",
+	"kubernetes.azure.com/agentpool",
+	"agentpool",
+	"karpenter.sh/nodepool",
+}
+
+var (
+	output        string
+	statusExample = `
+  # summarize the status of the running Agents
+  %[1]s status
+
+  # output the status of the running Agents in JSON
+  %[1]s status -o json
+`
+)
+
+// options provides information required by agent status command
+type options struct {
+	genericclioptions.IOStreams
+	common.Options
+	args       []string
+	restConfig *restclient.Config
+}
+
+// newOptions provides an instance of options with default values
+func newOptions(streams genericclioptions.IOStreams) *options {
+	o := &options{
+		IOStreams: streams,
+	}
+	o.SetConfigFlags()
+
+	return o
+}
+
+// New provides a cobra command wrapping options for "status" sub command
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+	cmd := &cobra.Command{
+		Use:          "status [flags]",
+		Short:        "Summarize the status of the Agents across the fleet",
+		Example:      fmt.Sprintf(statusExample, "kubectl datadog agent"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+
+			if err := o.validate(); err != nil {
+				return err
+			}
+
+			return o.run(c)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", outputTable, "Output format, one of table, json or yaml")
+
+	o.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command
+func (o *options) complete(cmd *cobra.Command, args []string) error {
+	o.args = args
+	var err error
+	o.restConfig, err = o.ConfigFlags.ToRawKubeConfigLoader().ClientConfig()
+	if err != nil {
+		return fmt.Errorf("unable to instantiate restConfig: %w", err)
+	}
+
+	return o.Init(cmd)
+}
+
+// validate ensures that all required arguments and flag values are provided
+func (o *options) validate() error {
+	switch output {
+	case outputTable, outputJSON, outputYAML:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %s, must be one of table, json or yaml", output)
+	}
+}
+
+// run runs the status command
+func (o *options) run(cmd *cobra.Command) error {
+	pods, err := o.getPods(metav1.ListOptions{LabelSelector: common.AgentLabel})
+	if err != nil {
+		return fmt.Errorf("unable to get Agent pods: %w", err)
+	}
+	clcPods, err := o.getPods(metav1.ListOptions{LabelSelector: common.ClcRunnerLabel})
+	if err != nil {
+		return fmt.Errorf("unable to get Agent pods: %w", err)
+	}
+	pods = append(pods, clcPods...)
+
+	// The nodes are used to group the Agents by node pool and profile
+	nodes := map[string]corev1.Node{}
+	nodeList, err := o.Clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		cmd.PrintErrln(fmt.Sprintf("Unable to list nodes, Agents are not grouped by node pool and profile: %v", err))
+	} else {
+		for _, node := range nodeList.Items {
+			nodes[node.Name] = node
+		}
+	}
+
+	statuses := make([]PodStatus, 0, len(pods))
+	mutex := &sync.Mutex{}
+	podChan := make(chan corev1.Pod, maxParallel)
+	var wg sync.WaitGroup
+	for i := 0; i < maxParallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pod := range podChan {
+				status := o.getPodStatus(pod, nodes[pod.Spec.NodeName])
+				mutex.Lock()
+				statuses = append(statuses, status)
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning {
+			cmd.PrintErrln(fmt.Sprintf("Ignoring pod: %s, phase: %s", pod.Name, pod.Status.Phase))
+			continue
+		}
+		podChan <- pod
+	}
+	close(podChan)
+	wg.Wait()
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Pod < statuses[j].Pod })
+	fleet := FleetStatus{
+		Pods:   statuses,
+		Groups: groupAnomalies(statuses),
+	}
+
+	return render(o.Out, fleet, output)
+}
+
+// getPodStatus collects and summarizes the status of an Agent pod
+func (o *options) getPodStatus(pod corev1.Pod, node corev1.Node) PodStatus {
+	status := PodStatus{
+		Pod:      pod.Name,
+		Node:     pod.Spec.NodeName,
+		NodePool: nodePool(node),
+		Profile:  node.Labels[agentprofile.ProfileLabelKey],
+	}
+
+	container := common.AgentLabelValue
+	if isCLCRunner(pod) {
+		container = common.ClcRunnerLabelValue
+	}
+
+	statusCmd := []string{"bash", "-c", "DD_LOG_LEVEL=off agent status --json"}
+	stdOut, stdErr, err := o.execInPod(&pod, statusCmd, container)
+	if err == nil && stdErr != "" {
+		err = fmt.Errorf("%s", stdErr)
+	}
+	if err != nil {
+		status.Error = err.Error()
+		status.Anomalies = []string{anomalyStatusNotCollected}
+		return status
+	}
+
+	agentStatus := AgentStatus{}
+	if err = json.Unmarshal([]byte(stdOut), &agentStatus); err != nil {
+		status.Error = fmt.Sprintf("unable to parse the Agent status: %v", err)
+		status.Anomalies = []string{anomalyStatusNotCollected}
+		return status
+	}
+
+	summarize(&status, agentStatus)
+	return status
+}
+
+// summarize fills a pod status with the summary of the Agent status and its anomalies
+func summarize(status *PodStatus, agentStatus AgentStatus) {
+	// Forwarder health
+	status.Forwarder = ForwarderSummary{
+		TransactionsSuccess: agentStatus.ForwarderStats.Transactions.Success,
+		TransactionsErrors:  agentStatus.ForwarderStats.Transactions.Errors,
+		TransactionsDropped: agentStatus.ForwarderStats.Transactions.Dropped,
+	}
+	for key, failure := range agentStatus.ForwarderStats.APIKeyFailure {
+		status.Forwarder.APIKeyFailures = append(status.Forwarder.APIKeyFailures, fmt.Sprintf("%s: %s", key, failure))
+	}
+	sort.Strings(status.Forwarder.APIKeyFailures)
+
+	// Check runner errors
+	for _, check := range agentStatus.RunnerStats.Checks {
+		for checkName, stats := range check {
+			if stats.LastError == "" {
+				continue
+			}
+			errMessage := stats.LastError
+			lastError := []CheckError{}
+			if err := json.Unmarshal([]byte(stats.LastError), &lastError); err == nil && len(lastError) > 0 {
+				errMessage = lastError[0].Message
+			}
+			status.Checks.Errors = append(status.Checks.Errors, fmt.Sprintf("%s: %s", checkName, errMessage))
+		}
+	}
+	sort.Strings(status.Checks.Errors)
+
+	// DogStatsD packet drops
+	dogstatsd := agentStatus.DogstatsdStats
+	status.DogStatsD = DogStatsDSummary{
+		Packets:             dogstatsd.UDPPackets + dogstatsd.UDSPackets,
+		PacketReadingErrors: dogstatsd.UDPPacketReadingErrors + dogstatsd.UDSPacketReadingErrors,
+		ParseErrors:         dogstatsd.MetricParseErrors + dogstatsd.EventParseErrors + dogstatsd.ServiceCheckParseErrors,
+	}
+
+	// Log tailers
+	status.Logs = LogsSummary{
+		Running: agentStatus.LogsStats.IsRunning,
+		Errors:  append([]string(nil), agentStatus.LogsStats.Errors...),
+	}
+	for _, integration := range agentStatus.LogsStats.Integrations {
+		for _, source := range integration.Sources {
+			status.Logs.Tailers += len(source.Inputs)
+			if strings.HasPrefix(source.Status, "Error") {
+				status.Logs.Errors = append(status.Logs.Errors, fmt.Sprintf("%s: %s", integration.Name, source.Status))
+			}
+		}
+	}
+
+	// APM receiver stats
+	for _, receiver := range agentStatus.APMStats.Receiver {
+		status.APM.TracesReceived += receiver.TracesReceived
+		status.APM.SpansReceived += receiver.SpansReceived
+		status.APM.SpansDropped += receiver.SpansDropped
+		for _, dropped := range receiver.TracesDropped {
+			status.APM.TracesDropped += dropped
+		}
+	}
+
+	status.Anomalies = anomalies(*status)
+}
+
+// anomalies returns the anomalies of a pod status
+func anomalies(status PodStatus) []string {
+	var anomalies []string
+	if status.Forwarder.TransactionsErrors > 0 || status.Forwarder.TransactionsDropped > 0 {
+		anomalies = append(anomalies, anomalyForwarderErrors)
+	}
+	if len(status.Forwarder.APIKeyFailures) > 0 {
+		anomalies = append(anomalies, anomalyInvalidAPIKey)
+	}
+	if len(status.Checks.Errors) > 0 {
+		anomalies = append(anomalies, anomalyCheckErrors)
+	}
+	if status.DogStatsD.PacketReadingErrors > 0 || status.DogStatsD.ParseErrors > 0 {
+		anomalies = append(anomalies, anomalyDogStatsDDrops)
+	}
+	if len(status.Logs.Errors) > 0 {
+		anomalies = append(anomalies, anomalyLogsErrors)
+	}
+	if status.APM.TracesDropped > 0 || status.APM.SpansDropped > 0 {
+		anomalies = append(anomalies, anomalyAPMDrops)
+	}
+	return anomalies
+}
+
+// groupAnomalies counts the anomalies of the Agents by node pool and profile
+func groupAnomalies(statuses []PodStatus) []GroupSummary {
+	groups := map[[2]string]*GroupSummary{}
+	for _, status := range statuses {
+		key := [2]string{status.NodePool, status.Profile}
+		group, found := groups[key]
+		if !found {
+			group = &GroupSummary{NodePool: status.NodePool, Profile: status.Profile}
+			groups[key] = group
+		}
+		group.Pods++
+		if len(status.Anomalies) == 0 {
+			continue
+		}
+		group.PodsWithAnomalies++
+		if group.Anomalies == nil {
+			group.Anomalies = map[string]int{}
+		}
+		for _, anomaly := range status.Anomalies {
+			group.Anomalies[anomaly]++
+		}
+	}
+
+	summaries := make([]GroupSummary, 0, len(groups))
+	for _, group := range groups {
+		summaries = append(summaries, *group)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].NodePool != summaries[j].NodePool {
+			return summaries[i].NodePool < summaries[j].NodePool
+		}
+		return summaries[i].Profile < summaries[j].Profile
+	})
+	return summaries
+}
+
+// render writes the fleet status in the given format
+func render(out io.Writer, fleet FleetStatus, format string) error {
+	switch format {
+	case outputJSON:
+		data, err := json.MarshalIndent(fleet, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	case outputYAML:
+		data, err := yaml.Marshal(fleet)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	default:
+		renderTables(out, fleet)
+		return nil
+	}
+}
+
+// renderTables writes the pod statuses and the anomalies by group as tables
+func renderTables(out io.Writer, fleet FleetStatus) {
+	podTable := newTable(out, []string{"Pod", "Node", "Node Pool", "Profile", "Forwarder Errors", "Check Errors", "DogStatsD Drops", "Log Tailers", "Log Errors", "APM Drops", "Anomalies"})
+	for _, status := range fleet.Pods {
+		anomalies := strings.Join(status.Anomalies, ",")
+		if status.Error != "" {
+			anomalies = fmt.Sprintf("%s (%s)", anomalies, status.Error)
+		}
+		podTable.Append([]string{
+			status.Pod,
+			status.Node,
+			valueOrNone(status.NodePool),
+			valueOrNone(status.Profile),
+			strconv.FormatInt(status.Forwarder.TransactionsErrors+status.Forwarder.TransactionsDropped, 10),
+			strconv.Itoa(len(status.Checks.Errors)),
+			strconv.FormatInt(status.DogStatsD.PacketReadingErrors+status.DogStatsD.ParseErrors, 10),
+			strconv.Itoa(status.Logs.Tailers),
+			strconv.Itoa(len(status.Logs.Errors)),
+			strconv.FormatInt(status.APM.TracesDropped+status.APM.SpansDropped, 10),
+			valueOrNone(anomalies),
+		})
+	}
+	podTable.Render()
+
+	fmt.Fprintln(out)
+
+	groupTable := newTable(out, []string{"Node Pool", "Profile", "Pods", "Pods With Anomalies", "Anomalies"})
+	for _, group := range fleet.Groups {
+		anomalies := make([]string, 0, len(group.Anomalies))
+		for anomaly, count := range group.Anomalies {
+			anomalies = append(anomalies, fmt.Sprintf("%s=%d", anomaly, count))
+		}
+		sort.Strings(anomalies)
+		groupTable.Append([]string{
+			valueOrNone(group.NodePool),
+			valueOrNone(group.Profile),
+			strconv.Itoa(group.Pods),
+			strconv.Itoa(group.PodsWithAnomalies),
+			valueOrNone(strings.Join(anomalies, ",")),
+		})
+	}
+	groupTable.Render()
+}
+
+func newTable(out io.Writer, header []string) *tablewriter.Table {
+	table := tablewriter.NewWriter(out)
+	table.SetHeader(header)
+	table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderLine(false)
+	return table
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return noneValue
+	}
+	return value
+}
+
+// nodePool returns the node pool of a node, based on the well-known node pool labels
+func nodePool(node corev1.Node) string {
+	for _, key := range nodePoolLabelKeys {
+		if value := node.Labels[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// execInPod exec a command in an Agent pod
+func (o *options) execInPod(pod *corev1.Pod, cmd []string, container string) (string, string, error) {
+	req := o.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(o.UserNamespace).
+		SubResource("exec")
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return "", "", fmt.Errorf("error adding to scheme: %w", err)
+	}
+
+	parameterCodec := runtime.NewParameterCodec(scheme)
+	req.VersionedParams(&corev1.PodExecOptions{
+		Command:   cmd,
+		Container: container,
+		Stdin:     false,
+		Stdout:    true,
+		Stderr:    true,
+		TTY:       false,
+	}, parameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(o.restConfig, "POST", req.URL())
+	if err != nil {
+		return "", "", err
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = exec.Stream(remotecommand.StreamOptions{
+		Stdin:  nil,
+		Stdout: &stdout,
+		Stderr: &stderr,
+		Tty:    false,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return stdout.String(), stderr.String(), nil
+}
+
+// getPods returns a list of the pods by ListOptions
+func (o *options) getPods(opts metav1.ListOptions) ([]corev1.Pod, error) {
+	podList, err := o.Clientset.CoreV1().Pods(o.UserNamespace).List(context.TODO(), opts)
+	if err != nil {
+		return []corev1.Pod{}, err
+	}
+
+	return podList.Items, nil
+}
+
+func isCLCRunner(pod corev1.Pod) bool {
+	if value, found := pod.GetLabels()[common.ComponentLabelKey]; found && value == common.ClcRunnerLabelValue {
+		return true
+	}
+
+	return false
+}
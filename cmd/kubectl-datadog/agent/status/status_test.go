@@ -0,0 +1,207 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_summarize(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusJSON string
+		want       PodStatus
+	}{
+		{
+			name:       "healthy agent",
+			statusJSON: healthyStatus,
+			want: PodStatus{
+				Forwarder: ForwarderSummary{TransactionsSuccess: 120},
+				DogStatsD: DogStatsDSummary{Packets: 30},
+				Logs:      LogsSummary{Running: true, Tailers: 2},
+				APM:       APMSummary{TracesReceived: 10, SpansReceived: 50},
+			},
+		},
+		{
+			name:       "agent with anomalies",
+			statusJSON: unhealthyStatus,
+			want: PodStatus{
+				Forwarder: ForwarderSummary{
+					TransactionsSuccess: 100,
+					TransactionsErrors:  3,
+					TransactionsDropped: 1,
+					APIKeyFailures:      []string{"API key ending with abcde: API Key invalid"},
+				},
+				Checks: ChecksSummary{Errors: []string{
+					"cri: permanent failure in criutil: retry number exceeded",
+					"redisdb:766ed21d64724d11: You must specify a host/port couple",
+				}},
+				DogStatsD: DogStatsDSummary{Packets: 30, PacketReadingErrors: 2, ParseErrors: 1},
+				Logs: LogsSummary{
+					Running: true,
+					Tailers: 1,
+					Errors:  []string{"nginx: Error: could not find any file matching pattern"},
+				},
+				APM: APMSummary{TracesReceived: 10, TracesDropped: 4, SpansReceived: 50},
+				Anomalies: []string{
+					anomalyForwarderErrors,
+					anomalyInvalidAPIKey,
+					anomalyCheckErrors,
+					anomalyDogStatsDDrops,
+					anomalyLogsErrors,
+					anomalyAPMDrops,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentStatus := AgentStatus{}
+			require.NoError(t, json.Unmarshal([]byte(tt.statusJSON), &agentStatus))
+
+			got := PodStatus{}
+			summarize(&got, agentStatus)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_groupAnomalies(t *testing.T) {
+	statuses := []PodStatus{
+		{Pod: "agent-1", NodePool: "pool-b"},
+		{Pod: "agent-2", NodePool: "pool-a", Profile: "gpu", Anomalies: []string{anomalyCheckErrors, anomalyAPMDrops}},
+		{Pod: "agent-3", NodePool: "pool-a", Profile: "gpu", Anomalies: []string{anomalyCheckErrors}},
+		{Pod: "agent-4", NodePool: "pool-a"},
+	}
+
+	want := []GroupSummary{
+		{NodePool: "pool-a", Pods: 1},
+		{
+			NodePool:          "pool-a",
+			Profile:           "gpu",
+			Pods:              2,
+			PodsWithAnomalies: 2,
+			Anomalies:         map[string]int{anomalyCheckErrors: 2, anomalyAPMDrops: 1},
+		},
+		{NodePool: "pool-b", Pods: 1},
+	}
+
+	assert.Equal(t, want, groupAnomalies(statuses))
+}
+
+func Test_nodePool(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   string
+	}{
+		{
+			name:   "gke node pool",
+			labels: map[string]string{"cloud.google.com/gke-nodepool": "default-pool"},
+			want:   "default-pool",
+		},
+		{
+			name:   "eks node group",
+			labels: map[string]string{"eks.amazonaws.com/nodegroup": "workers", "karpenter.sh/nodepool": "spot"},
+			want:   "workers",
+		},
+		{
+			name:   "unknown node pool",
+			labels: map[string]string{"kubernetes.io/os": "linux"},
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: tt.labels}}
+			assert.Equal(t, tt.want, nodePool(node))
+		})
+	}
+}
+
+func Test_render(t *testing.T) {
+	fleet := FleetStatus{
+		Pods: []PodStatus{
+			{Pod: "agent-1", Node: "node-1", NodePool: "pool-a", Anomalies: []string{anomalyCheckErrors}},
+		},
+		Groups: []GroupSummary{
+			{NodePool: "pool-a", Pods: 1, PodsWithAnomalies: 1, Anomalies: map[string]int{anomalyCheckErrors: 1}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		contains []string
+	}{
+		{
+			name:     "json",
+			format:   outputJSON,
+			contains: []string{`"pod": "agent-1"`, `"check-errors": 1`},
+		},
+		{
+			name:     "yaml",
+			format:   outputYAML,
+			contains: []string{"pod: agent-1", "check-errors: 1"},
+		},
+		{
+			name:     "table",
+			format:   outputTable,
+			contains: []string{"agent-1", "<none>", "check-errors=1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			require.NoError(t, render(out, fleet, tt.format))
+			for _, s := range tt.contains {
+				assert.Contains(t, out.String(), s)
+			}
+		})
+	}
+}
+
+const healthyStatus = `{
+  "forwarderStats": {"Transactions": {"Success": 120, "Errors": 0, "Dropped": 0}},
+  "runnerStats": {"Checks": {"cpu": {"cpu": {"LastError": ""}}}},
+  "dogstatsdStats": {"UdpPackets": 20, "UdsPackets": 10},
+  "logsStats": {
+    "is_running": true,
+    "integrations": [
+      {"name": "redis", "sources": [{"type": "file", "status": "OK", "inputs": ["/var/log/redis-1.log", "/var/log/redis-2.log"]}]}
+    ]
+  },
+  "apmStats": {"receiver": [{"Lang": "go", "TracesReceived": 10, "SpansReceived": 50}]}
+}`
+
+const unhealthyStatus = `{
+  "forwarderStats": {
+    "Transactions": {"Success": 100, "Errors": 3, "Dropped": 1},
+    "APIKeyFailure": {"API key ending with abcde": "API Key invalid"}
+  },
+  "runnerStats": {
+    "Checks": {
+      "cri": {"cri": {"LastError": "[{\"message\":\"permanent failure in criutil: retry number exceeded\",\"traceback\":\"\"}]"}},
+      "redisdb": {"redisdb:766ed21d64724d11": {"LastError": "You must specify a host/port couple"}}
+    }
+  },
+  "dogstatsdStats": {"UdpPackets": 30, "UdpPacketReadingErrors": 2, "MetricParseErrors": 1},
+  "logsStats": {
+    "is_running": true,
+    "integrations": [
+      {"name": "nginx", "sources": [{"type": "file", "status": "Error: could not find any file matching pattern", "inputs": []}]},
+      {"name": "redis", "sources": [{"type": "file", "status": "OK", "inputs": ["/var/log/redis.log"]}]}
+    ]
+  },
+  "apmStats": {"receiver": [{"Lang": "python", "TracesReceived": 10, "TracesDropped": {"DecodingError": 3, "Timeout": 1}, "SpansReceived": 50}]}
+}`
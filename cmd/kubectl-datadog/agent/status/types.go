@@ -0,0 +1,154 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package status
+
+// AgentStatus represents the subset of the `agent status --json` output used by the command
+type AgentStatus struct {
+	ForwarderStats ForwarderStats `json:"forwarderStats"`
+	RunnerStats    RunnerStats    `json:"runnerStats"`
+	DogstatsdStats DogstatsdStats `json:"dogstatsdStats"`
+	LogsStats      LogsStats      `json:"logsStats"`
+	APMStats       APMStats       `json:"apmStats"`
+}
+
+// ForwarderStats holds forwarder stats
+type ForwarderStats struct {
+	Transactions  Transactions      `json:"Transactions"`
+	APIKeyStatus  map[string]string `json:"APIKeyStatus"`
+	APIKeyFailure map[string]string `json:"APIKeyFailure"`
+}
+
+// Transactions holds forwarder transactions stats
+type Transactions struct {
+	Success int64 `json:"Success"`
+	Errors  int64 `json:"Errors"`
+	Dropped int64 `json:"Dropped"`
+}
+
+// RunnerStats holds check runner stats
+type RunnerStats struct {
+	Checks map[string]map[string]CheckStats `json:"Checks"`
+}
+
+// CheckStats holds check stats
+type CheckStats struct {
+	LastError string `json:"LastError"`
+}
+
+// CheckError represents LastError when not empty
+type CheckError struct {
+	Message string `json:"message"`
+}
+
+// DogstatsdStats holds DogStatsD stats
+type DogstatsdStats struct {
+	UDPPackets              int64 `json:"UdpPackets"`
+	UDPPacketReadingErrors  int64 `json:"UdpPacketReadingErrors"`
+	UDSPackets              int64 `json:"UdsPackets"`
+	UDSPacketReadingErrors  int64 `json:"UdsPacketReadingErrors"`
+	MetricParseErrors       int64 `json:"MetricParseErrors"`
+	EventParseErrors        int64 `json:"EventParseErrors"`
+	ServiceCheckParseErrors int64 `json:"ServiceCheckParseErrors"`
+}
+
+// LogsStats holds logs agent stats
+type LogsStats struct {
+	IsRunning    bool              `json:"is_running"`
+	Integrations []LogsIntegration `json:"integrations"`
+	Errors       []string          `json:"errors"`
+}
+
+// LogsIntegration holds the log sources of an integration
+type LogsIntegration struct {
+	Name    string       `json:"name"`
+	Sources []LogsSource `json:"sources"`
+}
+
+// LogsSource holds a log source status
+type LogsSource struct {
+	Type   string   `json:"type"`
+	Status string   `json:"status"`
+	Inputs []string `json:"inputs"`
+}
+
+// APMStats holds trace agent stats
+type APMStats struct {
+	Receiver []APMReceiverStats `json:"receiver"`
+}
+
+// APMReceiverStats holds trace agent receiver stats for a tracer
+type APMReceiverStats struct {
+	Lang           string           `json:"Lang"`
+	TracesReceived int64            `json:"TracesReceived"`
+	TracesDropped  map[string]int64 `json:"TracesDropped"`
+	SpansReceived  int64            `json:"SpansReceived"`
+	SpansDropped   int64            `json:"SpansDropped"`
+}
+
+// FleetStatus is the aggregated status of the Agents
+type FleetStatus struct {
+	Pods   []PodStatus    `json:"pods"`
+	Groups []GroupSummary `json:"groups"`
+}
+
+// PodStatus is the summarized status of an Agent pod
+type PodStatus struct {
+	Pod       string           `json:"pod"`
+	Node      string           `json:"node"`
+	NodePool  string           `json:"nodePool,omitempty"`
+	Profile   string           `json:"profile,omitempty"`
+	Forwarder ForwarderSummary `json:"forwarder"`
+	Checks    ChecksSummary    `json:"checks"`
+	DogStatsD DogStatsDSummary `json:"dogstatsd"`
+	Logs      LogsSummary      `json:"logs"`
+	APM       APMSummary       `json:"apm"`
+	Anomalies []string         `json:"anomalies,omitempty"`
+	Error     string           `json:"error,omitempty"`
+}
+
+// ForwarderSummary summarizes the forwarder health
+type ForwarderSummary struct {
+	TransactionsSuccess int64    `json:"transactionsSuccess"`
+	TransactionsErrors  int64    `json:"transactionsErrors"`
+	TransactionsDropped int64    `json:"transactionsDropped"`
+	APIKeyFailures      []string `json:"apiKeyFailures,omitempty"`
+}
+
+// ChecksSummary summarizes the check runner errors
+type ChecksSummary struct {
+	Errors []string `json:"errors,omitempty"`
+}
+
+// DogStatsDSummary summarizes the DogStatsD packet drops
+type DogStatsDSummary struct {
+	Packets             int64 `json:"packets"`
+	PacketReadingErrors int64 `json:"packetReadingErrors"`
+	ParseErrors         int64 `json:"parseErrors"`
+}
+
+// LogsSummary summarizes the log tailers
+type LogsSummary struct {
+	Running bool     `json:"running"`
+	Tailers int      `json:"tailers"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// APMSummary summarizes the trace agent receiver stats
+type APMSummary struct {
+	TracesReceived int64 `json:"tracesReceived"`
+	TracesDropped  int64 `json:"tracesDropped"`
+	SpansReceived  int64 `json:"spansReceived"`
+	SpansDropped   int64 `json:"spansDropped"`
+}
+
+// GroupSummary summarizes the anomalies of the Agents running on a node pool with a given profile
+type GroupSummary struct {
+	NodePool          string         `json:"nodePool"`
+	Profile           string         `json:"profile"`
+	Pods              int            `json:"pods"`
+	PodsWithAnomalies int            `json:"podsWithAnomalies"`
+	Anomalies         map[string]int `json:"anomalies,omitempty"`
+}
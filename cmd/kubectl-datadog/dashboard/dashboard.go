@@ -0,0 +1,78 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package dashboard
+
+import (
+	"context"
+	"time"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/datadogresource"
+)
+
+// New provides a cobra command wrapping the DatadogDashboard sub commands
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	return datadogresource.New(streams, kind{})
+}
+
+// kind describes the DatadogDashboard resource
+type kind struct{}
+
+func (kind) Name() string { return "dashboard" }
+
+func (kind) Kind() string { return "DatadogDashboard" }
+
+func (kind) NewObject() client.Object { return &v1alpha1.DatadogDashboard{} }
+
+func (kind) NewList() client.ObjectList { return &v1alpha1.DatadogDashboardList{} }
+
+func (kind) Objects(list client.ObjectList) []client.Object {
+	items := list.(*v1alpha1.DatadogDashboardList).Items
+	objects := make([]client.Object, 0, len(items))
+	for i := range items {
+		objects = append(objects, &items[i])
+	}
+	return objects
+}
+
+func (kind) ID(obj client.Object) string { return obj.(*v1alpha1.DatadogDashboard).Status.ID }
+
+func (kind) Path(id string) string { return "/dashboard/" + id }
+
+func (kind) Fields(obj client.Object) []datadogresource.Field {
+	dashboard := obj.(*v1alpha1.DatadogDashboard)
+	return []datadogresource.Field{
+		{Name: "ID", Value: dashboard.Status.ID},
+		{Name: "TITLE", Value: dashboard.Spec.Title},
+		{Name: "SYNC STATUS", Value: string(dashboard.Status.SyncStatus)},
+	}
+}
+
+func (kind) LiveFieldNames() []string {
+	return []string{"LIVE TITLE", "MODIFIED"}
+}
+
+func (kind) LiveFields(ctx context.Context, api *datadogapi.APIClient, obj client.Object) ([]datadogresource.Field, error) {
+	d, _, err := datadogV1.NewDashboardsApi(api).GetDashboard(ctx, obj.(*v1alpha1.DatadogDashboard).Status.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	modified := ""
+	if d.ModifiedAt != nil {
+		modified = d.ModifiedAt.UTC().Format(time.RFC3339)
+	}
+	return []datadogresource.Field{
+		{Name: "LIVE TITLE", Value: d.Title},
+		{Name: "MODIFIED", Value: modified},
+	}, nil
+}
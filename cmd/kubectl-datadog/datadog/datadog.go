@@ -11,9 +11,14 @@ import (
 
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/agent/agent"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/clusteragent/clusteragent"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/dashboard"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/flare"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/get"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/metrics"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/migrate"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/monitor"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/profile"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/slo"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/validate/validate"
 )
 
@@ -41,6 +46,7 @@ func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
 	cmd.AddCommand(get.New(streams))
 	cmd.AddCommand(flare.New(streams))
 	cmd.AddCommand(validate.New(streams))
+	cmd.AddCommand(migrate.New(streams))
 
 	// Agent commands
 	cmd.AddCommand(agent.New(streams))
@@ -51,6 +57,14 @@ func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
 	// DatadogMetric commands
 	cmd.AddCommand(metrics.New(streams))
 
+	// DatadogAgentProfile commands
+	cmd.AddCommand(profile.New(streams))
+
+	// DatadogMonitor, DatadogSLO and DatadogDashboard commands
+	cmd.AddCommand(monitor.New(streams))
+	cmd.AddCommand(slo.New(streams))
+	cmd.AddCommand(dashboard.New(streams))
+
 	o := newOptions(streams)
 	o.configFlags.AddFlags(cmd.Flags())
 
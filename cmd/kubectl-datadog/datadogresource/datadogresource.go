@@ -0,0 +1,327 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogresource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	ctrutils "github.com/DataDog/datadog-operator/pkg/controller/utils"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+	"github.com/DataDog/datadog-operator/pkg/plugin/common"
+)
+
+const (
+	apiKeyEnvVar = "DD_API_KEY"
+	appKeyEnvVar = "DD_APP_KEY"
+)
+
+var example = `
+  # list the %[2]ss of the current namespace with their state in Datadog
+  %[1]s list
+
+  # show the %[2]s foo
+  %[1]s get foo
+
+  # sync the %[2]s foo with Datadog without waiting for the next periodic sync
+  %[1]s sync foo
+
+  # print the URL of the %[2]s foo in the Datadog web application
+  %[1]s open foo
+`
+
+// options provides information required by the resource commands
+type options struct {
+	genericclioptions.IOStreams
+	common.Options
+	kind          Kind
+	args          []string
+	name          string
+	site          string
+	allNamespaces bool
+}
+
+// newOptions provides an instance of options with default values
+func newOptions(streams genericclioptions.IOStreams, kind Kind) *options {
+	o := &options{
+		IOStreams: streams,
+		kind:      kind,
+	}
+	o.SetConfigFlags()
+	return o
+}
+
+// New provides a cobra command wrapping the list, get, sync and open sub commands of a resource kind
+func New(streams genericclioptions.IOStreams, kind Kind) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s [subcommand] [flags]", kind.Name()),
+		Short:   fmt.Sprintf("Manage the %s resources synced with Datadog", kind.Kind()),
+		Example: fmt.Sprintf(example, "kubectl datadog "+kind.Name(), kind.Kind()),
+	}
+
+	cmd.AddCommand(newSubCommand(streams, kind, "list", fmt.Sprintf("List the %s resources with their state in Datadog", kind.Kind()), (*options).list))
+	cmd.AddCommand(newSubCommand(streams, kind, "get", fmt.Sprintf("Show a %s with its state in Datadog", kind.Kind()), (*options).get))
+	cmd.AddCommand(newSubCommand(streams, kind, "sync", fmt.Sprintf("Request an immediate sync of a %s with Datadog", kind.Kind()), (*options).sync))
+	cmd.AddCommand(newSubCommand(streams, kind, "open", fmt.Sprintf("Print the URL of a %s in the Datadog web application", kind.Kind()), (*options).open))
+
+	return cmd
+}
+
+// newSubCommand provides a cobra command running one of the resource commands
+func newSubCommand(streams genericclioptions.IOStreams, kind Kind, use, short string, run func(*options) error) *cobra.Command {
+	o := newOptions(streams, kind)
+	cmd := &cobra.Command{
+		Use:          fmt.Sprintf("%s [%s name] [flags]", use, kind.Kind()),
+		Short:        short,
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(use); err != nil {
+				return err
+			}
+
+			return run(o)
+		},
+	}
+
+	if use == "list" {
+		cmd.Use = fmt.Sprintf("%s [flags]", use)
+		cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "List the resources across all namespaces")
+	}
+	if use != "sync" {
+		cmd.Flags().StringVar(&o.site, "site", os.Getenv(constants.DDSite), fmt.Sprintf("Datadog site of the operator, one of %s. Defaults to the DD_SITE environment variable", strings.Join(common.SupportedSites, ", ")))
+	}
+	o.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command
+func (o *options) complete(cmd *cobra.Command, args []string) error {
+	o.args = args
+	if len(args) > 0 {
+		o.name = args[0]
+	}
+
+	site, err := common.ResolveSite(o.site)
+	if err != nil {
+		return err
+	}
+	o.site = site
+
+	return o.Init(cmd)
+}
+
+// validate ensures that all required arguments and flag values are provided
+func (o *options) validate(use string) error {
+	if use == "list" {
+		if len(o.args) > 0 {
+			return errors.New("no argument is allowed")
+		}
+		return nil
+	}
+
+	if o.name == "" {
+		return fmt.Errorf("%s name argument is missing", o.kind.Kind())
+	}
+	if len(o.args) > 1 {
+		return fmt.Errorf("one argument is allowed, got %d", len(o.args))
+	}
+
+	return nil
+}
+
+// list runs the list command
+func (o *options) list() error {
+	listOptions := &client.ListOptions{}
+	if !o.allNamespaces {
+		listOptions.Namespace = o.UserNamespace
+	}
+	list := o.kind.NewList()
+	if err := o.Client.List(context.TODO(), list, listOptions); err != nil {
+		return fmt.Errorf("unable to list %s: %w", o.kind.Kind(), err)
+	}
+	objects := o.kind.Objects(list)
+
+	api, auth := o.newAPIClient()
+
+	header := []string{"NAMESPACE", "NAME"}
+	for _, field := range o.kind.Fields(o.kind.NewObject()) {
+		header = append(header, field.Name)
+	}
+	if api != nil {
+		header = append(header, o.kind.LiveFieldNames()...)
+	}
+
+	table := newTable(o.Out, header)
+	for _, obj := range objects {
+		row := []string{obj.GetNamespace(), obj.GetName()}
+		for _, field := range o.kind.Fields(obj) {
+			row = append(row, field.Value)
+		}
+		if api != nil {
+			for _, field := range o.liveFields(auth, api, obj) {
+				row = append(row, field.Value)
+			}
+		}
+		table.Append(row)
+	}
+
+	// Send output
+	table.Render()
+
+	return nil
+}
+
+// get runs the get command
+func (o *options) get() error {
+	obj, err := o.getObject()
+	if err != nil {
+		return err
+	}
+
+	fields := []Field{
+		{Name: "NAMESPACE", Value: obj.GetNamespace()},
+		{Name: "NAME", Value: obj.GetName()},
+	}
+	fields = append(fields, o.kind.Fields(obj)...)
+	if id := o.kind.ID(obj); id != "" {
+		fields = append(fields, Field{Name: "URL", Value: o.url(id)})
+	}
+	if api, auth := o.newAPIClient(); api != nil {
+		fields = append(fields, o.liveFields(auth, api, obj)...)
+	}
+
+	for _, field := range fields {
+		fmt.Fprintf(o.Out, "%s: %s\n", field.Name, field.Value)
+		for _, item := range field.Items {
+			fmt.Fprintf(o.Out, "  - %s\n", item)
+		}
+	}
+
+	return nil
+}
+
+// sync runs the sync command
+func (o *options) sync() error {
+	obj, err := o.getObject()
+	if err != nil {
+		return err
+	}
+
+	// The controller forces a sync when the annotation is more recent than its last sync
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, ctrutils.ForceSyncAnnotationKey, time.Now().UTC().Format(time.RFC3339))
+	if err = o.Client.Patch(context.TODO(), obj, client.RawPatch(types.MergePatchType, []byte(patch))); err != nil {
+		return fmt.Errorf("unable to request the sync of %s %s/%s: %w", o.kind.Kind(), obj.GetNamespace(), obj.GetName(), err)
+	}
+
+	fmt.Fprintf(o.Out, "Sync of %s %s/%s requested\n", o.kind.Kind(), obj.GetNamespace(), obj.GetName())
+	return nil
+}
+
+// open runs the open command
+func (o *options) open() error {
+	obj, err := o.getObject()
+	if err != nil {
+		return err
+	}
+
+	id := o.kind.ID(obj)
+	if id == "" {
+		return fmt.Errorf("%s %s/%s is not created in Datadog yet", o.kind.Kind(), obj.GetNamespace(), obj.GetName())
+	}
+
+	fmt.Fprintln(o.Out, o.url(id))
+	return nil
+}
+
+// getObject gets the custom resource named in the arguments
+func (o *options) getObject() (client.Object, error) {
+	obj := o.kind.NewObject()
+	err := o.Client.Get(context.TODO(), client.ObjectKey{Namespace: o.UserNamespace, Name: o.name}, obj)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("%s %s/%s not found", o.kind.Kind(), o.UserNamespace, o.name)
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get %s: %w", o.kind.Kind(), err)
+	}
+	return obj, nil
+}
+
+// url returns the URL of an object in the Datadog web application
+func (o *options) url(id string) string {
+	return common.AppURL(o.site) + o.kind.Path(id)
+}
+
+// newAPIClient returns a Datadog API client and its authentication context.
+// The client is nil when the credentials are not set, the live state is then not shown.
+func (o *options) newAPIClient() (*datadogapi.APIClient, context.Context) {
+	apiKey, appKey := os.Getenv(apiKeyEnvVar), os.Getenv(appKeyEnvVar)
+	if apiKey == "" || appKey == "" {
+		fmt.Fprintf(o.ErrOut, "%s and %s are not set, the state in Datadog is not shown\n", apiKeyEnvVar, appKeyEnvVar)
+		return nil, nil
+	}
+
+	config := datadogapi.NewConfiguration()
+	config.HTTPClient = datadogclient.NewHTTPClient(datadogclient.ProxyConfigFromEnv())
+	auth := context.WithValue(context.Background(), datadogapi.ContextAPIKeys, map[string]datadogapi.APIKey{
+		"apiKeyAuth": {Key: apiKey},
+		"appKeyAuth": {Key: appKey},
+	})
+	// The second server accepts any site, the first one only the sites known by the client version
+	auth = context.WithValue(auth, datadogapi.ContextServerIndex, 1)
+	auth = context.WithValue(auth, datadogapi.ContextServerVariables, map[string]string{"site": o.site})
+
+	return datadogapi.NewAPIClient(config), auth
+}
+
+// liveFields returns the state of an object in Datadog, the fields are empty when it can't be fetched
+func (o *options) liveFields(ctx context.Context, api *datadogapi.APIClient, obj client.Object) []Field {
+	if o.kind.ID(obj) != "" {
+		live, err := o.kind.LiveFields(ctx, api, obj)
+		if err == nil {
+			return live
+		}
+		fmt.Fprintf(o.ErrOut, "unable to get %s %s/%s from Datadog: %v\n", o.kind.Kind(), obj.GetNamespace(), obj.GetName(), err)
+	}
+
+	names := o.kind.LiveFieldNames()
+	fields := make([]Field, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, Field{Name: name})
+	}
+	return fields
+}
+
+func newTable(out io.Writer, header []string) *tablewriter.Table {
+	table := tablewriter.NewWriter(out)
+	table.SetHeader(header)
+	table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderLine(false)
+	table.SetAutoWrapText(false)
+	return table
+}
@@ -0,0 +1,130 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogresource
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	ctrutils "github.com/DataDog/datadog-operator/pkg/controller/utils"
+)
+
+// dashboardKind is a minimal Kind used to test the commands
+type dashboardKind struct{}
+
+func (dashboardKind) Name() string { return "dashboard" }
+
+func (dashboardKind) Kind() string { return "DatadogDashboard" }
+
+func (dashboardKind) NewObject() client.Object { return &v1alpha1.DatadogDashboard{} }
+
+func (dashboardKind) NewList() client.ObjectList { return &v1alpha1.DatadogDashboardList{} }
+
+func (dashboardKind) Objects(list client.ObjectList) []client.Object {
+	var objects []client.Object
+	for i := range list.(*v1alpha1.DatadogDashboardList).Items {
+		objects = append(objects, &list.(*v1alpha1.DatadogDashboardList).Items[i])
+	}
+	return objects
+}
+
+func (dashboardKind) ID(obj client.Object) string { return obj.(*v1alpha1.DatadogDashboard).Status.ID }
+
+func (dashboardKind) Path(id string) string { return "/dashboard/" + id }
+
+func (dashboardKind) Fields(obj client.Object) []Field {
+	return []Field{{Name: "ID", Value: obj.(*v1alpha1.DatadogDashboard).Status.ID}}
+}
+
+func (dashboardKind) LiveFields(context.Context, *datadogapi.APIClient, client.Object) ([]Field, error) {
+	return nil, nil
+}
+
+func (dashboardKind) LiveFieldNames() []string { return []string{"LIVE TITLE"} }
+
+func newTestOptions(t *testing.T, objects ...client.Object) (*options, *bytes.Buffer) {
+	t.Setenv(apiKeyEnvVar, "")
+	t.Setenv(appKeyEnvVar, "")
+
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(s))
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := newOptions(streams, dashboardKind{})
+	o.Client = fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build()
+	o.UserNamespace = "default"
+	o.site = "datadoghq.eu"
+	return o, out
+}
+
+func newDashboard(name, id string) *v1alpha1.DatadogDashboard {
+	return &v1alpha1.DatadogDashboard{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Status:     v1alpha1.DatadogDashboardStatus{ID: id},
+	}
+}
+
+func Test_options_list(t *testing.T) {
+	o, out := newTestOptions(t, newDashboard("foo", "abc-def-ghi"), newDashboard("bar", ""))
+
+	require.NoError(t, o.list())
+
+	assert.Equal(t, []string{
+		"NAMESPACE", "NAME", "ID",
+		"default", "bar",
+		"default", "foo", "abc-def-ghi",
+	}, strings.Fields(out.String()))
+}
+
+func Test_options_get(t *testing.T) {
+	o, out := newTestOptions(t, newDashboard("foo", "abc-def-ghi"))
+	o.name = "foo"
+
+	require.NoError(t, o.get())
+
+	assert.Equal(t, "NAMESPACE: default\nNAME: foo\nID: abc-def-ghi\nURL: https://app.datadoghq.eu/dashboard/abc-def-ghi\n", out.String())
+}
+
+func Test_options_sync(t *testing.T) {
+	o, out := newTestOptions(t, newDashboard("foo", "abc-def-ghi"))
+	o.name = "foo"
+
+	require.NoError(t, o.sync())
+	assert.Equal(t, "Sync of DatadogDashboard default/foo requested\n", out.String())
+
+	dashboard := &v1alpha1.DatadogDashboard{}
+	require.NoError(t, o.Client.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "foo"}, dashboard))
+	requestTime, err := time.Parse(time.RFC3339, dashboard.Annotations[ctrutils.ForceSyncAnnotationKey])
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), requestTime, time.Minute)
+}
+
+func Test_options_open(t *testing.T) {
+	o, out := newTestOptions(t, newDashboard("foo", "abc-def-ghi"), newDashboard("bar", ""))
+
+	o.name = "foo"
+	require.NoError(t, o.open())
+	assert.Equal(t, "https://app.datadoghq.eu/dashboard/abc-def-ghi\n", out.String())
+
+	o.name = "bar"
+	assert.EqualError(t, o.open(), "DatadogDashboard default/bar is not created in Datadog yet")
+
+	o.name = "baz"
+	assert.EqualError(t, o.open(), "DatadogDashboard default/baz not found")
+}
@@ -0,0 +1,48 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogresource
+
+import (
+	"context"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Kind describes a custom resource synced with the Datadog API by the operator
+type Kind interface {
+	// Name is the name of the command managing the resource, for example monitor
+	Name() string
+	// Kind is the kind of the custom resource, for example DatadogMonitor
+	Kind() string
+	// NewObject returns an empty custom resource
+	NewObject() client.Object
+	// NewList returns an empty list of custom resources
+	NewList() client.ObjectList
+	// Objects returns the items of a list
+	Objects(list client.ObjectList) []client.Object
+	// ID returns the ID of the object in Datadog, empty until the operator created it
+	ID(obj client.Object) string
+	// Path returns the path of an object in the Datadog web application
+	Path(id string) string
+	// Fields returns the state of a custom resource, as reported in its status
+	Fields(obj client.Object) []Field
+	// LiveFields returns the state of the object in Datadog.
+	// They must have the same names, in the same order, for all objects.
+	LiveFields(ctx context.Context, api *datadogapi.APIClient, obj client.Object) ([]Field, error)
+	// LiveFieldNames returns the names of the fields returned by LiveFields
+	LiveFieldNames() []string
+}
+
+// Field is a piece of state of a resource
+type Field struct {
+	// Name is the name of the field, used as column header in tables
+	Name string
+	// Value is the value of the field
+	Value string
+	// Items are details of the value only shown when a single resource is displayed
+	Items []string
+}
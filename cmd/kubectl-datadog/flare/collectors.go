@@ -0,0 +1,290 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package flare
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+const (
+	agentObjectsDir = "agent-objects"
+	agentFlaresDir  = "agent-flares"
+
+	agentContainerName = "agent"
+)
+
+// managedByOperatorLabels selects the objects rendered by the operator
+var managedByOperatorLabels = client.MatchingLabels{"app.kubernetes.io/managed-by": "datadog-operator"}
+
+// agentFlareArchiveRegexp matches the path of the archive created by the `agent flare` command
+var agentFlareArchiveRegexp = regexp.MustCompile(`(/\S+\.zip)`)
+
+// agentObject is an object rendered by the operator
+type agentObject struct {
+	kind string
+	obj  client.Object
+}
+
+// resourceStatus is the status of a custom resource
+type resourceStatus struct {
+	Namespace string      `json:"namespace"`
+	Name      string      `json:"name"`
+	Status    interface{} `json:"status"`
+}
+
+// listAgentObjects lists the objects rendered by the operator: the agent workloads, their pods, configmaps and services
+func (o *options) listAgentObjects() ([]agentObject, error) {
+	var objects []agentObject
+	opts := []client.ListOption{client.InNamespace(o.UserNamespace), managedByOperatorLabels}
+
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := o.Client.List(context.TODO(), daemonSets, opts...); err != nil {
+		return nil, err
+	}
+	for i := range daemonSets.Items {
+		objects = append(objects, agentObject{kind: "DaemonSet", obj: &daemonSets.Items[i]})
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := o.Client.List(context.TODO(), deployments, opts...); err != nil {
+		return nil, err
+	}
+	for i := range deployments.Items {
+		objects = append(objects, agentObject{kind: "Deployment", obj: &deployments.Items[i]})
+	}
+
+	configMaps := &corev1.ConfigMapList{}
+	if err := o.Client.List(context.TODO(), configMaps, opts...); err != nil {
+		return nil, err
+	}
+	for i := range configMaps.Items {
+		objects = append(objects, agentObject{kind: "ConfigMap", obj: &configMaps.Items[i]})
+	}
+
+	services := &corev1.ServiceList{}
+	if err := o.Client.List(context.TODO(), services, opts...); err != nil {
+		return nil, err
+	}
+	for i := range services.Items {
+		objects = append(objects, agentObject{kind: "Service", obj: &services.Items[i]})
+	}
+
+	pods := &corev1.PodList{}
+	if err := o.Client.List(context.TODO(), pods, opts...); err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		objects = append(objects, agentObject{kind: "Pod", obj: &pods.Items[i]})
+	}
+
+	return objects, nil
+}
+
+// createAgentObjectFiles stores the objects rendered by the operator, except the pods, in files
+func (o *options) createAgentObjectFiles(objects []agentObject, dir string, cmd *cobra.Command) error {
+	objectsDir := filepath.Join(dir, agentObjectsDir)
+	if err := os.MkdirAll(objectsDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		if object.kind == "Pod" {
+			continue
+		}
+
+		template, err := yaml.Marshal(object.obj)
+		if err != nil {
+			return err
+		}
+
+		fileName := fmt.Sprintf("%s-%s.yaml", strings.ToLower(object.kind), object.obj.GetName())
+		if err = redactAndSave(filepath.Join(objectsDir, fileName), template, cmd); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createEventsFile gets the events of the DatadogAgents and of the objects rendered by the operator
+func (o *options) createEventsFile(objects []agentObject, dir string, cmd *cobra.Command) error {
+	ownedUIDs := map[types.UID]struct{}{}
+	for _, object := range objects {
+		ownedUIDs[object.obj.GetUID()] = struct{}{}
+	}
+
+	ddaList := &v2alpha1.DatadogAgentList{}
+	if err := o.Client.List(context.TODO(), ddaList, client.InNamespace(o.UserNamespace)); err != nil {
+		return err
+	}
+	for _, dda := range ddaList.Items {
+		ownedUIDs[dda.UID] = struct{}{}
+	}
+
+	eventList := &corev1.EventList{}
+	if err := o.Client.List(context.TODO(), eventList, client.InNamespace(o.UserNamespace)); err != nil {
+		return err
+	}
+
+	var events []corev1.Event
+	for _, event := range eventList.Items {
+		if _, found := ownedUIDs[event.InvolvedObject.UID]; found {
+			events = append(events, event)
+		}
+	}
+
+	template, err := yaml.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	return redactAndSave(filepath.Join(dir, "events.yaml"), template, cmd)
+}
+
+// createStatusFiles gets the statuses of the DatadogAgentProfiles, DatadogMonitors and DatadogSLOs
+func (o *options) createStatusFiles(dir string, cmd *cobra.Command) error {
+	var errs []error
+
+	profiles := &v1alpha1.DatadogAgentProfileList{}
+	if err := o.Client.List(context.TODO(), profiles, client.InNamespace(o.UserNamespace)); err != nil {
+		errs = append(errs, err)
+	} else {
+		statuses := make([]resourceStatus, 0, len(profiles.Items))
+		for _, profile := range profiles.Items {
+			statuses = append(statuses, resourceStatus{Namespace: profile.Namespace, Name: profile.Name, Status: profile.Status})
+		}
+		errs = append(errs, saveStatuses(filepath.Join(dir, "datadogagentprofile-statuses.yaml"), statuses, cmd))
+	}
+
+	monitors := &v1alpha1.DatadogMonitorList{}
+	if err := o.Client.List(context.TODO(), monitors, client.InNamespace(o.UserNamespace)); err != nil {
+		errs = append(errs, err)
+	} else {
+		statuses := make([]resourceStatus, 0, len(monitors.Items))
+		for _, monitor := range monitors.Items {
+			statuses = append(statuses, resourceStatus{Namespace: monitor.Namespace, Name: monitor.Name, Status: monitor.Status})
+		}
+		errs = append(errs, saveStatuses(filepath.Join(dir, "datadogmonitor-statuses.yaml"), statuses, cmd))
+	}
+
+	slos := &v1alpha1.DatadogSLOList{}
+	if err := o.Client.List(context.TODO(), slos, client.InNamespace(o.UserNamespace)); err != nil {
+		errs = append(errs, err)
+	} else {
+		statuses := make([]resourceStatus, 0, len(slos.Items))
+		for _, slo := range slos.Items {
+			statuses = append(statuses, resourceStatus{Namespace: slo.Namespace, Name: slo.Name, Status: slo.Status})
+		}
+		errs = append(errs, saveStatuses(filepath.Join(dir, "datadogslo-statuses.yaml"), statuses, cmd))
+	}
+
+	return errors.Join(errs...)
+}
+
+// saveStatuses stores custom resource statuses in a file
+func saveStatuses(filePath string, statuses []resourceStatus, cmd *cobra.Command) error {
+	template, err := yaml.Marshal(statuses)
+	if err != nil {
+		return err
+	}
+
+	return redactAndSave(filePath, template, cmd)
+}
+
+// createAgentFlareFiles runs the `agent flare` command in an agent pod and stores the content of the flare
+func (o *options) createAgentFlareFiles(podName, dir string, cmd *cobra.Command) error {
+	pod, err := o.Clientset.CoreV1().Pods(o.UserNamespace).Get(context.TODO(), podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Without stdin, the agent doesn't upload the flare and prints the path of the archive
+	flareOutput, err := o.execInContainer([]string{"agent", "flare"}, pod, agentContainerName)
+	if err != nil {
+		return err
+	}
+	archivePath, err := agentFlareArchivePath(flareOutput)
+	if err != nil {
+		return err
+	}
+
+	archive, err := o.execInContainer([]string{"cat", archivePath}, pod, agentContainerName)
+	if err != nil {
+		return err
+	}
+
+	return extractAndRedact(archive, filepath.Join(dir, agentFlaresDir, pod.Name), cmd)
+}
+
+// agentFlareArchivePath returns the path of the archive created by the `agent flare` command
+func agentFlareArchivePath(flareOutput []byte) (string, error) {
+	match := agentFlareArchiveRegexp.FindSubmatch(flareOutput)
+	if match == nil {
+		return "", fmt.Errorf("agent flare archive not found in the command output: %s", flareOutput)
+	}
+	return string(match[1]), nil
+}
+
+// extractAndRedact extracts a zip archive, redacting each file
+func extractAndRedact(archive []byte, dir string, cmd *cobra.Command) error {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		// Keep the extracted files in the target directory
+		filePath := filepath.Join(dir, filepath.Clean(string(filepath.Separator)+file.Name))
+		if err = os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+			return err
+		}
+
+		data, err := readZipFile(file)
+		if err != nil {
+			return err
+		}
+
+		if err = redactAndSave(filePath, data, cmd); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,63 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package flare
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentFlareArchivePath(t *testing.T) {
+	output := []byte(`Asking the agent to build the flare archive.
+/tmp/datadog-agent-2025-01-30-10-00-00.zip is going to be uploaded to Datadog
+Are you sure you want to upload a flare? [y/N]
+Aborting. (You can still use /tmp/datadog-agent-2025-01-30-10-00-00.zip)
+`)
+	path, err := agentFlareArchivePath(output)
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/datadog-agent-2025-01-30-10-00-00.zip", path)
+
+	_, err = agentFlareArchivePath([]byte("Error: unable to contact the agent"))
+	assert.Error(t, err)
+}
+
+func TestExtractAndRedact(t *testing.T) {
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	files := map[string]string{
+		"node/datadog.yaml":   "api_key: aaaaaaaaaaaaaaaaaaaaaaaaaaaabbbb\n",
+		"../../escaped.yaml":  "site: datadoghq.com\n",
+		"node/logs/agent.log": "agent started\n",
+	}
+	for name, content := range files {
+		f, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	dir := t.TempDir()
+	require.NoError(t, extractAndRedact(archive.Bytes(), filepath.Join(dir, "pod"), &cobra.Command{}))
+
+	content, err := os.ReadFile(filepath.Join(dir, "pod", "node", "datadog.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "api_key: ***************************abbbb")
+
+	// Files are kept in the target directory
+	_, err = os.Stat(filepath.Join(dir, "pod", "escaped.yaml"))
+	assert.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(dir, "pod", "node", "logs", "agent.log"))
+	assert.NoError(t, err)
+}
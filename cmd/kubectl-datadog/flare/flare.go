@@ -40,19 +40,30 @@ import (
 
 const (
 	httpTimeout = 60 * time.Second
-	flareURL    = "https://%s-flare.agent.datadoghq.%s/support/flare"
+	flareURL    = "https://%s-flare.agent.%s/support/flare"
 )
 
 var (
 	email        string
 	apiKey       string
 	ddSite       string
+	output       string
+	agentPods    []string
 	flareExample = `
   # send flare for an existing case 123 (api key from stdin)
   %[1]s flare 123 --email foo@bar.com
 
   # send flare and create a new case (email and api key from stdin)
   %[1]s flare
+
+  # send flare to the Datadog EU site
+  %[1]s flare --ddSite datadoghq.eu
+
+  # write the flare to a local archive without uploading it
+  %[1]s flare --output flare.zip
+
+  # include the agent flare of an agent pod
+  %[1]s flare --output flare.zip --agentPods datadog-agent-abcde
 `
 )
 
@@ -97,7 +108,9 @@ func New(streams genericclioptions.IOStreams) *cobra.Command {
 
 	cmd.Flags().StringVarP(&email, "email", "e", "", "Your email")
 	cmd.Flags().StringVarP(&apiKey, "apiKey", "k", "", "Your api key, could also be taken from stdin")
-	cmd.Flags().StringVarP(&ddSite, "ddSite", "d", "us", "Your Datadog site US or EU (default: US)")
+	cmd.Flags().StringVarP(&ddSite, "ddSite", "d", common.DefaultSite, fmt.Sprintf("Your Datadog site, one of %s", strings.Join(common.SupportedSites, ", ")))
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the flare archive to this file instead of uploading it to Datadog")
+	cmd.Flags().StringSliceVar(&agentPods, "agentPods", nil, "Names of the agent pods to collect an agent flare from")
 
 	o.ConfigFlags.AddFlags(cmd.Flags())
 
@@ -117,6 +130,11 @@ func (o *options) complete(cmd *cobra.Command, args []string) error {
 		o.caseID = args[0]
 	}
 
+	// The flare is not uploaded, no need to ask for the Datadog credentials
+	if output != "" {
+		return nil
+	}
+
 	if email == "" {
 		email, err = common.AskForInput("Please enter your email: ")
 		if err != nil {
@@ -132,7 +150,7 @@ func (o *options) complete(cmd *cobra.Command, args []string) error {
 	}
 
 	if ddSite == "" {
-		ddSite, err = common.AskForInput("Please enter your Datatog site [us/eu] (default us): ")
+		ddSite, err = common.AskForInput(fmt.Sprintf("Please enter your Datadog site (default %s): ", common.DefaultSite))
 		if err != nil {
 			return err
 		}
@@ -147,6 +165,10 @@ func (o *options) validate() error {
 		return errors.New("either one or no arguments are allowed")
 	}
 
+	if output != "" {
+		return nil
+	}
+
 	if email == "" {
 		return errors.New("email is missing")
 	}
@@ -155,14 +177,11 @@ func (o *options) validate() error {
 		return errors.New("apiKey is missing")
 	}
 
-	switch ddSite {
-	case "", "u", "us", "US":
-		o.site = "com"
-	case "e", "eu", "EU":
-		o.site = "eu"
-	default:
-		return fmt.Errorf("invalid datadog site %s", ddSite)
+	site, err := common.ResolveSite(ddSite)
+	if err != nil {
+		return err
 	}
+	o.site = site
 
 	return nil
 }
@@ -211,12 +230,45 @@ func (o *options) run(cmd *cobra.Command) error {
 		cmd.Println(fmt.Sprintf("Couldn't collect operator version: %v", err))
 	}
 
+	// Collect the objects rendered by the operator and their events
+	agentObjects, err := o.listAgentObjects()
+	if err != nil {
+		cmd.Println(fmt.Sprintf("Couldn't list agent objects: %v", err))
+	}
+	if err = o.createAgentObjectFiles(agentObjects, baseDir, cmd); err != nil {
+		cmd.Println(fmt.Sprintf("Couldn't collect agent objects: %v", err))
+	}
+	if err = o.createEventsFile(agentObjects, baseDir, cmd); err != nil {
+		cmd.Println(fmt.Sprintf("Couldn't collect events: %v", err))
+	}
+
+	// Collect the statuses of the other custom resources
+	if err = o.createStatusFiles(baseDir, cmd); err != nil {
+		cmd.Println(fmt.Sprintf("Couldn't collect custom resource statuses: %v", err))
+	}
+
+	// Collect the agent flares of the selected agent pods
+	for _, podName := range agentPods {
+		if err = o.createAgentFlareFiles(podName, baseDir, cmd); err != nil {
+			cmd.Println(fmt.Sprintf("Couldn't collect agent flare of pod %s: %v", podName, err))
+		}
+	}
+
 	// Create zip with the collected files
-	zipFilePath := getArchivePath()
+	zipFilePath := output
+	if zipFilePath == "" {
+		zipFilePath = getArchivePath()
+	}
 	if err = o.zip.Archive([]string{baseDir}, zipFilePath); err != nil {
 		return err
 	}
 
+	// Offline mode, the flare is never uploaded
+	if output != "" {
+		cmd.Println("Flare was successfully written to", zipFilePath)
+		return nil
+	}
+
 	// Get the operator version
 	version, err := o.getVersion(leaderPod)
 	if err != nil {
@@ -425,8 +477,13 @@ func (o *options) getLeader() (*corev1.Pod, error) {
 	return o.Clientset.CoreV1().Pods(o.UserNamespace).Get(context.TODO(), leaderName, metav1.GetOptions{})
 }
 
-// execInPod execs a given command in a given pod
+// execInPod execs a given command in the default container of a given pod
 func (o *options) execInPod(command []string, pod *corev1.Pod) ([]byte, error) {
+	return o.execInContainer(command, pod, "")
+}
+
+// execInContainer execs a given command in a given container of a given pod
+func (o *options) execInContainer(command []string, pod *corev1.Pod, container string) ([]byte, error) {
 	req := o.Clientset.CoreV1().RESTClient().Post().
 		Resource("pods").
 		Name(pod.Name).
@@ -441,11 +498,12 @@ func (o *options) execInPod(command []string, pod *corev1.Pod) ([]byte, error) {
 
 	parameterCodec := runtime.NewParameterCodec(scheme)
 	req.VersionedParams(&corev1.PodExecOptions{
-		Command: command,
-		Stdin:   false,
-		Stdout:  true,
-		Stderr:  false,
-		TTY:     false,
+		Container: container,
+		Command:   command,
+		Stdin:     false,
+		Stdout:    true,
+		Stderr:    false,
+		TTY:       false,
 	}, parameterCodec)
 
 	restConfig, err := o.ConfigFlags.ToRESTConfig()
@@ -0,0 +1,22 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package flare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildFlareURL(t *testing.T) {
+	apiKey = "0123456789abcdef"
+	defer func() { apiKey = "" }()
+
+	o := &options{site: "us3.datadoghq.com", caseID: "123"}
+	url, err := o.buildFlareURL("1.12.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://1-12-0-flare.agent.us3.datadoghq.com/support/flare/123?api_key=0123456789abcdef", url)
+}
@@ -0,0 +1,247 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package helm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/pmezard/go-difflib/difflib"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	edsdatadoghqv1alpha1 "github.com/DataDog/extendeddaemonset/api/v1alpha1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// diffedComponents are the components whose pod templates are compared, in the output order
+var diffedComponents = []v2alpha1.ComponentName{
+	v2alpha1.NodeAgentComponentName,
+	v2alpha1.ClusterAgentComponentName,
+	v2alpha1.ClusterChecksRunnerComponentName,
+}
+
+// maxRenderReconciles is the number of reconciles run to render a DatadogAgent
+const maxRenderReconciles = 3
+
+// podTemplates are the pod templates of the Agent components
+type podTemplates map[v2alpha1.ComponentName]*corev1.PodTemplateSpec
+
+// loadHelmManifest reads the pod templates of the workloads rendered by `helm template`
+func loadHelmManifest(path string) (podTemplates, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	templates := podTemplates{}
+	decoder := k8syaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		obj := map[string]interface{}{}
+		if err = decoder.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("unable to parse the Helm manifest: %w", err)
+		}
+
+		switch obj["kind"] {
+		case "DaemonSet":
+			ds := &appsv1.DaemonSet{}
+			if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj, ds); err != nil {
+				return nil, err
+			}
+			templates.add("DaemonSet", ds.Spec.Template)
+		case "Deployment":
+			deployment := &appsv1.Deployment{}
+			if err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj, deployment); err != nil {
+				return nil, err
+			}
+			templates.add("Deployment", deployment.Spec.Template)
+		}
+	}
+
+	return templates, nil
+}
+
+// renderDatadogAgent runs the DatadogAgent reconcile against an in-memory client and returns the pod templates it creates
+func renderDatadogAgent(dda *v2alpha1.DatadogAgent) (podTemplates, error) {
+	// Invalid configurations are not rendered by the reconcile
+	if err := v2alpha1.IsValidDatadogAgent(&dda.Spec); err != nil {
+		return nil, fmt.Errorf("invalid DatadogAgent: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiregistrationv1.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(edsdatadoghqv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v2alpha1.AddToScheme(scheme))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(dda.DeepCopy()).
+		WithStatusSubresource(&v2alpha1.DatadogAgent{}, &appsv1.DaemonSet{}, &appsv1.Deployment{}).
+		Build()
+
+	// The recorder drops the events when it has no channel
+	reconciler, err := datadogagent.NewReconciler(datadogagent.ReconcilerOptions{}, fakeClient, kubernetes.PlatformInfo{}, scheme, logr.Discard(), &record.FakeRecorder{}, noopForwarders{})
+	if err != nil {
+		return nil, err
+	}
+
+	// The first reconcile only adds the finalizer to the DatadogAgent
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: dda.Namespace, Name: dda.Name}}
+	templates := podTemplates{}
+	for i := 0; i < maxRenderReconciles && len(templates) == 0; i++ {
+		if _, err = reconciler.Reconcile(context.TODO(), request); err != nil {
+			return nil, fmt.Errorf("unable to render the DatadogAgent: %w", err)
+		}
+
+		daemonSets := &appsv1.DaemonSetList{}
+		if err = fakeClient.List(context.TODO(), daemonSets, client.InNamespace(dda.Namespace)); err != nil {
+			return nil, err
+		}
+		for _, ds := range daemonSets.Items {
+			templates.add("DaemonSet", ds.Spec.Template)
+		}
+		deployments := &appsv1.DeploymentList{}
+		if err = fakeClient.List(context.TODO(), deployments, client.InNamespace(dda.Namespace)); err != nil {
+			return nil, err
+		}
+		for _, deployment := range deployments.Items {
+			templates.add("Deployment", deployment.Spec.Template)
+		}
+	}
+
+	return templates, nil
+}
+
+// add registers the pod template of an Agent component, the other workloads are ignored.
+// The node Agent runs as a DaemonSet, the Deployments are identified by their main container,
+// as the chart and the operator use the same container names.
+func (t podTemplates) add(kind string, template corev1.PodTemplateSpec) {
+	if kind == "DaemonSet" {
+		if _, found := t[v2alpha1.NodeAgentComponentName]; !found {
+			t[v2alpha1.NodeAgentComponentName] = template.DeepCopy()
+		}
+		return
+	}
+
+	for _, container := range template.Spec.Containers {
+		switch container.Name {
+		case "cluster-agent":
+			t[v2alpha1.ClusterAgentComponentName] = template.DeepCopy()
+			return
+		case "agent":
+			t[v2alpha1.ClusterChecksRunnerComponentName] = template.DeepCopy()
+			return
+		}
+	}
+}
+
+// diffPodTemplates writes the unified diff of the pod templates rendered by the chart and by the operator
+func diffPodTemplates(out io.Writer, helmTemplates, operatorTemplates podTemplates) error {
+	for _, component := range diffedComponents {
+		helmTemplate, operatorTemplate := helmTemplates[component], operatorTemplates[component]
+		if helmTemplate == nil && operatorTemplate == nil {
+			continue
+		}
+
+		helmYAML, err := podTemplateYAML(helmTemplate)
+		if err != nil {
+			return err
+		}
+		operatorYAML, err := podTemplateYAML(operatorTemplate)
+		if err != nil {
+			return err
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(helmYAML),
+			B:        difflib.SplitLines(operatorYAML),
+			FromFile: fmt.Sprintf("helm/%s", component),
+			ToFile:   fmt.Sprintf("operator/%s", component),
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+		if diff == "" {
+			fmt.Fprintf(out, "No difference in the %s pod template\n", component)
+			continue
+		}
+		fmt.Fprint(out, diff)
+	}
+
+	return nil
+}
+
+// podTemplateYAML returns the YAML of a pod template, with its lists sorted to only show meaningful differences
+func podTemplateYAML(template *corev1.PodTemplateSpec) (string, error) {
+	if template == nil {
+		return "", nil
+	}
+
+	template = template.DeepCopy()
+	// The order of the init containers matters, only their content is sorted
+	sortContainerLists(template.Spec.InitContainers)
+	sortContainerLists(template.Spec.Containers)
+	sort.Slice(template.Spec.Containers, func(i, j int) bool { return template.Spec.Containers[i].Name < template.Spec.Containers[j].Name })
+	sort.Slice(template.Spec.Volumes, func(i, j int) bool { return template.Spec.Volumes[i].Name < template.Spec.Volumes[j].Name })
+
+	data, err := yaml.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func sortContainerLists(containers []corev1.Container) {
+	for i := range containers {
+		container := &containers[i]
+		sort.Slice(container.Env, func(i, j int) bool { return container.Env[i].Name < container.Env[j].Name })
+		sort.Slice(container.VolumeMounts, func(i, j int) bool {
+			return container.VolumeMounts[i].MountPath < container.VolumeMounts[j].MountPath
+		})
+	}
+}
+
+// noopForwarders disables the metrics forwarders when rendering a DatadogAgent
+type noopForwarders struct{}
+
+func (noopForwarders) Register(client.Object)                      {}
+func (noopForwarders) Unregister(datadog.MonitoredObject)          {}
+func (noopForwarders) ProcessError(datadog.MonitoredObject, error) {}
+func (noopForwarders) ProcessEvent(datadog.MonitoredObject, datadog.Event) {
+}
+func (noopForwarders) MetricsForwarderStatusForObj(datadog.MonitoredObject) *datadog.ConditionCommon {
+	return nil
+}
+func (noopForwarders) SetEnabledFeatures(datadog.MonitoredObject, []feature.Feature) {}
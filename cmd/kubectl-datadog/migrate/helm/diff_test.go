@@ -0,0 +1,119 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package helm
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+const helmManifest = `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: datadog
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: datadog
+spec:
+  template:
+    spec:
+      containers:
+      - name: agent
+        image: gcr.io/datadoghq/agent:7.60.0
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: datadog-cluster-agent
+spec:
+  template:
+    spec:
+      containers:
+      - name: cluster-agent
+        image: gcr.io/datadoghq/cluster-agent:7.60.0
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: datadog-clusterchecks
+spec:
+  template:
+    spec:
+      containers:
+      - name: agent
+        image: gcr.io/datadoghq/agent:7.60.0
+`
+
+func Test_loadHelmManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(helmManifest), 0o600))
+
+	templates, err := loadHelmManifest(path)
+	require.NoError(t, err)
+
+	require.Len(t, templates, 3)
+	assert.Equal(t, "agent", templates[v2alpha1.NodeAgentComponentName].Spec.Containers[0].Name)
+	assert.Equal(t, "cluster-agent", templates[v2alpha1.ClusterAgentComponentName].Spec.Containers[0].Name)
+	assert.Equal(t, "agent", templates[v2alpha1.ClusterChecksRunnerComponentName].Spec.Containers[0].Name)
+}
+
+func Test_renderDatadogAgent(t *testing.T) {
+	dda := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "datadog", Namespace: "datadog"},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Global: &v2alpha1.GlobalConfig{
+				Credentials: &v2alpha1.DatadogCredentials{APISecret: &v2alpha1.SecretConfig{SecretName: "datadog-secret", KeyName: "api-key"}},
+			},
+			Features: &v2alpha1.DatadogFeatures{
+				ClusterChecks: &v2alpha1.ClusterChecksFeatureConfig{Enabled: ptr.To(true), UseClusterChecksRunners: ptr.To(true)},
+			},
+		},
+	}
+
+	templates, err := renderDatadogAgent(dda)
+	require.NoError(t, err)
+
+	assert.Contains(t, templates, v2alpha1.NodeAgentComponentName)
+	assert.Contains(t, templates, v2alpha1.ClusterAgentComponentName)
+	assert.Contains(t, templates, v2alpha1.ClusterChecksRunnerComponentName)
+}
+
+func Test_diffPodTemplates(t *testing.T) {
+	template := func(image string, env ...corev1.EnvVar) *corev1.PodTemplateSpec {
+		return &corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "agent", Image: image, Env: env}}},
+		}
+	}
+	helmTemplates := podTemplates{
+		v2alpha1.NodeAgentComponentName:           template("agent:7.60.0", corev1.EnvVar{Name: "DD_B"}, corev1.EnvVar{Name: "DD_A"}),
+		v2alpha1.ClusterChecksRunnerComponentName: template("agent:7.60.0"),
+	}
+	operatorTemplates := podTemplates{
+		v2alpha1.NodeAgentComponentName:    template("agent:7.60.0", corev1.EnvVar{Name: "DD_A"}, corev1.EnvVar{Name: "DD_B"}),
+		v2alpha1.ClusterAgentComponentName: template("cluster-agent:7.60.0"),
+	}
+
+	out := &bytes.Buffer{}
+	require.NoError(t, diffPodTemplates(out, helmTemplates, operatorTemplates))
+
+	assert.Contains(t, out.String(), "No difference in the nodeAgent pod template")
+	assert.Contains(t, out.String(), "+++ operator/clusterAgent")
+	assert.Contains(t, out.String(), "+  - image: cluster-agent:7.60.0")
+	assert.Contains(t, out.String(), "--- helm/clusterChecksRunner")
+}
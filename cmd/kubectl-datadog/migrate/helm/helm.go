@@ -0,0 +1,183 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+const defaultNamespace = "default"
+
+var helmExample = `
+  # convert the values of the datadog Helm chart to a DatadogAgent
+  %[1]s helm --values values.yaml > datadog-agent.yaml
+
+  # compare the pod templates rendered by the chart and by the operator
+  helm template datadog datadog/datadog --values values.yaml > helm-manifest.yaml
+  %[1]s helm --values values.yaml --helm-manifest helm-manifest.yaml
+`
+
+// options provides information required by migrate helm command
+type options struct {
+	genericclioptions.IOStreams
+	valuesFiles  []string
+	name         string
+	namespace    string
+	helmManifest string
+}
+
+// newOptions provides an instance of options with default values
+func newOptions(streams genericclioptions.IOStreams) *options {
+	return &options{
+		IOStreams: streams,
+	}
+}
+
+// New provides a cobra command wrapping options for "helm" sub command
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+	cmd := &cobra.Command{
+		Use:          "helm [flags]",
+		Short:        "Convert the values of the datadog Helm chart to a DatadogAgent",
+		Example:      fmt.Sprintf(helmExample, "kubectl datadog migrate"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				return err
+			}
+
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&o.valuesFiles, "values", "f", nil, "Values files of the datadog Helm chart, merged in order like with helm")
+	cmd.Flags().StringVar(&o.name, "name", "datadog", "Name of the DatadogAgent")
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", "", "Namespace of the DatadogAgent")
+	cmd.Flags().StringVar(&o.helmManifest, "helm-manifest", "", "Manifest rendered by `helm template` with the same values. When set, the pod templates rendered by the chart and by the operator are compared instead of printing the DatadogAgent")
+
+	return cmd
+}
+
+// validate ensures that all required arguments and flag values are provided
+func (o *options) validate() error {
+	if len(o.valuesFiles) == 0 {
+		return errors.New("at least one values file is required")
+	}
+	if o.name == "" {
+		return errors.New("the DatadogAgent name is required")
+	}
+
+	return nil
+}
+
+// run runs the migrate helm command
+func (o *options) run() error {
+	values := map[string]interface{}{}
+	for _, valuesFile := range o.valuesFiles {
+		fileValues, err := readValues(valuesFile)
+		if err != nil {
+			return err
+		}
+		mergeValues(values, fileValues)
+	}
+
+	spec, m, err := migrateValues(values)
+	if err != nil {
+		return err
+	}
+
+	if len(m.unmapped) > 0 {
+		fmt.Fprintln(o.ErrOut, "The following values have no equivalent in the DatadogAgent and must be migrated manually:")
+		for _, path := range m.unmapped {
+			fmt.Fprintf(o.ErrOut, "  - %s\n", path)
+		}
+	}
+	if len(m.invalid) > 0 {
+		fmt.Fprintln(o.ErrOut, "The following values could not be converted:")
+		for _, invalid := range m.invalid {
+			fmt.Fprintf(o.ErrOut, "  - %s\n", invalid)
+		}
+	}
+
+	dda := &v2alpha1.DatadogAgent{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v2alpha1.GroupVersion.String(),
+			Kind:       "DatadogAgent",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      o.name,
+			Namespace: o.namespace,
+		},
+		Spec: *spec,
+	}
+
+	if o.helmManifest != "" {
+		return o.diff(dda)
+	}
+
+	manifest, err := datadogAgentManifest(dda)
+	if err != nil {
+		return err
+	}
+	_, err = o.Out.Write(manifest)
+	return err
+}
+
+// diff compares the pod templates rendered by the chart and by the operator
+func (o *options) diff(dda *v2alpha1.DatadogAgent) error {
+	helmTemplates, err := loadHelmManifest(o.helmManifest)
+	if err != nil {
+		return err
+	}
+
+	dda = dda.DeepCopy()
+	if dda.Namespace == "" {
+		dda.Namespace = defaultNamespace
+	}
+	operatorTemplates, err := renderDatadogAgent(dda)
+	if err != nil {
+		return err
+	}
+
+	return diffPodTemplates(o.Out, helmTemplates, operatorTemplates)
+}
+
+// readValues reads a values file of the chart
+func readValues(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	if err = yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("unable to parse the values file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// datadogAgentManifest returns the YAML manifest of a DatadogAgent, without its status and server side metadata
+func datadogAgentManifest(dda *v2alpha1.DatadogAgent) ([]byte, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(dda)
+	if err != nil {
+		return nil, err
+	}
+	unstructured.RemoveNestedField(content, "status")
+	unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+
+	return yaml.Marshal(content)
+}
@@ -0,0 +1,438 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package helm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+// valueMapping maps a value of the `datadog` Helm chart to a field of the DatadogAgent spec
+type valueMapping struct {
+	// helm is the path of the value in the chart values
+	helm string
+	// dda is the path of the field in the DatadogAgent spec
+	dda string
+	// convert converts the chart value to the field value, the chart value is used as is when nil
+	convert func(interface{}) (interface{}, error)
+}
+
+// valueMappings are the chart values having an equivalent in the DatadogAgent spec
+var valueMappings = buildValueMappings()
+
+func buildValueMappings() map[string]valueMapping {
+	mappings := []valueMapping{
+		// Global configuration
+		{helm: "registry", dda: "global.registry"},
+		{helm: "datadog.apiKey", dda: "global.credentials.apiKey"},
+		{helm: "datadog.apiKeyExistingSecret", dda: "global.credentials.apiSecret", convert: secretConfig("api-key")},
+		{helm: "datadog.appKey", dda: "global.credentials.appKey"},
+		{helm: "datadog.appKeyExistingSecret", dda: "global.credentials.appSecret", convert: secretConfig("app-key")},
+		{helm: "clusterAgent.token", dda: "global.clusterAgentToken"},
+		{helm: "clusterAgent.tokenExistingSecret", dda: "global.clusterAgentTokenSecret", convert: secretConfig("token")},
+		{helm: "datadog.site", dda: "global.site"},
+		{helm: "datadog.clusterName", dda: "global.clusterName"},
+		{helm: "datadog.tags", dda: "global.tags"},
+		{helm: "datadog.logLevel", dda: "global.logLevel"},
+		{helm: "datadog.env", dda: "global.env"},
+		{helm: "datadog.checksCardinality", dda: "global.checksTagCardinality"},
+		{helm: "datadog.criSocketPath", dda: "global.criSocketPath"},
+		{helm: "datadog.dockerSocketPath", dda: "global.dockerSocketPath"},
+		{helm: "datadog.kubelet.tlsVerify", dda: "global.kubelet.tlsVerify"},
+		{helm: "datadog.kubelet.hostCAPath", dda: "global.kubelet.hostCAPath"},
+		{helm: "datadog.kubelet.agentCAPath", dda: "global.kubelet.agentCAPath"},
+		{helm: "datadog.nodeLabelsAsTags", dda: "global.nodeLabelsAsTags"},
+		{helm: "datadog.podLabelsAsTags", dda: "global.podLabelsAsTags"},
+		{helm: "datadog.podAnnotationsAsTags", dda: "global.podAnnotationsAsTags"},
+		{helm: "datadog.namespaceLabelsAsTags", dda: "global.namespaceLabelsAsTags"},
+		{helm: "datadog.namespaceAnnotationsAsTags", dda: "global.namespaceAnnotationsAsTags"},
+		{helm: "datadog.kubernetesResourcesLabelsAsTags", dda: "global.kubernetesResourcesLabelsAsTags"},
+		{helm: "datadog.kubernetesResourcesAnnotationsAsTags", dda: "global.kubernetesResourcesAnnotationsAsTags"},
+		{helm: "datadog.originDetectionUnified.enabled", dda: "global.originDetectionUnified.enabled"},
+		{helm: "datadog.processAgent.runInCoreAgent", dda: "global.runProcessChecksInCoreAgent"},
+		{helm: "datadog.secretBackend.command", dda: "global.secretBackend.command"},
+		{helm: "datadog.secretBackend.arguments", dda: "global.secretBackend.args"},
+		{helm: "datadog.secretBackend.timeout", dda: "global.secretBackend.timeout"},
+		{helm: "datadog.secretBackend.enableGlobalPermissions", dda: "global.secretBackend.enableGlobalPermissions"},
+		{helm: "datadog.secretBackend.roles", dda: "global.secretBackend.roles"},
+		{helm: "datadog.networkPolicy.create", dda: "global.networkPolicy.create"},
+		{helm: "datadog.networkPolicy.flavor", dda: "global.networkPolicy.flavor"},
+		{helm: "datadog.networkPolicy.dnsSelectorEndpoints", dda: "global.networkPolicy.dnsSelectorEndpoints"},
+
+		// Node Agent features
+		{helm: "datadog.logs.enabled", dda: "features.logCollection.enabled"},
+		{helm: "datadog.logs.containerCollectAll", dda: "features.logCollection.containerCollectAll"},
+		{helm: "datadog.logs.containerCollectUsingFiles", dda: "features.logCollection.containerCollectUsingFiles"},
+		{helm: "datadog.logs.autoMultiLineDetection", dda: "features.logCollection.autoMultiLineDetection.enabled"},
+		{helm: "datadog.apm.enabled", dda: "features.apm.enabled"},
+		{helm: "datadog.apm.portEnabled", dda: "features.apm.hostPortConfig.enabled"},
+		{helm: "datadog.apm.port", dda: "features.apm.hostPortConfig.hostPort"},
+		{helm: "datadog.apm.socketEnabled", dda: "features.apm.unixDomainSocketConfig.enabled"},
+		{helm: "datadog.apm.socketPath", dda: "features.apm.unixDomainSocketConfig.path"},
+		{helm: "datadog.apm.errorTrackingStandalone.enabled", dda: "features.apm.errorTrackingStandalone.enabled"},
+		{helm: "datadog.apm.instrumentation.enabled", dda: "features.apm.instrumentation.enabled"},
+		{helm: "datadog.apm.instrumentation.enabledNamespaces", dda: "features.apm.instrumentation.enabledNamespaces"},
+		{helm: "datadog.apm.instrumentation.disabledNamespaces", dda: "features.apm.instrumentation.disabledNamespaces"},
+		{helm: "datadog.apm.instrumentation.libVersions", dda: "features.apm.instrumentation.libVersions"},
+		{helm: "datadog.apm.instrumentation.targets", dda: "features.apm.instrumentation.targets"},
+		{helm: "datadog.apm.instrumentation.injector.imageTag", dda: "features.apm.instrumentation.injector.imageTag"},
+		{helm: "datadog.apm.instrumentation.language_detection.enabled", dda: "features.apm.instrumentation.languageDetection.enabled"},
+		{helm: "datadog.processAgent.processCollection", dda: "features.liveProcessCollection.enabled"},
+		{helm: "datadog.processAgent.stripProcessArguments", dda: "features.liveProcessCollection.stripProcessArguments"},
+		{helm: "datadog.processAgent.containerCollection", dda: "features.liveContainerCollection.enabled"},
+		{helm: "datadog.processAgent.processDiscovery", dda: "features.processDiscovery.enabled"},
+		{helm: "datadog.systemProbe.enableOOMKill", dda: "features.oomKill.enabled"},
+		{helm: "datadog.systemProbe.enableTCPQueueLength", dda: "features.tcpQueueLength.enabled"},
+		{helm: "datadog.systemProbe.collectDNSStats", dda: "features.npm.collectDNSStats"},
+		{helm: "datadog.systemProbe.enableConntrack", dda: "features.npm.enableConntrack"},
+		{helm: "datadog.networkMonitoring.enabled", dda: "features.npm.enabled"},
+		{helm: "datadog.serviceMonitoring.enabled", dda: "features.usm.enabled"},
+		{helm: "datadog.discovery.enabled", dda: "features.serviceDiscovery.enabled"},
+		{helm: "datadog.gpuMonitoring.enabled", dda: "features.gpu.enabled"},
+		{helm: "datadog.securityAgent.runtime.enabled", dda: "features.cws.enabled"},
+		{helm: "datadog.securityAgent.runtime.syscallMonitor.enabled", dda: "features.cws.syscallMonitorEnabled"},
+		{helm: "datadog.securityAgent.runtime.network.enabled", dda: "features.cws.network.enabled"},
+		{helm: "datadog.securityAgent.compliance.enabled", dda: "features.cspm.enabled"},
+		{helm: "datadog.securityAgent.compliance.checkInterval", dda: "features.cspm.checkInterval"},
+		{helm: "datadog.securityAgent.compliance.host_benchmarks.enabled", dda: "features.cspm.hostBenchmarks.enabled"},
+		{helm: "datadog.dogstatsd.port", dda: "features.dogstatsd.hostPortConfig.hostPort"},
+		{helm: "datadog.dogstatsd.useHostPort", dda: "features.dogstatsd.hostPortConfig.enabled"},
+		{helm: "datadog.dogstatsd.useSocketVolume", dda: "features.dogstatsd.unixDomainSocketConfig.enabled"},
+		{helm: "datadog.dogstatsd.socketPath", dda: "features.dogstatsd.unixDomainSocketConfig.path"},
+		{helm: "datadog.dogstatsd.originDetection", dda: "features.dogstatsd.originDetectionEnabled"},
+		{helm: "datadog.dogstatsd.tagCardinality", dda: "features.dogstatsd.tagCardinality"},
+		{helm: "datadog.dogstatsd.mapperProfiles", dda: "features.dogstatsd.mapperProfiles.configData", convert: yamlString},
+		{helm: "datadog.otlp.receiver.protocols.grpc.enabled", dda: "features.otlp.receiver.protocols.grpc.enabled"},
+		{helm: "datadog.otlp.receiver.protocols.grpc.endpoint", dda: "features.otlp.receiver.protocols.grpc.endpoint"},
+		{helm: "datadog.otlp.receiver.protocols.http.enabled", dda: "features.otlp.receiver.protocols.http.enabled"},
+		{helm: "datadog.otlp.receiver.protocols.http.endpoint", dda: "features.otlp.receiver.protocols.http.endpoint"},
+		{helm: "datadog.remoteConfiguration.enabled", dda: "features.remoteConfiguration.enabled"},
+		{helm: "remoteConfiguration.enabled", dda: "features.remoteConfiguration.enabled"},
+		{helm: "datadog.sbom.containerImage.enabled", dda: "features.sbom.containerImage.enabled"},
+		{helm: "datadog.sbom.containerImage.uncompressedLayersSupport", dda: "features.sbom.containerImage.uncompressedLayersSupport"},
+		{helm: "datadog.sbom.containerImage.overlayFSDirectScan", dda: "features.sbom.containerImage.overlayFSDirectScan"},
+		{helm: "datadog.sbom.host.enabled", dda: "features.sbom.host.enabled"},
+		{helm: "datadog.prometheusScrape.enabled", dda: "features.prometheusScrape.enabled"},
+		{helm: "datadog.prometheusScrape.serviceEndpoints", dda: "features.prometheusScrape.enableServiceEndpoints"},
+		{helm: "datadog.prometheusScrape.additionalConfigs", dda: "features.prometheusScrape.additionalConfigs", convert: yamlString},
+		{helm: "datadog.prometheusScrape.version", dda: "features.prometheusScrape.version"},
+
+		// Cluster Agent features
+		{helm: "datadog.collectEvents", dda: "features.eventCollection.collectKubernetesEvents"},
+		{helm: "datadog.orchestratorExplorer.enabled", dda: "features.orchestratorExplorer.enabled"},
+		{helm: "datadog.orchestratorExplorer.container_scrubbing.enabled", dda: "features.orchestratorExplorer.scrubContainers"},
+		{helm: "datadog.orchestratorExplorer.customResources", dda: "features.orchestratorExplorer.customResources"},
+		{helm: "datadog.kubeStateMetricsCore.enabled", dda: "features.kubeStateMetricsCore.enabled"},
+		{helm: "datadog.clusterChecks.enabled", dda: "features.clusterChecks.enabled"},
+		{helm: "clusterChecksRunner.enabled", dda: "features.clusterChecks.useClusterChecksRunners"},
+		{helm: "datadog.helmCheck.enabled", dda: "features.helmCheck.enabled"},
+		{helm: "datadog.helmCheck.collectEvents", dda: "features.helmCheck.collectEvents"},
+		{helm: "datadog.helmCheck.valuesAsTags", dda: "features.helmCheck.valuesAsTags"},
+		{helm: "clusterAgent.admissionController.enabled", dda: "features.admissionController.enabled"},
+		{helm: "clusterAgent.admissionController.mutateUnlabelled", dda: "features.admissionController.mutateUnlabelled"},
+		{helm: "clusterAgent.admissionController.configMode", dda: "features.admissionController.agentCommunicationMode"},
+		{helm: "clusterAgent.admissionController.failurePolicy", dda: "features.admissionController.failurePolicy"},
+		{helm: "clusterAgent.admissionController.webhookName", dda: "features.admissionController.webhookName"},
+		{helm: "clusterAgent.admissionController.validation.enabled", dda: "features.admissionController.validation.enabled"},
+		{helm: "clusterAgent.admissionController.mutation.enabled", dda: "features.admissionController.mutation.enabled"},
+		{helm: "clusterAgent.admissionController.cwsInstrumentation.enabled", dda: "features.admissionController.cwsInstrumentation.enabled"},
+		{helm: "clusterAgent.admissionController.cwsInstrumentation.mode", dda: "features.admissionController.cwsInstrumentation.mode"},
+		{helm: "clusterAgent.metricsProvider.enabled", dda: "features.externalMetricsServer.enabled"},
+		{helm: "clusterAgent.metricsProvider.useDatadogMetrics", dda: "features.externalMetricsServer.useDatadogMetrics"},
+		{helm: "clusterAgent.metricsProvider.wpaController", dda: "features.externalMetricsServer.wpaController"},
+		{helm: "clusterAgent.metricsProvider.registerAPIService", dda: "features.externalMetricsServer.registerAPIService"},
+		{helm: "clusterAgent.metricsProvider.service.port", dda: "features.externalMetricsServer.port"},
+		{helm: "clusterAgent.metricsProvider.endpoint", dda: "features.externalMetricsServer.endpoint.url"},
+
+		// Custom configurations
+		{helm: "datadog.confd", dda: "override.nodeAgent.extraConfd.configDataMap"},
+		{helm: "datadog.checksd", dda: "override.nodeAgent.extraChecksd.configDataMap"},
+		{helm: "agents.customAgentConfig", dda: "override.nodeAgent.customConfigurations", convert: customConfiguration(apicommon.AgentGeneralConfigFile)},
+		{helm: "clusterAgent.confd", dda: "override.clusterAgent.extraConfd.configDataMap"},
+		{helm: "clusterAgent.datadog_cluster_yaml", dda: "override.clusterAgent.customConfigurations", convert: customConfiguration(apicommon.ClusterAgentConfigFile)},
+
+		// Component enablement
+		{helm: "agents.enabled", dda: "override.nodeAgent.disabled", convert: not},
+		{helm: "clusterAgent.enabled", dda: "override.clusterAgent.disabled", convert: not},
+	}
+
+	// Node Agent overrides
+	mappings = append(mappings, componentMappings("agents", v2alpha1.NodeAgentComponentName, "agent")...)
+	mappings = append(mappings,
+		valueMapping{helm: "agents.useHostNetwork", dda: "override.nodeAgent.hostNetwork"},
+		valueMapping{helm: "agents.updateStrategy", dda: "override.nodeAgent.updateStrategy"},
+	)
+	for _, container := range []struct{ helm, dda string }{
+		{helm: "agent", dda: "agent"},
+		{helm: "traceAgent", dda: "trace-agent"},
+		{helm: "processAgent", dda: "process-agent"},
+		{helm: "systemProbe", dda: "system-probe"},
+		{helm: "securityAgent", dda: "security-agent"},
+	} {
+		helmPrefix := fmt.Sprintf("agents.containers.%s", container.helm)
+		mappings = append(mappings, containerMappings(helmPrefix, v2alpha1.NodeAgentComponentName, container.dda)...)
+		mappings = append(mappings, valueMapping{helm: helmPrefix + ".securityContext", dda: fmt.Sprintf("override.nodeAgent.containers.%s.securityContext", container.dda)})
+	}
+
+	// Cluster Agent overrides
+	mappings = append(mappings, componentMappings("clusterAgent", v2alpha1.ClusterAgentComponentName, "cluster-agent")...)
+	mappings = append(mappings, containerMappings("clusterAgent", v2alpha1.ClusterAgentComponentName, "cluster-agent")...)
+	mappings = append(mappings, containerMappings("clusterAgent.containers.clusterAgent", v2alpha1.ClusterAgentComponentName, "cluster-agent")...)
+	mappings = append(mappings,
+		valueMapping{helm: "clusterAgent.containers.clusterAgent.securityContext", dda: "override.clusterAgent.containers.cluster-agent.securityContext"},
+		valueMapping{helm: "clusterAgent.securityContext", dda: "override.clusterAgent.securityContext"},
+		valueMapping{helm: "clusterAgent.replicas", dda: "override.clusterAgent.replicas"},
+		valueMapping{helm: "clusterAgent.strategy", dda: "override.clusterAgent.updateStrategy"},
+		valueMapping{helm: "clusterAgent.createPodDisruptionBudget", dda: "override.clusterAgent.createPodDisruptionBudget"},
+		valueMapping{helm: "clusterAgent.pdb.create", dda: "override.clusterAgent.createPodDisruptionBudget"},
+		valueMapping{helm: "clusterAgent.useHostNetwork", dda: "override.clusterAgent.hostNetwork"},
+	)
+
+	// Cluster Checks Runner overrides
+	mappings = append(mappings, componentMappings("clusterChecksRunner", v2alpha1.ClusterChecksRunnerComponentName, "agent")...)
+	mappings = append(mappings, containerMappings("clusterChecksRunner", v2alpha1.ClusterChecksRunnerComponentName, "agent")...)
+	mappings = append(mappings,
+		valueMapping{helm: "clusterChecksRunner.securityContext", dda: "override.clusterChecksRunner.securityContext"},
+		valueMapping{helm: "clusterChecksRunner.replicas", dda: "override.clusterChecksRunner.replicas"},
+		valueMapping{helm: "clusterChecksRunner.strategy", dda: "override.clusterChecksRunner.updateStrategy"},
+		valueMapping{helm: "clusterChecksRunner.createPodDisruptionBudget", dda: "override.clusterChecksRunner.createPodDisruptionBudget"},
+		valueMapping{helm: "clusterChecksRunner.pdb.create", dda: "override.clusterChecksRunner.createPodDisruptionBudget"},
+	)
+
+	mappingsByPath := make(map[string]valueMapping, len(mappings))
+	for _, mapping := range mappings {
+		mappingsByPath[mapping.helm] = mapping
+	}
+	return mappingsByPath
+}
+
+// componentMappings returns the mappings of the pod level values shared by the chart components.
+// The volume mounts are defined at the component level in the chart, and are mapped to the main container.
+func componentMappings(helmPrefix string, component v2alpha1.ComponentName, mainContainer string) []valueMapping {
+	ddaPrefix := fmt.Sprintf("override.%s", component)
+	return []valueMapping{
+		{helm: helmPrefix + ".image.name", dda: ddaPrefix + ".image.name"},
+		{helm: helmPrefix + ".image.repository", dda: ddaPrefix + ".image.name"},
+		{helm: helmPrefix + ".image.tag", dda: ddaPrefix + ".image.tag"},
+		{helm: helmPrefix + ".image.tagSuffix", dda: ddaPrefix + ".image.jmxEnabled", convert: equals("jmx")},
+		{helm: helmPrefix + ".image.pullPolicy", dda: ddaPrefix + ".image.pullPolicy"},
+		{helm: helmPrefix + ".image.pullSecrets", dda: ddaPrefix + ".image.pullSecrets"},
+		{helm: helmPrefix + ".nodeSelector", dda: ddaPrefix + ".nodeSelector"},
+		{helm: helmPrefix + ".tolerations", dda: ddaPrefix + ".tolerations"},
+		{helm: helmPrefix + ".affinity", dda: ddaPrefix + ".affinity"},
+		{helm: helmPrefix + ".priorityClassName", dda: ddaPrefix + ".priorityClassName"},
+		{helm: helmPrefix + ".podLabels", dda: ddaPrefix + ".labels"},
+		{helm: helmPrefix + ".podAnnotations", dda: ddaPrefix + ".annotations"},
+		{helm: helmPrefix + ".env", dda: ddaPrefix + ".env"},
+		{helm: helmPrefix + ".envFrom", dda: ddaPrefix + ".envFrom"},
+		{helm: helmPrefix + ".volumes", dda: ddaPrefix + ".volumes"},
+		{helm: helmPrefix + ".volumeMounts", dda: fmt.Sprintf("%s.containers.%s.volumeMounts", ddaPrefix, mainContainer)},
+		{helm: helmPrefix + ".dnsConfig", dda: ddaPrefix + ".dnsConfig"},
+		{helm: helmPrefix + ".rbac.create", dda: ddaPrefix + ".createRbac"},
+		{helm: helmPrefix + ".rbac.serviceAccountName", dda: ddaPrefix + ".serviceAccountName"},
+		{helm: helmPrefix + ".rbac.serviceAccountAnnotations", dda: ddaPrefix + ".serviceAccountAnnotations"},
+	}
+}
+
+// containerMappings returns the mappings of the container level values.
+// The security context is mapped separately, as it's defined at the pod level for some components of the chart.
+func containerMappings(helmPrefix string, component v2alpha1.ComponentName, container string) []valueMapping {
+	ddaPrefix := fmt.Sprintf("override.%s.containers.%s", component, container)
+	return []valueMapping{
+		{helm: helmPrefix + ".resources", dda: ddaPrefix + ".resources"},
+		{helm: helmPrefix + ".logLevel", dda: ddaPrefix + ".logLevel"},
+		{helm: helmPrefix + ".livenessProbe", dda: ddaPrefix + ".livenessProbe"},
+		{helm: helmPrefix + ".readinessProbe", dda: ddaPrefix + ".readinessProbe"},
+		{helm: helmPrefix + ".startupProbe", dda: ddaPrefix + ".startupProbe"},
+		{helm: helmPrefix + ".command", dda: ddaPrefix + ".command"},
+		{helm: helmPrefix + ".args", dda: ddaPrefix + ".args"},
+	}
+}
+
+// secretConfig converts a secret name to a secret reference, using the key name of the chart
+func secretConfig(keyName string) func(interface{}) (interface{}, error) {
+	return func(value interface{}) (interface{}, error) {
+		name, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a secret name, got %v", value)
+		}
+		return map[string]interface{}{"secretName": name, "keyName": keyName}, nil
+	}
+}
+
+// customConfiguration converts an Agent configuration to a custom configuration file
+func customConfiguration(fileName apicommon.AgentConfigFileName) func(interface{}) (interface{}, error) {
+	return func(value interface{}) (interface{}, error) {
+		configData, err := yamlString(value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{string(fileName): map[string]interface{}{"configData": configData}}, nil
+	}
+}
+
+// yamlString converts a structured value to its YAML representation, strings are kept as is
+func yamlString(value interface{}) (interface{}, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func not(value interface{}) (interface{}, error) {
+	b, ok := value.(bool)
+	if !ok {
+		return nil, fmt.Errorf("expected a boolean, got %v", value)
+	}
+	return !b, nil
+}
+
+func equals(expected string) func(interface{}) (interface{}, error) {
+	return func(value interface{}) (interface{}, error) {
+		return value == expected, nil
+	}
+}
+
+// migration is the result of the conversion of chart values to a DatadogAgent spec
+type migration struct {
+	// spec is the DatadogAgent spec, as unstructured content
+	spec map[string]interface{}
+	// unmapped lists the chart values without equivalent in the DatadogAgent spec
+	unmapped []string
+	// invalid lists the chart values which could not be converted
+	invalid []string
+}
+
+// migrateValues converts chart values to a DatadogAgent spec
+func migrateValues(values map[string]interface{}) (*v2alpha1.DatadogAgentSpec, *migration, error) {
+	m := &migration{spec: map[string]interface{}{}}
+	for _, key := range sortedKeys(values) {
+		m.walk(key, values[key])
+	}
+
+	spec := &v2alpha1.DatadogAgentSpec{}
+	if err := decodeSpec(m.spec, spec); err != nil {
+		return nil, nil, fmt.Errorf("unable to build the DatadogAgent spec: %w", err)
+	}
+	return spec, m, nil
+}
+
+// walk maps a value, or the values it contains when it has no mapping
+func (m *migration) walk(path string, value interface{}) {
+	if mapping, found := valueMappings[path]; found {
+		m.apply(mapping, value)
+		return
+	}
+
+	if isEmpty(value) {
+		return
+	}
+
+	if values, ok := value.(map[string]interface{}); ok {
+		for _, key := range sortedKeys(values) {
+			m.walk(path+"."+key, values[key])
+		}
+		return
+	}
+
+	m.unmapped = append(m.unmapped, path)
+}
+
+// apply sets the field of the DatadogAgent spec mapped to a chart value
+func (m *migration) apply(mapping valueMapping, value interface{}) {
+	if isEmpty(value) {
+		return
+	}
+
+	var err error
+	if mapping.convert != nil {
+		if value, err = mapping.convert(value); err != nil {
+			m.invalid = append(m.invalid, fmt.Sprintf("%s: %v", mapping.helm, err))
+			return
+		}
+	}
+
+	fragment := map[string]interface{}{}
+	setPath(fragment, strings.Split(mapping.dda, "."), value)
+
+	// Check the type of the value before merging it
+	if err = decodeSpec(fragment, &v2alpha1.DatadogAgentSpec{}); err != nil {
+		m.invalid = append(m.invalid, fmt.Sprintf("%s: %v", mapping.helm, err))
+		return
+	}
+	mergeValues(m.spec, fragment)
+}
+
+// decodeSpec decodes unstructured content to a DatadogAgent spec, rejecting unknown fields
+func decodeSpec(content map[string]interface{}, spec *v2alpha1.DatadogAgentSpec) error {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(spec)
+}
+
+func setPath(content map[string]interface{}, path []string, value interface{}) {
+	for _, key := range path[:len(path)-1] {
+		child, ok := content[key].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			content[key] = child
+		}
+		content = child
+	}
+	content[path[len(path)-1]] = value
+}
+
+// mergeValues merges src into dst, the maps are merged recursively and the other values of src take precedence
+func mergeValues(dst, src map[string]interface{}) {
+	for key, srcValue := range src {
+		srcMap, srcIsMap := srcValue.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[key] = srcValue
+	}
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,160 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+func Test_migrateValues(t *testing.T) {
+	tests := []struct {
+		name         string
+		values       string
+		wantSpec     func(t *testing.T, spec *v2alpha1.DatadogAgentSpec)
+		wantUnmapped []string
+		wantInvalid  []string
+	}{
+		{
+			name: "global config and features",
+			values: `
+datadog:
+  apiKeyExistingSecret: datadog-secret
+  site: datadoghq.eu
+  clusterName: prod
+  tags: ["env:prod"]
+  logs:
+    enabled: true
+    containerCollectAll: true
+  dogstatsd:
+    port: 8125
+    useHostPort: true
+clusterChecksRunner:
+  enabled: true
+`,
+			wantSpec: func(t *testing.T, spec *v2alpha1.DatadogAgentSpec) {
+				assert.Equal(t, &v2alpha1.SecretConfig{SecretName: "datadog-secret", KeyName: "api-key"}, spec.Global.Credentials.APISecret)
+				assert.Equal(t, ptr.To("datadoghq.eu"), spec.Global.Site)
+				assert.Equal(t, ptr.To("prod"), spec.Global.ClusterName)
+				assert.Equal(t, []string{"env:prod"}, spec.Global.Tags)
+				assert.Equal(t, ptr.To(true), spec.Features.LogCollection.Enabled)
+				assert.Equal(t, ptr.To(true), spec.Features.LogCollection.ContainerCollectAll)
+				assert.Equal(t, ptr.To[int32](8125), spec.Features.Dogstatsd.HostPortConfig.Port)
+				assert.Equal(t, ptr.To(true), spec.Features.Dogstatsd.HostPortConfig.Enabled)
+				assert.Equal(t, ptr.To(true), spec.Features.ClusterChecks.UseClusterChecksRunners)
+			},
+		},
+		{
+			name: "component overrides",
+			values: `
+agents:
+  image:
+    tag: 7.60.0
+    tagSuffix: jmx
+  tolerations:
+  - operator: Exists
+  containers:
+    traceAgent:
+      resources:
+        requests:
+          cpu: 100m
+clusterAgent:
+  enabled: false
+  replicas: 2
+`,
+			wantSpec: func(t *testing.T, spec *v2alpha1.DatadogAgentSpec) {
+				nodeAgent := spec.Override[v2alpha1.NodeAgentComponentName]
+				require.NotNil(t, nodeAgent)
+				assert.Equal(t, "7.60.0", nodeAgent.Image.Tag)
+				assert.True(t, nodeAgent.Image.JMXEnabled)
+				assert.Equal(t, []corev1.Toleration{{Operator: corev1.TolerationOpExists}}, nodeAgent.Tolerations)
+				assert.Equal(t, resource.MustParse("100m"), nodeAgent.Containers["trace-agent"].Resources.Requests[corev1.ResourceCPU])
+
+				clusterAgent := spec.Override[v2alpha1.ClusterAgentComponentName]
+				require.NotNil(t, clusterAgent)
+				assert.Equal(t, ptr.To(true), clusterAgent.Disabled)
+				assert.Equal(t, ptr.To[int32](2), clusterAgent.Replicas)
+			},
+		},
+		{
+			name: "custom configurations",
+			values: `
+datadog:
+  confd:
+    redisdb.yaml: |-
+      instances:
+        - host: redis
+agents:
+  customAgentConfig:
+    kubelet_wait_on_missing_container: 10
+`,
+			wantSpec: func(t *testing.T, spec *v2alpha1.DatadogAgentSpec) {
+				nodeAgent := spec.Override[v2alpha1.NodeAgentComponentName]
+				require.NotNil(t, nodeAgent)
+				assert.Equal(t, map[string]string{"redisdb.yaml": "instances:\n  - host: redis"}, nodeAgent.ExtraConfd.ConfigDataMap)
+				assert.Equal(t, ptr.To("kubelet_wait_on_missing_container: 10\n"), nodeAgent.CustomConfigurations[apicommon.AgentGeneralConfigFile].ConfigData)
+			},
+		},
+		{
+			name: "unmapped and invalid values",
+			values: `
+targetSystem: linux
+datadog:
+  leaderElection: true
+  logs:
+    enabled: "yes"
+  kubelet:
+    host: {}
+  tags: []
+`,
+			wantSpec: func(t *testing.T, spec *v2alpha1.DatadogAgentSpec) {
+				assert.Nil(t, spec.Features)
+			},
+			wantUnmapped: []string{"datadog.leaderElection", "targetSystem"},
+			wantInvalid:  []string{"datadog.logs.enabled"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal([]byte(tt.values), &values))
+
+			spec, m, err := migrateValues(values)
+			require.NoError(t, err)
+
+			tt.wantSpec(t, spec)
+			assert.Equal(t, tt.wantUnmapped, m.unmapped)
+			require.Len(t, m.invalid, len(tt.wantInvalid))
+			for i := range tt.wantInvalid {
+				assert.Contains(t, m.invalid[i], tt.wantInvalid[i])
+			}
+		})
+	}
+}
+
+func Test_mergeValues(t *testing.T) {
+	dst := map[string]interface{}{
+		"datadog": map[string]interface{}{"site": "datadoghq.com", "tags": []interface{}{"a"}},
+	}
+	src := map[string]interface{}{
+		"datadog": map[string]interface{}{"site": "datadoghq.eu", "clusterName": "prod"},
+	}
+
+	mergeValues(dst, src)
+
+	assert.Equal(t, map[string]interface{}{
+		"datadog": map[string]interface{}{"site": "datadoghq.eu", "clusterName": "prod", "tags": []interface{}{"a"}},
+	}, dst)
+}
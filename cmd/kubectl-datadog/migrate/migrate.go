@@ -0,0 +1,25 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/migrate/helm"
+)
+
+// New provides a cobra command wrapping options for "migrate" sub command
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate [subcommand] [flags]",
+		Short: "Migrate an existing Datadog installation to the operator",
+	}
+
+	cmd.AddCommand(helm.New(streams))
+
+	return cmd
+}
@@ -0,0 +1,106 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/datadogresource"
+)
+
+// triggeringStates are the states of the triggered monitor groups
+var triggeringStates = map[datadogV1.MonitorOverallStates]bool{
+	datadogV1.MONITOROVERALLSTATES_ALERT:   true,
+	datadogV1.MONITOROVERALLSTATES_WARN:    true,
+	datadogV1.MONITOROVERALLSTATES_NO_DATA: true,
+}
+
+// New provides a cobra command wrapping the DatadogMonitor sub commands
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	return datadogresource.New(streams, kind{})
+}
+
+// kind describes the DatadogMonitor resource
+type kind struct{}
+
+func (kind) Name() string { return "monitor" }
+
+func (kind) Kind() string { return "DatadogMonitor" }
+
+func (kind) NewObject() client.Object { return &v1alpha1.DatadogMonitor{} }
+
+func (kind) NewList() client.ObjectList { return &v1alpha1.DatadogMonitorList{} }
+
+func (kind) Objects(list client.ObjectList) []client.Object {
+	items := list.(*v1alpha1.DatadogMonitorList).Items
+	objects := make([]client.Object, 0, len(items))
+	for i := range items {
+		objects = append(objects, &items[i])
+	}
+	return objects
+}
+
+func (kind) ID(obj client.Object) string {
+	if id := obj.(*v1alpha1.DatadogMonitor).Status.ID; id != 0 {
+		return strconv.Itoa(id)
+	}
+	return ""
+}
+
+func (kind) Path(id string) string { return "/monitors/" + id }
+
+func (k kind) Fields(obj client.Object) []datadogresource.Field {
+	monitor := obj.(*v1alpha1.DatadogMonitor)
+
+	triggered := make([]string, 0, len(monitor.Status.TriggeredState))
+	for _, state := range monitor.Status.TriggeredState {
+		triggered = append(triggered, fmt.Sprintf("%s: %s since %s", state.MonitorGroup, state.State, state.LastTransitionTime.UTC().Format(time.RFC3339)))
+	}
+
+	return []datadogresource.Field{
+		{Name: "ID", Value: k.ID(obj)},
+		{Name: "TYPE", Value: string(monitor.Spec.Type)},
+		{Name: "STATE", Value: string(monitor.Status.MonitorState)},
+		{Name: "TRIGGERED", Value: strconv.Itoa(len(triggered)), Items: triggered},
+		{Name: "SYNC STATUS", Value: string(monitor.Status.MonitorStateSyncStatus)},
+	}
+}
+
+func (kind) LiveFieldNames() []string {
+	return []string{"LIVE STATE", "LIVE TRIGGERED"}
+}
+
+func (kind) LiveFields(ctx context.Context, api *datadogapi.APIClient, obj client.Object) ([]datadogresource.Field, error) {
+	monitor := obj.(*v1alpha1.DatadogMonitor)
+	m, _, err := datadogV1.NewMonitorsApi(api).GetMonitor(ctx, int64(monitor.Status.ID), *datadogV1.NewGetMonitorOptionalParameters().WithGroupStates("all"))
+	if err != nil {
+		return nil, err
+	}
+
+	var triggered []string
+	for group, state := range m.GetState().Groups {
+		if triggeringStates[state.GetStatus()] {
+			triggered = append(triggered, fmt.Sprintf("%s: %s", group, state.GetStatus()))
+		}
+	}
+	sort.Strings(triggered)
+
+	return []datadogresource.Field{
+		{Name: "LIVE STATE", Value: string(m.GetOverallState())},
+		{Name: "LIVE TRIGGERED", Value: strconv.Itoa(len(triggered)), Items: triggered},
+	}, nil
+}
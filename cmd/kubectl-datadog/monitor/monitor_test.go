@@ -0,0 +1,43 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/datadogresource"
+)
+
+func Test_kind_Fields(t *testing.T) {
+	since := metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	monitor := &v1alpha1.DatadogMonitor{
+		Spec: v1alpha1.DatadogMonitorSpec{Type: v1alpha1.DatadogMonitorTypeMetric},
+		Status: v1alpha1.DatadogMonitorStatus{
+			ID:                     12345,
+			MonitorState:           v1alpha1.DatadogMonitorStateAlert,
+			MonitorStateSyncStatus: v1alpha1.MonitorStateSyncStatusOK,
+			TriggeredState: []v1alpha1.DatadogMonitorTriggeredState{
+				{MonitorGroup: "host:foo", State: v1alpha1.DatadogMonitorStateAlert, LastTransitionTime: since},
+			},
+		},
+	}
+
+	assert.Equal(t, []datadogresource.Field{
+		{Name: "ID", Value: "12345"},
+		{Name: "TYPE", Value: "metric alert"},
+		{Name: "STATE", Value: "Alert"},
+		{Name: "TRIGGERED", Value: "1", Items: []string{"host:foo: Alert since 2024-01-02T03:04:05Z"}},
+		{Name: "SYNC STATUS", Value: "OK"},
+	}, kind{}.Fields(monitor))
+
+	assert.Equal(t, "", kind{}.ID(&v1alpha1.DatadogMonitor{}))
+	assert.Equal(t, "/monitors/12345", kind{}.Path("12345"))
+}
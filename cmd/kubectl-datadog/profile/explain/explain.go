@@ -0,0 +1,154 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package explain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/agentprofile"
+	"github.com/DataDog/datadog-operator/pkg/plugin/common"
+)
+
+var explainExample = `
+  # explain which DatadogAgentProfile applies to the node foo
+  %[1]s explain foo
+`
+
+// options provides information required by profile explain command.
+type options struct {
+	genericclioptions.IOStreams
+	common.Options
+	args     []string
+	nodeName string
+}
+
+// newOptions provides an instance of options with default values.
+func newOptions(streams genericclioptions.IOStreams) *options {
+	o := &options{
+		IOStreams: streams,
+	}
+	o.SetConfigFlags()
+	return o
+}
+
+// New provides a cobra command wrapping options for "explain" sub command.
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+	cmd := &cobra.Command{
+		Use:          "explain [node name] [flags]",
+		Short:        "Explain which DatadogAgentProfile applies to a node",
+		Example:      fmt.Sprintf(explainExample, "kubectl datadog profile"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+
+			return o.run()
+		},
+	}
+
+	o.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command.
+func (o *options) complete(cmd *cobra.Command, args []string) error {
+	o.args = args
+	if len(args) > 0 {
+		o.nodeName = args[0]
+	}
+
+	return o.Init(cmd)
+}
+
+// validate ensures that all required arguments and flag values are provided.
+func (o *options) validate() error {
+	if len(o.args) != 1 {
+		return errors.New("exactly one node name is required")
+	}
+
+	return nil
+}
+
+// run runs the explain command.
+func (o *options) run() error {
+	node := &corev1.Node{}
+	err := o.Client.Get(context.TODO(), client.ObjectKey{Name: o.nodeName}, node)
+	if err != nil && apierrors.IsNotFound(err) {
+		return fmt.Errorf("node %s not found", o.nodeName)
+	} else if err != nil {
+		return fmt.Errorf("unable to get node: %w", err)
+	}
+
+	profileList := &v1alpha1.DatadogAgentProfileList{}
+	if err = o.Client.List(context.TODO(), profileList); err != nil {
+		return fmt.Errorf("unable to list DatadogAgentProfile: %w", err)
+	}
+
+	applied, explanations := agentprofile.ExplainNode(profileList.Items, node)
+
+	currentLabel := node.Labels[agentprofile.ProfileLabelKey]
+	if currentLabel == "" {
+		currentLabel = "<none>"
+	}
+	fmt.Fprintf(o.Out, "Node:            %s\n", node.Name)
+	fmt.Fprintf(o.Out, "Profile label:   %s\n", currentLabel)
+	fmt.Fprintf(o.Out, "Applied profile: %s\n\n", applied.String())
+
+	if len(explanations) == 0 {
+		fmt.Fprintln(o.Out, "No DatadogAgentProfile found")
+		return nil
+	}
+
+	table := newTable(o.Out)
+	for _, explanation := range explanations {
+		table.Append([]string{
+			strconv.Itoa(int(explanation.Priority)),
+			explanation.Profile.Namespace,
+			explanation.Profile.Name,
+			strconv.FormatBool(explanation.Matches),
+			strconv.FormatBool(explanation.Applied),
+			explanation.Reason,
+		})
+	}
+
+	// Send output
+	table.Render()
+
+	return nil
+}
+
+func newTable(out io.Writer) *tablewriter.Table {
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"PRIORITY", "NAMESPACE", "NAME", "MATCHES", "APPLIED", "REASON"})
+	table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderLine(false)
+	table.SetAutoWrapText(false)
+	return table
+}
@@ -0,0 +1,41 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package profile
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/profile/explain"
+)
+
+// options provides information required by profile command
+type options struct {
+	genericclioptions.IOStreams
+	configFlags *genericclioptions.ConfigFlags
+}
+
+// newOptions provides an instance of options with default values
+func newOptions(streams genericclioptions.IOStreams) *options {
+	return &options{
+		configFlags: genericclioptions.NewConfigFlags(false),
+		IOStreams:   streams,
+	}
+}
+
+// New provides a cobra command wrapping options for "profile" sub command
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "profile [subcommand] [flags]",
+	}
+
+	cmd.AddCommand(explain.New(streams))
+
+	o := newOptions(streams)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
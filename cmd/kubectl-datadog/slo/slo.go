@@ -0,0 +1,117 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package slo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	datadogapi "github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	datadogV1 "github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/datadogresource"
+)
+
+// timeframes are the durations of the SLO timeframes
+var timeframes = map[v1alpha1.DatadogSLOTimeFrame]time.Duration{
+	v1alpha1.DatadogSLOTimeFrame7d:  7 * 24 * time.Hour,
+	v1alpha1.DatadogSLOTimeFrame30d: 30 * 24 * time.Hour,
+	v1alpha1.DatadogSLOTimeFrame90d: 90 * 24 * time.Hour,
+}
+
+// New provides a cobra command wrapping the DatadogSLO sub commands
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	return datadogresource.New(streams, kind{})
+}
+
+// kind describes the DatadogSLO resource
+type kind struct{}
+
+func (kind) Name() string { return "slo" }
+
+func (kind) Kind() string { return "DatadogSLO" }
+
+func (kind) NewObject() client.Object { return &v1alpha1.DatadogSLO{} }
+
+func (kind) NewList() client.ObjectList { return &v1alpha1.DatadogSLOList{} }
+
+func (kind) Objects(list client.ObjectList) []client.Object {
+	items := list.(*v1alpha1.DatadogSLOList).Items
+	objects := make([]client.Object, 0, len(items))
+	for i := range items {
+		objects = append(objects, &items[i])
+	}
+	return objects
+}
+
+func (kind) ID(obj client.Object) string { return obj.(*v1alpha1.DatadogSLO).Status.ID }
+
+func (kind) Path(id string) string { return "/slo?slo_id=" + url.QueryEscape(id) }
+
+func (kind) Fields(obj client.Object) []datadogresource.Field {
+	slo := obj.(*v1alpha1.DatadogSLO)
+	return []datadogresource.Field{
+		{Name: "ID", Value: slo.Status.ID},
+		{Name: "TYPE", Value: string(slo.Spec.Type)},
+		{Name: "TIMEFRAME", Value: string(slo.Spec.Timeframe)},
+		{Name: "TARGET", Value: slo.Spec.TargetThreshold.String()},
+		{Name: "SYNC STATUS", Value: string(slo.Status.SyncStatus)},
+	}
+}
+
+func (kind) LiveFieldNames() []string {
+	return []string{"SLI", "ERROR BUDGET REMAINING"}
+}
+
+func (kind) LiveFields(ctx context.Context, api *datadogapi.APIClient, obj client.Object) ([]datadogresource.Field, error) {
+	slo := obj.(*v1alpha1.DatadogSLO)
+	timeframe, found := timeframes[slo.Spec.Timeframe]
+	if !found {
+		return nil, fmt.Errorf("unsupported timeframe %q", slo.Spec.Timeframe)
+	}
+
+	now := time.Now()
+	history, _, err := datadogV1.NewServiceLevelObjectivesApi(api).GetSLOHistory(ctx, slo.Status.ID, now.Add(-timeframe).Unix(), now.Unix())
+	if err != nil {
+		return nil, err
+	}
+	if history.Data == nil || history.Data.Overall == nil {
+		return nil, errors.New("no SLO history")
+	}
+
+	return []datadogresource.Field{
+		{Name: "SLI", Value: formatPercent(history.Data.Overall.SliValue.Get())},
+		{Name: "ERROR BUDGET REMAINING", Value: formatPercent(errorBudgetRemaining(history.Data.Overall.ErrorBudgetRemaining, slo.Spec.Timeframe))},
+	}, nil
+}
+
+// errorBudgetRemaining returns the error budget remaining over the timeframe of the SLO
+func errorBudgetRemaining(budgets map[string]float64, timeframe v1alpha1.DatadogSLOTimeFrame) *float64 {
+	if budget, found := budgets[string(timeframe)]; found {
+		return &budget
+	}
+	// The history is requested over the timeframe of the SLO, it is the only one returned
+	var remaining *float64
+	for _, budget := range budgets {
+		remaining = &budget
+	}
+	return remaining
+}
+
+func formatPercent(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', 3, 64) + "%"
+}
@@ -0,0 +1,52 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package slo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+)
+
+func Test_errorBudgetRemaining(t *testing.T) {
+	tests := []struct {
+		name      string
+		budgets   map[string]float64
+		timeframe v1alpha1.DatadogSLOTimeFrame
+		want      string
+	}{
+		{
+			name:      "budget of the timeframe",
+			budgets:   map[string]float64{"7d": 42.12345, "30d": 12},
+			timeframe: v1alpha1.DatadogSLOTimeFrame7d,
+			want:      "42.123%",
+		},
+		{
+			name:      "budget of the requested range",
+			budgets:   map[string]float64{"custom": -3.5},
+			timeframe: v1alpha1.DatadogSLOTimeFrame30d,
+			want:      "-3.500%",
+		},
+		{
+			name:      "no budget",
+			timeframe: v1alpha1.DatadogSLOTimeFrame90d,
+			want:      "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatPercent(errorBudgetRemaining(tt.budgets, tt.timeframe)))
+		})
+	}
+}
+
+func Test_kind_Path(t *testing.T) {
+	assert.Equal(t, "/slo?slo_id=abc123", kind{}.Path("abc123"))
+	assert.Equal(t, "99.900%", formatPercent(ptr.To(99.9)))
+}
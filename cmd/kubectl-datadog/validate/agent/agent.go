@@ -0,0 +1,175 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+var agentExample = `
+  # lint a DatadogAgent manifest before applying it
+  %[1]s agent -f datadog-agent.yaml
+`
+
+// options provides information required by validate agent command
+type options struct {
+	genericclioptions.IOStreams
+	filename string
+}
+
+// newOptions provides an instance of options with default values
+func newOptions(streams genericclioptions.IOStreams) *options {
+	return &options{
+		IOStreams: streams,
+	}
+}
+
+// New provides a cobra command wrapping options for "agent" sub command
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+	cmd := &cobra.Command{
+		Use:          "agent [flags]",
+		Short:        "Validate a DatadogAgent manifest offline",
+		Example:      fmt.Sprintf(agentExample, "kubectl datadog validate"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				return err
+			}
+
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.filename, "filename", "f", "", "DatadogAgent manifest to validate, - reads the standard input")
+
+	return cmd
+}
+
+// validate ensures that all required arguments and flag values are provided
+func (o *options) validate() error {
+	if o.filename == "" {
+		return errors.New("the manifest file is required")
+	}
+
+	return nil
+}
+
+// run runs the validate agent command
+func (o *options) run() error {
+	var in io.Reader = o.In
+	if o.filename != "-" {
+		f, err := os.Open(o.filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	manifests, err := loadManifests(in)
+	if err != nil {
+		return err
+	}
+	if len(manifests) == 0 {
+		return fmt.Errorf("no DatadogAgent found in %s", o.filename)
+	}
+
+	errorCount := 0
+	for _, m := range manifests {
+		if len(m.findings) == 0 {
+			fmt.Fprintf(o.Out, "DatadogAgent %s: no problem found\n", m.name)
+			continue
+		}
+
+		fmt.Fprintf(o.Out, "DatadogAgent %s: %d problem(s) found\n", m.name, len(m.findings))
+		for _, f := range m.findings {
+			fmt.Fprintf(o.Out, "  %-7s %s: %s\n", f.Severity, f.Field, f.Message)
+			if f.Severity == severityError {
+				errorCount++
+			}
+		}
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("%d error(s) found", errorCount)
+	}
+	return nil
+}
+
+// manifest is a linted DatadogAgent
+type manifest struct {
+	name     string
+	findings []finding
+}
+
+// loadManifests decodes and lints the DatadogAgents of a YAML or JSON stream, the other objects are ignored
+func loadManifests(in io.Reader) ([]manifest, error) {
+	var manifests []manifest
+	decoder := k8syaml.NewYAMLOrJSONDecoder(in, 4096)
+	for {
+		raw := json.RawMessage{}
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("unable to parse the manifest: %w", err)
+		}
+
+		typeMeta := struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		}{}
+		if err := json.Unmarshal(raw, &typeMeta); err != nil || typeMeta.Kind != "DatadogAgent" {
+			continue
+		}
+
+		m, err := decodeManifest(raw, typeMeta.APIVersion)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, m)
+	}
+
+	return manifests, nil
+}
+
+// decodeManifest lints a DatadogAgent, the fields unknown to the API are reported as errors
+func decodeManifest(raw []byte, apiVersion string) (manifest, error) {
+	dda := &v2alpha1.DatadogAgent{}
+	if err := json.Unmarshal(raw, dda); err != nil {
+		return manifest{}, fmt.Errorf("unable to decode the DatadogAgent: %w", err)
+	}
+	m := manifest{name: dda.Name}
+
+	if apiVersion != v2alpha1.GroupVersion.String() {
+		// The v1alpha1 DatadogAgent is no longer served by the operator
+		m.findings = append(m.findings, finding{
+			Severity: severityError,
+			Field:    "apiVersion",
+			Message:  fmt.Sprintf("%s is not supported, use %s", apiVersion, v2alpha1.GroupVersion.String()),
+		})
+		return m, nil
+	}
+
+	if err := yaml.UnmarshalStrict(raw, &v2alpha1.DatadogAgent{}); err != nil {
+		m.findings = append(m.findings, finding{Severity: severityError, Field: "spec", Message: err.Error()})
+	}
+
+	m.findings = append(m.findings, lint(dda)...)
+	return m, nil
+}
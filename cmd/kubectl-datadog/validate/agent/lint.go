@@ -0,0 +1,372 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package agent
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	// Registers the features built by feature.BuildFeatures
+	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	componentagent "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/agent"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/defaults"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/apm"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/kubernetesstatecore"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/orchestratorexplorer"
+	featutils "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/utils"
+	"github.com/DataDog/datadog-operator/pkg/agentprofile"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/utils"
+)
+
+// severity of a finding
+type severity string
+
+const (
+	// severityError is used for configurations that are rejected or not deployed as written
+	severityError severity = "error"
+	// severityWarning is used for configurations that are deployed with a degraded behavior
+	severityWarning severity = "warning"
+)
+
+// finding is a problem found in a DatadogAgent
+type finding struct {
+	Severity severity
+	Field    string
+	Message  string
+}
+
+// components are the components of a DatadogAgent, in the output order
+var components = []v2alpha1.ComponentName{
+	v2alpha1.NodeAgentComponentName,
+	v2alpha1.ClusterAgentComponentName,
+	v2alpha1.ClusterChecksRunnerComponentName,
+}
+
+// imageRequirement is the minimum image version of a component needed by a feature
+type imageRequirement struct {
+	feature    feature.IDType
+	minVersion string
+	// component returns the component running the feature
+	component func(dda *v2alpha1.DatadogAgent) v2alpha1.ComponentName
+	// applies returns whether the configuration of the feature depends on the version
+	applies func(dda *v2alpha1.DatadogAgent) bool
+	// degradation describes what happens when the image is older than the minimum version
+	degradation string
+}
+
+// imageRequirements are the minimum versions encoded in the feature packages
+var imageRequirements = []imageRequirement{
+	{
+		feature:     feature.KubernetesStateCoreIDType,
+		minVersion:  kubernetesstatecore.CRDAPIServiceCollectionMinVersion,
+		component:   clusterCheckComponent,
+		applies:     always,
+		degradation: "the CRD and APIService metrics are not collected",
+	},
+	{
+		feature:     feature.OrchestratorExplorerIDType,
+		minVersion:  orchestratorexplorer.NoProcessAgentMinVersion,
+		component:   nodeAgent,
+		applies:     always,
+		degradation: "the process-agent container is still deployed",
+	},
+	{
+		feature:    feature.APMIDType,
+		minVersion: apm.MinInstrumentationTargetsVersion,
+		component:  func(*v2alpha1.DatadogAgent) v2alpha1.ComponentName { return v2alpha1.ClusterAgentComponentName },
+		applies: func(dda *v2alpha1.DatadogAgent) bool {
+			return dda.Spec.Features.APM.SingleStepInstrumentation != nil && len(dda.Spec.Features.APM.SingleStepInstrumentation.Targets) > 0
+		},
+		degradation: "features.apm.instrumentation.targets is ignored",
+	},
+	{
+		feature:     feature.LiveProcessIDType,
+		minVersion:  featutils.ProcessConfigRunInCoreAgentMinVersion,
+		component:   nodeAgent,
+		applies:     runProcessChecksInCoreAgent,
+		degradation: "the process checks run in the process-agent container",
+	},
+	{
+		feature:     feature.LiveContainerIDType,
+		minVersion:  featutils.ProcessConfigRunInCoreAgentMinVersion,
+		component:   nodeAgent,
+		applies:     runProcessChecksInCoreAgent,
+		degradation: "the container checks run in the process-agent container",
+	},
+	{
+		feature:     feature.ProcessDiscoveryIDType,
+		minVersion:  featutils.ProcessConfigRunInCoreAgentMinVersion,
+		component:   nodeAgent,
+		applies:     runProcessChecksInCoreAgent,
+		degradation: "the process discovery check runs in the process-agent container",
+	},
+}
+
+func always(*v2alpha1.DatadogAgent) bool { return true }
+
+func nodeAgent(*v2alpha1.DatadogAgent) v2alpha1.ComponentName {
+	return v2alpha1.NodeAgentComponentName
+}
+
+// clusterCheckComponent returns the component running the cluster checks
+func clusterCheckComponent(dda *v2alpha1.DatadogAgent) v2alpha1.ComponentName {
+	clusterChecks := dda.Spec.Features.ClusterChecks
+	if clusterChecks != nil && apiutils.BoolValue(clusterChecks.Enabled) && apiutils.BoolValue(clusterChecks.UseClusterChecksRunners) {
+		return v2alpha1.ClusterChecksRunnerComponentName
+	}
+	return v2alpha1.ClusterAgentComponentName
+}
+
+func runProcessChecksInCoreAgent(dda *v2alpha1.DatadogAgent) bool {
+	return featutils.OverrideProcessConfigRunInCoreAgent(dda, apiutils.BoolValue(dda.Spec.Global.RunProcessChecksInCoreAgent))
+}
+
+// lint runs the validation, the defaulting and the feature configuration of the operator on a DatadogAgent
+// and returns the problems found in its configuration
+func lint(dda *v2alpha1.DatadogAgent) []finding {
+	var findings []finding
+
+	// Invalid configurations are not rolled out by the operator, the other checks would be meaningless
+	if err := v2alpha1.IsValidDatadogAgent(&dda.Spec); err != nil {
+		return append(findings, finding{Severity: severityError, Field: "spec", Message: err.Error()})
+	}
+	findings = append(findings, lintDeprecatedFields(dda)...)
+
+	dda = dda.DeepCopy()
+	defaults.DefaultDatadogAgent(dda)
+	features, requiredComponents := feature.BuildFeatures(dda, &feature.Options{Logger: logr.Discard()})
+
+	// BuildFeatures only returns the merged requirements, the features are configured
+	// again to know the components required by each of them.
+	featureComponents := make(map[feature.IDType]feature.RequiredComponents, len(features))
+	for _, feat := range features {
+		featureComponents[feat.ID()] = feat.Configure(dda.DeepCopy())
+	}
+
+	findings = append(findings, lintDisabledComponents(dda, features, featureComponents, requiredComponents)...)
+	findings = append(findings, lintImageVersions(dda, features, featureComponents)...)
+
+	hostPortFindings, err := lintHostPorts(dda, features, requiredComponents)
+	if err != nil {
+		return append(findings, finding{Severity: severityError, Field: "spec.features", Message: fmt.Sprintf("unable to configure the node Agent: %v", err)})
+	}
+	return append(findings, hostPortFindings...)
+}
+
+// lintDeprecatedFields reports the fields relying on behaviors removed from the operator
+func lintDeprecatedFields(dda *v2alpha1.DatadogAgent) []finding {
+	var findings []finding
+	for _, component := range components {
+		override, found := dda.Spec.Override[component]
+		if !found || override == nil {
+			continue
+		}
+
+		// The operator removes the old profile label from the nodes, selecting it never matches
+		message := fmt.Sprintf("the %s label was deprecated in operator v1.8.0 and is removed from the nodes, use %s", agentprofile.OldProfileLabelKey, agentprofile.ProfileLabelKey)
+		if _, found := override.NodeSelector[agentprofile.OldProfileLabelKey]; found {
+			findings = append(findings, finding{Severity: severityWarning, Field: fmt.Sprintf("spec.override.%s.nodeSelector", component), Message: message})
+		}
+		if override.Affinity != nil && override.Affinity.NodeAffinity != nil && usesNodeLabel(override.Affinity.NodeAffinity, agentprofile.OldProfileLabelKey) {
+			findings = append(findings, finding{Severity: severityWarning, Field: fmt.Sprintf("spec.override.%s.affinity.nodeAffinity", component), Message: message})
+		}
+	}
+	return findings
+}
+
+// usesNodeLabel returns whether a node affinity selects nodes on a label
+func usesNodeLabel(affinity *corev1.NodeAffinity, label string) bool {
+	var terms []corev1.NodeSelectorTerm
+	if affinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		terms = append(terms, affinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms...)
+	}
+	for _, preferred := range affinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		terms = append(terms, preferred.Preference)
+	}
+
+	for _, term := range terms {
+		for _, expression := range term.MatchExpressions {
+			if expression.Key == label {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lintDisabledComponents reports the features requiring a component that is not deployed
+func lintDisabledComponents(dda *v2alpha1.DatadogAgent, features []feature.Feature, featureComponents map[feature.IDType]feature.RequiredComponents, requiredComponents feature.RequiredComponents) []finding {
+	var findings []finding
+	for _, component := range components {
+		var requiredBy, disabledBy []string
+		for _, feat := range features {
+			// The default feature requires all the components, disabling them is supported
+			if feat.ID() == feature.DefaultIDType {
+				continue
+			}
+			rc := requiredComponent(featureComponents[feat.ID()], component)
+			if rc.IsRequired == nil {
+				continue
+			}
+			if *rc.IsRequired {
+				requiredBy = append(requiredBy, string(feat.ID()))
+			} else {
+				disabledBy = append(disabledBy, string(feat.ID()))
+			}
+		}
+		if len(requiredBy) == 0 {
+			continue
+		}
+
+		if override, found := dda.Spec.Override[component]; found && override != nil && apiutils.BoolValue(override.Disabled) {
+			// Most features are enabled by default, they are only reported as not running
+			findings = append(findings, finding{
+				Severity: severityWarning,
+				Field:    fmt.Sprintf("spec.override.%s.disabled", component),
+				Message:  fmt.Sprintf("the %s component is disabled, the features requiring it don't run: %s", component, strings.Join(requiredBy, ", ")),
+			})
+			continue
+		}
+
+		// A component not required by one feature is not deployed, even if other features require it
+		merged := requiredComponent(requiredComponents, component)
+		if merged.IsRequired != nil && !*merged.IsRequired {
+			findings = append(findings, finding{
+				Severity: severityError,
+				Field:    "spec.features",
+				Message: fmt.Sprintf("the %s component is required by the features: %s, but is not deployed because of the features: %s",
+					component, strings.Join(requiredBy, ", "), strings.Join(disabledBy, ", ")),
+			})
+		}
+	}
+	return findings
+}
+
+func requiredComponent(rc feature.RequiredComponents, component v2alpha1.ComponentName) feature.RequiredComponent {
+	switch component {
+	case v2alpha1.ClusterAgentComponentName:
+		return rc.ClusterAgent
+	case v2alpha1.ClusterChecksRunnerComponentName:
+		return rc.ClusterChecksRunner
+	default:
+		return rc.Agent
+	}
+}
+
+// lintImageVersions reports the overridden images older than the versions required by the enabled features
+func lintImageVersions(dda *v2alpha1.DatadogAgent, features []feature.Feature, featureComponents map[feature.IDType]feature.RequiredComponents) []finding {
+	enabled := make(map[feature.IDType]bool, len(features))
+	for _, feat := range features {
+		rc := featureComponents[feat.ID()]
+		enabled[feat.ID()] = rc.IsEnabled()
+	}
+
+	var findings []finding
+	for _, requirement := range imageRequirements {
+		if !enabled[requirement.feature] || !requirement.applies(dda) {
+			continue
+		}
+
+		component := requirement.component(dda)
+		override, found := dda.Spec.Override[component]
+		if !found || override == nil || override.Image == nil {
+			// The default images are always recent enough
+			continue
+		}
+		version := common.GetAgentVersionFromImage(*override.Image)
+		// Tags that are not versions, like latest, can't be compared
+		if !utils.IsAboveMinVersion(version, "0.0.0-0") || utils.IsAboveMinVersion(version, requirement.minVersion) {
+			continue
+		}
+
+		findings = append(findings, finding{
+			Severity: severityWarning,
+			Field:    fmt.Sprintf("spec.override.%s.image", component),
+			Message: fmt.Sprintf("the %s feature requires version %s or above, %s with version %s",
+				requirement.feature, strings.TrimSuffix(requirement.minVersion, "-0"), requirement.degradation, version),
+		})
+	}
+	return findings
+}
+
+// hostPort identifies a port opened on the nodes
+type hostPort struct {
+	port     int32
+	protocol corev1.Protocol
+}
+
+// lintHostPorts reports the host ports opened by several features of the node Agent
+func lintHostPorts(dda *v2alpha1.DatadogAgent, features []feature.Feature, requiredComponents feature.RequiredComponents) ([]finding, error) {
+	if !requiredComponents.Agent.IsEnabled() {
+		return nil, nil
+	}
+
+	// Each feature configures its own pod template to know which ports it opens
+	owners := map[hostPort][]string{}
+	for _, feat := range features {
+		template := componentagent.NewDefaultAgentPodTemplateSpec(dda, requiredComponents.Agent, nil)
+		managers := feature.NewPodTemplateManagers(template)
+
+		var err error
+		if requiredComponents.Agent.SingleContainerStrategyEnabled() {
+			err = feat.ManageSingleContainerNodeAgent(managers, kubernetes.DefaultProvider)
+		} else {
+			err = feat.ManageNodeAgent(managers, kubernetes.DefaultProvider)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		opened := map[hostPort]bool{}
+		for _, container := range template.Spec.Containers {
+			for _, port := range container.Ports {
+				if port.HostPort == 0 {
+					continue
+				}
+				key := hostPort{port: port.HostPort, protocol: port.Protocol}
+				if key.protocol == "" {
+					key.protocol = corev1.ProtocolTCP
+				}
+				if !opened[key] {
+					opened[key] = true
+					owners[key] = append(owners[key], string(feat.ID()))
+				}
+			}
+		}
+	}
+
+	clashes := make([]hostPort, 0, len(owners))
+	for key, featureIDs := range owners {
+		if len(featureIDs) > 1 {
+			clashes = append(clashes, key)
+		}
+	}
+	sort.Slice(clashes, func(i, j int) bool {
+		if clashes[i].port != clashes[j].port {
+			return clashes[i].port < clashes[j].port
+		}
+		return clashes[i].protocol < clashes[j].protocol
+	})
+
+	findings := make([]finding, 0, len(clashes))
+	for _, key := range clashes {
+		findings = append(findings, finding{
+			Severity: severityError,
+			Field:    "spec.features",
+			Message:  fmt.Sprintf("the host port %d/%s is opened by the features: %s", key.port, key.protocol, strings.Join(owners[key], ", ")),
+		})
+	}
+	return findings, nil
+}
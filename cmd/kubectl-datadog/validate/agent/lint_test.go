@@ -0,0 +1,164 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package agent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/pkg/agentprofile"
+)
+
+func newDatadogAgent(features *v2alpha1.DatadogFeatures, override map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride) *v2alpha1.DatadogAgent {
+	return &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "datadog", Namespace: "datadog"},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Global: &v2alpha1.GlobalConfig{
+				Credentials: &v2alpha1.DatadogCredentials{APISecret: &v2alpha1.SecretConfig{SecretName: "datadog-secret", KeyName: "api-key"}},
+			},
+			Features: features,
+			Override: override,
+		},
+	}
+}
+
+func Test_lint(t *testing.T) {
+	tests := []struct {
+		name string
+		dda  *v2alpha1.DatadogAgent
+		want []finding
+	}{
+		{
+			name: "default configuration",
+			dda:  newDatadogAgent(nil, nil),
+			want: nil,
+		},
+		{
+			name: "invalid configuration",
+			dda: newDatadogAgent(&v2alpha1.DatadogFeatures{
+				LogCollection: &apicommon.LogCollectionFeatureConfig{
+					Enabled:         ptr.To(true),
+					ProcessingRules: []apicommon.LogsProcessingRule{{Name: "exclude", Type: apicommon.LogsProcessingRuleExcludeAtMatch}},
+				},
+			}, nil),
+			want: []finding{
+				{Severity: severityError, Field: "spec", Message: "spec.features.logCollection.processingRules[0].pattern must be defined"},
+			},
+		},
+		{
+			name: "disabled cluster agent",
+			dda: newDatadogAgent(&v2alpha1.DatadogFeatures{
+				KubeStateMetricsCore: &v2alpha1.KubeStateMetricsCoreFeatureConfig{Enabled: ptr.To(true)},
+			}, map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+				v2alpha1.ClusterAgentComponentName: {Disabled: ptr.To(true)},
+			}),
+			want: []finding{
+				{Severity: severityWarning, Field: "spec.override.clusterAgent.disabled"},
+			},
+		},
+		{
+			name: "host port clash",
+			dda: newDatadogAgent(&v2alpha1.DatadogFeatures{
+				APM: &v2alpha1.APMFeatureConfig{Enabled: ptr.To(true), HostPortConfig: &apicommon.HostPortConfig{Enabled: ptr.To(true), Port: ptr.To[int32](4317)}},
+				OTLP: &v2alpha1.OTLPFeatureConfig{Receiver: v2alpha1.OTLPReceiverConfig{Protocols: v2alpha1.OTLPProtocolsConfig{
+					GRPC: &v2alpha1.OTLPGRPCConfig{Enabled: ptr.To(true)},
+				}}},
+			}, nil),
+			want: []finding{
+				{Severity: severityError, Field: "spec.features", Message: "the host port 4317/TCP is opened by the features: apm, otlp"},
+			},
+		},
+		{
+			name: "image below the feature minimum version",
+			dda: newDatadogAgent(&v2alpha1.DatadogFeatures{
+				KubeStateMetricsCore: &v2alpha1.KubeStateMetricsCoreFeatureConfig{Enabled: ptr.To(true)},
+				OrchestratorExplorer: &v2alpha1.OrchestratorExplorerFeatureConfig{Enabled: ptr.To(true)},
+			}, map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+				v2alpha1.ClusterAgentComponentName: {Image: &v2alpha1.AgentImageConfig{Tag: "7.45.0"}},
+				v2alpha1.NodeAgentComponentName:    {Image: &v2alpha1.AgentImageConfig{Name: "gcr.io/datadoghq/agent:latest"}},
+			}),
+			want: []finding{
+				{Severity: severityWarning, Field: "spec.override.clusterAgent.image", Message: "the ksm feature requires version 7.46.0 or above, the CRD and APIService metrics are not collected with version 7.45.0"},
+			},
+		},
+		{
+			name: "deprecated profile label",
+			dda: newDatadogAgent(nil, map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+				v2alpha1.NodeAgentComponentName: {
+					NodeSelector: map[string]string{agentprofile.OldProfileLabelKey: "foo"},
+					Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{
+							Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: agentprofile.OldProfileLabelKey, Operator: corev1.NodeSelectorOpExists}}},
+						}},
+					}},
+				},
+			}),
+			want: []finding{
+				{Severity: severityWarning, Field: "spec.override.nodeAgent.nodeSelector"},
+				{Severity: severityWarning, Field: "spec.override.nodeAgent.affinity.nodeAffinity"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lint(tt.dda)
+
+			require.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].Severity, got[i].Severity)
+				assert.Equal(t, tt.want[i].Field, got[i].Field)
+				if tt.want[i].Message != "" {
+					assert.Equal(t, tt.want[i].Message, got[i].Message)
+				}
+			}
+		})
+	}
+}
+
+func Test_loadManifests(t *testing.T) {
+	manifests, err := loadManifests(strings.NewReader(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: datadog
+---
+apiVersion: datadoghq.com/v1alpha1
+kind: DatadogAgent
+metadata:
+  name: legacy
+---
+apiVersion: datadoghq.com/v2alpha1
+kind: DatadogAgent
+metadata:
+  name: datadog
+spec:
+  global:
+    credentials:
+      apiSecret:
+        secretName: datadog-secret
+        keyName: api-key
+  features:
+    unknown: {}
+`))
+	require.NoError(t, err)
+
+	require.Len(t, manifests, 2)
+	assert.Equal(t, "legacy", manifests[0].name)
+	require.Len(t, manifests[0].findings, 1)
+	assert.Equal(t, "apiVersion", manifests[0].findings[0].Field)
+
+	assert.Equal(t, "datadog", manifests[1].name)
+	require.Len(t, manifests[1].findings, 1)
+	assert.Contains(t, manifests[1].findings[0].Message, `unknown field "unknown"`)
+}
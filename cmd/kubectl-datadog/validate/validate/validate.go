@@ -10,6 +10,7 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/validate/ad/ad"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/validate/agent"
 )
 
 // options provides information required by validate command
@@ -33,6 +34,7 @@ func New(streams genericclioptions.IOStreams) *cobra.Command {
 	}
 
 	cmd.AddCommand(ad.New(streams))
+	cmd.AddCommand(agent.New(streams))
 
 	o := newOptions(streams)
 	o.configFlags.AddFlags(cmd.Flags())
@@ -6,6 +6,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net/http"
@@ -26,6 +27,7 @@ import (
 	"k8s.io/klog/v2"
 	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -39,6 +41,7 @@ import (
 	"github.com/DataDog/datadog-operator/pkg/controller/debug"
 	"github.com/DataDog/datadog-operator/pkg/remoteconfig"
 	"github.com/DataDog/datadog-operator/pkg/secrets"
+	"github.com/DataDog/datadog-operator/pkg/tracing"
 	"github.com/DataDog/datadog-operator/pkg/version"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -87,6 +90,8 @@ const (
 	defaultCanaryAutoPauseMaxRestarts          = 0
 	defaultCanaryAutoFailMaxRestarts           = 0
 	defaultCanaryAutoPauseMaxSlowStartDuration = 0
+
+	tracingShutdownTimeout = 5 * time.Second
 )
 
 type options struct {
@@ -98,6 +103,7 @@ type options struct {
 	logEncoder       string
 	printVersion     bool
 	pprofActive      bool
+	tracing          tracing.Options
 
 	// Leader Election options
 	enableLeaderElection        bool
@@ -123,8 +129,10 @@ type options struct {
 	introspectionEnabled                   bool
 	datadogAgentProfileEnabled             bool
 	remoteConfigEnabled                    bool
+	remoteConfigOfflineSource              string
 	datadogDashboardEnabled                bool
 	datadogGenericResourceEnabled          bool
+	datadogInstrumentationEnabled          bool
 
 	// Secret Backend options
 	secretBackendCommand string
@@ -140,6 +148,10 @@ func (opts *options) Parse() {
 	flag.StringVar(&opts.logEncoder, "logEncoder", "json", "log encoding ('json' or 'console')")
 	flag.BoolVar(&opts.printVersion, "version", false, "Print version and exit")
 	flag.BoolVar(&opts.pprofActive, "pprof", false, "Enable pprof endpoint")
+	flag.BoolVar(&opts.tracing.Enabled, "otlpTracingEnabled", false, "Export traces of the reconcile loops and Datadog API calls with OTLP")
+	flag.StringVar(&opts.tracing.Endpoint, "otlpTracingEndpoint", "", "host:port address of the OTLP gRPC receiver. Defaults to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable, or localhost:4317")
+	flag.BoolVar(&opts.tracing.Insecure, "otlpTracingInsecure", false, "Disable TLS when exporting the traces to the OTLP receiver")
+	flag.Float64Var(&opts.tracing.SampleRatio, "otlpTracingSampleRatio", 1, "Ratio of the reconcile traces sampled, between 0 and 1")
 
 	// Leader Election options flags
 	flag.BoolVar(&opts.enableLeaderElection, "enable-leader-election", true,
@@ -158,8 +170,10 @@ func (opts *options) Parse() {
 	flag.BoolVar(&opts.introspectionEnabled, "introspectionEnabled", false, "Enable introspection (beta)")
 	flag.BoolVar(&opts.datadogAgentProfileEnabled, "datadogAgentProfileEnabled", false, "Enable DatadogAgentProfile controller (beta)")
 	flag.BoolVar(&opts.remoteConfigEnabled, "remoteConfigEnabled", false, "Enable RemoteConfig capabilities in the Operator (beta)")
+	flag.StringVar(&opts.remoteConfigOfflineSource, "remoteConfigOfflineSource", "", "Read RemoteConfig signed bundles from an offline source instead of the Datadog backend: configmap://<namespace>/<name>, file://<path> or http(s)://<endpoint>")
 	flag.BoolVar(&opts.datadogDashboardEnabled, "datadogDashboardEnabled", false, "Enable the DatadogDashboard controller")
 	flag.BoolVar(&opts.datadogGenericResourceEnabled, "datadogGenericResourceEnabled", false, "Enable the DatadogGenericResource controller")
+	flag.BoolVar(&opts.datadogInstrumentationEnabled, "datadogInstrumentationEnabled", false, "Enable DatadogInstrumentation support in the DatadogAgent controller (beta)")
 
 	// ExtendedDaemonset configuration
 	flag.BoolVar(&opts.supportExtendedDaemonset, "supportExtendedDaemonset", false, "Support usage of Datadog ExtendedDaemonset CRD.")
@@ -224,6 +238,18 @@ func run(opts *options) error {
 		defer profiler.Stop()
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), opts.tracing)
+	if err != nil {
+		return setupErrorf(setupLog, err, "Unable to setup tracing")
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			setupLog.Error(err, "Unable to flush the traces")
+		}
+	}()
+
 	// Dispatch CLI flags to each package
 	secrets.SetSecretBackendCommand(opts.secretBackendCommand)
 	secrets.SetSecretBackendArgs(opts.secretBackendArgs)
@@ -242,9 +268,15 @@ func run(opts *options) error {
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
 	}
 
+	var newClient client.NewClientFunc
+	if opts.tracing.Enabled {
+		newClient = tracing.NewManagerClient
+	}
+
 	restConfig := ctrl.GetConfigOrDie()
 	restConfig.UserAgent = "datadog-operator"
 	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
+		NewClient:                  newClient,
 		Scheme:                     scheme,
 		Metrics:                    metricsServerOptions,
 		HealthProbeBindAddress:     ":8081",
@@ -262,6 +294,7 @@ func run(opts *options) error {
 			IntrospectionEnabled:          opts.introspectionEnabled,
 			DatadogDashboardEnabled:       opts.datadogDashboardEnabled,
 			DatadogGenericResourceEnabled: opts.datadogGenericResourceEnabled,
+			DatadogInstrumentationEnabled: opts.datadogInstrumentationEnabled,
 		}),
 	})
 	if err != nil {
@@ -284,7 +317,12 @@ func run(opts *options) error {
 			// to handle that.
 			<-mgr.Elected()
 
-			err = remoteconfig.NewRemoteConfigUpdater(mgr.GetClient(), ctrl.Log.WithName("remote_config")).Setup(creds)
+			rcUpdater := remoteconfig.NewRemoteConfigUpdater(mgr.GetClient(), mgr.GetEventRecorderFor("remote-config"), ctrl.Log.WithName("remote_config"))
+			if opts.remoteConfigOfflineSource != "" {
+				err = rcUpdater.SetupOffline(opts.remoteConfigOfflineSource)
+			} else {
+				err = rcUpdater.Setup(creds)
+			}
 			if err != nil {
 				setupErrorf(setupLog, err, "Unable to set up Remote Config service")
 			}
@@ -315,6 +353,7 @@ func run(opts *options) error {
 		DatadogAgentProfileEnabled:    opts.datadogAgentProfileEnabled,
 		DatadogDashboardEnabled:       opts.datadogDashboardEnabled,
 		DatadogGenericResourceEnabled: opts.datadogGenericResourceEnabled,
+		DatadogInstrumentationEnabled: opts.datadogInstrumentationEnabled,
 	}
 
 	if err = controller.SetupControllers(setupLog, mgr, options); err != nil {
@@ -0,0 +1,85 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	componentdca "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/clusteragent"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/clusteragenttls"
+)
+
+const (
+	clusterAgentProbeTimeout = 5 * time.Second
+
+	// clusterAgentVersionPath is served by the Cluster Agent without authentication
+	clusterAgentVersionPath = "/version"
+	// clusterAgentClusterIDPath is only served to the clients authenticated with a token
+	clusterAgentClusterIDPath = "/api/v1/cluster/id"
+)
+
+// clusterAgentProber sends requests to the API of the Cluster Agent
+type clusterAgentProber interface {
+	// Probe sends a GET request to the URL, authenticated with the token if it isn't empty,
+	// and returns the status code of the response
+	Probe(ctx context.Context, tlsConfig *tls.Config, url, token string) (int, error)
+}
+
+type httpClusterAgentProber struct{}
+
+// Probe implements clusterAgentProber
+func (httpClusterAgentProber) Probe(ctx context.Context, tlsConfig *tls.Config, url, token string) (int, error) {
+	client := &http.Client{
+		Timeout:   clusterAgentProbeTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// clusterAgentTLSConfig returns the TLS configuration verifying the Cluster Agent with the bundle of certificate
+// authorities, and presenting the client certificate of the Agents once it is issued.
+func (r *Reconciler) clusterAgentTLSConfig(ctx context.Context, instance *datadoghqv2alpha1.DatadogAgent) (*tls.Config, error) {
+	bundleSecret, err := r.getSecret(ctx, instance.Namespace, componentdca.GetClusterAgentCABundleSecretName(instance))
+	if err != nil {
+		return nil, err
+	}
+	roots := x509.NewCertPool()
+	if bundleSecret == nil || !roots.AppendCertsFromPEM(bundleSecret.Data[clusteragenttls.CACertKey]) {
+		return nil, fmt.Errorf("cluster agent certificate authorities not found in secret %s", componentdca.GetClusterAgentCABundleSecretName(instance))
+	}
+	tlsConfig := &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+
+	clientSecret, err := r.getSecret(ctx, instance.Namespace, componentdca.GetClusterAgentClientTLSSecretName(instance))
+	if err != nil {
+		return nil, err
+	}
+	if clientSecret != nil {
+		if clientCert, err := tls.X509KeyPair(clientSecret.Data[corev1.TLSCertKey], clientSecret.Data[corev1.TLSPrivateKeyKey]); err == nil {
+			tlsConfig.Certificates = []tls.Certificate{clientCert}
+		}
+	}
+	return tlsConfig, nil
+}
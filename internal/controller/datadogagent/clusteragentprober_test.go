@@ -0,0 +1,46 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_httpClusterAgentProber(t *testing.T) {
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		authorization = req.Header.Get("Authorization")
+		if authorization != "Bearer token" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	ctx := context.Background()
+
+	statusCode, err := httpClusterAgentProber{}.Probe(ctx, &tls.Config{RootCAs: roots}, server.URL, "token")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "Bearer token", authorization)
+
+	statusCode, err = httpClusterAgentProber{}.Probe(ctx, &tls.Config{RootCAs: roots}, server.URL, "")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Empty(t, authorization)
+
+	// The server certificate isn't issued by the certificate authorities
+	_, err = httpClusterAgentProber{}.Probe(ctx, &tls.Config{RootCAs: x509.NewCertPool()}, server.URL, "token")
+	assert.Error(t, err)
+}
@@ -23,6 +23,7 @@ const (
 	DDLogsEnabled                       = "DD_LOGS_ENABLED"
 	DDProcessCollectionEnabled          = "DD_PROCESS_CONFIG_PROCESS_COLLECTION_ENABLED"
 	DDProcessConfigRunInCoreAgent       = "DD_PROCESS_CONFIG_RUN_IN_CORE_AGENT_ENABLED"
+	DDProcessAdditionalEndpoints        = "DD_PROCESS_ADDITIONAL_ENDPOINTS"
 	DDSystemProbeEnabled                = "DD_SYSTEM_PROBE_ENABLED"
 	DDSystemProbeExternal               = "DD_SYSTEM_PROBE_EXTERNAL"
 	DDSystemProbeSocket                 = "DD_SYSPROBE_SOCKET"
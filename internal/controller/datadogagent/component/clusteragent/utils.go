@@ -129,3 +129,38 @@ func GetResourceMetadataAsTagsClusterRoleName(dda metav1.Object) string {
 func GetApiserverAuthReaderRoleBindingName(dda metav1.Object) string {
 	return fmt.Sprintf("%s-apiserver", GetClusterAgentRbacResourcesName(dda))
 }
+
+// GetClusterAgentCASecretName returns the name of the secret storing the certificate authority of the Cluster Agent TLS
+func GetClusterAgentCASecretName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s-ca", dda.GetName(), constants.DefaultClusterAgentResourceSuffix)
+}
+
+// GetClusterAgentCABundleSecretName returns the name of the secret storing the bundle of certificate authorities
+// trusted by the Cluster Agent and the Agents
+func GetClusterAgentCABundleSecretName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s-ca-bundle", dda.GetName(), constants.DefaultClusterAgentResourceSuffix)
+}
+
+// GetClusterAgentServerTLSSecretName returns the name of the secret storing the serving certificate of the Cluster Agent
+func GetClusterAgentServerTLSSecretName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s-tls", dda.GetName(), constants.DefaultClusterAgentResourceSuffix)
+}
+
+// GetClusterAgentClientTLSSecretName returns the name of the secret storing the client certificate of the Agents
+func GetClusterAgentClientTLSSecretName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s-client-tls", dda.GetName(), constants.DefaultClusterAgentResourceSuffix)
+}
+
+// GetClusterAgentServerDNSNames returns the DNS names of the Cluster Agent and external metrics provider services,
+// covered by the serving certificate of the Cluster Agent
+func GetClusterAgentServerDNSNames(dda metav1.Object) []string {
+	var dnsNames []string
+	for _, service := range []string{GetClusterAgentServiceName(dda), GetMetricsServerServiceName(dda)} {
+		dnsNames = append(dnsNames,
+			service,
+			fmt.Sprintf("%s.%s", service, dda.GetNamespace()),
+			fmt.Sprintf("%s.%s.svc", service, dda.GetNamespace()),
+		)
+	}
+	return dnsNames
+}
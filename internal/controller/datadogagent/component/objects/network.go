@@ -7,9 +7,13 @@ package objects
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -20,12 +24,16 @@ import (
 	componentdca "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/clusteragent"
 	componentccr "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/clusterchecksrunner"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	anp "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+	calico "github.com/DataDog/datadog-operator/pkg/calico/v3"
 	cilium "github.com/DataDog/datadog-operator/pkg/cilium/v1"
+	"github.com/DataDog/datadog-operator/pkg/constants"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 )
 
-// BuildKubernetesNetworkPolicy creates the base node agent kubernetes network policy
-func BuildKubernetesNetworkPolicy(dda metav1.Object, componentName v2alpha1.ComponentName) (string, string, metav1.LabelSelector, []netv1.PolicyType, []netv1.NetworkPolicyIngressRule, []netv1.NetworkPolicyEgressRule) {
+// BuildKubernetesNetworkPolicy creates the base node agent kubernetes network policy, extended with the additional rules.
+// Kubernetes network policies can't select domain names, see KubernetesRulesWithDomainNames.
+func BuildKubernetesNetworkPolicy(dda metav1.Object, componentName v2alpha1.ComponentName, additionalIngress, additionalEgress []v2alpha1.NetworkPolicyRule) (string, string, metav1.LabelSelector, []netv1.PolicyType, []netv1.NetworkPolicyIngressRule, []netv1.NetworkPolicyEgressRule) {
 	policyName, podSelector := GetNetworkPolicyMetadata(dda, componentName)
 	ddaNamespace := dda.GetNamespace()
 
@@ -127,9 +135,189 @@ func BuildKubernetesNetworkPolicy(dda metav1.Object, componentName v2alpha1.Comp
 		ingress = []netv1.NetworkPolicyIngressRule{}
 	}
 
+	for _, rule := range additionalIngress {
+		peers := kubernetesPeers(rule)
+		if len(peers) == 0 && len(rule.DomainNames) > 0 {
+			continue
+		}
+		ingress = append(ingress, netv1.NetworkPolicyIngressRule{
+			Ports: kubernetesPorts(rule.Ports),
+			From:  peers,
+		})
+	}
+	for _, rule := range additionalEgress {
+		peers := kubernetesPeers(rule)
+		if len(peers) == 0 && len(rule.DomainNames) > 0 {
+			continue
+		}
+		egress = append(egress, netv1.NetworkPolicyEgressRule{
+			Ports: kubernetesPorts(rule.Ports),
+			To:    peers,
+		})
+	}
+
 	return policyName, ddaNamespace, podSelector, policyTypes, ingress, egress
 }
 
+// NetworkPolicyAdditionalRules returns the additional ingress and egress rules of a component network policy:
+// the rules of the component override, plus egress rules to the proxies and the intake endpoint the Agents are configured with.
+func NetworkPolicyAdditionalRules(dda *v2alpha1.DatadogAgent, componentName v2alpha1.ComponentName) ([]v2alpha1.NetworkPolicyRule, []v2alpha1.NetworkPolicyRule) {
+	var ingress, egress []v2alpha1.NetworkPolicyRule
+
+	var env []corev1.EnvVar
+	var productEndpoints []string
+	if dda.Spec.Global != nil {
+		if proxy := dda.Spec.Global.Proxy; proxy != nil {
+			if proxy.HTTP != nil {
+				env = append(env, corev1.EnvVar{Name: constants.DDProxyHTTP, Value: *proxy.HTTP})
+			}
+			if proxy.HTTPS != nil {
+				env = append(env, corev1.EnvVar{Name: constants.DDProxyHTTPS, Value: *proxy.HTTPS})
+			}
+			for _, product := range []*v2alpha1.ProductIntakeConfig{proxy.Logs, proxy.APM, proxy.Process} {
+				if product != nil && product.URL != nil {
+					productEndpoints = append(productEndpoints, *product.URL)
+				}
+			}
+		}
+		if additionalEndpoints := dda.Spec.Global.AdditionalEndpoints; additionalEndpoints != nil {
+			for _, endpoints := range [][]v2alpha1.AdditionalEndpoint{
+				additionalEndpoints.Metrics,
+				additionalEndpoints.Logs,
+				additionalEndpoints.APM,
+				additionalEndpoints.Process,
+				additionalEndpoints.Orchestrator,
+			} {
+				for _, endpoint := range endpoints {
+					// Intake endpoints use HTTPS by default
+					endpointURL := endpoint.URL
+					if !strings.Contains(endpointURL, "://") {
+						endpointURL = "https://" + endpointURL
+					}
+					productEndpoints = append(productEndpoints, endpointURL)
+				}
+			}
+		}
+		env = append(env, dda.Spec.Global.Env...)
+		if dda.Spec.Global.Endpoint != nil && dda.Spec.Global.Endpoint.URL != nil {
+			env = append(env, corev1.EnvVar{Name: constants.DDddURL, Value: *dda.Spec.Global.Endpoint.URL})
+		}
+	}
+	if override, found := dda.Spec.Override[componentName]; found && override != nil {
+		// Override env vars take precedence over the global ones
+		env = append(env, override.Env...)
+		if override.NetworkPolicy != nil {
+			ingress = append(ingress, override.NetworkPolicy.AdditionalIngress...)
+			egress = append(egress, override.NetworkPolicy.AdditionalEgress...)
+		}
+	}
+
+	endpoints := map[string]string{}
+	for _, envVar := range env {
+		switch envVar.Name {
+		case constants.DDProxyHTTP, constants.DDProxyHTTPS, constants.DDddURL:
+			endpoints[envVar.Name] = envVar.Value
+		}
+	}
+	for _, name := range []string{constants.DDProxyHTTPS, constants.DDProxyHTTP, constants.DDddURL} {
+		if rule := endpointEgressRule(endpoints[name]); rule != nil {
+			egress = append(egress, *rule)
+		}
+	}
+	seen := map[string]bool{}
+	for _, endpoint := range productEndpoints {
+		if seen[endpoint] {
+			continue
+		}
+		seen[endpoint] = true
+		if rule := endpointEgressRule(endpoint); rule != nil {
+			egress = append(egress, *rule)
+		}
+	}
+
+	return ingress, egress
+}
+
+// endpointEgressRule returns the egress rule allowing the connection to an URL
+func endpointEgressRule(endpoint string) *v2alpha1.NetworkPolicyRule {
+	if endpoint == "" {
+		return nil
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Hostname() == "" {
+		return nil
+	}
+
+	port := 80
+	if u.Scheme == "https" {
+		port = 443
+	}
+	if u.Port() != "" {
+		if port, err = strconv.Atoi(u.Port()); err != nil {
+			return nil
+		}
+	}
+
+	rule := &v2alpha1.NetworkPolicyRule{
+		Ports: []v2alpha1.NetworkPolicyRulePort{{Port: int32(port)}},
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil {
+		if ip.To4() != nil {
+			rule.IPBlocks = []string{ip.String() + "/32"}
+		} else {
+			rule.IPBlocks = []string{ip.String() + "/128"}
+		}
+	} else {
+		rule.DomainNames = []string{u.Hostname()}
+	}
+	return rule
+}
+
+// KubernetesRulesWithDomainNames returns the rules with domain names. Kubernetes network policies can't
+// select domain names: the rules are restricted to their other peers, and skipped when they have none
+// rather than allowed to any peer.
+func KubernetesRulesWithDomainNames(rules []v2alpha1.NetworkPolicyRule) []v2alpha1.NetworkPolicyRule {
+	var withDomainNames []v2alpha1.NetworkPolicyRule
+	for _, rule := range rules {
+		if len(rule.DomainNames) > 0 {
+			withDomainNames = append(withDomainNames, rule)
+		}
+	}
+	return withDomainNames
+}
+
+// kubernetesPeers returns the peers of a rule, without its domain names.
+func kubernetesPeers(rule v2alpha1.NetworkPolicyRule) []netv1.NetworkPolicyPeer {
+	var peers []netv1.NetworkPolicyPeer
+	for _, cidr := range rule.IPBlocks {
+		peers = append(peers, netv1.NetworkPolicyPeer{IPBlock: &netv1.IPBlock{CIDR: cidr}})
+	}
+	if rule.PodSelector != nil || rule.NamespaceSelector != nil {
+		peers = append(peers, netv1.NetworkPolicyPeer{
+			PodSelector:       rule.PodSelector,
+			NamespaceSelector: rule.NamespaceSelector,
+		})
+	}
+	return peers
+}
+
+func kubernetesPorts(ports []v2alpha1.NetworkPolicyRulePort) []netv1.NetworkPolicyPort {
+	var policyPorts []netv1.NetworkPolicyPort
+	for _, port := range ports {
+		policyPorts = append(policyPorts, netv1.NetworkPolicyPort{
+			Protocol: port.Protocol,
+			Port: &intstr.IntOrString{
+				Type:   intstr.Int,
+				IntVal: port.Port,
+			},
+		})
+	}
+	return policyPorts
+}
+
 // GetNetworkPolicyMetadata generates a label selector based on component
 func GetNetworkPolicyMetadata(dda metav1.Object, componentName v2alpha1.ComponentName) (policyName string, podSelector metav1.LabelSelector) {
 	switch componentName {
@@ -169,8 +357,260 @@ func dcaServicePort() netv1.NetworkPolicyPort {
 	}
 }
 
-// BuildCiliumPolicy creates the base node agent, DCA, or CCR cilium network policy
-func BuildCiliumPolicy(dda metav1.Object, site string, ddURL string, hostNetwork bool, dnsSelectorEndpoints []metav1.LabelSelector, componentName v2alpha1.ComponentName) (string, string, []cilium.NetworkPolicySpec) {
+// BuildCalicoPolicy creates the base node agent, DCA, or CCR calico global network policy, from the rules of the
+// kubernetes network policy extended with the additional rules. Domain names are only enforced by Calico Enterprise and Calico Cloud.
+func BuildCalicoPolicy(dda metav1.Object, componentName v2alpha1.ComponentName, additionalIngress, additionalEgress []v2alpha1.NetworkPolicyRule) (string, calico.GlobalNetworkPolicySpec) {
+	policyName, namespace, podSelector, _, ingress, egress := BuildKubernetesNetworkPolicy(dda, componentName, nil, nil)
+
+	spec := calico.GlobalNetworkPolicySpec{
+		Selector:          calicoSelector(podSelector),
+		NamespaceSelector: calicoNamespaceSelector(namespace),
+		Types:             []calico.PolicyType{calico.PolicyTypeIngress, calico.PolicyTypeEgress},
+	}
+	for _, rule := range append(policyRulesFromIngress(ingress), additionalIngress...) {
+		for _, protocolRule := range calicoRules(rule, namespace) {
+			peer := protocolRule.Destination
+			protocolRule.Destination = calico.EntityRule{Ports: peer.Ports}
+			peer.Ports = nil
+			protocolRule.Source = peer
+			spec.Ingress = append(spec.Ingress, protocolRule)
+		}
+	}
+	for _, rule := range append(policyRulesFromEgress(egress), additionalEgress...) {
+		spec.Egress = append(spec.Egress, calicoRules(rule, namespace)...)
+	}
+
+	return clusterPolicyName(namespace, policyName), spec
+}
+
+// BuildAdminNetworkPolicy creates the base node agent, DCA, or CCR admin network policy, from the egress rules of the
+// kubernetes network policy extended with the additional egress rules. The egress traffic not allowed by the rules is denied,
+// the ingress traffic is left to the kubernetes network policies.
+func BuildAdminNetworkPolicy(dda metav1.Object, componentName v2alpha1.ComponentName, additionalEgress []v2alpha1.NetworkPolicyRule) (string, anp.AdminNetworkPolicySpec) {
+	policyName, namespace, podSelector, _, _, egress := BuildKubernetesNetworkPolicy(dda, componentName, nil, nil)
+
+	spec := anp.AdminNetworkPolicySpec{
+		Priority: adminNetworkPolicyPriority,
+		Subject: anp.Subject{
+			Pods: &anp.NamespacedPod{
+				NamespaceSelector: namespaceNameSelector(namespace),
+				PodSelector:       podSelector,
+			},
+		},
+	}
+	for _, rule := range append(policyRulesFromEgress(egress), additionalEgress...) {
+		spec.Egress = append(spec.Egress, anp.AdminNetworkPolicyEgressRule{
+			Action: anp.RuleActionAllow,
+			To:     adminNetworkPolicyPeers(rule, namespace),
+			Ports:  adminNetworkPolicyPorts(rule.Ports),
+		})
+	}
+	spec.Egress = append(spec.Egress, anp.AdminNetworkPolicyEgressRule{
+		Name:   "deny-all",
+		Action: anp.RuleActionDeny,
+		To:     adminNetworkPolicyPeers(v2alpha1.NetworkPolicyRule{}, namespace),
+	})
+
+	return clusterPolicyName(namespace, policyName), spec
+}
+
+// clusterPolicyName returns the name of a cluster-scoped network policy, prefixed by the namespace of the DatadogAgent
+func clusterPolicyName(namespace, policyName string) string {
+	return fmt.Sprintf("%s-%s", namespace, policyName)
+}
+
+// policyRulesFromIngress converts kubernetes network policy ingress rules to flavor independent rules
+func policyRulesFromIngress(ingress []netv1.NetworkPolicyIngressRule) []v2alpha1.NetworkPolicyRule {
+	var rules []v2alpha1.NetworkPolicyRule
+	for _, rule := range ingress {
+		rules = append(rules, policyRulesFromKubernetes(rule.Ports, rule.From)...)
+	}
+	return rules
+}
+
+// policyRulesFromEgress converts kubernetes network policy egress rules to flavor independent rules
+func policyRulesFromEgress(egress []netv1.NetworkPolicyEgressRule) []v2alpha1.NetworkPolicyRule {
+	var rules []v2alpha1.NetworkPolicyRule
+	for _, rule := range egress {
+		rules = append(rules, policyRulesFromKubernetes(rule.Ports, rule.To)...)
+	}
+	return rules
+}
+
+// policyRulesFromKubernetes converts a kubernetes network policy rule. The IP blocks are grouped in a rule,
+// and a rule is created for each selector peer.
+func policyRulesFromKubernetes(ports []netv1.NetworkPolicyPort, peers []netv1.NetworkPolicyPeer) []v2alpha1.NetworkPolicyRule {
+	var rulePorts []v2alpha1.NetworkPolicyRulePort
+	for _, port := range ports {
+		if port.Port == nil || port.Port.Type != intstr.Int {
+			continue
+		}
+		rulePorts = append(rulePorts, v2alpha1.NetworkPolicyRulePort{Port: port.Port.IntVal, Protocol: port.Protocol})
+	}
+
+	if len(peers) == 0 {
+		return []v2alpha1.NetworkPolicyRule{{Ports: rulePorts}}
+	}
+
+	var rules []v2alpha1.NetworkPolicyRule
+	ipBlocks := v2alpha1.NetworkPolicyRule{Ports: rulePorts}
+	for _, peer := range peers {
+		if peer.IPBlock != nil {
+			ipBlocks.IPBlocks = append(ipBlocks.IPBlocks, peer.IPBlock.CIDR)
+			continue
+		}
+		rules = append(rules, v2alpha1.NetworkPolicyRule{
+			Ports:             rulePorts,
+			PodSelector:       peer.PodSelector,
+			NamespaceSelector: peer.NamespaceSelector,
+		})
+	}
+	if len(ipBlocks.IPBlocks) > 0 {
+		rules = append([]v2alpha1.NetworkPolicyRule{ipBlocks}, rules...)
+	}
+	return rules
+}
+
+// calicoRules translates a rule to calico egress rules: Calico rules have a single peer and a single protocol,
+// a rule is created for each of them. Ingress rules are built by swapping the source and destination.
+func calicoRules(rule v2alpha1.NetworkPolicyRule, namespace string) []calico.Rule {
+	var peers []calico.EntityRule
+	if len(rule.IPBlocks) > 0 {
+		peers = append(peers, calico.EntityRule{Nets: rule.IPBlocks})
+	}
+	if len(rule.DomainNames) > 0 {
+		peers = append(peers, calico.EntityRule{Domains: rule.DomainNames})
+	}
+	if rule.PodSelector != nil || rule.NamespaceSelector != nil {
+		peer := calico.EntityRule{NamespaceSelector: calicoNamespaceSelector(namespace)}
+		if rule.PodSelector != nil {
+			peer.Selector = calicoSelector(*rule.PodSelector)
+		}
+		if rule.NamespaceSelector != nil {
+			peer.NamespaceSelector = calicoSelector(*rule.NamespaceSelector)
+		}
+		peers = append(peers, peer)
+	}
+	if len(peers) == 0 {
+		peers = append(peers, calico.EntityRule{})
+	}
+
+	// Ports are grouped by protocol
+	var protocols []calico.Protocol
+	portsByProtocol := map[calico.Protocol][]string{}
+	for _, port := range rule.Ports {
+		protocol := calico.ProtocolTCP
+		if port.Protocol != nil {
+			protocol = calico.Protocol(*port.Protocol)
+		}
+		if _, found := portsByProtocol[protocol]; !found {
+			protocols = append(protocols, protocol)
+		}
+		portsByProtocol[protocol] = append(portsByProtocol[protocol], strconv.Itoa(int(port.Port)))
+	}
+
+	var rules []calico.Rule
+	for _, peer := range peers {
+		if len(protocols) == 0 {
+			rules = append(rules, calico.Rule{Action: calico.ActionAllow, Destination: peer})
+			continue
+		}
+		for _, protocol := range protocols {
+			destination := peer
+			destination.Ports = portsByProtocol[protocol]
+			rules = append(rules, calico.Rule{Action: calico.ActionAllow, Protocol: &protocol, Destination: destination})
+		}
+	}
+	return rules
+}
+
+// calicoNamespaceSelector returns the calico selector of a namespace
+func calicoNamespaceSelector(namespace string) string {
+	return fmt.Sprintf("%s == '%s'", calicoNamespaceNameLabel, namespace)
+}
+
+// calicoSelector translates a label selector to a calico selector expression
+func calicoSelector(selector metav1.LabelSelector) string {
+	var terms []string
+	keys := make([]string, 0, len(selector.MatchLabels))
+	for key := range selector.MatchLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		terms = append(terms, fmt.Sprintf("%s == '%s'", key, selector.MatchLabels[key]))
+	}
+	for _, expression := range selector.MatchExpressions {
+		values := make([]string, 0, len(expression.Values))
+		for _, value := range expression.Values {
+			values = append(values, fmt.Sprintf("'%s'", value))
+		}
+		switch expression.Operator {
+		case metav1.LabelSelectorOpIn:
+			terms = append(terms, fmt.Sprintf("%s in { %s }", expression.Key, strings.Join(values, ", ")))
+		case metav1.LabelSelectorOpNotIn:
+			terms = append(terms, fmt.Sprintf("%s not in { %s }", expression.Key, strings.Join(values, ", ")))
+		case metav1.LabelSelectorOpExists:
+			terms = append(terms, fmt.Sprintf("has(%s)", expression.Key))
+		case metav1.LabelSelectorOpDoesNotExist:
+			terms = append(terms, fmt.Sprintf("!has(%s)", expression.Key))
+		}
+	}
+	if len(terms) == 0 {
+		return "all()"
+	}
+	return strings.Join(terms, " && ")
+}
+
+// namespaceNameSelector returns the label selector of a namespace
+func namespaceNameSelector(namespace string) metav1.LabelSelector {
+	return metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: namespace}}
+}
+
+// adminNetworkPolicyPeers translates the peers of a rule. Rules without peer allow any pod and any network.
+func adminNetworkPolicyPeers(rule v2alpha1.NetworkPolicyRule, namespace string) []anp.AdminNetworkPolicyEgressPeer {
+	var peers []anp.AdminNetworkPolicyEgressPeer
+	if len(rule.IPBlocks) > 0 {
+		peers = append(peers, anp.AdminNetworkPolicyEgressPeer{Networks: rule.IPBlocks})
+	}
+	if len(rule.DomainNames) > 0 {
+		peers = append(peers, anp.AdminNetworkPolicyEgressPeer{DomainNames: rule.DomainNames})
+	}
+	switch {
+	case rule.PodSelector != nil:
+		pods := &anp.NamespacedPod{NamespaceSelector: namespaceNameSelector(namespace), PodSelector: *rule.PodSelector}
+		if rule.NamespaceSelector != nil {
+			pods.NamespaceSelector = *rule.NamespaceSelector
+		}
+		peers = append(peers, anp.AdminNetworkPolicyEgressPeer{Pods: pods})
+	case rule.NamespaceSelector != nil:
+		peers = append(peers, anp.AdminNetworkPolicyEgressPeer{Namespaces: rule.NamespaceSelector})
+	}
+	if len(peers) == 0 {
+		peers = []anp.AdminNetworkPolicyEgressPeer{
+			{Namespaces: &metav1.LabelSelector{}},
+			{Networks: []string{"0.0.0.0/0", "::/0"}},
+		}
+	}
+	return peers
+}
+
+func adminNetworkPolicyPorts(ports []v2alpha1.NetworkPolicyRulePort) []anp.AdminNetworkPolicyPort {
+	var policyPorts []anp.AdminNetworkPolicyPort
+	for _, port := range ports {
+		protocol := corev1.ProtocolTCP
+		if port.Protocol != nil {
+			protocol = *port.Protocol
+		}
+		policyPorts = append(policyPorts, anp.AdminNetworkPolicyPort{
+			PortNumber: &anp.Port{Protocol: protocol, Port: port.Port},
+		})
+	}
+	return policyPorts
+}
+
+// BuildCiliumPolicy creates the base node agent, DCA, or CCR cilium network policy, extended with the additional rules
+func BuildCiliumPolicy(dda metav1.Object, site string, ddURL string, hostNetwork bool, dnsSelectorEndpoints []metav1.LabelSelector, componentName v2alpha1.ComponentName, additionalIngress, additionalEgress []v2alpha1.NetworkPolicyRule) (string, string, []cilium.NetworkPolicySpec) {
 	policyName, podSelector := GetNetworkPolicyMetadata(dda, componentName)
 	var policySpecs []cilium.NetworkPolicySpec
 
@@ -206,9 +646,114 @@ func BuildCiliumPolicy(dda metav1.Object, site string, ddURL string, hostNetwork
 			egressChecks(podSelector),
 		}
 	}
+
+	if len(additionalIngress) > 0 || len(additionalEgress) > 0 {
+		policySpecs = append(policySpecs, additionalRules(podSelector, additionalIngress, additionalEgress))
+	}
+
 	return policyName, dda.GetNamespace(), policySpecs
 }
 
+// cilium additional user-defined rules
+func additionalRules(podSelector metav1.LabelSelector, additionalIngress, additionalEgress []v2alpha1.NetworkPolicyRule) cilium.NetworkPolicySpec {
+	spec := cilium.NetworkPolicySpec{
+		Description:      "Additional rules",
+		EndpointSelector: podSelector,
+	}
+
+	// Cilium doesn't support combining several kinds of peers in a rule, a rule is created for each kind
+	for _, rule := range additionalIngress {
+		ports := ciliumPorts(rule.Ports)
+		if len(rule.IPBlocks) > 0 {
+			spec.Ingress = append(spec.Ingress, cilium.IngressRule{FromCIDR: rule.IPBlocks, ToPorts: ports})
+		}
+		if endpoints := ciliumEndpoints(rule); endpoints != nil {
+			spec.Ingress = append(spec.Ingress, cilium.IngressRule{FromEndpoints: endpoints, ToPorts: ports})
+		}
+		if len(rule.IPBlocks) == 0 && rule.PodSelector == nil && rule.NamespaceSelector == nil {
+			spec.Ingress = append(spec.Ingress, cilium.IngressRule{FromEntities: []cilium.Entity{cilium.EntityAll}, ToPorts: ports})
+		}
+	}
+	for _, rule := range additionalEgress {
+		ports := ciliumPorts(rule.Ports)
+		if len(rule.IPBlocks) > 0 {
+			spec.Egress = append(spec.Egress, cilium.EgressRule{ToCIDR: rule.IPBlocks, ToPorts: ports})
+		}
+		if len(rule.DomainNames) > 0 {
+			var fqdns []cilium.FQDNSelector
+			for _, name := range rule.DomainNames {
+				fqdns = append(fqdns, cilium.FQDNSelector{MatchName: name})
+			}
+			spec.Egress = append(spec.Egress, cilium.EgressRule{ToFQDNs: fqdns, ToPorts: ports})
+		}
+		if endpoints := ciliumEndpoints(rule); endpoints != nil {
+			spec.Egress = append(spec.Egress, cilium.EgressRule{ToEndpoints: endpoints, ToPorts: ports})
+		}
+		if len(rule.IPBlocks) == 0 && len(rule.DomainNames) == 0 && rule.PodSelector == nil && rule.NamespaceSelector == nil {
+			spec.Egress = append(spec.Egress, cilium.EgressRule{ToEntities: []cilium.Entity{cilium.EntityAll}, ToPorts: ports})
+		}
+	}
+
+	return spec
+}
+
+// ciliumEndpoints translates the pod and namespace selectors of a rule to a cilium endpoint selector
+func ciliumEndpoints(rule v2alpha1.NetworkPolicyRule) []metav1.LabelSelector {
+	if rule.PodSelector == nil && rule.NamespaceSelector == nil {
+		return nil
+	}
+
+	selector := metav1.LabelSelector{}
+	if rule.PodSelector != nil {
+		selector = *rule.PodSelector.DeepCopy()
+	}
+	if rule.NamespaceSelector != nil {
+		if selector.MatchLabels == nil && len(rule.NamespaceSelector.MatchLabels) > 0 {
+			selector.MatchLabels = map[string]string{}
+		}
+		for key, value := range rule.NamespaceSelector.MatchLabels {
+			selector.MatchLabels[ciliumNamespaceLabelPrefix+key] = value
+		}
+		for _, expression := range rule.NamespaceSelector.MatchExpressions {
+			expression.Key = ciliumNamespaceLabelPrefix + expression.Key
+			selector.MatchExpressions = append(selector.MatchExpressions, expression)
+		}
+		// Without namespace label, cilium only selects the endpoints of the policy namespace
+		selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      ciliumPodNamespaceLabel,
+			Operator: metav1.LabelSelectorOpExists,
+		})
+	}
+	return []metav1.LabelSelector{selector}
+}
+
+func ciliumPorts(ports []v2alpha1.NetworkPolicyRulePort) []cilium.PortRule {
+	if len(ports) == 0 {
+		return nil
+	}
+	portRule := cilium.PortRule{}
+	for _, port := range ports {
+		protocol := cilium.ProtocolTCP
+		if port.Protocol != nil && *port.Protocol == corev1.ProtocolUDP {
+			protocol = cilium.ProtocolUDP
+		}
+		portRule.Ports = append(portRule.Ports, cilium.PortProtocol{
+			Port:     strconv.Itoa(int(port.Port)),
+			Protocol: protocol,
+		})
+	}
+	return []cilium.PortRule{portRule}
+}
+
+const (
+	ciliumPodNamespaceLabel    = "k8s:io.kubernetes.pod.namespace"
+	ciliumNamespaceLabelPrefix = "k8s:io.cilium.k8s.namespace.labels."
+	calicoNamespaceNameLabel   = "projectcalico.org/name"
+
+	// adminNetworkPolicyPriority is the priority of the admin network policies, they select distinct pods
+	adminNetworkPolicyPriority = 50
+)
+
 // cilium egress ports for ECS
 func egressECSPorts(podSelector metav1.LabelSelector) cilium.NetworkPolicySpec {
 	return cilium.NetworkPolicySpec{
@@ -0,0 +1,346 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package objects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	anp "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+	calico "github.com/DataDog/datadog-operator/pkg/calico/v3"
+	cilium "github.com/DataDog/datadog-operator/pkg/cilium/v1"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+)
+
+func TestNetworkPolicyAdditionalRules(t *testing.T) {
+	udp := corev1.ProtocolUDP
+	kafka := v2alpha1.NetworkPolicyRule{
+		Ports:    []v2alpha1.NetworkPolicyRulePort{{Port: 9092}},
+		IPBlocks: []string{"10.0.0.0/24"},
+	}
+	syslog := v2alpha1.NetworkPolicyRule{
+		Ports:       []v2alpha1.NetworkPolicyRulePort{{Port: 514, Protocol: &udp}},
+		PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "syslog"}},
+	}
+
+	dda := &v2alpha1.DatadogAgent{
+		Spec: v2alpha1.DatadogAgentSpec{
+			Global: &v2alpha1.GlobalConfig{
+				Endpoint: &v2alpha1.Endpoint{URL: apiutils.NewStringPointer("https://intake.example.com:8443")},
+				Env: []corev1.EnvVar{
+					{Name: constants.DDProxyHTTPS, Value: "http://global-proxy:3128"},
+				},
+			},
+			Override: map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+				v2alpha1.NodeAgentComponentName: {
+					Env: []corev1.EnvVar{
+						{Name: constants.DDProxyHTTPS, Value: "http://10.1.2.3:3128"},
+						{Name: constants.DDProxyHTTP, Value: "proxy.example.com"},
+					},
+					NetworkPolicy: &v2alpha1.NetworkPolicyRules{
+						AdditionalEgress:  []v2alpha1.NetworkPolicyRule{kafka},
+						AdditionalIngress: []v2alpha1.NetworkPolicyRule{syslog},
+					},
+				},
+			},
+		},
+	}
+
+	ingress, egress := NetworkPolicyAdditionalRules(dda, v2alpha1.NodeAgentComponentName)
+	assert.Equal(t, []v2alpha1.NetworkPolicyRule{syslog}, ingress)
+	assert.Equal(t, []v2alpha1.NetworkPolicyRule{
+		kafka,
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 3128}}, IPBlocks: []string{"10.1.2.3/32"}},
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 80}}, DomainNames: []string{"proxy.example.com"}},
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 8443}}, DomainNames: []string{"intake.example.com"}},
+	}, egress)
+
+	ingress, egress = NetworkPolicyAdditionalRules(dda, v2alpha1.ClusterAgentComponentName)
+	assert.Empty(t, ingress)
+	assert.Equal(t, []v2alpha1.NetworkPolicyRule{
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 3128}}, DomainNames: []string{"global-proxy"}},
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 8443}}, DomainNames: []string{"intake.example.com"}},
+	}, egress)
+
+	dda.Spec.Global.Proxy = &v2alpha1.ProxyConfig{
+		HTTP: apiutils.NewStringPointer("http://typed-proxy:3128"),
+		Logs: &v2alpha1.ProductIntakeConfig{URL: apiutils.NewStringPointer("logs-proxy:10514")},
+	}
+	ingress, egress = NetworkPolicyAdditionalRules(dda, v2alpha1.ClusterChecksRunnerComponentName)
+	assert.Empty(t, ingress)
+	assert.Equal(t, []v2alpha1.NetworkPolicyRule{
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 3128}}, DomainNames: []string{"global-proxy"}},
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 3128}}, DomainNames: []string{"typed-proxy"}},
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 8443}}, DomainNames: []string{"intake.example.com"}},
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 10514}}, DomainNames: []string{"logs-proxy"}},
+	}, egress)
+
+	dda.Spec.Global.Proxy = nil
+	dda.Spec.Global.AdditionalEndpoints = &v2alpha1.AdditionalEndpointsConfig{
+		Metrics: []v2alpha1.AdditionalEndpoint{
+			{URL: "https://app.datadoghq.eu", APIKeySecret: v2alpha1.SecretConfig{SecretName: "eu", KeyName: "api_key"}},
+			{URL: "https://app.datadoghq.eu", APIKeySecret: v2alpha1.SecretConfig{SecretName: "eu", KeyName: "other_api_key"}},
+		},
+		Logs: []v2alpha1.AdditionalEndpoint{
+			{URL: "agent-http-intake.logs.datadoghq.eu", APIKeySecret: v2alpha1.SecretConfig{SecretName: "eu", KeyName: "api_key"}},
+		},
+	}
+	_, egress = NetworkPolicyAdditionalRules(dda, v2alpha1.ClusterChecksRunnerComponentName)
+	assert.Equal(t, []v2alpha1.NetworkPolicyRule{
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 3128}}, DomainNames: []string{"global-proxy"}},
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 8443}}, DomainNames: []string{"intake.example.com"}},
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 443}}, DomainNames: []string{"app.datadoghq.eu"}},
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 443}}, DomainNames: []string{"agent-http-intake.logs.datadoghq.eu"}},
+	}, egress)
+}
+
+func TestBuildKubernetesNetworkPolicyAdditionalRules(t *testing.T) {
+	dda := &metav1.ObjectMeta{Namespace: "datadog", Name: "foo"}
+	namespaceSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "data"}}
+
+	_, _, _, _, baseIngress, baseEgress := BuildKubernetesNetworkPolicy(dda, v2alpha1.NodeAgentComponentName, nil, nil)
+	_, _, _, _, ingress, egress := BuildKubernetesNetworkPolicy(dda, v2alpha1.NodeAgentComponentName,
+		[]v2alpha1.NetworkPolicyRule{
+			{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 514}}, NamespaceSelector: namespaceSelector},
+		},
+		[]v2alpha1.NetworkPolicyRule{
+			{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 9092}}, IPBlocks: []string{"10.0.0.0/24", "10.0.1.0/24"}},
+			{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 443}}, DomainNames: []string{"intake.example.com"}},
+			{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 3128}}, DomainNames: []string{"proxy.example.com"}, IPBlocks: []string{"10.0.2.0/24"}},
+		},
+	)
+
+	assert.Equal(t, append(baseIngress, netv1.NetworkPolicyIngressRule{
+		Ports: []netv1.NetworkPolicyPort{{Port: &intstr.IntOrString{Type: intstr.Int, IntVal: 514}}},
+		From:  []netv1.NetworkPolicyPeer{{NamespaceSelector: namespaceSelector}},
+	}), ingress)
+	assert.Equal(t, append(baseEgress,
+		netv1.NetworkPolicyEgressRule{
+			Ports: []netv1.NetworkPolicyPort{{Port: &intstr.IntOrString{Type: intstr.Int, IntVal: 9092}}},
+			To: []netv1.NetworkPolicyPeer{
+				{IPBlock: &netv1.IPBlock{CIDR: "10.0.0.0/24"}},
+				{IPBlock: &netv1.IPBlock{CIDR: "10.0.1.0/24"}},
+			},
+		},
+		// Domain names can't be selected, the rule with only domain names is skipped and the other one keeps its IP blocks
+		netv1.NetworkPolicyEgressRule{
+			Ports: []netv1.NetworkPolicyPort{{Port: &intstr.IntOrString{Type: intstr.Int, IntVal: 3128}}},
+			To:    []netv1.NetworkPolicyPeer{{IPBlock: &netv1.IPBlock{CIDR: "10.0.2.0/24"}}},
+		},
+	), egress)
+}
+
+func TestKubernetesRulesWithDomainNames(t *testing.T) {
+	withDomainNames := v2alpha1.NetworkPolicyRule{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 443}}, DomainNames: []string{"intake.example.com"}}
+	assert.Equal(t, []v2alpha1.NetworkPolicyRule{withDomainNames}, KubernetesRulesWithDomainNames([]v2alpha1.NetworkPolicyRule{
+		{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 9092}}, IPBlocks: []string{"10.0.0.0/24"}},
+		withDomainNames,
+	}))
+}
+
+func TestBuildCiliumPolicyAdditionalRules(t *testing.T) {
+	dda := &metav1.ObjectMeta{Namespace: "datadog", Name: "foo"}
+	udp := corev1.ProtocolUDP
+
+	_, _, baseSpecs := BuildCiliumPolicy(dda, "datadoghq.com", "", false, nil, v2alpha1.NodeAgentComponentName, nil, nil)
+	_, _, specs := BuildCiliumPolicy(dda, "datadoghq.com", "", false, nil, v2alpha1.NodeAgentComponentName,
+		[]v2alpha1.NetworkPolicyRule{
+			{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 514, Protocol: &udp}}, IPBlocks: []string{"10.0.0.0/8"}},
+		},
+		[]v2alpha1.NetworkPolicyRule{
+			{
+				Ports:             []v2alpha1.NetworkPolicyRulePort{{Port: 9092}},
+				DomainNames:       []string{"kafka.example.com"},
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "kafka"}},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "data"}},
+			},
+		},
+	)
+
+	assert.Len(t, specs, len(baseSpecs)+1)
+	assert.Equal(t, baseSpecs, specs[:len(baseSpecs)])
+
+	kafkaPorts := []cilium.PortRule{{Ports: []cilium.PortProtocol{{Port: "9092", Protocol: cilium.ProtocolTCP}}}}
+	assert.Equal(t, cilium.NetworkPolicySpec{
+		Description:      "Additional rules",
+		EndpointSelector: specs[0].EndpointSelector,
+		Ingress: []cilium.IngressRule{
+			{
+				FromCIDR: []string{"10.0.0.0/8"},
+				ToPorts:  []cilium.PortRule{{Ports: []cilium.PortProtocol{{Port: "514", Protocol: cilium.ProtocolUDP}}}},
+			},
+		},
+		Egress: []cilium.EgressRule{
+			{
+				ToFQDNs: []cilium.FQDNSelector{{MatchName: "kafka.example.com"}},
+				ToPorts: kafkaPorts,
+			},
+			{
+				ToEndpoints: []metav1.LabelSelector{
+					{
+						MatchLabels: map[string]string{
+							"app": "kafka",
+							"k8s:io.cilium.k8s.namespace.labels.team": "data",
+						},
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "k8s:io.kubernetes.pod.namespace", Operator: metav1.LabelSelectorOpExists},
+						},
+					},
+				},
+				ToPorts: kafkaPorts,
+			},
+		},
+	}, specs[len(baseSpecs)])
+}
+
+func TestBuildCalicoPolicy(t *testing.T) {
+	dda := &metav1.ObjectMeta{Namespace: "datadog", Name: "foo"}
+	udp := corev1.ProtocolUDP
+
+	name, spec := BuildCalicoPolicy(dda, v2alpha1.ClusterAgentComponentName,
+		[]v2alpha1.NetworkPolicyRule{
+			{Ports: []v2alpha1.NetworkPolicyRulePort{{Port: 514, Protocol: &udp}}, IPBlocks: []string{"10.0.0.0/8"}},
+		},
+		[]v2alpha1.NetworkPolicyRule{
+			{
+				Ports:       []v2alpha1.NetworkPolicyRulePort{{Port: 9092}, {Port: 9093, Protocol: &udp}},
+				DomainNames: []string{"kafka.example.com"},
+			},
+		},
+	)
+
+	tcp, calicoUDP := calico.ProtocolTCP, calico.ProtocolUDP
+	dcaSelector := "app.kubernetes.io/instance == 'foo-cluster-agent' && app.kubernetes.io/part-of == 'datadog-foo'"
+	agentSelector := "app.kubernetes.io/instance == 'foo-agent' && app.kubernetes.io/part-of == 'datadog-foo'"
+	namespaceSelector := "projectcalico.org/name == 'datadog'"
+
+	assert.Equal(t, "datadog-foo-cluster-agent", name)
+	assert.Equal(t, calico.GlobalNetworkPolicySpec{
+		Selector:          dcaSelector,
+		NamespaceSelector: namespaceSelector,
+		Types:             []calico.PolicyType{calico.PolicyTypeIngress, calico.PolicyTypeEgress},
+		Ingress: []calico.Rule{
+			{
+				Action:      calico.ActionAllow,
+				Protocol:    &tcp,
+				Source:      calico.EntityRule{Selector: agentSelector, NamespaceSelector: namespaceSelector},
+				Destination: calico.EntityRule{Ports: []string{"5005"}},
+			},
+			{
+				Action:      calico.ActionAllow,
+				Protocol:    &tcp,
+				Source:      calico.EntityRule{Selector: dcaSelector, NamespaceSelector: namespaceSelector},
+				Destination: calico.EntityRule{Ports: []string{"5005"}},
+			},
+			{
+				Action:      calico.ActionAllow,
+				Protocol:    &tcp,
+				Source:      calico.EntityRule{Selector: agentSelector, NamespaceSelector: namespaceSelector},
+				Destination: calico.EntityRule{Ports: []string{"5000"}},
+			},
+			{
+				Action:      calico.ActionAllow,
+				Protocol:    &calicoUDP,
+				Source:      calico.EntityRule{Nets: []string{"10.0.0.0/8"}},
+				Destination: calico.EntityRule{Ports: []string{"514"}},
+			},
+		},
+		Egress: []calico.Rule{
+			{
+				Action:      calico.ActionAllow,
+				Protocol:    &tcp,
+				Destination: calico.EntityRule{Ports: []string{"443"}},
+			},
+			{
+				Action:      calico.ActionAllow,
+				Protocol:    &tcp,
+				Destination: calico.EntityRule{Selector: dcaSelector, NamespaceSelector: namespaceSelector, Ports: []string{"5005"}},
+			},
+			{
+				Action:      calico.ActionAllow,
+				Protocol:    &tcp,
+				Destination: calico.EntityRule{Domains: []string{"kafka.example.com"}, Ports: []string{"9092"}},
+			},
+			{
+				Action:      calico.ActionAllow,
+				Protocol:    &calicoUDP,
+				Destination: calico.EntityRule{Domains: []string{"kafka.example.com"}, Ports: []string{"9093"}},
+			},
+		},
+	}, spec)
+}
+
+func TestBuildAdminNetworkPolicy(t *testing.T) {
+	dda := &metav1.ObjectMeta{Namespace: "datadog", Name: "foo"}
+
+	name, spec := BuildAdminNetworkPolicy(dda, v2alpha1.NodeAgentComponentName, []v2alpha1.NetworkPolicyRule{
+		{
+			Ports:             []v2alpha1.NetworkPolicyRulePort{{Port: 5432}},
+			IPBlocks:          []string{"10.0.0.0/24"},
+			DomainNames:       []string{"db.example.com"},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "db"}},
+		},
+	})
+
+	_, podSelector := GetNetworkPolicyMetadata(dda, v2alpha1.NodeAgentComponentName)
+	anyPeer := []anp.AdminNetworkPolicyEgressPeer{
+		{Namespaces: &metav1.LabelSelector{}},
+		{Networks: []string{"0.0.0.0/0", "::/0"}},
+	}
+
+	assert.Equal(t, "datadog-foo-agent", name)
+	assert.Equal(t, anp.AdminNetworkPolicySpec{
+		Priority: adminNetworkPolicyPriority,
+		Subject: anp.Subject{
+			Pods: &anp.NamespacedPod{
+				NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "datadog"}},
+				PodSelector:       podSelector,
+			},
+		},
+		Egress: []anp.AdminNetworkPolicyEgressRule{
+			{
+				Action: anp.RuleActionAllow,
+				To:     anyPeer,
+				Ports:  []anp.AdminNetworkPolicyPort{{PortNumber: &anp.Port{Protocol: corev1.ProtocolTCP, Port: 443}}},
+			},
+			{
+				Action: anp.RuleActionAllow,
+				To: []anp.AdminNetworkPolicyEgressPeer{
+					{Networks: []string{"10.0.0.0/24"}},
+					{DomainNames: []string{"db.example.com"}},
+					{Namespaces: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "db"}}},
+				},
+				Ports: []anp.AdminNetworkPolicyPort{{PortNumber: &anp.Port{Protocol: corev1.ProtocolTCP, Port: 5432}}},
+			},
+			{
+				Name:   "deny-all",
+				Action: anp.RuleActionDeny,
+				To:     anyPeer,
+			},
+		},
+	}, spec)
+}
+
+func Test_calicoSelector(t *testing.T) {
+	assert.Equal(t, "all()", calicoSelector(metav1.LabelSelector{}))
+	assert.Equal(t, "app == 'agent' && team == 'foo' && env in { 'prod', 'staging' } && zone not in { 'a' } && has(tier) && !has(canary)",
+		calicoSelector(metav1.LabelSelector{
+			MatchLabels: map[string]string{"team": "foo", "app": "agent"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"prod", "staging"}},
+				{Key: "zone", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"a"}},
+				{Key: "tier", Operator: metav1.LabelSelectorOpExists},
+				{Key: "canary", Operator: metav1.LabelSelectorOpDoesNotExist},
+			},
+		}))
+}
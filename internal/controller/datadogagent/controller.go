@@ -19,12 +19,14 @@ import (
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/tracing"
 
 	// Use to register features
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/admissioncontroller"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/apm"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/asm"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/autoscaling"
+	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/clusteragenttls"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/clusterchecks"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/cspm"
 	_ "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/cws"
@@ -56,15 +58,17 @@ import (
 
 const (
 	defaultRequeuePeriod = 15 * time.Second
+	datadogAgentKind     = "DatadogAgent"
 )
 
 // ReconcilerOptions provides options read from command line
 type ReconcilerOptions struct {
-	ExtendedDaemonsetOptions   componentagent.ExtendedDaemonsetOptions
-	SupportCilium              bool
-	OperatorMetricsEnabled     bool
-	IntrospectionEnabled       bool
-	DatadogAgentProfileEnabled bool
+	ExtendedDaemonsetOptions      componentagent.ExtendedDaemonsetOptions
+	SupportCilium                 bool
+	OperatorMetricsEnabled        bool
+	IntrospectionEnabled          bool
+	DatadogAgentProfileEnabled    bool
+	DatadogInstrumentationEnabled bool
 }
 
 // Reconciler is the internal reconciler for Datadog Agent
@@ -76,6 +80,8 @@ type Reconciler struct {
 	log          logr.Logger
 	recorder     record.EventRecorder
 	forwarders   datadog.MetricForwardersManager
+
+	clusterAgentProber clusterAgentProber
 }
 
 // NewReconciler returns a reconciler for DatadogAgent
@@ -90,6 +96,8 @@ func NewReconciler(options ReconcilerOptions, client client.Client, platformInfo
 		log:          log,
 		recorder:     recorder,
 		forwarders:   metricForwardersMgr,
+
+		clusterAgentProber: httpClusterAgentProber{},
 	}, nil
 }
 
@@ -98,7 +106,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	var resp reconcile.Result
 	var err error
 
+	ctx, span := tracing.StartSpan(ctx, "DatadogAgent.Reconcile", tracing.RequestAttributes(datadogAgentKind, request.Namespace, request.Name)...)
 	resp, err = r.internalReconcileV2(ctx, request)
+	tracing.EndSpan(span, err)
 
 	r.metricsForwarderProcessError(request, err)
 	return resp, err
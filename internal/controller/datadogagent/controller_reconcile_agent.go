@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -34,7 +35,7 @@ import (
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 )
 
-func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents feature.RequiredComponents, features []feature.Feature,
+func (r *Reconciler) reconcileV2Agent(ctx context.Context, logger logr.Logger, requiredComponents feature.RequiredComponents, features []feature.Feature,
 	dda *datadoghqv2alpha1.DatadogAgent, resourcesManager feature.ResourceManagers, newStatus *datadoghqv2alpha1.DatadogAgentStatus,
 	provider string, providerList map[string]struct{}, profile *v1alpha1.DatadogAgentProfile) (reconcile.Result, error) {
 	var result reconcile.Result
@@ -81,6 +82,9 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 		if r.options.DatadogAgentProfileEnabled {
 			// Apply overrides from profiles after override from manifest, so they can override what's defined in the DDA.
 			overrideFromProfile := agentprofile.OverrideFromProfile(profile)
+			if errs := override.ProfileDependencies(logger, resourcesManager, &overrideFromProfile, dda.Namespace); len(errs) > 0 {
+				return result, utilerrors.NewAggregate(errs)
+			}
 			componentOverrides = append(componentOverrides, &overrideFromProfile)
 		}
 
@@ -119,13 +123,13 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 					true,
 				)
 			}
-			if err := r.deleteV2ExtendedDaemonSet(daemonsetLogger, dda, eds, newStatus); err != nil {
+			if err := r.deleteV2ExtendedDaemonSet(ctx, daemonsetLogger, dda, eds, newStatus); err != nil {
 				return reconcile.Result{}, err
 			}
 			return reconcile.Result{}, nil
 		}
 
-		return r.createOrUpdateExtendedDaemonset(daemonsetLogger, dda, eds, newStatus, updateEDSStatusV2WithAgent)
+		return r.createOrUpdateExtendedDaemonset(ctx, daemonsetLogger, dda, eds, newStatus, updateEDSStatusV2WithAgent)
 	}
 
 	// Start by creating the Default Agent daemonset
@@ -156,6 +160,9 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 	if r.options.DatadogAgentProfileEnabled {
 		// Apply overrides from profiles after override from manifest, so they can override what's defined in the DDA.
 		overrideFromProfile := agentprofile.OverrideFromProfile(profile)
+		if errs := override.ProfileDependencies(logger, resourcesManager, &overrideFromProfile, dda.Namespace); len(errs) > 0 {
+			return result, utilerrors.NewAggregate(errs)
+		}
 		componentOverrides = append(componentOverrides, &overrideFromProfile)
 	}
 
@@ -194,14 +201,14 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, requiredComponents fea
 				true,
 			)
 		}
-		if err := r.deleteV2DaemonSet(daemonsetLogger, dda, daemonset, newStatus); err != nil {
+		if err := r.deleteV2DaemonSet(ctx, daemonsetLogger, dda, daemonset, newStatus); err != nil {
 			return reconcile.Result{}, err
 		}
 		deleteStatusWithAgent(newStatus)
 		return reconcile.Result{}, nil
 	}
 
-	return r.createOrUpdateDaemonset(daemonsetLogger, dda, daemonset, newStatus, updateDSStatusV2WithAgent, profile)
+	return r.createOrUpdateDaemonset(ctx, daemonsetLogger, dda, daemonset, newStatus, updateDSStatusV2WithAgent, profile)
 }
 
 func updateDSStatusV2WithAgent(dsName string, ds *appsv1.DaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
@@ -216,8 +223,8 @@ func updateEDSStatusV2WithAgent(eds *edsv1alpha1.ExtendedDaemonSet, newStatus *d
 	newStatus.Agent = condition.UpdateCombinedDaemonSetStatus(newStatus.AgentList)
 }
 
-func (r *Reconciler) deleteV2DaemonSet(logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, ds *appsv1.DaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus) error {
-	err := r.client.Delete(context.TODO(), ds)
+func (r *Reconciler) deleteV2DaemonSet(ctx context.Context, logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, ds *appsv1.DaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus) error {
+	err := r.client.Delete(ctx, ds)
 	if err != nil {
 		return err
 	}
@@ -229,8 +236,8 @@ func (r *Reconciler) deleteV2DaemonSet(logger logr.Logger, dda *datadoghqv2alpha
 	return nil
 }
 
-func (r *Reconciler) deleteV2ExtendedDaemonSet(logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, eds *edsv1alpha1.ExtendedDaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus) error {
-	err := r.client.Delete(context.TODO(), eds)
+func (r *Reconciler) deleteV2ExtendedDaemonSet(ctx context.Context, logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, eds *edsv1alpha1.ExtendedDaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus) error {
+	err := r.client.Delete(ctx, eds)
 	if err != nil {
 		return err
 	}
@@ -414,7 +421,7 @@ func (r *Reconciler) cleanupExtraneousDaemonSets(ctx context.Context, logger log
 
 		for _, eds := range edsList.Items {
 			if _, ok := validExtendedDaemonSetNames[eds.Name]; !ok {
-				if err := r.deleteV2ExtendedDaemonSet(logger, dda, &eds, newStatus); err != nil {
+				if err := r.deleteV2ExtendedDaemonSet(ctx, logger, dda, &eds, newStatus); err != nil {
 					return err
 				}
 			}
@@ -428,7 +435,7 @@ func (r *Reconciler) cleanupExtraneousDaemonSets(ctx context.Context, logger log
 
 	for _, daemonSet := range daemonSetList.Items {
 		if _, ok := validDaemonSetNames[daemonSet.Name]; !ok {
-			if err := r.deleteV2DaemonSet(logger, dda, &daemonSet, newStatus); err != nil {
+			if err := r.deleteV2DaemonSet(ctx, logger, dda, &daemonSet, newStatus); err != nil {
 				return err
 			}
 		}
@@ -0,0 +1,278 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	componentdca "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/clusteragent"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/clusteragenttls"
+	"github.com/DataDog/datadog-operator/pkg/certificates"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// ensureClusterAgentCertificates issues and renews the certificates securing the connections to the Cluster Agent,
+// and records their generation in the status. The pods are restarted on a new generation to load the new certificates.
+// With cert-manager, the certificates are only read from the secrets it manages.
+// It returns the bundle of certificate authorities, empty until the certificates are issued.
+func (r *Reconciler) ensureClusterAgentCertificates(ctx context.Context, logger logr.Logger, instance *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, resourceManagers feature.ResourceManagers, now metav1.Time) ([]byte, error) {
+	if !clusteragenttls.IsEnabled(instance) {
+		newStatus.ClusterAgentTLS = nil
+		return nil, nil
+	}
+
+	bundleSecret, err := r.getSecret(ctx, instance.Namespace, componentdca.GetClusterAgentCABundleSecretName(instance))
+	if err != nil {
+		return nil, err
+	}
+	var previousBundle []byte
+	if bundleSecret != nil {
+		previousBundle = bundleSecret.Data[clusteragenttls.CACertKey]
+	}
+
+	var caBundle []byte
+	var notAfter time.Time
+	if clusteragenttls.UseCertManager(instance, r.platformInfo) {
+		serverSecret, err := r.getSecret(ctx, instance.Namespace, componentdca.GetClusterAgentServerTLSSecretName(instance))
+		if err != nil {
+			return nil, err
+		}
+		clientSecret, err := r.getSecret(ctx, instance.Namespace, componentdca.GetClusterAgentClientTLSSecretName(instance))
+		if err != nil {
+			return nil, err
+		}
+		if serverSecret == nil || clientSecret == nil {
+			logger.V(1).Info("Cluster Agent certificates not issued by cert-manager yet")
+			return nil, nil
+		}
+
+		for _, secret := range []*corev1.Secret{serverSecret, clientSecret} {
+			certs, err := certificates.ParseCertificates(secret.Data[corev1.TLSCertKey])
+			if err != nil {
+				return nil, fmt.Errorf("invalid certificate in secret %s/%s: %w", secret.Namespace, secret.Name, err)
+			}
+			if len(certs) == 0 {
+				logger.V(1).Info("Cluster Agent certificate not issued by cert-manager yet", "secret", secret.Name)
+				return nil, nil
+			}
+			notAfter = earliest(notAfter, certs[0].NotAfter)
+		}
+		if caBundle, err = mergeCABundle(serverSecret.Data[clusteragenttls.CACertKey], previousBundle, now.Time); err != nil {
+			return nil, err
+		}
+	} else {
+		caBundle, notAfter, err = r.issueClusterAgentCertificates(ctx, logger, instance, resourceManagers, now.Time)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The pods read the bundle from its own secret, it is the same for both issuers
+	if err := resourceManagers.SecretManager().AddSecret(instance.Namespace, componentdca.GetClusterAgentCABundleSecretName(instance), clusteragenttls.CACertKey, string(caBundle)); err != nil {
+		return nil, fmt.Errorf("error adding cluster agent certificate authorities secret to store: %w", err)
+	}
+
+	// The pods are also restarted on a new certificate authority, to trust it before it issues the certificates
+	updateClusterAgentTLSStatus(newStatus, notAfter, previousBundle != nil && !bytes.Equal(previousBundle, caBundle), now)
+	if !newStatus.ClusterAgentTLS.ClusterAgentVerified {
+		newStatus.ClusterAgentTLS.ClusterAgentVerified = r.verifyClusterAgentTLS(ctx, logger, instance)
+	}
+
+	return caBundle, nil
+}
+
+// verifyClusterAgentTLS returns true if the Cluster Agent serves a certificate issued by the certificate authorities.
+// A Cluster Agent ignoring the certificate settings keeps serving its own certificate, the Agents can't verify it
+// and keep their default connection to the Cluster Agent.
+func (r *Reconciler) verifyClusterAgentTLS(ctx context.Context, logger logr.Logger, instance *datadoghqv2alpha1.DatadogAgent) bool {
+	tlsConfig, err := r.clusterAgentTLSConfig(ctx, instance)
+	if err == nil {
+		_, err = r.clusterAgentProber.Probe(ctx, tlsConfig, clusteragenttls.ClusterAgentURL(instance)+clusterAgentVersionPath, "")
+	}
+	if err != nil {
+		logger.V(1).Info("Cluster Agent doesn't serve the issued certificate yet, the Agents keep connecting to it without TLS", "error", err.Error())
+		return false
+	}
+	logger.Info("Cluster Agent serves the issued certificate, the Agents connect to it over TLS")
+	return true
+}
+
+// mergeCABundle returns the bundle of the certificate authority issuing the cert-manager certificates.
+// cert-manager only sets the current certificate authority in the certificate secrets, a renewed one is kept from the
+// previous bundle while it is valid, to trust the certificates it issued until all the pods are restarted.
+func mergeCABundle(caCert, previousBundle []byte, now time.Time) ([]byte, error) {
+	cas, err := certificates.ParseCertificates(caCert)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate authority issuing the cluster agent certificates: %w", err)
+	}
+	// Parsing errors are ignored, the bundle is rebuilt from the valid certificate authorities
+	previousCAs, _ := certificates.ParseCertificates(previousBundle)
+	for _, previousCA := range previousCAs {
+		if now.Before(previousCA.NotAfter) && !slices.ContainsFunc(cas, previousCA.Equal) {
+			cas = append(cas, previousCA)
+		}
+	}
+	return certificates.Bundle(cas...), nil
+}
+
+// setMetricsServerCABundle configures the API server to verify the external metrics server with the certificate authorities
+func setMetricsServerCABundle(resourceManagers feature.ResourceManagers, caBundle []byte) {
+	if len(caBundle) == 0 {
+		return
+	}
+	if obj, found := resourceManagers.Store().Get(kubernetes.APIServiceKind, "", componentdca.GetMetricsServerAPIServiceName()); found {
+		if apiService, ok := obj.(*apiregistrationv1.APIService); ok {
+			apiService.Spec.CABundle = caBundle
+			apiService.Spec.InsecureSkipTLSVerify = false
+		}
+	}
+}
+
+// issueClusterAgentCertificates issues the certificate authority, the serving and the client certificates when
+// they are missing or need to be renewed, and adds their secrets to the store.
+// A renewed certificate authority is kept in the bundle while it is valid, to trust the certificates it issued
+// until all the pods are restarted.
+// It returns the bundle of certificate authorities and the earliest expiration of the certificates.
+func (r *Reconciler) issueClusterAgentCertificates(ctx context.Context, logger logr.Logger, instance *datadoghqv2alpha1.DatadogAgent, resourceManagers feature.ResourceManagers, now time.Time) ([]byte, time.Time, error) {
+	ns := instance.Namespace
+	caName := componentdca.GetClusterAgentCASecretName(instance)
+	caSecret, err := r.getSecret(ctx, ns, caName)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var ca *certificates.KeyPair
+	var previousCAs []*x509.Certificate
+	if caSecret != nil {
+		if ca, err = certificates.ParseKeyPair(caSecret.Data[corev1.TLSCertKey], caSecret.Data[corev1.TLSPrivateKeyKey]); err != nil {
+			logger.Info("Invalid Cluster Agent certificate authority, issuing a new one", "error", err.Error())
+		}
+		// Parsing errors are ignored, the bundle is rebuilt from the valid certificate authorities
+		bundle, _ := certificates.ParseCertificates(caSecret.Data[clusteragenttls.CACertKey])
+		for _, cert := range bundle {
+			if now.Before(cert.NotAfter) && (ca == nil || !cert.Equal(ca.Certificate)) {
+				previousCAs = append(previousCAs, cert)
+			}
+		}
+	}
+	if ca == nil || certificates.NeedsRenewal(ca.Certificate, now) {
+		if ca != nil {
+			previousCAs = append(previousCAs, ca.Certificate)
+		}
+		if ca, err = certificates.NewCA(caName, clusteragenttls.CAValidity(instance), now); err != nil {
+			return nil, time.Time{}, err
+		}
+		logger.Info("Issued a new Cluster Agent certificate authority", "notAfter", ca.Certificate.NotAfter)
+	}
+	caBundle := certificates.Bundle(append([]*x509.Certificate{ca.Certificate}, previousCAs...)...)
+	if err = addKeyPairSecret(resourceManagers, ns, caName, ca, caBundle); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	validity := clusteragenttls.CertificateValidity(instance)
+	var notAfter time.Time
+	for _, leaf := range []struct {
+		secretName string
+		commonName string
+		dnsNames   []string
+	}{
+		{
+			secretName: componentdca.GetClusterAgentServerTLSSecretName(instance),
+			commonName: componentdca.GetClusterAgentServiceName(instance),
+			dnsNames:   componentdca.GetClusterAgentServerDNSNames(instance),
+		},
+		{
+			secretName: componentdca.GetClusterAgentClientTLSSecretName(instance),
+			commonName: clusteragenttls.ClientCommonName,
+		},
+	} {
+		secret, err := r.getSecret(ctx, ns, leaf.secretName)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		var keyPair *certificates.KeyPair
+		if secret != nil {
+			keyPair, _ = certificates.ParseKeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		}
+		if keyPair == nil || certificates.NeedsRenewal(keyPair.Certificate, now) || !certificates.IsIssuedBy(keyPair.Certificate, ca.Certificate) || !certificates.HasDNSNames(keyPair.Certificate, leaf.dnsNames) {
+			if keyPair, err = ca.Issue(leaf.commonName, leaf.dnsNames, validity, now); err != nil {
+				return nil, time.Time{}, err
+			}
+			logger.Info("Issued a new Cluster Agent certificate", "secret", leaf.secretName, "notAfter", keyPair.Certificate.NotAfter)
+		}
+		if err = addKeyPairSecret(resourceManagers, ns, leaf.secretName, keyPair, caBundle); err != nil {
+			return nil, time.Time{}, err
+		}
+		notAfter = earliest(notAfter, keyPair.Certificate.NotAfter)
+	}
+
+	return caBundle, notAfter, nil
+}
+
+// getSecret returns the secret, or nil if it doesn't exist
+func (r *Reconciler) getSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+func addKeyPairSecret(resourceManagers feature.ResourceManagers, namespace, name string, keyPair *certificates.KeyPair, caBundle []byte) error {
+	keyPEM, err := keyPair.KeyPEM()
+	if err != nil {
+		return err
+	}
+	for key, value := range map[string][]byte{
+		corev1.TLSCertKey:         keyPair.CertificatePEM(),
+		corev1.TLSPrivateKeyKey:   keyPEM,
+		clusteragenttls.CACertKey: caBundle,
+	} {
+		if err := resourceManagers.SecretManager().AddSecret(namespace, name, key, string(value)); err != nil {
+			return fmt.Errorf("error adding cluster agent certificate secret to store: %w", err)
+		}
+	}
+	return nil
+}
+
+// updateClusterAgentTLSStatus increments the generation of the certificates when new ones are issued,
+// or when the bundle of certificate authorities changed
+func updateClusterAgentTLSStatus(newStatus *datadoghqv2alpha1.DatadogAgentStatus, notAfter time.Time, caBundleChanged bool, now metav1.Time) {
+	if newStatus.ClusterAgentTLS == nil {
+		newStatus.ClusterAgentTLS = &datadoghqv2alpha1.ClusterAgentTLSStatus{}
+	}
+	status := newStatus.ClusterAgentTLS
+	if !caBundleChanged && status.NotAfter != nil && status.NotAfter.Time.Equal(notAfter) {
+		return
+	}
+	status.Generation++
+	status.NotAfter = &metav1.Time{Time: notAfter}
+	status.LastRotationTime = &now
+}
+
+func earliest(current, t time.Time) time.Time {
+	if current.IsZero() || t.Before(current) {
+		return t
+	}
+	return current
+}
@@ -0,0 +1,201 @@
+package datadogagent
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	componentdca "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/clusteragent"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/clusteragenttls"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	"github.com/DataDog/datadog-operator/pkg/certificates"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func Test_ensureClusterAgentCertificates(t *testing.T) {
+	sch := runtime.NewScheme()
+	_ = scheme.AddToScheme(sch)
+	_ = v2alpha1.AddToScheme(sch)
+	_ = apiregistrationv1.AddToScheme(sch)
+	ctx := context.Background()
+	logger := logf.Log.WithName("Test_ensureClusterAgentCertificates")
+
+	dda := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "foo"},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Global: &v2alpha1.GlobalConfig{
+				ClusterAgentTLS: &v2alpha1.ClusterAgentTLSConfig{
+					Enabled:             apiutils.NewBoolPointer(true),
+					CertificateValidity: &metav1.Duration{Duration: 90 * time.Hour},
+				},
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(sch).Build()
+	prober := &fakeClusterAgentProber{err: errors.New("x509: certificate signed by unknown authority")}
+	r := &Reconciler{client: fakeClient, scheme: sch, clusterAgentProber: prober}
+
+	// reconcile runs the step and applies the secrets of the store like the reconciler does
+	reconcile := func(now time.Time, status *v2alpha1.DatadogAgentStatus) (*v2alpha1.DatadogAgentStatus, feature.ResourceManagers) {
+		resourceManagers := feature.NewResourceManagers(store.NewStore(dda, &store.StoreOptions{Scheme: sch, Logger: logger}))
+		require.NoError(t, resourceManagers.APIServiceManager().AddAPIService(componentdca.GetMetricsServerAPIServiceName(), testNamespace, apiregistrationv1.APIServiceSpec{InsecureSkipTLSVerify: true}))
+
+		newStatus := status.DeepCopy()
+		caBundle, err := r.ensureClusterAgentCertificates(ctx, logger, dda, newStatus, resourceManagers, metav1.NewTime(now))
+		require.NoError(t, err)
+		setMetricsServerCABundle(resourceManagers, caBundle)
+
+		for _, name := range []string{"foo-cluster-agent-ca", "foo-cluster-agent-ca-bundle", "foo-cluster-agent-tls", "foo-cluster-agent-client-tls"} {
+			obj, found := resourceManagers.Store().Get(kubernetes.SecretsKind, testNamespace, name)
+			if !found {
+				continue
+			}
+			secret := obj.(*corev1.Secret).DeepCopy()
+			if err := fakeClient.Update(ctx, secret); err != nil {
+				require.NoError(t, fakeClient.Create(ctx, secret))
+			}
+		}
+		return newStatus, resourceManagers
+	}
+	getKeyPair := func(name string) *certificates.KeyPair {
+		secret := &corev1.Secret{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: name}, secret))
+		keyPair, err := certificates.ParseKeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		require.NoError(t, err)
+		return keyPair
+	}
+
+	// First issuance
+	issuance := time.Now()
+	status, resourceManagers := reconcile(issuance, &v2alpha1.DatadogAgentStatus{})
+	require.NotNil(t, status.ClusterAgentTLS)
+	assert.Equal(t, int64(1), status.ClusterAgentTLS.Generation)
+	assert.Equal(t, issuance.Unix(), status.ClusterAgentTLS.LastRotationTime.Unix())
+	assert.False(t, status.ClusterAgentTLS.ClusterAgentVerified, "the cluster agent doesn't serve the issued certificate yet")
+
+	ca := getKeyPair("foo-cluster-agent-ca")
+	server := getKeyPair("foo-cluster-agent-tls")
+	clientCert := getKeyPair("foo-cluster-agent-client-tls")
+	assert.True(t, certificates.IsIssuedBy(server.Certificate, ca.Certificate))
+	assert.True(t, certificates.IsIssuedBy(clientCert.Certificate, ca.Certificate))
+	assert.True(t, certificates.HasDNSNames(server.Certificate, componentdca.GetClusterAgentServerDNSNames(dda)))
+	assert.Equal(t, clusteragenttls.ClientCommonName, clientCert.Certificate.Subject.CommonName)
+	assert.True(t, status.ClusterAgentTLS.NotAfter.Time.Equal(server.Certificate.NotAfter))
+
+	obj, found := resourceManagers.Store().Get(kubernetes.APIServiceKind, "", componentdca.GetMetricsServerAPIServiceName())
+	require.True(t, found)
+	apiService := obj.(*apiregistrationv1.APIService)
+	assert.False(t, apiService.Spec.InsecureSkipTLSVerify)
+	assert.Equal(t, certificates.Bundle(ca.Certificate), apiService.Spec.CABundle)
+	bundleSecret := &corev1.Secret{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "foo-cluster-agent-ca-bundle"}, bundleSecret))
+	assert.Equal(t, certificates.Bundle(ca.Certificate), bundleSecret.Data[clusteragenttls.CACertKey])
+
+	// The certificates are kept until they need to be renewed, the cluster agent is verified once it serves them
+	prober.err = nil
+	prober.statusCode = http.StatusOK
+	status, _ = reconcile(issuance.Add(time.Hour), status)
+	assert.Equal(t, int64(1), status.ClusterAgentTLS.Generation)
+	assert.True(t, getKeyPair("foo-cluster-agent-tls").Certificate.Equal(server.Certificate))
+	assert.True(t, status.ClusterAgentTLS.ClusterAgentVerified)
+	assert.Equal(t, "https://foo-cluster-agent.foo.svc:5005/version", prober.urls[len(prober.urls)-1])
+	probes := len(prober.urls)
+
+	// The certificates are renewed by the same certificate authority
+	status, _ = reconcile(issuance.Add(61*time.Hour), status)
+	assert.Equal(t, int64(2), status.ClusterAgentTLS.Generation)
+	renewed := getKeyPair("foo-cluster-agent-tls")
+	assert.False(t, renewed.Certificate.Equal(server.Certificate))
+	assert.True(t, getKeyPair("foo-cluster-agent-ca").Certificate.Equal(ca.Certificate))
+	assert.True(t, status.ClusterAgentTLS.ClusterAgentVerified)
+	assert.Len(t, prober.urls, probes, "a verified cluster agent is not probed again")
+
+	// The renewed certificate authority is kept in the bundle
+	status, _ = reconcile(issuance.Add(241*time.Hour), status)
+	assert.Equal(t, int64(3), status.ClusterAgentTLS.Generation)
+	newCA := getKeyPair("foo-cluster-agent-ca")
+	assert.False(t, newCA.Certificate.Equal(ca.Certificate))
+	assert.True(t, certificates.IsIssuedBy(getKeyPair("foo-cluster-agent-tls").Certificate, newCA.Certificate))
+	secret := &corev1.Secret{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "foo-cluster-agent-client-tls"}, secret))
+	assert.Equal(t, certificates.Bundle(newCA.Certificate, ca.Certificate), secret.Data[clusteragenttls.CACertKey])
+
+	// The status is cleared when the TLS is disabled
+	dda.Spec.Global.ClusterAgentTLS.Enabled = apiutils.NewBoolPointer(false)
+	status, _ = reconcile(issuance.Add(242*time.Hour), status)
+	assert.Nil(t, status.ClusterAgentTLS)
+}
+
+func Test_mergeCABundle(t *testing.T) {
+	now := time.Now()
+	previousCA, err := certificates.NewCA("previous", 10*time.Hour, now.Add(-5*time.Hour))
+	require.NoError(t, err)
+	expiredCA, err := certificates.NewCA("expired", time.Hour, now.Add(-2*time.Hour))
+	require.NoError(t, err)
+	currentCA, err := certificates.NewCA("current", 10*time.Hour, now)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		previousBundle []byte
+		want           []byte
+	}{
+		{
+			name: "no previous bundle",
+			want: certificates.Bundle(currentCA.Certificate),
+		},
+		{
+			name:           "same certificate authority",
+			previousBundle: certificates.Bundle(currentCA.Certificate),
+			want:           certificates.Bundle(currentCA.Certificate),
+		},
+		{
+			name:           "renewed certificate authority",
+			previousBundle: certificates.Bundle(previousCA.Certificate, expiredCA.Certificate),
+			want:           certificates.Bundle(currentCA.Certificate, previousCA.Certificate),
+		},
+		{
+			name:           "invalid previous bundle",
+			previousBundle: []byte("invalid"),
+			want:           certificates.Bundle(currentCA.Certificate),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeCABundle(currentCA.CertificatePEM(), tt.previousBundle, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// fakeClusterAgentProber returns the same response to all the probes and records their URL and token
+type fakeClusterAgentProber struct {
+	statusCode int
+	err        error
+	urls       []string
+	tokens     []string
+}
+
+func (p *fakeClusterAgentProber) Probe(_ context.Context, _ *tls.Config, url, token string) (int, error) {
+	p.urls = append(p.urls, url)
+	p.tokens = append(p.tokens, token)
+	return p.statusCode, p.err
+}
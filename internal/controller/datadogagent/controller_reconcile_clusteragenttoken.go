@@ -0,0 +1,205 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/clusteragenttls"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/enabledefault"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/secrets"
+)
+
+// rotateClusterAgentToken moves the rotation of the generated Cluster Agent token forward.
+// A rotation introduces a new token accepted by the Cluster Agent along with the current one, then switches
+// the node Agents and the Cluster Checks Runners to it once all the Cluster Agent pods are restarted and the Cluster
+// Agent API serves a request authenticated with it, and finally
+// retires the former token once all the Agent pods use the new one.
+// The token accepted along with the current one is only stored in the token secret, the status holds its hash.
+// The steps are applied to the pods by the "enabledefault" feature from the status at the next reconcile.
+func (r *Reconciler) rotateClusterAgentToken(ctx context.Context, logger logr.Logger, instance *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, resourceManagers feature.ResourceManagers, now metav1.Time) error {
+	if newStatus.ClusterAgent == nil || newStatus.ClusterAgent.GeneratedToken == "" {
+		return nil
+	}
+	if newStatus.ClusterAgentToken == nil {
+		newStatus.ClusterAgentToken = &datadoghqv2alpha1.ClusterAgentTokenStatus{Generation: 1, LastRotationTime: &now}
+	}
+	status := newStatus.ClusterAgentToken
+	currentToken := newStatus.ClusterAgent.GeneratedToken
+
+	var additionalToken string
+	if status.Phase != "" {
+		var err error
+		if additionalToken, err = r.getAdditionalClusterAgentToken(ctx, instance, status.AdditionalTokenHash); err != nil {
+			return err
+		}
+	}
+
+	switch status.Phase {
+	case datadoghqv2alpha1.ClusterAgentTokenRotationPhaseClusterAgentRollout:
+		if additionalToken == "" {
+			// The secret wasn't updated with the new token, the Cluster Agent is restarted with another one
+			additionalToken = apiutils.GenerateRandomString(32)
+			status.AdditionalTokenHash = enabledefault.TokenHash(additionalToken)
+			logger.Info("New Cluster Agent token not found in the token secret, restarting the Cluster Agent with another one", "generation", status.Generation+1)
+			break
+		}
+		checksum, err := enabledefault.ClusterAgentTokenChecksum(currentToken, status.AdditionalTokenHash)
+		if err != nil {
+			return err
+		}
+		done, err := r.podsHaveTokenChecksum(ctx, instance, checksum, constants.DefaultClusterAgentResourceSuffix)
+		if err != nil {
+			return err
+		}
+		if !done || !r.clusterAgentAcceptsToken(ctx, logger, instance, newStatus, additionalToken) {
+			break
+		}
+		newStatus.ClusterAgent.GeneratedToken = additionalToken
+		additionalToken = currentToken
+		status.AdditionalTokenHash = enabledefault.TokenHash(currentToken)
+		status.Phase = datadoghqv2alpha1.ClusterAgentTokenRotationPhaseAgentRollout
+		status.Generation++
+		status.LastRotationTime = &now
+		logger.Info("Cluster Agent accepts the new token, restarting the Agents to use it", "generation", status.Generation)
+
+	case datadoghqv2alpha1.ClusterAgentTokenRotationPhaseAgentRollout:
+		if additionalToken == "" {
+			// The former token can't be accepted anymore, the Agents still using it are being restarted with the new one
+			status.AdditionalTokenHash = ""
+			status.Phase = ""
+			logger.Info("Former Cluster Agent token not found in the token secret, retiring it", "generation", status.Generation)
+			break
+		}
+		checksum, err := enabledefault.ClientTokenChecksum(currentToken)
+		if err != nil {
+			return err
+		}
+		done, err := r.podsHaveTokenChecksum(ctx, instance, checksum, constants.DefaultAgentResourceSuffix, constants.DefaultClusterChecksRunnerResourceSuffix)
+		if err != nil {
+			return err
+		}
+		if !done {
+			break
+		}
+		// The former token stays in the secret until the Cluster Agent stops referencing it at the next reconcile
+		status.AdditionalTokenHash = ""
+		status.Phase = ""
+		logger.Info("Agents use the new token, retiring the former Cluster Agent token", "generation", status.Generation)
+
+	default:
+		// A rotation in progress is completed even if the rotation is disabled meanwhile
+		if !enabledefault.IsTokenRotationEnabled(instance) || status.LastRotationTime == nil ||
+			now.Before(&metav1.Time{Time: status.LastRotationTime.Add(enabledefault.TokenRotationInterval(instance))}) {
+			return nil
+		}
+		if !enabledefault.SupportsAdditionalAuthToken(instance) {
+			logger.Info("Cluster Agent image doesn't accept an additional token, not rotating the Cluster Agent token", "minVersion", enabledefault.MinAdditionalAuthTokenVersion)
+			return nil
+		}
+		additionalToken = apiutils.GenerateRandomString(32)
+		status.AdditionalTokenHash = enabledefault.TokenHash(additionalToken)
+		status.Phase = datadoghqv2alpha1.ClusterAgentTokenRotationPhaseClusterAgentRollout
+		logger.Info("Rotating the Cluster Agent token, restarting the Cluster Agent to accept the new token", "generation", status.Generation+1)
+	}
+
+	if additionalToken == "" {
+		return nil
+	}
+	// The feature sets the token from the status of the previous reconcile, the token secret is updated for the new one
+	secretName := secrets.GetDefaultDCATokenSecretName(instance)
+	for key, value := range map[string]string{
+		common.DefaultTokenKey:           newStatus.ClusterAgent.GeneratedToken,
+		enabledefault.AdditionalTokenKey: additionalToken,
+	} {
+		if err := resourceManagers.SecretManager().AddSecret(instance.Namespace, secretName, key, value); err != nil {
+			return fmt.Errorf("error adding cluster agent token to store: %w", err)
+		}
+	}
+	return nil
+}
+
+// clusterAgentAcceptsToken returns true if the Cluster Agent serves a request authenticated with the token.
+// The checksum of the Cluster Agent pods only tells they were restarted with the token, a Cluster Agent ignoring
+// the additional token would reject all the Agents switching to it.
+func (r *Reconciler) clusterAgentAcceptsToken(ctx context.Context, logger logr.Logger, instance *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, token string) bool {
+	// The Cluster Agent serves its own certificate until it is verified to serve the issued one, like the Agents
+	// the operator doesn't verify it then
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: true}
+	if newStatus.ClusterAgentTLS != nil && newStatus.ClusterAgentTLS.ClusterAgentVerified {
+		var err error
+		if tlsConfig, err = r.clusterAgentTLSConfig(ctx, instance); err != nil {
+			logger.Info("Unable to check the Cluster Agent accepts the new token, the Agents keep the current one", "error", err.Error())
+			return false
+		}
+	}
+	statusCode, err := r.clusterAgentProber.Probe(ctx, tlsConfig, clusteragenttls.ClusterAgentURL(instance)+clusterAgentClusterIDPath, token)
+	if err != nil {
+		logger.Info("Unable to check the Cluster Agent accepts the new token, the Agents keep the current one", "error", err.Error())
+		return false
+	}
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		logger.Info("Cluster Agent doesn't accept the new token, the Agents keep the current one", "statusCode", statusCode)
+		return false
+	}
+	return true
+}
+
+// getAdditionalClusterAgentToken returns the token of the token secret accepted by the Cluster Agent along with the
+// current one, or an empty string if it doesn't match the hash of the status.
+func (r *Reconciler) getAdditionalClusterAgentToken(ctx context.Context, instance *datadoghqv2alpha1.DatadogAgent, tokenHash string) (string, error) {
+	secret, err := r.getSecret(ctx, instance.Namespace, secrets.GetDefaultDCATokenSecretName(instance))
+	if err != nil || secret == nil {
+		return "", err
+	}
+	token := string(secret.Data[enabledefault.AdditionalTokenKey])
+	if token == "" || enabledefault.TokenHash(token) != tokenHash {
+		return "", nil
+	}
+	return token, nil
+}
+
+// podsHaveTokenChecksum returns true once all the pods of the components have the token checksum annotation.
+// Pods still running with a former checksum, including terminating ones, may use or only accept a former token.
+func (r *Reconciler) podsHaveTokenChecksum(ctx context.Context, dda *datadoghqv2alpha1.DatadogAgent, checksum string, components ...string) (bool, error) {
+	annotationKey := object.GetChecksumAnnotationKey(string(feature.DefaultIDType))
+	for _, component := range components {
+		pods := &corev1.PodList{}
+		err := r.client.List(
+			ctx,
+			pods,
+			client.MatchingLabels{
+				apicommon.AgentDeploymentNameLabelKey:      dda.GetName(),
+				apicommon.AgentDeploymentComponentLabelKey: component,
+			},
+			client.InNamespace(dda.GetNamespace()),
+		)
+		if err != nil {
+			return false, err
+		}
+		for _, pod := range pods.Items {
+			if pod.Annotations[annotationKey] != checksum {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
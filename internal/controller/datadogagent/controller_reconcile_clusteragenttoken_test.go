@@ -0,0 +1,246 @@
+package datadogagent
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/enabledefault"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func Test_rotateClusterAgentToken(t *testing.T) {
+	sch := runtime.NewScheme()
+	_ = scheme.AddToScheme(sch)
+	_ = v2alpha1.AddToScheme(sch)
+	ctx := context.Background()
+	logger := logf.Log.WithName("Test_rotateClusterAgentToken")
+
+	dda := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "foo"},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Global: &v2alpha1.GlobalConfig{
+				ClusterAgentTokenRotation: &v2alpha1.ClusterAgentTokenRotationConfig{
+					Enabled:  apiutils.NewBoolPointer(true),
+					Interval: &metav1.Duration{Duration: 24 * time.Hour},
+				},
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(sch).Build()
+	prober := &fakeClusterAgentProber{statusCode: http.StatusForbidden}
+	r := &Reconciler{client: fakeClient, scheme: sch, clusterAgentProber: prober}
+
+	// setPodChecksum creates or updates a pod of a component with a token checksum annotation
+	setPodChecksum := func(component, checksum string) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      component,
+				Labels: map[string]string{
+					apicommon.AgentDeploymentNameLabelKey:      "foo",
+					apicommon.AgentDeploymentComponentLabelKey: component,
+				},
+				Annotations: map[string]string{object.GetChecksumAnnotationKey(string(feature.DefaultIDType)): checksum},
+			},
+		}
+		if err := fakeClient.Update(ctx, pod); err != nil {
+			require.NoError(t, fakeClient.Create(ctx, pod))
+		}
+	}
+	// rotate runs the step and applies the token secret of the store like the reconciler does
+	rotate := func(now time.Time, status *v2alpha1.DatadogAgentStatus) *v2alpha1.DatadogAgentStatus {
+		resourceManagers := feature.NewResourceManagers(store.NewStore(dda, &store.StoreOptions{Scheme: sch, Logger: logger}))
+		newStatus := status.DeepCopy()
+		require.NoError(t, r.rotateClusterAgentToken(ctx, logger, dda, newStatus, resourceManagers, metav1.NewTime(now)))
+		if obj, found := resourceManagers.Store().Get(kubernetes.SecretsKind, testNamespace, "foo-token"); found {
+			secret := obj.(*corev1.Secret).DeepCopy()
+			if err := fakeClient.Update(ctx, secret); err != nil {
+				require.NoError(t, fakeClient.Create(ctx, secret))
+			}
+		}
+		return newStatus
+	}
+	getTokens := func() (string, string) {
+		secret := &corev1.Secret{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "foo-token"}, secret))
+		return string(secret.Data[common.DefaultTokenKey]), string(secret.Data[enabledefault.AdditionalTokenKey])
+	}
+
+	initialChecksum, _ := enabledefault.ClientTokenChecksum("initial")
+	setPodChecksum(constants.DefaultClusterAgentResourceSuffix, initialChecksum)
+	setPodChecksum(constants.DefaultAgentResourceSuffix, initialChecksum)
+	setPodChecksum(constants.DefaultClusterChecksRunnerResourceSuffix, initialChecksum)
+
+	// The token generation starts when the token is generated
+	start := time.Now()
+	status := rotate(start, &v2alpha1.DatadogAgentStatus{ClusterAgent: &v2alpha1.DeploymentStatus{GeneratedToken: "initial"}})
+	require.NotNil(t, status.ClusterAgentToken)
+	assert.Equal(t, int64(1), status.ClusterAgentToken.Generation)
+	assert.Empty(t, status.ClusterAgentToken.Phase)
+
+	// No rotation before the interval
+	status = rotate(start.Add(time.Hour), status)
+	assert.Empty(t, status.ClusterAgentToken.Phase)
+
+	// A new token is introduced in the Cluster Agent
+	status = rotate(start.Add(25*time.Hour), status)
+	assert.Equal(t, v2alpha1.ClusterAgentTokenRotationPhaseClusterAgentRollout, status.ClusterAgentToken.Phase)
+	assert.Equal(t, "initial", status.ClusterAgent.GeneratedToken)
+	token, pendingToken := getTokens()
+	assert.Equal(t, "initial", token)
+	require.NotEmpty(t, pendingToken)
+	assert.Equal(t, enabledefault.TokenHash(pendingToken), status.ClusterAgentToken.AdditionalTokenHash, "only the hash of the new token should be in the status")
+
+	// The Agents keep the current token until all the Cluster Agent pods accept the new one
+	status = rotate(start.Add(26*time.Hour), status)
+	assert.Equal(t, v2alpha1.ClusterAgentTokenRotationPhaseClusterAgentRollout, status.ClusterAgentToken.Phase)
+
+	rotationChecksum, _ := enabledefault.ClusterAgentTokenChecksum("initial", enabledefault.TokenHash(pendingToken))
+	setPodChecksum(constants.DefaultClusterAgentResourceSuffix, rotationChecksum)
+	status = rotate(start.Add(26*time.Hour), status)
+	assert.Equal(t, v2alpha1.ClusterAgentTokenRotationPhaseClusterAgentRollout, status.ClusterAgentToken.Phase, "the Cluster Agent API rejects the new token")
+	assert.Equal(t, "https://foo-cluster-agent.foo.svc:5005/api/v1/cluster/id", prober.urls[len(prober.urls)-1])
+	assert.Equal(t, pendingToken, prober.tokens[len(prober.tokens)-1])
+
+	prober.statusCode = http.StatusOK
+	switchTime := start.Add(27 * time.Hour)
+	status = rotate(switchTime, status)
+	assert.Equal(t, v2alpha1.ClusterAgentTokenRotationPhaseAgentRollout, status.ClusterAgentToken.Phase)
+	assert.Equal(t, pendingToken, status.ClusterAgent.GeneratedToken)
+	assert.Equal(t, enabledefault.TokenHash("initial"), status.ClusterAgentToken.AdditionalTokenHash)
+	token, previousToken := getTokens()
+	assert.Equal(t, pendingToken, token)
+	assert.Equal(t, "initial", previousToken)
+	assert.Equal(t, int64(2), status.ClusterAgentToken.Generation)
+	assert.Equal(t, switchTime.Unix(), status.ClusterAgentToken.LastRotationTime.Unix())
+
+	// The former token is retired once all the Agent pods use the new one
+	newChecksum, _ := enabledefault.ClientTokenChecksum(pendingToken)
+	setPodChecksum(constants.DefaultAgentResourceSuffix, newChecksum)
+	status = rotate(start.Add(28*time.Hour), status)
+	assert.Equal(t, v2alpha1.ClusterAgentTokenRotationPhaseAgentRollout, status.ClusterAgentToken.Phase, "a Cluster Checks Runner still uses the former token")
+
+	setPodChecksum(constants.DefaultClusterChecksRunnerResourceSuffix, newChecksum)
+	status = rotate(start.Add(29*time.Hour), status)
+	assert.Empty(t, status.ClusterAgentToken.Phase)
+	assert.Empty(t, status.ClusterAgentToken.AdditionalTokenHash)
+	assert.Equal(t, int64(2), status.ClusterAgentToken.Generation)
+
+	// The next rotation is scheduled from the last one
+	dda.Spec.Global.ClusterAgentTokenRotation.Enabled = apiutils.NewBoolPointer(false)
+	status = rotate(switchTime.Add(25*time.Hour), status)
+	assert.Empty(t, status.ClusterAgentToken.Phase, "the rotation is disabled")
+	dda.Spec.Global.ClusterAgentTokenRotation.Enabled = apiutils.NewBoolPointer(true)
+	status = rotate(switchTime.Add(23*time.Hour), status)
+	assert.Empty(t, status.ClusterAgentToken.Phase)
+	status = rotate(switchTime.Add(25*time.Hour), status)
+	assert.Equal(t, v2alpha1.ClusterAgentTokenRotationPhaseClusterAgentRollout, status.ClusterAgentToken.Phase)
+}
+
+func Test_rotateClusterAgentToken_unsupportedClusterAgent(t *testing.T) {
+	sch := runtime.NewScheme()
+	_ = scheme.AddToScheme(sch)
+	_ = v2alpha1.AddToScheme(sch)
+	logger := logf.Log.WithName("Test_rotateClusterAgentToken_unsupportedClusterAgent")
+
+	dda := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "foo"},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Global: &v2alpha1.GlobalConfig{
+				ClusterAgentTokenRotation: &v2alpha1.ClusterAgentTokenRotationConfig{Enabled: apiutils.NewBoolPointer(true)},
+			},
+			Override: map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+				v2alpha1.ClusterAgentComponentName: {Image: &v2alpha1.AgentImageConfig{Tag: "7.62.0"}},
+			},
+		},
+	}
+	r := &Reconciler{client: fake.NewClientBuilder().WithScheme(sch).Build(), scheme: sch, clusterAgentProber: &fakeClusterAgentProber{statusCode: http.StatusOK}}
+	resourceManagers := feature.NewResourceManagers(store.NewStore(dda, &store.StoreOptions{Scheme: sch, Logger: logger}))
+
+	lastRotation := metav1.NewTime(time.Now().Add(-31 * 24 * time.Hour))
+	status := &v2alpha1.DatadogAgentStatus{
+		ClusterAgent:      &v2alpha1.DeploymentStatus{GeneratedToken: "initial"},
+		ClusterAgentToken: &v2alpha1.ClusterAgentTokenStatus{Generation: 1, LastRotationTime: &lastRotation},
+	}
+	require.NoError(t, r.rotateClusterAgentToken(context.Background(), logger, dda, status, resourceManagers, metav1.Now()))
+	assert.Empty(t, status.ClusterAgentToken.Phase, "the cluster agent doesn't accept an additional token")
+	assert.Empty(t, status.ClusterAgentToken.AdditionalTokenHash)
+	_, found := resourceManagers.Store().Get(kubernetes.SecretsKind, testNamespace, "foo-token")
+	assert.False(t, found)
+}
+
+func Test_rotateClusterAgentToken_tokenNotInSecret(t *testing.T) {
+	sch := runtime.NewScheme()
+	_ = scheme.AddToScheme(sch)
+	_ = v2alpha1.AddToScheme(sch)
+	ctx := context.Background()
+	logger := logf.Log.WithName("Test_rotateClusterAgentToken_tokenNotInSecret")
+
+	dda := &v2alpha1.DatadogAgent{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "foo"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "foo-token"},
+		Data:       map[string][]byte{common.DefaultTokenKey: []byte("initial")},
+	}).Build()
+	r := &Reconciler{client: fakeClient, scheme: sch, clusterAgentProber: &fakeClusterAgentProber{statusCode: http.StatusOK}}
+
+	tests := []struct {
+		name      string
+		phase     v2alpha1.ClusterAgentTokenRotationPhase
+		wantPhase v2alpha1.ClusterAgentTokenRotationPhase
+		wantToken bool
+	}{
+		{
+			name:      "new token not in the secret, a new one is introduced",
+			phase:     v2alpha1.ClusterAgentTokenRotationPhaseClusterAgentRollout,
+			wantPhase: v2alpha1.ClusterAgentTokenRotationPhaseClusterAgentRollout,
+			wantToken: true,
+		},
+		{
+			name:      "former token not in the secret, it is retired",
+			phase:     v2alpha1.ClusterAgentTokenRotationPhaseAgentRollout,
+			wantPhase: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceManagers := feature.NewResourceManagers(store.NewStore(dda, &store.StoreOptions{Scheme: sch, Logger: logger}))
+			status := &v2alpha1.DatadogAgentStatus{
+				ClusterAgent:      &v2alpha1.DeploymentStatus{GeneratedToken: "initial"},
+				ClusterAgentToken: &v2alpha1.ClusterAgentTokenStatus{Generation: 1, Phase: tt.phase, AdditionalTokenHash: enabledefault.TokenHash("lost")},
+			}
+			require.NoError(t, r.rotateClusterAgentToken(ctx, logger, dda, status, resourceManagers, metav1.Now()))
+			assert.Equal(t, tt.wantPhase, status.ClusterAgentToken.Phase)
+
+			obj, found := resourceManagers.Store().Get(kubernetes.SecretsKind, testNamespace, "foo-token")
+			if !tt.wantToken {
+				assert.Empty(t, status.ClusterAgentToken.AdditionalTokenHash)
+				assert.False(t, found)
+				return
+			}
+			require.True(t, found)
+			additionalToken := string(obj.(*corev1.Secret).Data[enabledefault.AdditionalTokenKey])
+			assert.NotEqual(t, "lost", additionalToken)
+			assert.Equal(t, enabledefault.TokenHash(additionalToken), status.ClusterAgentToken.AdditionalTokenHash)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/pkg/instrumentation"
+)
+
+// applyInstrumentations merges the DatadogInstrumentations into the Single Step
+// Instrumentation targets of the DatadogAgent. The DatadogAgent must have its
+// defaults applied, it is modified in place. Targets generated from
+// DatadogInstrumentations are evaluated before the ones of the DatadogAgent spec.
+func (r *Reconciler) applyInstrumentations(ctx context.Context, logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time) error {
+	instrumentationList := datadoghqv1alpha1.DatadogInstrumentationList{}
+	if err := r.client.List(ctx, &instrumentationList); err != nil {
+		return err
+	}
+
+	instrumentations := instrumentation.SortInstrumentations(instrumentationList.Items)
+	newStatus.InstrumentationTargets = nil
+
+	if instrumentation.IsSingleStepInstrumentationEnabled(dda) {
+		ssi := dda.Spec.Features.APM.SingleStepInstrumentation
+		targets := instrumentation.BuildTargets(logger, instrumentations, ssi.Targets, now)
+		if len(targets) > 0 {
+			ssi.Targets = append(targets, ssi.Targets...)
+			for _, target := range targets {
+				newStatus.InstrumentationTargets = append(newStatus.InstrumentationTargets, target.Name)
+			}
+		}
+	} else {
+		instrumentation.RejectAll(instrumentations, instrumentation.SSIDisabledConditionReason, "APM Single Step Instrumentation is not enabled in the DatadogAgent", now)
+	}
+
+	// Only the DatadogInstrumentations whose status changed are updated
+	previousStatus := make(map[types.NamespacedName]datadoghqv1alpha1.DatadogInstrumentationStatus, len(instrumentationList.Items))
+	for _, instr := range instrumentationList.Items {
+		previousStatus[client.ObjectKeyFromObject(&instr)] = instr.Status
+	}
+	for i := range instrumentations {
+		if !apiequality.Semantic.DeepEqual(previousStatus[client.ObjectKeyFromObject(&instrumentations[i])], instrumentations[i].Status) {
+			r.updateInstrumentationStatus(ctx, logger, &instrumentations[i])
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) updateInstrumentationStatus(ctx context.Context, logger logr.Logger, instr *datadoghqv1alpha1.DatadogInstrumentation) {
+	if err := r.client.Status().Update(ctx, instr); err != nil {
+		if apierrors.IsConflict(err) {
+			logger.V(1).Info("unable to update DatadogInstrumentation status due to update conflict", "datadoginstrumentation", instrumentation.TargetName(instr))
+			return
+		}
+		logger.Error(err, "unable to update DatadogInstrumentation status", "datadoginstrumentation", instrumentation.TargetName(instr))
+	}
+}
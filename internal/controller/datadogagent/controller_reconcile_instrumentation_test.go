@@ -0,0 +1,143 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/pkg/instrumentation"
+)
+
+func Test_applyInstrumentations(t *testing.T) {
+	sch := runtime.NewScheme()
+	_ = scheme.AddToScheme(sch)
+	_ = v1alpha1.AddToScheme(sch)
+	ctx := context.Background()
+	now := metav1.NewTime(time.Now())
+
+	newInstrumentation := func(name string) *v1alpha1.DatadogInstrumentation {
+		return &v1alpha1.DatadogInstrumentation{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      name,
+			},
+			Spec: v1alpha1.DatadogInstrumentationSpec{
+				PodSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+				DDTraceVersions: map[string]string{"python": "3"},
+			},
+		}
+	}
+
+	newDDA := func(ssiEnabled bool) *v2alpha1.DatadogAgent {
+		return &v2alpha1.DatadogAgent{
+			Spec: v2alpha1.DatadogAgentSpec{
+				Features: &v2alpha1.DatadogFeatures{
+					APM: &v2alpha1.APMFeatureConfig{
+						Enabled: apiutils.NewBoolPointer(true),
+						SingleStepInstrumentation: &v2alpha1.SingleStepInstrumentation{
+							Enabled: apiutils.NewBoolPointer(ssiEnabled),
+							Targets: []v2alpha1.SSITarget{{
+								Name:              "from-spec",
+								NamespaceSelector: &v2alpha1.NamespaceSelector{MatchNames: []string{"other"}},
+							}},
+						},
+					},
+					AdmissionController: &v2alpha1.AdmissionControllerFeatureConfig{
+						Enabled: apiutils.NewBoolPointer(true),
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name               string
+		ssiEnabled         bool
+		wantTargets        []string
+		wantStatusTargets  []string
+		wantAcceptedReason string
+	}{
+		{
+			name:               "SSI enabled, instrumentation targets are evaluated first",
+			ssiEnabled:         true,
+			wantTargets:        []string{testNamespace + "/a", testNamespace + "/b", "from-spec"},
+			wantStatusTargets:  []string{testNamespace + "/a", testNamespace + "/b"},
+			wantAcceptedReason: instrumentation.AcceptedConditionReason,
+		},
+		{
+			name:               "SSI disabled, instrumentations are rejected",
+			ssiEnabled:         false,
+			wantTargets:        []string{"from-spec"},
+			wantAcceptedReason: instrumentation.SSIDisabledConditionReason,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(sch).
+				WithStatusSubresource(&v1alpha1.DatadogInstrumentation{}).
+				WithObjects(newInstrumentation("a"), newInstrumentation("b")).
+				Build()
+			r := &Reconciler{
+				client: fakeClient,
+				log:    logf.Log.WithName(t.Name()),
+				options: ReconcilerOptions{
+					DatadogInstrumentationEnabled: true,
+				},
+			}
+
+			dda := newDDA(tt.ssiEnabled)
+			newStatus := &v2alpha1.DatadogAgentStatus{}
+			err := r.applyInstrumentations(ctx, r.log, dda, newStatus, now)
+			require.NoError(t, err)
+
+			var targets []string
+			for _, target := range dda.Spec.Features.APM.SingleStepInstrumentation.Targets {
+				targets = append(targets, target.Name)
+			}
+			assert.Equal(t, tt.wantTargets, targets)
+			assert.Equal(t, tt.wantStatusTargets, newStatus.InstrumentationTargets)
+
+			for _, name := range []string{"a", "b"} {
+				instr := &v1alpha1.DatadogInstrumentation{}
+				require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: name}, instr))
+				accepted := meta.FindStatusCondition(instr.Status.Conditions, instrumentation.AcceptedConditionType)
+				require.NotNil(t, accepted)
+				assert.Equal(t, tt.wantAcceptedReason, accepted.Reason)
+			}
+
+			// The status is only updated when it changes
+			resourceVersions := map[string]string{}
+			for _, name := range []string{"a", "b"} {
+				instr := &v1alpha1.DatadogInstrumentation{}
+				require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: name}, instr))
+				resourceVersions[name] = instr.ResourceVersion
+			}
+			require.NoError(t, r.applyInstrumentations(ctx, r.log, newDDA(tt.ssiEnabled), &v2alpha1.DatadogAgentStatus{}, metav1.NewTime(now.Add(time.Minute))))
+			for _, name := range []string{"a", "b"} {
+				instr := &v1alpha1.DatadogInstrumentation{}
+				require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: name}, instr))
+				assert.Equal(t, resourceVersions[name], instr.ResourceVersion, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/prometheusoperator"
+)
+
+// applyServiceMonitors translates the Prometheus Operator ServiceMonitors into
+// prometheusScrape endpoints checks configurations. The DatadogAgent must have
+// its defaults applied, it is modified in place.
+//
+// ServiceMonitor and Service changes trigger a reconcile (see SetupWithManager).
+// Bearer token Secrets of other namespaces are copied in a Secret of the
+// DatadogAgent namespace, as the Agent pods can only mount Secrets of their
+// own namespace. Changes of the copied Secrets are not watched, they are
+// propagated on the next reconcile.
+//
+// PodMonitors select pods by labels, which the Agent autodiscovery of the
+// OpenMetrics checks cannot express: they are not translated, and reported in
+// the logs.
+func (r *Reconciler) applyServiceMonitors(ctx context.Context, logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, resourceManagers feature.ResourceManagers) error {
+	features := dda.Spec.Features
+	if features == nil || features.PrometheusScrape == nil || !apiutils.BoolValue(features.PrometheusScrape.Enabled) {
+		return nil
+	}
+	promOperator := features.PrometheusScrape.PrometheusOperator
+	if promOperator == nil || !apiutils.BoolValue(promOperator.Enabled) {
+		return nil
+	}
+	if !r.platformInfo.IsResourceSupported(prometheusoperator.ServiceMonitorKind) {
+		logger.V(1).Info("ServiceMonitor resource is not supported by the cluster, skipping Prometheus Operator translation")
+		return nil
+	}
+	r.logIgnoredPodMonitors(ctx, logger)
+
+	listOptions := []client.ListOption{}
+	if promOperator.ServiceMonitorSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(promOperator.ServiceMonitorSelector)
+		if err != nil {
+			return err
+		}
+		listOptions = append(listOptions, client.MatchingLabelsSelector{Selector: selector})
+	}
+
+	serviceMonitorList := &unstructured.UnstructuredList{}
+	serviceMonitorList.SetGroupVersionKind(prometheusoperator.ServiceMonitorListGVK)
+	if err := r.client.List(ctx, serviceMonitorList, listOptions...); err != nil {
+		return err
+	}
+	if len(serviceMonitorList.Items) == 0 {
+		return nil
+	}
+
+	// Services are only listed in the namespaces selected by the ServiceMonitors
+	servicesByNamespace := map[string][]corev1.Service{}
+	listServices := func(namespace string) ([]corev1.Service, error) {
+		if services, found := servicesByNamespace[namespace]; found {
+			return services, nil
+		}
+		serviceList := &corev1.ServiceList{}
+		if err := r.client.List(ctx, serviceList, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		servicesByNamespace[namespace] = serviceList.Items
+		return serviceList.Items, nil
+	}
+
+	var configs []datadoghqv2alpha1.PrometheusScrapeConfig
+	for i := range serviceMonitorList.Items {
+		sm, err := prometheusoperator.ServiceMonitorFromUnstructured(&serviceMonitorList.Items[i])
+		if err != nil {
+			logger.Info("Unable to parse ServiceMonitor, skipping", "servicemonitor", client.ObjectKeyFromObject(&serviceMonitorList.Items[i]).String(), "error", err.Error())
+			continue
+		}
+		var candidates []corev1.Service
+		listed := map[string]bool{}
+		for _, namespace := range sm.ServiceNamespaces() {
+			if listed[namespace] {
+				continue
+			}
+			listed[namespace] = true
+			services, err := listServices(namespace)
+			if err != nil {
+				return err
+			}
+			candidates = append(candidates, services...)
+		}
+		services, err := prometheusoperator.SelectServices(sm, candidates)
+		if err != nil {
+			logger.Info("Invalid ServiceMonitor selector, skipping", "servicemonitor", client.ObjectKeyFromObject(&serviceMonitorList.Items[i]).String(), "error", err.Error())
+			continue
+		}
+		resolveToken := func(namespace string, ref *corev1.SecretKeySelector) *corev1.SecretKeySelector {
+			tokenRef, err := r.resolveBearerToken(ctx, dda, resourceManagers, namespace, ref)
+			if err != nil {
+				logger.Info("Unable to resolve the bearer token of the ServiceMonitor, the endpoint is scraped without token", "servicemonitor", sm.Namespace+"/"+sm.Name, "secret", namespace+"/"+ref.Name, "error", err.Error())
+			}
+			return tokenRef
+		}
+		configs = append(configs, prometheusoperator.BuildConfigs(sm, services, resolveToken)...)
+	}
+	features.PrometheusScrape.Configs = append(features.PrometheusScrape.Configs, configs...)
+
+	return nil
+}
+
+// resolveBearerToken returns the Secret key containing the bearer token
+// referenced by a ServiceMonitor of the given namespace. Secrets of other
+// namespaces than the DatadogAgent one are copied in the DatadogAgent
+// namespace, the copy is updated on each reconcile.
+func (r *Reconciler) resolveBearerToken(ctx context.Context, dda *datadoghqv2alpha1.DatadogAgent, resourceManagers feature.ResourceManagers, namespace string, ref *corev1.SecretKeySelector) (*corev1.SecretKeySelector, error) {
+	if namespace == dda.Namespace {
+		return ref.DeepCopy(), nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		return nil, err
+	}
+	token, found := secret.Data[ref.Key]
+	if !found {
+		return nil, fmt.Errorf("key %s not found", ref.Key)
+	}
+
+	secretName := getPrometheusOperatorTokensSecretName(dda)
+	key := prometheusoperator.BearerTokenKey(namespace, ref)
+	if err := resourceManagers.SecretManager().AddSecret(dda.Namespace, secretName, key, string(token)); err != nil {
+		return nil, err
+	}
+
+	return &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+		Key:                  key,
+	}, nil
+}
+
+// getPrometheusOperatorTokensSecretName returns the name of the Secret
+// containing the bearer tokens copied from other namespaces.
+func getPrometheusOperatorTokensSecretName(dda *datadoghqv2alpha1.DatadogAgent) string {
+	return fmt.Sprintf("%s-prometheus-operator-tokens", dda.Name)
+}
+
+// logIgnoredPodMonitors reports the PodMonitors, which are not translated into checks.
+func (r *Reconciler) logIgnoredPodMonitors(ctx context.Context, logger logr.Logger) {
+	if !r.platformInfo.IsResourceSupported(prometheusoperator.PodMonitorKind) {
+		return
+	}
+
+	podMonitorList := &metav1.PartialObjectMetadataList{}
+	podMonitorList.SetGroupVersionKind(prometheusoperator.PodMonitorListGVK)
+	if err := r.client.List(ctx, podMonitorList); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Info("Unable to list PodMonitors", "error", err.Error())
+		}
+		return
+	}
+	for i := range podMonitorList.Items {
+		logger.Info("PodMonitors cannot be translated into checks and are ignored, use a ServiceMonitor or the prometheusScrape annotations instead", "podmonitor", client.ObjectKeyFromObject(&podMonitorList.Items[i]).String())
+	}
+}
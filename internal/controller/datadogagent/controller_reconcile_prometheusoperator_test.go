@@ -0,0 +1,142 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/prometheusoperator"
+)
+
+func Test_applyServiceMonitors(t *testing.T) {
+	sch := runtime.NewScheme()
+	_ = scheme.AddToScheme(sch)
+	_ = v2alpha1.AddToScheme(sch)
+	sch.AddKnownTypeWithName(prometheusoperator.ServiceMonitorGVK, &unstructured.Unstructured{})
+	sch.AddKnownTypeWithName(prometheusoperator.ServiceMonitorListGVK, &unstructured.UnstructuredList{})
+	ctx := context.Background()
+	logger := logf.Log.WithName(t.Name())
+
+	serviceMonitor := func(namespace, secretName string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "monitoring.coreos.com/v1",
+			"kind":       prometheusoperator.ServiceMonitorKind,
+			"metadata":   map[string]interface{}{"name": "sm", "namespace": namespace},
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "foo"}},
+				"endpoints": []interface{}{
+					map[string]interface{}{
+						"port":              "metrics",
+						"bearerTokenSecret": map[string]interface{}{"name": secretName, "key": "token"},
+					},
+				},
+			},
+		}}
+	}
+	service := func(namespace string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "foo", Labels: map[string]string{"app": "foo"}},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "metrics", Port: 8080}}},
+		}
+	}
+	secret := func(namespace string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "scrape-token"},
+			Data:       map[string][]byte{"token": []byte("s3cr3t")},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		namespace       string
+		withSecret      bool
+		wantTokenRef    *corev1.SecretKeySelector
+		wantCopiedToken string
+	}{
+		{
+			name:         "token Secret in the DatadogAgent namespace is used as is",
+			namespace:    testNamespace,
+			withSecret:   true,
+			wantTokenRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "scrape-token"}, Key: "token"},
+		},
+		{
+			name:       "token Secret of the ServiceMonitor namespace is copied",
+			namespace:  "monitoring",
+			withSecret: true,
+			wantTokenRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "foo-prometheus-operator-tokens"},
+				Key:                  "monitoring_scrape-token_token",
+			},
+			wantCopiedToken: "s3cr3t",
+		},
+		{
+			name:      "missing token Secret is ignored",
+			namespace: "monitoring",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := []runtime.Object{serviceMonitor(tt.namespace, "scrape-token"), service(tt.namespace)}
+			if tt.withSecret {
+				objects = append(objects, secret(tt.namespace))
+			}
+			r := &Reconciler{
+				client: fake.NewClientBuilder().WithScheme(sch).WithRuntimeObjects(objects...).Build(),
+				log:    logger,
+				platformInfo: kubernetes.NewPlatformInfoFromVersionMaps(nil, map[string]string{
+					prometheusoperator.ServiceMonitorKind: "monitoring.coreos.com/v1",
+				}, nil),
+			}
+
+			dda := &v2alpha1.DatadogAgent{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "foo"},
+				Spec: v2alpha1.DatadogAgentSpec{
+					Features: &v2alpha1.DatadogFeatures{
+						PrometheusScrape: &v2alpha1.PrometheusScrapeFeatureConfig{
+							Enabled: apiutils.NewBoolPointer(true),
+							PrometheusOperator: &v2alpha1.PrometheusOperatorConfig{
+								Enabled: apiutils.NewBoolPointer(true),
+							},
+						},
+					},
+				},
+			}
+			depsStore := store.NewStore(dda, &store.StoreOptions{Scheme: sch, Logger: logger})
+			resourceManagers := feature.NewResourceManagers(depsStore)
+
+			require.NoError(t, r.applyServiceMonitors(ctx, logger, dda, resourceManagers))
+
+			configs := dda.Spec.Features.PrometheusScrape.Configs
+			require.Len(t, configs, 1)
+			assert.Equal(t, []v2alpha1.PrometheusScrapeService{{Name: "foo", Namespace: tt.namespace}}, configs[0].Autodiscovery.KubernetesEndpoints)
+			assert.Equal(t, tt.wantTokenRef, configs[0].BearerTokenSecret)
+
+			obj, found := depsStore.Get(kubernetes.SecretsKind, testNamespace, "foo-prometheus-operator-tokens")
+			if tt.wantCopiedToken == "" {
+				assert.False(t, found)
+				return
+			}
+			require.True(t, found)
+			assert.Equal(t, tt.wantCopiedToken, string(obj.(*corev1.Secret).Data[tt.wantTokenRef.Key]))
+		})
+	}
+}
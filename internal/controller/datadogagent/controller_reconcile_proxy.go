@@ -0,0 +1,85 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+const proxyCredentialsNotEncodedEventReason = "ProxyCredentialsNotEncoded"
+
+// checkProxyCredentials reports the proxy credentials that are not URL-encoded. The credentials are
+// inserted as is in the proxy URLs by the Agents environment variables expansion, a reserved character
+// such as `@` or `/` breaks the URLs. The Secrets are only read, the check doesn't block the reconcile.
+func (r *Reconciler) checkProxyCredentials(ctx context.Context, logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent) {
+	if dda.Spec.Global == nil || dda.Spec.Global.Proxy == nil {
+		return
+	}
+	proxy := dda.Spec.Global.Proxy
+
+	for _, credential := range []struct {
+		name     string
+		secret   *datadoghqv2alpha1.SecretConfig
+		password bool
+	}{
+		{name: "usernameSecret", secret: proxy.UsernameSecret},
+		{name: "passwordSecret", secret: proxy.PasswordSecret, password: true},
+	} {
+		if credential.secret == nil {
+			continue
+		}
+		secret := &corev1.Secret{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: dda.Namespace, Name: credential.secret.SecretName}, secret); err != nil {
+			logger.V(1).Info("Unable to check the proxy credentials", "secret", credential.secret.SecretName, "error", err.Error())
+			continue
+		}
+		value, found := secret.Data[credential.secret.KeyName]
+		if !found || isURLEncodedUserInfo(string(value), credential.password) {
+			continue
+		}
+		logger.Info("The proxy credentials are not URL-encoded, the proxy URLs are invalid", "credentials", credential.name, "secret", credential.secret.SecretName)
+		r.recorder.Eventf(dda, corev1.EventTypeWarning, proxyCredentialsNotEncodedEventReason, "The proxy %s %s/%s is not URL-encoded, the proxy URLs are invalid", credential.name, credential.secret.SecretName, credential.secret.KeyName)
+	}
+}
+
+// isURLEncodedUserInfo returns true if the value can be used as is as the username, or the password,
+// of the user info of a URL (RFC 3986 section 3.2.1).
+func isURLEncodedUserInfo(value string, password bool) bool {
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '%':
+			if i+2 >= len(value) || !isHex(value[i+1]) || !isHex(value[i+2]) {
+				return false
+			}
+			i += 2
+		case c == ':':
+			// the first colon separates the username from the password
+			if !password {
+				return false
+			}
+		default:
+			// unreserved and sub-delims characters
+			switch c {
+			case '-', '.', '_', '~', '!', '$', '&', '\'', '(', ')', '*', '+', ',', ';', '=':
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
@@ -0,0 +1,84 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+func Test_checkProxyCredentials(t *testing.T) {
+	tests := []struct {
+		name       string
+		username   string
+		password   string
+		wantEvents int
+	}{
+		{
+			name:     "URL-encoded credentials",
+			username: "j%40doe",
+			password: "p%40ss:word",
+		},
+		{
+			name:       "password not URL-encoded",
+			username:   "jdoe",
+			password:   "p@ss/word",
+			wantEvents: 1,
+		},
+		{
+			name:       "username with a colon",
+			username:   "j:doe",
+			password:   "password",
+			wantEvents: 1,
+		},
+		{
+			name:       "invalid escape",
+			username:   "jdoe%4",
+			password:   "100%",
+			wantEvents: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "proxy"},
+				Data: map[string][]byte{
+					"username": []byte(tt.username),
+					"password": []byte(tt.password),
+				},
+			}
+			recorder := record.NewFakeRecorder(2)
+			r := &Reconciler{
+				client:   fake.NewClientBuilder().WithObjects(secret).Build(),
+				recorder: recorder,
+			}
+			dda := &v2alpha1.DatadogAgent{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "foo"},
+				Spec: v2alpha1.DatadogAgentSpec{
+					Global: &v2alpha1.GlobalConfig{
+						Proxy: &v2alpha1.ProxyConfig{
+							UsernameSecret: &v2alpha1.SecretConfig{SecretName: "proxy", KeyName: "username"},
+							PasswordSecret: &v2alpha1.SecretConfig{SecretName: "proxy", KeyName: "password"},
+						},
+					},
+				},
+			}
+
+			r.checkProxyCredentials(context.Background(), logf.Log, dda)
+
+			assert.Len(t, recorder.Events, tt.wantEvents)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/remoteconfig"
+)
+
+const (
+	remoteConfigApprovedEventReason = "RemoteConfigApproved"
+	remoteConfigInvalidEventReason  = "RemoteConfigInvalid"
+)
+
+// approveRemoteConfigProposal applies the pending Remote Configuration proposal once the
+// DatadogAgent approval annotation matches its config ID. The proposal changes are applied on top
+// of the applied configuration, which is also set in the DatadogAgent status so that the features are built with it.
+func (r *Reconciler) approveRemoteConfigProposal(logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time) {
+	proposal := newStatus.RemoteConfigProposal
+	if proposal == nil {
+		return
+	}
+	approvedID, found := dda.Annotations[constants.RemoteConfigApprovedIDAnnotationKey]
+	if !found || approvedID != proposal.ConfigID {
+		return
+	}
+
+	approved, err := remoteconfig.ApplyChanges(newStatus.RemoteConfigConfiguration, proposal.Changes)
+	if err != nil {
+		logger.Info("Unable to apply the approved Remote Configuration proposal", "configID", proposal.ConfigID, "error", err.Error())
+		r.recorder.Eventf(dda, corev1.EventTypeWarning, remoteConfigInvalidEventReason, "Remote Configuration %q approved but not applied: %v", proposal.ConfigID, err)
+		return
+	}
+	changed := remoteconfig.ChangedFeatures(newStatus.RemoteConfigConfiguration, approved)
+	entry := datadoghqv2alpha1.RemoteConfigHistoryEntry{
+		ConfigID:     proposal.ConfigID,
+		AppliedAt:    now,
+		ApprovalMode: datadoghqv2alpha1.RemoteConfigApprovalModeManual,
+		Features:     changed,
+	}
+	newStatus.RemoteConfigConfiguration = approved
+	newStatus.RemoteConfigProposal = nil
+	newStatus.RemoteConfigHistory = remoteconfig.AppendHistory(dda, newStatus.RemoteConfigHistory, entry)
+	dda.Status.RemoteConfigConfiguration = newStatus.RemoteConfigConfiguration
+
+	logger.Info("Remote Configuration proposal approved", "configID", proposal.ConfigID)
+	remoteconfig.LogApplied(logger, dda, entry)
+	r.recorder.Eventf(dda, corev1.EventTypeNormal, remoteConfigApprovedEventReason, "Remote Configuration %q approved, it changes features: %s", proposal.ConfigID, remoteconfig.JoinFeatures(changed))
+}
+
+// applyRemoteConfigFeatures merges the features configured by Remote Configuration into the
+// DatadogAgent spec. The applied configuration is filtered by the current Remote Configuration policy first,
+// so that features removed from the allowed features stop being configured remotely.
+// A configuration that makes the spec invalid is not merged, the spec is then reconciled without it.
+func (r *Reconciler) applyRemoteConfigFeatures(logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus) {
+	if newStatus.RemoteConfigConfiguration == nil || newStatus.RemoteConfigConfiguration.Features == nil {
+		return
+	}
+
+	rc := newStatus.RemoteConfigConfiguration.DeepCopy()
+	if rejected := remoteconfig.FilterDisallowedFeatures(dda, rc); len(rejected) > 0 {
+		logger.Info("Removing the features no longer allowed from the Remote Configuration", "features", remoteconfig.JoinFeatures(rejected))
+		newStatus.RemoteConfigConfiguration = rc
+	}
+	dda.Status.RemoteConfigConfiguration = rc
+
+	if err := remoteconfig.ValidateConfiguration(dda, rc); err != nil {
+		logger.Info("Ignoring the Remote Configuration, it makes the DatadogAgent spec invalid", "error", err.Error())
+		r.recorder.Eventf(dda, corev1.EventTypeWarning, remoteConfigInvalidEventReason, "Remote Configuration ignored, it makes the DatadogAgent spec invalid: %v", err)
+		return
+	}
+	remoteconfig.MergeConfiguration(&dda.Spec, rc)
+}
@@ -0,0 +1,177 @@
+package datadogagent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+)
+
+func Test_approveRemoteConfigProposal(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+	proposed := &v2alpha1.RemoteConfigConfiguration{
+		Features: &v2alpha1.DatadogFeatures{
+			CWS: &v2alpha1.CWSFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		wantApproved bool
+	}{
+		{
+			name: "no approval annotation",
+		},
+		{
+			name:        "annotation approves another config",
+			annotations: map[string]string{constants.RemoteConfigApprovedIDAnnotationKey: "other"},
+		},
+		{
+			name:         "proposal approved",
+			annotations:  map[string]string{constants.RemoteConfigApprovedIDAnnotationKey: "id"},
+			wantApproved: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(1)
+			r := &Reconciler{recorder: recorder}
+			dda := &v2alpha1.DatadogAgent{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "foo", Annotations: tt.annotations},
+				Status: v2alpha1.DatadogAgentStatus{
+					RemoteConfigProposal: &v2alpha1.RemoteConfigProposal{
+						ConfigID: "id",
+						Changes:  []v2alpha1.RemoteConfigChange{{Path: "cws.enabled", Value: "true"}},
+					},
+				},
+			}
+			newStatus := dda.Status.DeepCopy()
+
+			r.approveRemoteConfigProposal(logf.Log, dda, newStatus, now)
+
+			if !tt.wantApproved {
+				assert.Equal(t, dda.Status, *newStatus)
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			assert.Nil(t, newStatus.RemoteConfigProposal)
+			assert.Equal(t, proposed, newStatus.RemoteConfigConfiguration)
+			assert.Equal(t, proposed, dda.Status.RemoteConfigConfiguration)
+			assert.Equal(t, []v2alpha1.RemoteConfigHistoryEntry{
+				{
+					ConfigID:     "id",
+					AppliedAt:    now,
+					ApprovalMode: v2alpha1.RemoteConfigApprovalModeManual,
+					Features:     []v2alpha1.RemoteConfigFeature{v2alpha1.RemoteConfigFeatureCWS},
+				},
+			}, newStatus.RemoteConfigHistory)
+			assert.Len(t, recorder.Events, 1)
+		})
+	}
+}
+
+func Test_applyRemoteConfigFeatures(t *testing.T) {
+	dda := &v2alpha1.DatadogAgent{
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				NPM: &apicommon.NPMFeatureConfig{Enabled: apiutils.NewBoolPointer(false)},
+				OrchestratorExplorer: &v2alpha1.OrchestratorExplorerFeatureConfig{
+					Enabled:         apiutils.NewBoolPointer(true),
+					CustomResources: []string{"datadoghq.com/v1alpha1/datadogmetrics"},
+				},
+				RemoteConfiguration: &v2alpha1.RemoteConfigurationFeatureConfig{
+					AllowedFeatures: []v2alpha1.RemoteConfigFeature{"npm", v2alpha1.RemoteConfigFeatureOrchestratorExplorer},
+				},
+			},
+		},
+		Status: v2alpha1.DatadogAgentStatus{
+			RemoteConfigConfiguration: &v2alpha1.RemoteConfigConfiguration{
+				Features: &v2alpha1.DatadogFeatures{
+					NPM: &apicommon.NPMFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+					OrchestratorExplorer: &v2alpha1.OrchestratorExplorerFeatureConfig{
+						CustomResources: []string{"cilium.io/v2/ciliumnetworkpolicies"},
+					},
+					RemoteConfiguration: &v2alpha1.RemoteConfigurationFeatureConfig{Enabled: apiutils.NewBoolPointer(false)},
+				},
+			},
+		},
+	}
+
+	r := &Reconciler{recorder: record.NewFakeRecorder(1)}
+	newStatus := dda.Status.DeepCopy()
+	r.applyRemoteConfigFeatures(logf.Log, dda, newStatus)
+
+	assert.True(t, *dda.Spec.Features.NPM.Enabled)
+	assert.Equal(t, []string{"datadoghq.com/v1alpha1/datadogmetrics", "cilium.io/v2/ciliumnetworkpolicies"}, dda.Spec.Features.OrchestratorExplorer.CustomResources)
+	assert.Nil(t, dda.Spec.Features.RemoteConfiguration.Enabled)
+	assert.NotNil(t, dda.Status.RemoteConfigConfiguration.Features.OrchestratorExplorer.CustomResources)
+	assert.Nil(t, newStatus.RemoteConfigConfiguration.Features.RemoteConfiguration)
+}
+
+func Test_applyRemoteConfigFeatures_allowedFeaturesRemoved(t *testing.T) {
+	dda := &v2alpha1.DatadogAgent{
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				NPM: &apicommon.NPMFeatureConfig{Enabled: apiutils.NewBoolPointer(false)},
+				CWS: &v2alpha1.CWSFeatureConfig{Enabled: apiutils.NewBoolPointer(false)},
+				RemoteConfiguration: &v2alpha1.RemoteConfigurationFeatureConfig{
+					AllowedFeatures: []v2alpha1.RemoteConfigFeature{v2alpha1.RemoteConfigFeatureCWS},
+				},
+			},
+		},
+		Status: v2alpha1.DatadogAgentStatus{
+			RemoteConfigConfiguration: &v2alpha1.RemoteConfigConfiguration{
+				Features: &v2alpha1.DatadogFeatures{
+					NPM: &apicommon.NPMFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+					CWS: &v2alpha1.CWSFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+				},
+			},
+		},
+	}
+
+	r := &Reconciler{recorder: record.NewFakeRecorder(1)}
+	newStatus := dda.Status.DeepCopy()
+	r.applyRemoteConfigFeatures(logf.Log, dda, newStatus)
+
+	assert.False(t, *dda.Spec.Features.NPM.Enabled, "NPM is no longer allowed")
+	assert.True(t, *dda.Spec.Features.CWS.Enabled)
+	assert.Nil(t, newStatus.RemoteConfigConfiguration.Features.NPM)
+	assert.NotNil(t, newStatus.RemoteConfigConfiguration.Features.CWS)
+}
+
+func Test_applyRemoteConfigFeatures_invalidSpec(t *testing.T) {
+	dda := &v2alpha1.DatadogAgent{
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				EventCollection: &v2alpha1.EventCollectionFeatureConfig{
+					Filter: &v2alpha1.EventFilter{Namespace: apiutils.NewStringPointer("foo"), ExcludedNamespaces: []string{"bar"}},
+				},
+				RemoteConfiguration: &v2alpha1.RemoteConfigurationFeatureConfig{
+					AllowedFeatures: []v2alpha1.RemoteConfigFeature{"eventCollection"},
+				},
+			},
+		},
+		Status: v2alpha1.DatadogAgentStatus{
+			RemoteConfigConfiguration: &v2alpha1.RemoteConfigConfiguration{
+				Features: &v2alpha1.DatadogFeatures{
+					EventCollection: &v2alpha1.EventCollectionFeatureConfig{CollectKubernetesEvents: apiutils.NewBoolPointer(true)},
+				},
+			},
+		},
+	}
+	recorder := record.NewFakeRecorder(1)
+	r := &Reconciler{recorder: recorder}
+	r.applyRemoteConfigFeatures(logf.Log, dda, dda.Status.DeepCopy())
+
+	assert.Nil(t, dda.Spec.Features.EventCollection.CollectKubernetesEvents)
+	assert.Len(t, recorder.Events, 1)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/DataDog/datadog-operator/pkg/controller/utils"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 	"github.com/DataDog/datadog-operator/pkg/secrets"
+	"github.com/DataDog/datadog-operator/pkg/tracing"
 )
 
 func (r *Reconciler) internalReconcileV2(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -47,6 +48,7 @@ func (r *Reconciler) internalReconcileV2(ctx context.Context, request reconcile.
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			metrics.CleanupMetricsByDatadogAgent(request.Namespace, request.Name)
 			return result, nil
 		}
 		// Error reading the object - requeue the request.
@@ -81,31 +83,34 @@ func (r *Reconciler) internalReconcileV2(ctx context.Context, request reconcile.
 		return result, err
 	}
 
-	// TODO check if IsValideDatadogAgent function is needed for v2
-	/*
-		if err = datadoghqv2alpha1.IsValidDatadogAgent(&instance.Spec); err != nil {
-			reqLogger.V(1).Info("Invalid spec", "error", err)
-			return r.updateStatusIfNeeded(reqLogger, instance, &instance.Status, result, err)
-		}
-	*/
+	instanceCopy := instance.DeepCopy()
+	newStatus := instance.Status.DeepCopy()
+	now := metav1.NewTime(time.Now())
+
+	// The features configured by Remote Configuration are validated and defaulted with the rest of the spec
+	r.approveRemoteConfigProposal(reqLogger, instanceCopy, newStatus, now)
+	r.applyRemoteConfigFeatures(reqLogger, instanceCopy, newStatus)
+
+	// Invalid configurations are not rolled out to the Agents
+	if err = datadoghqv2alpha1.IsValidDatadogAgent(&instanceCopy.Spec); err != nil {
+		reqLogger.V(1).Info("Invalid spec", "error", err)
+		return r.updateStatusIfNeededV2(ctx, reqLogger, instance, newStatus, result, err, now)
+	}
 
 	// Set default values for GlobalConfig and Features
-	instanceCopy := instance.DeepCopy()
 	defaults.DefaultDatadogAgent(instanceCopy)
-	return r.reconcileInstanceV2(ctx, reqLogger, instanceCopy)
+	return r.reconcileInstanceV2(ctx, reqLogger, instanceCopy, newStatus, now)
 }
 
-func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger, instance *datadoghqv2alpha1.DatadogAgent) (reconcile.Result, error) {
+func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger, instance *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, now metav1.Time) (reconcile.Result, error) {
 	var result reconcile.Result
-	newStatus := instance.Status.DeepCopy()
-	now := metav1.NewTime(time.Now())
-	features, requiredComponents := feature.BuildFeatures(instance, reconcilerOptionsToFeatureOptions(&r.options, logger))
-	// update list of enabled features for metrics forwarder
-	r.updateMetricsForwardersFeatures(instance, features)
 
-	// -----------------------
-	// Manage dependencies
-	// -----------------------
+	if r.options.DatadogInstrumentationEnabled {
+		if err := r.applyInstrumentations(ctx, logger, instance, newStatus, now); err != nil {
+			return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, err, now)
+		}
+	}
+
 	storeOptions := &store.StoreOptions{
 		SupportCilium: r.options.SupportCilium,
 		PlatformInfo:  r.platformInfo,
@@ -115,39 +120,73 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	depsStore := store.NewStore(instance, storeOptions)
 	resourceManagers := feature.NewResourceManagers(depsStore)
 
+	if err := r.applyServiceMonitors(ctx, logger, instance, resourceManagers); err != nil {
+		return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, err, now)
+	}
+	r.checkProxyCredentials(ctx, logger, instance)
+
+	// The certificates are issued before the features are configured with their generation,
+	// the pods are restarted in the same reconcile as the certificates are renewed.
+	caBundle, err := r.ensureClusterAgentCertificates(ctx, logger, instance, newStatus, resourceManagers, now)
+	if err != nil {
+		return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, err, now)
+	}
+	featuresInstance := *instance
+	featuresInstance.Status.ClusterAgentTLS = newStatus.ClusterAgentTLS
+
+	features, requiredComponents := feature.BuildFeatures(&featuresInstance, reconcilerOptionsToFeatureOptions(&r.options, logger))
+	// update list of enabled features for metrics forwarder
+	r.updateMetricsForwardersFeatures(instance, features)
+	metrics.SetDatadogAgentFeatures(instance, featureIDs(features))
+
+	// -----------------------
+	// Manage dependencies
+	// -----------------------
 	var errs []error
 
 	// Set up dependencies required by enabled features
 	for _, feat := range features {
 		logger.V(1).Info("Dependency ManageDependencies", "featureID", feat.ID())
-		if featErr := feat.ManageDependencies(resourceManagers, requiredComponents); featErr != nil {
+		_, span := tracing.StartSpan(ctx, "feature.ManageDependencies", tracing.FeatureAttributeKey.String(string(feat.ID())))
+		featErr := feat.ManageDependencies(resourceManagers, requiredComponents)
+		tracing.EndSpan(span, featErr)
+		if featErr != nil {
 			errs = append(errs, featErr)
 		}
 	}
 	if len(errs) > 0 {
-		return r.updateStatusIfNeededV2(logger, instance, newStatus, result, errors.NewAggregate(errs), now)
+		return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, errors.NewAggregate(errs), now)
 	}
 
 	// Examine user configuration to override any external dependencies (e.g. RBACs)
 	errs = override.Dependencies(logger, resourceManagers, instance)
 	if len(errs) > 0 {
-		return r.updateStatusIfNeededV2(logger, instance, newStatus, result, errors.NewAggregate(errs), now)
+		return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, errors.NewAggregate(errs), now)
 	}
 
 	userSpecifiedClusterAgentToken := instance.Spec.Global.ClusterAgentToken != nil || instance.Spec.Global.ClusterAgentTokenSecret != nil
 	if !userSpecifiedClusterAgentToken {
 		ensureAutoGeneratedTokenInStatus(instance, newStatus, resourceManagers, logger)
+		if requiredComponents.ClusterAgent.IsEnabled() {
+			if err := r.rotateClusterAgentToken(ctx, logger, instance, newStatus, resourceManagers, now); err != nil {
+				return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, err, now)
+			}
+		}
+	} else {
+		newStatus.ClusterAgentToken = nil
 	}
 
+	setMetricsServerCABundle(resourceManagers, caBundle)
+
 	// -----------------------------
 	// Start reconcile Components
 	// -----------------------------
 
-	var err error
-
-	result, err = r.reconcileV2ClusterAgent(logger, requiredComponents, features, instance, resourceManagers, newStatus)
+	clusterAgentCtx, span := tracing.StartSpan(ctx, "component.ClusterAgent")
+	result, err = r.reconcileV2ClusterAgent(clusterAgentCtx, logger, requiredComponents, features, instance, resourceManagers, newStatus)
+	tracing.EndSpan(span, err)
 	if utils.ShouldReturn(result, err) {
-		return r.updateStatusIfNeededV2(logger, instance, newStatus, result, err, now)
+		return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, err, now)
 	} else {
 		// Update the status to make it the ClusterAgentReconcileConditionType successful
 		condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.ClusterAgentReconcileConditionType, metav1.ConditionTrue, "reconcile_succeed", "reconcile succeed", false)
@@ -165,7 +204,7 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 		// Get a node list for profiles and introspection
 		nodeList, e := r.getNodeList(ctx)
 		if e != nil {
-			return r.updateStatusIfNeededV2(logger, instance, newStatus, result, e, now)
+			return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, e, now)
 		}
 
 		if r.options.IntrospectionEnabled {
@@ -177,19 +216,36 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 			metrics.DAPEnabled.Set(metrics.TrueValue)
 			var profilesByNode map[string]types.NamespacedName
 			profiles, profilesByNode, e = r.profilesToApply(ctx, logger, nodeList, now, instance)
-			if err != nil {
-				return r.updateStatusIfNeededV2(logger, instance, newStatus, result, e, now)
+			if e != nil {
+				return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, e, now)
 			}
 
 			if err = r.handleProfiles(ctx, profilesByNode, instance.Namespace); err != nil {
-				return r.updateStatusIfNeededV2(logger, instance, newStatus, result, err, now)
+				return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, err, now)
 			}
 		}
 	}
 
 	for _, profile := range profiles {
+		// Profiles overriding features run their own feature pipeline for the node agent
+		profileFeatures, profileRequiredComponents := features, requiredComponents
+		if r.options.DatadogAgentProfileEnabled && agentprofile.HasFeaturesOverride(&profile) {
+			var profileErr error
+			profileFeatures, profileRequiredComponents, profileErr = r.buildProfileFeatures(logger, instance, newStatus, &profile, features, resourceManagers)
+			if profileErr != nil {
+				logger.Error(profileErr, "unable to build the profile features", "datadogagentprofile", profile.Name, "datadogagentprofile_namespace", profile.Namespace)
+				errs = append(errs, profileErr)
+				continue
+			}
+		}
+
 		for provider := range providerList {
-			result, err = r.reconcileV2Agent(logger, requiredComponents, features, instance, resourceManagers, newStatus, provider, providerList, &profile)
+			agentCtx, agentSpan := tracing.StartSpan(ctx, "component.NodeAgent",
+				tracing.ProviderAttributeKey.String(provider),
+				tracing.ProfileAttributeKey.String(profile.Name),
+			)
+			result, err = r.reconcileV2Agent(agentCtx, logger, profileRequiredComponents, profileFeatures, instance, resourceManagers, newStatus, provider, providerList, &profile)
+			tracing.EndSpan(agentSpan, err)
 			if utils.ShouldReturn(result, err) {
 				// If the agent reconcile failed, we should not continue with the other profiles
 				errs = append(errs, err)
@@ -198,15 +254,17 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	}
 
 	if utils.ShouldReturn(result, errors.NewAggregate(errs)) {
-		return r.updateStatusIfNeededV2(logger, instance, newStatus, result, errors.NewAggregate(errs), now)
+		return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, errors.NewAggregate(errs), now)
 	} else {
 		// Update the status to set AgentReconcileConditionType to successful
 		condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.AgentReconcileConditionType, metav1.ConditionTrue, "reconcile_succeed", "reconcile succeed", false)
 	}
 
-	result, err = r.reconcileV2ClusterChecksRunner(logger, requiredComponents, features, instance, resourceManagers, newStatus)
+	ccrCtx, span := tracing.StartSpan(ctx, "component.ClusterChecksRunner")
+	result, err = r.reconcileV2ClusterChecksRunner(ccrCtx, logger, requiredComponents, features, instance, resourceManagers, newStatus)
+	tracing.EndSpan(span, err)
 	if utils.ShouldReturn(result, err) {
-		return r.updateStatusIfNeededV2(logger, instance, newStatus, result, err, now)
+		return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, err, now)
 	} else {
 		// Update the status to set ClusterChecksRunnerReconcileConditionType to successful
 		condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.ClusterChecksRunnerReconcileConditionType, metav1.ConditionTrue, "reconcile_succeed", "reconcile succeed", false)
@@ -231,28 +289,34 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	// ------------------------------
 	// Create and update dependencies
 	// ------------------------------
-	errs = append(errs, depsStore.Apply(ctx, r.client)...)
+	applyCtx, span := tracing.StartSpan(ctx, "store.Apply")
+	applyErrs := depsStore.Apply(applyCtx, r.client)
+	tracing.EndSpan(span, errors.NewAggregate(applyErrs))
+	errs = append(errs, applyErrs...)
 	if len(errs) > 0 {
 		logger.V(2).Info("Dependencies apply error", "errs", errs)
-		return r.updateStatusIfNeededV2(logger, instance, newStatus, result, errors.NewAggregate(errs), now)
+		return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, errors.NewAggregate(errs), now)
 	}
 
 	// -----------------------------
 	// Cleanup unused dependencies
 	// -----------------------------
 	// Run it after the deployments reconcile
-	if errs = depsStore.Cleanup(ctx, r.client); len(errs) > 0 {
-		return r.updateStatusIfNeededV2(logger, instance, newStatus, result, errors.NewAggregate(errs), now)
+	cleanupCtx, span := tracing.StartSpan(ctx, "store.Cleanup")
+	errs = depsStore.Cleanup(cleanupCtx, r.client)
+	tracing.EndSpan(span, errors.NewAggregate(errs))
+	if len(errs) > 0 {
+		return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, errors.NewAggregate(errs), now)
 	}
 
 	// Always requeue
 	if !result.Requeue && result.RequeueAfter == 0 {
 		result.RequeueAfter = defaultRequeuePeriod
 	}
-	return r.updateStatusIfNeededV2(logger, instance, newStatus, result, err, now)
+	return r.updateStatusIfNeededV2(ctx, logger, instance, newStatus, result, err, now)
 }
 
-func (r *Reconciler) updateStatusIfNeededV2(logger logr.Logger, agentdeployment *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, result reconcile.Result, currentError error, now metav1.Time) (reconcile.Result, error) {
+func (r *Reconciler) updateStatusIfNeededV2(ctx context.Context, logger logr.Logger, agentdeployment *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, result reconcile.Result, currentError error, now metav1.Time) (reconcile.Result, error) {
 	if currentError == nil {
 		condition.UpdateDatadogAgentStatusConditions(newStatus, now, common.DatadogAgentReconcileErrorConditionType, metav1.ConditionFalse, "DatadogAgent_reconcile_ok", "DatadogAgent reconcile ok", false)
 	} else {
@@ -260,11 +324,12 @@ func (r *Reconciler) updateStatusIfNeededV2(logger logr.Logger, agentdeployment
 	}
 
 	r.setMetricsForwarderStatusV2(logger, agentdeployment, newStatus)
+	metrics.SetDatadogAgentStatus(agentdeployment, newStatus, currentError)
 
 	if !apiequality.Semantic.DeepEqual(&agentdeployment.Status, newStatus) {
 		updateAgentDeployment := agentdeployment.DeepCopy()
 		updateAgentDeployment.Status = *newStatus
-		if err := r.client.Status().Update(context.TODO(), updateAgentDeployment); err != nil {
+		if err := r.client.Status().Update(ctx, updateAgentDeployment); err != nil {
 			if apierrors.IsConflict(err) {
 				logger.V(1).Info("unable to update DatadogAgent status due to update conflict")
 				return reconcile.Result{RequeueAfter: time.Second}, nil
@@ -277,18 +342,6 @@ func (r *Reconciler) updateStatusIfNeededV2(logger logr.Logger, agentdeployment
 	return result, currentError
 }
 
-func (r *Reconciler) updateDAPStatus(logger logr.Logger, profile *datadoghqv1alpha1.DatadogAgentProfile) {
-	// update dap status for non-default profiles only
-	if !agentprofile.IsDefaultProfile(profile.Namespace, profile.Name) {
-		if err := r.client.Status().Update(context.TODO(), profile); err != nil {
-			if apierrors.IsConflict(err) {
-				logger.V(1).Info("unable to update DatadogAgentProfile status due to update conflict")
-			}
-			logger.Error(err, "unable to update DatadogAgentProfile status")
-		}
-	}
-}
-
 // setMetricsForwarderStatus sets the metrics forwarder status condition if enabled
 func (r *Reconciler) setMetricsForwarderStatusV2(logger logr.Logger, agentdeployment *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus) {
 	if r.options.OperatorMetricsEnabled {
@@ -340,13 +393,22 @@ func (r *Reconciler) updateMetricsForwardersFeatures(dda *datadoghqv2alpha1.Data
 	}
 }
 
+// featureIDs returns the IDs of the given features
+func featureIDs(features []feature.Feature) []string {
+	ids := make([]string, 0, len(features))
+	for _, feat := range features {
+		ids = append(ids, string(feat.ID()))
+	}
+	return ids
+}
+
 // profilesToApply gets a list of profiles and returns the ones that should be
 // applied in the cluster.
 // - If there are no profiles, it returns the default profile.
-// - If there are no conflicting profiles, it returns all the profiles plus the default one.
-// - If there are conflicting profiles, it returns a subset that does not
-// conflict plus the default one. When there are conflicting profiles, the
-// oldest one is the one that takes precedence. When two profiles share an
+// - Otherwise, it returns the profiles applied to at least one node plus the
+// default one. When several profiles match a node, the profile with the
+// highest priority is applied to it. When two profiles share the same
+// priority, the oldest one takes precedence. When two profiles share an
 // identical creation timestamp, the profile whose name is alphabetically first
 // is considered to have priority.
 // This function also returns a map that maps each node name to the profile that
@@ -361,11 +423,11 @@ func (r *Reconciler) profilesToApply(ctx context.Context, logger logr.Logger, no
 	var profileListToApply []datadoghqv1alpha1.DatadogAgentProfile
 	profileAppliedByNode := make(map[string]types.NamespacedName, len(nodeList))
 
-	sortedProfiles := agentprofile.SortProfiles(profilesList.Items)
+	sortedProfiles := agentprofile.SortProfilesByPriority(profilesList.Items)
 	for _, profile := range sortedProfiles {
 		maxUnavailable := agentprofile.GetMaxUnavailable(logger, dda, &profile, len(nodeList), &r.options.ExtendedDaemonsetOptions)
+		// The status of the profile is only updated by the DatadogAgentProfile controller
 		profileAppliedByNode, err = agentprofile.ApplyProfile(logger, &profile, nodeList, profileAppliedByNode, now, maxUnavailable)
-		r.updateDAPStatus(logger, &profile)
 		if err != nil {
 			// profile is invalid or conflicts
 			logger.Error(err, "profile cannot be applied", "datadogagentprofile", profile.Name, "datadogagentprofile_namespace", profile.Namespace)
@@ -380,6 +442,84 @@ func (r *Reconciler) profilesToApply(ctx context.Context, logger logr.Logger, no
 	return profileListToApply, profileAppliedByNode, nil
 }
 
+// buildProfileFeatures builds the features of the DatadogAgent with the
+// features overridden by the profile, and sets up the dependencies of the
+// features enabled or changed by the profile. The returned features and required
+// components are only meant to be used for the node agent of the profile: the
+// Cluster Agent and the Cluster Checks Runners are shared by all the profiles.
+// The dependencies of the other features of the DatadogAgent, like the Cluster
+// Agent token, are already set up and must not be overwritten.
+func (r *Reconciler) buildProfileFeatures(logger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, profile *datadoghqv1alpha1.DatadogAgentProfile, ddaFeatures []feature.Feature, resourceManagers feature.ResourceManagers) ([]feature.Feature, feature.RequiredComponents, error) {
+	profileDDA := agentprofile.DatadogAgentWithProfileFeatures(dda, profile)
+	if err := datadoghqv2alpha1.IsValidDatadogAgent(&profileDDA.Spec); err != nil {
+		return nil, feature.RequiredComponents{}, fmt.Errorf("invalid features in profile %s/%s: %w", profile.Namespace, profile.Name, err)
+	}
+	// The features set in the profile are not defaulted yet
+	defaults.DefaultDatadogAgent(profileDDA)
+
+	// On the first reconcile, the token generated for the DatadogAgent is only in the new status
+	if (profileDDA.Status.ClusterAgent == nil || profileDDA.Status.ClusterAgent.GeneratedToken == "") && newStatus.ClusterAgent != nil {
+		if profileDDA.Status.ClusterAgent == nil {
+			profileDDA.Status.ClusterAgent = &datadoghqv2alpha1.DeploymentStatus{}
+		}
+		profileDDA.Status.ClusterAgent.GeneratedToken = newStatus.ClusterAgent.GeneratedToken
+	}
+	// The certificates issued in this reconcile are only in the new status
+	profileDDA.Status.ClusterAgentTLS = newStatus.ClusterAgentTLS
+
+	features, requiredComponents := feature.BuildFeatures(profileDDA, reconcilerOptionsToFeatureOptions(&r.options, logger))
+
+	ddaFeatureIDs := make(map[feature.IDType]struct{}, len(ddaFeatures))
+	for _, feat := range ddaFeatures {
+		ddaFeatureIDs[feat.ID()] = struct{}{}
+	}
+
+	changedFeatureIDs := profileFeatureIDs(profile.Spec.Config.Features)
+
+	var errs []error
+	for _, feat := range features {
+		_, enabledInDDA := ddaFeatureIDs[feat.ID()]
+		_, changedByProfile := changedFeatureIDs[feat.ID()]
+		if enabledInDDA && !changedByProfile {
+			continue
+		}
+		logger.V(1).Info("Profile dependency ManageDependencies", "featureID", feat.ID(), "datadogagentprofile", profile.Name)
+		if featErr := feat.ManageDependencies(resourceManagers, requiredComponents); featErr != nil {
+			errs = append(errs, featErr)
+		}
+	}
+
+	return features, requiredComponents, errors.NewAggregate(errs)
+}
+
+// profileFeatureIDs returns the IDs of the features configured by a profile.
+func profileFeatureIDs(features *datadoghqv1alpha1.ProfileFeatures) map[feature.IDType]struct{} {
+	ids := map[feature.IDType]struct{}{}
+	if features == nil {
+		return ids
+	}
+	configured := map[feature.IDType]bool{
+		feature.LogCollectionIDType:    features.LogCollection != nil,
+		feature.LiveProcessIDType:      features.LiveProcessCollection != nil,
+		feature.LiveContainerIDType:    features.LiveContainerCollection != nil,
+		feature.ProcessDiscoveryIDType: features.ProcessDiscovery != nil,
+		feature.OOMKillIDType:          features.OOMKill != nil,
+		feature.TCPQueueLengthIDType:   features.TCPQueueLength != nil,
+		feature.EBPFCheckIDType:        features.EBPFCheck != nil,
+		feature.NPMIDType:              features.NPM != nil,
+		feature.USMIDType:              features.USM != nil,
+		feature.DogstatsdIDType:        features.Dogstatsd != nil,
+		feature.ServiceDiscoveryType:   features.ServiceDiscovery != nil,
+		feature.GPUIDType:              features.GPU != nil,
+	}
+	for id, isConfigured := range configured {
+		if isConfigured {
+			ids[id] = struct{}{}
+		}
+	}
+	return ids
+}
+
 func (r *Reconciler) getNodeList(ctx context.Context) ([]corev1.Node, error) {
 	nodeList := corev1.NodeList{}
 	err := r.client.List(ctx, &nodeList)
@@ -41,7 +41,7 @@ type updateDepStatusComponentFunc func(deployment *appsv1.Deployment, newStatus
 type updateDSStatusComponentFunc func(daemonsetName string, daemonset *appsv1.DaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string)
 type updateEDSStatusComponentFunc func(eds *edsv1alpha1.ExtendedDaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string)
 
-func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, deployment *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateStatusFunc updateDepStatusComponentFunc) (reconcile.Result, error) {
+func (r *Reconciler) createOrUpdateDeployment(ctx context.Context, parentLogger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, deployment *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateStatusFunc updateDepStatusComponentFunc) (reconcile.Result, error) {
 	logger := parentLogger.WithValues("deployment.Namespace", deployment.Namespace, "deployment.Name", deployment.Name)
 
 	var result reconcile.Result
@@ -67,7 +67,7 @@ func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *dat
 
 	currentDeployment := &appsv1.Deployment{}
 	alreadyExists := true
-	err = r.client.Get(context.TODO(), nsName, currentDeployment)
+	err = r.client.Get(ctx, nsName, currentDeployment)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("deployment is not found")
@@ -102,7 +102,7 @@ func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *dat
 		updateDeployment.Labels = mergeAnnotationsLabels(logger, currentDeployment.GetLabels(), deployment.GetLabels(), keepLabelsFilter)
 
 		now := metav1.NewTime(time.Now())
-		err = kubernetes.UpdateFromObject(context.TODO(), r.client, updateDeployment, currentDeployment.ObjectMeta)
+		err = kubernetes.UpdateFromObject(ctx, r.client, updateDeployment, currentDeployment.ObjectMeta)
 		if err != nil {
 			updateStatusFunc(nil, newStatus, now, metav1.ConditionFalse, updateSucceeded, "Unable to update Deployment")
 			return reconcile.Result{}, err
@@ -113,7 +113,7 @@ func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *dat
 	} else {
 		now := metav1.NewTime(time.Now())
 
-		err = r.client.Create(context.TODO(), deployment)
+		err = r.client.Create(ctx, deployment)
 		if err != nil {
 			updateStatusFunc(nil, newStatus, now, metav1.ConditionFalse, createSucceeded, "Unable to create Deployment")
 			return reconcile.Result{}, err
@@ -128,7 +128,7 @@ func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *dat
 	return result, err
 }
 
-func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, daemonset *appsv1.DaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateStatusFunc updateDSStatusComponentFunc, profile *v1alpha1.DatadogAgentProfile) (reconcile.Result, error) {
+func (r *Reconciler) createOrUpdateDaemonset(ctx context.Context, parentLogger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, daemonset *appsv1.DaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateStatusFunc updateDSStatusComponentFunc, profile *v1alpha1.DatadogAgentProfile) (reconcile.Result, error) {
 	logger := parentLogger.WithValues("daemonset.Namespace", daemonset.Namespace, "daemonset.Name", daemonset.Name)
 
 	var result reconcile.Result
@@ -147,7 +147,7 @@ func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, dda *data
 
 	currentDaemonset := &appsv1.DaemonSet{}
 	alreadyExists := true
-	err = r.client.Get(context.TODO(), nsName, currentDaemonset)
+	err = r.client.Get(ctx, nsName, currentDaemonset)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("daemonset is not found")
@@ -160,24 +160,6 @@ func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, dda *data
 
 	if alreadyExists {
 		now := metav1.Now()
-		if agentprofile.CreateStrategyEnabled() {
-			if profile.Status.CreateStrategy != nil {
-				profile.Status.CreateStrategy.PodsReady = currentDaemonset.Status.NumberReady
-			}
-			if shouldCheckCreateStrategyStatus(profile) {
-				newStatus := v1alpha1.WaitingStatus
-
-				if int(profile.Status.CreateStrategy.NodesLabeled-currentDaemonset.Status.NumberReady) < int(profile.Status.CreateStrategy.MaxUnavailable) {
-					newStatus = v1alpha1.InProgressStatus
-				}
-
-				if profile.Status.CreateStrategy.Status != newStatus {
-					profile.Status.CreateStrategy.LastTransition = &now
-				}
-				profile.Status.CreateStrategy.Status = newStatus
-			}
-			r.updateDAPStatus(logger, profile)
-		}
 
 		// When overriding node labels in <1.7.0, the hash could be updated
 		// without updating the pod template spec in <1.7.0 since pod template
@@ -244,7 +226,7 @@ func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, dda *data
 
 		var updateProfileDS bool
 		ddaLastSpecUpdate := getDDALastUpdatedTime(dda.ManagedFields, dda.CreationTimestamp)
-		updateProfileDS, err = r.shouldUpdateProfileDaemonSet(profile, ddaLastSpecUpdate, now)
+		updateProfileDS, err = r.shouldUpdateProfileDaemonSet(ctx, profile, ddaLastSpecUpdate, now)
 		if err != nil {
 			return result, err
 		}
@@ -252,7 +234,7 @@ func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, dda *data
 		if updateProfileDS {
 			logger.Info("Updating Daemonset")
 
-			err = kubernetes.UpdateFromObject(context.TODO(), r.client, updateDaemonset, currentDaemonset.ObjectMeta)
+			err = kubernetes.UpdateFromObject(ctx, r.client, updateDaemonset, currentDaemonset.ObjectMeta)
 			if err != nil {
 				updateStatusFunc(updateDaemonset.Name, updateDaemonset, newStatus, now, metav1.ConditionFalse, updateSucceeded, "Unable to update Daemonset")
 				return reconcile.Result{}, err
@@ -271,7 +253,7 @@ func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, dda *data
 		now := metav1.Now()
 		logger.Info("Creating Daemonset")
 
-		err = r.client.Create(context.TODO(), daemonset)
+		err = r.client.Create(ctx, daemonset)
 		if err != nil {
 			updateStatusFunc(daemonset.Name, nil, newStatus, now, metav1.ConditionFalse, createSucceeded, "Unable to create Daemonset")
 			return reconcile.Result{}, err
@@ -284,7 +266,7 @@ func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, dda *data
 	return result, err
 }
 
-func (r *Reconciler) createOrUpdateExtendedDaemonset(parentLogger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, eds *edsv1alpha1.ExtendedDaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateStatusFunc updateEDSStatusComponentFunc) (reconcile.Result, error) {
+func (r *Reconciler) createOrUpdateExtendedDaemonset(ctx context.Context, parentLogger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, eds *edsv1alpha1.ExtendedDaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateStatusFunc updateEDSStatusComponentFunc) (reconcile.Result, error) {
 	logger := parentLogger.WithValues("ExtendedDaemonSet.Namespace", eds.Namespace, "ExtendedDaemonSet.Name", eds.Name)
 
 	var result reconcile.Result
@@ -310,7 +292,7 @@ func (r *Reconciler) createOrUpdateExtendedDaemonset(parentLogger logr.Logger, d
 
 	currentEDS := &edsv1alpha1.ExtendedDaemonSet{}
 	alreadyExists := true
-	err = r.client.Get(context.TODO(), nsName, currentEDS)
+	err = r.client.Get(ctx, nsName, currentEDS)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("ExtendedDaemonSet is not found")
@@ -349,7 +331,7 @@ func (r *Reconciler) createOrUpdateExtendedDaemonset(parentLogger logr.Logger, d
 		updateEDS.Labels = mergeAnnotationsLabels(logger, currentEDS.GetLabels(), eds.GetLabels(), keepLabelsFilter)
 
 		now := metav1.NewTime(time.Now())
-		err = kubernetes.UpdateFromObject(context.TODO(), r.client, updateEDS, currentEDS.ObjectMeta)
+		err = kubernetes.UpdateFromObject(ctx, r.client, updateEDS, currentEDS.ObjectMeta)
 		if err != nil {
 			updateStatusFunc(updateEDS, newStatus, now, metav1.ConditionFalse, updateSucceeded, "Unable to update ExtendedDaemonSet")
 			return reconcile.Result{}, err
@@ -360,7 +342,7 @@ func (r *Reconciler) createOrUpdateExtendedDaemonset(parentLogger logr.Logger, d
 	} else {
 		now := metav1.NewTime(time.Now())
 
-		err = r.client.Create(context.TODO(), eds)
+		err = r.client.Create(ctx, eds)
 		if err != nil {
 			updateStatusFunc(nil, newStatus, now, metav1.ConditionFalse, createSucceeded, "Unable to create ExtendedDaemonSet")
 			return reconcile.Result{}, err
@@ -403,27 +385,11 @@ func ensureSelectorInPodTemplateLabels(logger logr.Logger, selector *metav1.Labe
 	return labels
 }
 
-func shouldCheckCreateStrategyStatus(profile *v1alpha1.DatadogAgentProfile) bool {
-	if profile == nil {
-		return false
-	}
-
-	if profile.Name == "" || profile.Name == "default" {
-		return false
-	}
-
-	if profile.Status.CreateStrategy == nil {
-		return false
-	}
-
-	return profile.Status.CreateStrategy.Status != v1alpha1.CompletedStatus
-}
-
 // shouldUpdateProfileDaemonSet determines if we should update a daemonset
 // created from a profile based on the canary status, if one exists
 // * true causes the daemonset to be updated immediately
 // * false causes the reconcile to skip updating the daemonset
-func (r *Reconciler) shouldUpdateProfileDaemonSet(profile *v1alpha1.DatadogAgentProfile, ddaLastUpdateTime metav1.Time, now metav1.Time) (bool, error) {
+func (r *Reconciler) shouldUpdateProfileDaemonSet(ctx context.Context, profile *v1alpha1.DatadogAgentProfile, ddaLastUpdateTime metav1.Time, now metav1.Time) (bool, error) {
 	// eds needs to be enabled
 	if !r.options.ExtendedDaemonsetOptions.Enabled {
 		return true, nil
@@ -444,7 +410,7 @@ func (r *Reconciler) shouldUpdateProfileDaemonSet(profile *v1alpha1.DatadogAgent
 
 	// TODO: check that EDS is for a specific DDA
 	edsList := edsv1alpha1.ExtendedDaemonSetList{}
-	if err := r.client.List(context.TODO(), &edsList, client.MatchingLabels{
+	if err := r.client.List(ctx, &edsList, client.MatchingLabels{
 		apicommon.AgentDeploymentComponentLabelKey: constants.DefaultAgentResourceSuffix,
 		kubernetes.AppKubernetesManageByLabelKey:   "datadog-operator",
 	}); err != nil {
@@ -465,7 +431,7 @@ func (r *Reconciler) shouldUpdateProfileDaemonSet(profile *v1alpha1.DatadogAgent
 		}
 		// get ers associated with eds
 		ersList := edsv1alpha1.ExtendedDaemonSetReplicaSetList{}
-		if err := r.client.List(context.TODO(), &ersList, client.MatchingLabels{
+		if err := r.client.List(ctx, &ersList, client.MatchingLabels{
 			edsv1alpha1.ExtendedDaemonSetNameLabelKey: eds.Name,
 		}); err != nil {
 			return false, err
@@ -1,6 +1,7 @@
 package datadogagent
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -115,123 +116,6 @@ func Test_ensureSelectorInPodTemplateLabels(t *testing.T) {
 	}
 }
 
-func Test_shouldCheckCreateStrategyStatus(t *testing.T) {
-	tests := []struct {
-		name           string
-		profile        *v1alpha1.DatadogAgentProfile
-		CreateStrategy string
-		expected       bool
-	}{
-		{
-			name:           "nil profile",
-			profile:        nil,
-			CreateStrategy: "true",
-			expected:       false,
-		},
-		{
-			name:           "create strategy false",
-			profile:        nil,
-			CreateStrategy: "false",
-			expected:       false,
-		},
-		{
-			name:           "empty profile",
-			profile:        &v1alpha1.DatadogAgentProfile{},
-			CreateStrategy: "true",
-			expected:       false,
-		},
-		{
-			name: "default profile",
-			profile: &v1alpha1.DatadogAgentProfile{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "default",
-				},
-			},
-			CreateStrategy: "true",
-			expected:       false,
-		},
-		{
-			name: "empty profile status",
-			profile: &v1alpha1.DatadogAgentProfile{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "foo",
-				},
-				Status: v1alpha1.DatadogAgentProfileStatus{},
-			},
-			CreateStrategy: "true",
-			expected:       false,
-		},
-		{
-			name: "completed create strategy status",
-			profile: &v1alpha1.DatadogAgentProfile{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "foo",
-				},
-				Status: v1alpha1.DatadogAgentProfileStatus{
-					CreateStrategy: &v1alpha1.CreateStrategy{
-						Status: v1alpha1.CompletedStatus,
-					},
-				},
-			},
-			CreateStrategy: "true",
-			expected:       false,
-		},
-		{
-			name: "in progress create strategy status",
-			profile: &v1alpha1.DatadogAgentProfile{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "foo",
-				},
-				Status: v1alpha1.DatadogAgentProfileStatus{
-					CreateStrategy: &v1alpha1.CreateStrategy{
-						Status: v1alpha1.InProgressStatus,
-					},
-				},
-			},
-			CreateStrategy: "true",
-			expected:       true,
-		},
-		{
-			name: "waiting create strategy status",
-			profile: &v1alpha1.DatadogAgentProfile{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "foo",
-				},
-				Status: v1alpha1.DatadogAgentProfileStatus{
-					CreateStrategy: &v1alpha1.CreateStrategy{
-						Status: v1alpha1.WaitingStatus,
-					},
-				},
-			},
-			CreateStrategy: "true",
-			expected:       true,
-		},
-		{
-			name: "empty status in create strategy status",
-			profile: &v1alpha1.DatadogAgentProfile{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "foo",
-				},
-				Status: v1alpha1.DatadogAgentProfileStatus{
-					CreateStrategy: &v1alpha1.CreateStrategy{
-						Status: "",
-					},
-				},
-			},
-			CreateStrategy: "true",
-			expected:       true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Setenv(apicommon.CreateStrategyEnabled, tt.CreateStrategy)
-			actual := shouldCheckCreateStrategyStatus(tt.profile)
-			assert.Equal(t, tt.expected, actual)
-		})
-	}
-}
-
 func Test_shouldUpdateProfileDaemonSet(t *testing.T) {
 	sch := runtime.NewScheme()
 	_ = scheme.AddToScheme(sch)
@@ -727,7 +611,7 @@ func Test_shouldUpdateProfileDaemonSet(t *testing.T) {
 				options: tt.reconcilerOptions,
 			}
 
-			actual, err := r.shouldUpdateProfileDaemonSet(tt.profile, tt.ddaLastUpdateTime, tt.now)
+			actual, err := r.shouldUpdateProfileDaemonSet(context.Background(), tt.profile, tt.ddaLastUpdateTime, tt.now)
 			assert.Equal(t, tt.expectedShouldUpdate, actual)
 			assert.Equal(t, tt.errorMessage, err)
 		})
@@ -9,6 +9,16 @@ import (
 	"github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	agentcommon "github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/defaults"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	fakefeature "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/secrets"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,7 +38,7 @@ import (
 const testNamespace = "foo"
 
 func Test_profilesToApply(t *testing.T) {
-	t1 := time.Now()
+	t1 := time.Now().Truncate(time.Second)
 	t2 := t1.Add(time.Minute)
 	t3 := t2.Add(time.Minute)
 	now := metav1.NewTime(t1)
@@ -101,7 +112,7 @@ func Test_profilesToApply(t *testing.T) {
 					Valid:       "Unknown",
 					Applied:     "Unknown",
 				}
-				profileList[0].ResourceVersion = "1000"
+				profileList[0].ResourceVersion = "999"
 				return profileList
 			},
 			wantProfileAppliedByNode: map[string]types.NamespacedName{},
@@ -148,10 +159,12 @@ func Test_profilesToApply(t *testing.T) {
 							Message:            "Profile applied",
 						},
 					},
-					Valid:   "True",
-					Applied: "True",
+					Valid:         "True",
+					Applied:       "True",
+					MatchingNodes: 1,
+					WonNodes:      []string{"node1"},
 				}
-				profileList[0].ResourceVersion = "1000"
+				profileList[0].ResourceVersion = "999"
 				return profileList
 			},
 			wantProfileAppliedByNode: map[string]types.NamespacedName{
@@ -207,10 +220,12 @@ func Test_profilesToApply(t *testing.T) {
 							Message:            "Profile applied",
 						},
 					},
-					Valid:   "True",
-					Applied: "True",
+					Valid:         "True",
+					Applied:       "True",
+					MatchingNodes: 1,
+					WonNodes:      []string{"node1"},
 				}
-				profileList[0].ResourceVersion = "1000"
+				profileList[0].ResourceVersion = "999"
 				profileList[1].Status = v1alpha1.DatadogAgentProfileStatus{
 					LastUpdate:  &now,
 					CurrentHash: "e7eda6755e8a98d127140e2169204312",
@@ -230,10 +245,12 @@ func Test_profilesToApply(t *testing.T) {
 							Message:            "Profile applied",
 						},
 					},
-					Valid:   "True",
-					Applied: "True",
+					Valid:         "True",
+					Applied:       "True",
+					MatchingNodes: 1,
+					WonNodes:      []string{"node2"},
 				}
-				profileList[1].ResourceVersion = "1000"
+				profileList[1].ResourceVersion = "999"
 				return profileList
 			},
 			wantProfileAppliedByNode: map[string]types.NamespacedName{
@@ -250,12 +267,13 @@ func Test_profilesToApply(t *testing.T) {
 		{
 			// This test defines 3 profiles created in this order: profile-2,
 			// profile-1, profile-3 (not sorted here to make sure that the code does).
-			// - profile-1 and profile-2 conflict, but profile-2 is the oldest,
-			// so it wins.
-			// - profile-1 and profile-3 conflict, but profile-1 is not applied
-			// because of the conflict with profile-2, so profile-3 should be.
+			// - profile-1 and profile-2 both match node3, but profile-2 is the
+			// oldest, so it wins node3.
+			// - profile-1 and profile-3 both match node1, profile-1 is older
+			// than profile-3, so it wins node1.
+			// - profile-3 doesn't win any node, so it is not applied.
 			// So in this case, the returned profiles should be profile-2,
-			// profile-3 and a default one.
+			// profile-1 and a default one.
 			name: "several conflicting profiles with different creation timestamps",
 			nodeList: []corev1.Node{
 				// node1 matches profile-1 and profile-3
@@ -290,7 +308,7 @@ func Test_profilesToApply(t *testing.T) {
 			},
 			profileList: generateObjectList([]string{"1", "2", "3"}, []time.Time{t2, t1, t3}),
 			wantProfilesToApply: func() []v1alpha1.DatadogAgentProfile {
-				profileList := generateProfileList([]string{"2", "3"}, []time.Time{t1, t3})
+				profileList := generateProfileList([]string{"2", "1"}, []time.Time{t1, t2})
 				profileList[0].Status = v1alpha1.DatadogAgentProfileStatus{
 					LastUpdate:  &now,
 					CurrentHash: "e7eda6755e8a98d127140e2169204312",
@@ -310,13 +328,15 @@ func Test_profilesToApply(t *testing.T) {
 							Message:            "Profile applied",
 						},
 					},
-					Valid:   "True",
-					Applied: "True",
+					Valid:         "True",
+					Applied:       "True",
+					MatchingNodes: 2,
+					WonNodes:      []string{"node2", "node3"},
 				}
-				profileList[0].ResourceVersion = "1000"
+				profileList[0].ResourceVersion = "999"
 				profileList[1].Status = v1alpha1.DatadogAgentProfileStatus{
 					LastUpdate:  &now,
-					CurrentHash: "6cc0746a51b8e52da6e4e625d3181686",
+					CurrentHash: "36a4d655a44a0ca07780fff47dd96c6a",
 					Conditions: []metav1.Condition{
 						{
 							Type:               "Valid",
@@ -333,16 +353,21 @@ func Test_profilesToApply(t *testing.T) {
 							Message:            "Profile applied",
 						},
 					},
-					Valid:   "True",
-					Applied: "True",
+					Valid:         "True",
+					Applied:       "True",
+					MatchingNodes: 2,
+					WonNodes:      []string{"node1"},
+					LostNodes: []v1alpha1.LostNode{
+						{Node: "node3", Profile: testNamespace + "/2"},
+					},
 				}
-				profileList[1].ResourceVersion = "1000"
+				profileList[1].ResourceVersion = "999"
 				return profileList
 			},
 			wantProfileAppliedByNode: map[string]types.NamespacedName{
 				"node1": {
 					Namespace: testNamespace,
-					Name:      "3",
+					Name:      "1",
 				},
 				"node2": {
 					Namespace: testNamespace,
@@ -396,10 +421,12 @@ func Test_profilesToApply(t *testing.T) {
 							Message:            "Profile applied",
 						},
 					},
-					Valid:   "True",
-					Applied: "True",
+					Valid:         "True",
+					Applied:       "True",
+					MatchingNodes: 1,
+					WonNodes:      []string{"node1"},
 				}
-				profileList[0].ResourceVersion = "1000"
+				profileList[0].ResourceVersion = "999"
 				return profileList
 			},
 			wantProfileAppliedByNode: map[string]types.NamespacedName{
@@ -518,10 +545,12 @@ func Test_profilesToApply(t *testing.T) {
 							Message:            "Profile applied",
 						},
 					},
-					Valid:   "True",
-					Applied: "True",
+					Valid:         "True",
+					Applied:       "True",
+					MatchingNodes: 1,
+					WonNodes:      []string{"node1"},
 				}
-				profileList[0].ResourceVersion = "1000"
+				profileList[0].ResourceVersion = "999"
 				profileList[1].Status = v1alpha1.DatadogAgentProfileStatus{
 					LastUpdate:  &now,
 					CurrentHash: "e7eda6755e8a98d127140e2169204312",
@@ -537,7 +566,7 @@ func Test_profilesToApply(t *testing.T) {
 					Valid:   "True",
 					Applied: "Unknown",
 				}
-				profileList[1].ResourceVersion = "1000"
+				profileList[1].ResourceVersion = "999"
 				return profileList
 			},
 			wantProfileAppliedByNode: map[string]types.NamespacedName{
@@ -584,6 +613,13 @@ func Test_profilesToApply(t *testing.T) {
 			assert.Equal(t, wantProfilesToApply, profilesToApply)
 			// assert.ElementsMatch(t, wantProfilesToApply, profilesToApply)
 			assert.Equal(t, tt.wantProfileAppliedByNode, profileAppliedByNode)
+
+			// The status of the profiles is only updated by the DatadogAgentProfile controller
+			storedProfiles := v1alpha1.DatadogAgentProfileList{}
+			require.NoError(t, r.client.List(ctx, &storedProfiles))
+			for _, profile := range storedProfiles.Items {
+				assert.Nil(t, profile.Status.LastUpdate)
+			}
 		})
 	}
 }
@@ -650,3 +686,144 @@ func defaultProfile() v1alpha1.DatadogAgentProfile {
 		},
 	}
 }
+
+func Test_buildProfileFeatures(t *testing.T) {
+	sch := runtime.NewScheme()
+	_ = scheme.AddToScheme(sch)
+	_ = v2alpha1.AddToScheme(sch)
+	logger := logf.Log.WithName("Test_buildProfileFeatures")
+	r := &Reconciler{
+		client:       fake.NewClientBuilder().WithScheme(sch).Build(),
+		scheme:       sch,
+		platformInfo: kubernetes.NewPlatformInfoFromVersionMaps(nil, nil, nil),
+	}
+
+	// First reconcile: the status has no generated token yet
+	dda := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "foo"},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				OOMKill: &common.OOMKillFeatureConfig{Enabled: apiutils.NewBoolPointer(false)},
+			},
+		},
+	}
+	defaults.DefaultDatadogAgent(dda)
+	newStatus := dda.Status.DeepCopy()
+
+	// The features of the DatadogAgent are set up like in the reconcile loop
+	features, requiredComponents := feature.BuildFeatures(dda, reconcilerOptionsToFeatureOptions(&r.options, logger))
+	depsStore := store.NewStore(dda, &store.StoreOptions{Scheme: sch, Logger: logger, PlatformInfo: r.platformInfo})
+	resourceManagers := feature.NewResourceManagers(depsStore)
+	for _, feat := range features {
+		require.NoError(t, feat.ManageDependencies(resourceManagers, requiredComponents))
+	}
+	ensureAutoGeneratedTokenInStatus(dda, newStatus, resourceManagers, logger)
+	require.NotNil(t, newStatus.ClusterAgent)
+	generatedToken := newStatus.ClusterAgent.GeneratedToken
+	require.NotEmpty(t, generatedToken)
+
+	profile := &v1alpha1.DatadogAgentProfile{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "oomkill"},
+		Spec: v1alpha1.DatadogAgentProfileSpec{
+			Config: &v1alpha1.Config{
+				Features: &v1alpha1.ProfileFeatures{
+					OOMKill: &common.OOMKillFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+				},
+			},
+		},
+	}
+	profileFeatures, _, err := r.buildProfileFeatures(logger, dda, newStatus, profile, features, resourceManagers)
+	require.NoError(t, err)
+	assert.Contains(t, featureIDs(profileFeatures), string(feature.OOMKillIDType))
+	assert.Empty(t, dda.Status.ClusterAgent, "the status of the DatadogAgent must not be modified")
+
+	// The token secret keeps the token saved in the status
+	obj, found := depsStore.Get(kubernetes.SecretsKind, testNamespace, secrets.GetDefaultDCATokenSecretName(dda))
+	require.True(t, found)
+	assert.Equal(t, generatedToken, string(obj.(*corev1.Secret).Data[agentcommon.DefaultTokenKey]))
+
+	// The node agents of the profile use the same token as the other node agents
+	tokenChecksum := func(features []feature.Feature) string {
+		for _, feat := range features {
+			if feat.ID() != feature.DefaultIDType {
+				continue
+			}
+			managers := fakefeature.NewPodTemplateManagers(t, corev1.PodTemplateSpec{})
+			require.NoError(t, feat.ManageNodeAgent(managers, kubernetes.LegacyProvider))
+			return managers.AnnotationMgr.Annotations[object.GetChecksumAnnotationKey(string(feature.DefaultIDType))]
+		}
+		return ""
+	}
+	wantChecksum := tokenChecksum(features)
+	require.NotEmpty(t, wantChecksum)
+	assert.Equal(t, wantChecksum, tokenChecksum(profileFeatures))
+}
+
+func Test_buildProfileFeatures_featureChangedByProfile(t *testing.T) {
+	sch := runtime.NewScheme()
+	_ = scheme.AddToScheme(sch)
+	_ = v2alpha1.AddToScheme(sch)
+	logger := logf.Log.WithName("Test_buildProfileFeatures_featureChangedByProfile")
+	r := &Reconciler{
+		client:       fake.NewClientBuilder().WithScheme(sch).Build(),
+		scheme:       sch,
+		platformInfo: kubernetes.NewPlatformInfo(&version.Info{GitVersion: "v1.30.0"}, nil, nil),
+	}
+
+	// DogStatsD is enabled in the DatadogAgent without host port
+	dda := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "foo"},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{},
+		},
+	}
+	defaults.DefaultDatadogAgent(dda)
+	features, requiredComponents := feature.BuildFeatures(dda, reconcilerOptionsToFeatureOptions(&r.options, logger))
+	require.Contains(t, featureIDs(features), string(feature.DogstatsdIDType))
+	depsStore := store.NewStore(dda, &store.StoreOptions{Scheme: sch, Logger: logger, PlatformInfo: r.platformInfo})
+	resourceManagers := feature.NewResourceManagers(depsStore)
+	for _, feat := range features {
+		require.NoError(t, feat.ManageDependencies(resourceManagers, requiredComponents))
+	}
+
+	// The profile enables the host port of DogStatsD
+	profile := &v1alpha1.DatadogAgentProfile{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "dogstatsd"},
+		Spec: v1alpha1.DatadogAgentProfileSpec{
+			Config: &v1alpha1.Config{
+				Features: &v1alpha1.ProfileFeatures{
+					Dogstatsd: &common.DogstatsdFeatureConfig{
+						HostPortConfig: &common.HostPortConfig{
+							Enabled: apiutils.NewBoolPointer(true),
+							Port:    apiutils.NewInt32Pointer(1234),
+						},
+					},
+				},
+			},
+		},
+	}
+	_, _, err := r.buildProfileFeatures(logger, dda, dda.Status.DeepCopy(), profile, features, resourceManagers)
+	require.NoError(t, err)
+
+	// The dependencies of the feature changed by the profile are set up
+	obj, found := depsStore.Get(kubernetes.ServicesKind, testNamespace, constants.GetLocalAgentServiceName(dda))
+	require.True(t, found)
+	var dogstatsdPorts []int32
+	for _, port := range obj.(*corev1.Service).Spec.Ports {
+		if port.Protocol == corev1.ProtocolUDP {
+			dogstatsdPorts = append(dogstatsdPorts, port.Port)
+		}
+	}
+	assert.Equal(t, []int32{1234}, dogstatsdPorts)
+}
+
+func Test_profileFeatureIDs(t *testing.T) {
+	assert.Empty(t, profileFeatureIDs(nil))
+	assert.Equal(t, map[feature.IDType]struct{}{
+		feature.NPMIDType: {},
+		feature.GPUIDType: {},
+	}, profileFeatureIDs(&v1alpha1.ProfileFeatures{
+		NPM: &common.NPMFeatureConfig{Enabled: apiutils.NewBoolPointer(false)},
+		GPU: &common.GPUFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+	}))
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	componentagent "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/agent"
@@ -634,3 +635,110 @@ func verifyPDB(t *testing.T, c client.Client) error {
 	assert.Nil(t, ccrPDB.Spec.MinAvailable)
 	return nil
 }
+
+func Test_ProfileFeatures(t *testing.T) {
+	const resourcesName = "foo"
+	const resourcesNamespace = "bar"
+	const dsName = "foo-agent"
+	const profileDSName = "datadog-agent-with-profile-bar-gpu"
+
+	eventBroadcaster := record.NewBroadcaster()
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "TestReconcileDatadogAgent_Reconcile"})
+	forwarders := dummyManager{}
+
+	logf.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	// Register operator types with the runtime scheme.
+	s := agenttestutils.TestScheme()
+
+	nodes := []client.Object{
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "default-node",
+			},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "gpu-node",
+				Labels: map[string]string{
+					"gpu": "true",
+				},
+			},
+		},
+	}
+
+	profile := &v1alpha1.DatadogAgentProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: resourcesNamespace,
+			Name:      "gpu",
+		},
+		Spec: v1alpha1.DatadogAgentProfileSpec{
+			ProfileAffinity: &v1alpha1.ProfileAffinity{
+				ProfileNodeAffinity: []corev1.NodeSelectorRequirement{
+					{
+						Key:      "gpu",
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{"true"},
+					},
+				},
+			},
+			Config: &v1alpha1.Config{
+				Features: &v1alpha1.ProfileFeatures{
+					NPM: &apicommon.NPMFeatureConfig{
+						Enabled: apiutils.NewBoolPointer(true),
+					},
+				},
+				Override: map[v1alpha1.ComponentName]*v1alpha1.Override{
+					v1alpha1.NodeAgentComponentName: {
+						Tolerations: []corev1.Toleration{
+							{
+								Key:      "nvidia.com/gpu",
+								Operator: corev1.TolerationOpExists,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	r := &Reconciler{
+		client: fake.NewClientBuilder().
+			WithStatusSubresource(&corev1.Node{}, &v2alpha1.DatadogAgent{}, &v1alpha1.DatadogAgentProfile{}).
+			WithObjects(nodes...).
+			WithObjects(profile).
+			Build(),
+		scheme:     s,
+		recorder:   recorder,
+		log:        logf.Log.WithName(t.Name()),
+		forwarders: forwarders,
+		options: ReconcilerOptions{
+			DatadogAgentProfileEnabled: true,
+		},
+	}
+
+	dda := testutils.NewInitializedDatadogAgentBuilder(resourcesNamespace, resourcesName).Build()
+	assert.NoError(t, r.client.Create(context.TODO(), dda))
+
+	_, err := r.Reconcile(context.TODO(), newRequest(resourcesNamespace, resourcesName))
+	assert.NoError(t, err)
+
+	// The default DaemonSet doesn't run NPM
+	assert.NoError(t, verifyDaemonsetContainers(r.client, resourcesNamespace, dsName, []string{
+		string(apicommon.CoreAgentContainerName),
+		string(apicommon.TraceAgentContainerName),
+	}))
+
+	// The profile DaemonSet runs NPM in the system-probe
+	profileDS := &appsv1.DaemonSet{}
+	assert.NoError(t, r.client.Get(context.TODO(), types.NamespacedName{Namespace: resourcesNamespace, Name: profileDSName}, profileDS))
+	var profileContainers []string
+	for _, container := range profileDS.Spec.Template.Spec.Containers {
+		profileContainers = append(profileContainers, container.Name)
+	}
+	assert.Contains(t, profileContainers, string(apicommon.SystemProbeContainerName))
+	assert.Contains(t, profileDS.Spec.Template.Spec.Tolerations, corev1.Toleration{
+		Key:      "nvidia.com/gpu",
+		Operator: corev1.TolerationOpExists,
+	})
+}
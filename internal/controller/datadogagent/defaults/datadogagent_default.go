@@ -6,6 +6,7 @@
 package defaults
 
 import (
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
@@ -91,7 +92,7 @@ const (
 	// DefaultAdmissionControllerCWSInstrumentationEnabled default CWS Instrumentation enabled value
 	DefaultAdmissionControllerCWSInstrumentationEnabled bool = false
 	// DefaultAdmissionControllerCWSInstrumentationMode default CWS Instrumentation mode
-	DefaultAdmissionControllerCWSInstrumentationMode string = "remote_copy"
+	DefaultAdmissionControllerCWSInstrumentationMode v2alpha1.CWSInstrumentationMode = v2alpha1.CWSInstrumentationModeRemoteCopy
 
 	defaultAdmissionASMThreatsEnabled bool = false
 	defaultAdmissionASMSCAEnabled     bool = false
@@ -215,7 +216,7 @@ func defaultFeaturesConfig(ddaSpec *v2alpha1.DatadogAgentSpec) {
 
 	// LogsCollection Feature
 	if ddaSpec.Features.LogCollection == nil {
-		ddaSpec.Features.LogCollection = &v2alpha1.LogCollectionFeatureConfig{}
+		ddaSpec.Features.LogCollection = &apicommon.LogCollectionFeatureConfig{}
 	}
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.LogCollection.Enabled, defaultLogCollectionEnabled)
 
@@ -233,7 +234,7 @@ func defaultFeaturesConfig(ddaSpec *v2alpha1.DatadogAgentSpec) {
 
 	// LiveContainerCollection Feature
 	if ddaSpec.Features.LiveContainerCollection == nil {
-		ddaSpec.Features.LiveContainerCollection = &v2alpha1.LiveContainerCollectionFeatureConfig{}
+		ddaSpec.Features.LiveContainerCollection = &apicommon.LiveContainerCollectionFeatureConfig{}
 	}
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.LiveContainerCollection.Enabled, defaultLiveContainerCollectionEnabled)
 
@@ -245,42 +246,42 @@ func defaultFeaturesConfig(ddaSpec *v2alpha1.DatadogAgentSpec) {
 
 	// LiveProcessCollection Feature
 	if ddaSpec.Features.LiveProcessCollection == nil {
-		ddaSpec.Features.LiveProcessCollection = &v2alpha1.LiveProcessCollectionFeatureConfig{}
+		ddaSpec.Features.LiveProcessCollection = &apicommon.LiveProcessCollectionFeatureConfig{}
 	}
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.LiveProcessCollection.Enabled, defaultLiveProcessCollectionEnabled)
 
 	// ProcessDiscovery Feature
 	if ddaSpec.Features.ProcessDiscovery == nil {
-		ddaSpec.Features.ProcessDiscovery = &v2alpha1.ProcessDiscoveryFeatureConfig{}
+		ddaSpec.Features.ProcessDiscovery = &apicommon.ProcessDiscoveryFeatureConfig{}
 	}
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.ProcessDiscovery.Enabled, defaultProcessDiscoveryEnabled)
 
 	// OOMKill Feature
 	if ddaSpec.Features.OOMKill == nil {
-		ddaSpec.Features.OOMKill = &v2alpha1.OOMKillFeatureConfig{}
+		ddaSpec.Features.OOMKill = &apicommon.OOMKillFeatureConfig{}
 	}
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.OOMKill.Enabled, defaultOOMKillEnabled)
 
 	// TCPQueueLength Feature
 	if ddaSpec.Features.TCPQueueLength == nil {
-		ddaSpec.Features.TCPQueueLength = &v2alpha1.TCPQueueLengthFeatureConfig{}
+		ddaSpec.Features.TCPQueueLength = &apicommon.TCPQueueLengthFeatureConfig{}
 	}
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.TCPQueueLength.Enabled, defaultTCPQueueLengthEnabled)
 
 	// EBPFCheck Feature
 	if ddaSpec.Features.EBPFCheck == nil {
-		ddaSpec.Features.EBPFCheck = &v2alpha1.EBPFCheckFeatureConfig{}
+		ddaSpec.Features.EBPFCheck = &apicommon.EBPFCheckFeatureConfig{}
 	}
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.EBPFCheck.Enabled, defaultEBPFCheckEnabled)
 
 	if ddaSpec.Features.ServiceDiscovery == nil {
-		ddaSpec.Features.ServiceDiscovery = &v2alpha1.ServiceDiscoveryFeatureConfig{}
+		ddaSpec.Features.ServiceDiscovery = &apicommon.ServiceDiscoveryFeatureConfig{}
 	}
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.ServiceDiscovery.Enabled, defaultServiceDiscoveryEnabled)
 
 	// GPU monitoring feature
 	if ddaSpec.Features.GPU == nil {
-		ddaSpec.Features.GPU = &v2alpha1.GPUFeatureConfig{}
+		ddaSpec.Features.GPU = &apicommon.GPUFeatureConfig{}
 	}
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.GPU.Enabled, defaultGPUMonitoringEnabled)
 
@@ -294,7 +295,7 @@ func defaultFeaturesConfig(ddaSpec *v2alpha1.DatadogAgentSpec) {
 
 	if *ddaSpec.Features.APM.Enabled {
 		if ddaSpec.Features.APM.HostPortConfig == nil {
-			ddaSpec.Features.APM.HostPortConfig = &v2alpha1.HostPortConfig{}
+			ddaSpec.Features.APM.HostPortConfig = &apicommon.HostPortConfig{}
 		}
 
 		apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.APM.HostPortConfig.Enabled, defaultAPMHostPortEnabled)
@@ -302,7 +303,7 @@ func defaultFeaturesConfig(ddaSpec *v2alpha1.DatadogAgentSpec) {
 		apiutils.DefaultInt32IfUnset(&ddaSpec.Features.APM.HostPortConfig.Port, defaultAPMHostPort)
 
 		if ddaSpec.Features.APM.UnixDomainSocketConfig == nil {
-			ddaSpec.Features.APM.UnixDomainSocketConfig = &v2alpha1.UnixDomainSocketConfig{}
+			ddaSpec.Features.APM.UnixDomainSocketConfig = &apicommon.UnixDomainSocketConfig{}
 		}
 
 		apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.APM.UnixDomainSocketConfig.Enabled, defaultAPMSocketEnabled)
@@ -385,7 +386,7 @@ func defaultFeaturesConfig(ddaSpec *v2alpha1.DatadogAgentSpec) {
 
 	// NPM (Network Performance Monitoring) Feature
 	if ddaSpec.Features.NPM == nil {
-		ddaSpec.Features.NPM = &v2alpha1.NPMFeatureConfig{}
+		ddaSpec.Features.NPM = &apicommon.NPMFeatureConfig{}
 	}
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.NPM.Enabled, defaultNPMEnabled)
 
@@ -396,18 +397,18 @@ func defaultFeaturesConfig(ddaSpec *v2alpha1.DatadogAgentSpec) {
 
 	// USM (Universal Service Monitoring) Feature
 	if ddaSpec.Features.USM == nil {
-		ddaSpec.Features.USM = &v2alpha1.USMFeatureConfig{}
+		ddaSpec.Features.USM = &apicommon.USMFeatureConfig{}
 	}
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.USM.Enabled, defaultUSMEnabled)
 
 	// Dogstatsd Feature
 	if ddaSpec.Features.Dogstatsd == nil {
-		ddaSpec.Features.Dogstatsd = &v2alpha1.DogstatsdFeatureConfig{}
+		ddaSpec.Features.Dogstatsd = &apicommon.DogstatsdFeatureConfig{}
 	}
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.Dogstatsd.OriginDetectionEnabled, defaultDogstatsdOriginDetectionEnabled)
 
 	if ddaSpec.Features.Dogstatsd.HostPortConfig == nil {
-		ddaSpec.Features.Dogstatsd.HostPortConfig = &v2alpha1.HostPortConfig{
+		ddaSpec.Features.Dogstatsd.HostPortConfig = &apicommon.HostPortConfig{
 			Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled),
 		}
 	}
@@ -417,7 +418,7 @@ func defaultFeaturesConfig(ddaSpec *v2alpha1.DatadogAgentSpec) {
 	}
 
 	if ddaSpec.Features.Dogstatsd.UnixDomainSocketConfig == nil {
-		ddaSpec.Features.Dogstatsd.UnixDomainSocketConfig = &v2alpha1.UnixDomainSocketConfig{}
+		ddaSpec.Features.Dogstatsd.UnixDomainSocketConfig = &apicommon.UnixDomainSocketConfig{}
 	}
 
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.Dogstatsd.UnixDomainSocketConfig.Enabled, defaultDogstatsdSocketEnabled)
@@ -438,7 +439,7 @@ func defaultFeaturesConfig(ddaSpec *v2alpha1.DatadogAgentSpec) {
 
 	if apiutils.BoolValue(ddaSpec.Features.OTLP.Receiver.Protocols.GRPC.Enabled) {
 		if ddaSpec.Features.OTLP.Receiver.Protocols.GRPC.HostPortConfig == nil {
-			ddaSpec.Features.OTLP.Receiver.Protocols.GRPC.HostPortConfig = &v2alpha1.HostPortConfig{}
+			ddaSpec.Features.OTLP.Receiver.Protocols.GRPC.HostPortConfig = &apicommon.HostPortConfig{}
 		}
 		apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.OTLP.Receiver.Protocols.GRPC.HostPortConfig.Enabled, defaultOTLPGRPCHostPortEnabled)
 	}
@@ -453,7 +454,7 @@ func defaultFeaturesConfig(ddaSpec *v2alpha1.DatadogAgentSpec) {
 
 	if apiutils.BoolValue(ddaSpec.Features.OTLP.Receiver.Protocols.HTTP.Enabled) {
 		if ddaSpec.Features.OTLP.Receiver.Protocols.HTTP.HostPortConfig == nil {
-			ddaSpec.Features.OTLP.Receiver.Protocols.HTTP.HostPortConfig = &v2alpha1.HostPortConfig{}
+			ddaSpec.Features.OTLP.Receiver.Protocols.HTTP.HostPortConfig = &apicommon.HostPortConfig{}
 		}
 		apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.OTLP.Receiver.Protocols.HTTP.HostPortConfig.Enabled, defaultOTLPHTTPHostPortEnabled)
 	}
@@ -547,8 +548,8 @@ func defaultFeaturesConfig(ddaSpec *v2alpha1.DatadogAgentSpec) {
 	}
 	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.AdmissionController.CWSInstrumentation.Enabled, DefaultAdmissionControllerCWSInstrumentationEnabled)
 
-	if *ddaSpec.Features.AdmissionController.CWSInstrumentation.Enabled {
-		apiutils.DefaultStringIfUnset(&ddaSpec.Features.AdmissionController.CWSInstrumentation.Mode, DefaultAdmissionControllerCWSInstrumentationMode)
+	if *ddaSpec.Features.AdmissionController.CWSInstrumentation.Enabled && ddaSpec.Features.AdmissionController.CWSInstrumentation.Mode == nil {
+		ddaSpec.Features.AdmissionController.CWSInstrumentation.Mode = apiutils.NewPointer(DefaultAdmissionControllerCWSInstrumentationMode)
 	}
 
 	// ExternalMetricsServer Feature
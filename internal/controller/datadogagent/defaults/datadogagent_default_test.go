@@ -8,6 +8,7 @@ package defaults
 import (
 	"testing"
 
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
@@ -176,40 +177,40 @@ func Test_defaultFeatures(t *testing.T) {
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLogCollectionEnabled),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveProcessCollectionEnabled),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultProcessDiscoveryEnabled),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultOOMKillEnabled),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultTCPQueueLengthEnabled),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultAPMEnabled),
-						HostPortConfig: &v2alpha1.HostPortConfig{
+						HostPortConfig: &apicommon.HostPortConfig{
 							Port:    apiutils.NewInt32Pointer(defaultAPMHostPort),
 							Enabled: apiutils.NewBoolPointer(defaultAPMHostPortEnabled),
 						},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAPMSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultAPMSocketHostPath),
 						},
@@ -242,16 +243,16 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultCWSEnabled),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultNPMEnabled),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultUSMEnabled),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -318,31 +319,31 @@ func Test_defaultFeatures(t *testing.T) {
 			name: "all features are disabled",
 			ddaSpec: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
@@ -368,10 +369,10 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
 					OTLP: &v2alpha1.OTLPFeatureConfig{Receiver: v2alpha1.OTLPReceiverConfig{Protocols: v2alpha1.OTLPProtocolsConfig{
@@ -411,31 +412,31 @@ func Test_defaultFeatures(t *testing.T) {
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
@@ -461,16 +462,16 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueFalse),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -533,47 +534,47 @@ func Test_defaultFeatures(t *testing.T) {
 			name: "liveProcess is enabled",
 			ddaSpec: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueTrue),
 					},
 				},
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLogCollectionEnabled),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueTrue),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultProcessDiscoveryEnabled),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultOOMKillEnabled),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultTCPQueueLengthEnabled),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultAPMEnabled),
-						HostPortConfig: &v2alpha1.HostPortConfig{
+						HostPortConfig: &apicommon.HostPortConfig{
 							Port:    apiutils.NewInt32Pointer(defaultAPMHostPort),
 							Enabled: apiutils.NewBoolPointer(defaultAPMHostPortEnabled),
 						},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAPMSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultAPMSocketHostPath),
 						},
@@ -606,16 +607,16 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultCWSEnabled),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultNPMEnabled),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultUSMEnabled),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -682,14 +683,14 @@ func Test_defaultFeatures(t *testing.T) {
 			name: "logCollection is enabled",
 			ddaSpec: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueTrue),
 					},
 				},
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled:                    apiutils.NewBoolPointer(valueTrue),
 						ContainerCollectUsingFiles: apiutils.NewBoolPointer(defaultLogContainerCollectUsingFiles),
 						ContainerLogsPath:          apiutils.NewStringPointer(defaultLogContainerLogsPath),
@@ -697,37 +698,37 @@ func Test_defaultFeatures(t *testing.T) {
 						ContainerSymlinksPath:      apiutils.NewStringPointer(defaultLogContainerSymlinksPath),
 						TempStoragePath:            apiutils.NewStringPointer(common.DefaultLogTempStoragePath),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveProcessCollectionEnabled),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultProcessDiscoveryEnabled),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultOOMKillEnabled),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultTCPQueueLengthEnabled),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultAPMEnabled),
-						HostPortConfig: &v2alpha1.HostPortConfig{
+						HostPortConfig: &apicommon.HostPortConfig{
 							Port:    apiutils.NewInt32Pointer(defaultAPMHostPort),
 							Enabled: apiutils.NewBoolPointer(defaultAPMHostPortEnabled),
 						},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAPMSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultAPMSocketHostPath),
 						},
@@ -760,16 +761,16 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultCWSEnabled),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultNPMEnabled),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultUSMEnabled),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -843,40 +844,40 @@ func Test_defaultFeatures(t *testing.T) {
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLogCollectionEnabled),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveProcessCollectionEnabled),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultProcessDiscoveryEnabled),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultOOMKillEnabled),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultTCPQueueLengthEnabled),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueTrue),
-						HostPortConfig: &v2alpha1.HostPortConfig{
+						HostPortConfig: &apicommon.HostPortConfig{
 							Port:    apiutils.NewInt32Pointer(defaultAPMHostPort),
 							Enabled: apiutils.NewBoolPointer(defaultAPMHostPortEnabled),
 						},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAPMSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultAPMSocketHostPath),
 						},
@@ -909,16 +910,16 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultCWSEnabled),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultNPMEnabled),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultUSMEnabled),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -985,47 +986,47 @@ func Test_defaultFeatures(t *testing.T) {
 			name: "NPM is enabled",
 			ddaSpec: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(valueTrue),
 					},
 				},
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLogCollectionEnabled),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveProcessCollectionEnabled),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultProcessDiscoveryEnabled),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultOOMKillEnabled),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultTCPQueueLengthEnabled),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultAPMEnabled),
-						HostPortConfig: &v2alpha1.HostPortConfig{
+						HostPortConfig: &apicommon.HostPortConfig{
 							Port:    apiutils.NewInt32Pointer(defaultAPMHostPort),
 							Enabled: apiutils.NewBoolPointer(defaultAPMHostPortEnabled),
 						},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAPMSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultAPMSocketHostPath),
 						},
@@ -1058,18 +1059,18 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultCWSEnabled),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled:         apiutils.NewBoolPointer(valueTrue),
 						EnableConntrack: apiutils.NewBoolPointer(defaultNPMEnableConntrack),
 						CollectDNSStats: apiutils.NewBoolPointer(defaultNPMCollectDNSStats),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultUSMEnabled),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -1150,40 +1151,40 @@ func Test_defaultFeatures(t *testing.T) {
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLogCollectionEnabled),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveProcessCollectionEnabled),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultProcessDiscoveryEnabled),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultOOMKillEnabled),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultTCPQueueLengthEnabled),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultAPMEnabled),
-						HostPortConfig: &v2alpha1.HostPortConfig{
+						HostPortConfig: &apicommon.HostPortConfig{
 							Port:    apiutils.NewInt32Pointer(defaultAPMHostPort),
 							Enabled: apiutils.NewBoolPointer(defaultAPMHostPortEnabled),
 						},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAPMSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultAPMSocketHostPath),
 						},
@@ -1216,16 +1217,16 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultCWSEnabled),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultNPMEnabled),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultUSMEnabled),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -1233,12 +1234,12 @@ func Test_defaultFeatures(t *testing.T) {
 					OTLP: &v2alpha1.OTLPFeatureConfig{Receiver: v2alpha1.OTLPReceiverConfig{Protocols: v2alpha1.OTLPProtocolsConfig{
 						GRPC: &v2alpha1.OTLPGRPCConfig{
 							Enabled:        apiutils.NewBoolPointer(valueTrue),
-							HostPortConfig: &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultOTLPGRPCHostPortEnabled)},
+							HostPortConfig: &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultOTLPGRPCHostPortEnabled)},
 							Endpoint:       apiutils.NewStringPointer(defaultOTLPGRPCEndpoint),
 						},
 						HTTP: &v2alpha1.OTLPHTTPConfig{
 							Enabled:        apiutils.NewBoolPointer(valueTrue),
-							HostPortConfig: &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultOTLPGRPCHostPortEnabled)},
+							HostPortConfig: &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultOTLPGRPCHostPortEnabled)},
 							Endpoint:       apiutils.NewStringPointer(defaultOTLPHTTPEndpoint),
 						},
 					}}},
@@ -1299,40 +1300,40 @@ func Test_defaultFeatures(t *testing.T) {
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLogCollectionEnabled),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveProcessCollectionEnabled),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultProcessDiscoveryEnabled),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultOOMKillEnabled),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultTCPQueueLengthEnabled),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultAPMEnabled),
-						HostPortConfig: &v2alpha1.HostPortConfig{
+						HostPortConfig: &apicommon.HostPortConfig{
 							Port:    apiutils.NewInt32Pointer(defaultAPMHostPort),
 							Enabled: apiutils.NewBoolPointer(defaultAPMHostPortEnabled),
 						},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAPMSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultAPMSocketHostPath),
 						},
@@ -1365,16 +1366,16 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultCWSEnabled),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultNPMEnabled),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultUSMEnabled),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -1451,40 +1452,40 @@ func Test_defaultFeatures(t *testing.T) {
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLogCollectionEnabled),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveProcessCollectionEnabled),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultProcessDiscoveryEnabled),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultOOMKillEnabled),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultTCPQueueLengthEnabled),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultAPMEnabled),
-						HostPortConfig: &v2alpha1.HostPortConfig{
+						HostPortConfig: &apicommon.HostPortConfig{
 							Port:    apiutils.NewInt32Pointer(defaultAPMHostPort),
 							Enabled: apiutils.NewBoolPointer(defaultAPMHostPortEnabled),
 						},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAPMSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultAPMSocketHostPath),
 						},
@@ -1517,16 +1518,16 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultCWSEnabled),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultNPMEnabled),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultUSMEnabled),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -1601,7 +1602,7 @@ func Test_defaultFeatures(t *testing.T) {
 							Enabled: apiutils.NewBoolPointer(true),
 						},
 						MutateUnlabelled:       apiutils.NewBoolPointer(true),
-						AgentCommunicationMode: apiutils.NewStringPointer("socket"),
+						AgentCommunicationMode: apiutils.NewPointer(v2alpha1.AgentCommunicationModeSocket),
 						CWSInstrumentation: &v2alpha1.CWSInstrumentationConfig{
 							Enabled: apiutils.NewBoolPointer(true),
 						},
@@ -1613,22 +1614,22 @@ func Test_defaultFeatures(t *testing.T) {
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLogCollectionEnabled),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveProcessCollectionEnabled),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultAPMEnabled),
-						HostPortConfig: &v2alpha1.HostPortConfig{
+						HostPortConfig: &apicommon.HostPortConfig{
 							Port:    apiutils.NewInt32Pointer(defaultAPMHostPort),
 							Enabled: apiutils.NewBoolPointer(defaultAPMHostPortEnabled),
 						},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAPMSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultAPMSocketHostPath),
 						},
@@ -1655,22 +1656,22 @@ func Test_defaultFeatures(t *testing.T) {
 							Enabled: apiutils.NewBoolPointer(defaultAdmissionASMIASTEnabled),
 						},
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultProcessDiscoveryEnabled),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultOOMKillEnabled),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultTCPQueueLengthEnabled),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					CSPM: &v2alpha1.CSPMFeatureConfig{
@@ -1679,16 +1680,16 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultCWSEnabled),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultNPMEnabled),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultUSMEnabled),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -1735,10 +1736,10 @@ func Test_defaultFeatures(t *testing.T) {
 						},
 						MutateUnlabelled:       apiutils.NewBoolPointer(valueTrue),
 						ServiceName:            apiutils.NewStringPointer(defaultAdmissionServiceName),
-						AgentCommunicationMode: apiutils.NewStringPointer("socket"),
+						AgentCommunicationMode: apiutils.NewPointer(v2alpha1.AgentCommunicationModeSocket),
 						CWSInstrumentation: &v2alpha1.CWSInstrumentationConfig{
 							Enabled: apiutils.NewBoolPointer(valueTrue),
-							Mode:    apiutils.NewStringPointer(DefaultAdmissionControllerCWSInstrumentationMode),
+							Mode:    apiutils.NewPointer(DefaultAdmissionControllerCWSInstrumentationMode),
 						},
 						KubernetesAdmissionEvents: &v2alpha1.KubernetesAdmissionEventsConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAdmissionControllerKubernetesAdmissionEventsEnabled),
@@ -1764,40 +1765,40 @@ func Test_defaultFeatures(t *testing.T) {
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLogCollectionEnabled),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveProcessCollectionEnabled),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultProcessDiscoveryEnabled),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultOOMKillEnabled),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultTCPQueueLengthEnabled),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultAPMEnabled),
-						HostPortConfig: &v2alpha1.HostPortConfig{
+						HostPortConfig: &apicommon.HostPortConfig{
 							Port:    apiutils.NewInt32Pointer(defaultAPMHostPort),
 							Enabled: apiutils.NewBoolPointer(defaultAPMHostPortEnabled),
 						},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAPMSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultAPMSocketHostPath),
 						},
@@ -1830,16 +1831,16 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultCWSEnabled),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultNPMEnabled),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultUSMEnabled),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -1914,40 +1915,40 @@ func Test_defaultFeatures(t *testing.T) {
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLogCollectionEnabled),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveProcessCollectionEnabled),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultProcessDiscoveryEnabled),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultOOMKillEnabled),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultTCPQueueLengthEnabled),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultAPMEnabled),
-						HostPortConfig: &v2alpha1.HostPortConfig{
+						HostPortConfig: &apicommon.HostPortConfig{
 							Port:    apiutils.NewInt32Pointer(defaultAPMHostPort),
 							Enabled: apiutils.NewBoolPointer(defaultAPMHostPortEnabled),
 						},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAPMSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultAPMSocketHostPath),
 						},
@@ -1980,16 +1981,16 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultCWSEnabled),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultNPMEnabled),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultUSMEnabled),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -2057,21 +2058,21 @@ func Test_defaultFeatures(t *testing.T) {
 			name: "all feature configs are empty structs, configures defaults where applicable, leaves others empty",
 			ddaSpec: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection:           &v2alpha1.LogCollectionFeatureConfig{},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{},
-					LiveProcessCollection:   &v2alpha1.LiveProcessCollectionFeatureConfig{},
-					ProcessDiscovery:        &v2alpha1.ProcessDiscoveryFeatureConfig{},
-					OOMKill:                 &v2alpha1.OOMKillFeatureConfig{},
-					TCPQueueLength:          &v2alpha1.TCPQueueLengthFeatureConfig{},
-					EBPFCheck:               &v2alpha1.EBPFCheckFeatureConfig{},
-					GPU:                     &v2alpha1.GPUFeatureConfig{},
-					ServiceDiscovery:        &v2alpha1.ServiceDiscoveryFeatureConfig{},
+					LogCollection:           &apicommon.LogCollectionFeatureConfig{},
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{},
+					LiveProcessCollection:   &apicommon.LiveProcessCollectionFeatureConfig{},
+					ProcessDiscovery:        &apicommon.ProcessDiscoveryFeatureConfig{},
+					OOMKill:                 &apicommon.OOMKillFeatureConfig{},
+					TCPQueueLength:          &apicommon.TCPQueueLengthFeatureConfig{},
+					EBPFCheck:               &apicommon.EBPFCheckFeatureConfig{},
+					GPU:                     &apicommon.GPUFeatureConfig{},
+					ServiceDiscovery:        &apicommon.ServiceDiscoveryFeatureConfig{},
 					APM:                     &v2alpha1.APMFeatureConfig{},
 					ASM:                     &v2alpha1.ASMFeatureConfig{},
 					CSPM:                    &v2alpha1.CSPMFeatureConfig{},
 					CWS:                     &v2alpha1.CWSFeatureConfig{},
-					NPM:                     &v2alpha1.NPMFeatureConfig{},
-					USM:                     &v2alpha1.USMFeatureConfig{},
+					NPM:                     &apicommon.NPMFeatureConfig{},
+					USM:                     &apicommon.USMFeatureConfig{},
 					OTLP:                    &v2alpha1.OTLPFeatureConfig{},
 					RemoteConfiguration:     &v2alpha1.RemoteConfigurationFeatureConfig{},
 					EventCollection:         &v2alpha1.EventCollectionFeatureConfig{},
@@ -2086,40 +2087,40 @@ func Test_defaultFeatures(t *testing.T) {
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLogCollectionEnabled),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveProcessCollectionEnabled),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultProcessDiscoveryEnabled),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultOOMKillEnabled),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultTCPQueueLengthEnabled),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultAPMEnabled),
-						HostPortConfig: &v2alpha1.HostPortConfig{
+						HostPortConfig: &apicommon.HostPortConfig{
 							Port:    apiutils.NewInt32Pointer(defaultAPMHostPort),
 							Enabled: apiutils.NewBoolPointer(defaultAPMHostPortEnabled),
 						},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAPMSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultAPMSocketHostPath),
 						},
@@ -2152,16 +2153,16 @@ func Test_defaultFeatures(t *testing.T) {
 					CWS: &v2alpha1.CWSFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultCWSEnabled),
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultNPMEnabled),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultUSMEnabled),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -2238,40 +2239,40 @@ func Test_defaultFeatures(t *testing.T) {
 			},
 			want: &v2alpha1.DatadogAgentSpec{
 				Features: &v2alpha1.DatadogFeatures{
-					LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+					LogCollection: &apicommon.LogCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLogCollectionEnabled),
 					},
-					LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveProcessCollectionEnabled),
 					},
-					LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
 					},
-					ProcessDiscovery: &v2alpha1.ProcessDiscoveryFeatureConfig{
+					ProcessDiscovery: &apicommon.ProcessDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultProcessDiscoveryEnabled),
 					},
-					OOMKill: &v2alpha1.OOMKillFeatureConfig{
+					OOMKill: &apicommon.OOMKillFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultOOMKillEnabled),
 					},
-					TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+					TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultTCPQueueLengthEnabled),
 					},
-					EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+					EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultEBPFCheckEnabled),
 					},
-					ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+					ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultServiceDiscoveryEnabled),
 					},
-					GPU: &v2alpha1.GPUFeatureConfig{
+					GPU: &apicommon.GPUFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultGPUMonitoringEnabled),
 					},
 					APM: &v2alpha1.APMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultAPMEnabled),
-						HostPortConfig: &v2alpha1.HostPortConfig{
+						HostPortConfig: &apicommon.HostPortConfig{
 							Port:    apiutils.NewInt32Pointer(defaultAPMHostPort),
 							Enabled: apiutils.NewBoolPointer(defaultAPMHostPortEnabled),
 						},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultAPMSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultAPMSocketHostPath),
 						},
@@ -2314,16 +2315,16 @@ func Test_defaultFeatures(t *testing.T) {
 							Enabled: apiutils.NewBoolPointer(defaultCWSSecurityProfilesEnabled),
 						},
 					},
-					NPM: &v2alpha1.NPMFeatureConfig{
+					NPM: &apicommon.NPMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultNPMEnabled),
 					},
-					USM: &v2alpha1.USMFeatureConfig{
+					USM: &apicommon.USMFeatureConfig{
 						Enabled: apiutils.NewBoolPointer(defaultUSMEnabled),
 					},
-					Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
+					Dogstatsd: &apicommon.DogstatsdFeatureConfig{
 						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
-						HostPortConfig:         &v2alpha1.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
-						UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+						HostPortConfig:         &apicommon.HostPortConfig{Enabled: apiutils.NewBoolPointer(defaultDogstatsdHostPortEnabled)},
+						UnixDomainSocketConfig: &apicommon.UnixDomainSocketConfig{
 							Enabled: apiutils.NewBoolPointer(defaultDogstatsdSocketEnabled),
 							Path:    apiutils.NewStringPointer(defaultDogstatsdHostSocketPath),
 						},
@@ -117,7 +117,7 @@ func (f *admissionControllerFeature) Configure(dda *v2alpha1.DatadogAgent) (reqC
 		}
 		// agent communication mode set by user
 		if ac.AgentCommunicationMode != nil && *ac.AgentCommunicationMode != "" {
-			f.agentCommunicationMode = *ac.AgentCommunicationMode
+			f.agentCommunicationMode = string(*ac.AgentCommunicationMode)
 		} else {
 			// agent communication mode set automatically
 			// use `socket` mode if either apm or dsd uses uds
@@ -144,7 +144,9 @@ func (f *admissionControllerFeature) Configure(dda *v2alpha1.DatadogAgent) (reqC
 
 		if ac.CWSInstrumentation != nil && apiutils.BoolValue(ac.CWSInstrumentation.Enabled) {
 			f.cwsInstrumentationEnabled = true
-			f.cwsInstrumentationMode = apiutils.StringValue(ac.CWSInstrumentation.Mode)
+			if ac.CWSInstrumentation.Mode != nil {
+				f.cwsInstrumentationMode = string(*ac.CWSInstrumentation.Mode)
+			}
 		}
 
 		if ac.KubernetesAdmissionEvents != nil && apiutils.BoolValue(ac.KubernetesAdmissionEvents.Enabled) {
@@ -269,7 +271,8 @@ func (f *admissionControllerFeature) ManageDependencies(managers feature.Resourc
 	if f.networkPolicy != "" {
 		policyName, podSelector := objects.GetNetworkPolicyMetadata(f.owner, v2alpha1.ClusterAgentComponentName)
 		switch f.networkPolicy {
-		case v2alpha1.NetworkPolicyFlavorKubernetes:
+		// Calico and admin network policies are complemented by kubernetes network policies for the features ingress
+		case v2alpha1.NetworkPolicyFlavorKubernetes, v2alpha1.NetworkPolicyFlavorCalico, v2alpha1.NetworkPolicyFlavorAdminNetworkPolicy:
 			ingressRules := []netv1.NetworkPolicyIngressRule{
 				{
 					Ports: []netv1.NetworkPolicyPort{
@@ -6,6 +6,7 @@
 package apm
 
 const (
+	DDAPMAdditionalEndpoints               = "DD_APM_ADDITIONAL_ENDPOINTS"
 	DDAPMNonLocalTraffic                   = "DD_APM_NON_LOCAL_TRAFFIC"
 	DDAPMReceiverPort                      = "DD_APM_RECEIVER_PORT"
 	DDAPMReceiverSocket                    = "DD_APM_RECEIVER_SOCKET"
@@ -73,6 +73,8 @@ type apmFeature struct {
 
 	errorTrackingStandalone bool
 
+	additionalEndpoints []v2alpha1.AdditionalEndpoint
+
 	logger logr.Logger
 }
 
@@ -148,6 +150,10 @@ func (f *apmFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.Requ
 		}
 		f.localServiceName = constants.GetLocalAgentServiceName(dda)
 
+		if dda.Spec.Global.AdditionalEndpoints != nil {
+			f.additionalEndpoints = dda.Spec.Global.AdditionalEndpoints.APM
+		}
+
 		reqComp = feature.RequiredComponents{
 			Agent: feature.RequiredComponent{
 				IsRequired: apiutils.NewBoolPointer(true),
@@ -371,16 +377,17 @@ func (f *apmFeature) ManageClusterAgent(managers feature.PodTemplateManagers) er
 	return nil
 }
 
-const minInstrumentationTargetsVersion = "7.64.0-0"
+// MinInstrumentationTargetsVersion is the minimum Cluster Agent version that supports instrumentation targets
+const MinInstrumentationTargetsVersion = "7.64.0-0"
 
 func supportsInstrumentationTargets(dda *v2alpha1.DatadogAgent) bool {
 	// Agent version must >= 7.64.0 to run feature in core agent
 	if nodeAgent, ok := dda.Spec.Override[v2alpha1.ClusterAgentComponentName]; ok {
 		if nodeAgent.Image != nil {
-			return utils.IsAboveMinVersion(common.GetAgentVersionFromImage(*nodeAgent.Image), minInstrumentationTargetsVersion)
+			return utils.IsAboveMinVersion(common.GetAgentVersionFromImage(*nodeAgent.Image), MinInstrumentationTargetsVersion)
 		}
 	}
-	return utils.IsAboveMinVersion(defaulting.ClusterAgentLatestVersion, minInstrumentationTargetsVersion)
+	return utils.IsAboveMinVersion(defaulting.ClusterAgentLatestVersion, MinInstrumentationTargetsVersion)
 }
 
 // ManageSingleContainerNodeAgent allows a feature to configure the Agent container for the Node Agent's corev1.PodTemplateSpec
@@ -405,6 +412,10 @@ func (f *apmFeature) manageNodeAgent(agentContainerName apicommon.AgentContainer
 		Value: "true",
 	})
 
+	for _, envVar := range featutils.AdditionalEndpointsEnvVars(DDAPMAdditionalEndpoints, f.additionalEndpoints) {
+		managers.EnvVar().AddEnvVarToContainer(agentContainerName, envVar)
+	}
+
 	// udp
 	apmPort := &corev1.ContainerPort{
 		Name:          constants.DefaultApmPortName,
@@ -0,0 +1,30 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package clusteragenttls
+
+import (
+	"time"
+)
+
+const (
+	// DefaultCertificateValidity is the default validity of the issued certificates
+	DefaultCertificateValidity = 90 * 24 * time.Hour
+	// caValidityFactor is the validity of the certificate authority relative to the issued certificates.
+	// The certificate authority is renewed once two thirds of its validity elapsed, it then outlives the certificates it issued.
+	caValidityFactor = 4
+
+	// MinVersion is the minimum version of the Cluster Agent and the Agents supporting the TLS of the Cluster Agent
+	MinVersion = "7.63.0-0"
+
+	// CACertKey is the secret key of the bundle of certificate authorities
+	CACertKey = "ca.crt"
+
+	tlsVolumeName = "cluster-agent-tls"
+	tlsVolumePath = "/etc/datadog-agent/cluster-agent-tls"
+
+	// ClientCommonName is the common name of the client certificate of the Agents
+	ClientCommonName = "datadog-agent"
+)
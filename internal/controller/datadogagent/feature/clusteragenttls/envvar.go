@@ -0,0 +1,17 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package clusteragenttls
+
+const (
+	DDClusterAgentURL                        = "DD_CLUSTER_AGENT_URL"
+	DDClusterAgentTLSCertFile                = "DD_CLUSTER_AGENT_TLS_CERT_FILE"
+	DDClusterAgentTLSKeyFile                 = "DD_CLUSTER_AGENT_TLS_KEY_FILE"
+	DDClusterAgentTLSClientCertFile          = "DD_CLUSTER_AGENT_TLS_CLIENT_CERT_FILE"
+	DDClusterAgentTLSClientKeyFile           = "DD_CLUSTER_AGENT_TLS_CLIENT_KEY_FILE"
+	DDClusterTrustChainCACertFilePath        = "DD_CLUSTER_TRUST_CHAIN_CA_CERT_FILE_PATH"
+	DDClusterTrustChainEnableTLSVerification = "DD_CLUSTER_TRUST_CHAIN_ENABLE_TLS_VERIFICATION"
+	DDExternalMetricsProviderConfig          = "DD_EXTERNAL_METRICS_PROVIDER_CONFIG"
+)
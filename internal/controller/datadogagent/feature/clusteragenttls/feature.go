@@ -0,0 +1,354 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package clusteragenttls
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+	componentdca "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/clusteragent"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	certmanager "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
+	"github.com/DataDog/datadog-operator/pkg/defaulting"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/utils"
+)
+
+func init() {
+	err := feature.Register(feature.ClusterAgentTLSIDType, buildClusterAgentTLSFeature)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func buildClusterAgentTLSFeature(options *feature.Options) feature.Feature {
+	clusterAgentTLSFeat := &clusterAgentTLSFeature{}
+
+	if options != nil {
+		clusterAgentTLSFeat.logger = options.Logger
+	}
+
+	return clusterAgentTLSFeat
+}
+
+type clusterAgentTLSFeature struct {
+	owner  metav1.Object
+	logger logr.Logger
+
+	validity               time.Duration
+	certManager            *v2alpha1.CertManagerConfig
+	generation             int64
+	clusterAgentVerified   bool
+	externalMetricsEnabled bool
+}
+
+// ID returns the ID of the Feature
+func (f *clusterAgentTLSFeature) ID() feature.IDType {
+	return feature.ClusterAgentTLSIDType
+}
+
+// Configure is used to configure the feature from a v2alpha1.DatadogAgent instance.
+func (f *clusterAgentTLSFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	f.owner = dda
+	if !isConfigured(dda) {
+		return reqComp
+	}
+	if !IsSupported(dda) {
+		f.logger.Info("Cluster Agent TLS is not supported by the configured images, the Agents keep connecting to the Cluster Agent without it", "minVersion", MinVersion)
+		return reqComp
+	}
+
+	f.validity = CertificateValidity(dda)
+	if certManager := dda.Spec.Global.ClusterAgentTLS.CertManager; certManager != nil && apiutils.BoolValue(certManager.Enabled) {
+		f.certManager = certManager
+	}
+
+	// The generation is updated by the reconciler before the features are configured,
+	// the pods are restarted in the same reconcile as the certificates are renewed.
+	if dda.Status.ClusterAgentTLS != nil {
+		f.generation = dda.Status.ClusterAgentTLS.Generation
+		f.clusterAgentVerified = dda.Status.ClusterAgentTLS.ClusterAgentVerified
+	}
+
+	if em := dda.Spec.Features.ExternalMetricsServer; em != nil && apiutils.BoolValue(em.Enabled) {
+		f.externalMetricsEnabled = true
+	}
+
+	reqComp = feature.RequiredComponents{
+		ClusterAgent: feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+	}
+
+	return reqComp
+}
+
+// ManageDependencies allows a feature to manage its dependencies.
+// Feature's dependencies should be added in the store.
+func (f *clusterAgentTLSFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
+	if !UseCertManager(f.owner.(*v2alpha1.DatadogAgent), managers.Store().GetPlatformInfo()) {
+		// The certificates are issued by the reconciler
+		return nil
+	}
+
+	ns := f.owner.GetNamespace()
+	issuerRef := certmanager.IssuerReference{}
+	if f.certManager.IssuerRef != nil {
+		issuerRef.Name = f.certManager.IssuerRef.Name
+		issuerRef.Kind = f.certManager.IssuerRef.Kind
+		issuerRef.Group = f.certManager.IssuerRef.Group
+	} else {
+		// Self-signed certificate authority issuing the certificates
+		caName := componentdca.GetClusterAgentCASecretName(f.owner)
+		selfSignedName := fmt.Sprintf("%s-selfsigned", caName)
+		if err := managers.CertManagerManager().AddIssuer(selfSignedName, ns, certmanager.IssuerSpec{SelfSigned: &certmanager.SelfSignedIssuer{}}); err != nil {
+			return fmt.Errorf("error adding cluster agent self-signed issuer to store: %w", err)
+		}
+		caCertificate := f.certificateSpec(caName, caName, caValidityFactor*f.validity, certmanager.IssuerReference{Name: selfSignedName, Kind: certmanager.IssuerKind})
+		caCertificate.IsCA = true
+		caCertificate.Usages = []certmanager.KeyUsage{certmanager.UsageCertSign, certmanager.UsageDigitalSignature}
+		if err := managers.CertManagerManager().AddCertificate(caName, ns, caCertificate); err != nil {
+			return fmt.Errorf("error adding cluster agent certificate authority to store: %w", err)
+		}
+		if err := managers.CertManagerManager().AddIssuer(caName, ns, certmanager.IssuerSpec{CA: &certmanager.CAIssuer{SecretName: caName}}); err != nil {
+			return fmt.Errorf("error adding cluster agent certificate authority issuer to store: %w", err)
+		}
+		issuerRef = certmanager.IssuerReference{Name: caName, Kind: certmanager.IssuerKind}
+	}
+
+	serverName := componentdca.GetClusterAgentServerTLSSecretName(f.owner)
+	serverCertificate := f.certificateSpec(serverName, componentdca.GetClusterAgentServiceName(f.owner), f.validity, issuerRef)
+	serverCertificate.DNSNames = componentdca.GetClusterAgentServerDNSNames(f.owner)
+	serverCertificate.Usages = []certmanager.KeyUsage{certmanager.UsageDigitalSignature, certmanager.UsageServerAuth}
+	if err := managers.CertManagerManager().AddCertificate(serverName, ns, serverCertificate); err != nil {
+		return fmt.Errorf("error adding cluster agent serving certificate to store: %w", err)
+	}
+
+	clientName := componentdca.GetClusterAgentClientTLSSecretName(f.owner)
+	clientCertificate := f.certificateSpec(clientName, ClientCommonName, f.validity, issuerRef)
+	clientCertificate.Usages = []certmanager.KeyUsage{certmanager.UsageDigitalSignature, certmanager.UsageClientAuth}
+	if err := managers.CertManagerManager().AddCertificate(clientName, ns, clientCertificate); err != nil {
+		return fmt.Errorf("error adding cluster agent client certificate to store: %w", err)
+	}
+
+	return nil
+}
+
+// certificateSpec returns a certificate renewed once two thirds of its validity elapsed, like the ones issued by the operator
+func (f *clusterAgentTLSFeature) certificateSpec(secretName, commonName string, validity time.Duration, issuerRef certmanager.IssuerReference) certmanager.CertificateSpec {
+	return certmanager.CertificateSpec{
+		SecretName:  secretName,
+		CommonName:  commonName,
+		Duration:    &metav1.Duration{Duration: validity},
+		RenewBefore: &metav1.Duration{Duration: validity / 3},
+		PrivateKey:  &certmanager.CertificatePrivateKey{Algorithm: "ECDSA", Size: 256, RotationPolicy: "Always"},
+		IssuerRef:   issuerRef,
+	}
+}
+
+// ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *clusterAgentTLSFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
+	f.addCertificatesVolume(managers, componentdca.GetClusterAgentServerTLSSecretName(f.owner))
+	managers.VolumeMount().AddVolumeMountToContainer(certificatesVolumeMount(), apicommon.ClusterAgentContainerName)
+
+	managers.EnvVar().AddEnvVarToContainer(apicommon.ClusterAgentContainerName, &corev1.EnvVar{
+		Name:  DDClusterAgentTLSCertFile,
+		Value: filepath.Join(tlsVolumePath, corev1.TLSCertKey),
+	})
+	managers.EnvVar().AddEnvVarToContainer(apicommon.ClusterAgentContainerName, &corev1.EnvVar{
+		Name:  DDClusterAgentTLSKeyFile,
+		Value: filepath.Join(tlsVolumePath, corev1.TLSPrivateKeyKey),
+	})
+	// The client certificates of the Agents are verified with the certificate authority
+	for _, envVar := range trustChainEnvVars() {
+		managers.EnvVar().AddEnvVarToContainer(apicommon.ClusterAgentContainerName, envVar)
+	}
+
+	if f.externalMetricsEnabled {
+		config, err := json.Marshal(map[string]string{
+			"tls-cert-file":        filepath.Join(tlsVolumePath, corev1.TLSCertKey),
+			"tls-private-key-file": filepath.Join(tlsVolumePath, corev1.TLSPrivateKeyKey),
+		})
+		if err != nil {
+			return err
+		}
+		managers.EnvVar().AddEnvVarToContainer(apicommon.ClusterAgentContainerName, &corev1.EnvVar{
+			Name:  DDExternalMetricsProviderConfig,
+			Value: string(config),
+		})
+	}
+
+	return nil
+}
+
+// ManageSingleContainerNodeAgent allows a feature to configure the Agent container for the Node Agent's corev1.PodTemplateSpec
+// if SingleContainerStrategy is enabled and can be used with the configured feature set.
+// It should do nothing if the feature doesn't need to configure it.
+func (f *clusterAgentTLSFeature) ManageSingleContainerNodeAgent(managers feature.PodTemplateManagers, provider string) error {
+	f.manageClient(managers)
+	return nil
+}
+
+// ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *clusterAgentTLSFeature) ManageNodeAgent(managers feature.PodTemplateManagers, provider string) error {
+	f.manageClient(managers)
+	return nil
+}
+
+// ManageClusterChecksRunner allows a feature to configure the ClusterChecksRunner's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *clusterAgentTLSFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
+	f.manageClient(managers)
+	return nil
+}
+
+// manageClient configures all the containers of a pod to connect to the Cluster Agent with the client certificate.
+// The Cluster Agent URL and its verification are only set once the Cluster Agent is verified to serve the issued
+// certificate, the Agents keep their default connection to a Cluster Agent ignoring the certificate settings.
+func (f *clusterAgentTLSFeature) manageClient(managers feature.PodTemplateManagers) {
+	f.addCertificatesVolume(managers, componentdca.GetClusterAgentClientTLSSecretName(f.owner))
+	managers.VolumeMount().AddVolumeMount(certificatesVolumeMount())
+
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  DDClusterAgentTLSClientCertFile,
+		Value: filepath.Join(tlsVolumePath, corev1.TLSCertKey),
+	})
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  DDClusterAgentTLSClientKeyFile,
+		Value: filepath.Join(tlsVolumePath, corev1.TLSPrivateKeyKey),
+	})
+	if !f.clusterAgentVerified {
+		return
+	}
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  DDClusterAgentURL,
+		Value: ClusterAgentURL(f.owner),
+	})
+	for _, envVar := range trustChainEnvVars() {
+		managers.EnvVar().AddEnvVar(envVar)
+	}
+}
+
+// ClusterAgentURL returns the URL of the Cluster Agent covered by its serving certificate.
+// The service IP address set by Kubernetes isn't covered, its DNS name is used instead.
+func ClusterAgentURL(dda metav1.Object) string {
+	return fmt.Sprintf("https://%s.%s.svc:%d", componentdca.GetClusterAgentServiceName(dda), dda.GetNamespace(), common.DefaultClusterAgentServicePort)
+}
+
+// addCertificatesVolume adds the volume of the certificate and the bundle of certificate authorities.
+// The bundle is read from its own secret: cert-manager only sets the current certificate authority in the certificate secrets.
+// The pods are restarted when new certificates are issued, the components only read them at startup.
+func (f *clusterAgentTLSFeature) addCertificatesVolume(managers feature.PodTemplateManagers, secretName string) {
+	managers.Volume().AddVolume(&corev1.Volume{
+		Name: tlsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						Secret: &corev1.SecretProjection{
+							LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+							Items: []corev1.KeyToPath{
+								{Key: corev1.TLSCertKey, Path: corev1.TLSCertKey},
+								{Key: corev1.TLSPrivateKeyKey, Path: corev1.TLSPrivateKeyKey},
+							},
+						},
+					},
+					{
+						Secret: &corev1.SecretProjection{
+							LocalObjectReference: corev1.LocalObjectReference{Name: componentdca.GetClusterAgentCABundleSecretName(f.owner)},
+							Items:                []corev1.KeyToPath{{Key: CACertKey, Path: CACertKey}},
+						},
+					},
+				},
+			},
+		},
+	})
+	managers.Annotation().AddAnnotation(object.GetChecksumAnnotationKey(string(feature.ClusterAgentTLSIDType)), strconv.FormatInt(f.generation, 10))
+}
+
+func certificatesVolumeMount() *corev1.VolumeMount {
+	return &corev1.VolumeMount{
+		Name:      tlsVolumeName,
+		MountPath: tlsVolumePath,
+		ReadOnly:  true,
+	}
+}
+
+func trustChainEnvVars() []*corev1.EnvVar {
+	return []*corev1.EnvVar{
+		{
+			Name:  DDClusterTrustChainCACertFilePath,
+			Value: filepath.Join(tlsVolumePath, CACertKey),
+		},
+		{
+			Name:  DDClusterTrustChainEnableTLSVerification,
+			Value: "true",
+		},
+	}
+}
+
+// IsEnabled returns true if the TLS of the Cluster Agent is enabled and supported by the configured images
+func IsEnabled(dda *v2alpha1.DatadogAgent) bool {
+	return isConfigured(dda) && IsSupported(dda)
+}
+
+func isConfigured(dda *v2alpha1.DatadogAgent) bool {
+	return dda.Spec.Global != nil && dda.Spec.Global.ClusterAgentTLS != nil && apiutils.BoolValue(dda.Spec.Global.ClusterAgentTLS.Enabled)
+}
+
+// IsSupported returns true if the Cluster Agent, the node Agent and the Cluster Checks Runner images support the TLS
+// of the Cluster Agent. Older Agents ignore the client certificate and cannot connect to the Cluster Agent over TLS.
+func IsSupported(dda *v2alpha1.DatadogAgent) bool {
+	for component, defaultVersion := range map[v2alpha1.ComponentName]string{
+		v2alpha1.ClusterAgentComponentName:        defaulting.ClusterAgentLatestVersion,
+		v2alpha1.NodeAgentComponentName:           defaulting.AgentLatestVersion,
+		v2alpha1.ClusterChecksRunnerComponentName: defaulting.AgentLatestVersion,
+	} {
+		version := defaultVersion
+		if override, ok := dda.Spec.Override[component]; ok && override.Image != nil {
+			version = common.GetAgentVersionFromImage(*override.Image)
+		}
+		if !utils.IsAboveMinVersion(version, MinVersion) {
+			return false
+		}
+	}
+	return true
+}
+
+// CertificateValidity returns the validity of the certificates of the Cluster Agent
+func CertificateValidity(dda *v2alpha1.DatadogAgent) time.Duration {
+	if validity := dda.Spec.Global.ClusterAgentTLS.CertificateValidity; validity != nil && validity.Duration > 0 {
+		return validity.Duration
+	}
+	return DefaultCertificateValidity
+}
+
+// CAValidity returns the validity of the certificate authority issued by the operator
+func CAValidity(dda *v2alpha1.DatadogAgent) time.Duration {
+	return caValidityFactor * CertificateValidity(dda)
+}
+
+// UseCertManager returns true if the certificates are issued by cert-manager.
+// It requires the cert-manager CRDs to be installed, the operator issues the certificates otherwise.
+func UseCertManager(dda *v2alpha1.DatadogAgent, platformInfo kubernetes.PlatformInfo) bool {
+	if !IsEnabled(dda) {
+		return false
+	}
+	certManager := dda.Spec.Global.ClusterAgentTLS.CertManager
+	return certManager != nil && apiutils.BoolValue(certManager.Enabled) && platformInfo.IsResourceSupported(certmanager.CertificateKind)
+}
@@ -0,0 +1,259 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package clusteragenttls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/test"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	certmanager "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/testutils"
+)
+
+func TestClusterAgentTLSFeature(t *testing.T) {
+	certManagerPlatformInfo := kubernetes.NewPlatformInfoFromVersionMaps(nil, map[string]string{certmanager.CertificateKind: "cert-manager.io/v1"}, nil)
+
+	tests := test.FeatureTestSuite{
+		{
+			Name: "cluster agent TLS not configured",
+			DDA: testutils.NewInitializedDatadogAgentBuilder("datadog", "foo").
+				Build(),
+			WantConfigure: false,
+		},
+		{
+			Name: "cluster agent TLS disabled",
+			DDA: testutils.NewInitializedDatadogAgentBuilder("datadog", "foo").
+				WithGlobalClusterAgentTLS(&v2alpha1.ClusterAgentTLSConfig{Enabled: apiutils.NewBoolPointer(false)}).
+				Build(),
+			WantConfigure: false,
+		},
+		{
+			Name: "cluster agent TLS enabled, agent image not supported",
+			DDA: testutils.NewInitializedDatadogAgentBuilder("datadog", "foo").
+				WithGlobalClusterAgentTLS(&v2alpha1.ClusterAgentTLSConfig{Enabled: apiutils.NewBoolPointer(true)}).
+				WithComponentOverride(v2alpha1.ClusterAgentComponentName, v2alpha1.DatadogAgentComponentOverride{
+					Image: &v2alpha1.AgentImageConfig{Tag: "7.66.0"},
+				}).
+				WithComponentOverride(v2alpha1.NodeAgentComponentName, v2alpha1.DatadogAgentComponentOverride{
+					Image: &v2alpha1.AgentImageConfig{Tag: "7.62.0"},
+				}).
+				Build(),
+			WantConfigure: false,
+		},
+		{
+			Name: "cluster agent TLS enabled, certificates issued by the operator",
+			DDA: func() *v2alpha1.DatadogAgent {
+				dda := testutils.NewInitializedDatadogAgentBuilder("datadog", "foo").
+					WithGlobalClusterAgentTLS(&v2alpha1.ClusterAgentTLSConfig{Enabled: apiutils.NewBoolPointer(true)}).
+					WithComponentOverride(v2alpha1.ClusterChecksRunnerComponentName, v2alpha1.DatadogAgentComponentOverride{
+						Image: &v2alpha1.AgentImageConfig{Name: "gcr.io/datadoghq/agent:7.66.0-jmx"},
+					}).
+					Build()
+				dda.Status.ClusterAgentTLS = &v2alpha1.ClusterAgentTLSStatus{Generation: 1}
+				return dda
+			}(),
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store store.StoreClient) {
+				_, found := store.Get(kubernetes.CertManagerCertificatesKind, "datadog", "foo-cluster-agent-tls")
+				assert.False(t, found, "the certificates should not be issued by cert-manager")
+			},
+			ClusterAgent:        testClusterAgent("1"),
+			Agent:               testClient("1", false),
+			ClusterChecksRunner: testClient("1", false),
+		},
+		{
+			Name: "cluster agent TLS enabled, cluster agent verified",
+			DDA: func() *v2alpha1.DatadogAgent {
+				dda := testutils.NewInitializedDatadogAgentBuilder("datadog", "foo").
+					WithGlobalClusterAgentTLS(&v2alpha1.ClusterAgentTLSConfig{Enabled: apiutils.NewBoolPointer(true)}).
+					Build()
+				dda.Status.ClusterAgentTLS = &v2alpha1.ClusterAgentTLSStatus{Generation: 1, ClusterAgentVerified: true}
+				return dda
+			}(),
+			WantConfigure:       true,
+			ClusterAgent:        testClusterAgent("1"),
+			Agent:               testClient("1", true),
+			ClusterChecksRunner: testClient("1", true),
+		},
+		{
+			Name: "cluster agent TLS enabled, certificates renewed",
+			DDA: func() *v2alpha1.DatadogAgent {
+				dda := testutils.NewInitializedDatadogAgentBuilder("datadog", "foo").
+					WithGlobalClusterAgentTLS(&v2alpha1.ClusterAgentTLSConfig{Enabled: apiutils.NewBoolPointer(true)}).
+					Build()
+				dda.Status.ClusterAgentTLS = &v2alpha1.ClusterAgentTLSStatus{Generation: 3, ClusterAgentVerified: true}
+				return dda
+			}(),
+			WantConfigure:       true,
+			ClusterAgent:        testClusterAgent("3"),
+			Agent:               testClient("3", true),
+			ClusterChecksRunner: testClient("3", true),
+		},
+		{
+			Name: "cluster agent TLS enabled, cert-manager not installed",
+			DDA: testutils.NewInitializedDatadogAgentBuilder("datadog", "foo").
+				WithGlobalClusterAgentTLS(&v2alpha1.ClusterAgentTLSConfig{
+					Enabled:     apiutils.NewBoolPointer(true),
+					CertManager: &v2alpha1.CertManagerConfig{Enabled: apiutils.NewBoolPointer(true)},
+				}).
+				Build(),
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store store.StoreClient) {
+				_, found := store.Get(kubernetes.CertManagerCertificatesKind, "datadog", "foo-cluster-agent-tls")
+				assert.False(t, found, "the certificates should be issued by the operator")
+			},
+		},
+		{
+			Name: "cluster agent TLS enabled, self-signed cert-manager certificate authority",
+			DDA: testutils.NewInitializedDatadogAgentBuilder("datadog", "foo").
+				WithGlobalClusterAgentTLS(&v2alpha1.ClusterAgentTLSConfig{
+					Enabled:     apiutils.NewBoolPointer(true),
+					CertManager: &v2alpha1.CertManagerConfig{Enabled: apiutils.NewBoolPointer(true)},
+				}).
+				Build(),
+			StoreOption:   &store.StoreOptions{PlatformInfo: certManagerPlatformInfo},
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store store.StoreClient) {
+				for _, name := range []string{"foo-cluster-agent-ca-selfsigned", "foo-cluster-agent-ca"} {
+					_, found := store.Get(kubernetes.CertManagerIssuersKind, "datadog", name)
+					assert.True(t, found, "issuer %s should be created", name)
+				}
+
+				ca := getCertificateSpec(t, store, "foo-cluster-agent-ca")
+				assert.Equal(t, true, ca["isCA"])
+				assert.Equal(t, map[string]interface{}{"name": "foo-cluster-agent-ca-selfsigned", "kind": "Issuer"}, ca["issuerRef"])
+
+				server := getCertificateSpec(t, store, "foo-cluster-agent-tls")
+				assert.Equal(t, "foo-cluster-agent-tls", server["secretName"])
+				assert.Contains(t, server["dnsNames"], "foo-cluster-agent.datadog.svc")
+				assert.Equal(t, map[string]interface{}{"name": "foo-cluster-agent-ca", "kind": "Issuer"}, server["issuerRef"])
+
+				client := getCertificateSpec(t, store, "foo-cluster-agent-client-tls")
+				assert.Equal(t, ClientCommonName, client["commonName"])
+				assert.Equal(t, []interface{}{"digital signature", "client auth"}, client["usages"])
+			},
+		},
+		{
+			Name: "cluster agent TLS enabled, cert-manager cluster issuer",
+			DDA: testutils.NewInitializedDatadogAgentBuilder("datadog", "foo").
+				WithGlobalClusterAgentTLS(&v2alpha1.ClusterAgentTLSConfig{
+					Enabled: apiutils.NewBoolPointer(true),
+					CertManager: &v2alpha1.CertManagerConfig{
+						Enabled:   apiutils.NewBoolPointer(true),
+						IssuerRef: &v2alpha1.CertManagerIssuerReference{Name: "corp-ca", Kind: certmanager.ClusterIssuerKind},
+					},
+				}).
+				Build(),
+			StoreOption:   &store.StoreOptions{PlatformInfo: certManagerPlatformInfo},
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store store.StoreClient) {
+				_, found := store.Get(kubernetes.CertManagerIssuersKind, "datadog", "foo-cluster-agent-ca")
+				assert.False(t, found, "no issuer should be created")
+
+				server := getCertificateSpec(t, store, "foo-cluster-agent-tls")
+				assert.Equal(t, map[string]interface{}{"name": "corp-ca", "kind": "ClusterIssuer"}, server["issuerRef"])
+			},
+		},
+	}
+
+	tests.Run(t, buildClusterAgentTLSFeature)
+}
+
+func getCertificateSpec(t testing.TB, store store.StoreClient, name string) map[string]interface{} {
+	obj, found := store.Get(kubernetes.CertManagerCertificatesKind, "datadog", name)
+	if !assert.True(t, found, "certificate %s should be created", name) {
+		return nil
+	}
+	spec, _, _ := unstructured.NestedMap(obj.(*unstructured.Unstructured).Object, "spec")
+	return spec
+}
+
+func testClusterAgent(generation string) *test.ComponentTest {
+	return test.NewDefaultComponentTest().WithWantFunc(
+		func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+			assertCertificatesVolume(t, mgr, "foo-cluster-agent-tls", generation)
+			assert.Equal(t, []*corev1.VolumeMount{certificatesVolumeMount()}, mgr.VolumeMountMgr.VolumeMountsByC[apicommon.ClusterAgentContainerName])
+
+			wantEnvVars := []*corev1.EnvVar{
+				{Name: DDClusterAgentTLSCertFile, Value: "/etc/datadog-agent/cluster-agent-tls/tls.crt"},
+				{Name: DDClusterAgentTLSKeyFile, Value: "/etc/datadog-agent/cluster-agent-tls/tls.key"},
+				{Name: DDClusterTrustChainCACertFilePath, Value: "/etc/datadog-agent/cluster-agent-tls/ca.crt"},
+				{Name: DDClusterTrustChainEnableTLSVerification, Value: "true"},
+			}
+			assert.ElementsMatch(t, wantEnvVars, mgr.EnvVarMgr.EnvVarsByC[apicommon.ClusterAgentContainerName])
+		},
+	)
+}
+
+func testClient(generation string, clusterAgentVerified bool) *test.ComponentTest {
+	return test.NewDefaultComponentTest().WithWantFunc(
+		func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+			assertCertificatesVolume(t, mgr, "foo-cluster-agent-client-tls", generation)
+			assert.Equal(t, []*corev1.VolumeMount{certificatesVolumeMount()}, mgr.VolumeMountMgr.VolumeMountsByC[apicommon.AllContainers])
+
+			wantEnvVars := []*corev1.EnvVar{
+				{Name: DDClusterAgentTLSClientCertFile, Value: "/etc/datadog-agent/cluster-agent-tls/tls.crt"},
+				{Name: DDClusterAgentTLSClientKeyFile, Value: "/etc/datadog-agent/cluster-agent-tls/tls.key"},
+			}
+			// The Agents keep their default connection until the Cluster Agent serves the issued certificate
+			if clusterAgentVerified {
+				wantEnvVars = append(wantEnvVars,
+					&corev1.EnvVar{Name: DDClusterAgentURL, Value: "https://foo-cluster-agent.datadog.svc:5005"},
+					&corev1.EnvVar{Name: DDClusterTrustChainCACertFilePath, Value: "/etc/datadog-agent/cluster-agent-tls/ca.crt"},
+					&corev1.EnvVar{Name: DDClusterTrustChainEnableTLSVerification, Value: "true"},
+				)
+			}
+			assert.ElementsMatch(t, wantEnvVars, mgr.EnvVarMgr.EnvVarsByC[apicommon.AllContainers])
+		},
+	)
+}
+
+func assertCertificatesVolume(t testing.TB, mgr *fake.PodTemplateManagers, secretName, generation string) {
+	wantVolumes := []*corev1.Volume{
+		{
+			Name: tlsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{
+							Secret: &corev1.SecretProjection{
+								LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+								Items: []corev1.KeyToPath{
+									{Key: "tls.crt", Path: "tls.crt"},
+									{Key: "tls.key", Path: "tls.key"},
+								},
+							},
+						},
+						{
+							Secret: &corev1.SecretProjection{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "foo-cluster-agent-ca-bundle"},
+								Items:                []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, wantVolumes, mgr.VolumeMgr.Volumes)
+	assert.Equal(t, generation, mgr.AnnotationMgr.Annotations[object.GetChecksumAnnotationKey(string(feature.ClusterAgentTLSIDType))])
+}
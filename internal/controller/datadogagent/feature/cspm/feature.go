@@ -51,7 +51,7 @@ type cspmFeature struct {
 	owner  metav1.Object
 	logger logr.Logger
 
-	customConfig                *v2alpha1.CustomConfig
+	customConfig                *apicommon.CustomConfig
 	configMapName               string
 	customConfigAnnotationKey   string
 	customConfigAnnotationValue string
@@ -66,9 +66,6 @@ func (f *cspmFeature) ID() feature.IDType {
 func (f *cspmFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
 	f.owner = dda
 
-	// Merge configuration from Status.RemoteConfigConfiguration into the Spec
-	mergeConfigs(&dda.Spec, &dda.Status)
-
 	cspmConfig := dda.Spec.Features.CSPM
 
 	if cspmConfig != nil && apiutils.BoolValue(cspmConfig.Enabled) {
@@ -110,24 +107,6 @@ func (f *cspmFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.Req
 	return reqComp
 }
 
-func mergeConfigs(ddaSpec *v2alpha1.DatadogAgentSpec, ddaStatus *v2alpha1.DatadogAgentStatus) {
-	if ddaStatus.RemoteConfigConfiguration == nil || ddaStatus.RemoteConfigConfiguration.Features == nil || ddaStatus.RemoteConfigConfiguration.Features.CSPM == nil || ddaStatus.RemoteConfigConfiguration.Features.CSPM.Enabled == nil {
-		return
-	}
-
-	if ddaSpec.Features == nil {
-		ddaSpec.Features = &v2alpha1.DatadogFeatures{}
-	}
-
-	if ddaSpec.Features.CSPM == nil {
-		ddaSpec.Features.CSPM = &v2alpha1.CSPMFeatureConfig{}
-	}
-
-	if ddaStatus.RemoteConfigConfiguration.Features.CSPM.Enabled != nil {
-		ddaSpec.Features.CSPM.Enabled = ddaStatus.RemoteConfigConfiguration.Features.CSPM.Enabled
-	}
-}
-
 // ManageDependencies allows a feature to manage its dependencies.
 // Feature's dependencies should be added in the store.
 func (f *cspmFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
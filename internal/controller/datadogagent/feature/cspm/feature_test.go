@@ -27,8 +27,8 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-var customConfig = &v2alpha1.CustomConfig{
-	ConfigMap: &v2alpha1.ConfigMapConfig{
+var customConfig = &apicommon.CustomConfig{
+	ConfigMap: &apicommon.ConfigMapConfig{
 		Name: "custom_test",
 		Items: []corev1.KeyToPath{
 			{
@@ -52,8 +52,8 @@ func Test_cspmFeature_Configure(t *testing.T) {
 	ddaCSPMEnabled := ddaCSPMDisabled.DeepCopy()
 	{
 		ddaCSPMEnabled.Spec.Features.CSPM.Enabled = apiutils.NewBoolPointer(true)
-		ddaCSPMEnabled.Spec.Features.CSPM.CustomBenchmarks = &v2alpha1.CustomConfig{
-			ConfigMap: &v2alpha1.ConfigMapConfig{
+		ddaCSPMEnabled.Spec.Features.CSPM.CustomBenchmarks = &apicommon.CustomConfig{
+			ConfigMap: &apicommon.ConfigMapConfig{
 				Name: "custom_test",
 				Items: []corev1.KeyToPath{
 					{
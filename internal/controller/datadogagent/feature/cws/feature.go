@@ -52,7 +52,7 @@ type cwsFeature struct {
 	owner  metav1.Object
 	logger logr.Logger
 
-	customConfig                *v2alpha1.CustomConfig
+	customConfig                *apicommon.CustomConfig
 	configMapName               string
 	customConfigAnnotationKey   string
 	customConfigAnnotationValue string
@@ -67,9 +67,6 @@ func (f *cwsFeature) ID() feature.IDType {
 func (f *cwsFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
 	f.owner = dda
 
-	// Merge configuration from Status.RemoteConfigConfiguration into the Spec
-	mergeConfigs(&dda.Spec, &dda.Status)
-
 	cwsConfig := dda.Spec.Features.CWS
 
 	if cwsConfig != nil && apiutils.BoolValue(cwsConfig.Enabled) {
@@ -116,24 +113,6 @@ func (f *cwsFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.Requ
 	return reqComp
 }
 
-func mergeConfigs(ddaSpec *v2alpha1.DatadogAgentSpec, ddaStatus *v2alpha1.DatadogAgentStatus) {
-	if ddaStatus.RemoteConfigConfiguration == nil || ddaStatus.RemoteConfigConfiguration.Features == nil || ddaStatus.RemoteConfigConfiguration.Features.CWS == nil || ddaStatus.RemoteConfigConfiguration.Features.CWS.Enabled == nil {
-		return
-	}
-
-	if ddaSpec.Features == nil {
-		ddaSpec.Features = &v2alpha1.DatadogFeatures{}
-	}
-
-	if ddaSpec.Features.CWS == nil {
-		ddaSpec.Features.CWS = &v2alpha1.CWSFeatureConfig{}
-	}
-
-	if ddaStatus.RemoteConfigConfiguration.Features.CWS.Enabled != nil {
-		ddaSpec.Features.CWS.Enabled = ddaStatus.RemoteConfigConfiguration.Features.CWS.Enabled
-	}
-}
-
 // ManageDependencies allows a feature to manage its dependencies.
 // Feature's dependencies should be added in the store.
 func (f *cwsFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
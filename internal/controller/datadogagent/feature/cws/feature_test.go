@@ -42,8 +42,8 @@ func Test_cwsFeature_Configure(t *testing.T) {
 	ddaCWSLiteEnabled := ddaCWSDisabled.DeepCopy()
 	{
 		ddaCWSLiteEnabled.Spec.Features.CWS.Enabled = apiutils.NewBoolPointer(true)
-		ddaCWSLiteEnabled.Spec.Features.CWS.CustomPolicies = &v2alpha1.CustomConfig{
-			ConfigMap: &v2alpha1.ConfigMapConfig{
+		ddaCWSLiteEnabled.Spec.Features.CWS.CustomPolicies = &apicommon.CustomConfig{
+			ConfigMap: &apicommon.ConfigMapConfig{
 				Name: "custom_test",
 				Items: []corev1.KeyToPath{
 					{
@@ -64,8 +64,8 @@ func Test_cwsFeature_Configure(t *testing.T) {
 		ddaCWSFullEnabled.Spec.Features.CWS.SecurityProfiles = &v2alpha1.CWSSecurityProfilesConfig{
 			Enabled: apiutils.NewBoolPointer(true),
 		}
-		ddaCWSFullEnabled.Spec.Features.CWS.CustomPolicies = &v2alpha1.CustomConfig{
-			ConfigMap: &v2alpha1.ConfigMapConfig{
+		ddaCWSFullEnabled.Spec.Features.CWS.CustomPolicies = &apicommon.CustomConfig{
+			ConfigMap: &apicommon.ConfigMapConfig{
 				Name: "custom_test",
 				Items: []corev1.KeyToPath{
 					{
@@ -343,8 +343,8 @@ func cwsAgentNodeWantFunc(withSubFeatures bool) *test.ComponentTest {
 			assert.True(t, apiutils.IsEqualStruct(volumes, wantVolumes), "Volumes \ndiff = %s", cmp.Diff(volumes, wantVolumes))
 
 			// check annotations
-			customConfig := &v2alpha1.CustomConfig{
-				ConfigMap: &v2alpha1.ConfigMapConfig{
+			customConfig := &apicommon.CustomConfig{
+				ConfigMap: &apicommon.ConfigMapConfig{
 					Name: "custom_test",
 					Items: []corev1.KeyToPath{
 						{
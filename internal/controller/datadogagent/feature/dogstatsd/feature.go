@@ -47,7 +47,7 @@ type dogstatsdFeature struct {
 	useHostNetwork         bool
 	originDetectionEnabled bool
 	tagCardinality         string
-	mapperProfiles         *v2alpha1.CustomConfig
+	mapperProfiles         *apicommon.CustomConfig
 
 	forceEnableLocalService bool
 	localServiceName        string
@@ -26,7 +26,7 @@ func Test_ebpfCheckFeature_Configure(t *testing.T) {
 	ddaEBPFCheckDisabled := v2alpha1.DatadogAgent{
 		Spec: v2alpha1.DatadogAgentSpec{
 			Features: &v2alpha1.DatadogFeatures{
-				EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+				EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 					Enabled: apiutils.NewBoolPointer(false),
 				},
 			},
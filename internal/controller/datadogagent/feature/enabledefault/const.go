@@ -5,8 +5,19 @@
 
 package enabledefault
 
+import "time"
+
 const (
 	DDAgentDaemonSet             = "AGENT_DAEMONSET"
 	DDClusterAgentDeployment     = "CLUSTER_AGENT_DEPLOYMENT"
 	DDDatadogAgentCustomResource = "DATADOGAGENT_CR_NAME"
+
+	// AdditionalTokenKey is the key of the token secret holding the token accepted by the Cluster Agent during a rotation
+	AdditionalTokenKey = "additional-token"
+	// MinAdditionalAuthTokenVersion is the minimum Cluster Agent version accepting an additional token,
+	// required to rotate the token without interrupting the connections of the Agents
+	MinAdditionalAuthTokenVersion = "7.63.0-0"
+
+	// DefaultTokenRotationInterval is the default time between two rotations of the generated Cluster Agent token
+	DefaultTokenRotationInterval = 30 * 24 * time.Hour
 )
@@ -6,8 +6,11 @@
 package enabledefault
 
 const (
-	DDClusterAgentAuthToken          = "DD_CLUSTER_AGENT_AUTH_TOKEN"
-	DDClusterAgentServiceAccountName = "DD_CLUSTER_AGENT_SERVICE_ACCOUNT_NAME"
+	DDClusterAgentAuthToken = "DD_CLUSTER_AGENT_AUTH_TOKEN"
+	// DDClusterAgentAdditionalAuthToken is a token accepted by the Cluster Agent along with its own token,
+	// supported since MinAdditionalAuthTokenVersion
+	DDClusterAgentAdditionalAuthToken = "DD_CLUSTER_AGENT_ADDITIONAL_AUTH_TOKEN"
+	DDClusterAgentServiceAccountName  = "DD_CLUSTER_AGENT_SERVICE_ACCOUNT_NAME"
 
 	// InstallInfoToolVersion is used by the Operator to override the tool
 	// version value in the Agent's install info
@@ -87,6 +87,11 @@ type credentialsInfo struct {
 type dcaTokenInfo struct {
 	token          keyInfo
 	secretCreation secretInfo
+	// additionalTokenHash is the hash of the token also accepted by the Cluster Agent during a rotation of the generated token,
+	// the token is added to the token secret by the reconciler
+	additionalTokenHash string
+	// clusterAgentAnnotationValue is the checksum of the tokens accepted by the Cluster Agent
+	clusterAgentAnnotationValue string
 }
 
 type keyInfo struct {
@@ -190,6 +195,7 @@ func (f *defaultFeature) Configure(dda *v2alpha1.DatadogAgent) feature.RequiredC
 			} else {
 				f.dcaTokenInfo.secretCreation.data[common.DefaultTokenKey] = dda.Status.ClusterAgent.GeneratedToken
 			}
+			f.dcaTokenInfo.additionalTokenHash = additionalClusterAgentTokenHash(dda.Status.ClusterAgentToken)
 		}
 
 		f.kubernetesResourcesLabelsAsTags = dda.Spec.Global.KubernetesResourcesLabelsAsTags
@@ -204,6 +210,16 @@ func (f *defaultFeature) Configure(dda *v2alpha1.DatadogAgent) feature.RequiredC
 		}
 		f.customConfigAnnotationValue = hash
 		f.customConfigAnnotationKey = object.GetChecksumAnnotationKey(string(feature.DefaultIDType))
+
+		// The Cluster Agent is restarted on its own when it starts or stops accepting an additional token
+		f.dcaTokenInfo.clusterAgentAnnotationValue = f.customConfigAnnotationValue
+		if f.dcaTokenInfo.additionalTokenHash != "" {
+			hash, err = ClusterAgentTokenChecksum(f.dcaTokenInfo.secretCreation.data[common.DefaultTokenKey], f.dcaTokenInfo.additionalTokenHash)
+			if err != nil {
+				f.logger.Error(err, "couldn't generate hash for Cluster Agent tokens")
+			}
+			f.dcaTokenInfo.clusterAgentAnnotationValue = hash
+		}
 	}
 
 	agentContainers := make([]apicommon.AgentContainerName, 0)
@@ -389,8 +405,12 @@ func (f *defaultFeature) clusterChecksRunnerDependencies(managers feature.Resour
 // It should do nothing if the feature doesn't need to configure it.
 func (f *defaultFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
 	f.addDefaultCommonEnvs(managers)
-	if f.customConfigAnnotationKey != "" && f.customConfigAnnotationValue != "" {
-		managers.Annotation().AddAnnotation(f.customConfigAnnotationKey, f.customConfigAnnotationValue)
+	if f.customConfigAnnotationKey != "" && f.dcaTokenInfo.clusterAgentAnnotationValue != "" {
+		managers.Annotation().AddAnnotation(f.customConfigAnnotationKey, f.dcaTokenInfo.clusterAgentAnnotationValue)
+	}
+	if f.dcaTokenInfo.additionalTokenHash != "" {
+		additionalTokenEnvVar := common.BuildEnvVarFromSource(DDClusterAgentAdditionalAuthToken, common.BuildEnvVarFromSecret(f.dcaTokenInfo.token.SecretName, AdditionalTokenKey))
+		managers.EnvVar().AddEnvVarToContainer(apicommon.ClusterAgentContainerName, additionalTokenEnvVar)
 	}
 	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
 		Name:  DDClusterAgentServiceAccountName,
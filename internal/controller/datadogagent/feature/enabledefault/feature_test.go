@@ -16,11 +16,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/fake"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/test"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 	"github.com/DataDog/datadog-operator/pkg/testutils"
 )
 
@@ -142,6 +147,119 @@ func Test_defaultFeature_ADP(t *testing.T) {
 	tests.Run(t, buildDefaultFeature)
 }
 
+func Test_defaultFeature_ClusterAgentTokenRotation(t *testing.T) {
+	newDDA := func(tokenStatus *v2alpha1.ClusterAgentTokenStatus) *v2alpha1.DatadogAgent {
+		dda := testutils.NewInitializedDatadogAgentBuilder("default", "datadog").Build()
+		dda.Status.ClusterAgent = &v2alpha1.DeploymentStatus{GeneratedToken: "current"}
+		dda.Status.ClusterAgentToken = tokenStatus
+		return dda
+	}
+	requiredComponents := feature.RequiredComponents{
+		ClusterAgent: feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+	}
+	additionalTokenEnvVar := &corev1.EnvVar{
+		Name: DDClusterAgentAdditionalAuthToken,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "datadog-token"},
+				Key:                  AdditionalTokenKey,
+			},
+		},
+	}
+	currentChecksum, _ := ClientTokenChecksum("current")
+	rotationChecksum, _ := ClusterAgentTokenChecksum("current", TokenHash("other"))
+
+	// wantTokens checks the token of the secret and the checksum annotations of the pods.
+	// The additional token is added to the secret by the reconciler.
+	wantTokens := func(rotating bool, clusterAgentChecksum string) (func(testing.TB, store.StoreClient), *test.ComponentTest, *test.ComponentTest) {
+		wantSecret := func(t testing.TB, store store.StoreClient) {
+			obj, found := store.Get(kubernetes.SecretsKind, "default", "datadog-token")
+			if !assert.True(t, found, "token secret should be created") {
+				return
+			}
+			secret := obj.(*corev1.Secret)
+			assert.Equal(t, "current", string(secret.Data[common.DefaultTokenKey]))
+			assert.NotContains(t, secret.Data, AdditionalTokenKey)
+		}
+		clusterAgent := test.NewDefaultComponentTest().WithWantFunc(func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+			assert.Equal(t, clusterAgentChecksum, mgr.AnnotationMgr.Annotations[object.GetChecksumAnnotationKey(string(feature.DefaultIDType))])
+			if rotating {
+				assert.Contains(t, mgr.EnvVarMgr.EnvVarsByC[apicommon.ClusterAgentContainerName], additionalTokenEnvVar)
+			} else {
+				assert.NotContains(t, mgr.EnvVarMgr.EnvVarsByC[apicommon.ClusterAgentContainerName], additionalTokenEnvVar)
+			}
+		})
+		agent := test.NewDefaultComponentTest().WithWantFunc(func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+			assert.Equal(t, currentChecksum, mgr.AnnotationMgr.Annotations[object.GetChecksumAnnotationKey(string(feature.DefaultIDType))])
+		})
+		return wantSecret, clusterAgent, agent
+	}
+
+	noRotationSecret, noRotationClusterAgent, noRotationAgent := wantTokens(false, currentChecksum)
+	clusterAgentRolloutSecret, clusterAgentRolloutClusterAgent, clusterAgentRolloutAgent := wantTokens(true, rotationChecksum)
+	agentRolloutSecret, agentRolloutClusterAgent, agentRolloutAgent := wantTokens(true, rotationChecksum)
+
+	tests := test.FeatureTestSuite{
+		{
+			Name:                 "no rotation in progress",
+			DDA:                  newDDA(&v2alpha1.ClusterAgentTokenStatus{Generation: 1}),
+			RequiredComponents:   requiredComponents,
+			WantConfigure:        true,
+			WantDependenciesFunc: noRotationSecret,
+			ClusterAgent:         noRotationClusterAgent,
+			Agent:                noRotationAgent,
+			ClusterChecksRunner:  noRotationAgent,
+		},
+		{
+			Name: "cluster agent accepting the pending token",
+			DDA: newDDA(&v2alpha1.ClusterAgentTokenStatus{
+				Generation:          1,
+				Phase:               v2alpha1.ClusterAgentTokenRotationPhaseClusterAgentRollout,
+				AdditionalTokenHash: TokenHash("other"),
+			}),
+			RequiredComponents:   requiredComponents,
+			WantConfigure:        true,
+			WantDependenciesFunc: clusterAgentRolloutSecret,
+			ClusterAgent:         clusterAgentRolloutClusterAgent,
+			Agent:                clusterAgentRolloutAgent,
+			ClusterChecksRunner:  clusterAgentRolloutAgent,
+		},
+		{
+			Name: "agents switched to the new token, cluster agent still accepting the previous token",
+			DDA: newDDA(&v2alpha1.ClusterAgentTokenStatus{
+				Generation:          2,
+				Phase:               v2alpha1.ClusterAgentTokenRotationPhaseAgentRollout,
+				AdditionalTokenHash: TokenHash("other"),
+			}),
+			RequiredComponents:   requiredComponents,
+			WantConfigure:        true,
+			WantDependenciesFunc: agentRolloutSecret,
+			ClusterAgent:         agentRolloutClusterAgent,
+			Agent:                agentRolloutAgent,
+			ClusterChecksRunner:  agentRolloutAgent,
+		},
+	}
+
+	tests.Run(t, buildDefaultFeature)
+}
+
+func Test_ClusterAgentTokenChecksum(t *testing.T) {
+	rotationChecksum, err := ClusterAgentTokenChecksum("old", TokenHash("new"))
+	assert.NoError(t, err)
+	switchedChecksum, err := ClusterAgentTokenChecksum("new", TokenHash("old"))
+	assert.NoError(t, err)
+	assert.Equal(t, rotationChecksum, switchedChecksum, "the cluster agent should not be restarted when the agents switch tokens")
+
+	clientChecksum, err := ClientTokenChecksum("new")
+	assert.NoError(t, err)
+	retiredChecksum, err := ClusterAgentTokenChecksum("new", "")
+	assert.NoError(t, err)
+	assert.Equal(t, clientChecksum, retiredChecksum)
+	assert.NotEqual(t, rotationChecksum, retiredChecksum)
+}
+
 func defaultFeatureManageClusterAgentWantFunc(t testing.TB, mgrInterface feature.PodTemplateManagers) {
 	mgr := mgrInterface.(*fake.PodTemplateManagers)
 	dcaEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommon.AllContainers]
@@ -171,3 +289,35 @@ func defaultFeatureManageClusterAgentWantFunc(t testing.TB, mgrInterface feature
 	}
 	t.Fatalf("Service account name missing in DCA envvars")
 }
+
+func Test_SupportsAdditionalAuthToken(t *testing.T) {
+	tests := []struct {
+		name  string
+		image *v2alpha1.AgentImageConfig
+		want  bool
+	}{
+		{
+			name: "default image",
+			want: true,
+		},
+		{
+			name:  "supported image",
+			image: &v2alpha1.AgentImageConfig{Tag: "7.64.1"},
+			want:  true,
+		},
+		{
+			name:  "older image",
+			image: &v2alpha1.AgentImageConfig{Name: "gcr.io/datadoghq/cluster-agent:7.62.0"},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := testutils.NewInitializedDatadogAgentBuilder("default", "datadog")
+			if tt.image != nil {
+				builder = builder.WithComponentOverride(v2alpha1.ClusterAgentComponentName, v2alpha1.DatadogAgentComponentOverride{Image: tt.image})
+			}
+			assert.Equal(t, tt.want, SupportsAdditionalAuthToken(builder.Build()))
+		})
+	}
+}
@@ -6,9 +6,19 @@
 package enabledefault
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"time"
+
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	componentagent "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/agent"
 	componentdca "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/clusteragent"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/defaulting"
+	"github.com/DataDog/datadog-operator/pkg/utils"
 )
 
 // getDaemonSetNameFromDatadogAgent returns the expected node Agent DS/EDS name based on
@@ -34,3 +44,57 @@ func getDeploymentNameFromDatadogAgent(dda *v2alpha1.DatadogAgent) string {
 	}
 	return deployName
 }
+
+// additionalClusterAgentTokenHash returns the hash of the token accepted by the Cluster Agent along with the current one
+// during a rotation of the generated token
+func additionalClusterAgentTokenHash(status *v2alpha1.ClusterAgentTokenStatus) string {
+	if status == nil || status.Phase == "" {
+		return ""
+	}
+	return status.AdditionalTokenHash
+}
+
+// TokenHash returns the hash of a token reported in the status instead of the token
+func TokenHash(token string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(token)))
+}
+
+// ClientTokenChecksum returns the checksum annotation of the pods using the generated Cluster Agent token
+func ClientTokenChecksum(token string) (string, error) {
+	return comparison.GenerateMD5ForSpec(map[string]string{common.DefaultTokenKey: token})
+}
+
+// ClusterAgentTokenChecksum returns the checksum annotation of the Cluster Agent pods accepting the tokens.
+// It doesn't depend on which token is the current one, the Cluster Agent isn't restarted when the Agents switch tokens.
+func ClusterAgentTokenChecksum(token, additionalTokenHash string) (string, error) {
+	if additionalTokenHash == "" {
+		return ClientTokenChecksum(token)
+	}
+	return comparison.GenerateMD5ForSpec(slices.Sorted(slices.Values([]string{TokenHash(token), additionalTokenHash})))
+}
+
+// SupportsAdditionalAuthToken returns true if the Cluster Agent image accepts an additional token.
+// An older Cluster Agent ignores it and rejects the Agents switching to the new token.
+func SupportsAdditionalAuthToken(dda *v2alpha1.DatadogAgent) bool {
+	if clusterAgent, ok := dda.Spec.Override[v2alpha1.ClusterAgentComponentName]; ok {
+		if clusterAgent.Image != nil {
+			return utils.IsAboveMinVersion(common.GetAgentVersionFromImage(*clusterAgent.Image), MinAdditionalAuthTokenVersion)
+		}
+	}
+	return utils.IsAboveMinVersion(defaulting.ClusterAgentLatestVersion, MinAdditionalAuthTokenVersion)
+}
+
+// IsTokenRotationEnabled returns true if the generated Cluster Agent token is rotated periodically
+func IsTokenRotationEnabled(dda *v2alpha1.DatadogAgent) bool {
+	global := dda.Spec.Global
+	return global != nil && global.ClusterAgentToken == nil && global.ClusterAgentTokenSecret == nil &&
+		global.ClusterAgentTokenRotation != nil && apiutils.BoolValue(global.ClusterAgentTokenRotation.Enabled)
+}
+
+// TokenRotationInterval returns the time between two rotations of the generated Cluster Agent token
+func TokenRotationInterval(dda *v2alpha1.DatadogAgent) time.Duration {
+	if interval := dda.Spec.Global.ClusterAgentTokenRotation.Interval; interval != nil && interval.Duration > 0 {
+		return interval.Duration
+	}
+	return DefaultTokenRotationInterval
+}
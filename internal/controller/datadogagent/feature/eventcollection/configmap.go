@@ -13,8 +13,20 @@ import (
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 )
 
-func buildDefaultConfigMap(namespace, name string, unbundleEvents bool, collectedEventTypes []v2alpha1.EventTypes) (*corev1.ConfigMap, error) {
-	content, err := kubeAPIServerCheckConfig(unbundleEvents, collectedEventTypes)
+// kubeAPIServerCheckOptions contains the options of the kubernetes_apiserver check instance
+type kubeAPIServerCheckOptions struct {
+	unbundleEvents      bool
+	collectedEventTypes []v2alpha1.EventTypes
+	filter              *v2alpha1.EventFilter
+	maxEventsPerRun     *int32
+}
+
+func (o *kubeAPIServerCheckOptions) isDefault() bool {
+	return !o.unbundleEvents && len(filteredEventTypes(o.filter)) == 0 && len(filterTags(o.filter)) == 0 && o.maxEventsPerRun == nil
+}
+
+func buildDefaultConfigMap(namespace, name string, options *kubeAPIServerCheckOptions) (*corev1.ConfigMap, error) {
+	content, err := kubeAPIServerCheckConfig(options)
 	if err != nil {
 		return nil, err
 	}
@@ -30,17 +42,58 @@ func buildDefaultConfigMap(namespace, name string, unbundleEvents bool, collecte
 	}, nil
 }
 
-func kubeAPIServerCheckConfig(unbundleEvents bool, collectedEventTypes []v2alpha1.EventTypes) (string, error) {
+func kubeAPIServerCheckConfig(options *kubeAPIServerCheckOptions) (string, error) {
+	instance := map[string]any{
+		"unbundle_events": options.unbundleEvents,
+	}
+	if len(options.collectedEventTypes) > 0 {
+		instance["collected_event_types"] = options.collectedEventTypes
+	}
+	if options.maxEventsPerRun != nil {
+		instance["max_events_per_run"] = *options.maxEventsPerRun
+	}
+	if fieldSelectors := filteredEventTypes(options.filter); len(fieldSelectors) > 0 {
+		instance["filtered_event_types"] = fieldSelectors
+	}
+	if tags := filterTags(options.filter); len(tags) > 0 {
+		instance["tags"] = tags
+	}
+
 	cm := map[string]any{
 		"init_config": nil,
-		"instances": []map[string]any{
-			{
-				"unbundle_events":       unbundleEvents,
-				"collected_event_types": collectedEventTypes,
-			},
-		},
+		"instances":   []map[string]any{instance},
 	}
 
 	b, err := yaml.Marshal(cm)
 	return string(b), err
 }
+
+// filteredEventTypes returns the field selectors of the `filtered_event_types` option equivalent to the filter.
+// The check combines them, so they must all match.
+func filteredEventTypes(filter *v2alpha1.EventFilter) []string {
+	if filter == nil {
+		return nil
+	}
+
+	var fieldSelectors []string
+	if filter.Namespace != nil && *filter.Namespace != "" {
+		fieldSelectors = append(fieldSelectors, "involvedObject.namespace=="+*filter.Namespace)
+	}
+	for _, namespace := range filter.ExcludedNamespaces {
+		fieldSelectors = append(fieldSelectors, "involvedObject.namespace!="+namespace)
+	}
+	if filter.Type != nil {
+		fieldSelectors = append(fieldSelectors, "type=="+string(*filter.Type))
+	}
+
+	return fieldSelectors
+}
+
+// filterTags returns the tags of the check instance, they are added to all the
+// events it collects.
+func filterTags(filter *v2alpha1.EventFilter) []string {
+	if filter == nil {
+		return nil
+	}
+	return filter.Tags
+}
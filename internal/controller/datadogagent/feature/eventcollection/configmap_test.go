@@ -0,0 +1,103 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package eventcollection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+func Test_kubeAPIServerCheckConfig(t *testing.T) {
+	normal := v2alpha1.EventTypeNormal
+	tests := []struct {
+		name        string
+		options     kubeAPIServerCheckOptions
+		wantDefault bool
+		want        string
+	}{
+		{
+			name:        "default",
+			wantDefault: true,
+			want: `init_config: null
+instances:
+- unbundle_events: false
+`,
+		},
+		{
+			name:        "empty filter",
+			options:     kubeAPIServerCheckOptions{filter: &v2alpha1.EventFilter{}},
+			wantDefault: true,
+			want: `init_config: null
+instances:
+- unbundle_events: false
+`,
+		},
+		{
+			name:    "included namespace",
+			options: kubeAPIServerCheckOptions{filter: &v2alpha1.EventFilter{Namespace: apiutils.NewStringPointer("prod")}},
+			want: `init_config: null
+instances:
+- filtered_event_types:
+  - involvedObject.namespace==prod
+  unbundle_events: false
+`,
+		},
+		{
+			name:    "excluded namespaces",
+			options: kubeAPIServerCheckOptions{filter: &v2alpha1.EventFilter{ExcludedNamespaces: []string{"kube-system", "sandbox"}}},
+			want: `init_config: null
+instances:
+- filtered_event_types:
+  - involvedObject.namespace!=kube-system
+  - involvedObject.namespace!=sandbox
+  unbundle_events: false
+`,
+		},
+		{
+			name:    "event type",
+			options: kubeAPIServerCheckOptions{filter: &v2alpha1.EventFilter{Type: &normal}},
+			want: `init_config: null
+instances:
+- filtered_event_types:
+  - type==Normal
+  unbundle_events: false
+`,
+		},
+		{
+			name:    "tags",
+			options: kubeAPIServerCheckOptions{filter: &v2alpha1.EventFilter{Tags: []string{"team:core", "env:prod"}}},
+			want: `init_config: null
+instances:
+- tags:
+  - team:core
+  - env:prod
+  unbundle_events: false
+`,
+		},
+		{
+			name:    "rate limit",
+			options: kubeAPIServerCheckOptions{maxEventsPerRun: apiutils.NewInt32Pointer(50)},
+			want: `init_config: null
+instances:
+- max_events_per_run: 50
+  unbundle_events: false
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantDefault, tt.options.isDefault())
+			got, err := kubeAPIServerCheckConfig(&tt.options)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -48,9 +48,8 @@ type eventCollectionFeature struct {
 	rbacSuffix         string
 	owner              metav1.Object
 
-	configMapName      string
-	unbundleEvents     bool
-	unbundleEventTypes []v2alpha1.EventTypes
+	configMapName string
+	checkOptions  kubeAPIServerCheckOptions
 
 	cmAnnotationKey   string
 	cmAnnotationValue string
@@ -73,15 +72,21 @@ func (f *eventCollectionFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp
 		f.serviceAccountName = constants.GetClusterAgentServiceAccount(dda)
 		f.rbacSuffix = common.ClusterAgentSuffix
 
-		if apiutils.BoolValue(dda.Spec.Features.EventCollection.UnbundleEvents) {
-			if len(dda.Spec.Features.EventCollection.CollectedEventTypes) > 0 {
-				f.configMapName = constants.GetConfName(dda, nil, defaultKubeAPIServerConf)
-				f.unbundleEvents = *dda.Spec.Features.EventCollection.UnbundleEvents
-				f.unbundleEventTypes = dda.Spec.Features.EventCollection.CollectedEventTypes
+		eventCollection := dda.Spec.Features.EventCollection
+		if apiutils.BoolValue(eventCollection.UnbundleEvents) {
+			if len(eventCollection.CollectedEventTypes) > 0 {
+				f.checkOptions.unbundleEvents = *eventCollection.UnbundleEvents
+				f.checkOptions.collectedEventTypes = eventCollection.CollectedEventTypes
 			} else {
 				f.logger.Info("UnbundleEvents is enabled but no CollectedEventTypes are specified, disabling unbundleEvents")
 			}
 		}
+		f.checkOptions.filter = eventCollection.Filter
+		f.checkOptions.maxEventsPerRun = eventCollection.MaxEventsPerRun
+
+		if !f.checkOptions.isDefault() {
+			f.configMapName = constants.GetConfName(dda, nil, defaultKubeAPIServerConf)
+		}
 
 		reqComp = feature.RequiredComponents{
 			ClusterAgent: feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
@@ -114,7 +119,7 @@ func (f *eventCollectionFeature) ManageDependencies(managers feature.ResourceMan
 
 	if f.configMapName != "" {
 		// creating ConfigMap for event collection if required
-		cm, err := buildDefaultConfigMap(f.owner.GetNamespace(), f.configMapName, f.unbundleEvents, f.unbundleEventTypes)
+		cm, err := buildDefaultConfigMap(f.owner.GetNamespace(), f.configMapName, &f.checkOptions)
 		if err != nil {
 			return err
 		}
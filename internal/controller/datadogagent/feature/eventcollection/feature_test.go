@@ -26,6 +26,7 @@ import (
 )
 
 func Test_eventCollectionFeature_Configure(t *testing.T) {
+	warningEventType := v2alpha1.EventTypeWarning
 	tests := test.FeatureTestSuite{
 		{
 			Name: "Event Collection not enabled",
@@ -63,6 +64,20 @@ func Test_eventCollectionFeature_Configure(t *testing.T) {
 			ClusterAgent:         test.NewDefaultComponentTest().WithWantFunc(unbundledEventsClusterAgentWantFunc),
 			WantDependenciesFunc: unbundledEventsDependencies,
 		},
+		{
+			Name: "Event filters",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithName("ddaDCA").
+				WithEventCollectionKubernetesEvents(true).
+				WithEventCollectionFilter(&v2alpha1.EventFilter{
+					ExcludedNamespaces: []string{"kube-system", "prod-sandbox"},
+					Type:               &warningEventType,
+				}, apiutils.NewInt32Pointer(100)).
+				Build(),
+			WantConfigure:        true,
+			ClusterAgent:         test.NewDefaultComponentTest().WithWantFunc(unbundledEventsClusterAgentWantFunc),
+			WantDependenciesFunc: eventFiltersDependencies,
+		},
 	}
 
 	tests.Run(t, buildEventCollectionFeature)
@@ -151,3 +166,29 @@ func unbundledEventsClusterAgentWantFunc(t testing.TB, mgrInterface feature.PodT
 	}
 	assert.True(t, apiutils.IsEqualStruct(volumeMounts, expectedVolumeMounts), "DCA volume mounts \ndiff = %s", cmp.Diff(volumeMounts, expectedVolumeMounts))
 }
+
+func eventFiltersDependencies(t testing.TB, store store.StoreClient) {
+	obj, found := store.Get(kubernetes.ConfigMapKind, "", "ddaDCA-kube-apiserver-config")
+	if !found {
+		t.Error("Should have created check ConfigMap")
+	} else {
+		cm := obj.(*corev1.ConfigMap)
+		expectedCM := map[string]string{
+			"kubernetes_apiserver.yaml": `init_config: null
+instances:
+- filtered_event_types:
+  - involvedObject.namespace!=kube-system
+  - involvedObject.namespace!=prod-sandbox
+  - type==Warning
+  max_events_per_run: 100
+  unbundle_events: false
+`,
+		}
+
+		assert.True(
+			t,
+			apiutils.IsEqualStruct(cm.Data, expectedCM),
+			"ConfigMap \ndiff = %s", cmp.Diff(cm.Data, expectedCM),
+		)
+	}
+}
@@ -23,7 +23,7 @@ func Test_GPUMonitoringFeature_Configure(t *testing.T) {
 	ddaGPUMonitoringDisabled := v2alpha1.DatadogAgent{
 		Spec: v2alpha1.DatadogAgentSpec{
 			Features: &v2alpha1.DatadogFeatures{
-				GPU: &v2alpha1.GPUFeatureConfig{
+				GPU: &apicommon.GPUFeatureConfig{
 					Enabled: apiutils.NewBoolPointer(false),
 				},
 			},
@@ -6,16 +6,59 @@
 package helmcheck
 
 import (
-	"fmt"
-	"sort"
-	"strconv"
-
+	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// helmCheckConfigFile is the Helm check configuration file
+type helmCheckConfigFile struct {
+	ClusterCheck bool                `yaml:"cluster_check"`
+	InitConfig   any                 `yaml:"init_config"`
+	Instances    []helmCheckInstance `yaml:"instances"`
+}
+
+// helmCheckInstance contains the options of the helm check instance.
+// The chart annotations, release filters and storage driver options are not
+// implemented by the Agent Helm check as of Agent 7.63, which ignores them.
+type helmCheckInstance struct {
+	CollectEvents              bool              `yaml:"collect_events"`
+	HelmValuesAsTags           map[string]string `yaml:"helm_values_as_tags,omitempty"`
+	HelmChartAnnotationsAsTags map[string]string `yaml:"helm_chart_annotations_as_tags,omitempty"`
+	Namespaces                 []string          `yaml:"namespaces,omitempty"`
+	ExcludedNamespaces         []string          `yaml:"excluded_namespaces,omitempty"`
+	Releases                   []string          `yaml:"releases,omitempty"`
+	ExcludedReleases           []string          `yaml:"excluded_releases,omitempty"`
+	StorageDriver              string            `yaml:"storage_driver,omitempty"`
+}
+
+func (f *helmCheckFeature) checkInstance() helmCheckInstance {
+	instance := helmCheckInstance{
+		CollectEvents:              f.collectEvents,
+		HelmValuesAsTags:           f.valuesAsTags,
+		HelmChartAnnotationsAsTags: f.chartAnnotationsAsTags,
+	}
+	if f.namespaces != nil {
+		instance.Namespaces = f.namespaces.Include
+		instance.ExcludedNamespaces = f.namespaces.Exclude
+	}
+	if f.releases != nil {
+		instance.Releases = f.releases.Include
+		instance.ExcludedReleases = f.releases.Exclude
+	}
+	if f.storageDriver != nil {
+		instance.StorageDriver = string(*f.storageDriver)
+	}
+
+	return instance
+}
+
 func (f *helmCheckFeature) buildHelmCheckConfigMap() (*corev1.ConfigMap, error) {
-	configMap := buildDefaultConfigMap(f.owner.GetNamespace(), f.configMapName, helmCheckConfig(f.runInClusterChecksRunner, f.collectEvents, f.valuesAsTags))
+	config, err := helmCheckConfig(f.runInClusterChecksRunner, f.checkInstance())
+	if err != nil {
+		return nil, err
+	}
+	configMap := buildDefaultConfigMap(f.owner.GetNamespace(), f.configMapName, config)
 	return configMap, nil
 }
 
@@ -38,35 +81,12 @@ func buildDefaultConfigMap(namespace, cmName string, content string) *corev1.Con
 // cluster checks are enabled but without Cluster Check Runners, we don't want
 // to set this check as a cluster check, because then it would be scheduled in
 // the DaemonSet agent instead of the DCA.
-func helmCheckConfig(clusterCheck bool, collectEvents bool, valuesAsTags map[string]string) string {
-	clusterChecksVal := strconv.FormatBool(clusterCheck)
-	collectEventsVal := strconv.FormatBool(collectEvents)
-	sortedTagsKeys := sortTagsKeys(valuesAsTags)
-	config := fmt.Sprintf(`---
-cluster_check: %s
-init_config:
-instances:
-  - collect_events: %s
-`, clusterChecksVal, collectEventsVal)
-
-	if len(valuesAsTags) > 0 {
-		config += "    helm_values_as_tags:\n"
-		for _, key := range sortedTagsKeys {
-			config += fmt.Sprintf("      %s: %s\n", key, valuesAsTags[key])
-		}
-	}
-
-	return config
-}
-
-func sortTagsKeys(tags map[string]string) []string {
-	keys := make([]string, 0, len(tags))
-
-	for k := range tags {
-		keys = append(keys, k)
+func helmCheckConfig(clusterCheck bool, instance helmCheckInstance) (string, error) {
+	config := helmCheckConfigFile{
+		ClusterCheck: clusterCheck,
+		Instances:    []helmCheckInstance{instance},
 	}
 
-	sort.Strings(keys)
-
-	return keys
+	b, err := yaml.Marshal(config)
+	return string(b), err
 }
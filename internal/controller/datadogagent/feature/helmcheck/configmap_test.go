@@ -11,6 +11,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/pkg/equality"
 )
 
@@ -28,8 +30,12 @@ func Test_buildHelmCheckConfigMap(t *testing.T) {
 		owner                    metav1.Object
 		configMapName            string
 
-		collectEvents bool
-		valuesAsTags  map[string]string
+		collectEvents          bool
+		valuesAsTags           map[string]string
+		chartAnnotationsAsTags map[string]string
+		namespaces             *v2alpha1.HelmCheckFilter
+		releases               *v2alpha1.HelmCheckFilter
+		storageDriver          *v2alpha1.HelmStorageDriver
 	}
 	tests := []struct {
 		name    string
@@ -50,11 +56,10 @@ func Test_buildHelmCheckConfigMap(t *testing.T) {
 					Namespace: owner.GetNamespace(),
 				},
 				Data: map[string]string{
-					helmCheckConfFileName: `---
-cluster_check: false
-init_config:
+					helmCheckConfFileName: `cluster_check: false
+init_config: null
 instances:
-  - collect_events: false
+- collect_events: false
 `,
 				},
 			},
@@ -73,11 +78,10 @@ instances:
 					Namespace: owner.GetNamespace(),
 				},
 				Data: map[string]string{
-					helmCheckConfFileName: `---
-cluster_check: false
-init_config:
+					helmCheckConfFileName: `cluster_check: false
+init_config: null
 instances:
-  - collect_events: false
+- collect_events: false
 `,
 				},
 			},
@@ -97,11 +101,10 @@ instances:
 					Namespace: owner.GetNamespace(),
 				},
 				Data: map[string]string{
-					helmCheckConfFileName: `---
-cluster_check: true
-init_config:
+					helmCheckConfFileName: `cluster_check: true
+init_config: null
 instances:
-  - collect_events: true
+- collect_events: true
 `,
 				},
 			},
@@ -121,11 +124,10 @@ instances:
 					Namespace: owner.GetNamespace(),
 				},
 				Data: map[string]string{
-					helmCheckConfFileName: `---
-cluster_check: false
-init_config:
+					helmCheckConfFileName: `cluster_check: false
+init_config: null
 instances:
-  - collect_events: true
+- collect_events: true
 `,
 				},
 			},
@@ -145,14 +147,13 @@ instances:
 					Namespace: owner.GetNamespace(),
 				},
 				Data: map[string]string{
-					helmCheckConfFileName: `---
-cluster_check: true
-init_config:
+					helmCheckConfFileName: `cluster_check: true
+init_config: null
 instances:
-  - collect_events: false
-    helm_values_as_tags:
-      foo: bar
-      zip: zap
+- collect_events: false
+  helm_values_as_tags:
+    foo: bar
+    zip: zap
 `,
 				},
 			},
@@ -172,14 +173,117 @@ instances:
 					Namespace: owner.GetNamespace(),
 				},
 				Data: map[string]string{
-					helmCheckConfFileName: `---
-cluster_check: false
-init_config:
+					helmCheckConfFileName: `cluster_check: false
+init_config: null
 instances:
-  - collect_events: false
-    helm_values_as_tags:
-      foo: bar
-      zip: zap
+- collect_events: false
+  helm_values_as_tags:
+    foo: bar
+    zip: zap
+`,
+				},
+			},
+		},
+		{
+			name: "chart annotations as tags",
+			fields: fields{
+				owner:                  owner,
+				enable:                 true,
+				configMapName:          defaultHelmCheckConf,
+				chartAnnotationsAsTags: map[string]string{"team": "chart_team"},
+				valuesAsTags:           map[string]string{"foo": "bar"},
+			},
+			want: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      defaultHelmCheckConf,
+					Namespace: owner.GetNamespace(),
+				},
+				Data: map[string]string{
+					helmCheckConfFileName: `cluster_check: false
+init_config: null
+instances:
+- collect_events: false
+  helm_values_as_tags:
+    foo: bar
+  helm_chart_annotations_as_tags:
+    team: chart_team
+`,
+				},
+			},
+		},
+		{
+			name: "namespace filters",
+			fields: fields{
+				owner:         owner,
+				enable:        true,
+				configMapName: defaultHelmCheckConf,
+				namespaces:    &v2alpha1.HelmCheckFilter{Include: []string{"prod", "staging"}, Exclude: []string{"kube-system"}},
+			},
+			want: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      defaultHelmCheckConf,
+					Namespace: owner.GetNamespace(),
+				},
+				Data: map[string]string{
+					helmCheckConfFileName: `cluster_check: false
+init_config: null
+instances:
+- collect_events: false
+  namespaces:
+  - prod
+  - staging
+  excluded_namespaces:
+  - kube-system
+`,
+				},
+			},
+		},
+		{
+			name: "release filters",
+			fields: fields{
+				owner:         owner,
+				enable:        true,
+				configMapName: defaultHelmCheckConf,
+				releases:      &v2alpha1.HelmCheckFilter{Include: []string{"app", "db"}, Exclude: []string{"db-test"}},
+			},
+			want: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      defaultHelmCheckConf,
+					Namespace: owner.GetNamespace(),
+				},
+				Data: map[string]string{
+					helmCheckConfFileName: `cluster_check: false
+init_config: null
+instances:
+- collect_events: false
+  releases:
+  - app
+  - db
+  excluded_releases:
+  - db-test
+`,
+				},
+			},
+		},
+		{
+			name: "storage driver",
+			fields: fields{
+				owner:         owner,
+				enable:        true,
+				configMapName: defaultHelmCheckConf,
+				storageDriver: apiutils.NewPointer(v2alpha1.HelmStorageDriverConfigMap),
+			},
+			want: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      defaultHelmCheckConf,
+					Namespace: owner.GetNamespace(),
+				},
+				Data: map[string]string{
+					helmCheckConfFileName: `cluster_check: false
+init_config: null
+instances:
+- collect_events: false
+  storage_driver: configmap
 `,
 				},
 			},
@@ -195,6 +299,10 @@ instances:
 				configMapName:            tt.fields.configMapName,
 				collectEvents:            tt.fields.collectEvents,
 				valuesAsTags:             tt.fields.valuesAsTags,
+				chartAnnotationsAsTags:   tt.fields.chartAnnotationsAsTags,
+				namespaces:               tt.fields.namespaces,
+				releases:                 tt.fields.releases,
+				storageDriver:            tt.fields.storageDriver,
 			}
 			got, err := f.buildHelmCheckConfigMap()
 
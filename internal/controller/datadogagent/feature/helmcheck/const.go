@@ -11,6 +11,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
 )
 
@@ -40,6 +41,30 @@ var helmCheckRBACPolicyRules = []rbacv1.PolicyRule{
 	},
 }
 
+// getHelmCheckRBACPolicyRules returns the policy rules required to read the
+// releases stored by the given storage driver, or by all the drivers if nil.
+func getHelmCheckRBACPolicyRules(storageDriver *v2alpha1.HelmStorageDriver) []rbacv1.PolicyRule {
+	if storageDriver == nil {
+		return helmCheckRBACPolicyRules
+	}
+
+	resource := rbac.SecretsResource
+	if *storageDriver == v2alpha1.HelmStorageDriverConfigMap {
+		resource = rbac.ConfigMapsResource
+	}
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{rbac.CoreAPIGroup},
+			Resources: []string{resource},
+			Verbs: []string{
+				rbac.GetVerb,
+				rbac.ListVerb,
+				rbac.WatchVerb,
+			},
+		},
+	}
+}
+
 func getHelmCheckRBACResourceName(owner metav1.Object, rbacSuffix string) string {
 	return fmt.Sprintf("%s-%s-%s-%s", owner.GetNamespace(), owner.GetName(), helmCheckRBACPrefix, rbacSuffix)
 }
@@ -46,6 +46,10 @@ type helmCheckFeature struct {
 	runInClusterChecksRunner bool
 	collectEvents            bool
 	valuesAsTags             map[string]string
+	chartAnnotationsAsTags   map[string]string
+	namespaces               *v2alpha1.HelmCheckFilter
+	releases                 *v2alpha1.HelmCheckFilter
+	storageDriver            *v2alpha1.HelmStorageDriver
 
 	serviceAccountName string
 	rbacSuffix         string
@@ -76,6 +80,10 @@ func (f *helmCheckFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp featur
 		f.configMapName = fmt.Sprintf("%s-%s", f.owner.GetName(), defaultHelmCheckConf)
 		f.collectEvents = apiutils.BoolValue(helmCheck.CollectEvents)
 		f.valuesAsTags = helmCheck.ValuesAsTags
+		f.chartAnnotationsAsTags = helmCheck.ChartAnnotationsAsTags
+		f.namespaces = helmCheck.Namespaces
+		f.releases = helmCheck.Releases
+		f.storageDriver = helmCheck.StorageDriver
 		f.serviceAccountName = constants.GetClusterAgentServiceAccount(dda)
 
 		if constants.IsClusterChecksEnabled(dda) && constants.IsCCREnabled(dda) {
@@ -123,8 +131,19 @@ func (f *helmCheckFeature) ManageDependencies(managers feature.ResourceManagers,
 
 	// Manage RBAC permission
 	rbacName := getHelmCheckRBACResourceName(f.owner, f.rbacSuffix)
+	policyRules := getHelmCheckRBACPolicyRules(f.storageDriver)
+
+	// Only grant access to the included namespaces when the check is restricted to them
+	if f.namespaces != nil && len(f.namespaces.Include) > 0 {
+		for _, ns := range f.namespaces.Include {
+			if err := managers.RBACManager().AddPolicyRules(ns, rbacName, f.serviceAccountName, policyRules, f.owner.GetNamespace()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 
-	return managers.RBACManager().AddClusterPolicyRules(f.owner.GetNamespace(), rbacName, f.serviceAccountName, helmCheckRBACPolicyRules)
+	return managers.RBACManager().AddClusterPolicyRules(f.owner.GetNamespace(), rbacName, f.serviceAccountName, policyRules)
 }
 
 // ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
@@ -17,6 +17,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/fake"
@@ -26,6 +27,7 @@ import (
 	"github.com/DataDog/datadog-operator/pkg/constants"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
 	"github.com/DataDog/datadog-operator/pkg/testutils"
 )
 
@@ -67,6 +69,26 @@ func Test_helmCheckFeature_Configure(t *testing.T) {
 			WantDependenciesFunc: helmCheckWantDepsFunc(true, true, valuesAsTags, "ccr"),
 			ClusterAgent:         helmCheckWantResourcesFunc(true, true),
 		},
+		{
+			Name: "Helm check restricted to namespaces",
+			DDA: testutils.NewInitializedDatadogAgentBuilder(resourcesNamespace, resourcesName).
+				WithHelmCheckEnabled(true).
+				WithHelmCheckNamespaces([]string{"ns1", "ns2"}, nil).
+				WithHelmCheckStorageDriver(v2alpha1.HelmStorageDriverSecret).
+				Build(),
+			WantConfigure:        true,
+			WantDependenciesFunc: helmCheckNamespacedWantDepsFunc([]string{"ns1", "ns2"}),
+		},
+		{
+			Name: "Helm check restricted to a storage driver",
+			DDA: testutils.NewInitializedDatadogAgentBuilder(resourcesNamespace, resourcesName).
+				WithHelmCheckEnabled(true).
+				WithHelmCheckNamespaces(nil, []string{"kube-system"}).
+				WithHelmCheckStorageDriver(v2alpha1.HelmStorageDriverConfigMap).
+				Build(),
+			WantConfigure:        true,
+			WantDependenciesFunc: helmCheckStorageDriverWantDepsFunc,
+		},
 	}
 
 	tests.Run(t, buildHelmCheckFeature)
@@ -84,7 +106,8 @@ func helmCheckWantDepsFunc(ccr bool, collectEvents bool, valuesAsTags map[string
 		} else {
 			cm := obj.(*corev1.ConfigMap)
 
-			wantData := helmCheckConfig(ccr, collectEvents, valuesAsTags)
+			wantData, err := helmCheckConfig(ccr, helmCheckInstance{CollectEvents: collectEvents, HelmValuesAsTags: valuesAsTags})
+			require.NoError(t, err)
 			wantCm, err := configmap.BuildConfigMapConfigData(resourcesNamespace, &wantData, configMapName, helmCheckConfFileName)
 			require.NoError(t, err)
 
@@ -180,14 +203,13 @@ func helmCheckWantResourcesFunc(ccr bool, collectEvents bool) *test.ComponentTes
 
 			// Validate configMap annotations
 			config := map[string]string{
-				"helm.yaml": fmt.Sprintf(`---
-cluster_check: %s
-init_config:
+				"helm.yaml": fmt.Sprintf(`cluster_check: %s
+init_config: null
 instances:
-  - collect_events: %s
-    helm_values_as_tags:
-      foo: bar
-      zip: zap
+- collect_events: %s
+  helm_values_as_tags:
+    foo: bar
+    zip: zap
 `, strconv.FormatBool(ccr), strconv.FormatBool(collectEvents)),
 			}
 
@@ -202,3 +224,73 @@ instances:
 			assert.True(t, apiutils.IsEqualStruct(annotations, wantAnnotations), "Annotations \ndiff = %s", cmp.Diff(annotations, wantAnnotations))
 		})
 }
+
+func helmCheckNamespacedWantDepsFunc(namespaces []string) func(t testing.TB, store store.StoreClient) {
+	return func(t testing.TB, store store.StoreClient) {
+		configMapName := fmt.Sprintf("%s-%s", resourcesName, defaultHelmCheckConf)
+		obj, found := store.Get(kubernetes.ConfigMapKind, resourcesNamespace, configMapName)
+		require.True(t, found, "Should have created a ConfigMap")
+		wantConfig := `cluster_check: false
+init_config: null
+instances:
+- collect_events: false
+  namespaces:
+  - ns1
+  - ns2
+  storage_driver: secret
+`
+		assert.Equal(t, wantConfig, obj.(*corev1.ConfigMap).Data[helmCheckConfFileName])
+
+		rbacName := fmt.Sprintf("%s-%s-%s-%s", resourcesNamespace, resourcesName, helmCheckRBACPrefix, "dca")
+		_, found = store.Get(kubernetes.ClusterRolesKind, "", rbacName)
+		assert.False(t, found, "Should not have created a ClusterRole")
+
+		wantRules := []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{rbac.CoreAPIGroup},
+				Resources: []string{rbac.SecretsResource},
+				Verbs:     []string{rbac.GetVerb, rbac.ListVerb, rbac.WatchVerb},
+			},
+		}
+		for _, ns := range namespaces {
+			roleObj, found := store.Get(kubernetes.RolesKind, ns, rbacName)
+			require.True(t, found, "Should have created a Role in %s", ns)
+			assert.Equal(t, wantRules, roleObj.(*rbacv1.Role).Rules)
+
+			rbObj, found := store.Get(kubernetes.RoleBindingKind, ns, rbacName)
+			require.True(t, found, "Should have created a RoleBinding in %s", ns)
+			rb := rbObj.(*rbacv1.RoleBinding)
+			assert.Equal(t, rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: rbacName}, rb.RoleRef)
+			require.Len(t, rb.Subjects, 1)
+			assert.Equal(t, resourcesNamespace, rb.Subjects[0].Namespace)
+		}
+	}
+}
+
+func helmCheckStorageDriverWantDepsFunc(t testing.TB, store store.StoreClient) {
+	configMapName := fmt.Sprintf("%s-%s", resourcesName, defaultHelmCheckConf)
+	obj, found := store.Get(kubernetes.ConfigMapKind, resourcesNamespace, configMapName)
+	require.True(t, found, "Should have created a ConfigMap")
+	wantConfig := `cluster_check: false
+init_config: null
+instances:
+- collect_events: false
+  excluded_namespaces:
+  - kube-system
+  storage_driver: configmap
+`
+	assert.Equal(t, wantConfig, obj.(*corev1.ConfigMap).Data[helmCheckConfFileName])
+
+	// Excluded namespaces do not narrow the RBAC, the ClusterRole only grants access to the ConfigMaps
+	rbacName := fmt.Sprintf("%s-%s-%s-%s", resourcesNamespace, resourcesName, helmCheckRBACPrefix, "dca")
+	crObj, found := store.Get(kubernetes.ClusterRolesKind, "", rbacName)
+	require.True(t, found, "Should have created a ClusterRole")
+	wantRules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{rbac.CoreAPIGroup},
+			Resources: []string{rbac.ConfigMapsResource},
+			Verbs:     []string{rbac.GetVerb, rbac.ListVerb, rbac.WatchVerb},
+		},
+	}
+	assert.Equal(t, wantRules, crObj.(*rbacv1.ClusterRole).Rules)
+}
@@ -73,4 +73,6 @@ const (
 	ServiceDiscoveryType = "service_discovery"
 	// GPUIDType GPU monitoring feature.
 	GPUIDType = "gpu"
+	// ClusterAgentTLSIDType Cluster Agent TLS feature.
+	ClusterAgentTLSIDType = "cluster_agent_tls"
 )
@@ -9,8 +9,7 @@ import (
 	"reflect"
 	"testing"
 
-	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
-
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -37,7 +36,7 @@ instances:
 		rbacSuffix               string
 		serviceAccountName       string
 		owner                    metav1.Object
-		customConfig             *v2alpha1.CustomConfig
+		customConfig             *apicommon.CustomConfig
 		configConfigMapName      string
 		collectorOpts            collectorOptions
 	}
@@ -64,7 +63,7 @@ instances:
 				enable:                   true,
 				runInClusterChecksRunner: true,
 				configConfigMapName:      defaultKubeStateMetricsCoreConf,
-				customConfig: &v2alpha1.CustomConfig{
+				customConfig: &apicommon.CustomConfig{
 					ConfigData: &overrideConf,
 				},
 			},
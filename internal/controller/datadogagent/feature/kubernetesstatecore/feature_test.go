@@ -10,7 +10,6 @@ import (
 	"testing"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
-	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/fake"
@@ -114,7 +113,7 @@ func ksmClusterAgentWantFunc(hasCustomConfig bool) *test.ComponentTest {
 			assert.True(t, apiutils.IsEqualStruct(dcaEnvVars, want), "DCA envvars \ndiff = %s", cmp.Diff(dcaEnvVars, want))
 
 			if hasCustomConfig {
-				customConfig := v2alpha1.CustomConfig{
+				customConfig := apicommon.CustomConfig{
 					ConfigData: apiutils.NewStringPointer(customData),
 				}
 				hash, err := comparison.GenerateMD5ForSpec(&customConfig)
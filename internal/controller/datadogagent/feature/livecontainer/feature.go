@@ -32,6 +32,8 @@ func buildLiveContainerFeature(options *feature.Options) feature.Feature {
 
 type liveContainerFeature struct {
 	runInCoreAgent bool
+
+	additionalEndpoints []v2alpha1.AdditionalEndpoint
 }
 
 // ID returns the ID of the Feature
@@ -48,6 +50,10 @@ func (f *liveContainerFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp fe
 
 		f.runInCoreAgent = featutils.OverrideProcessConfigRunInCoreAgent(dda, apiutils.BoolValue(dda.Spec.Global.RunProcessChecksInCoreAgent))
 
+		if dda.Spec.Global.AdditionalEndpoints != nil {
+			f.additionalEndpoints = dda.Spec.Global.AdditionalEndpoints.Process
+		}
+
 		if !f.runInCoreAgent {
 			reqContainers = append(reqContainers, apicommon.ProcessAgentContainerName)
 		}
@@ -124,6 +130,10 @@ func (f *liveContainerFeature) manageNodeAgent(agentContainerName apicommon.Agen
 		Value: "true",
 	})
 
+	for _, envVar := range featutils.AdditionalEndpointsEnvVars(common.DDProcessAdditionalEndpoints, f.additionalEndpoints) {
+		managers.EnvVar().AddEnvVarToContainer(agentContainerName, envVar)
+	}
+
 	return nil
 }
 
@@ -33,6 +33,8 @@ type liveProcessFeature struct {
 	scrubArgs      *bool
 	stripArgs      *bool
 	runInCoreAgent bool
+
+	additionalEndpoints []v2alpha1.AdditionalEndpoint
 }
 
 // ID returns the ID of the Feature
@@ -56,6 +58,10 @@ func (f *liveProcessFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feat
 
 		f.runInCoreAgent = featutils.OverrideProcessConfigRunInCoreAgent(dda, apiutils.BoolValue(dda.Spec.Global.RunProcessChecksInCoreAgent))
 
+		if dda.Spec.Global.AdditionalEndpoints != nil {
+			f.additionalEndpoints = dda.Spec.Global.AdditionalEndpoints.Process
+		}
+
 		if !f.runInCoreAgent {
 			reqContainers = append(reqContainers, apicommon.ProcessAgentContainerName)
 		}
@@ -139,6 +145,10 @@ func (f *liveProcessFeature) manageNodeAgent(agentContainerName apicommon.AgentC
 
 	managers.EnvVar().AddEnvVarToContainer(agentContainerName, enableEnvVar)
 
+	for _, envVar := range featutils.AdditionalEndpointsEnvVars(common.DDProcessAdditionalEndpoints, f.additionalEndpoints) {
+		managers.EnvVar().AddEnvVarToContainer(agentContainerName, envVar)
+	}
+
 	if f.scrubArgs != nil {
 		scrubArgsEnvVar := &corev1.EnvVar{
 			Name:  DDProcessConfigScrubArgs,
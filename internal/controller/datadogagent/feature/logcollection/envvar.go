@@ -6,7 +6,13 @@
 package logcollection
 
 const (
-	DDLogsConfigContainerCollectAll  = "DD_LOGS_CONFIG_CONTAINER_COLLECT_ALL"
-	DDLogsConfigOpenFilesLimit       = "DD_LOGS_CONFIG_OPEN_FILES_LIMIT"
-	DDLogsContainerCollectUsingFiles = "DD_LOGS_CONFIG_K8S_CONTAINER_USE_FILE"
+	DDLogsConfigAdditionalEndpoints                = "DD_LOGS_CONFIG_ADDITIONAL_ENDPOINTS"
+	DDLogsConfigAutoMultiLineDefaultMatchThreshold = "DD_LOGS_CONFIG_AUTO_MULTI_LINE_DEFAULT_MATCH_THRESHOLD"
+	DDLogsConfigAutoMultiLineDefaultSampleSize     = "DD_LOGS_CONFIG_AUTO_MULTI_LINE_DEFAULT_SAMPLE_SIZE"
+	DDLogsConfigAutoMultiLineDetection             = "DD_LOGS_CONFIG_AUTO_MULTI_LINE_DETECTION"
+	DDLogsConfigAutoMultiLineExtraPatterns         = "DD_LOGS_CONFIG_AUTO_MULTI_LINE_EXTRA_PATTERNS"
+	DDLogsConfigContainerCollectAll                = "DD_LOGS_CONFIG_CONTAINER_COLLECT_ALL"
+	DDLogsConfigOpenFilesLimit                     = "DD_LOGS_CONFIG_OPEN_FILES_LIMIT"
+	DDLogsConfigProcessingRules                    = "DD_LOGS_CONFIG_PROCESSING_RULES"
+	DDLogsContainerCollectUsingFiles               = "DD_LOGS_CONFIG_K8S_CONTAINER_USE_FILE"
 )
@@ -6,7 +6,9 @@
 package logcollection
 
 import (
+	"encoding/json"
 	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -15,6 +17,7 @@ import (
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	featutils "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object/volume"
 )
 
@@ -39,6 +42,17 @@ type logCollectionFeature struct {
 	containerSymlinksPath      string
 	tempStoragePath            string
 	openFilesLimit             int32
+	processingRules            []processingRule
+	autoMultiLineDetection     *apicommon.AutoMultiLineDetectionConfig
+	additionalEndpoints        []v2alpha1.AdditionalEndpoint
+}
+
+// processingRule is a logs processing rule in the Agent configuration format
+type processingRule struct {
+	Type               string `json:"type"`
+	Name               string `json:"name"`
+	Pattern            string `json:"pattern"`
+	ReplacePlaceholder string `json:"replace_placeholder,omitempty"`
 }
 
 // ID returns the ID of the Feature
@@ -67,6 +81,18 @@ func (f *logCollectionFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp fe
 		if logCollection.OpenFilesLimit != nil {
 			f.openFilesLimit = *logCollection.OpenFilesLimit
 		}
+		for _, rule := range logCollection.ProcessingRules {
+			f.processingRules = append(f.processingRules, processingRule{
+				Type:               string(rule.Type),
+				Name:               rule.Name,
+				Pattern:            rule.Pattern,
+				ReplacePlaceholder: apiutils.StringValue(rule.ReplacePlaceholder),
+			})
+		}
+		f.autoMultiLineDetection = logCollection.AutoMultiLineDetection
+		if dda.Spec.Global != nil && dda.Spec.Global.AdditionalEndpoints != nil {
+			f.additionalEndpoints = dda.Spec.Global.AdditionalEndpoints.Logs
+		}
 
 		reqComp = feature.RequiredComponents{
 			Agent: feature.RequiredComponent{
@@ -96,15 +122,13 @@ func (f *logCollectionFeature) ManageClusterAgent(managers feature.PodTemplateMa
 // if SingleContainerStrategy is enabled and can be used with the configured feature set.
 // It should do nothing if the feature doesn't need to configure it.
 func (f *logCollectionFeature) ManageSingleContainerNodeAgent(managers feature.PodTemplateManagers, provider string) error {
-	f.manageNodeAgent(apicommon.UnprivilegedSingleAgentContainerName, managers, provider)
-	return nil
+	return f.manageNodeAgent(apicommon.UnprivilegedSingleAgentContainerName, managers, provider)
 }
 
 // ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
 // It should do nothing if the feature doesn't need to configure it.
 func (f *logCollectionFeature) ManageNodeAgent(managers feature.PodTemplateManagers, provider string) error {
-	f.manageNodeAgent(apicommon.CoreAgentContainerName, managers, provider)
-	return nil
+	return f.manageNodeAgent(apicommon.CoreAgentContainerName, managers, provider)
 }
 
 func (f *logCollectionFeature) manageNodeAgent(agentContainerName apicommon.AgentContainerName, managers feature.PodTemplateManagers, provider string) error {
@@ -147,6 +171,45 @@ func (f *logCollectionFeature) manageNodeAgent(agentContainerName apicommon.Agen
 			Value: strconv.FormatInt(int64(f.openFilesLimit), 10),
 		})
 	}
+	if len(f.processingRules) > 0 {
+		rules, err := json.Marshal(f.processingRules)
+		if err != nil {
+			return err
+		}
+		managers.EnvVar().AddEnvVarToContainer(agentContainerName, &corev1.EnvVar{
+			Name:  DDLogsConfigProcessingRules,
+			Value: string(rules),
+		})
+	}
+	if autoMultiLine := f.autoMultiLineDetection; autoMultiLine != nil {
+		if autoMultiLine.Enabled != nil {
+			managers.EnvVar().AddEnvVarToContainer(agentContainerName, &corev1.EnvVar{
+				Name:  DDLogsConfigAutoMultiLineDetection,
+				Value: strconv.FormatBool(*autoMultiLine.Enabled),
+			})
+		}
+		if len(autoMultiLine.ExtraPatterns) > 0 {
+			managers.EnvVar().AddEnvVarToContainer(agentContainerName, &corev1.EnvVar{
+				Name:  DDLogsConfigAutoMultiLineExtraPatterns,
+				Value: strings.Join(autoMultiLine.ExtraPatterns, " "),
+			})
+		}
+		if autoMultiLine.DefaultSampleSize != nil {
+			managers.EnvVar().AddEnvVarToContainer(agentContainerName, &corev1.EnvVar{
+				Name:  DDLogsConfigAutoMultiLineDefaultSampleSize,
+				Value: strconv.FormatInt(int64(*autoMultiLine.DefaultSampleSize), 10),
+			})
+		}
+		if autoMultiLine.DefaultMatchThreshold != nil {
+			managers.EnvVar().AddEnvVarToContainer(agentContainerName, &corev1.EnvVar{
+				Name:  DDLogsConfigAutoMultiLineDefaultMatchThreshold,
+				Value: *autoMultiLine.DefaultMatchThreshold,
+			})
+		}
+	}
+	for _, envVar := range featutils.LogsAdditionalEndpointsEnvVars(DDLogsConfigAdditionalEndpoints, f.additionalEndpoints) {
+		managers.EnvVar().AddEnvVarToContainer(agentContainerName, envVar)
+	}
 
 	return nil
 }
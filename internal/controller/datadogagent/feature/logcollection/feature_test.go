@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
@@ -160,6 +161,101 @@ func Test_LogCollectionFeature_Configure(t *testing.T) {
 				},
 			),
 		},
+		{
+			Name: "processing rules",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithLogCollectionEnabled(true).
+				WithLogCollectionProcessingRules(
+					apicommon.LogsProcessingRule{
+						Type:    apicommon.LogsProcessingRuleExcludeAtMatch,
+						Name:    "exclude_healthchecks",
+						Pattern: "GET /healthz",
+					},
+					apicommon.LogsProcessingRule{
+						Type:               apicommon.LogsProcessingRuleMaskSequences,
+						Name:               "mask_tokens",
+						Pattern:            `token=\w+`,
+						ReplacePlaceholder: apiutils.NewStringPointer("token=[masked]"),
+					},
+				).
+				BuildWithDefaults(),
+			WantConfigure: true,
+			Agent: test.NewDefaultComponentTest().WithWantFunc(
+				func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+					wantEnvVars := createEnvVars("true", "false", "true")
+					wantEnvVars = append(wantEnvVars, &corev1.EnvVar{
+						Name:  DDLogsConfigProcessingRules,
+						Value: `[{"type":"exclude_at_match","name":"exclude_healthchecks","pattern":"GET /healthz"},{"type":"mask_sequences","name":"mask_tokens","pattern":"token=\\w+","replace_placeholder":"token=[masked]"}]`,
+					})
+					assertWants(t, mgrInterface, getWantVolumeMounts(), getWantVolumes(), wantEnvVars)
+				},
+			),
+		},
+		{
+			Name: "auto multi-line detection",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithLogCollectionEnabled(true).
+				WithLogCollectionAutoMultiLineDetection(&apicommon.AutoMultiLineDetectionConfig{
+					Enabled:               apiutils.NewBoolPointer(true),
+					ExtraPatterns:         []string{`\d{4}-\d{2}-\d{2}`, `\[\w+\]`},
+					DefaultSampleSize:     apiutils.NewInt32Pointer(200),
+					DefaultMatchThreshold: apiutils.NewStringPointer("0.3"),
+				}).
+				BuildWithDefaults(),
+			WantConfigure: true,
+			Agent: test.NewDefaultComponentTest().WithWantFunc(
+				func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+					wantEnvVars := createEnvVars("true", "false", "true")
+					wantEnvVars = append(wantEnvVars,
+						&corev1.EnvVar{
+							Name:  DDLogsConfigAutoMultiLineDetection,
+							Value: "true",
+						},
+						&corev1.EnvVar{
+							Name:  DDLogsConfigAutoMultiLineExtraPatterns,
+							Value: `\d{4}-\d{2}-\d{2} \[\w+\]`,
+						},
+						&corev1.EnvVar{
+							Name:  DDLogsConfigAutoMultiLineDefaultSampleSize,
+							Value: "200",
+						},
+						&corev1.EnvVar{
+							Name:  DDLogsConfigAutoMultiLineDefaultMatchThreshold,
+							Value: "0.3",
+						},
+					)
+					assertWants(t, mgrInterface, getWantVolumeMounts(), getWantVolumes(), wantEnvVars)
+				},
+			),
+		},
+		{
+			Name: "additional endpoints",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithLogCollectionEnabled(true).
+				WithGlobalAdditionalEndpoints(&v2alpha1.AdditionalEndpointsConfig{
+					Logs: []v2alpha1.AdditionalEndpoint{
+						{URL: "agent-http-intake.logs.datadoghq.eu", APIKeySecret: v2alpha1.SecretConfig{SecretName: "eu", KeyName: "api_key"}},
+						{URL: "https://logs.example.com:8443", APIKeySecret: v2alpha1.SecretConfig{SecretName: "example", KeyName: "api_key"}},
+					},
+				}).
+				BuildWithDefaults(),
+			WantConfigure: true,
+			Agent: test.NewDefaultComponentTest().WithWantFunc(
+				func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+					wantEnvVars := createEnvVars("true", "false", "true")
+					wantEnvVars = append(wantEnvVars,
+						common.BuildEnvVarFromSource("LOGS_CONFIG_ADDITIONAL_ENDPOINTS_0_API_KEY", common.BuildEnvVarFromSecret("eu", "api_key")),
+						common.BuildEnvVarFromSource("LOGS_CONFIG_ADDITIONAL_ENDPOINTS_1_API_KEY", common.BuildEnvVarFromSecret("example", "api_key")),
+						&corev1.EnvVar{
+							Name: DDLogsConfigAdditionalEndpoints,
+							Value: `[{"api_key":"$(LOGS_CONFIG_ADDITIONAL_ENDPOINTS_0_API_KEY)","Host":"agent-http-intake.logs.datadoghq.eu","Port":443,"is_reliable":true},` +
+								`{"api_key":"$(LOGS_CONFIG_ADDITIONAL_ENDPOINTS_1_API_KEY)","Host":"logs.example.com","Port":8443,"is_reliable":true}]`,
+						},
+					)
+					assertWants(t, mgrInterface, getWantVolumeMounts(), getWantVolumes(), wantEnvVars)
+				},
+			),
+		},
 	}
 
 	tests.Run(t, buildLogCollectionFeature)
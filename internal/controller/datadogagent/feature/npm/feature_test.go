@@ -26,7 +26,7 @@ func Test_npmFeature_Configure(t *testing.T) {
 	ddaNPMDisabled := v2alpha1.DatadogAgent{
 		Spec: v2alpha1.DatadogAgentSpec{
 			Features: &v2alpha1.DatadogFeatures{
-				NPM: &v2alpha1.NPMFeatureConfig{
+				NPM: &apicommon.NPMFeatureConfig{
 					Enabled: apiutils.NewBoolPointer(false),
 				},
 			},
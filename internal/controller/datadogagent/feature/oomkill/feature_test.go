@@ -26,7 +26,7 @@ func Test_oomKillFeature_Configure(t *testing.T) {
 	ddaOOMKillDisabled := v2alpha1.DatadogAgent{
 		Spec: v2alpha1.DatadogAgentSpec{
 			Features: &v2alpha1.DatadogFeatures{
-				OOMKill: &v2alpha1.OOMKillFeatureConfig{
+				OOMKill: &apicommon.OOMKillFeatureConfig{
 					Enabled: apiutils.NewBoolPointer(false),
 				},
 			},
@@ -11,8 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
-
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -36,7 +35,7 @@ instances:
 		rbacSuffix               string
 		serviceAccountName       string
 		owner                    metav1.Object
-		customConfig             *v2alpha1.CustomConfig
+		customConfig             *apicommon.CustomConfig
 		configConfigMapName      string
 		crCollection             []string
 	}
@@ -63,7 +62,7 @@ instances:
 				enable:                   true,
 				runInClusterChecksRunner: true,
 				configConfigMapName:      defaultOrchestratorExplorerConf,
-				customConfig: &v2alpha1.CustomConfig{
+				customConfig: &apicommon.CustomConfig{
 					ConfigData: &overrideConf,
 				},
 			},
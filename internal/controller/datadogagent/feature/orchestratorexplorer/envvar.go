@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	featutils "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/utils"
 )
 
 const (
@@ -48,5 +49,7 @@ func (f *orchestratorExplorerFeature) getEnvVars() []*corev1.EnvVar {
 		})
 	}
 
+	envVarsList = append(envVarsList, featutils.AdditionalEndpointsEnvVars(DDOrchestratorExplorerAdditionalEndpoints, f.additionalEndpoints)...)
+
 	return envVarsList
 }
@@ -49,10 +49,11 @@ type orchestratorExplorerFeature struct {
 	scrubContainers          bool
 	extraTags                []string
 	ddURL                    string
+	additionalEndpoints      []v2alpha1.AdditionalEndpoint
 	rbacSuffix               string
 	serviceAccountName       string
 	owner                    metav1.Object
-	customConfig             *v2alpha1.CustomConfig
+	customConfig             *apicommon.CustomConfig
 	customResources          []string
 	configConfigMapName      string
 
@@ -74,9 +75,6 @@ func (f *orchestratorExplorerFeature) ID() feature.IDType {
 func (f *orchestratorExplorerFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
 	f.owner = dda
 
-	// Merge configuration from Status.RemoteConfigConfiguration into the Spec
-	f.mergeConfigs(&dda.Spec, &dda.Status)
-
 	orchestratorExplorer := dda.Spec.Features.OrchestratorExplorer
 
 	if orchestratorExplorer != nil && apiutils.BoolValue(orchestratorExplorer.Enabled) {
@@ -118,6 +116,9 @@ func (f *orchestratorExplorerFeature) Configure(dda *v2alpha1.DatadogAgent) (req
 		if orchestratorExplorer.DDUrl != nil {
 			f.ddURL = *orchestratorExplorer.DDUrl
 		}
+		if dda.Spec.Global != nil && dda.Spec.Global.AdditionalEndpoints != nil {
+			f.additionalEndpoints = dda.Spec.Global.AdditionalEndpoints.Orchestrator
+		}
 		f.serviceAccountName = constants.GetClusterAgentServiceAccount(dda)
 
 		if constants.IsClusterChecksEnabled(dda) {
@@ -133,25 +134,6 @@ func (f *orchestratorExplorerFeature) Configure(dda *v2alpha1.DatadogAgent) (req
 	return reqComp
 }
 
-func (f *orchestratorExplorerFeature) mergeConfigs(ddaSpec *v2alpha1.DatadogAgentSpec, ddaStatus *v2alpha1.DatadogAgentStatus) {
-	if ddaStatus.RemoteConfigConfiguration == nil ||
-		ddaStatus.RemoteConfigConfiguration.Features == nil ||
-		ddaStatus.RemoteConfigConfiguration.Features.OrchestratorExplorer == nil ||
-		ddaStatus.RemoteConfigConfiguration.Features.OrchestratorExplorer.CustomResources == nil {
-		return
-	}
-
-	if ddaSpec.Features == nil {
-		ddaSpec.Features = &v2alpha1.DatadogFeatures{}
-	}
-
-	if ddaSpec.Features.OrchestratorExplorer == nil {
-		ddaSpec.Features.OrchestratorExplorer = &v2alpha1.OrchestratorExplorerFeatureConfig{}
-	}
-
-	ddaSpec.Features.OrchestratorExplorer.CustomResources = append(ddaSpec.Features.OrchestratorExplorer.CustomResources, ddaStatus.RemoteConfigConfiguration.Features.OrchestratorExplorer.CustomResources...)
-}
-
 // ManageDependencies allows a feature to manage its dependencies.
 // Feature's dependencies should be added in the store.
 func (f *orchestratorExplorerFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
@@ -140,7 +140,7 @@ func orchestratorExplorerClusterAgentWantFuncV2() *test.ComponentTest {
 			assert.True(t, apiutils.IsEqualStruct(dcaEnvVars, expectedOrchestratorEnvsV2), "DCA envvars \ndiff = %s", cmp.Diff(dcaEnvVars, expectedOrchestratorEnvsV2))
 
 			// check annotation
-			customConfig := v2alpha1.CustomConfig{
+			customConfig := apicommon.CustomConfig{
 				ConfigData: apiutils.NewStringPointer(customConfDataV2),
 			}
 			trueValue := true
@@ -8,7 +8,7 @@ package otelcollector
 import (
 	"testing"
 
-	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/otelcollector/defaultconfig"
 	"github.com/stretchr/testify/assert"
@@ -37,7 +37,7 @@ func Test_buildOtelCollectorConfigMap(t *testing.T) {
 		Name: "-otel-agent-config",
 	}
 	otelCollectorFeature.configMapName = "-otel-agent-config"
-	otelCollectorFeature.customConfig = &v2alpha1.CustomConfig{}
+	otelCollectorFeature.customConfig = &apicommon.CustomConfig{}
 	otelCollectorFeature.customConfig.ConfigData = &defaultconfig.DefaultOtelCollectorConfig
 
 	configMap, err := otelCollectorFeature.buildOTelAgentCoreConfigMap()
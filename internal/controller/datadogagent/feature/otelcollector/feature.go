@@ -40,7 +40,7 @@ func buildOtelCollectorFeature(options *feature.Options) feature.Feature {
 }
 
 type otelCollectorFeature struct {
-	customConfig    *v2alpha1.CustomConfig
+	customConfig    *apicommon.CustomConfig
 	owner           metav1.Object
 	configMapName   string
 	ports           []*corev1.ContainerPort
@@ -137,7 +137,7 @@ func (o *otelCollectorFeature) buildOTelAgentCoreConfigMap() (*corev1.ConfigMap,
 func (o *otelCollectorFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
 	// check if an otel collector config was provided. If not, use default.
 	if o.customConfig == nil {
-		o.customConfig = &v2alpha1.CustomConfig{}
+		o.customConfig = &apicommon.CustomConfig{}
 	}
 	if o.customConfig.ConfigData == nil && o.customConfig.ConfigMap == nil {
 		var defaultConfig = defaultconfig.DefaultOtelCollectorConfig
@@ -30,6 +30,8 @@ func buildProcessDiscoveryFeature(options *feature.Options) feature.Feature {
 
 type processDiscoveryFeature struct {
 	runInCoreAgent bool
+
+	additionalEndpoints []v2alpha1.AdditionalEndpoint
 }
 
 func (p processDiscoveryFeature) ID() feature.IDType {
@@ -45,6 +47,10 @@ func (p *processDiscoveryFeature) Configure(dda *v2alpha1.DatadogAgent) feature.
 
 		p.runInCoreAgent = featutils.OverrideProcessConfigRunInCoreAgent(dda, apiutils.BoolValue(dda.Spec.Global.RunProcessChecksInCoreAgent))
 
+		if dda.Spec.Global.AdditionalEndpoints != nil {
+			p.additionalEndpoints = dda.Spec.Global.AdditionalEndpoints.Process
+		}
+
 		if !p.runInCoreAgent {
 			reqContainers = append(reqContainers, apicommon.ProcessAgentContainerName)
 		}
@@ -117,6 +123,10 @@ func (p processDiscoveryFeature) manageNodeAgent(agentContainerName apicommon.Ag
 
 	managers.EnvVar().AddEnvVarToContainer(agentContainerName, enableEnvVar)
 
+	for _, envVar := range featutils.AdditionalEndpointsEnvVars(common.DDProcessAdditionalEndpoints, p.additionalEndpoints) {
+		managers.EnvVar().AddEnvVarToContainer(agentContainerName, envVar)
+	}
+
 	return nil
 }
 
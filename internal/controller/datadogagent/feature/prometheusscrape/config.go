@@ -0,0 +1,223 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package prometheusscrape
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+// prometheusCheck is a `prometheus_scrape.checks` item in the Agent configuration format
+type prometheusCheck struct {
+	Autodiscovery  *prometheusAutodiscovery `json:"autodiscovery,omitempty"`
+	Configurations []openmetricsInstance    `json:"configurations"`
+}
+
+type prometheusAutodiscovery struct {
+	KubernetesAnnotations    *prometheusAnnotations `json:"kubernetes_annotations,omitempty"`
+	KubernetesContainerNames []string               `json:"kubernetes_container_names,omitempty"`
+}
+
+type prometheusAnnotations struct {
+	Include map[string]string `json:"include,omitempty"`
+	Exclude map[string]string `json:"exclude,omitempty"`
+}
+
+// openmetricsInstance is an OpenMetrics check instance in the Agent configuration format
+type openmetricsInstance struct {
+	OpenMetricsEndpoint string            `json:"openmetrics_endpoint,omitempty"`
+	Namespace           string            `json:"namespace,omitempty"`
+	Metrics             []string          `json:"metrics,omitempty"`
+	ExcludeMetrics      []string          `json:"exclude_metrics,omitempty"`
+	RawMetricPrefix     string            `json:"raw_metric_prefix,omitempty"`
+	RenameLabels        map[string]string `json:"rename_labels,omitempty"`
+	Tags                []string          `json:"tags,omitempty"`
+	BearerTokenAuth     bool              `json:"bearer_token_auth,omitempty"`
+	BearerTokenPath     string            `json:"bearer_token_path,omitempty"`
+	Timeout             int32             `json:"timeout,omitempty"`
+}
+
+// endpointsCheck is an OpenMetrics endpoints check configuration file, scheduled by the Cluster Agent
+type endpointsCheck struct {
+	AdvancedADIdentifiers []advancedADIdentifier `json:"advanced_ad_identifiers"`
+	ClusterCheck          bool                   `json:"cluster_check"`
+	InitConfig            map[string]string      `json:"init_config"`
+	Instances             []openmetricsInstance  `json:"instances"`
+}
+
+type advancedADIdentifier struct {
+	KubeEndpoints kubeResource `json:"kube_endpoints"`
+}
+
+type kubeResource struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+func isEndpointsConfig(config *v2alpha1.PrometheusScrapeConfig) bool {
+	return config.Autodiscovery != nil && len(config.Autodiscovery.KubernetesEndpoints) > 0
+}
+
+// buildChecks returns the value of the `prometheus_scrape.checks` option: the
+// additional configs followed by the typed configs that are not endpoints checks.
+func buildChecks(additionalConfigs string, configs []v2alpha1.PrometheusScrapeConfig) (string, error) {
+	var checks []interface{}
+	for i := range configs {
+		if isEndpointsConfig(&configs[i]) {
+			continue
+		}
+		check := prometheusCheck{
+			Configurations: []openmetricsInstance{buildInstance(&configs[i])},
+		}
+		if ad := configs[i].Autodiscovery; ad != nil {
+			check.Autodiscovery = &prometheusAutodiscovery{
+				KubernetesContainerNames: ad.KubernetesContainerNames,
+			}
+			if ad.KubernetesAnnotations != nil {
+				check.Autodiscovery.KubernetesAnnotations = &prometheusAnnotations{
+					Include: ad.KubernetesAnnotations.Include,
+					Exclude: ad.KubernetesAnnotations.Exclude,
+				}
+			}
+		}
+		checks = append(checks, check)
+	}
+
+	if len(checks) == 0 {
+		if additionalConfigs == "" {
+			return "", nil
+		}
+		return apiutils.YAMLToJSONString(additionalConfigs), nil
+	}
+
+	if additionalConfigs != "" {
+		var additionalChecks []interface{}
+		if err := yaml.Unmarshal([]byte(additionalConfigs), &additionalChecks); err != nil {
+			return "", fmt.Errorf("unable to parse additionalConfigs: %w", err)
+		}
+		checks = append(additionalChecks, checks...)
+	}
+
+	jsonChecks, err := json.Marshal(checks)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonChecks), nil
+}
+
+// buildEndpointsChecksConfigMap returns a ConfigMap with a check configuration
+// file per service of the endpoints checks configurations.
+func buildEndpointsChecksConfigMap(namespace, name string, configs []v2alpha1.PrometheusScrapeConfig) (*corev1.ConfigMap, error) {
+	data := map[string]string{}
+	for i := range configs {
+		if !isEndpointsConfig(&configs[i]) {
+			continue
+		}
+		instance := buildInstance(&configs[i])
+		for _, service := range configs[i].Autodiscovery.KubernetesEndpoints {
+			check := endpointsCheck{
+				AdvancedADIdentifiers: []advancedADIdentifier{
+					{KubeEndpoints: kubeResource{Name: service.Name, Namespace: service.Namespace}},
+				},
+				ClusterCheck: true,
+				InitConfig:   map[string]string{},
+				Instances:    []openmetricsInstance{instance},
+			}
+			content, err := yaml.Marshal(check)
+			if err != nil {
+				return nil, err
+			}
+			data[fmt.Sprintf("%s_%s_%d.yaml", service.Namespace, service.Name, i)] = string(content)
+		}
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: data,
+	}, nil
+}
+
+func buildInstance(config *v2alpha1.PrometheusScrapeConfig) openmetricsInstance {
+	instance := openmetricsInstance{
+		Namespace:       apiutils.StringValue(config.Namespace),
+		Metrics:         config.Metrics,
+		ExcludeMetrics:  config.ExcludeMetrics,
+		RawMetricPrefix: apiutils.StringValue(config.RawMetricPrefix),
+		RenameLabels:    config.RenameLabels,
+		Tags:            config.Tags,
+	}
+	if len(instance.Metrics) == 0 {
+		instance.Metrics = []string{".*"}
+	}
+	if config.Timeout != nil {
+		instance.Timeout = *config.Timeout
+	}
+	if config.Endpoint != nil {
+		instance.OpenMetricsEndpoint = buildEndpointURL(config.Endpoint)
+	}
+	if config.BearerTokenSecret != nil {
+		instance.BearerTokenAuth = true
+		instance.BearerTokenPath = bearerTokenPath(config.BearerTokenSecret)
+	}
+
+	return instance
+}
+
+func buildEndpointURL(endpoint *v2alpha1.PrometheusScrapeEndpoint) string {
+	scheme := "http"
+	if endpoint.Scheme != nil {
+		scheme = *endpoint.Scheme
+	}
+	path := "/metrics"
+	if endpoint.Path != nil {
+		path = *endpoint.Path
+	}
+	port := endpoint.Port.String()
+	if endpoint.Port.Type == intstr.String {
+		port = fmt.Sprintf("%%%%port_%s%%%%", endpoint.Port.StrVal)
+	}
+
+	return fmt.Sprintf("%s://%%%%host%%%%:%s%s", scheme, port, path)
+}
+
+func bearerTokenPath(secret *corev1.SecretKeySelector) string {
+	return filepath.Join(bearerTokenVolumePath, secret.Name, secret.Key)
+}
+
+// bearerTokenSecrets returns the sorted names of the Secrets containing bearer tokens.
+func bearerTokenSecrets(configs []v2alpha1.PrometheusScrapeConfig) []string {
+	names := map[string]struct{}{}
+	for _, config := range configs {
+		if config.BearerTokenSecret != nil {
+			names[config.BearerTokenSecret.Name] = struct{}{}
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	return sorted
+}
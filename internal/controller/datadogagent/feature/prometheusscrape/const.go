@@ -0,0 +1,17 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package prometheusscrape
+
+const (
+	endpointsChecksFolderName = "openmetrics.d"
+	endpointsChecksVolumeName = "prometheus-scrape-endpoints-checks"
+
+	bearerTokenVolumeName = "prometheus-scrape-bearer-token"
+	bearerTokenVolumePath = "/etc/datadog-agent/prometheus-scrape"
+
+	// defaultEndpointsChecksConf default endpoints checks ConfigMap name
+	defaultEndpointsChecksConf string = "prometheus-scrape-endpoints-checks"
+)
@@ -6,14 +6,23 @@
 package prometheusscrape
 
 import (
+	"fmt"
+	"path/filepath"
 	"strconv"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object/volume"
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 )
 
 func init() {
@@ -26,6 +35,9 @@ func init() {
 func buildPrometheusScrapeFeature(options *feature.Options) feature.Feature {
 	prometheusScrapeFeat := &prometheusScrapeFeature{}
 
+	if options != nil {
+		prometheusScrapeFeat.logger = options.Logger
+	}
 	return prometheusScrapeFeat
 }
 
@@ -33,6 +45,14 @@ type prometheusScrapeFeature struct {
 	enableServiceEndpoints bool
 	additionalConfigs      string
 	openmetricsVersion     int
+	configs                []v2alpha1.PrometheusScrapeConfig
+
+	endpointsChecksConfig                *corev1.ConfigMap
+	endpointsChecksConfigAnnotationKey   string
+	endpointsChecksConfigAnnotationValue string
+	bearerTokenSecrets                   []string
+
+	logger logr.Logger
 }
 
 // ID returns the ID of the Feature
@@ -56,6 +76,26 @@ func (f *prometheusScrapeFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp
 		if prometheusScrape.Version != nil {
 			f.openmetricsVersion = *prometheusScrape.Version
 		}
+		f.configs = prometheusScrape.Configs
+		f.bearerTokenSecrets = bearerTokenSecrets(f.configs)
+
+		// Endpoints checks are dispatched by the Cluster Agent to the node Agents
+		// running the pods backing the services.
+		cm, err := buildEndpointsChecksConfigMap(dda.GetNamespace(), fmt.Sprintf("%s-%s", dda.GetName(), defaultEndpointsChecksConf), f.configs)
+		if err != nil {
+			f.logger.Error(err, "couldn't generate configMap for prometheus scrape endpoints checks")
+		} else if cm != nil && !constants.IsClusterChecksEnabled(dda) {
+			f.logger.Info("Prometheus scrape configurations with kubernetesEndpoints require the cluster checks feature, skipping them")
+		} else if cm != nil {
+			f.endpointsChecksConfig = cm
+			hash, err := comparison.GenerateMD5ForSpec(cm.Data)
+			if err != nil {
+				f.logger.Error(err, "couldn't generate hash for prometheus scrape endpoints checks config")
+			}
+			f.endpointsChecksConfigAnnotationValue = hash
+			f.endpointsChecksConfigAnnotationKey = object.GetChecksumAnnotationKey(feature.PrometheusScrapeIDType)
+		}
+
 		reqComp = feature.RequiredComponents{
 			Agent: feature.RequiredComponent{
 				IsRequired: apiutils.NewBoolPointer(true),
@@ -78,6 +118,10 @@ func (f *prometheusScrapeFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp
 // ManageDependencies allows a feature to manage its dependencies.
 // Feature's dependencies should be added in the store.
 func (f *prometheusScrapeFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
+	if f.endpointsChecksConfig != nil {
+		return managers.Store().AddOrUpdate(kubernetes.ConfigMapKind, f.endpointsChecksConfig)
+	}
+
 	return nil
 }
 
@@ -92,10 +136,14 @@ func (f *prometheusScrapeFeature) ManageClusterAgent(managers feature.PodTemplat
 		Name:  DDPrometheusScrapeServiceEndpoints,
 		Value: strconv.FormatBool(f.enableServiceEndpoints),
 	})
-	if f.additionalConfigs != "" {
+	checks, err := buildChecks(f.additionalConfigs, f.configs)
+	if err != nil {
+		return err
+	}
+	if checks != "" {
 		managers.EnvVar().AddEnvVarToContainer(apicommon.ClusterAgentContainerName, &corev1.EnvVar{
 			Name:  DDPrometheusScrapeChecks,
-			Value: apiutils.YAMLToJSONString(f.additionalConfigs),
+			Value: checks,
 		})
 	}
 	if f.openmetricsVersion != 0 {
@@ -105,6 +153,22 @@ func (f *prometheusScrapeFeature) ManageClusterAgent(managers feature.PodTemplat
 		})
 	}
 
+	if f.endpointsChecksConfig != nil {
+		vol := volume.GetBasicVolume(f.endpointsChecksConfig.Name, endpointsChecksVolumeName)
+		volMount := corev1.VolumeMount{
+			Name:      endpointsChecksVolumeName,
+			MountPath: fmt.Sprintf("%s%s/%s", common.ConfigVolumePath, common.ConfdVolumePath, endpointsChecksFolderName),
+			ReadOnly:  true,
+		}
+		managers.VolumeMount().AddVolumeMountToContainer(&volMount, apicommon.ClusterAgentContainerName)
+		managers.Volume().AddVolume(&vol)
+
+		// Add md5 hash annotation so that the Cluster Agent reloads the checks
+		if f.endpointsChecksConfigAnnotationKey != "" && f.endpointsChecksConfigAnnotationValue != "" {
+			managers.Annotation().AddAnnotation(f.endpointsChecksConfigAnnotationKey, f.endpointsChecksConfigAnnotationValue)
+		}
+	}
+
 	return nil
 }
 
@@ -112,15 +176,13 @@ func (f *prometheusScrapeFeature) ManageClusterAgent(managers feature.PodTemplat
 // if SingleContainerStrategy is enabled and can be used with the configured feature set.
 // It should do nothing if the feature doesn't need to configure it.
 func (f *prometheusScrapeFeature) ManageSingleContainerNodeAgent(managers feature.PodTemplateManagers, provider string) error {
-	f.manageNodeAgent(apicommon.UnprivilegedSingleAgentContainerName, managers, provider)
-	return nil
+	return f.manageNodeAgent(apicommon.UnprivilegedSingleAgentContainerName, managers, provider)
 }
 
 // ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
 // It should do nothing if the feature doesn't need to configure it.
 func (f *prometheusScrapeFeature) ManageNodeAgent(managers feature.PodTemplateManagers, provider string) error {
-	f.manageNodeAgent(apicommon.CoreAgentContainerName, managers, provider)
-	return nil
+	return f.manageNodeAgent(apicommon.CoreAgentContainerName, managers, provider)
 }
 
 func (f *prometheusScrapeFeature) manageNodeAgent(agentContainerName apicommon.AgentContainerName, managers feature.PodTemplateManagers, provider string) error {
@@ -132,10 +194,14 @@ func (f *prometheusScrapeFeature) manageNodeAgent(agentContainerName apicommon.A
 		Name:  DDPrometheusScrapeServiceEndpoints,
 		Value: strconv.FormatBool(f.enableServiceEndpoints),
 	})
-	if f.additionalConfigs != "" {
+	checks, err := buildChecks(f.additionalConfigs, f.configs)
+	if err != nil {
+		return err
+	}
+	if checks != "" {
 		managers.EnvVar().AddEnvVarToContainer(agentContainerName, &corev1.EnvVar{
 			Name:  DDPrometheusScrapeChecks,
-			Value: apiutils.YAMLToJSONString(f.additionalConfigs),
+			Value: checks,
 		})
 	}
 	if f.openmetricsVersion != 0 {
@@ -145,6 +211,24 @@ func (f *prometheusScrapeFeature) manageNodeAgent(agentContainerName apicommon.A
 		})
 	}
 
+	// Bearer tokens are read from files by the OpenMetrics check
+	for i, secretName := range f.bearerTokenSecrets {
+		volumeName := fmt.Sprintf("%s-%d", bearerTokenVolumeName, i)
+		managers.Volume().AddVolume(&corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secretName,
+				},
+			},
+		})
+		managers.VolumeMount().AddVolumeMountToContainer(&corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: filepath.Join(bearerTokenVolumePath, secretName),
+			ReadOnly:  true,
+		}, agentContainerName)
+	}
+
 	return nil
 }
 
@@ -9,15 +9,21 @@ import (
 	"testing"
 
 	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/fake"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/test"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 	"github.com/DataDog/datadog-operator/pkg/testutils"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func Test_prometheusScrapeFeature_Configure(t *testing.T) {
@@ -211,11 +217,153 @@ func Test_prometheusScrapeFeature_Configure(t *testing.T) {
 				},
 			),
 		},
+		{
+			Name: "typed configs",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithPrometheusScrapeEnabled(true).
+				WithPrometheusScrapeAdditionalConfigs(yamlConfigs).
+				WithPrometheusScrapeConfigs([]v2alpha1.PrometheusScrapeConfig{
+					{
+						Autodiscovery: &v2alpha1.PrometheusScrapeAutodiscovery{
+							KubernetesContainerNames: []string{"api"},
+						},
+						Endpoint: &v2alpha1.PrometheusScrapeEndpoint{
+							Port: intstr.FromInt(9090),
+						},
+						Metrics:           []string{"http_.*"},
+						Namespace:         apiutils.NewStringPointer("api"),
+						BearerTokenSecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "token"}, Key: "t"},
+					},
+				}).
+				Build(),
+			WantConfigure: true,
+			Agent: test.NewDefaultComponentTest().WithWantFunc(
+				func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+					wantEnvVars := []*corev1.EnvVar{
+						{
+							Name:  DDPrometheusScrapeEnabled,
+							Value: "true",
+						},
+						{
+							Name:  DDPrometheusScrapeServiceEndpoints,
+							Value: "false",
+						},
+						{
+							Name:  DDPrometheusScrapeChecks,
+							Value: typedJSONConfigs,
+						},
+					}
+					assertContainerEnvVars(t, mgrInterface, apicommon.CoreAgentContainerName, wantEnvVars)
+
+					mgr := mgrInterface.(*fake.PodTemplateManagers)
+					wantVolumeMounts := []*corev1.VolumeMount{
+						{
+							Name:      bearerTokenVolumeName + "-0",
+							MountPath: "/etc/datadog-agent/prometheus-scrape/token",
+							ReadOnly:  true,
+						},
+					}
+					assert.Equal(t, wantVolumeMounts, mgr.VolumeMountMgr.VolumeMountsByC[apicommon.CoreAgentContainerName])
+					assert.Len(t, mgr.VolumeMgr.Volumes, 1)
+				},
+			),
+			ClusterAgent: test.NewDefaultComponentTest().WithWantFunc(
+				func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+					wantEnvVars := []*corev1.EnvVar{
+						{
+							Name:  DDPrometheusScrapeEnabled,
+							Value: "true",
+						},
+						{
+							Name:  DDPrometheusScrapeServiceEndpoints,
+							Value: "false",
+						},
+						{
+							Name:  DDPrometheusScrapeChecks,
+							Value: typedJSONConfigs,
+						},
+					}
+					assertContainerEnvVars(t, mgrInterface, apicommon.ClusterAgentContainerName, wantEnvVars)
+				},
+			),
+		},
+		{
+			Name: "endpoints configs",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithName("foo").
+				WithPrometheusScrapeEnabled(true).
+				WithClusterChecksEnabled(true).
+				WithPrometheusScrapeConfigs(endpointsConfigs).
+				Build(),
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store store.StoreClient) {
+				obj, found := store.Get(kubernetes.ConfigMapKind, "", "foo-"+defaultEndpointsChecksConf)
+				require.True(t, found, "Should have created a ConfigMap")
+				cm := obj.(*corev1.ConfigMap)
+				assert.Equal(t, map[string]string{"ns_svc_0.yaml": endpointsCheckYAML}, cm.Data)
+			},
+			ClusterAgent: test.NewDefaultComponentTest().WithWantFunc(
+				func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+					mgr := mgrInterface.(*fake.PodTemplateManagers)
+					wantVolumeMounts := []*corev1.VolumeMount{
+						{
+							Name:      endpointsChecksVolumeName,
+							MountPath: "/etc/datadog-agent/conf.d/openmetrics.d",
+							ReadOnly:  true,
+						},
+					}
+					assert.Equal(t, wantVolumeMounts, mgr.VolumeMountMgr.VolumeMountsByC[apicommon.ClusterAgentContainerName])
+					assert.Len(t, mgr.VolumeMgr.Volumes, 1)
+					assert.Contains(t, mgr.AnnotationMgr.Annotations, object.GetChecksumAnnotationKey(feature.PrometheusScrapeIDType))
+				},
+			),
+		},
+		{
+			Name: "endpoints configs without cluster checks",
+			DDA: testutils.NewDatadogAgentBuilder().
+				WithName("foo").
+				WithPrometheusScrapeEnabled(true).
+				WithClusterChecksEnabled(false).
+				WithPrometheusScrapeConfigs(endpointsConfigs).
+				Build(),
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store store.StoreClient) {
+				_, found := store.Get(kubernetes.ConfigMapKind, "", "foo-"+defaultEndpointsChecksConf)
+				assert.False(t, found, "Should not have created a ConfigMap")
+			},
+		},
 	}
 
 	tests.Run(t, buildPrometheusScrapeFeature)
 }
 
+const typedJSONConfigs = `[{"autodiscovery":{"kubernetes_annotations":{"exclude":{"custom_exclude_label":"true"},"include":{"custom_include_label":"true"}},"kubernetes_container_names":["my-app"]},"configurations":[{"send_distribution_buckets":true}],"timeout":5},` +
+	`{"autodiscovery":{"kubernetes_container_names":["api"]},"configurations":[{"openmetrics_endpoint":"http://%%host%%:9090/metrics","namespace":"api","metrics":["http_.*"],"bearer_token_auth":true,"bearer_token_path":"/etc/datadog-agent/prometheus-scrape/token/t"}]}]`
+
+var endpointsConfigs = []v2alpha1.PrometheusScrapeConfig{
+	{
+		Autodiscovery: &v2alpha1.PrometheusScrapeAutodiscovery{
+			KubernetesEndpoints: []v2alpha1.PrometheusScrapeService{{Name: "svc", Namespace: "ns"}},
+		},
+		Endpoint: &v2alpha1.PrometheusScrapeEndpoint{
+			Port:   intstr.FromString("metrics"),
+			Scheme: apiutils.NewStringPointer("https"),
+		},
+	},
+}
+
+const endpointsCheckYAML = `advanced_ad_identifiers:
+- kube_endpoints:
+    name: svc
+    namespace: ns
+cluster_check: true
+init_config: {}
+instances:
+- metrics:
+  - .*
+  openmetrics_endpoint: https://%%host%%:%%port_metrics%%/metrics
+`
+
 func assertContainerEnvVars(t testing.TB, mgrInterface feature.PodTemplateManagers, containerName apicommon.AgentContainerName, wantEnvVars []*corev1.EnvVar) {
 	mgr := mgrInterface.(*fake.PodTemplateManagers)
 	envVars := mgr.EnvVarMgr.EnvVarsByC[containerName]
@@ -59,9 +59,6 @@ func (f *sbomFeature) ID() feature.IDType {
 func (f *sbomFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
 	f.owner = dda
 
-	// Merge configuration from Status.RemoteConfigConfiguration into the Spec
-	mergeConfigs(&dda.Spec, &dda.Status)
-
 	sbomConfig := dda.Spec.Features.SBOM
 
 	if sbomConfig != nil && apiutils.BoolValue(sbomConfig.Enabled) {
@@ -89,38 +86,6 @@ func (f *sbomFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.Req
 	return reqComp
 }
 
-func mergeConfigs(ddaSpec *v2alpha1.DatadogAgentSpec, ddaStatus *v2alpha1.DatadogAgentStatus) {
-	if ddaStatus.RemoteConfigConfiguration == nil || ddaStatus.RemoteConfigConfiguration.Features == nil || ddaStatus.RemoteConfigConfiguration.Features.SBOM == nil || ddaStatus.RemoteConfigConfiguration.Features.SBOM.Enabled == nil {
-		return
-	}
-
-	if ddaSpec.Features == nil {
-		ddaSpec.Features = &v2alpha1.DatadogFeatures{}
-	}
-
-	if ddaSpec.Features.SBOM == nil {
-		ddaSpec.Features.SBOM = &v2alpha1.SBOMFeatureConfig{}
-	}
-
-	if ddaStatus.RemoteConfigConfiguration.Features.SBOM.Enabled != nil {
-		ddaSpec.Features.SBOM.Enabled = ddaStatus.RemoteConfigConfiguration.Features.SBOM.Enabled
-	}
-
-	if ddaStatus.RemoteConfigConfiguration.Features.SBOM.Host != nil && ddaStatus.RemoteConfigConfiguration.Features.SBOM.Host.Enabled != nil {
-		if ddaSpec.Features.SBOM.Host == nil {
-			ddaSpec.Features.SBOM.Host = &v2alpha1.SBOMHostConfig{}
-		}
-		ddaSpec.Features.SBOM.Host.Enabled = ddaStatus.RemoteConfigConfiguration.Features.SBOM.Host.Enabled
-	}
-
-	if ddaStatus.RemoteConfigConfiguration.Features.SBOM.ContainerImage != nil && ddaStatus.RemoteConfigConfiguration.Features.SBOM.ContainerImage.Enabled != nil {
-		if ddaSpec.Features.SBOM.ContainerImage == nil {
-			ddaSpec.Features.SBOM.ContainerImage = &v2alpha1.SBOMContainerImageConfig{}
-		}
-		ddaSpec.Features.SBOM.ContainerImage.Enabled = ddaStatus.RemoteConfigConfiguration.Features.SBOM.ContainerImage.Enabled
-	}
-}
-
 // ManageDependencies allows a feature to manage its dependencies.
 // Feature's dependencies should be added in the store.
 func (f *sbomFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
@@ -26,7 +26,7 @@ func Test_serviceDiscoveryFeature_Configure(t *testing.T) {
 	ddaServiceDiscoveryDisabled := v2alpha1.DatadogAgent{
 		Spec: v2alpha1.DatadogAgentSpec{
 			Features: &v2alpha1.DatadogFeatures{
-				ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+				ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 					Enabled: apiutils.NewBoolPointer(false),
 				},
 			},
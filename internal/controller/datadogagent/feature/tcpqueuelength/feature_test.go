@@ -27,7 +27,7 @@ func Test_tcpQueueLengthFeature_Configure(t *testing.T) {
 	ddaTCPQLDisabled := v2alpha1.DatadogAgent{
 		Spec: v2alpha1.DatadogAgentSpec{
 			Features: &v2alpha1.DatadogFeatures{
-				TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+				TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 					Enabled: apiutils.NewBoolPointer(false),
 				},
 			},
@@ -164,6 +164,9 @@ type ResourceManagers interface {
 	NetworkPolicyManager() merger.NetworkPolicyManager
 	ServiceManager() merger.ServiceManager
 	CiliumPolicyManager() merger.CiliumPolicyManager
+	CalicoPolicyManager() merger.CalicoPolicyManager
+	AdminNetworkPolicyManager() merger.AdminNetworkPolicyManager
+	CertManagerManager() merger.CertManagerManager
 	ConfigMapManager() merger.ConfigMapManager
 	APIServiceManager() merger.APIServiceManager
 }
@@ -177,6 +180,9 @@ func NewResourceManagers(store store.StoreClient) ResourceManagers {
 		networkPolicy: merger.NewNetworkPolicyManager(store),
 		service:       merger.NewServiceManager(store),
 		cilium:        merger.NewCiliumPolicyManager(store),
+		calico:        merger.NewCalicoPolicyManager(store),
+		anp:           merger.NewAdminNetworkPolicyManager(store),
+		certManager:   merger.NewCertManagerManager(store),
 		configMap:     merger.NewConfigMapManager(store),
 		apiService:    merger.NewAPIServiceManager(store),
 	}
@@ -189,6 +195,9 @@ type resourceManagersImpl struct {
 	networkPolicy merger.NetworkPolicyManager
 	service       merger.ServiceManager
 	cilium        merger.CiliumPolicyManager
+	calico        merger.CalicoPolicyManager
+	anp           merger.AdminNetworkPolicyManager
+	certManager   merger.CertManagerManager
 	configMap     merger.ConfigMapManager
 	apiService    merger.APIServiceManager
 }
@@ -217,6 +226,18 @@ func (impl *resourceManagersImpl) CiliumPolicyManager() merger.CiliumPolicyManag
 	return impl.cilium
 }
 
+func (impl *resourceManagersImpl) CalicoPolicyManager() merger.CalicoPolicyManager {
+	return impl.calico
+}
+
+func (impl *resourceManagersImpl) AdminNetworkPolicyManager() merger.AdminNetworkPolicyManager {
+	return impl.anp
+}
+
+func (impl *resourceManagersImpl) CertManagerManager() merger.CertManagerManager {
+	return impl.certManager
+}
+
 func (impl *resourceManagersImpl) ConfigMapManager() merger.ConfigMapManager {
 	return impl.configMap
 }
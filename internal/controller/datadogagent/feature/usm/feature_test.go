@@ -26,7 +26,7 @@ func Test_usmFeature_Configure(t *testing.T) {
 	ddaUSMDisabled := v2alpha1.DatadogAgent{
 		Spec: v2alpha1.DatadogAgentSpec{
 			Features: &v2alpha1.DatadogFeatures{
-				USM: &v2alpha1.USMFeatureConfig{
+				USM: &apicommon.USMFeatureConfig{
 					Enabled: apiutils.NewBoolPointer(false),
 				},
 			},
@@ -0,0 +1,109 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
+)
+
+const defaultLogsAdditionalEndpointPort = 443
+
+// logsAdditionalEndpoint is the format of the logs additional endpoints expected by the Agent
+type logsAdditionalEndpoint struct {
+	APIKey     string `json:"api_key"`
+	Host       string `json:"Host"`
+	Port       int    `json:"Port"`
+	IsReliable bool   `json:"is_reliable"`
+}
+
+// AdditionalEndpointsEnvVars returns the environment variables configuring the additional endpoints of a product,
+// in the `{"<url>": ["<api key>"]}` format used by the metrics, APM, process and orchestrator products.
+// The API keys are read from secrets and referenced in the configuration, so they must be added to the container first.
+func AdditionalEndpointsEnvVars(envVarName string, endpoints []v2alpha1.AdditionalEndpoint) []*corev1.EnvVar {
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	envVars := additionalEndpointsAPIKeyEnvVars(envVarName, endpoints)
+	config := map[string][]string{}
+	for i, endpoint := range endpoints {
+		config[endpoint.URL] = append(config[endpoint.URL], fmt.Sprintf("$(%s)", envVars[i].Name))
+	}
+	value, _ := json.Marshal(config)
+
+	return append(envVars, &corev1.EnvVar{
+		Name:  envVarName,
+		Value: string(value),
+	})
+}
+
+// LogsAdditionalEndpointsEnvVars returns the environment variables configuring the additional endpoints of the logs product.
+// The API keys are read from secrets and referenced in the configuration, so they must be added to the container first.
+func LogsAdditionalEndpointsEnvVars(envVarName string, endpoints []v2alpha1.AdditionalEndpoint) []*corev1.EnvVar {
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	envVars := additionalEndpointsAPIKeyEnvVars(envVarName, endpoints)
+	config := make([]logsAdditionalEndpoint, 0, len(endpoints))
+	for i, endpoint := range endpoints {
+		host, port := logsAdditionalEndpointHostPort(endpoint.URL)
+		config = append(config, logsAdditionalEndpoint{
+			APIKey:     fmt.Sprintf("$(%s)", envVars[i].Name),
+			Host:       host,
+			Port:       port,
+			IsReliable: true,
+		})
+	}
+	value, _ := json.Marshal(config)
+
+	return append(envVars, &corev1.EnvVar{
+		Name:  envVarName,
+		Value: string(value),
+	})
+}
+
+// additionalEndpointsAPIKeyEnvVars returns one environment variable per endpoint, holding its API key.
+// They don't use the `DD_` prefix to not be read as configuration by the Agent.
+func additionalEndpointsAPIKeyEnvVars(envVarName string, endpoints []v2alpha1.AdditionalEndpoint) []*corev1.EnvVar {
+	prefix := strings.TrimPrefix(envVarName, "DD_")
+	envVars := make([]*corev1.EnvVar, 0, len(endpoints)+1)
+	for i, endpoint := range endpoints {
+		envVars = append(envVars, common.BuildEnvVarFromSource(
+			fmt.Sprintf("%s_%d_API_KEY", prefix, i),
+			common.BuildEnvVarFromSecret(endpoint.APIKeySecret.SecretName, endpoint.APIKeySecret.KeyName),
+		))
+	}
+	return envVars
+}
+
+// logsAdditionalEndpointHostPort returns the host and port of a logs endpoint, which can be an URL or a `host:port` address
+func logsAdditionalEndpointHostPort(endpoint string) (string, int) {
+	if strings.Contains(endpoint, "://") {
+		if u, err := url.Parse(endpoint); err == nil {
+			endpoint = u.Host
+		}
+	}
+	host, portStr, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return endpoint, defaultLogsAdditionalEndpointPort
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return host, defaultLogsAdditionalEndpointPort
+	}
+	return host, port
+}
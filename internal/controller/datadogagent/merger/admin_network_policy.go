@@ -0,0 +1,80 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	anp "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// AdminNetworkPolicyManager is used to manage admin network policy resources.
+type AdminNetworkPolicyManager interface {
+	AddAdminNetworkPolicy(name string, policySpec anp.AdminNetworkPolicySpec) error
+}
+
+// NewAdminNetworkPolicyManager returns a new AdminNetworkPolicyManager instance
+func NewAdminNetworkPolicyManager(store store.StoreClient) AdminNetworkPolicyManager {
+	manager := &adminNetworkPolicyManagerImpl{
+		store: store,
+	}
+	return manager
+}
+
+// adminNetworkPolicyManagerImpl is used to manage admin network policy resources.
+type adminNetworkPolicyManagerImpl struct {
+	store store.StoreClient
+}
+
+// AddAdminNetworkPolicy creates an admin network policy or adds rules to an admin network policy.
+// Admin network policies are cluster-scoped. As rules are evaluated in order, the allow rules
+// are added before the existing deny and pass rules.
+func (m *adminNetworkPolicyManagerImpl) AddAdminNetworkPolicy(name string, policySpec anp.AdminNetworkPolicySpec) error {
+	obj, _ := m.store.GetOrCreate(kubernetes.AdminNetworkPoliciesKind, "", name)
+	policy, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unable to get from the store the Admin Network Policy %s", name)
+	}
+
+	var typedPolicy anp.AdminNetworkPolicy
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(policy.UnstructuredContent(), &typedPolicy)
+	if err != nil {
+		return fmt.Errorf("unable to convert unstructured object %s to admin network policy, err: %w", name, err)
+	}
+
+	if typedPolicy.Spec.Subject.Namespaces == nil && typedPolicy.Spec.Subject.Pods == nil {
+		typedPolicy.Spec.Priority = policySpec.Priority
+		typedPolicy.Spec.Subject = policySpec.Subject
+	}
+	typedPolicy.Spec.Ingress = mergeAdminNetworkPolicyRules(typedPolicy.Spec.Ingress, policySpec.Ingress, func(rule anp.AdminNetworkPolicyIngressRule) anp.RuleAction { return rule.Action })
+	typedPolicy.Spec.Egress = mergeAdminNetworkPolicyRules(typedPolicy.Spec.Egress, policySpec.Egress, func(rule anp.AdminNetworkPolicyEgressRule) anp.RuleAction { return rule.Action })
+
+	unstructuredPolicy := &unstructured.Unstructured{}
+	unstructuredPolicy.Object, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&typedPolicy)
+	if err != nil {
+		return fmt.Errorf("unable to convert admin network policy %s to unstructured object, err: %w", name, err)
+	}
+	unstructuredPolicy.SetGroupVersionKind(anp.GroupVersionAdminNetworkPolicyKind())
+	return m.store.AddOrUpdate(kubernetes.AdminNetworkPoliciesKind, unstructuredPolicy)
+}
+
+// mergeAdminNetworkPolicyRules returns the allow rules of both lists, followed by their other rules
+func mergeAdminNetworkPolicyRules[T any](existing, added []T, action func(T) anp.RuleAction) []T {
+	var allowRules, otherRules []T
+	for _, rule := range append(append([]T{}, existing...), added...) {
+		if action(rule) == anp.RuleActionAllow {
+			allowRules = append(allowRules, rule)
+		} else {
+			otherRules = append(otherRules, rule)
+		}
+	}
+	return append(allowRules, otherRules...)
+}
@@ -0,0 +1,55 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	anp "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func TestAdminNetworkPolicyManager_AddAdminNetworkPolicy(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	testScheme.AddKnownTypes(v2alpha1.GroupVersion, &v2alpha1.DatadogAgent{})
+	owner := &v2alpha1.DatadogAgent{ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"}}
+	s := store.NewStore(owner, &store.StoreOptions{Scheme: testScheme})
+	m := NewAdminNetworkPolicyManager(s)
+
+	subject := anp.Subject{Namespaces: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "bar"}}}
+	intake := anp.AdminNetworkPolicyEgressRule{Action: anp.RuleActionAllow, To: []anp.AdminNetworkPolicyEgressPeer{{DomainNames: []string{"*.datadoghq.com"}}}}
+	denyAll := anp.AdminNetworkPolicyEgressRule{Name: "deny-all", Action: anp.RuleActionDeny, To: []anp.AdminNetworkPolicyEgressPeer{{Networks: []string{"0.0.0.0/0"}}}}
+	kafka := anp.AdminNetworkPolicyEgressRule{Action: anp.RuleActionAllow, To: []anp.AdminNetworkPolicyEgressPeer{{Networks: []string{"10.0.0.0/24"}}}}
+
+	require.NoError(t, m.AddAdminNetworkPolicy("bar-foo-agent", anp.AdminNetworkPolicySpec{
+		Priority: 50,
+		Subject:  subject,
+		Egress:   []anp.AdminNetworkPolicyEgressRule{intake, denyAll},
+	}))
+	require.NoError(t, m.AddAdminNetworkPolicy("bar-foo-agent", anp.AdminNetworkPolicySpec{
+		Egress: []anp.AdminNetworkPolicyEgressRule{kafka},
+	}))
+
+	obj, found := s.Get(kubernetes.AdminNetworkPoliciesKind, "", "bar-foo-agent")
+	require.True(t, found)
+	assert.Equal(t, anp.GroupVersionAdminNetworkPolicyKind(), obj.GetObjectKind().GroupVersionKind())
+	assert.Empty(t, obj.GetOwnerReferences())
+
+	var policy anp.AdminNetworkPolicy
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).UnstructuredContent(), &policy))
+	assert.Equal(t, int32(50), policy.Spec.Priority)
+	assert.Equal(t, subject, policy.Spec.Subject)
+	// the deny rule stays last
+	assert.Equal(t, []anp.AdminNetworkPolicyEgressRule{intake, kafka, denyAll}, policy.Spec.Egress)
+}
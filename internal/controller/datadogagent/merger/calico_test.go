@@ -0,0 +1,60 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	calico "github.com/DataDog/datadog-operator/pkg/calico/v3"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func TestCalicoPolicyManager_AddCalicoPolicy(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	testScheme.AddKnownTypes(v2alpha1.GroupVersion, &v2alpha1.DatadogAgent{})
+	owner := &v2alpha1.DatadogAgent{ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"}}
+	s := store.NewStore(owner, &store.StoreOptions{Scheme: testScheme})
+	m := NewCalicoPolicyManager(s)
+
+	tcp := calico.ProtocolTCP
+	require.NoError(t, m.AddCalicoPolicy("bar-foo-agent", calico.GlobalNetworkPolicySpec{
+		Selector:          "app == 'agent'",
+		NamespaceSelector: "projectcalico.org/name == 'bar'",
+		Types:             []calico.PolicyType{calico.PolicyTypeEgress},
+		Egress: []calico.Rule{
+			{Action: calico.ActionAllow, Protocol: &tcp, Destination: calico.EntityRule{Ports: []string{"443"}}},
+		},
+	}))
+	require.NoError(t, m.AddCalicoPolicy("bar-foo-agent", calico.GlobalNetworkPolicySpec{
+		Selector: "app == 'other'",
+		Types:    []calico.PolicyType{calico.PolicyTypeIngress, calico.PolicyTypeEgress},
+		Ingress: []calico.Rule{
+			{Action: calico.ActionAllow, Protocol: &tcp, Destination: calico.EntityRule{Ports: []string{"8125"}}},
+		},
+	}))
+
+	obj, found := s.Get(kubernetes.CalicoGlobalNetworkPoliciesKind, "", "bar-foo-agent")
+	require.True(t, found)
+	assert.Equal(t, calico.GroupVersionGlobalNetworkPolicyKind(), obj.GetObjectKind().GroupVersionKind())
+	// cluster-scoped policies can't be owned by the DatadogAgent
+	assert.Empty(t, obj.GetOwnerReferences())
+
+	var policy calico.GlobalNetworkPolicy
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).UnstructuredContent(), &policy))
+	assert.Equal(t, "app == 'agent'", policy.Spec.Selector)
+	assert.Equal(t, "projectcalico.org/name == 'bar'", policy.Spec.NamespaceSelector)
+	assert.Equal(t, []calico.PolicyType{calico.PolicyTypeEgress, calico.PolicyTypeIngress}, policy.Spec.Types)
+	assert.Len(t, policy.Spec.Ingress, 1)
+	assert.Len(t, policy.Spec.Egress, 1)
+}
@@ -0,0 +1,66 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	certmanager "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// CertManagerManager is used to manage cert-manager issuer and certificate resources.
+type CertManagerManager interface {
+	AddIssuer(name, namespace string, spec certmanager.IssuerSpec) error
+	AddCertificate(name, namespace string, spec certmanager.CertificateSpec) error
+}
+
+// NewCertManagerManager returns a new CertManagerManager instance
+func NewCertManagerManager(store store.StoreClient) CertManagerManager {
+	manager := &certManagerManagerImpl{
+		store: store,
+	}
+	return manager
+}
+
+// certManagerManagerImpl is used to manage cert-manager issuer and certificate resources.
+type certManagerManagerImpl struct {
+	store store.StoreClient
+}
+
+// AddIssuer creates or updates a cert-manager issuer
+func (m *certManagerManagerImpl) AddIssuer(name, namespace string, spec certmanager.IssuerSpec) error {
+	issuer := &certmanager.Issuer{Spec: spec}
+	return m.addObject(kubernetes.CertManagerIssuersKind, certmanager.GroupVersionIssuerKind(), name, namespace, issuer)
+}
+
+// AddCertificate creates or updates a cert-manager certificate
+func (m *certManagerManagerImpl) AddCertificate(name, namespace string, spec certmanager.CertificateSpec) error {
+	certificate := &certmanager.Certificate{Spec: spec}
+	return m.addObject(kubernetes.CertManagerCertificatesKind, certmanager.GroupVersionCertificateKind(), name, namespace, certificate)
+}
+
+func (m *certManagerManagerImpl) addObject(kind kubernetes.ObjectKind, gvk schema.GroupVersionKind, name, namespace string, typedObj any) error {
+	obj, _ := m.store.GetOrCreate(kind, namespace, name)
+	stored, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unable to get from the store the %s %s", gvk.Kind, name)
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(typedObj)
+	if err != nil {
+		return fmt.Errorf("unable to convert %s %s to unstructured object, err: %w", gvk.Kind, name, err)
+	}
+	stored.Object["spec"] = content["spec"]
+	stored.SetGroupVersionKind(gvk)
+
+	return m.store.AddOrUpdate(kind, stored)
+}
@@ -0,0 +1,54 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package merger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	certmanager "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func TestCertManagerManager(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	testScheme.AddKnownTypes(v2alpha1.GroupVersion, &v2alpha1.DatadogAgent{})
+	owner := &v2alpha1.DatadogAgent{ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"}}
+	s := store.NewStore(owner, &store.StoreOptions{Scheme: testScheme})
+	m := NewCertManagerManager(s)
+
+	require.NoError(t, m.AddIssuer("foo-ca", "bar", certmanager.IssuerSpec{CA: &certmanager.CAIssuer{SecretName: "foo-ca"}}))
+	require.NoError(t, m.AddCertificate("foo-tls", "bar", certmanager.CertificateSpec{
+		SecretName: "foo-tls",
+		DNSNames:   []string{"foo.bar.svc"},
+		Usages:     []certmanager.KeyUsage{certmanager.UsageServerAuth},
+		IssuerRef:  certmanager.IssuerReference{Name: "foo-ca", Kind: certmanager.IssuerKind},
+	}))
+
+	obj, found := s.Get(kubernetes.CertManagerIssuersKind, "bar", "foo-ca")
+	require.True(t, found)
+	assert.Equal(t, certmanager.GroupVersionIssuerKind(), obj.GetObjectKind().GroupVersionKind())
+	var issuer certmanager.Issuer
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).UnstructuredContent(), &issuer))
+	assert.Equal(t, "foo-ca", issuer.Spec.CA.SecretName)
+
+	obj, found = s.Get(kubernetes.CertManagerCertificatesKind, "bar", "foo-tls")
+	require.True(t, found)
+	assert.Equal(t, certmanager.GroupVersionCertificateKind(), obj.GetObjectKind().GroupVersionKind())
+	// namespaced objects are owned by the DatadogAgent
+	assert.Len(t, obj.GetOwnerReferences(), 1)
+	var certificate certmanager.Certificate
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).UnstructuredContent(), &certificate))
+	assert.Equal(t, []string{"foo.bar.svc"}, certificate.Spec.DNSNames)
+	assert.Equal(t, "foo-ca", certificate.Spec.IssuerRef.Name)
+}
@@ -40,8 +40,8 @@ func (m *serviceManagerImpl) AddService(name, namespace string, selector map[str
 		return fmt.Errorf("unable to get from the store the Service %s", name)
 	}
 
-	if len(ports) > 0 {
-		service.Spec.Ports = append(service.Spec.Ports, ports...)
+	for _, port := range ports {
+		service.Spec.Ports = addServicePort(service.Spec.Ports, port)
 	}
 	if selector != nil {
 		service.Spec.Selector = selector
@@ -52,3 +52,14 @@ func (m *serviceManagerImpl) AddService(name, namespace string, selector map[str
 	}
 	return m.store.AddOrUpdate(kubernetes.ServicesKind, service)
 }
+
+// addServicePort adds a port to a Service, or replaces the port with the same name.
+func addServicePort(ports []corev1.ServicePort, newPort corev1.ServicePort) []corev1.ServicePort {
+	for id := range ports {
+		if ports[id].Name == newPort.Name {
+			ports[id] = newPort
+			return ports
+		}
+	}
+	return append(ports, newPort)
+}
@@ -139,6 +139,35 @@ func TestServiceManager_AddService(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:  "replace the port with the same name",
+			store: store.NewStore(owner, storeOptions).AddOrUpdateStore(kubernetes.ServicesKind, existingService.DeepCopy()),
+			args: args{
+				namespace: ns,
+				name:      name2,
+				selector:  selector,
+				ports: []corev1.ServicePort{
+					{
+						Protocol:   corev1.ProtocolUDP,
+						TargetPort: intstr.FromInt(portNumber1),
+						Port:       int32(portNumber2),
+						Name:       name1,
+					},
+				},
+				itp: &serviceInternalTrafficPolicy,
+			},
+			wantErr: false,
+			validateFunc: func(t *testing.T, store *store.Store) {
+				obj, found := store.Get(kubernetes.ServicesKind, ns, name2)
+				if !found {
+					t.Errorf("missing Service %s/%s", ns, name2)
+				}
+				service, ok := obj.(*corev1.Service)
+				if !ok || len(service.Spec.Ports) != 1 || service.Spec.Ports[0].Port != int32(portNumber2) {
+					t.Errorf("Port not replaced in Service %s/%s", ns, name2)
+				}
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
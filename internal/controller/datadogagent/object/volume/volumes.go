@@ -12,7 +12,7 @@ import (
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 
-	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 )
 
@@ -61,7 +61,7 @@ func GetVolumesEmptyDir(volumeName, mountPath string, readOnly bool) (corev1.Vol
 // common ConfigMapConfig
 
 // GetVolumesFromConfigMap returns a Volume and VolumeMount from a ConfigMapConfig. It is only used in the features that are within the conf.d/ file path
-func GetVolumesFromConfigMap(configMap *v2alpha1.ConfigMapConfig, volumeName, defaultCMName, configFolder string) (corev1.Volume, corev1.VolumeMount) {
+func GetVolumesFromConfigMap(configMap *apicommon.ConfigMapConfig, volumeName, defaultCMName, configFolder string) (corev1.Volume, corev1.VolumeMount) {
 	volume := GetVolumeFromConfigMap(
 		configMap,
 		defaultCMName,
@@ -77,7 +77,7 @@ func GetVolumesFromConfigMap(configMap *v2alpha1.ConfigMapConfig, volumeName, de
 }
 
 // GetVolumeFromConfigMap returns a Volume from a common ConfigMapConfig.
-func GetVolumeFromConfigMap(configMap *v2alpha1.ConfigMapConfig, defaultConfigMapName, volumeName string) corev1.Volume {
+func GetVolumeFromConfigMap(configMap *apicommon.ConfigMapConfig, defaultConfigMapName, volumeName string) corev1.Volume {
 	cmName := defaultConfigMapName
 	if configMap != nil && len(configMap.Name) > 0 {
 		cmName = configMap.Name
@@ -102,7 +102,7 @@ func GetVolumeFromConfigMap(configMap *v2alpha1.ConfigMapConfig, defaultConfigMa
 }
 
 // GetVolumeFromCustomConfig returns a Volume from a v2alpha1 CustomConfig. It is used for agent-level configuration overrides in v2alpha1.
-func GetVolumeFromCustomConfig(customConfig v2alpha1.CustomConfig, defaultConfigMapName, volumeName string) corev1.Volume {
+func GetVolumeFromCustomConfig(customConfig apicommon.CustomConfig, defaultConfigMapName, volumeName string) corev1.Volume {
 	var vol corev1.Volume
 	if customConfig.ConfigMap != nil {
 		vol = GetVolumeFromConfigMap(
@@ -117,7 +117,7 @@ func GetVolumeFromCustomConfig(customConfig v2alpha1.CustomConfig, defaultConfig
 }
 
 // GetVolumeFromMultiCustomConfig returns a Volume from a v2alpha1 MultiCustomConfig. It is used for Extra Checksd and Extra Confd.
-func GetVolumeFromMultiCustomConfig(multiCustomConfig *v2alpha1.MultiCustomConfig, volumeName, configMapName string) corev1.Volume {
+func GetVolumeFromMultiCustomConfig(multiCustomConfig *apicommon.MultiCustomConfig, volumeName, configMapName string) corev1.Volume {
 	var vol corev1.Volume
 	if multiCustomConfig.ConfigMap != nil {
 		vol = corev1.Volume{
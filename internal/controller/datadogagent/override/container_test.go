@@ -838,8 +838,8 @@ func TestContainer(t *testing.T) {
 			},
 			override: v2alpha1.DatadogAgentGenericContainer{
 				SeccompConfig: &v2alpha1.SeccompConfig{
-					CustomProfile: &v2alpha1.CustomConfig{
-						ConfigMap: &v2alpha1.ConfigMapConfig{
+					CustomProfile: &apicommon.CustomConfig{
+						ConfigMap: &apicommon.ConfigMapConfig{
 							Name: "custom-seccomp-profile",
 						},
 					},
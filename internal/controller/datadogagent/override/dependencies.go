@@ -12,6 +12,7 @@ import (
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/util/errors"
 
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	componentdca "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/clusteragent"
 	componentccr "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/clusterchecksrunner"
@@ -88,7 +89,7 @@ func overrideRBAC(logger logr.Logger, manager feature.ResourceManagers, override
 	return errors.NewAggregate(errs)
 }
 
-func overrideCustomConfigs(logger logr.Logger, manager feature.ResourceManagers, customConfigMap map[v2alpha1.AgentConfigFileName]v2alpha1.CustomConfig, componentName v2alpha1.ComponentName, ddaName, namespace string) (errs []error) {
+func overrideCustomConfigs(logger logr.Logger, manager feature.ResourceManagers, customConfigMap map[apicommon.AgentConfigFileName]apicommon.CustomConfig, componentName v2alpha1.ComponentName, ddaName, namespace string) (errs []error) {
 	for fileName, customConfig := range customConfigMap {
 		// Favor ConfigMap setting; if it is specified, then move on
 		if customConfig.ConfigMap != nil {
@@ -119,7 +120,7 @@ func overrideCustomConfigs(logger logr.Logger, manager feature.ResourceManagers,
 	return errs
 }
 
-func overrideExtraConfigs(logger logr.Logger, manager feature.ResourceManagers, multiCustomConfig *v2alpha1.MultiCustomConfig, namespace, configMapName string, isYaml bool) (errs []error) {
+func overrideExtraConfigs(logger logr.Logger, manager feature.ResourceManagers, multiCustomConfig *apicommon.MultiCustomConfig, namespace, configMapName string, isYaml bool) (errs []error) {
 	if multiCustomConfig != nil && multiCustomConfig.ConfigMap == nil && len(multiCustomConfig.ConfigDataMap) > 0 {
 		cm, err := configmap.BuildConfigMapMulti(namespace, multiCustomConfig.ConfigDataMap, configMapName, isYaml)
 		if err != nil {
@@ -143,3 +144,64 @@ func overrideExtraConfigs(logger logr.Logger, manager feature.ResourceManagers,
 	}
 	return errs
 }
+
+// ProfileDependencies creates the ConfigMaps for the custom configurations
+// defined inline in the node agent override of a profile. The ConfigMaps are
+// named after the profile DaemonSet so they don't conflict with the ones of the
+// DatadogAgent, and the override is updated to reference them. A checksum
+// annotation is added to the override so that the pods are rolled out when
+// the configuration changes.
+func ProfileDependencies(logger logr.Logger, manager feature.ResourceManagers, override *v2alpha1.DatadogAgentComponentOverride, namespace string) (errs []error) {
+	if override == nil || override.Name == nil || *override.Name == "" {
+		return nil
+	}
+	profileDSName := *override.Name
+	componentName := v2alpha1.NodeAgentComponentName
+
+	// Don't update the map in place, it's shared with the profile
+	customConfigs := make(map[apicommon.AgentConfigFileName]apicommon.CustomConfig, len(override.CustomConfigurations))
+	for fileName, customConfig := range override.CustomConfigurations {
+		customConfigs[fileName] = customConfig
+	}
+	for _, fileName := range sortKeys(customConfigs) {
+		customConfig := customConfigs[fileName]
+		if customConfig.ConfigMap != nil || customConfig.ConfigData == nil {
+			continue
+		}
+
+		errs = append(errs, overrideCustomConfigs(logger, manager, map[apicommon.AgentConfigFileName]apicommon.CustomConfig{fileName: customConfig}, componentName, profileDSName, namespace)...)
+
+		configMapName := fmt.Sprintf("%s-%s", getDefaultConfigMapName(profileDSName, string(fileName)), strings.ToLower(string(componentName)))
+		addProfileChecksumAnnotation(logger, override, configMapName, customConfig)
+		customConfigs[fileName] = apicommon.CustomConfig{
+			ConfigMap: &apicommon.ConfigMapConfig{Name: configMapName},
+		}
+	}
+	if len(customConfigs) > 0 {
+		override.CustomConfigurations = customConfigs
+	}
+
+	if override.ExtraConfd != nil && override.ExtraConfd.ConfigMap == nil && len(override.ExtraConfd.ConfigDataMap) > 0 {
+		configMapName := fmt.Sprintf(extraConfdConfigMapName, profileDSName)
+		errs = append(errs, overrideExtraConfigs(logger, manager, override.ExtraConfd, namespace, configMapName, true)...)
+
+		addProfileChecksumAnnotation(logger, override, configMapName, override.ExtraConfd)
+		override.ExtraConfd = &apicommon.MultiCustomConfig{
+			ConfigMap: &apicommon.ConfigMapConfig{Name: configMapName},
+		}
+	}
+
+	return errs
+}
+
+func addProfileChecksumAnnotation(logger logr.Logger, override *v2alpha1.DatadogAgentComponentOverride, configMapName string, spec interface{}) {
+	hash, err := comparison.GenerateMD5ForSpec(spec)
+	if err != nil {
+		logger.Error(err, "couldn't generate hash for profile custom config", "configmap", configMapName)
+		return
+	}
+	if override.Annotations == nil {
+		override.Annotations = map[string]string{}
+	}
+	override.Annotations[object.GetChecksumAnnotationKey(configMapName)] = hash
+}
@@ -8,10 +8,13 @@ package override
 import (
 	"testing"
 
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/store"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -49,8 +52,8 @@ func TestDependencies(t *testing.T) {
 				Spec: v2alpha1.DatadogAgentSpec{
 					Override: map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
 						v2alpha1.NodeAgentComponentName: {
-							ExtraConfd: &v2alpha1.MultiCustomConfig{
-								ConfigMap: &v2alpha1.ConfigMapConfig{
+							ExtraConfd: &apicommon.MultiCustomConfig{
+								ConfigMap: &apicommon.ConfigMapConfig{
 									Name: "cmName",
 								},
 							},
@@ -66,7 +69,7 @@ func TestDependencies(t *testing.T) {
 				Spec: v2alpha1.DatadogAgentSpec{
 					Override: map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
 						v2alpha1.NodeAgentComponentName: {
-							ExtraConfd: &v2alpha1.MultiCustomConfig{
+							ExtraConfd: &apicommon.MultiCustomConfig{
 								ConfigDataMap: map[string]string{
 									"path_to_file.yaml": "yaml: data",
 								},
@@ -83,8 +86,8 @@ func TestDependencies(t *testing.T) {
 				Spec: v2alpha1.DatadogAgentSpec{
 					Override: map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
 						v2alpha1.NodeAgentComponentName: {
-							ExtraChecksd: &v2alpha1.MultiCustomConfig{
-								ConfigMap: &v2alpha1.ConfigMapConfig{
+							ExtraChecksd: &apicommon.MultiCustomConfig{
+								ConfigMap: &apicommon.ConfigMapConfig{
 									Name: "cmName",
 								},
 							},
@@ -100,7 +103,7 @@ func TestDependencies(t *testing.T) {
 				Spec: v2alpha1.DatadogAgentSpec{
 					Override: map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
 						v2alpha1.NodeAgentComponentName: {
-							ExtraChecksd: &v2alpha1.MultiCustomConfig{
+							ExtraChecksd: &apicommon.MultiCustomConfig{
 								ConfigDataMap: map[string]string{
 									"path_to_file.py": "print('hello')",
 								},
@@ -170,3 +173,63 @@ func TestDependencies(t *testing.T) {
 		})
 	}
 }
+
+func TestProfileDependencies(t *testing.T) {
+	testLogger := logf.Log.WithName("TestProfileDependencies")
+
+	testScheme := runtime.NewScheme()
+	testScheme.AddKnownTypes(v2alpha1.GroupVersion, &v2alpha1.DatadogAgent{})
+	storeOptions := &store.StoreOptions{
+		Scheme: testScheme,
+	}
+
+	profileDSName := "datadog-agent-with-profile-default-gpu"
+	customConfigs := map[apicommon.AgentConfigFileName]apicommon.CustomConfig{
+		apicommon.AgentGeneralConfigFile: {
+			ConfigData: apiutils.NewStringPointer("logs_config:\n  run_path: /var/lib/datadog"),
+		},
+		apicommon.SystemProbeConfigFile: {
+			ConfigMap: &apicommon.ConfigMapConfig{Name: "user-system-probe"},
+		},
+	}
+	profileOverride := &v2alpha1.DatadogAgentComponentOverride{
+		Name:                 &profileDSName,
+		CustomConfigurations: customConfigs,
+		ExtraConfd: &apicommon.MultiCustomConfig{
+			ConfigDataMap: map[string]string{
+				"nvidia.yaml": "instances: [{}]",
+			},
+		},
+	}
+
+	dda := v2alpha1.DatadogAgent{}
+	store := store.NewStore(&dda, storeOptions)
+	manager := feature.NewResourceManagers(store)
+
+	errs := ProfileDependencies(testLogger, manager, profileOverride, "default")
+	assert.Empty(t, errs)
+
+	datadogYamlCMName := profileDSName + "-datadog-yaml-nodeagent"
+	extraConfdCMName := profileDSName + "-extra-confd"
+	_, found := store.Get(kubernetes.ConfigMapKind, "default", datadogYamlCMName)
+	assert.True(t, found)
+	_, found = store.Get(kubernetes.ConfigMapKind, "default", extraConfdCMName)
+	assert.True(t, found)
+
+	assert.Equal(t, map[apicommon.AgentConfigFileName]apicommon.CustomConfig{
+		apicommon.AgentGeneralConfigFile: {
+			ConfigMap: &apicommon.ConfigMapConfig{Name: datadogYamlCMName},
+		},
+		apicommon.SystemProbeConfigFile: {
+			ConfigMap: &apicommon.ConfigMapConfig{Name: "user-system-probe"},
+		},
+	}, profileOverride.CustomConfigurations)
+	assert.Equal(t, &apicommon.MultiCustomConfig{
+		ConfigMap: &apicommon.ConfigMapConfig{Name: extraConfdCMName},
+	}, profileOverride.ExtraConfd)
+	assert.Contains(t, profileOverride.Annotations, object.GetChecksumAnnotationKey(datadogYamlCMName))
+	assert.Contains(t, profileOverride.Annotations, object.GetChecksumAnnotationKey(extraConfdCMName))
+
+	// The map of the profile is left untouched
+	assert.NotNil(t, customConfigs[apicommon.AgentGeneralConfigFile].ConfigData)
+}
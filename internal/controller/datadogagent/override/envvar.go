@@ -28,4 +28,10 @@ const (
 	DDTags                         = "DD_TAGS"
 	DockerHost                     = "DOCKER_HOST"
 	DDLogLevel                     = "DD_LOG_LEVEL"
+	DDAdditionalEndpoints          = "DD_ADDITIONAL_ENDPOINTS"
+	DDAPMDDURL                     = "DD_APM_DD_URL"
+	DDLogsConfigLogsDDURL          = "DD_LOGS_CONFIG_LOGS_DD_URL"
+	DDProcessConfigProcessDDURL    = "DD_PROCESS_CONFIG_PROCESS_DD_URL"
+	ProxyUsername                  = "PROXY_USERNAME"
+	ProxyPassword                  = "PROXY_PASSWORD"
 )
@@ -168,8 +168,8 @@ defaults
 			dda: testutils.NewDatadogAgentBuilder().
 				WithFIPS(v2alpha1.FIPSConfig{
 					Enabled: apiutils.NewBoolPointer(true),
-					CustomFIPSConfig: &v2alpha1.CustomConfig{
-						ConfigMap: &v2alpha1.ConfigMapConfig{
+					CustomFIPSConfig: &apicommon.CustomConfig{
+						ConfigMap: &apicommon.ConfigMapConfig{
 							Name: "foo",
 							Items: []corev1.KeyToPath{
 								{
@@ -209,7 +209,7 @@ defaults
 			dda: testutils.NewDatadogAgentBuilder().
 				WithFIPS(v2alpha1.FIPSConfig{
 					Enabled: apiutils.NewBoolPointer(true),
-					CustomFIPSConfig: &v2alpha1.CustomConfig{
+					CustomFIPSConfig: &apicommon.CustomConfig{
 						ConfigData: apiutils.NewStringPointer(customConfig),
 					},
 				}).
@@ -11,9 +11,11 @@ import (
 	"path"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -23,7 +25,10 @@ import (
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/common"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/objects"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature"
+	featutils "github.com/DataDog/datadog-operator/internal/controller/datadogagent/feature/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object/volume"
+	anp "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+	calico "github.com/DataDog/datadog-operator/pkg/calico/v3"
 	"github.com/DataDog/datadog-operator/pkg/constants"
 	"github.com/DataDog/datadog-operator/pkg/defaulting"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
@@ -71,6 +76,21 @@ func applyGlobalSettings(logger logr.Logger, manager feature.PodTemplateManagers
 		})
 	}
 
+	// AdditionalEndpoints configures the intake endpoints the metrics are sent to, on top of the main one.
+	// The other products are configured by their features.
+	if config.AdditionalEndpoints != nil {
+		for _, envVar := range featutils.AdditionalEndpointsEnvVars(DDAdditionalEndpoints, config.AdditionalEndpoints.Metrics) {
+			manager.EnvVar().AddEnvVar(envVar)
+		}
+	}
+
+	// Proxy configures the HTTP(S) proxy used to reach Datadog.
+	if config.Proxy != nil {
+		for _, envVar := range proxyEnvVars(config.Proxy) {
+			manager.EnvVar().AddEnvVar(envVar)
+		}
+	}
+
 	// Registry is the image registry to use for all Agent images.
 	if *config.Registry != defaulting.DefaultImageRegistry {
 		image := defaulting.DefaultAgentImageName
@@ -100,9 +120,16 @@ func applyGlobalSettings(logger logr.Logger, manager feature.PodTemplateManagers
 	if config.NetworkPolicy != nil {
 		if apiutils.BoolValue(config.NetworkPolicy.Create) {
 			var err error
+			additionalIngress, additionalEgress := objects.NetworkPolicyAdditionalRules(dda, componentName)
 			switch config.NetworkPolicy.Flavor {
 			case v2alpha1.NetworkPolicyFlavorKubernetes:
-				err = resourcesManager.NetworkPolicyManager().AddKubernetesNetworkPolicy(objects.BuildKubernetesNetworkPolicy(dda, componentName))
+				for _, rules := range [][]v2alpha1.NetworkPolicyRule{additionalIngress, additionalEgress} {
+					for _, rule := range objects.KubernetesRulesWithDomainNames(rules) {
+						logger.Info("Kubernetes network policies can't select domain names, the rule is restricted to its IP blocks and selectors, or skipped if it has none. Use IP blocks or another network policy flavor to allow these domains",
+							"component", componentName, "domainNames", rule.DomainNames, "ports", rule.Ports)
+					}
+				}
+				err = resourcesManager.NetworkPolicyManager().AddKubernetesNetworkPolicy(objects.BuildKubernetesNetworkPolicy(dda, componentName, additionalIngress, additionalEgress))
 			case v2alpha1.NetworkPolicyFlavorCilium:
 				var ddURL string
 				var dnsSelectorEndpoints []metav1.LabelSelector
@@ -120,8 +147,33 @@ func applyGlobalSettings(logger logr.Logger, manager feature.PodTemplateManagers
 						constants.IsHostNetworkEnabled(dda, v2alpha1.ClusterAgentComponentName),
 						dnsSelectorEndpoints,
 						componentName,
+						additionalIngress,
+						additionalEgress,
 					),
 				)
+			case v2alpha1.NetworkPolicyFlavorCalico:
+				platformInfo := resourcesManager.Store().GetPlatformInfo()
+				if !platformInfo.IsResourceSupported(calico.GlobalNetworkPolicyKind) {
+					err = fmt.Errorf("the %s flavor requires the %s resource", config.NetworkPolicy.Flavor, calico.GlobalNetworkPolicyKind)
+					break
+				}
+				err = resourcesManager.CalicoPolicyManager().AddCalicoPolicy(objects.BuildCalicoPolicy(dda, componentName, additionalIngress, additionalEgress))
+			case v2alpha1.NetworkPolicyFlavorAdminNetworkPolicy:
+				platformInfo := resourcesManager.Store().GetPlatformInfo()
+				if !platformInfo.IsResourceSupported(anp.AdminNetworkPolicyKind) {
+					err = fmt.Errorf("the %s flavor requires the %s resource", config.NetworkPolicy.Flavor, anp.AdminNetworkPolicyKind)
+					break
+				}
+				// The admin network policy restricts the egress traffic, the ingress traffic is restricted by the kubernetes network policy
+				for _, rule := range objects.KubernetesRulesWithDomainNames(additionalIngress) {
+					logger.Info("Kubernetes network policies can't select domain names, the ingress rule is restricted to its IP blocks and selectors, or skipped if it has none",
+						"component", componentName, "domainNames", rule.DomainNames, "ports", rule.Ports)
+				}
+				policyName, namespace, podSelector, _, ingress, _ := objects.BuildKubernetesNetworkPolicy(dda, componentName, additionalIngress, nil)
+				err = resourcesManager.NetworkPolicyManager().AddKubernetesNetworkPolicy(policyName, namespace, podSelector, []netv1.PolicyType{netv1.PolicyTypeIngress}, ingress, nil)
+				if err == nil {
+					err = resourcesManager.AdminNetworkPolicyManager().AddAdminNetworkPolicy(objects.BuildAdminNetworkPolicy(dda, componentName, additionalEgress))
+				}
 			}
 			if err != nil {
 				logger.Error(err, "Error adding Network Policy to the store")
@@ -435,3 +487,58 @@ func applyGlobalSettings(logger logr.Logger, manager feature.PodTemplateManagers
 
 	return manager.PodTemplateSpec()
 }
+
+// proxyEnvVars returns the environment variables configuring the Agent proxy.
+// The proxy credentials are read from secrets and referenced in the proxy URLs.
+func proxyEnvVars(proxy *v2alpha1.ProxyConfig) []*corev1.EnvVar {
+	var envVars []*corev1.EnvVar
+
+	var credentials string
+	if proxy.UsernameSecret != nil {
+		envVars = append(envVars, common.BuildEnvVarFromSource(ProxyUsername, common.BuildEnvVarFromSecret(proxy.UsernameSecret.SecretName, proxy.UsernameSecret.KeyName)))
+		credentials = fmt.Sprintf("$(%s)", ProxyUsername)
+		if proxy.PasswordSecret != nil {
+			envVars = append(envVars, common.BuildEnvVarFromSource(ProxyPassword, common.BuildEnvVarFromSecret(proxy.PasswordSecret.SecretName, proxy.PasswordSecret.KeyName)))
+			credentials += fmt.Sprintf(":$(%s)", ProxyPassword)
+		}
+	}
+
+	if proxy.HTTP != nil {
+		envVars = append(envVars, &corev1.EnvVar{Name: constants.DDProxyHTTP, Value: proxyURLWithCredentials(*proxy.HTTP, credentials)})
+	}
+	if proxy.HTTPS != nil {
+		envVars = append(envVars, &corev1.EnvVar{Name: constants.DDProxyHTTPS, Value: proxyURLWithCredentials(*proxy.HTTPS, credentials)})
+	}
+	if len(proxy.NoProxy) > 0 {
+		envVars = append(envVars, &corev1.EnvVar{Name: constants.DDProxyNoProxy, Value: strings.Join(proxy.NoProxy, " ")})
+	}
+
+	// Per-product intake URLs
+	for _, product := range []struct {
+		envVar string
+		config *v2alpha1.ProductIntakeConfig
+	}{
+		{envVar: DDLogsConfigLogsDDURL, config: proxy.Logs},
+		{envVar: DDAPMDDURL, config: proxy.APM},
+		{envVar: DDProcessConfigProcessDDURL, config: proxy.Process},
+	} {
+		if product.config != nil && product.config.URL != nil {
+			envVars = append(envVars, &corev1.EnvVar{Name: product.envVar, Value: *product.config.URL})
+		}
+	}
+
+	return envVars
+}
+
+// proxyURLWithCredentials adds the credentials to the user info of the proxy URL.
+// The credentials reference environment variables expanded as is, so the secrets hold URL-encoded values.
+func proxyURLWithCredentials(proxyURL, credentials string) string {
+	if credentials == "" {
+		return proxyURL
+	}
+	scheme, host, found := strings.Cut(proxyURL, "://")
+	if !found {
+		return proxyURL
+	}
+	return fmt.Sprintf("%s://%s@%s", scheme, credentials, host)
+}
@@ -185,7 +185,7 @@ func PodTemplateSpec(logger logr.Logger, manager feature.PodTemplateManagers, ov
 	}
 }
 
-func overrideCustomConfigVolumes(logger logr.Logger, manager feature.PodTemplateManagers, customConfs map[v2alpha1.AgentConfigFileName]v2alpha1.CustomConfig, componentName v2alpha1.ComponentName, ddaName string) {
+func overrideCustomConfigVolumes(logger logr.Logger, manager feature.PodTemplateManagers, customConfs map[apicommon.AgentConfigFileName]apicommon.CustomConfig, componentName v2alpha1.ComponentName, ddaName string) {
 	sortedKeys := sortKeys(customConfs)
 	for _, fileName := range sortedKeys {
 		customConfig := customConfs[fileName]
@@ -406,8 +406,8 @@ func mergePodAntiAffinities(affinity1 *v1.PodAntiAffinity, affinity2 *v1.PodAnti
 	return merged
 }
 
-func sortKeys(keysMap map[v2alpha1.AgentConfigFileName]v2alpha1.CustomConfig) []v2alpha1.AgentConfigFileName {
-	sortedKeys := make([]v2alpha1.AgentConfigFileName, 0, len(keysMap))
+func sortKeys(keysMap map[apicommon.AgentConfigFileName]apicommon.CustomConfig) []apicommon.AgentConfigFileName {
+	sortedKeys := make([]apicommon.AgentConfigFileName, 0, len(keysMap))
 	for key := range keysMap {
 		sortedKeys = append(sortedKeys, key)
 	}
@@ -459,9 +459,9 @@ func TestPodTemplateSpec(t *testing.T) {
 				return fake.NewPodTemplateManagers(t, v1.PodTemplateSpec{})
 			},
 			override: v2alpha1.DatadogAgentComponentOverride{
-				CustomConfigurations: map[v2alpha1.AgentConfigFileName]v2alpha1.CustomConfig{
-					v2alpha1.AgentGeneralConfigFile: {
-						ConfigMap: &v2alpha1.ConfigMapConfig{
+				CustomConfigurations: map[apicommon.AgentConfigFileName]apicommon.CustomConfig{
+					apicommon.AgentGeneralConfigFile: {
+						ConfigMap: &apicommon.ConfigMapConfig{
 							Name: "custom-config",
 						},
 					},
@@ -470,7 +470,7 @@ func TestPodTemplateSpec(t *testing.T) {
 			validateManager: func(t *testing.T, manager *fake.PodTemplateManagers) {
 				found := false
 				for _, vol := range manager.VolumeMgr.Volumes {
-					if vol.Name == fmt.Sprintf("%s-%s", getDefaultConfigMapName("datadog-agent", string(v2alpha1.AgentGeneralConfigFile)), strings.ToLower(string(v2alpha1.NodeAgentComponentName))) {
+					if vol.Name == fmt.Sprintf("%s-%s", getDefaultConfigMapName("datadog-agent", string(apicommon.AgentGeneralConfigFile)), strings.ToLower(string(v2alpha1.NodeAgentComponentName))) {
 						found = true
 						break
 					}
@@ -484,8 +484,8 @@ func TestPodTemplateSpec(t *testing.T) {
 				return fake.NewPodTemplateManagers(t, v1.PodTemplateSpec{})
 			},
 			override: v2alpha1.DatadogAgentComponentOverride{
-				ExtraConfd: &v2alpha1.MultiCustomConfig{
-					ConfigMap: &v2alpha1.ConfigMapConfig{
+				ExtraConfd: &apicommon.MultiCustomConfig{
+					ConfigMap: &apicommon.ConfigMapConfig{
 						Name: "extra-confd",
 					},
 				},
@@ -507,7 +507,7 @@ func TestPodTemplateSpec(t *testing.T) {
 				return fake.NewPodTemplateManagers(t, v1.PodTemplateSpec{})
 			},
 			override: v2alpha1.DatadogAgentComponentOverride{
-				ExtraConfd: &v2alpha1.MultiCustomConfig{
+				ExtraConfd: &apicommon.MultiCustomConfig{
 					ConfigDataMap: map[string]string{
 						"path_to_file.yaml": "yaml: data",
 					},
@@ -530,8 +530,8 @@ func TestPodTemplateSpec(t *testing.T) {
 				return fake.NewPodTemplateManagers(t, v1.PodTemplateSpec{})
 			},
 			override: v2alpha1.DatadogAgentComponentOverride{
-				ExtraChecksd: &v2alpha1.MultiCustomConfig{
-					ConfigMap: &v2alpha1.ConfigMapConfig{
+				ExtraChecksd: &apicommon.MultiCustomConfig{
+					ConfigMap: &apicommon.ConfigMapConfig{
 						Name: "extra-checksd",
 					},
 				},
@@ -553,7 +553,7 @@ func TestPodTemplateSpec(t *testing.T) {
 				return fake.NewPodTemplateManagers(t, v1.PodTemplateSpec{})
 			},
 			override: v2alpha1.DatadogAgentComponentOverride{
-				ExtraChecksd: &v2alpha1.MultiCustomConfig{
+				ExtraChecksd: &apicommon.MultiCustomConfig{
 					ConfigDataMap: map[string]string{
 						"path_to_file.py": "print('hello')",
 					},
@@ -219,8 +219,10 @@ func (ds *Store) Apply(ctx context.Context, k8sClient client.Client) []error {
 				objStore.(*v1.Service).Spec.ClusterIPs = objAPIServer.(*v1.Service).Spec.ClusterIPs
 				objStore.SetResourceVersion(objAPIServer.GetResourceVersion())
 			}
-			// The APIServiceKind and CiliumNetworkPoliciesKind resource version must be set.
-			if kind == kubernetes.APIServiceKind || kind == kubernetes.CiliumNetworkPoliciesKind {
+			// The resource version of the APIServiceKind and of the unstructured objects must be set.
+			switch kind {
+			case kubernetes.APIServiceKind, kubernetes.CiliumNetworkPoliciesKind, kubernetes.CalicoGlobalNetworkPoliciesKind, kubernetes.AdminNetworkPoliciesKind,
+				kubernetes.CertManagerIssuersKind, kubernetes.CertManagerCertificatesKind:
 				objStore.SetResourceVersion(objAPIServer.GetResourceVersion())
 			}
 
@@ -413,6 +415,10 @@ func shouldSetOwnerReference(kind kubernetes.ObjectKind, objNamespace, ownerName
 		return false
 	case kubernetes.APIServiceKind:
 		return false
+	case kubernetes.CalicoGlobalNetworkPoliciesKind:
+		return false
+	case kubernetes.AdminNetworkPoliciesKind:
+		return false
 	}
 
 	// Owner-reference should not be added to namespaced resources in a different namespace than the owner
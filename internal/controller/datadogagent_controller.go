@@ -30,11 +30,16 @@ import (
 
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
 	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/object"
 	"github.com/DataDog/datadog-operator/internal/controller/metrics"
+	anp "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+	calico "github.com/DataDog/datadog-operator/pkg/calico/v3"
+	certmanager "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/prometheusoperator"
 )
 
 // DatadogAgentReconciler reconciles a DatadogAgent object.
@@ -98,6 +103,13 @@ type DatadogAgentReconciler struct {
 // Use CiliumNetworkPolicy
 // +kubebuilder:rbac:groups=cilium.io,resources=ciliumnetworkpolicies,verbs=get;list;watch;create;update;patch;delete
 
+// Use Calico GlobalNetworkPolicy and AdminNetworkPolicy
+// +kubebuilder:rbac:groups=projectcalico.org,resources=globalnetworkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy.networking.k8s.io,resources=adminnetworkpolicies,verbs=get;list;watch;create;update;patch;delete
+
+// Use cert-manager Issuers and Certificates for the Cluster Agent TLS
+// +kubebuilder:rbac:groups=cert-manager.io,resources=issuers;certificates,verbs=get;list;watch;create;update;patch;delete
+
 // OpenShift
 // +kubebuilder:rbac:groups=quota.openshift.io,resources=clusterresourcequotas,verbs=get;list
 // +kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,resourceNames=restricted,verbs=use
@@ -182,6 +194,13 @@ type DatadogAgentReconciler struct {
 // Profiles
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=list;watch;patch
 
+// Instrumentations
+// +kubebuilder:rbac:groups=datadoghq.com,resources=datadoginstrumentations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=datadoghq.com,resources=datadoginstrumentations/status,verbs=get;update;patch
+
+// Prometheus Operator
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=get;list;watch
+
 // Reconcile loop for DatadogAgent.
 func (r *DatadogAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	return r.internal.Reconcile(ctx, req)
@@ -189,29 +208,43 @@ func (r *DatadogAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 // SetupWithManager creates a new DatadogAgent controller.
 func (r *DatadogAgentReconciler) SetupWithManager(mgr ctrl.Manager, metricForwardersMgr datadog.MetricForwardersManager) error {
+	// Owned objects and DatadogAgents only trigger a reconcile on spec changes.
+	// The predicate is set per watch rather than as a global event filter so
+	// that the node and profile watches below can use their own predicates.
+	generationChanged := ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{})
+
 	builder := ctrl.NewControllerManagedBy(mgr).
-		Owns(&corev1.Secret{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&appsv1.DaemonSet{}).
-		Owns(&appsv1.Deployment{}).
-		Owns(&rbacv1.Role{}).
-		Owns(&rbacv1.RoleBinding{}).
-		Owns(&corev1.ServiceAccount{}).
+		Owns(&corev1.Secret{}, generationChanged).
+		Owns(&corev1.ConfigMap{}, generationChanged).
+		Owns(&appsv1.DaemonSet{}, generationChanged).
+		Owns(&appsv1.Deployment{}, generationChanged).
+		Owns(&rbacv1.Role{}, generationChanged).
+		Owns(&rbacv1.RoleBinding{}, generationChanged).
+		Owns(&corev1.ServiceAccount{}, generationChanged).
 		// We let PlatformInfo supply PDB object based on the current API version
-		Owns(r.PlatformInfo.CreatePDBObject()).
-		Owns(&networkingv1.NetworkPolicy{})
+		Owns(r.PlatformInfo.CreatePDBObject(), generationChanged).
+		Owns(&networkingv1.NetworkPolicy{}, generationChanged)
 
+	// Reconcile all DatadogAgents when a profile spec changes. The status of the
+	// profiles is updated by the DatadogAgentProfile controller and doesn't
+	// trigger a reconcile: the nodes a profile is applied to are computed from
+	// the nodes, which are watched below.
 	if r.Options.DatadogAgentProfileEnabled {
 		builder.Watches(
 			&datadoghqv1alpha1.DatadogAgentProfile{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsForAllDDAs()),
-			ctrlbuilder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.Funcs{
-				DeleteFunc: func(e event.DeleteEvent) bool {
-					metrics.CleanupMetricsByProfile(e.Object)
-					return true
-				},
-			}),
-			))
+			ctrlbuilder.WithPredicates(enqueueIfProfileSpecChanges()),
+		)
+	}
+
+	// Reconcile all DatadogAgents when a DatadogInstrumentation spec changes, as
+	// they are merged into the Single Step Instrumentation targets.
+	if r.Options.DatadogInstrumentationEnabled {
+		builder.Watches(
+			&datadoghqv1alpha1.DatadogInstrumentation{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsForAllDDAs()),
+			ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		)
 	}
 
 	// Watch nodes and reconcile all DatadogAgents for node creation, node deletion, and node label change events
@@ -219,7 +252,7 @@ func (r *DatadogAgentReconciler) SetupWithManager(mgr ctrl.Manager, metricForwar
 		builder.Watches(
 			&corev1.Node{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsForAllDDAs()),
-			ctrlbuilder.WithPredicates(r.enqueueIfNodeLabelsChange()),
+			ctrlbuilder.WithPredicates(enqueueIfNodeLabelsChange()),
 		)
 	}
 
@@ -227,11 +260,11 @@ func (r *DatadogAgentReconciler) SetupWithManager(mgr ctrl.Manager, metricForwar
 	// cluster-scoped. That means that DatadogAgent cannot be their owner, and
 	// we cannot use .Owns().
 	handlerEnqueue := handler.EnqueueRequestsFromMapFunc(enqueueIfOwnedByDatadogAgent)
-	builder.Watches(&rbacv1.ClusterRole{}, handlerEnqueue)
-	builder.Watches(&rbacv1.ClusterRoleBinding{}, handlerEnqueue)
+	builder.Watches(&rbacv1.ClusterRole{}, handlerEnqueue, generationChanged)
+	builder.Watches(&rbacv1.ClusterRoleBinding{}, handlerEnqueue, generationChanged)
 
 	if r.Options.ExtendedDaemonsetOptions.Enabled {
-		builder = builder.Owns(&edsdatadoghqv1alpha1.ExtendedDaemonSet{})
+		builder = builder.Owns(&edsdatadoghqv1alpha1.ExtendedDaemonSet{}, generationChanged)
 	}
 
 	if r.Options.SupportCilium {
@@ -241,10 +274,37 @@ func (r *DatadogAgentReconciler) SetupWithManager(mgr ctrl.Manager, metricForwar
 			Version: "v2",
 			Kind:    "CiliumNetworkPolicy",
 		})
-		builder = builder.Owns(policy)
+		builder = builder.Owns(policy, generationChanged)
+	}
+
+	// Calico global network policies and admin network policies are cluster-scoped, they are watched like ClusterRoles.
+	if r.PlatformInfo.IsResourceSupported(calico.GlobalNetworkPolicyKind) {
+		builder.Watches(calico.EmptyCalicoUnstructuredPolicy(), handlerEnqueue, generationChanged)
+	}
+	if r.PlatformInfo.IsResourceSupported(anp.AdminNetworkPolicyKind) {
+		builder.Watches(anp.EmptyAdminNetworkPolicyUnstructuredPolicy(), handlerEnqueue, generationChanged)
+	}
+	// ServiceMonitors are translated into checks of all the DatadogAgents enabling the Prometheus Operator translation.
+	if r.PlatformInfo.IsResourceSupported(prometheusoperator.ServiceMonitorKind) {
+		builder.Watches(
+			prometheusoperator.EmptyServiceMonitorUnstructured(),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsForAllDDAs()),
+			generationChanged,
+		)
+		// Services selected by the ServiceMonitors are resolved during the reconcile.
+		builder.Watches(
+			&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueRequestsForPrometheusOperatorDDAs()),
+			ctrlbuilder.WithPredicates(enqueueIfServiceEndpointsChange()),
+		)
+	}
+	if r.PlatformInfo.IsResourceSupported(certmanager.CertificateKind) {
+		builder = builder.Owns(certmanager.EmptyIssuerUnstructured(), generationChanged)
+		builder = builder.Owns(certmanager.EmptyCertificateUnstructured(), generationChanged)
 	}
 
-	var builderOptions []ctrlbuilder.ForOption
+	// Annotation changes are watched to apply approved Remote Configuration proposals.
+	builderOptions := []ctrlbuilder.ForOption{ctrlbuilder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))}
 	if r.Options.OperatorMetricsEnabled {
 		builderOptions = append(builderOptions, ctrlbuilder.WithPredicates(predicate.Funcs{
 			// On `DatadogAgent` object creation, we register a metrics forwarder for it.
@@ -255,7 +315,7 @@ func (r *DatadogAgentReconciler) SetupWithManager(mgr ctrl.Manager, metricForwar
 		}))
 	}
 
-	if err := builder.For(&datadoghqv2alpha1.DatadogAgent{}, builderOptions...).WithEventFilter(predicate.GenerationChangedPredicate{}).Complete(r); err != nil {
+	if err := builder.For(&datadoghqv2alpha1.DatadogAgent{}, builderOptions...).Complete(r); err != nil {
 		return err
 	}
 
@@ -281,7 +341,46 @@ func enqueueIfOwnedByDatadogAgent(ctx context.Context, obj client.Object) []reco
 	return []reconcile.Request{{NamespacedName: owner}}
 }
 
-func (r *DatadogAgentReconciler) enqueueIfNodeLabelsChange() predicate.Funcs {
+// enqueueIfServiceEndpointsChange triggers a reconcile when a Service is
+// created or deleted, or when the labels or ports used to translate the
+// ServiceMonitors into checks change.
+func enqueueIfServiceEndpointsChange() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldService, okOld := e.ObjectOld.(*corev1.Service)
+			newService, okNew := e.ObjectNew.(*corev1.Service)
+			if !okOld || !okNew {
+				return false
+			}
+			return !reflect.DeepEqual(oldService.Labels, newService.Labels) || !reflect.DeepEqual(oldService.Spec.Ports, newService.Spec.Ports)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// enqueueIfProfileSpecChanges triggers a reconcile when a profile is created,
+// deleted or when its spec changes, but not on status updates.
+func enqueueIfProfileSpecChanges() predicate.Predicate {
+	return predicate.Or(predicate.GenerationChangedPredicate{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			metrics.CleanupMetricsByProfile(e.Object)
+			return true
+		},
+	})
+}
+
+func enqueueIfNodeLabelsChange() predicate.Funcs {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
@@ -295,6 +394,32 @@ func (r *DatadogAgentReconciler) enqueueIfNodeLabelsChange() predicate.Funcs {
 	}
 }
 
+// enqueueRequestsForPrometheusOperatorDDAs enqueues the DatadogAgents translating
+// the Prometheus Operator ServiceMonitors into checks.
+func (r *DatadogAgentReconciler) enqueueRequestsForPrometheusOperatorDDAs() handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var requests []reconcile.Request
+
+		ddaList := datadoghqv2alpha1.DatadogAgentList{}
+		if err := r.List(ctx, &ddaList); err != nil {
+			return requests
+		}
+
+		for _, dda := range ddaList.Items {
+			features := dda.Spec.Features
+			if features == nil || features.PrometheusScrape == nil || features.PrometheusScrape.PrometheusOperator == nil {
+				continue
+			}
+			if !apiutils.BoolValue(features.PrometheusScrape.Enabled) || !apiutils.BoolValue(features.PrometheusScrape.PrometheusOperator.Enabled) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: dda.Namespace, Name: dda.Name}})
+		}
+
+		return requests
+	}
+}
+
 func (r *DatadogAgentReconciler) enqueueRequestsForAllDDAs() handler.MapFunc {
 	return func(ctx context.Context, obj client.Object) []reconcile.Request {
 		var requests []reconcile.Request
@@ -0,0 +1,32 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+)
+
+func Test_enqueueIfProfileSpecChanges(t *testing.T) {
+	profile := &datadoghqv1alpha1.DatadogAgentProfile{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar", Generation: 1},
+	}
+	statusUpdated := profile.DeepCopy()
+	statusUpdated.Status.WonNodes = []string{"node1"}
+	specUpdated := profile.DeepCopy()
+	specUpdated.Generation = 2
+
+	p := enqueueIfProfileSpecChanges()
+	assert.True(t, p.Create(event.CreateEvent{Object: profile}))
+	assert.True(t, p.Delete(event.DeleteEvent{Object: profile}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: profile, ObjectNew: specUpdated}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: profile, ObjectNew: statusUpdated}))
+}
@@ -7,48 +7,240 @@ package datadoghq
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/agent"
+	"github.com/DataDog/datadog-operator/internal/controller/metrics"
+	"github.com/DataDog/datadog-operator/pkg/agentprofile"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/tracing"
+)
+
+const (
+	datadogAgentProfileKind = "DatadogAgentProfile"
+
+	// createStrategyRequeuePeriod is the period at which the status of a
+	// profile is refreshed while its nodes are labeled by the create strategy.
+	createStrategyRequeuePeriod = 15 * time.Second
 )
 
 // Reconciler reconciles a DatadogAgentProfile object
 type Reconciler struct {
-	client client.Client
-	scheme *runtime.Scheme
-	log    logr.Logger
+	client     client.Client
+	scheme     *runtime.Scheme
+	log        logr.Logger
+	edsOptions *agent.ExtendedDaemonsetOptions
 }
 
 //+kubebuilder:rbac:groups=datadoghq.com,resources=datadogagentprofiles,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=datadoghq.com,resources=datadogagentprofiles/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=datadoghq.com,resources=datadogagentprofiles/finalizers,verbs=update
+//+kubebuilder:rbac:groups=datadoghq.com,resources=datadogagents,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch
 
 // NewReconciler returns a new Reconciler object
-func NewReconciler(client client.Client, scheme *runtime.Scheme, log logr.Logger) (*Reconciler, error) {
+func NewReconciler(client client.Client, scheme *runtime.Scheme, log logr.Logger, edsOptions *agent.ExtendedDaemonsetOptions) (*Reconciler, error) {
 	return &Reconciler{
-		client: client,
-		scheme: scheme,
-		log:    log,
+		client:     client,
+		scheme:     scheme,
+		log:        log,
+		edsOptions: edsOptions,
 	}, nil
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
-	// _ = log.FromContext(ctx)
-
-	// // TODO(user): your logic here
-
-	// return ctrl.Result{}, nil
-	return r.internalReconcile(ctx, req)
+	ctx, span := tracing.StartSpan(ctx, "DatadogAgentProfile.Reconcile", tracing.RequestAttributes(datadogAgentProfileKind, req.Namespace, req.Name)...)
+	res, err := r.internalReconcile(ctx, req)
+	tracing.EndSpan(span, err)
+	return res, err
 }
 
+// internalReconcile validates the profile and computes the nodes it matches,
+// wins and loses against the other profiles. The profile is applied to the
+// nodes by the DatadogAgent reconciler, which watches the profiles. This
+// controller is the only one updating the status of the profiles.
 func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
 	reqLogger := r.log.WithValues("datadogagentprofile", req.NamespacedName)
 	reqLogger.Info("Reconciling DatadogAgentProfile")
 
 	var result reconcile.Result
+	profile := &v1alpha1.DatadogAgentProfile{}
+	if err := r.client.Get(ctx, req.NamespacedName, profile); err != nil {
+		if apierrors.IsNotFound(err) {
+			return result, nil
+		}
+		return result, err
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := r.client.List(ctx, nodeList); err != nil {
+		return result, err
+	}
+
+	profileList := &v1alpha1.DatadogAgentProfileList{}
+	if err := r.client.List(ctx, profileList); err != nil {
+		return result, err
+	}
+
+	now := metav1.NewTime(time.Now())
+	newStatus := profile.Status.DeepCopy()
+	if hash, err := comparison.GenerateMD5ForSpec(profile.Spec); err != nil {
+		reqLogger.Error(err, "couldn't generate hash for profile")
+	} else {
+		newStatus.CurrentHash = hash
+	}
+
+	preview, err := agentprofile.PreviewProfile(profile, profileList.Items, nodeList.Items)
+	if err != nil {
+		reqLogger.V(1).Info("Invalid profile", "error", err)
+		metrics.DAPValid.With(prometheus.Labels{"datadogagentprofile": profile.Name}).Set(metrics.FalseValue)
+		newStatus.Valid = metav1.ConditionFalse
+		meta.SetStatusCondition(&newStatus.Conditions, agentprofile.NewDatadogAgentProfileCondition(agentprofile.ValidConditionType, metav1.ConditionFalse, now, agentprofile.InvalidConditionReason, err.Error()))
+		newStatus.MatchingNodes = 0
+		newStatus.WonNodes = nil
+		newStatus.LostNodes = nil
+		newStatus.Applied = metav1.ConditionUnknown
+		meta.RemoveStatusCondition(&newStatus.Conditions, agentprofile.AppliedConditionType)
+		newStatus.CreateStrategy = nil
+	} else {
+		metrics.DAPValid.With(prometheus.Labels{"datadogagentprofile": profile.Name}).Set(metrics.TrueValue)
+		newStatus.Valid = metav1.ConditionTrue
+		meta.SetStatusCondition(&newStatus.Conditions, agentprofile.NewDatadogAgentProfileCondition(agentprofile.ValidConditionType, metav1.ConditionTrue, now, agentprofile.ValidConditionReason, "Valid manifest"))
+		newStatus.MatchingNodes = preview.MatchingNodes
+		newStatus.WonNodes = preview.WonNodes
+		newStatus.LostNodes = preview.LostNodes
+
+		switch {
+		case len(preview.WonNodes) > 0:
+			newStatus.Applied = metav1.ConditionTrue
+			meta.SetStatusCondition(&newStatus.Conditions, agentprofile.NewDatadogAgentProfileCondition(agentprofile.AppliedConditionType, metav1.ConditionTrue, now, agentprofile.AppliedConditionReason, "Profile applied"))
+		case len(preview.LostNodes) > 0:
+			newStatus.Applied = metav1.ConditionFalse
+			meta.SetStatusCondition(&newStatus.Conditions, agentprofile.NewDatadogAgentProfileCondition(agentprofile.AppliedConditionType, metav1.ConditionFalse, now, agentprofile.ConflictConditionReason, "All the matching nodes are applied by profiles with a higher precedence"))
+		default:
+			newStatus.Applied = metav1.ConditionUnknown
+			meta.RemoveStatusCondition(&newStatus.Conditions, agentprofile.AppliedConditionType)
+		}
+
+		newStatus.CreateStrategy = nil
+		if agentprofile.CreateStrategyEnabled() && newStatus.Applied != metav1.ConditionFalse {
+			createStrategy, err := r.createStrategyStatus(ctx, reqLogger, profile, preview.WonNodes, nodeList.Items, now)
+			if err != nil {
+				return result, err
+			}
+			newStatus.CreateStrategy = createStrategy
+		}
+	}
+
+	result, err = r.updateStatusIfNeeded(ctx, reqLogger, profile, newStatus, now)
+	if err == nil && newStatus.CreateStrategy != nil && newStatus.CreateStrategy.Status != v1alpha1.CompletedStatus {
+		// The nodes are labeled progressively by the DatadogAgent reconciler
+		result.RequeueAfter = createStrategyRequeuePeriod
+	}
+	return result, err
+}
+
+// createStrategyStatus computes the create strategy status of a profile from
+// the labels of the nodes it's applied to and from its DaemonSet.
+func (r *Reconciler) createStrategyStatus(ctx context.Context, logger logr.Logger, profile *v1alpha1.DatadogAgentProfile, wonNodes []string, nodes []corev1.Node, now metav1.Time) (*v1alpha1.CreateStrategy, error) {
+	ddaList := &v2alpha1.DatadogAgentList{}
+	if err := r.client.List(ctx, ddaList); err != nil {
+		return nil, err
+	}
+	dda := &v2alpha1.DatadogAgent{}
+	if len(ddaList.Items) > 0 {
+		dda = &ddaList.Items[0]
+	}
+
+	won := make(map[string]bool, len(wonNodes))
+	for _, node := range wonNodes {
+		won[node] = true
+	}
+	labeledNodes, toLabelNodes := 0, 0
+	for _, node := range nodes {
+		if !won[node.Name] {
+			continue
+		}
+		if node.Labels[agentprofile.ProfileLabelKey] == profile.Name {
+			labeledNodes++
+		} else {
+			toLabelNodes++
+		}
+	}
+	maxUnavailable := agentprofile.GetMaxUnavailable(logger, dda, profile, len(nodes), r.edsOptions)
+
+	var daemonSet *appsv1.DaemonSet
+	if dda.Name != "" {
+		ds := &appsv1.DaemonSet{}
+		dsName := agentprofile.DaemonSetName(types.NamespacedName{Namespace: profile.Namespace, Name: profile.Name})
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: dda.Namespace, Name: dsName}, ds); err == nil {
+			daemonSet = ds
+		} else if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	return agentprofile.CreateStrategyStatus(logger, profile.Status.CreateStrategy, labeledNodes, toLabelNodes, maxUnavailable, daemonSet, now), nil
+}
+
+func (r *Reconciler) updateStatusIfNeeded(ctx context.Context, logger logr.Logger, profile *v1alpha1.DatadogAgentProfile, newStatus *v1alpha1.DatadogAgentProfileStatus, now metav1.Time) (reconcile.Result, error) {
+	if apiequality.Semantic.DeepEqual(&profile.Status, newStatus) {
+		return reconcile.Result{}, nil
+	}
+
+	newStatus.LastUpdate = &now
+	profile.Status = *newStatus
+	if err := r.client.Status().Update(ctx, profile); err != nil {
+		if apierrors.IsConflict(err) {
+			logger.V(1).Info("unable to update DatadogAgentProfile status due to update conflict, retrying")
+			return reconcile.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "unable to update DatadogAgentProfile status")
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// EnqueueRequestsForAllProfiles returns a map function reconciling all the
+// profiles. It's used on node and profile changes, since the nodes a profile
+// is applied to depend on the nodes labels and on the other profiles.
+func (r *Reconciler) EnqueueRequestsForAllProfiles() handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var requests []reconcile.Request
+
+		profileList := v1alpha1.DatadogAgentProfileList{}
+		if err := r.client.List(ctx, &profileList); err != nil {
+			r.log.Error(err, "unable to list DatadogAgentProfiles")
+			return requests
+		}
+
+		for _, profile := range profileList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: profile.Namespace,
+					Name:      profile.Name,
+				},
+			})
+		}
 
-	return result, nil
+		return requests
+	}
 }
@@ -0,0 +1,251 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadoghq
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/pkg/agentprofile"
+)
+
+const testNamespace = "bar"
+
+func testProfile(name string, priority int32, key, value string) *v1alpha1.DatadogAgentProfile {
+	return &v1alpha1.DatadogAgentProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      name,
+		},
+		Spec: v1alpha1.DatadogAgentProfileSpec{
+			Priority: apiutils.NewInt32Pointer(priority),
+			ProfileAffinity: &v1alpha1.ProfileAffinity{
+				ProfileNodeAffinity: []corev1.NodeSelectorRequirement{
+					{
+						Key:      key,
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{value},
+					},
+				},
+			},
+			Config: &v1alpha1.Config{
+				Override: map[v1alpha1.ComponentName]*v1alpha1.Override{
+					v1alpha1.NodeAgentComponentName: {
+						PriorityClassName: apiutils.NewStringPointer("foo"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestReconciler_Reconcile(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(s)
+	_ = v1alpha1.AddToScheme(s)
+
+	nodes := []client.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"os": "linux", "gpu": "true"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"os": "linux"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node3", Labels: map[string]string{"os": "windows"}}},
+	}
+
+	invalid := testProfile("invalid", 0, "os", "linux")
+	invalid.Spec.ProfileAffinity = nil
+
+	tests := []struct {
+		name           string
+		profiles       []client.Object
+		request        string
+		wantValid      metav1.ConditionStatus
+		wantApplied    metav1.ConditionStatus
+		wantMatching   int32
+		wantWonNodes   []string
+		wantLostNodes  []v1alpha1.LostNode
+		wantConditions map[string]string
+	}{
+		{
+			name:         "profile not found",
+			request:      "missing",
+			wantValid:    "",
+			wantApplied:  "",
+			wantMatching: 0,
+		},
+		{
+			name:           "invalid profile",
+			profiles:       []client.Object{invalid},
+			request:        "invalid",
+			wantValid:      metav1.ConditionFalse,
+			wantApplied:    metav1.ConditionUnknown,
+			wantConditions: map[string]string{agentprofile.ValidConditionType: agentprofile.InvalidConditionReason},
+		},
+		{
+			name:         "valid profile",
+			profiles:     []client.Object{testProfile("linux", 0, "os", "linux")},
+			request:      "linux",
+			wantValid:    metav1.ConditionTrue,
+			wantApplied:  metav1.ConditionTrue,
+			wantMatching: 2,
+			wantWonNodes: []string{"node1", "node2"},
+			wantConditions: map[string]string{
+				agentprofile.ValidConditionType:   agentprofile.ValidConditionReason,
+				agentprofile.AppliedConditionType: agentprofile.AppliedConditionReason,
+			},
+		},
+		{
+			name:          "profile partially overridden by a higher priority profile",
+			profiles:      []client.Object{testProfile("linux", 0, "os", "linux"), testProfile("gpu", 10, "gpu", "true")},
+			request:       "linux",
+			wantValid:     metav1.ConditionTrue,
+			wantApplied:   metav1.ConditionTrue,
+			wantMatching:  2,
+			wantWonNodes:  []string{"node2"},
+			wantLostNodes: []v1alpha1.LostNode{{Node: "node1", Profile: testNamespace + "/gpu"}},
+		},
+		{
+			name:         "profile matching no node",
+			profiles:     []client.Object{testProfile("arm", 0, "arch", "arm64")},
+			request:      "arm",
+			wantValid:    metav1.ConditionTrue,
+			wantApplied:  metav1.ConditionUnknown,
+			wantMatching: 0,
+		},
+		{
+			name:          "profile fully overridden by a higher priority profile",
+			profiles:      []client.Object{testProfile("gpu", 0, "gpu", "true"), testProfile("linux", 10, "os", "linux")},
+			request:       "gpu",
+			wantValid:     metav1.ConditionTrue,
+			wantApplied:   metav1.ConditionFalse,
+			wantMatching:  1,
+			wantLostNodes: []v1alpha1.LostNode{{Node: "node1", Profile: testNamespace + "/linux"}},
+			wantConditions: map[string]string{
+				agentprofile.ValidConditionType:   agentprofile.ValidConditionReason,
+				agentprofile.AppliedConditionType: agentprofile.ConflictConditionReason,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(s).
+				WithStatusSubresource(&v1alpha1.DatadogAgentProfile{}).
+				WithObjects(nodes...).
+				WithObjects(tt.profiles...).
+				Build()
+			r, err := NewReconciler(c, s, logf.Log.WithName(tt.name), nil)
+			assert.NoError(t, err)
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: tt.request}}
+			result, err := r.Reconcile(context.TODO(), request)
+			assert.NoError(t, err)
+			assert.Equal(t, reconcile.Result{}, result)
+
+			profile := &v1alpha1.DatadogAgentProfile{}
+			if err = c.Get(context.TODO(), request.NamespacedName, profile); err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantValid, profile.Status.Valid)
+			assert.Equal(t, tt.wantApplied, profile.Status.Applied)
+			assert.Equal(t, tt.wantMatching, profile.Status.MatchingNodes)
+			assert.Equal(t, tt.wantWonNodes, profile.Status.WonNodes)
+			assert.Equal(t, tt.wantLostNodes, profile.Status.LostNodes)
+			assert.NotNil(t, profile.Status.LastUpdate)
+			for conditionType, reason := range tt.wantConditions {
+				condition := meta.FindStatusCondition(profile.Status.Conditions, conditionType)
+				if assert.NotNil(t, condition) {
+					assert.Equal(t, reason, condition.Reason)
+				}
+			}
+
+			// A second reconcile doesn't update the status
+			lastUpdate := profile.Status.LastUpdate
+			_, err = r.Reconcile(context.TODO(), request)
+			assert.NoError(t, err)
+			assert.NoError(t, c.Get(context.TODO(), request.NamespacedName, profile))
+			assert.True(t, lastUpdate.Equal(profile.Status.LastUpdate))
+		})
+	}
+}
+
+func TestReconciler_ReconcileCreateStrategy(t *testing.T) {
+	t.Setenv(apicommon.CreateStrategyEnabled, "true")
+	s := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(s)
+	_ = v1alpha1.AddToScheme(s)
+	_ = v2alpha1.AddToScheme(s)
+
+	profile := testProfile("linux", 0, "os", "linux")
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithStatusSubresource(&v1alpha1.DatadogAgentProfile{}).
+		WithObjects(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"os": "linux", agentprofile.ProfileLabelKey: "linux"}}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"os": "linux"}}},
+			&v2alpha1.DatadogAgent{ObjectMeta: metav1.ObjectMeta{Namespace: "datadog", Name: "datadog"}},
+			&appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "datadog",
+					Name:      agentprofile.DaemonSetName(types.NamespacedName{Namespace: testNamespace, Name: "linux"}),
+				},
+				Status: appsv1.DaemonSetStatus{NumberReady: 1},
+			},
+			profile,
+		).
+		Build()
+	r, err := NewReconciler(c, s, logf.Log.WithName(t.Name()), nil)
+	assert.NoError(t, err)
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "linux"}}
+	result, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	// The status is refreshed until all the nodes are labeled
+	assert.Equal(t, reconcile.Result{RequeueAfter: createStrategyRequeuePeriod}, result)
+
+	assert.NoError(t, c.Get(context.TODO(), request.NamespacedName, profile))
+	if assert.NotNil(t, profile.Status.CreateStrategy) {
+		assert.Equal(t, v1alpha1.InProgressStatus, profile.Status.CreateStrategy.Status)
+		assert.Equal(t, int32(1), profile.Status.CreateStrategy.NodesLabeled)
+		assert.Equal(t, int32(1), profile.Status.CreateStrategy.PodsReady)
+		assert.Equal(t, int32(1), profile.Status.CreateStrategy.MaxUnavailable)
+	}
+}
+
+func TestReconciler_EnqueueRequestsForAllProfiles(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(s)
+	_ = v1alpha1.AddToScheme(s)
+
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(testProfile("linux", 0, "os", "linux"), testProfile("gpu", 10, "gpu", "true")).
+		Build()
+	r, err := NewReconciler(c, s, logf.Log.WithName("TestReconciler_EnqueueRequestsForAllProfiles"), nil)
+	assert.NoError(t, err)
+
+	requests := r.EnqueueRequestsForAllProfiles()(context.TODO(), &corev1.Node{})
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "linux"}},
+		{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "gpu"}},
+	}, requests)
+}
@@ -9,12 +9,17 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	componentagent "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/agent"
 	dap "github.com/DataDog/datadog-operator/internal/controller/datadogagentprofile"
 )
 
@@ -24,7 +29,9 @@ type DatadogAgentProfileReconciler struct {
 	Log      logr.Logger
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
-	internal *dap.Reconciler
+	// ExtendedDaemonsetOptions are used to compute the maxUnavailable of the create strategy.
+	ExtendedDaemonsetOptions componentagent.ExtendedDaemonsetOptions
+	internal                 *dap.Reconciler
 }
 
 // +kubebuilder:rbac:groups=datadoghq.com,resources=datadogagentprofiles,verbs=get;list;watch;create;update;patch;delete
@@ -38,14 +45,29 @@ func (r *DatadogAgentProfileReconciler) Reconcile(ctx context.Context, req ctrl.
 
 // SetupWithManager creates a new DatadogAgentProfile controller.
 func (r *DatadogAgentProfileReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	internal, err := dap.NewReconciler(r.Client, r.Scheme, r.Log)
+	internal, err := dap.NewReconciler(r.Client, r.Scheme, r.Log, &r.ExtendedDaemonsetOptions)
 	if err != nil {
 		return err
 	}
 	r.internal = internal
 
+	// The status of the profiles is only updated by this controller, only
+	// reconcile on spec changes.
+	// The nodes a profile is applied to depend on the labels of the nodes and
+	// on the other profiles, so all the profiles are reconciled when one of
+	// them changes.
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&datadoghqv1alpha1.DatadogAgentProfile{})
+		For(&datadoghqv1alpha1.DatadogAgentProfile{}, ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&datadoghqv1alpha1.DatadogAgentProfile{},
+			handler.EnqueueRequestsFromMapFunc(internal.EnqueueRequestsForAllProfiles()),
+			ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&corev1.Node{},
+			handler.EnqueueRequestsFromMapFunc(internal.EnqueueRequestsForAllProfiles()),
+			ctrlbuilder.WithPredicates(enqueueIfNodeLabelsChange()),
+		)
 
 	err = builder.Complete(r)
 	if err != nil {
@@ -19,12 +19,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/metrics"
 	"github.com/DataDog/datadog-operator/internal/controller/utils"
 	ctrutils "github.com/DataDog/datadog-operator/pkg/controller/utils"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
 	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+	"github.com/DataDog/datadog-operator/pkg/tracing"
 )
 
 const (
@@ -55,7 +57,10 @@ func NewReconciler(client client.Client, ddClient datadogclient.DatadogDashboard
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	return r.internalReconcile(ctx, request)
+	ctx, span := tracing.StartSpan(ctx, "DatadogDashboard.Reconcile", tracing.RequestAttributes(datadogDashboardKind, request.Namespace, request.Name)...)
+	res, err := r.internalReconcile(ctx, request)
+	tracing.EndSpan(span, err)
+	return res, err
 }
 
 func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
@@ -69,6 +74,7 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 
 	if err = r.client.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: req.Name}, instance); err != nil {
 		if apierrors.IsNotFound(err) {
+			metrics.CleanupMetricsByObject(datadogDashboardKind, req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 
@@ -106,8 +112,8 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 		if instanceSpecHash != statusSpecHash {
 			logger.Info("DatadogDashboard manifest has changed")
 			shouldUpdate = true
-		} else if instance.Status.LastForceSyncTime == nil || ((defaultForceSyncPeriod - now.Sub(instance.Status.LastForceSyncTime.Time)) <= 0) {
-			// Periodically force a sync with the API to ensure parity
+		} else if instance.Status.LastForceSyncTime == nil || ((defaultForceSyncPeriod - now.Sub(instance.Status.LastForceSyncTime.Time)) <= 0) || ctrutils.ForceSyncRequested(instance, instance.Status.LastForceSyncTime) {
+			// Periodically, or when requested, force a sync with the API to ensure parity
 			// Get Dashboard to make sure it exists before trying any updates. If it doesn't, set shouldCreate
 			_, err = r.get(instance)
 			if err != nil {
@@ -232,6 +238,8 @@ func updateErrStatus(status *v1alpha1.DatadogDashboardStatus, now metav1.Time, s
 }
 
 func (r *Reconciler) updateStatusIfNeeded(logger logr.Logger, instance *v1alpha1.DatadogDashboard, status *v1alpha1.DatadogDashboardStatus, result ctrl.Result) (ctrl.Result, error) {
+	metrics.SetResourceReconcileResult(datadogDashboardKind, instance, status.SyncStatus == v1alpha1.DatadogDashboardSyncStatusOK)
+
 	if !apiequality.Semantic.DeepEqual(&instance.Status, status) {
 		instance.Status = *status
 		if err := r.client.Status().Update(context.TODO(), instance); err != nil {
@@ -45,7 +45,8 @@ func (r *DatadogDashboardReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.DatadogDashboard{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{})
+		// Annotation changes are used to request a sync
+		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))
 
 	err := builder.Complete(r)
 
@@ -19,12 +19,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/internal/controller/metrics"
 	"github.com/DataDog/datadog-operator/internal/controller/utils"
 	ctrutils "github.com/DataDog/datadog-operator/pkg/controller/utils"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
 	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+	"github.com/DataDog/datadog-operator/pkg/tracing"
 )
 
 const (
@@ -59,7 +61,10 @@ func NewReconciler(client client.Client, ddClient datadogclient.DatadogGenericCl
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	return r.internalReconcile(ctx, request)
+	ctx, span := tracing.StartSpan(ctx, "DatadogGenericResource.Reconcile", tracing.RequestAttributes(datadogGenericResourceKind, request.Namespace, request.Name)...)
+	res, err := r.internalReconcile(ctx, request)
+	tracing.EndSpan(span, err)
+	return res, err
 }
 
 func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
@@ -73,6 +78,7 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 
 	if err = r.client.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: req.Name}, instance); err != nil {
 		if apierrors.IsNotFound(err) {
+			metrics.CleanupMetricsByObject(datadogGenericResourceKind, req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 
@@ -195,6 +201,8 @@ func updateErrStatus(status *v1alpha1.DatadogGenericResourceStatus, now metav1.T
 }
 
 func (r *Reconciler) updateStatusIfNeeded(logger logr.Logger, instance *v1alpha1.DatadogGenericResource, status *v1alpha1.DatadogGenericResourceStatus, result ctrl.Result) (ctrl.Result, error) {
+	metrics.SetResourceReconcileResult(datadogGenericResourceKind, instance, status.SyncStatus == v1alpha1.DatadogSyncStatusOK)
+
 	if !apiequality.Semantic.DeepEqual(&instance.Status, status) {
 		instance.Status = *status
 		if err := r.client.Status().Update(context.TODO(), instance); err != nil {
@@ -28,11 +28,13 @@ import (
 
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
+	"github.com/DataDog/datadog-operator/internal/controller/metrics"
 	ctrutils "github.com/DataDog/datadog-operator/pkg/controller/utils"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
 	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+	"github.com/DataDog/datadog-operator/pkg/tracing"
 	"github.com/DataDog/datadog-operator/pkg/utils"
 )
 
@@ -84,7 +86,10 @@ func NewReconciler(client client.Client, ddClient datadogclient.DatadogMonitorCl
 
 // Reconcile is similar to reconciler.Reconcile interface, but taking a context
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	return r.internalReconcile(ctx, request)
+	ctx, span := tracing.StartSpan(ctx, "DatadogMonitor.Reconcile", tracing.RequestAttributes(datadogMonitorKind, request.Namespace, request.Name)...)
+	res, err := r.internalReconcile(ctx, request)
+	tracing.EndSpan(span, err)
+	return res, err
 }
 
 // Reconcile loop for DatadogMonitor
@@ -113,6 +118,7 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			metrics.CleanupMetricsByObject(datadogMonitorKind, req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 
 		}
@@ -154,8 +160,8 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 			// Custom resource manifest has changed, need to update the API
 			logger.V(1).Info("DatadogMonitor manifest has changed")
 			shouldUpdate = true
-		} else if instance.Status.MonitorLastForceSyncTime == nil || (forceSyncPeriod-now.Sub(instance.Status.MonitorLastForceSyncTime.Time)) <= 0 {
-			// Periodically force a sync with the API monitor to ensure parity
+		} else if instance.Status.MonitorLastForceSyncTime == nil || (forceSyncPeriod-now.Sub(instance.Status.MonitorLastForceSyncTime.Time)) <= 0 || ctrutils.ForceSyncRequested(instance, instance.Status.MonitorLastForceSyncTime) {
+			// Periodically, or when requested, force a sync with the API monitor to ensure parity
 			// Get monitor to make sure it exists before trying any updates. If it doesn't, set shouldCreate
 			m, err = r.get(instance, newStatus)
 			if err != nil {
@@ -295,6 +301,7 @@ func updateMonitorState(m datadogV1.Monitor, now metav1.Time, status *datadoghqv
 func (r *Reconciler) updateStatusIfNeeded(logger logr.Logger, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, now metav1.Time, status *datadoghqv1alpha1.DatadogMonitorStatus, currentErr error, result ctrl.Result) (ctrl.Result, error) {
 	// Update Error and Active conditions
 	condition.SetErrorActiveConditions(status, now, currentErr)
+	metrics.SetResourceReconcileResult(datadogMonitorKind, datadogMonitor, currentErr == nil)
 
 	if !apiequality.Semantic.DeepEqual(&datadogMonitor.Status, status) {
 		datadogMonitor.Status = *status
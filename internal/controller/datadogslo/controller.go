@@ -26,12 +26,14 @@ import (
 	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/finalizer"
+	"github.com/DataDog/datadog-operator/internal/controller/metrics"
 	"github.com/DataDog/datadog-operator/internal/controller/utils"
 	ctrutils "github.com/DataDog/datadog-operator/pkg/controller/utils"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
 	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+	"github.com/DataDog/datadog-operator/pkg/tracing"
 )
 
 const (
@@ -63,7 +65,9 @@ func NewReconciler(client client.Client, ddClient datadogclient.DatadogSLOClient
 var _ reconcile.Reconciler = (*Reconciler)(nil)
 
 func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, span := tracing.StartSpan(ctx, "DatadogSLO.Reconcile", tracing.RequestAttributes(datadogSLOKind, req.Namespace, req.Name)...)
 	res, err := r.internalReconcile(ctx, req)
+	tracing.EndSpan(span, err)
 	return res, err
 }
 
@@ -78,6 +82,7 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 	var err error
 	if err = r.client.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: req.Name}, instance); err != nil {
 		if apierrors.IsNotFound(err) {
+			metrics.CleanupMetricsByObject(datadogSLOKind, req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
@@ -119,8 +124,8 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 	} else {
 		if instanceSpecHash != statusSpecHash {
 			shouldUpdate = true
-		} else if instance.Status.LastForceSyncTime == nil || (defaultForceSyncPeriod-now.Sub(instance.Status.LastForceSyncTime.Time)) <= 0 {
-			// Periodically force a sync with the API SLO to ensure parity
+		} else if instance.Status.LastForceSyncTime == nil || (defaultForceSyncPeriod-now.Sub(instance.Status.LastForceSyncTime.Time)) <= 0 || ctrutils.ForceSyncRequested(instance, instance.Status.LastForceSyncTime) {
+			// Periodically, or when requested, force a sync with the API SLO to ensure parity
 			// Get SLO to make sure it exists before trying any updates. If it doesn't, set shouldCreate
 			_, err = r.get(instance)
 			if err != nil {
@@ -217,6 +222,8 @@ func updateErrStatus(status *v1alpha1.DatadogSLOStatus, now metav1.Time, syncSta
 }
 
 func (r *Reconciler) updateStatusIfNeeded(logger logr.Logger, instance *v1alpha1.DatadogSLO, status *v1alpha1.DatadogSLOStatus, result ctrl.Result) (ctrl.Result, error) {
+	metrics.SetResourceReconcileResult(datadogSLOKind, instance, status.SyncStatus == v1alpha1.DatadogSLOSyncStatusOK)
+
 	if !apiequality.Semantic.DeepEqual(&instance.Status, status) {
 		instance.Status = *status
 		if err := r.client.Status().Update(context.TODO(), instance); err != nil {
@@ -44,7 +44,8 @@ func (r *DatadogSLOReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.DatadogSLO{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{})
+		// Annotation changes are used to request a sync
+		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))
 
 	err := builder.Complete(r)
 	if err != nil {
@@ -8,9 +8,24 @@ package metrics
 const (
 	datadogAgentSubsystem        = "datadogagent"
 	datadogAgentProfileSubsystem = "datadogagentprofile"
+	datadogAPISubsystem          = "datadog_api"
+	customResourceSubsystem      = "custom_resource"
 
 	TrueValue  = 1.0
 	FalseValue = 0.0
 
 	datadogAgentProfileLabelKey = "datadogagentprofile"
+
+	kindLabelKey      = "kind"
+	namespaceLabelKey = "namespace"
+	nameLabelKey      = "name"
+	componentLabelKey = "component"
+	stateLabelKey     = "state"
+	featureLabelKey   = "feature"
+	endpointLabelKey  = "endpoint"
+	methodLabelKey    = "method"
+
+	desiredState = "desired"
+	readyState   = "ready"
+	updatedState = "updated"
 )
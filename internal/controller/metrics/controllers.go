@@ -0,0 +1,87 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// last successful reconcile of a custom resource
+	LastSuccessfulReconcile = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: customResourceSubsystem,
+			Name:      "last_successful_reconcile_timestamp_seconds",
+			Help:      "Unix timestamp of the last successful reconcile of the custom resource",
+		},
+		[]string{
+			kindLabelKey,
+			namespaceLabelKey,
+			nameLabelKey,
+		},
+	)
+
+	// custom resource synced with Datadog
+	ResourceSynced = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: customResourceSubsystem,
+			Name:      "synced",
+			Help:      "1 if the custom resource is synced with Datadog. 0 if the last sync failed",
+		},
+		[]string{
+			kindLabelKey,
+			namespaceLabelKey,
+			nameLabelKey,
+		},
+	)
+)
+
+func init() {
+	// Register custom metrics with the global prometheus registry
+	metrics.Registry.MustRegister(LastSuccessfulReconcile)
+	metrics.Registry.MustRegister(ResourceSynced)
+}
+
+// SetReconcileSucceeded records a successful reconcile of a custom resource
+func SetReconcileSucceeded(kind string, obj client.Object) {
+	LastSuccessfulReconcile.With(objectLabels(kind, obj.GetNamespace(), obj.GetName())).Set(float64(time.Now().Unix()))
+}
+
+// SetResourceSynced records whether a custom resource is synced with Datadog
+func SetResourceSynced(kind string, obj client.Object, synced bool) {
+	value := FalseValue
+	if synced {
+		value = TrueValue
+	}
+	ResourceSynced.With(objectLabels(kind, obj.GetNamespace(), obj.GetName())).Set(value)
+}
+
+// SetResourceReconcileResult records the result of the reconcile of a custom resource synced with Datadog
+func SetResourceReconcileResult(kind string, obj client.Object, synced bool) {
+	SetResourceSynced(kind, obj, synced)
+	if synced {
+		SetReconcileSucceeded(kind, obj)
+	}
+}
+
+// CleanupMetricsByObject deletes the prometheus metrics of a deleted custom resource
+func CleanupMetricsByObject(kind, namespace, name string) {
+	labels := objectLabels(kind, namespace, name)
+	LastSuccessfulReconcile.Delete(labels)
+	ResourceSynced.Delete(labels)
+}
+
+func objectLabels(kind, namespace, name string) prometheus.Labels {
+	return prometheus.Labels{
+		kindLabelKey:      kind,
+		namespaceLabelKey: namespace,
+		nameLabelKey:      name,
+	}
+}
@@ -7,9 +7,14 @@ package metrics
 
 import (
 	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 )
 
+const datadogAgentKind = "DatadogAgent"
+
 var (
 	// introspection enabled
 	IntrospectionEnabled = prometheus.NewGauge(
@@ -19,9 +24,87 @@ var (
 			Help:      "1 if introspection is enabled. 0 if introspection is disabled",
 		},
 	)
+
+	// replicas of the DatadogAgent components
+	DatadogAgentComponentReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: datadogAgentSubsystem,
+			Name:      "component_replicas",
+			Help:      "Number of desired, ready and updated replicas of a DatadogAgent component",
+		},
+		[]string{
+			namespaceLabelKey,
+			nameLabelKey,
+			componentLabelKey,
+			stateLabelKey,
+		},
+	)
+
+	// features enabled in the DatadogAgent
+	DatadogAgentFeatureEnabled = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: datadogAgentSubsystem,
+			Name:      "feature_enabled",
+			Help:      "1 if the feature is enabled in the DatadogAgent",
+		},
+		[]string{
+			namespaceLabelKey,
+			nameLabelKey,
+			featureLabelKey,
+		},
+	)
 )
 
 func init() {
 	// Register custom metrics with the global prometheus registry
 	metrics.Registry.MustRegister(IntrospectionEnabled)
+	metrics.Registry.MustRegister(DatadogAgentComponentReplicas)
+	metrics.Registry.MustRegister(DatadogAgentFeatureEnabled)
+}
+
+// SetDatadogAgentStatus records the replicas of the DatadogAgent components and the reconcile result
+func SetDatadogAgentStatus(dda client.Object, status *v2alpha1.DatadogAgentStatus, reconcileErr error) {
+	if status.Agent != nil {
+		setComponentReplicas(dda, v2alpha1.NodeAgentComponentName, status.Agent.Desired, status.Agent.Ready, status.Agent.UpToDate)
+	}
+	if status.ClusterAgent != nil {
+		setComponentReplicas(dda, v2alpha1.ClusterAgentComponentName, status.ClusterAgent.Replicas, status.ClusterAgent.ReadyReplicas, status.ClusterAgent.UpdatedReplicas)
+	}
+	if status.ClusterChecksRunner != nil {
+		setComponentReplicas(dda, v2alpha1.ClusterChecksRunnerComponentName, status.ClusterChecksRunner.Replicas, status.ClusterChecksRunner.ReadyReplicas, status.ClusterChecksRunner.UpdatedReplicas)
+	}
+	if reconcileErr == nil {
+		SetReconcileSucceeded(datadogAgentKind, dda)
+	}
+}
+
+// SetDatadogAgentFeatures records the features enabled in the DatadogAgent
+func SetDatadogAgentFeatures(dda client.Object, features []string) {
+	DatadogAgentFeatureEnabled.DeletePartialMatch(prometheus.Labels{namespaceLabelKey: dda.GetNamespace(), nameLabelKey: dda.GetName()})
+	for _, feature := range features {
+		DatadogAgentFeatureEnabled.With(prometheus.Labels{
+			namespaceLabelKey: dda.GetNamespace(),
+			nameLabelKey:      dda.GetName(),
+			featureLabelKey:   feature,
+		}).Set(TrueValue)
+	}
+}
+
+// CleanupMetricsByDatadogAgent deletes the prometheus metrics of a deleted DatadogAgent
+func CleanupMetricsByDatadogAgent(namespace, name string) {
+	labels := prometheus.Labels{namespaceLabelKey: namespace, nameLabelKey: name}
+	DatadogAgentComponentReplicas.DeletePartialMatch(labels)
+	DatadogAgentFeatureEnabled.DeletePartialMatch(labels)
+	CleanupMetricsByObject(datadogAgentKind, namespace, name)
+}
+
+func setComponentReplicas(dda client.Object, component v2alpha1.ComponentName, desired, ready, updated int32) {
+	for state, value := range map[string]int32{desiredState: desired, readyState: ready, updatedState: updated} {
+		DatadogAgentComponentReplicas.With(prometheus.Labels{
+			namespaceLabelKey: dda.GetNamespace(),
+			nameLabelKey:      dda.GetName(),
+			componentLabelKey: string(component),
+			stateLabelKey:     state,
+		}).Set(float64(value))
+	}
 }
@@ -0,0 +1,31 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+func TestSetDatadogAgentFeatures(t *testing.T) {
+	dda := &v2alpha1.DatadogAgent{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"}}
+
+	SetDatadogAgentFeatures(dda, []string{"apm", "logs"})
+	assert.Equal(t, 2, testutil.CollectAndCount(DatadogAgentFeatureEnabled))
+
+	// Features disabled since the last reconcile are removed
+	SetDatadogAgentFeatures(dda, []string{"apm"})
+	assert.Equal(t, 1, testutil.CollectAndCount(DatadogAgentFeatureEnabled))
+	assert.Equal(t, TrueValue, testutil.ToFloat64(DatadogAgentFeatureEnabled.WithLabelValues("foo", "bar", "apm")))
+
+	CleanupMetricsByDatadogAgent("foo", "bar")
+	assert.Equal(t, 0, testutil.CollectAndCount(DatadogAgentFeatureEnabled))
+}
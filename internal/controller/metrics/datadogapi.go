@@ -0,0 +1,93 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package metrics
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// idPathSegment matches the path segments holding a resource ID, like a monitor ID or a dashboard `abc-def-ghi` ID
+var idPathSegment = regexp.MustCompile(`[0-9]|^[a-z0-9]{3}-[a-z0-9]{3}-[a-z0-9]{3}$`)
+
+var (
+	// latency of the Datadog API calls
+	DatadogAPIRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: datadogAPISubsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the Datadog API requests sent by the operator",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{
+			endpointLabelKey,
+			methodLabelKey,
+		},
+	)
+
+	// errors of the Datadog API calls
+	DatadogAPIRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: datadogAPISubsystem,
+			Name:      "request_errors_total",
+			Help:      "Number of Datadog API requests sent by the operator that failed or returned an error status code",
+		},
+		[]string{
+			endpointLabelKey,
+			methodLabelKey,
+		},
+	)
+)
+
+func init() {
+	// Register custom metrics with the global prometheus registry
+	metrics.Registry.MustRegister(DatadogAPIRequestDuration)
+	metrics.Registry.MustRegister(DatadogAPIRequestErrors)
+}
+
+// InstrumentDatadogAPIRoundTripper returns a http.RoundTripper recording the latency and errors of the Datadog API requests
+func InstrumentDatadogAPIRoundTripper(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		labels := prometheus.Labels{
+			endpointLabelKey: datadogAPIEndpoint(req.URL.Path),
+			methodLabelKey:   req.Method,
+		}
+
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+		DatadogAPIRequestDuration.With(labels).Observe(time.Since(start).Seconds())
+		if err != nil || resp.StatusCode >= http.StatusBadRequest {
+			DatadogAPIRequestErrors.With(labels).Inc()
+		}
+		return resp, err
+	})
+}
+
+// datadogAPIEndpoint replaces the resource IDs of an API path to keep the cardinality of the endpoint label low
+func datadogAPIEndpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		// Keep the API version
+		if i > 0 && segments[i-1] == "api" {
+			continue
+		}
+		if idPathSegment.MatchString(segment) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,73 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_datadogAPIEndpoint(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "no id",
+			path: "/api/v1/validate",
+			want: "/api/v1/validate",
+		},
+		{
+			name: "monitor id",
+			path: "/api/v1/monitor/12345",
+			want: "/api/v1/monitor/:id",
+		},
+		{
+			name: "dashboard id",
+			path: "/api/v1/dashboard/abc-def-ghi",
+			want: "/api/v1/dashboard/:id",
+		},
+		{
+			name: "slo id",
+			path: "/api/v1/slo/3b1e9d8f0c2a4e6b8d0f1a3c5e7b9d1f",
+			want: "/api/v1/slo/:id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, datadogAPIEndpoint(tt.path))
+		})
+	}
+}
+
+func TestInstrumentDatadogAPIRoundTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/monitor/404" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: InstrumentDatadogAPIRoundTripper(http.DefaultTransport)}
+	errors := DatadogAPIRequestErrors.WithLabelValues("/api/v1/monitor/:id", http.MethodGet)
+	before := testutil.ToFloat64(errors)
+
+	resp, err := client.Get(server.URL + "/api/v1/monitor/200")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, before, testutil.ToFloat64(errors))
+
+	resp, err = client.Get(server.URL + "/api/v1/monitor/404")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, before+1, testutil.ToFloat64(errors))
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent"
 	componentagent "github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/agent"
+	"github.com/DataDog/datadog-operator/internal/controller/metrics"
 	"github.com/DataDog/datadog-operator/pkg/config"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
 	"github.com/DataDog/datadog-operator/pkg/datadogclient"
@@ -49,6 +50,7 @@ type SetupOptions struct {
 	OtelAgentEnabled              bool
 	DatadogDashboardEnabled       bool
 	DatadogGenericResourceEnabled bool
+	DatadogInstrumentationEnabled bool
 }
 
 // ExtendedDaemonsetOptions defines ExtendedDaemonset options
@@ -106,6 +108,9 @@ func SetupControllers(logger logr.Logger, mgr manager.Manager, options SetupOpti
 	}
 	platformInfo := kubernetes.NewPlatformInfo(versionInfo, groups, resources)
 
+	// The latency and errors of the Datadog API requests are exposed as Prometheus metrics
+	datadogclient.SetTransportInstrumentation(metrics.InstrumentDatadogAPIRoundTripper)
+
 	var metricForwardersMgr datadog.MetricForwardersManager
 	if options.OperatorMetricsEnabled {
 		metricForwardersMgr = datadog.NewForwardersManager(mgr.GetClient(), &platformInfo)
@@ -157,10 +162,11 @@ func startDatadogAgent(logger logr.Logger, mgr manager.Manager, pInfo kubernetes
 				CanaryAutoFailEnabled:               options.SupportExtendedDaemonset.CanaryAutoFailEnabled,
 				CanaryAutoFailMaxRestarts:           int32(options.SupportExtendedDaemonset.CanaryAutoFailMaxRestarts),
 			},
-			SupportCilium:              options.SupportCilium,
-			OperatorMetricsEnabled:     options.OperatorMetricsEnabled,
-			IntrospectionEnabled:       options.IntrospectionEnabled,
-			DatadogAgentProfileEnabled: options.DatadogAgentProfileEnabled,
+			SupportCilium:                 options.SupportCilium,
+			OperatorMetricsEnabled:        options.OperatorMetricsEnabled,
+			IntrospectionEnabled:          options.IntrospectionEnabled,
+			DatadogAgentProfileEnabled:    options.DatadogAgentProfileEnabled,
+			DatadogInstrumentationEnabled: options.DatadogInstrumentationEnabled,
 		},
 	}).SetupWithManager(mgr, metricForwardersMgr)
 }
@@ -259,5 +265,8 @@ func startDatadogAgentProfiles(logger logr.Logger, mgr manager.Manager, pInfo ku
 		Log:      ctrl.Log.WithName("controllers").WithName(profileControllerName),
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor(profileControllerName),
+		ExtendedDaemonsetOptions: componentagent.ExtendedDaemonsetOptions{
+			MaxPodUnavailable: options.SupportExtendedDaemonset.MaxPodUnavailable,
+		},
 	}).SetupWithManager(mgr)
 }
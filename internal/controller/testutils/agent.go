@@ -73,7 +73,7 @@ func NewDatadogAgentWithAPM(namespace string, name string) v2alpha1.DatadogAgent
 		&v2alpha1.DatadogFeatures{
 			APM: &v2alpha1.APMFeatureConfig{
 				Enabled: apiutils.NewBoolPointer(true),
-				HostPortConfig: &v2alpha1.HostPortConfig{
+				HostPortConfig: &apicommon.HostPortConfig{
 					Enabled: apiutils.NewBoolPointer(true),
 				},
 			},
@@ -134,8 +134,8 @@ func NewDatadogAgentWithDogstatsd(namespace string, name string) v2alpha1.Datado
 		namespace,
 		name,
 		&v2alpha1.DatadogFeatures{
-			Dogstatsd: &v2alpha1.DogstatsdFeatureConfig{
-				HostPortConfig: &v2alpha1.HostPortConfig{
+			Dogstatsd: &apicommon.DogstatsdFeatureConfig{
+				HostPortConfig: &apicommon.HostPortConfig{
 					Enabled: apiutils.NewBoolPointer(true),
 					Port:    apiutils.NewInt32Pointer(1234),
 				},
@@ -150,7 +150,7 @@ func NewDatadogAgentWithEBPFCheck(namespace string, name string) v2alpha1.Datado
 		namespace,
 		name,
 		&v2alpha1.DatadogFeatures{
-			EBPFCheck: &v2alpha1.EBPFCheckFeatureConfig{
+			EBPFCheck: &apicommon.EBPFCheckFeatureConfig{
 				Enabled: apiutils.NewBoolPointer(true),
 			},
 		},
@@ -163,7 +163,7 @@ func NewDatadogAgentWithServiceDiscovery(namespace, name string) v2alpha1.Datado
 		namespace,
 		name,
 		&v2alpha1.DatadogFeatures{
-			ServiceDiscovery: &v2alpha1.ServiceDiscoveryFeatureConfig{
+			ServiceDiscovery: &apicommon.ServiceDiscoveryFeatureConfig{
 				Enabled: apiutils.NewBoolPointer(true),
 			},
 		},
@@ -217,7 +217,7 @@ func NewDatadogAgentWithLiveContainerCollection(namespace string, name string) v
 		namespace,
 		name,
 		&v2alpha1.DatadogFeatures{
-			LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+			LiveContainerCollection: &apicommon.LiveContainerCollectionFeatureConfig{
 				Enabled: apiutils.NewBoolPointer(true),
 			},
 		},
@@ -230,7 +230,7 @@ func NewDatadogAgentWithLiveProcessCollection(namespace string, name string) v2a
 		namespace,
 		name,
 		&v2alpha1.DatadogFeatures{
-			LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+			LiveProcessCollection: &apicommon.LiveProcessCollectionFeatureConfig{
 				Enabled: apiutils.NewBoolPointer(true),
 			},
 		},
@@ -243,7 +243,7 @@ func NewDatadogAgentWithLogCollection(namespace string, name string) v2alpha1.Da
 		namespace,
 		name,
 		&v2alpha1.DatadogFeatures{
-			LogCollection: &v2alpha1.LogCollectionFeatureConfig{
+			LogCollection: &apicommon.LogCollectionFeatureConfig{
 				Enabled:             apiutils.NewBoolPointer(true),
 				ContainerCollectAll: apiutils.NewBoolPointer(true),
 			},
@@ -257,7 +257,7 @@ func NewDatadogAgentWithNPM(namespace string, name string) v2alpha1.DatadogAgent
 		namespace,
 		name,
 		&v2alpha1.DatadogFeatures{
-			NPM: &v2alpha1.NPMFeatureConfig{
+			NPM: &apicommon.NPMFeatureConfig{
 				Enabled: apiutils.NewBoolPointer(true),
 			},
 		},
@@ -270,7 +270,7 @@ func NewDatadogAgentWithOOMKill(namespace string, name string) v2alpha1.DatadogA
 		namespace,
 		name,
 		&v2alpha1.DatadogFeatures{
-			OOMKill: &v2alpha1.OOMKillFeatureConfig{
+			OOMKill: &apicommon.OOMKillFeatureConfig{
 				Enabled: apiutils.NewBoolPointer(true),
 			},
 		},
@@ -332,7 +332,7 @@ func NewDatadogAgentWithTCPQueueLength(namespace string, name string) v2alpha1.D
 		namespace,
 		name,
 		&v2alpha1.DatadogFeatures{
-			TCPQueueLength: &v2alpha1.TCPQueueLengthFeatureConfig{
+			TCPQueueLength: &apicommon.TCPQueueLengthFeatureConfig{
 				Enabled: apiutils.NewBoolPointer(true),
 			},
 		},
@@ -345,7 +345,7 @@ func NewDatadogAgentWithUSM(namespace string, name string) v2alpha1.DatadogAgent
 		namespace,
 		name,
 		&v2alpha1.DatadogFeatures{
-			USM: &v2alpha1.USMFeatureConfig{
+			USM: &apicommon.USMFeatureConfig{
 				Enabled: apiutils.NewBoolPointer(true),
 			},
 		},
@@ -358,7 +358,7 @@ func NewDatadogAgentWithGPUMonitoring(namespace string, name string) v2alpha1.Da
 		namespace,
 		name,
 		&v2alpha1.DatadogFeatures{
-			GPU: &v2alpha1.GPUFeatureConfig{
+			GPU: &apicommon.GPUFeatureConfig{
 				Enabled: apiutils.NewBoolPointer(true),
 			},
 		},
@@ -536,8 +536,8 @@ func NewDatadogAgentWithOverrides(namespace string, name string) v2alpha1.Datado
 				},
 				SeccompConfig: &v2alpha1.SeccompConfig{
 					CustomRootPath: apiutils.NewStringPointer("/some/path"),
-					CustomProfile: &v2alpha1.CustomConfig{
-						ConfigMap: &v2alpha1.ConfigMapConfig{
+					CustomProfile: &apicommon.CustomConfig{
+						ConfigMap: &apicommon.ConfigMapConfig{
 							Name: "custom-seccomp-cm",
 						},
 					},
@@ -0,0 +1,48 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package adminnetworkpolicy
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// AdminNetworkPolicyKind is the kind of the admin network policies
+const AdminNetworkPolicyKind = "AdminNetworkPolicy"
+
+// GroupVersionAdminNetworkPolicyListKind return the schema.GroupVersionKind for AdminNetworkPolicyList
+func GroupVersionAdminNetworkPolicyListKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "policy.networking.k8s.io",
+		Version: "v1alpha1",
+		Kind:    "AdminNetworkPolicyList",
+	}
+}
+
+// GroupVersionAdminNetworkPolicyKind return the schema.GroupVersionKind for AdminNetworkPolicy
+func GroupVersionAdminNetworkPolicyKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "policy.networking.k8s.io",
+		Version: "v1alpha1",
+		Kind:    AdminNetworkPolicyKind,
+	}
+}
+
+// EmptyAdminNetworkPolicyUnstructuredListPolicy return a new unstructured.UnstructuredList for AdminNetworkPolicy
+func EmptyAdminNetworkPolicyUnstructuredListPolicy() *unstructured.UnstructuredList {
+	policy := &unstructured.UnstructuredList{}
+	policy.SetGroupVersionKind(GroupVersionAdminNetworkPolicyListKind())
+
+	return policy
+}
+
+// EmptyAdminNetworkPolicyUnstructuredPolicy return a new unstructured.Unstructured for AdminNetworkPolicy
+func EmptyAdminNetworkPolicyUnstructuredPolicy() *unstructured.Unstructured {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(GroupVersionAdminNetworkPolicyKind())
+
+	return policy
+}
@@ -0,0 +1,93 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package adminnetworkpolicy
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RuleAction is an AdminNetworkPolicy rule action
+type RuleAction string
+
+const (
+	// RuleActionAllow allows the traffic matched by a rule
+	RuleActionAllow RuleAction = "Allow"
+	// RuleActionDeny denies the traffic matched by a rule
+	RuleActionDeny RuleAction = "Deny"
+	// RuleActionPass delegates the traffic matched by a rule to the network policies
+	RuleActionPass RuleAction = "Pass"
+)
+
+// AdminNetworkPolicy is an upstream Kubernetes admin network policy
+type AdminNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AdminNetworkPolicySpec `json:"spec,omitempty"`
+}
+
+// AdminNetworkPolicySpec is an admin network policy spec
+type AdminNetworkPolicySpec struct {
+	Priority int32                           `json:"priority"`
+	Subject  Subject                         `json:"subject"`
+	Ingress  []AdminNetworkPolicyIngressRule `json:"ingress,omitempty"`
+	Egress   []AdminNetworkPolicyEgressRule  `json:"egress,omitempty"`
+}
+
+// Subject selects the pods an admin network policy applies to
+type Subject struct {
+	Namespaces *metav1.LabelSelector `json:"namespaces,omitempty"`
+	Pods       *NamespacedPod        `json:"pods,omitempty"`
+}
+
+// NamespacedPod selects pods by namespace and pod labels
+type NamespacedPod struct {
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+	PodSelector       metav1.LabelSelector `json:"podSelector"`
+}
+
+// AdminNetworkPolicyIngressRule is an admin network policy ingress rule
+type AdminNetworkPolicyIngressRule struct {
+	Name   string                          `json:"name,omitempty"`
+	Action RuleAction                      `json:"action"`
+	From   []AdminNetworkPolicyIngressPeer `json:"from"`
+	Ports  []AdminNetworkPolicyPort        `json:"ports,omitempty"`
+}
+
+// AdminNetworkPolicyEgressRule is an admin network policy egress rule
+type AdminNetworkPolicyEgressRule struct {
+	Name   string                         `json:"name,omitempty"`
+	Action RuleAction                     `json:"action"`
+	To     []AdminNetworkPolicyEgressPeer `json:"to"`
+	Ports  []AdminNetworkPolicyPort       `json:"ports,omitempty"`
+}
+
+// AdminNetworkPolicyIngressPeer is the peer of an ingress rule
+type AdminNetworkPolicyIngressPeer struct {
+	Namespaces *metav1.LabelSelector `json:"namespaces,omitempty"`
+	Pods       *NamespacedPod        `json:"pods,omitempty"`
+}
+
+// AdminNetworkPolicyEgressPeer is the peer of an egress rule
+type AdminNetworkPolicyEgressPeer struct {
+	Namespaces  *metav1.LabelSelector `json:"namespaces,omitempty"`
+	Pods        *NamespacedPod        `json:"pods,omitempty"`
+	Networks    []string              `json:"networks,omitempty"`
+	DomainNames []string              `json:"domainNames,omitempty"`
+}
+
+// AdminNetworkPolicyPort is the port of a rule
+type AdminNetworkPolicyPort struct {
+	PortNumber *Port   `json:"portNumber,omitempty"`
+	NamedPort  *string `json:"namedPort,omitempty"`
+}
+
+// Port is a port number and its protocol
+type Port struct {
+	Protocol corev1.Protocol `json:"protocol"`
+	Port     int32           `json:"port"`
+}
@@ -12,7 +12,9 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -39,6 +41,9 @@ const (
 
 // ApplyProfile validates a profile spec and returns a map that maps each
 // node name to the profile that should be applied to it.
+// Profiles must be applied in the order returned by SortProfilesByPriority:
+// nodes already present in profileAppliedByNode are applied by a profile with
+// a higher precedence and are reported as lost in the profile status.
 // When create strategy is enabled, the profile is mapped to:
 // - existing nodes with the correct label
 // - nodes that need a new or corrected label up to maxUnavailable # of nodes
@@ -88,12 +93,9 @@ func ApplyProfile(logger logr.Logger, profile *v1alpha1.DatadogAgentProfile, nod
 
 		if matchesNode {
 			if existingProfile, found := profileAppliedByNode[node.Name]; found {
-				// Conflict. This profile should not be applied.
-				logger.Info("conflict with existing profile, skipping", "conflicting profile", profile.Namespace+"/"+profile.Name, "existing profile", existingProfile.String())
-				profileStatus.Conditions = SetDatadogAgentProfileCondition(profileStatus.Conditions, NewDatadogAgentProfileCondition(AppliedConditionType, metav1.ConditionFalse, now, ConflictConditionReason, "Conflict with existing profile"))
-				profileStatus.Applied = metav1.ConditionFalse
-				UpdateProfileStatus(logger, profile, profileStatus, now)
-				return profileAppliedByNode, fmt.Errorf("conflict with existing profile")
+				// The node is applied by a profile with a higher precedence.
+				logger.V(1).Info("node is applied by a profile with a higher precedence", "datadogagentprofile", profile.Namespace+"/"+profile.Name, "node", node.Name, "existing profile", existingProfile.String())
+				profileStatus.LostNodes = append(profileStatus.LostNodes, v1alpha1.LostNode{Node: node.Name, Profile: existingProfile.String()})
 			} else {
 				profileLabelValue, labelExists := node.Labels[ProfileLabelKey]
 				if labelExists && profileLabelValue == profile.Name {
@@ -102,12 +104,29 @@ func ApplyProfile(logger logr.Logger, profile *v1alpha1.DatadogAgentProfile, nod
 					matchingNodes[node.Name] = false
 					toLabelNodeCount++
 				}
-				profileStatus.Conditions = SetDatadogAgentProfileCondition(profileStatus.Conditions, NewDatadogAgentProfileCondition(AppliedConditionType, metav1.ConditionTrue, now, AppliedConditionReason, "Profile applied"))
-				profileStatus.Applied = metav1.ConditionTrue
+				profileStatus.WonNodes = append(profileStatus.WonNodes, node.Name)
 			}
 		}
 	}
 
+	profileStatus.MatchingNodes = int32(len(profileStatus.WonNodes) + len(profileStatus.LostNodes))
+	sort.Strings(profileStatus.WonNodes)
+	sort.Slice(profileStatus.LostNodes, func(i, j int) bool {
+		return profileStatus.LostNodes[i].Node < profileStatus.LostNodes[j].Node
+	})
+
+	if len(profileStatus.WonNodes) > 0 {
+		profileStatus.Conditions = SetDatadogAgentProfileCondition(profileStatus.Conditions, NewDatadogAgentProfileCondition(AppliedConditionType, metav1.ConditionTrue, now, AppliedConditionReason, "Profile applied"))
+		profileStatus.Applied = metav1.ConditionTrue
+	} else if len(profileStatus.LostNodes) > 0 {
+		// Conflict. This profile should not be applied.
+		logger.Info("all the matching nodes are applied by profiles with a higher precedence, skipping", "datadogagentprofile", profile.Namespace+"/"+profile.Name)
+		profileStatus.Conditions = SetDatadogAgentProfileCondition(profileStatus.Conditions, NewDatadogAgentProfileCondition(AppliedConditionType, metav1.ConditionFalse, now, ConflictConditionReason, "All the matching nodes are applied by profiles with a higher precedence"))
+		profileStatus.Applied = metav1.ConditionFalse
+		UpdateProfileStatus(logger, profile, profileStatus, now)
+		return profileAppliedByNode, fmt.Errorf("conflict with existing profile")
+	}
+
 	numNodesToLabel := 0
 	if CreateStrategyEnabled() {
 		profileStatus.CreateStrategy = &v1alpha1.CreateStrategy{}
@@ -185,12 +204,82 @@ func OverrideFromProfile(profile *v1alpha1.DatadogAgentProfile) v2alpha1.Datadog
 			profileComponentOverride.PriorityClassName = nodeAgentOverride.PriorityClassName
 			profileComponentOverride.RuntimeClassName = nodeAgentOverride.RuntimeClassName
 			profileComponentOverride.UpdateStrategy = nodeAgentOverride.UpdateStrategy
+			profileComponentOverride.Tolerations = nodeAgentOverride.Tolerations
+			profileComponentOverride.Volumes = nodeAgentOverride.Volumes
+			profileComponentOverride.CustomConfigurations = nodeAgentOverride.CustomConfigurations
+			profileComponentOverride.ExtraConfd = nodeAgentOverride.ExtraConfd
 		}
 	}
 
 	return profileComponentOverride
 }
 
+// HasFeaturesOverride returns true if the given profile overrides the
+// configuration of some features of the DatadogAgent.
+func HasFeaturesOverride(profile *v1alpha1.DatadogAgentProfile) bool {
+	if IsDefaultProfile(profile.Namespace, profile.Name) || profile.Spec.Config == nil || profile.Spec.Config.Features == nil {
+		return false
+	}
+
+	return !apiequality.Semantic.DeepEqual(*profile.Spec.Config.Features, v1alpha1.ProfileFeatures{})
+}
+
+// DatadogAgentWithProfileFeatures returns a copy of the given DatadogAgent in
+// which the configuration of each feature set in the profile replaces the
+// configuration of the same feature in the DatadogAgent. The returned
+// DatadogAgent needs to be defaulted again before building its features.
+func DatadogAgentWithProfileFeatures(dda *v2alpha1.DatadogAgent, profile *v1alpha1.DatadogAgentProfile) *v2alpha1.DatadogAgent {
+	ddaCopy := dda.DeepCopy()
+	if !HasFeaturesOverride(profile) {
+		return ddaCopy
+	}
+
+	if ddaCopy.Spec.Features == nil {
+		ddaCopy.Spec.Features = &v2alpha1.DatadogFeatures{}
+	}
+	features := ddaCopy.Spec.Features
+	profileFeatures := profile.Spec.Config.Features.DeepCopy()
+
+	if profileFeatures.LogCollection != nil {
+		features.LogCollection = profileFeatures.LogCollection
+	}
+	if profileFeatures.LiveProcessCollection != nil {
+		features.LiveProcessCollection = profileFeatures.LiveProcessCollection
+	}
+	if profileFeatures.LiveContainerCollection != nil {
+		features.LiveContainerCollection = profileFeatures.LiveContainerCollection
+	}
+	if profileFeatures.ProcessDiscovery != nil {
+		features.ProcessDiscovery = profileFeatures.ProcessDiscovery
+	}
+	if profileFeatures.OOMKill != nil {
+		features.OOMKill = profileFeatures.OOMKill
+	}
+	if profileFeatures.TCPQueueLength != nil {
+		features.TCPQueueLength = profileFeatures.TCPQueueLength
+	}
+	if profileFeatures.EBPFCheck != nil {
+		features.EBPFCheck = profileFeatures.EBPFCheck
+	}
+	if profileFeatures.NPM != nil {
+		features.NPM = profileFeatures.NPM
+	}
+	if profileFeatures.USM != nil {
+		features.USM = profileFeatures.USM
+	}
+	if profileFeatures.Dogstatsd != nil {
+		features.Dogstatsd = profileFeatures.Dogstatsd
+	}
+	if profileFeatures.ServiceDiscovery != nil {
+		features.ServiceDiscovery = profileFeatures.ServiceDiscovery
+	}
+	if profileFeatures.GPU != nil {
+		features.GPU = profileFeatures.GPU
+	}
+
+	return ddaCopy
+}
+
 // IsDefaultProfile returns true if the given profile namespace and name
 // correspond to the default profile.
 func IsDefaultProfile(profileNamespace string, profileName string) bool {
@@ -364,6 +453,25 @@ func SortProfiles(profiles []v1alpha1.DatadogAgentProfile) []v1alpha1.DatadogAge
 	return sortedProfiles
 }
 
+// SortProfilesByPriority sorts the profiles by decreasing priority. Profiles
+// with the same priority are sorted like SortProfiles.
+func SortProfilesByPriority(profiles []v1alpha1.DatadogAgentProfile) []v1alpha1.DatadogAgentProfile {
+	sortedProfiles := SortProfiles(profiles)
+
+	sort.SliceStable(sortedProfiles, func(i, j int) bool {
+		return profilePriority(&sortedProfiles[i]) > profilePriority(&sortedProfiles[j])
+	})
+
+	return sortedProfiles
+}
+
+func profilePriority(profile *v1alpha1.DatadogAgentProfile) int32 {
+	if profile.Spec.Priority == nil {
+		return 0
+	}
+	return *profile.Spec.Priority
+}
+
 func profileMatchesNode(profile *v1alpha1.DatadogAgentProfile, nodeLabels map[string]string) (bool, error) {
 	if profile.Spec.ProfileAffinity == nil {
 		return true, nil
@@ -464,6 +572,41 @@ func getCreateStrategyStatus(status *v1alpha1.CreateStrategy, toLabelNodeCount i
 	return status.Status
 }
 
+// CreateStrategyStatus returns the create strategy status of a profile whose
+// matching nodes are labeledNodes nodes already having the profile label and
+// toLabelNodes nodes that still need it. daemonSet is the profile DaemonSet, nil
+// if it doesn't exist yet. The nodes are labeled by the DatadogAgent reconciler
+// from the previous status, the status itself is only updated by the
+// DatadogAgentProfile controller.
+func CreateStrategyStatus(logger logr.Logger, previous *v1alpha1.CreateStrategy, labeledNodes, toLabelNodes, maxUnavailable int, daemonSet *appsv1.DaemonSet, now metav1.Time) *v1alpha1.CreateStrategy {
+	createStrategy := &v1alpha1.CreateStrategy{
+		Status:         getCreateStrategyStatus(previous, toLabelNodes),
+		NodesLabeled:   int32(labeledNodes),
+		MaxUnavailable: int32(maxUnavailable),
+	}
+	if canLabel(logger, createStrategy) {
+		createStrategy.NodesLabeled += int32(min(max(getNumNodesToLabel(previous, maxUnavailable, toLabelNodes), 0), toLabelNodes))
+	}
+
+	// New profiles wait for their DaemonSet to be created before labeling nodes
+	if daemonSet != nil {
+		createStrategy.PodsReady = daemonSet.Status.NumberReady
+		if createStrategy.Status != v1alpha1.CompletedStatus {
+			createStrategy.Status = v1alpha1.WaitingStatus
+			if createStrategy.NodesLabeled-createStrategy.PodsReady < createStrategy.MaxUnavailable {
+				createStrategy.Status = v1alpha1.InProgressStatus
+			}
+		}
+	}
+
+	createStrategy.LastTransition = &now
+	if previous != nil && previous.Status == createStrategy.Status && previous.LastTransition != nil {
+		createStrategy.LastTransition = previous.LastTransition
+	}
+
+	return createStrategy
+}
+
 // CreateStrategyEnabled returns true if the create strategy enabled env var is set to true
 func CreateStrategyEnabled() bool {
 	return os.Getenv(apicommon.CreateStrategyEnabled) == "true"
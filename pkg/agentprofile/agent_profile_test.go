@@ -17,6 +17,7 @@ import (
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/component/agent"
 
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -36,6 +37,8 @@ func TestApplyProfile(t *testing.T) {
 		profileAppliedByNode           map[string]types.NamespacedName
 		expectedProfilesAppliedPerNode map[string]types.NamespacedName
 		expectedErr                    error
+		expectedWonNodes               []string
+		expectedLostNodes              []v1alpha1.LostNode
 	}{
 		{
 			name:    "empty profile, empty profileAppliedByNode",
@@ -212,6 +215,46 @@ func TestApplyProfile(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf("conflict with existing profile"),
 		},
+		{
+			name:    "partially conflicting profile",
+			profile: exampleProfileForLinux(),
+			nodes: []v1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "node1",
+						Labels: map[string]string{
+							"os": "linux",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "node2",
+						Labels: map[string]string{
+							"os": "linux",
+						},
+					},
+				},
+			},
+			profileAppliedByNode: map[string]types.NamespacedName{
+				"node1": {
+					Namespace: testNamespace,
+					Name:      "gpu",
+				},
+			},
+			expectedProfilesAppliedPerNode: map[string]types.NamespacedName{
+				"node1": {
+					Namespace: testNamespace,
+					Name:      "gpu",
+				},
+				"node2": {
+					Namespace: testNamespace,
+					Name:      "linux",
+				},
+			},
+			expectedWonNodes:  []string{"node2"},
+			expectedLostNodes: []v1alpha1.LostNode{{Node: "node1", Profile: testNamespace + "/gpu"}},
+		},
 		{
 			name:    "invalid profile",
 			profile: exampleInvalidProfile(),
@@ -255,10 +298,39 @@ func TestApplyProfile(t *testing.T) {
 			profileAppliedByNode, err := ApplyProfile(testLogger, &test.profile, test.nodes, test.profileAppliedByNode, now, 1)
 			assert.Equal(t, test.expectedErr, err)
 			assert.Equal(t, test.expectedProfilesAppliedPerNode, profileAppliedByNode)
+			if test.expectedWonNodes != nil || test.expectedLostNodes != nil {
+				assert.Equal(t, test.expectedWonNodes, test.profile.Status.WonNodes)
+				assert.Equal(t, test.expectedLostNodes, test.profile.Status.LostNodes)
+			}
 		})
 	}
 }
 
+func TestSortProfilesByPriority(t *testing.T) {
+	t1 := metav1.NewTime(time.Now())
+	t2 := metav1.NewTime(t1.Add(time.Minute))
+	profile := func(name string, creation metav1.Time, priority *int32) v1alpha1.DatadogAgentProfile {
+		return v1alpha1.DatadogAgentProfile{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: name, CreationTimestamp: creation},
+			Spec:       v1alpha1.DatadogAgentProfileSpec{Priority: priority},
+		}
+	}
+
+	profiles := []v1alpha1.DatadogAgentProfile{
+		profile("d", t1, nil),
+		profile("c", t2, apiutils.NewInt32Pointer(10)),
+		profile("b", t2, apiutils.NewInt32Pointer(-1)),
+		profile("a", t2, nil),
+		profile("e", t1, apiutils.NewInt32Pointer(10)),
+	}
+
+	var names []string
+	for _, p := range SortProfilesByPriority(profiles) {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"e", "c", "d", "a", "b"}, names)
+}
+
 func TestOverrideFromProfile(t *testing.T) {
 	overrideNameForLinuxProfile := "datadog-agent-with-profile-default-linux"
 	overrideNameForExampleProfile := "datadog-agent-with-profile-default-example"
@@ -428,6 +500,122 @@ func TestOverrideFromProfile(t *testing.T) {
 	}
 }
 
+func TestOverrideFromProfilePodSettings(t *testing.T) {
+	profile := exampleProfileForLinux()
+	nodeAgentOverride := profile.Spec.Config.Override[v1alpha1.NodeAgentComponentName]
+	nodeAgentOverride.Tolerations = []v1.Toleration{
+		{
+			Key:      "nvidia.com/gpu",
+			Operator: v1.TolerationOpExists,
+			Effect:   v1.TaintEffectNoSchedule,
+		},
+	}
+	nodeAgentOverride.Volumes = []v1.Volume{
+		{
+			Name: "bottlerocket-logs",
+			VolumeSource: v1.VolumeSource{
+				HostPath: &v1.HostPathVolumeSource{Path: "/local/var/log"},
+			},
+		},
+	}
+	nodeAgentOverride.CustomConfigurations = map[apicommon.AgentConfigFileName]apicommon.CustomConfig{
+		apicommon.AgentGeneralConfigFile: {ConfigData: apiutils.NewStringPointer("log_level: debug")},
+	}
+	nodeAgentOverride.ExtraConfd = &apicommon.MultiCustomConfig{
+		ConfigMap: &apicommon.ConfigMapConfig{Name: "gpu-checks"},
+	}
+
+	override := OverrideFromProfile(&profile)
+
+	assert.Equal(t, nodeAgentOverride.Tolerations, override.Tolerations)
+	assert.Equal(t, nodeAgentOverride.Volumes, override.Volumes)
+	assert.Equal(t, nodeAgentOverride.CustomConfigurations, override.CustomConfigurations)
+	assert.Equal(t, nodeAgentOverride.ExtraConfd, override.ExtraConfd)
+}
+
+func TestDatadogAgentWithProfileFeatures(t *testing.T) {
+	dda := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "datadog",
+		},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				NPM: &apicommon.NPMFeatureConfig{
+					Enabled: apiutils.NewBoolPointer(true),
+				},
+				LogCollection: &apicommon.LogCollectionFeatureConfig{
+					Enabled:           apiutils.NewBoolPointer(true),
+					ContainerLogsPath: apiutils.NewStringPointer("/var/lib/docker/containers"),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		profile          v1alpha1.DatadogAgentProfile
+		wantOverride     bool
+		expectedFeatures *v2alpha1.DatadogFeatures
+	}{
+		{
+			name:             "profile without features",
+			profile:          exampleProfileForLinux(),
+			expectedFeatures: dda.Spec.Features,
+		},
+		{
+			name: "default profile",
+			profile: v1alpha1.DatadogAgentProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: defaultProfileName},
+				Spec: v1alpha1.DatadogAgentProfileSpec{
+					Config: &v1alpha1.Config{
+						Features: &v1alpha1.ProfileFeatures{
+							NPM: &apicommon.NPMFeatureConfig{Enabled: apiutils.NewBoolPointer(false)},
+						},
+					},
+				},
+			},
+			expectedFeatures: dda.Spec.Features,
+		},
+		{
+			name: "profile with features",
+			profile: v1alpha1.DatadogAgentProfile{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "spot"},
+				Spec: v1alpha1.DatadogAgentProfileSpec{
+					Config: &v1alpha1.Config{
+						Features: &v1alpha1.ProfileFeatures{
+							NPM: &apicommon.NPMFeatureConfig{Enabled: apiutils.NewBoolPointer(false)},
+							GPU: &apicommon.GPUFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+							LogCollection: &apicommon.LogCollectionFeatureConfig{
+								Enabled: apiutils.NewBoolPointer(true),
+							},
+						},
+					},
+				},
+			},
+			wantOverride: true,
+			expectedFeatures: &v2alpha1.DatadogFeatures{
+				NPM: &apicommon.NPMFeatureConfig{Enabled: apiutils.NewBoolPointer(false)},
+				GPU: &apicommon.GPUFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+				LogCollection: &apicommon.LogCollectionFeatureConfig{
+					Enabled: apiutils.NewBoolPointer(true),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.wantOverride, HasFeaturesOverride(&test.profile))
+
+			profileDDA := DatadogAgentWithProfileFeatures(dda, &test.profile)
+			assert.Equal(t, test.expectedFeatures, profileDDA.Spec.Features)
+			// The DatadogAgent is not modified
+			assert.True(t, apiutils.BoolValue(dda.Spec.Features.NPM.Enabled))
+		})
+	}
+}
+
 func TestDaemonSetName(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -751,6 +939,135 @@ func configWithAllOverrides(cpuRequest string) *v1alpha1.Config {
 	}
 }
 
+func TestCreateStrategyStatus(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+	oneSecBefore := metav1.NewTime(now.Add(time.Duration(-1) * time.Second))
+	daemonSet := func(ready int32) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{Status: appsv1.DaemonSetStatus{NumberReady: ready}}
+	}
+	tests := []struct {
+		name           string
+		previous       *v1alpha1.CreateStrategy
+		labeledNodes   int
+		toLabelNodes   int
+		maxUnavailable int
+		daemonSet      *appsv1.DaemonSet
+		expected       *v1alpha1.CreateStrategy
+	}{
+		{
+			name:           "new profile waits for its DaemonSet",
+			toLabelNodes:   3,
+			maxUnavailable: 1,
+			expected: &v1alpha1.CreateStrategy{
+				Status:         v1alpha1.WaitingStatus,
+				MaxUnavailable: 1,
+				LastTransition: &now,
+			},
+		},
+		{
+			name: "DaemonSet created",
+			previous: &v1alpha1.CreateStrategy{
+				Status:         v1alpha1.WaitingStatus,
+				MaxUnavailable: 1,
+				LastTransition: &oneSecBefore,
+			},
+			toLabelNodes:   3,
+			maxUnavailable: 1,
+			daemonSet:      daemonSet(0),
+			expected: &v1alpha1.CreateStrategy{
+				Status:         v1alpha1.InProgressStatus,
+				MaxUnavailable: 1,
+				LastTransition: &now,
+			},
+		},
+		{
+			name: "nodes labeled up to maxUnavailable",
+			previous: &v1alpha1.CreateStrategy{
+				Status:         v1alpha1.InProgressStatus,
+				MaxUnavailable: 1,
+				LastTransition: &oneSecBefore,
+			},
+			toLabelNodes:   3,
+			maxUnavailable: 1,
+			daemonSet:      daemonSet(0),
+			expected: &v1alpha1.CreateStrategy{
+				Status:         v1alpha1.WaitingStatus,
+				NodesLabeled:   1,
+				MaxUnavailable: 1,
+				LastTransition: &now,
+			},
+		},
+		{
+			name: "labeled node ready",
+			previous: &v1alpha1.CreateStrategy{
+				Status:         v1alpha1.WaitingStatus,
+				NodesLabeled:   1,
+				MaxUnavailable: 1,
+				LastTransition: &oneSecBefore,
+			},
+			labeledNodes:   1,
+			toLabelNodes:   2,
+			maxUnavailable: 1,
+			daemonSet:      daemonSet(1),
+			expected: &v1alpha1.CreateStrategy{
+				Status:         v1alpha1.InProgressStatus,
+				NodesLabeled:   1,
+				MaxUnavailable: 1,
+				PodsReady:      1,
+				LastTransition: &now,
+			},
+		},
+		{
+			name: "all nodes labeled",
+			previous: &v1alpha1.CreateStrategy{
+				Status:         v1alpha1.InProgressStatus,
+				NodesLabeled:   2,
+				MaxUnavailable: 1,
+				PodsReady:      2,
+				LastTransition: &oneSecBefore,
+			},
+			labeledNodes:   3,
+			maxUnavailable: 1,
+			daemonSet:      daemonSet(3),
+			expected: &v1alpha1.CreateStrategy{
+				Status:         v1alpha1.CompletedStatus,
+				NodesLabeled:   3,
+				MaxUnavailable: 1,
+				PodsReady:      3,
+				LastTransition: &now,
+			},
+		},
+		{
+			name: "unchanged status keeps its last transition",
+			previous: &v1alpha1.CreateStrategy{
+				Status:         v1alpha1.CompletedStatus,
+				NodesLabeled:   3,
+				MaxUnavailable: 1,
+				PodsReady:      3,
+				LastTransition: &oneSecBefore,
+			},
+			labeledNodes:   3,
+			maxUnavailable: 1,
+			daemonSet:      daemonSet(3),
+			expected: &v1alpha1.CreateStrategy{
+				Status:         v1alpha1.CompletedStatus,
+				NodesLabeled:   3,
+				MaxUnavailable: 1,
+				PodsReady:      3,
+				LastTransition: &oneSecBefore,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testLogger := zap.New(zap.UseDevMode(true))
+			status := CreateStrategyStatus(testLogger, tt.previous, tt.labeledNodes, tt.toLabelNodes, tt.maxUnavailable, tt.daemonSet, now)
+			assert.Equal(t, tt.expected, status)
+		})
+	}
+}
+
 func Test_validateProfileName(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -0,0 +1,73 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package agentprofile
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+)
+
+// ProfileExplanation describes why a profile is or isn't applied to a node.
+type ProfileExplanation struct {
+	Profile  types.NamespacedName
+	Priority int32
+	Matches  bool
+	Applied  bool
+	Reason   string
+}
+
+// ExplainNode returns, for each profile in order of precedence, whether it
+// matches the node and whether it is the profile applied to it. The profile
+// applied to the node is the first matching valid profile, or the default
+// profile when no profile matches. It doesn't take into account the node
+// labeling done by the create strategy.
+func ExplainNode(profiles []v1alpha1.DatadogAgentProfile, node *v1.Node) (types.NamespacedName, []ProfileExplanation) {
+	applied := types.NamespacedName{Name: defaultProfileName}
+	appliedFound := false
+
+	sortedProfiles := SortProfilesByPriority(profiles)
+	explanations := make([]ProfileExplanation, 0, len(sortedProfiles))
+	for i := range sortedProfiles {
+		profile := &sortedProfiles[i]
+		explanation := ProfileExplanation{
+			Profile:  types.NamespacedName{Namespace: profile.Namespace, Name: profile.Name},
+			Priority: profilePriority(profile),
+		}
+
+		matches, err := explainProfileMatch(profile, node)
+		switch {
+		case err != nil:
+			explanation.Reason = fmt.Sprintf("Invalid profile: %v", err)
+		case !matches:
+			explanation.Reason = "Node doesn't match the profile affinity"
+		case appliedFound:
+			explanation.Matches = true
+			explanation.Reason = fmt.Sprintf("Profile %s has a higher precedence", applied.String())
+		default:
+			explanation.Matches = true
+			explanation.Applied = true
+			explanation.Reason = "Matching profile with the highest precedence"
+			applied = explanation.Profile
+			appliedFound = true
+		}
+
+		explanations = append(explanations, explanation)
+	}
+
+	return applied, explanations
+}
+
+func explainProfileMatch(profile *v1alpha1.DatadogAgentProfile, node *v1.Node) (bool, error) {
+	if err := ValidateProfile(profile); err != nil {
+		return false, err
+	}
+
+	return profileMatchesNode(profile, node.Labels)
+}
@@ -0,0 +1,93 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package agentprofile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+func TestExplainNode(t *testing.T) {
+	linux := exampleProfileForLinux()
+	windows := exampleProfileForWindows()
+	highPriorityLinux := exampleProfileForLinux()
+	highPriorityLinux.Name = "linux-gpu"
+	highPriorityLinux.Spec.Priority = apiutils.NewInt32Pointer(10)
+	invalid := exampleInvalidProfile()
+	invalid.Name = "invalid"
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node1",
+			Labels: map[string]string{"os": "linux"},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		profiles         []v1alpha1.DatadogAgentProfile
+		wantApplied      types.NamespacedName
+		wantExplanations []ProfileExplanation
+	}{
+		{
+			name:        "no profiles",
+			wantApplied: types.NamespacedName{Name: defaultProfileName},
+		},
+		{
+			name:        "no matching profile",
+			profiles:    []v1alpha1.DatadogAgentProfile{windows, invalid},
+			wantApplied: types.NamespacedName{Name: defaultProfileName},
+			wantExplanations: []ProfileExplanation{
+				{
+					Profile: types.NamespacedName{Namespace: testNamespace, Name: "invalid"},
+					Reason:  "Invalid profile: profileAffinity must be defined",
+				},
+				{
+					Profile: types.NamespacedName{Namespace: testNamespace, Name: "windows"},
+					Reason:  "Node doesn't match the profile affinity",
+				},
+			},
+		},
+		{
+			name:        "highest priority wins",
+			profiles:    []v1alpha1.DatadogAgentProfile{linux, highPriorityLinux},
+			wantApplied: types.NamespacedName{Namespace: testNamespace, Name: "linux-gpu"},
+			wantExplanations: []ProfileExplanation{
+				{
+					Profile:  types.NamespacedName{Namespace: testNamespace, Name: "linux-gpu"},
+					Priority: 10,
+					Matches:  true,
+					Applied:  true,
+					Reason:   "Matching profile with the highest precedence",
+				},
+				{
+					Profile: types.NamespacedName{Namespace: testNamespace, Name: "linux"},
+					Matches: true,
+					Reason:  "Profile default/linux-gpu has a higher precedence",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied, explanations := ExplainNode(tt.profiles, node)
+			assert.Equal(t, tt.wantApplied, applied)
+			if tt.wantExplanations == nil {
+				assert.Empty(t, explanations)
+			} else {
+				assert.Equal(t, tt.wantExplanations, explanations)
+			}
+		})
+	}
+}
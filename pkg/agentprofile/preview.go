@@ -0,0 +1,98 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package agentprofile
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+)
+
+// Preview is the result of the evaluation of a profile against the nodes of
+// the cluster.
+type Preview struct {
+	// MatchingNodes is the number of nodes matching the profile affinity.
+	MatchingNodes int32
+	// WonNodes are the matching nodes the profile is applied to.
+	WonNodes []string
+	// LostNodes are the matching nodes another profile is applied to.
+	LostNodes []v1alpha1.LostNode
+}
+
+// ValidateProfile checks that the name and the spec of a profile are valid.
+func ValidateProfile(profile *v1alpha1.DatadogAgentProfile) error {
+	if err := validateProfileName(profile.Name); err != nil {
+		return err
+	}
+
+	return v1alpha1.ValidateDatadogAgentProfileSpec(&profile.Spec)
+}
+
+// PreviewProfile evaluates a profile against the given nodes, taking into
+// account the precedence of the other profiles. Unlike ApplyProfile, it
+// doesn't depend on a DatadogAgent and doesn't take into account the node
+// labeling done by the create strategy.
+func PreviewProfile(profile *v1alpha1.DatadogAgentProfile, profiles []v1alpha1.DatadogAgentProfile, nodes []v1.Node) (Preview, error) {
+	preview := Preview{}
+	if err := ValidateProfile(profile); err != nil {
+		return preview, err
+	}
+
+	profileNamespacedName := types.NamespacedName{Namespace: profile.Namespace, Name: profile.Name}
+	allProfiles := []v1alpha1.DatadogAgentProfile{*profile}
+	for _, p := range profiles {
+		if (types.NamespacedName{Namespace: p.Namespace, Name: p.Name}) != profileNamespacedName {
+			allProfiles = append(allProfiles, p)
+		}
+	}
+
+	profileAppliedByNode := make(map[string]types.NamespacedName, len(nodes))
+	for _, p := range SortProfilesByPriority(allProfiles) {
+		current := types.NamespacedName{Namespace: p.Namespace, Name: p.Name} == profileNamespacedName
+		if !current && ValidateProfile(&p) != nil {
+			continue
+		}
+
+		for _, node := range nodes {
+			matches, err := profileMatchesNode(&p, node.Labels)
+			if err != nil {
+				if current {
+					return Preview{}, err
+				}
+				break
+			}
+			if !matches {
+				continue
+			}
+
+			existingProfile, found := profileAppliedByNode[node.Name]
+			if current {
+				preview.MatchingNodes++
+				if found {
+					preview.LostNodes = append(preview.LostNodes, v1alpha1.LostNode{Node: node.Name, Profile: existingProfile.String()})
+				} else {
+					preview.WonNodes = append(preview.WonNodes, node.Name)
+				}
+			} else if !found {
+				profileAppliedByNode[node.Name] = types.NamespacedName{Namespace: p.Namespace, Name: p.Name}
+			}
+		}
+
+		if current {
+			break
+		}
+	}
+
+	sort.Strings(preview.WonNodes)
+	sort.Slice(preview.LostNodes, func(i, j int) bool {
+		return preview.LostNodes[i].Node < preview.LostNodes[j].Node
+	})
+
+	return preview, nil
+}
@@ -0,0 +1,117 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package agentprofile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+func TestPreviewProfile(t *testing.T) {
+	nodes := []v1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node1",
+				Labels: map[string]string{"os": "linux", "gpu": "true"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node2",
+				Labels: map[string]string{"os": "linux"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node3",
+				Labels: map[string]string{"os": "windows"},
+			},
+		},
+	}
+
+	linux := exampleProfileForLinux()
+	gpu := exampleProfileForLinux()
+	gpu.Name = "gpu"
+	gpu.Spec.Priority = apiutils.NewInt32Pointer(10)
+	gpu.Spec.ProfileAffinity.ProfileNodeAffinity = []v1.NodeSelectorRequirement{
+		{
+			Key:      "gpu",
+			Operator: v1.NodeSelectorOpIn,
+			Values:   []string{"true"},
+		},
+	}
+	invalidHighPriority := exampleInvalidProfile()
+	invalidHighPriority.Spec.Priority = apiutils.NewInt32Pointer(100)
+
+	tests := []struct {
+		name            string
+		profile         v1alpha1.DatadogAgentProfile
+		profiles        []v1alpha1.DatadogAgentProfile
+		expectedPreview Preview
+		expectedErr     bool
+	}{
+		{
+			name:        "invalid profile",
+			profile:     exampleInvalidProfile(),
+			expectedErr: true,
+		},
+		{
+			name:     "profile alone",
+			profile:  linux,
+			profiles: []v1alpha1.DatadogAgentProfile{linux},
+			expectedPreview: Preview{
+				MatchingNodes: 2,
+				WonNodes:      []string{"node1", "node2"},
+			},
+		},
+		{
+			name:     "profile not in the list",
+			profile:  linux,
+			profiles: nil,
+			expectedPreview: Preview{
+				MatchingNodes: 2,
+				WonNodes:      []string{"node1", "node2"},
+			},
+		},
+		{
+			name:     "profile with a lower priority",
+			profile:  linux,
+			profiles: []v1alpha1.DatadogAgentProfile{linux, gpu, invalidHighPriority},
+			expectedPreview: Preview{
+				MatchingNodes: 2,
+				WonNodes:      []string{"node2"},
+				LostNodes:     []v1alpha1.LostNode{{Node: "node1", Profile: testNamespace + "/gpu"}},
+			},
+		},
+		{
+			name:     "profile with a higher priority",
+			profile:  gpu,
+			profiles: []v1alpha1.DatadogAgentProfile{linux, gpu},
+			expectedPreview: Preview{
+				MatchingNodes: 1,
+				WonNodes:      []string{"node1"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			preview, err := PreviewProfile(&test.profile, test.profiles, nodes)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedPreview, preview)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package calico
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GlobalNetworkPolicyKind is the kind of the Calico global network policies
+const GlobalNetworkPolicyKind = "GlobalNetworkPolicy"
+
+// GroupVersionGlobalNetworkPolicyListKind return the schema.GroupVersionKind for GlobalNetworkPolicyList
+func GroupVersionGlobalNetworkPolicyListKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "projectcalico.org",
+		Version: "v3",
+		Kind:    "GlobalNetworkPolicyList",
+	}
+}
+
+// GroupVersionGlobalNetworkPolicyKind return the schema.GroupVersionKind for GlobalNetworkPolicy
+func GroupVersionGlobalNetworkPolicyKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "projectcalico.org",
+		Version: "v3",
+		Kind:    GlobalNetworkPolicyKind,
+	}
+}
+
+// EmptyCalicoUnstructuredListPolicy return a new unstructured.UnstructuredList for GlobalNetworkPolicy
+func EmptyCalicoUnstructuredListPolicy() *unstructured.UnstructuredList {
+	policy := &unstructured.UnstructuredList{}
+	policy.SetGroupVersionKind(GroupVersionGlobalNetworkPolicyListKind())
+
+	return policy
+}
+
+// EmptyCalicoUnstructuredPolicy return a new unstructured.Unstructured for GlobalNetworkPolicy
+func EmptyCalicoUnstructuredPolicy() *unstructured.Unstructured {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(GroupVersionGlobalNetworkPolicyKind())
+
+	return policy
+}
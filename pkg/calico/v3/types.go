@@ -0,0 +1,77 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package calico
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Action is a Calico rule action
+type Action string
+
+const (
+	// ActionAllow allows the traffic matched by a rule
+	ActionAllow Action = "Allow"
+	// ActionDeny denies the traffic matched by a rule
+	ActionDeny Action = "Deny"
+)
+
+// Protocol is a Calico network protocol
+type Protocol string
+
+const (
+	// ProtocolTCP refers to the TCP network protocol
+	ProtocolTCP Protocol = "TCP"
+	// ProtocolUDP refers to the UDP network protocol
+	ProtocolUDP Protocol = "UDP"
+	// ProtocolSCTP refers to the SCTP network protocol
+	ProtocolSCTP Protocol = "SCTP"
+)
+
+// PolicyType is a Calico policy type
+type PolicyType string
+
+const (
+	// PolicyTypeIngress is the ingress policy type
+	PolicyTypeIngress PolicyType = "Ingress"
+	// PolicyTypeEgress is the egress policy type
+	PolicyTypeEgress PolicyType = "Egress"
+)
+
+// GlobalNetworkPolicy is a Calico global network policy
+type GlobalNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GlobalNetworkPolicySpec `json:"spec,omitempty"`
+}
+
+// GlobalNetworkPolicySpec is a Calico global network policy spec
+type GlobalNetworkPolicySpec struct {
+	Selector          string       `json:"selector,omitempty"`
+	NamespaceSelector string       `json:"namespaceSelector,omitempty"`
+	Types             []PolicyType `json:"types,omitempty"`
+	Ingress           []Rule       `json:"ingress,omitempty"`
+	Egress            []Rule       `json:"egress,omitempty"`
+}
+
+// Rule is a Calico policy rule
+type Rule struct {
+	Action      Action     `json:"action"`
+	Protocol    *Protocol  `json:"protocol,omitempty"`
+	Source      EntityRule `json:"source,omitempty"`
+	Destination EntityRule `json:"destination,omitempty"`
+}
+
+// EntityRule is the source or destination of a Calico rule
+type EntityRule struct {
+	Nets              []string `json:"nets,omitempty"`
+	Selector          string   `json:"selector,omitempty"`
+	NamespaceSelector string   `json:"namespaceSelector,omitempty"`
+	Ports             []string `json:"ports,omitempty"`
+	// Domains is only supported by Calico Enterprise and Calico Cloud
+	Domains []string `json:"domains,omitempty"`
+}
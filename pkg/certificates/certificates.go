@@ -0,0 +1,185 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package certificates
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+)
+
+const (
+	certificatePEMType = "CERTIFICATE"
+	privateKeyPEMType  = "PRIVATE KEY"
+
+	// The certificates are valid a bit before their issuance to tolerate clock skews
+	clockSkew = 5 * time.Minute
+)
+
+// KeyPair is a certificate and its private key
+type KeyPair struct {
+	Certificate *x509.Certificate
+	Key         crypto.Signer
+}
+
+// NewCA returns a self-signed certificate authority
+func NewCA(commonName string, validity time.Duration, now time.Time) (*KeyPair, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName},
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return newKeyPair(template, nil, validity, now)
+}
+
+// Issue returns a certificate signed by the certificate authority.
+// The certificate is a serving certificate when dnsNames are provided, a client certificate otherwise.
+func (ca *KeyPair) Issue(commonName string, dnsNames []string, validity time.Duration, now time.Time) (*KeyPair, error) {
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: commonName},
+		DNSNames:    dnsNames,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if len(dnsNames) > 0 {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	}
+	return newKeyPair(template, ca, validity, now)
+}
+
+func newKeyPair(template *x509.Certificate, parent *KeyPair, validity time.Duration, now time.Time) (*KeyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate the private key: %w", err)
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate the serial number: %w", err)
+	}
+	template.SerialNumber = serialNumber
+	template.NotBefore = now.Add(-clockSkew)
+	template.NotAfter = now.Add(validity)
+
+	parentCert, parentKey := template, crypto.Signer(key)
+	if parent != nil {
+		parentCert, parentKey = parent.Certificate, parent.Key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, key.Public(), parentKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyPair{Certificate: cert, Key: key}, nil
+}
+
+// CertificatePEM returns the PEM encoded certificate
+func (kp *KeyPair) CertificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: certificatePEMType, Bytes: kp.Certificate.Raw})
+}
+
+// KeyPEM returns the PEM encoded PKCS #8 private key
+func (kp *KeyPair) KeyPEM() ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(kp.Key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: privateKeyPEMType, Bytes: der}), nil
+}
+
+// ParseKeyPair decodes a PEM encoded certificate and its private key
+func ParseKeyPair(certPEM, keyPEM []byte) (*KeyPair, error) {
+	certs, err := ParseCertificates(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate found")
+	}
+
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("no private key found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the private key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key type")
+	}
+
+	kp := &KeyPair{Certificate: certs[0], Key: signer}
+	if !kp.hasMatchingKey() {
+		return nil, errors.New("the private key doesn't match the certificate")
+	}
+	return kp, nil
+}
+
+func (kp *KeyPair) hasMatchingKey() bool {
+	public, ok := kp.Key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && public.Equal(kp.Certificate.PublicKey)
+}
+
+// ParseCertificates decodes a bundle of PEM encoded certificates
+func ParseCertificates(bundle []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			return certs, nil
+		}
+		if block.Type != certificatePEMType {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+}
+
+// Bundle returns the PEM encoded certificates of a bundle of certificate authorities
+func Bundle(cas ...*x509.Certificate) []byte {
+	var buf bytes.Buffer
+	for _, ca := range cas {
+		_ = pem.Encode(&buf, &pem.Block{Type: certificatePEMType, Bytes: ca.Raw})
+	}
+	return buf.Bytes()
+}
+
+// NeedsRenewal returns true once two thirds of the validity of the certificate elapsed
+func NeedsRenewal(cert *x509.Certificate, now time.Time) bool {
+	validity := cert.NotAfter.Sub(cert.NotBefore)
+	return !now.Before(cert.NotBefore.Add(validity * 2 / 3))
+}
+
+// IsIssuedBy returns true if the certificate is signed by the certificate authority
+func IsIssuedBy(cert, ca *x509.Certificate) bool {
+	return cert.CheckSignatureFrom(ca) == nil
+}
+
+// HasDNSNames returns true if the certificate is valid for exactly the DNS names
+func HasDNSNames(cert *x509.Certificate, dnsNames []string) bool {
+	return slices.Equal(slices.Sorted(slices.Values(cert.DNSNames)), slices.Sorted(slices.Values(dnsNames)))
+}
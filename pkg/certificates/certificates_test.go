@@ -0,0 +1,78 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package certificates
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssue(t *testing.T) {
+	now := time.Now()
+	ca, err := NewCA("ca", 24*time.Hour, now)
+	require.NoError(t, err)
+	assert.True(t, ca.Certificate.IsCA)
+
+	server, err := ca.Issue("server", []string{"server.default.svc"}, time.Hour, now)
+	require.NoError(t, err)
+	client, err := ca.Issue("client", nil, time.Hour, now)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+	_, err = server.Certificate.Verify(x509.VerifyOptions{Roots: roots, DNSName: "server.default.svc", CurrentTime: now})
+	assert.NoError(t, err)
+	_, err = client.Certificate.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, CurrentTime: now})
+	assert.NoError(t, err)
+	_, err = client.Certificate.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, CurrentTime: now})
+	assert.Error(t, err)
+
+	assert.True(t, IsIssuedBy(server.Certificate, ca.Certificate))
+	otherCA, err := NewCA("ca", 24*time.Hour, now)
+	require.NoError(t, err)
+	assert.False(t, IsIssuedBy(server.Certificate, otherCA.Certificate))
+
+	assert.True(t, HasDNSNames(server.Certificate, []string{"server.default.svc"}))
+	assert.False(t, HasDNSNames(server.Certificate, []string{"server.default.svc", "server"}))
+}
+
+func TestParseKeyPair(t *testing.T) {
+	ca, err := NewCA("ca", time.Hour, time.Now())
+	require.NoError(t, err)
+	keyPEM, err := ca.KeyPEM()
+	require.NoError(t, err)
+
+	parsed, err := ParseKeyPair(ca.CertificatePEM(), keyPEM)
+	require.NoError(t, err)
+	assert.True(t, parsed.Certificate.Equal(ca.Certificate))
+
+	other, err := NewCA("other", time.Hour, time.Now())
+	require.NoError(t, err)
+	_, err = ParseKeyPair(other.CertificatePEM(), keyPEM)
+	assert.EqualError(t, err, "the private key doesn't match the certificate")
+
+	_, err = ParseKeyPair(nil, keyPEM)
+	assert.EqualError(t, err, "no certificate found")
+
+	certs, err := ParseCertificates(Bundle(ca.Certificate, other.Certificate))
+	require.NoError(t, err)
+	require.Len(t, certs, 2)
+	assert.True(t, certs[1].Equal(other.Certificate))
+}
+
+func TestNeedsRenewal(t *testing.T) {
+	now := time.Now()
+	ca, err := NewCA("ca", 90*time.Hour, now)
+	require.NoError(t, err)
+
+	assert.False(t, NeedsRenewal(ca.Certificate, now))
+	assert.False(t, NeedsRenewal(ca.Certificate, now.Add(59*time.Hour)))
+	assert.True(t, NeedsRenewal(ca.Certificate, now.Add(61*time.Hour)))
+}
@@ -0,0 +1,72 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package certmanager
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// GroupName is the API group of cert-manager
+	GroupName = "cert-manager.io"
+	// IssuerKind is the kind of the cert-manager issuers
+	IssuerKind = "Issuer"
+	// ClusterIssuerKind is the kind of the cert-manager cluster issuers
+	ClusterIssuerKind = "ClusterIssuer"
+	// CertificateKind is the kind of the cert-manager certificates
+	CertificateKind = "Certificate"
+)
+
+func groupVersionKind(kind string) schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   GroupName,
+		Version: "v1",
+		Kind:    kind,
+	}
+}
+
+// GroupVersionIssuerKind return the schema.GroupVersionKind for Issuer
+func GroupVersionIssuerKind() schema.GroupVersionKind {
+	return groupVersionKind(IssuerKind)
+}
+
+// GroupVersionCertificateKind return the schema.GroupVersionKind for Certificate
+func GroupVersionCertificateKind() schema.GroupVersionKind {
+	return groupVersionKind(CertificateKind)
+}
+
+// EmptyIssuerUnstructured return a new unstructured.Unstructured for Issuer
+func EmptyIssuerUnstructured() *unstructured.Unstructured {
+	issuer := &unstructured.Unstructured{}
+	issuer.SetGroupVersionKind(GroupVersionIssuerKind())
+
+	return issuer
+}
+
+// EmptyIssuerUnstructuredList return a new unstructured.UnstructuredList for Issuer
+func EmptyIssuerUnstructuredList() *unstructured.UnstructuredList {
+	issuers := &unstructured.UnstructuredList{}
+	issuers.SetGroupVersionKind(groupVersionKind(IssuerKind + "List"))
+
+	return issuers
+}
+
+// EmptyCertificateUnstructured return a new unstructured.Unstructured for Certificate
+func EmptyCertificateUnstructured() *unstructured.Unstructured {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(GroupVersionCertificateKind())
+
+	return certificate
+}
+
+// EmptyCertificateUnstructuredList return a new unstructured.UnstructuredList for Certificate
+func EmptyCertificateUnstructuredList() *unstructured.UnstructuredList {
+	certificates := &unstructured.UnstructuredList{}
+	certificates.SetGroupVersionKind(groupVersionKind(CertificateKind + "List"))
+
+	return certificates
+}
@@ -0,0 +1,81 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Issuer is a cert-manager issuer
+type Issuer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IssuerSpec `json:"spec,omitempty"`
+}
+
+// IssuerSpec is a cert-manager issuer spec, only the self-signed and CA issuers are supported
+type IssuerSpec struct {
+	SelfSigned *SelfSignedIssuer `json:"selfSigned,omitempty"`
+	CA         *CAIssuer         `json:"ca,omitempty"`
+}
+
+// SelfSignedIssuer issues self-signed certificates
+type SelfSignedIssuer struct{}
+
+// CAIssuer issues certificates signed by a certificate authority stored in a secret
+type CAIssuer struct {
+	SecretName string `json:"secretName"`
+}
+
+// Certificate is a cert-manager certificate
+type Certificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CertificateSpec `json:"spec,omitempty"`
+}
+
+// CertificateSpec is a cert-manager certificate spec
+type CertificateSpec struct {
+	SecretName  string                 `json:"secretName"`
+	CommonName  string                 `json:"commonName,omitempty"`
+	DNSNames    []string               `json:"dnsNames,omitempty"`
+	Duration    *metav1.Duration       `json:"duration,omitempty"`
+	RenewBefore *metav1.Duration       `json:"renewBefore,omitempty"`
+	IsCA        bool                   `json:"isCA,omitempty"`
+	Usages      []KeyUsage             `json:"usages,omitempty"`
+	PrivateKey  *CertificatePrivateKey `json:"privateKey,omitempty"`
+	IssuerRef   IssuerReference        `json:"issuerRef"`
+}
+
+// KeyUsage is a usage of a certificate
+type KeyUsage string
+
+const (
+	// UsageDigitalSignature allows the key to sign
+	UsageDigitalSignature KeyUsage = "digital signature"
+	// UsageCertSign allows the key to sign certificates
+	UsageCertSign KeyUsage = "cert sign"
+	// UsageServerAuth allows the certificate to authenticate servers
+	UsageServerAuth KeyUsage = "server auth"
+	// UsageClientAuth allows the certificate to authenticate clients
+	UsageClientAuth KeyUsage = "client auth"
+)
+
+// CertificatePrivateKey configures the private key of a certificate
+type CertificatePrivateKey struct {
+	Algorithm      string `json:"algorithm,omitempty"`
+	Size           int    `json:"size,omitempty"`
+	RotationPolicy string `json:"rotationPolicy,omitempty"`
+}
+
+// IssuerReference references the issuer of a certificate
+type IssuerReference struct {
+	Name  string `json:"name"`
+	Kind  string `json:"kind,omitempty"`
+	Group string `json:"group,omitempty"`
+}
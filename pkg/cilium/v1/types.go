@@ -31,6 +31,8 @@ const (
 	EntityRemoteNode Entity = "remote-node"
 	// EntityWorld is a world entity
 	EntityWorld Entity = "world"
+	// EntityAll is the entity matching all endpoints
+	EntityAll Entity = "all"
 )
 
 // NetworkPolicy is a Cilium network policy
@@ -51,6 +53,7 @@ type NetworkPolicySpec struct {
 
 // IngressRule is a Cilium ingress rule
 type IngressRule struct {
+	FromCIDR      []string               `json:"fromCIDR,omitempty"`
 	FromEndpoints []metav1.LabelSelector `json:"fromEndpoints,omitempty"`
 	FromEntities  []Entity               `json:"fromEntities,omitempty"`
 	ToPorts       []PortRule             `json:"toPorts,omitempty"`
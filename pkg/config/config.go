@@ -34,6 +34,8 @@ const (
 	dashboardWatchNamespaceEnvVar = "DD_DASHBOARD_WATCH_NAMESPACE"
 	// GenericResourceWatchNamespaceEnvVar is a comma-separated list of namespaces watched by the DatadogGenericResource controller.
 	genericResourceWatchNamespaceEnvVar = "DD_GENERIC_RESOURCE_WATCH_NAMESPACE"
+	// InstrumentationWatchNamespaceEnvVar is a comma-separated list of namespaces watched for DatadogInstrumentations by the DatadogAgent controller.
+	instrumentationWatchNamespaceEnvVar = "DD_INSTRUMENTATION_WATCH_NAMESPACE"
 	// MonitorWatchNamespaceEnvVar is a comma-separated list of namespaces watched by the DatadogMonitor controller.
 	monitorWatchNamespaceEnvVar = "DD_MONITOR_WATCH_NAMESPACE"
 	// ProfilesWatchNamespaceEnvVar is a comma-separated list of namespaces watched by the DatadogAgentProfile controller.
@@ -49,6 +51,7 @@ var (
 	agentObj           = &datadoghqv2alpha1.DatadogAgent{}
 	dashboardObj       = &datadoghqv1alpha1.DatadogDashboard{}
 	genericResourceObj = &datadoghqv1alpha1.DatadogGenericResource{}
+	instrumentationObj = &datadoghqv1alpha1.DatadogInstrumentation{}
 	monitorObj         = &datadoghqv1alpha1.DatadogMonitor{}
 	sloObj             = &datadoghqv1alpha1.DatadogSLO{}
 	profileObj         = &datadoghqv1alpha1.DatadogAgentProfile{}
@@ -64,6 +67,7 @@ type WatchOptions struct {
 	IntrospectionEnabled          bool
 	DatadogDashboardEnabled       bool
 	DatadogGenericResourceEnabled bool
+	DatadogInstrumentationEnabled bool
 }
 
 // CacheOptions function configures Controller Runtime cache options on a resource level (supported in v0.16+).
@@ -95,6 +99,14 @@ func CacheOptions(logger logr.Logger, opts WatchOptions) cache.Options {
 		}
 	}
 
+	if opts.DatadogInstrumentationEnabled {
+		instrumentationNamespaces := getWatchNamespacesFromEnv(logger, instrumentationWatchNamespaceEnvVar)
+		logger.Info("DatadogInstrumentation Enabled", "watching namespaces", maps.Keys(instrumentationNamespaces))
+		byObject[instrumentationObj] = cache.ByObject{
+			Namespaces: instrumentationNamespaces,
+		}
+	}
+
 	if opts.DatadogMonitorEnabled {
 		monitorNamespaces := getWatchNamespacesFromEnv(logger, monitorWatchNamespaceEnvVar)
 		logger.Info("DatadogMonitor Enabled", "watching namespaces", maps.Keys(monitorNamespaces))
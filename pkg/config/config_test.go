@@ -38,6 +38,7 @@ func Test_CacheConfig(t *testing.T) {
 				DatadogAgentProfileEnabled:    true,
 				DatadogDashboardEnabled:       true,
 				DatadogGenericResourceEnabled: true,
+				DatadogInstrumentationEnabled: true,
 			},
 
 			envConfig: map[string]string{
@@ -48,6 +49,7 @@ func Test_CacheConfig(t *testing.T) {
 				profileWatchNamespaceEnvVar:         "profileNs",
 				dashboardWatchNamespaceEnvVar:       "dashboardNs",
 				genericResourceWatchNamespaceEnvVar: "genericNs",
+				instrumentationWatchNamespaceEnvVar: "instrumentationNs",
 			},
 
 			wantDefaultNamepsace: objectConfig{configured: true, namespaces: []string{"agentNs"}},
@@ -56,6 +58,7 @@ func Test_CacheConfig(t *testing.T) {
 				agentObj:           {configured: true, namespaces: []string{"agentNs"}},
 				dashboardObj:       {configured: true, namespaces: []string{"dashboardNs"}},
 				genericResourceObj: {configured: true, namespaces: []string{"genericNs"}},
+				instrumentationObj: {configured: true, namespaces: []string{"instrumentationNs"}},
 				monitorObj:         {configured: true, namespaces: []string{"monitorNs", "monitorNs2"}},
 				sloObj:             {configured: true, namespaces: []string{"nsWithSpace"}},
 				profileObj:         {configured: true, namespaces: []string{"profileNs"}},
@@ -81,6 +84,7 @@ func Test_CacheConfig(t *testing.T) {
 				agentObj:           {configured: true, namespaces: []string{"datadog"}},
 				dashboardObj:       {configured: false},
 				genericResourceObj: {configured: false},
+				instrumentationObj: {configured: false},
 				monitorObj:         {configured: false},
 				sloObj:             {configured: false},
 				profileObj:         {configured: true, namespaces: []string{"profileNs"}},
@@ -109,6 +113,7 @@ func Test_CacheConfig(t *testing.T) {
 				agentObj:           {configured: true, namespaces: []string{"agentNs1", "agentNs2"}},
 				dashboardObj:       {configured: false},
 				genericResourceObj: {configured: false},
+				instrumentationObj: {configured: false},
 				monitorObj:         {configured: false},
 				sloObj:             {configured: false},
 				profileObj:         {configured: true, namespaces: []string{"profileNs"}},
@@ -136,6 +141,7 @@ func Test_CacheConfig(t *testing.T) {
 				agentObj:           {configured: true, namespaces: []string{"agentNs1", "agentNs2"}},
 				dashboardObj:       {configured: false},
 				genericResourceObj: {configured: false},
+				instrumentationObj: {configured: false},
 				monitorObj:         {configured: true, namespaces: []string{"datadog"}},
 				sloObj:             {configured: false},
 				profileObj:         {configured: false},
@@ -164,6 +170,7 @@ func Test_CacheConfig(t *testing.T) {
 				agentObj:           {configured: true, namespaces: []string{"agentNs1", "agentNs2"}},
 				dashboardObj:       {configured: false},
 				genericResourceObj: {configured: false},
+				instrumentationObj: {configured: false},
 				monitorObj:         {configured: false},
 				sloObj:             {configured: false},
 				profileObj:         {configured: false},
@@ -69,4 +69,6 @@ const (
 	MD5AgentDeploymentAnnotationKey = "agent.datadoghq.com/agentspechash"
 	// MD5ChecksumAnnotationKey annotation key is used to identify customConfig configurations
 	MD5ChecksumAnnotationKey = "checksum/%s-custom-config"
+	// RemoteConfigApprovedIDAnnotationKey annotation key is used on a DatadogAgent to approve the Remote Configuration proposal with the given config ID
+	RemoteConfigApprovedIDAnnotationKey = "agent.datadoghq.com/remote-config-approved-id"
 )
@@ -6,10 +6,13 @@
 package constants
 
 const (
-	DDAPIKey      = "DD_API_KEY"
-	DDAppKey      = "DD_APP_KEY"
-	DDddURL       = "DD_DD_URL"
-	DDURL         = "DD_URL"
-	DDSite        = "DD_SITE"
-	DDClusterName = "DD_CLUSTER_NAME"
+	DDAPIKey       = "DD_API_KEY"
+	DDAppKey       = "DD_APP_KEY"
+	DDddURL        = "DD_DD_URL"
+	DDURL          = "DD_URL"
+	DDSite         = "DD_SITE"
+	DDClusterName  = "DD_CLUSTER_NAME"
+	DDProxyHTTP    = "DD_PROXY_HTTP"
+	DDProxyHTTPS   = "DD_PROXY_HTTPS"
+	DDProxyNoProxy = "DD_PROXY_NO_PROXY"
 )
@@ -12,13 +12,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/pkg/defaulting"
 )
 
 // GetConfName get the name of the Configmap for a CustomConfigSpec
-func GetConfName(owner metav1.Object, conf *v2alpha1.CustomConfig, defaultName string) string {
+func GetConfName(owner metav1.Object, conf *apicommon.CustomConfig, defaultName string) string {
 	// `configData` and `configMap` can't be set together.
 	// Return the default if the conf is not overridden or if it is just overridden with the ConfigData.
 	if conf != nil && conf.ConfigMap != nil {
@@ -6,3 +6,7 @@
 package utils
 
 const NotFoundString = "404 Not Found"
+
+// ForceSyncAnnotationKey is the annotation used to request an immediate sync of a resource with the Datadog API.
+// Its value is the RFC3339 time of the request.
+const ForceSyncAnnotationKey = "operator.datadoghq.com/force-sync"
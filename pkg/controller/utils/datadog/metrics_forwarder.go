@@ -10,12 +10,15 @@ import (
 	"errors"
 	"fmt"
 	"hash/fnv"
+	"net/url"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	api "github.com/zorkian/go-datadog-api"
+	"golang.org/x/net/http/httpproxy"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,6 +29,7 @@ import (
 
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	"github.com/DataDog/datadog-operator/pkg/config"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
 	"github.com/DataDog/datadog-operator/pkg/kubernetes"
 	"github.com/DataDog/datadog-operator/pkg/secrets"
 )
@@ -128,6 +132,8 @@ type metricsForwarder struct {
 	decryptor           secrets.Decryptor
 	creds               sync.Map
 	baseURL             string
+	proxyConfig         httpproxy.Config
+	clientProxyConfig   httpproxy.Config
 	status              *ConditionCommon
 	credsManager        *config.CredentialManager
 	sync.Mutex
@@ -254,6 +260,12 @@ func (mf *metricsForwarder) setup() error {
 	mf.baseURL = getbaseURL(dda)
 	mf.logger.V(1).Info("Got API URL for DatadogAgent", "site", mf.baseURL)
 
+	proxyConfig, err := mf.getProxyConfig(dda)
+	if err != nil {
+		return err
+	}
+	mf.proxyConfig = proxyConfig
+
 	if dda.Spec.Global != nil && dda.Spec.Global.ClusterName != nil {
 		mf.clusterName = *dda.Spec.Global.ClusterName
 	}
@@ -415,12 +427,13 @@ func (mf *metricsForwarder) initAPIClient(apiKey string) error {
 	}
 	mf.datadogClient = datadogClient
 	mf.keysHash = hashKeys(apiKey)
+	mf.clientProxyConfig = mf.proxyConfig
 	return nil
 }
 
-// updateCredsIfNeeded used to update Datadog apiKey if it changes
+// updateCredsIfNeeded used to update Datadog apiKey or proxy configuration if it changes
 func (mf *metricsForwarder) updateCredsIfNeeded(apiKey string) error {
-	if mf.keysHash != hashKeys(apiKey) {
+	if mf.keysHash != hashKeys(apiKey) || mf.clientProxyConfig != mf.proxyConfig {
 		return mf.initAPIClient(apiKey)
 	}
 	return nil
@@ -435,6 +448,7 @@ func (mf *metricsForwarder) validateCreds(apiKey string) (*api.Client, error) {
 func (mf *metricsForwarder) delegatedValidateCreds(apiKey string) (*api.Client, error) {
 	datadogClient := api.NewClient(apiKey, emptyAppKey)
 	datadogClient.SetBaseUrl(mf.baseURL)
+	datadogClient.HttpClient = datadogclient.NewHTTPClient(mf.proxyConfig)
 	valid, err := datadogClient.Validate()
 	if err != nil {
 		return nil, fmt.Errorf("cannot validate datadog credentials: %w", err)
@@ -661,6 +675,62 @@ func (mf *metricsForwarder) cleanSecretsCache() {
 	})
 }
 
+// getProxyConfig returns the proxy configuration of the DatadogAgent, on top of the Operator one
+func (mf *metricsForwarder) getProxyConfig(dda *v2alpha1.DatadogAgent) (httpproxy.Config, error) {
+	proxyConfig := datadogclient.ProxyConfigFromEnv()
+	if dda.Spec.Global == nil || dda.Spec.Global.Proxy == nil {
+		return proxyConfig, nil
+	}
+	proxy := dda.Spec.Global.Proxy
+
+	// The credentials are URL-encoded in the secrets, like in the proxy URLs of the Agents
+	var userInfo *url.Userinfo
+	if proxy.UsernameSecret != nil {
+		username, err := mf.getURLEncodedKeyFromSecret(dda.Namespace, proxy.UsernameSecret.SecretName, proxy.UsernameSecret.KeyName)
+		if err != nil {
+			return proxyConfig, err
+		}
+		userInfo = url.User(username)
+		if proxy.PasswordSecret != nil {
+			password, err := mf.getURLEncodedKeyFromSecret(dda.Namespace, proxy.PasswordSecret.SecretName, proxy.PasswordSecret.KeyName)
+			if err != nil {
+				return proxyConfig, err
+			}
+			userInfo = url.UserPassword(username, password)
+		}
+	}
+
+	var err error
+	if proxy.HTTP != nil {
+		if proxyConfig.HTTPProxy, err = proxyURLWithUserInfo(*proxy.HTTP, userInfo); err != nil {
+			return proxyConfig, err
+		}
+	}
+	if proxy.HTTPS != nil {
+		if proxyConfig.HTTPSProxy, err = proxyURLWithUserInfo(*proxy.HTTPS, userInfo); err != nil {
+			return proxyConfig, err
+		}
+	}
+	if len(proxy.NoProxy) > 0 {
+		proxyConfig.NoProxy = strings.Join(proxy.NoProxy, ",")
+	}
+
+	return proxyConfig, nil
+}
+
+// getURLEncodedKeyFromSecret retrieves a URL-encoded value from a secret object and decodes it
+func (mf *metricsForwarder) getURLEncodedKeyFromSecret(namespace, secretName, dataKey string) (string, error) {
+	value, err := mf.getKeyFromSecret(namespace, secretName, dataKey)
+	if err != nil {
+		return "", err
+	}
+	decoded, err := url.PathUnescape(value)
+	if err != nil {
+		return "", fmt.Errorf("the key %s of secret %s/%s is not URL-encoded: %w", dataKey, namespace, secretName, err)
+	}
+	return decoded, nil
+}
+
 // getKeyFromSecret is used to retrieve an API or App key from a secret object
 func (mf *metricsForwarder) getKeyFromSecret(namespace, secretName, dataKey string) (string, error) {
 	secret := &corev1.Secret{}
@@ -785,3 +855,16 @@ func getbaseURL(dda *v2alpha1.DatadogAgent) string {
 	}
 	return defaultbaseURL
 }
+
+// proxyURLWithUserInfo adds the proxy credentials to the proxy URL
+func proxyURLWithUserInfo(proxyURL string, userInfo *url.Userinfo) (string, error) {
+	if userInfo == nil {
+		return proxyURL, nil
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid proxy URL %q: %w", proxyURL, err)
+	}
+	u.User = userInfo
+	return u.String(), nil
+}
@@ -8,6 +8,7 @@ package utils
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -42,3 +43,16 @@ func GetDatadogAgentResourceUID(dda metav1.Object) string {
 func GetDatadogAgentResourceCreationTime(dda metav1.Object) string {
 	return strconv.FormatInt(dda.GetCreationTimestamp().Unix(), 10)
 }
+
+// ForceSyncRequested returns true if a sync was requested with the ForceSyncAnnotationKey annotation after the last forced sync
+func ForceSyncRequested(obj metav1.Object, lastForceSyncTime *metav1.Time) bool {
+	value, found := obj.GetAnnotations()[ForceSyncAnnotationKey]
+	if !found {
+		return false
+	}
+	requestTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return false
+	}
+	return lastForceSyncTime == nil || requestTime.After(lastForceSyncTime.Time)
+}
@@ -0,0 +1,58 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestForceSyncRequested(t *testing.T) {
+	lastForceSync := metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	tests := []struct {
+		name              string
+		annotations       map[string]string
+		lastForceSyncTime *metav1.Time
+		want              bool
+	}{
+		{
+			name: "no annotation",
+			want: false,
+		},
+		{
+			name:        "invalid annotation",
+			annotations: map[string]string{ForceSyncAnnotationKey: "now"},
+			want:        false,
+		},
+		{
+			name:        "never synced",
+			annotations: map[string]string{ForceSyncAnnotationKey: "2024-01-02T03:00:00Z"},
+			want:        true,
+		},
+		{
+			name:              "requested before the last sync",
+			annotations:       map[string]string{ForceSyncAnnotationKey: "2024-01-02T03:00:00Z"},
+			lastForceSyncTime: &lastForceSync,
+			want:              false,
+		},
+		{
+			name:              "requested after the last sync",
+			annotations:       map[string]string{ForceSyncAnnotationKey: "2024-01-02T03:05:00Z"},
+			lastForceSyncTime: &lastForceSync,
+			want:              true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Annotations: tt.annotations}
+			assert.Equal(t, tt.want, ForceSyncRequested(obj, tt.lastForceSyncTime))
+		})
+	}
+}
@@ -36,6 +36,7 @@ func InitDatadogMonitorClient(logger logr.Logger, creds config.Creds) (DatadogMo
 	}
 
 	configV1 := datadogapi.NewConfiguration()
+	configV1.HTTPClient = NewHTTPClient(ProxyConfigFromEnv())
 	apiClient := datadogapi.NewAPIClient(configV1)
 	client := datadogV1.NewMonitorsApi(apiClient)
 
@@ -60,6 +61,7 @@ func InitDatadogSLOClient(logger logr.Logger, creds config.Creds) (DatadogSLOCli
 	}
 
 	configV1 := datadogapi.NewConfiguration()
+	configV1.HTTPClient = NewHTTPClient(ProxyConfigFromEnv())
 	apiClient := datadogapi.NewAPIClient(configV1)
 	client := datadogV1.NewServiceLevelObjectivesApi(apiClient)
 
@@ -83,6 +85,7 @@ func InitDatadogDashboardClient(logger logr.Logger, creds config.Creds) (Datadog
 	}
 
 	configV1 := datadogapi.NewConfiguration()
+	configV1.HTTPClient = NewHTTPClient(ProxyConfigFromEnv())
 	apiClient := datadogapi.NewAPIClient(configV1)
 	client := datadogV1.NewDashboardsApi(apiClient)
 
@@ -108,6 +111,7 @@ func InitDatadogGenericClient(logger logr.Logger, creds config.Creds) (DatadogGe
 	}
 
 	configV1 := datadogapi.NewConfiguration()
+	configV1.HTTPClient = NewHTTPClient(ProxyConfigFromEnv())
 	apiClient := datadogapi.NewAPIClient(configV1)
 	syntheticsClient := datadogV1.NewSyntheticsApi(apiClient)
 	notebooksClient := datadogV1.NewNotebooksApi(apiClient)
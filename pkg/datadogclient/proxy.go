@@ -0,0 +1,56 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+
+	"github.com/DataDog/datadog-operator/pkg/constants"
+	"github.com/DataDog/datadog-operator/pkg/tracing"
+)
+
+// instrumentTransport wraps the transport of the HTTP clients, see SetTransportInstrumentation
+var instrumentTransport = func(next http.RoundTripper) http.RoundTripper { return next }
+
+// SetTransportInstrumentation sets the function wrapping the transport of the HTTP clients created afterwards,
+// for example to record metrics about the Datadog API requests. It must be called before creating the clients.
+func SetTransportInstrumentation(instrument func(next http.RoundTripper) http.RoundTripper) {
+	instrumentTransport = instrument
+}
+
+// ProxyConfigFromEnv returns the proxy configuration of the Operator.
+// The DD_PROXY_HTTP, DD_PROXY_HTTPS and DD_PROXY_NO_PROXY environment variables
+// take precedence over the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY ones.
+func ProxyConfigFromEnv() httpproxy.Config {
+	proxyConfig := *httpproxy.FromEnvironment()
+	if value := os.Getenv(constants.DDProxyHTTP); value != "" {
+		proxyConfig.HTTPProxy = value
+	}
+	if value := os.Getenv(constants.DDProxyHTTPS); value != "" {
+		proxyConfig.HTTPSProxy = value
+	}
+	if value := os.Getenv(constants.DDProxyNoProxy); value != "" {
+		// The Agent separates the hosts with spaces
+		proxyConfig.NoProxy = strings.Join(strings.Fields(value), ",")
+	}
+	return proxyConfig
+}
+
+// NewHTTPClient returns an HTTP client sending its requests through the configured proxy.
+// Each request is traced, and instrumented by the function set with SetTransportInstrumentation.
+func NewHTTPClient(proxyConfig httpproxy.Config) *http.Client {
+	proxyFunc := proxyConfig.ProxyFunc()
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+	return &http.Client{Transport: tracing.NewTransport(instrumentTransport(transport))}
+}
@@ -57,6 +57,8 @@ func IsEqualObject(kind kubernetes.ObjectKind, a, b client.Object) bool {
 		return IsEqualNetworkPolicies(a, b)
 	case kubernetes.CiliumNetworkPoliciesKind:
 		return IsEqualCiliumNetworkPolicies(a, b)
+	case kubernetes.CalicoGlobalNetworkPoliciesKind, kubernetes.AdminNetworkPoliciesKind, kubernetes.CertManagerIssuersKind, kubernetes.CertManagerCertificatesKind:
+		return IsEqualUnstructuredSpec(a, b)
 	default:
 		return false
 	}
@@ -220,6 +222,21 @@ func IsEqualCiliumNetworkPolicies(objA, objB client.Object) bool {
 	return apiequality.Semantic.DeepEqual(unstructuredA["specs"], unstructuredB["specs"])
 }
 
+// IsEqualUnstructuredSpec return true if the specs of the two unstructured objects are equal
+func IsEqualUnstructuredSpec(objA, objB client.Object) bool {
+	unstructuredA, errA := runtime.DefaultUnstructuredConverter.ToUnstructured(objA)
+	if errA != nil {
+		return false
+	}
+
+	unstructuredB, errB := runtime.DefaultUnstructuredConverter.ToUnstructured(objB)
+	if errB != nil {
+		return false
+	}
+
+	return apiequality.Semantic.DeepEqual(unstructuredA["spec"], unstructuredB["spec"])
+}
+
 // IsEqualOperatorObjectMeta return true if the meta information added by the Operator are equal:
 // Annotations, Labels, OwnerReference
 func IsEqualOperatorObjectMeta(a, b metav1.Object) bool {
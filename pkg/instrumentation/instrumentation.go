@@ -0,0 +1,169 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package instrumentation
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+// IsSingleStepInstrumentationEnabled returns true if the DatadogAgent runs the
+// Single Step Instrumentation, which is required to apply DatadogInstrumentation targets.
+// It expects a DatadogAgent with defaults applied.
+func IsSingleStepInstrumentationEnabled(dda *v2alpha1.DatadogAgent) bool {
+	features := dda.Spec.Features
+	if features == nil || features.APM == nil || features.AdmissionController == nil {
+		return false
+	}
+
+	return apiutils.BoolValue(features.APM.Enabled) &&
+		apiutils.BoolValue(features.AdmissionController.Enabled) &&
+		features.APM.SingleStepInstrumentation != nil &&
+		apiutils.BoolValue(features.APM.SingleStepInstrumentation.Enabled)
+}
+
+// TargetName returns the name of the Single Step Instrumentation target
+// generated for a DatadogInstrumentation.
+func TargetName(instrumentation *v1alpha1.DatadogInstrumentation) string {
+	return types.NamespacedName{Namespace: instrumentation.Namespace, Name: instrumentation.Name}.String()
+}
+
+// SortInstrumentations sorts the DatadogInstrumentations by creation timestamp.
+// If two DatadogInstrumentations have the same creation timestamp, it sorts
+// them by namespace and name.
+func SortInstrumentations(instrumentations []v1alpha1.DatadogInstrumentation) []v1alpha1.DatadogInstrumentation {
+	sorted := make([]v1alpha1.DatadogInstrumentation, len(instrumentations))
+	copy(sorted, instrumentations)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].CreationTimestamp.Equal(&sorted[j].CreationTimestamp) {
+			return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+		}
+
+		return TargetName(&sorted[i]) < TargetName(&sorted[j])
+	})
+
+	return sorted
+}
+
+// BuildTargets validates the given DatadogInstrumentations and returns the
+// Single Step Instrumentation targets of the ones that can be applied. The
+// DatadogInstrumentations are expected to be sorted with SortInstrumentations:
+// when the pod selectors of two DatadogInstrumentations of the same namespace
+// overlap, the first one is accepted and the other one is marked as conflicting.
+// A DatadogInstrumentation overlapping with a target of the DatadogAgent spec is
+// also marked as conflicting.
+// The status of each DatadogInstrumentation is updated in place.
+func BuildTargets(logger logr.Logger, instrumentations []v1alpha1.DatadogInstrumentation, specTargets []v2alpha1.SSITarget, now metav1.Time) []v2alpha1.SSITarget {
+	var targets []v2alpha1.SSITarget
+	var accepted []*v1alpha1.DatadogInstrumentation
+
+	for i := range instrumentations {
+		instr := &instrumentations[i]
+		status := newStatus(instr)
+
+		if err := v1alpha1.IsValidDatadogInstrumentation(&instr.Spec); err != nil {
+			logger.Info("DatadogInstrumentation is invalid, skipping", "datadoginstrumentation", TargetName(instr), "error", err.Error())
+			setCondition(&status, ValidConditionType, metav1.ConditionFalse, InvalidConditionReason, err.Error(), now)
+			setCondition(&status, AcceptedConditionType, metav1.ConditionFalse, InvalidConditionReason, "Invalid spec", now)
+			updateStatus(instr, status, now)
+			continue
+		}
+		setCondition(&status, ValidConditionType, metav1.ConditionTrue, ValidConditionReason, "Valid spec", now)
+
+		if conflicting := findSpecTargetConflict(instr, specTargets); conflicting != nil {
+			msg := fmt.Sprintf("Pod selector overlaps with the DatadogAgent target %s", conflicting.Name)
+			logger.Info("DatadogInstrumentation conflicts with a DatadogAgent target, skipping", "datadoginstrumentation", TargetName(instr), "target", conflicting.Name)
+			setCondition(&status, AcceptedConditionType, metav1.ConditionFalse, ConflictConditionReason, msg, now)
+			updateStatus(instr, status, now)
+			continue
+		}
+		if conflicting := findConflict(instr, accepted); conflicting != nil {
+			msg := fmt.Sprintf("Pod selector overlaps with DatadogInstrumentation %s", TargetName(conflicting))
+			logger.Info("DatadogInstrumentation conflicts with an existing one, skipping", "datadoginstrumentation", TargetName(instr), "existing", TargetName(conflicting))
+			setCondition(&status, AcceptedConditionType, metav1.ConditionFalse, ConflictConditionReason, msg, now)
+			updateStatus(instr, status, now)
+			continue
+		}
+
+		accepted = append(accepted, instr)
+		targets = append(targets, targetFromInstrumentation(instr))
+		status.Target = TargetName(instr)
+		setCondition(&status, AcceptedConditionType, metav1.ConditionTrue, AcceptedConditionReason, "Target merged into the Cluster Agent configuration", now)
+		updateStatus(instr, status, now)
+	}
+
+	return targets
+}
+
+// RejectAll marks all the given DatadogInstrumentations as not accepted with
+// the given reason and message. Their spec is still validated to keep the
+// Valid condition up to date.
+func RejectAll(instrumentations []v1alpha1.DatadogInstrumentation, reason, message string, now metav1.Time) {
+	for i := range instrumentations {
+		instr := &instrumentations[i]
+		status := newStatus(instr)
+		if err := v1alpha1.IsValidDatadogInstrumentation(&instr.Spec); err != nil {
+			setCondition(&status, ValidConditionType, metav1.ConditionFalse, InvalidConditionReason, err.Error(), now)
+		} else {
+			setCondition(&status, ValidConditionType, metav1.ConditionTrue, ValidConditionReason, "Valid spec", now)
+		}
+		setCondition(&status, AcceptedConditionType, metav1.ConditionFalse, reason, message, now)
+		updateStatus(instr, status, now)
+	}
+}
+
+func targetFromInstrumentation(instr *v1alpha1.DatadogInstrumentation) v2alpha1.SSITarget {
+	return v2alpha1.SSITarget{
+		Name:        TargetName(instr),
+		PodSelector: instr.Spec.PodSelector,
+		NamespaceSelector: &v2alpha1.NamespaceSelector{
+			MatchNames: []string{instr.Namespace},
+		},
+		TracerVersions: instr.Spec.DDTraceVersions,
+		TracerConfigs:  instr.Spec.DDTraceConfigs,
+	}
+}
+
+func findConflict(instr *v1alpha1.DatadogInstrumentation, accepted []*v1alpha1.DatadogInstrumentation) *v1alpha1.DatadogInstrumentation {
+	for _, other := range accepted {
+		if other.Namespace != instr.Namespace {
+			continue
+		}
+		if selectorsOverlap(instr.Spec.PodSelector, other.Spec.PodSelector) {
+			return other
+		}
+	}
+
+	return nil
+}
+
+// findSpecTargetConflict returns the first target of the DatadogAgent spec that
+// could match the same pods as the DatadogInstrumentation. The labels of the
+// namespace aren't known, a target selecting namespaces by labels is assumed to
+// select the namespace of the DatadogInstrumentation.
+func findSpecTargetConflict(instr *v1alpha1.DatadogInstrumentation, specTargets []v2alpha1.SSITarget) *v2alpha1.SSITarget {
+	for i := range specTargets {
+		target := &specTargets[i]
+		if nsSelector := target.NamespaceSelector; nsSelector != nil && len(nsSelector.MatchNames) > 0 && !slices.Contains(nsSelector.MatchNames, instr.Namespace) {
+			continue
+		}
+		if selectorsOverlap(instr.Spec.PodSelector, target.PodSelector) {
+			return target
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package instrumentation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+func newInstrumentation(namespace, name string, created time.Time, selector *metav1.LabelSelector) v1alpha1.DatadogInstrumentation {
+	return v1alpha1.DatadogInstrumentation{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: v1alpha1.DatadogInstrumentationSpec{
+			PodSelector:     selector,
+			DDTraceVersions: map[string]string{"java": "1"},
+		},
+	}
+}
+
+func TestSortInstrumentations(t *testing.T) {
+	now := time.Now()
+	instrumentations := []v1alpha1.DatadogInstrumentation{
+		newInstrumentation("ns", "c", now, nil),
+		newInstrumentation("ns", "b", now, nil),
+		newInstrumentation("ns", "a", now.Add(time.Minute), nil),
+	}
+
+	sorted := SortInstrumentations(instrumentations)
+
+	names := []string{}
+	for i := range sorted {
+		names = append(names, TargetName(&sorted[i]))
+	}
+	assert.Equal(t, []string{"ns/b", "ns/c", "ns/a"}, names)
+}
+
+func TestBuildTargets(t *testing.T) {
+	logger := zap.New(zap.UseDevMode(true))
+	created := time.Now()
+	now := metav1.NewTime(created.Add(time.Hour))
+
+	invalid := newInstrumentation("ns1", "invalid", created, nil)
+	invalid.Spec.DDTraceVersions = nil
+
+	instrumentations := []v1alpha1.DatadogInstrumentation{
+		newInstrumentation("ns1", "first", created, &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}}),
+		newInstrumentation("ns1", "conflict", created.Add(time.Second), &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}}),
+		newInstrumentation("ns1", "disjoint", created.Add(2*time.Second), &metav1.LabelSelector{MatchLabels: map[string]string{"app": "b"}}),
+		newInstrumentation("ns2", "other-namespace", created.Add(3*time.Second), nil),
+		newInstrumentation("ns3", "spec-conflict", created.Add(4*time.Second), &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}}),
+		invalid,
+	}
+	instrumentations[0].Spec.DDTraceConfigs = []corev1.EnvVar{{Name: "DD_PROFILING_ENABLED", Value: "true"}}
+	specTargets := []v2alpha1.SSITarget{
+		{
+			Name:              "spec-ns3",
+			PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}},
+			NamespaceSelector: &v2alpha1.NamespaceSelector{MatchNames: []string{"ns2", "ns3"}},
+		},
+		{
+			Name:              "spec-ns1-disjoint",
+			PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "c"}},
+			NamespaceSelector: &v2alpha1.NamespaceSelector{MatchLabels: map[string]string{"team": "a"}},
+		},
+	}
+
+	targets := BuildTargets(logger, instrumentations, specTargets, now)
+
+	expectedTargets := []v2alpha1.SSITarget{
+		{
+			Name:              "ns1/first",
+			PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}},
+			NamespaceSelector: &v2alpha1.NamespaceSelector{MatchNames: []string{"ns1"}},
+			TracerVersions:    map[string]string{"java": "1"},
+			TracerConfigs:     []corev1.EnvVar{{Name: "DD_PROFILING_ENABLED", Value: "true"}},
+		},
+		{
+			Name:              "ns1/disjoint",
+			PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "b"}},
+			NamespaceSelector: &v2alpha1.NamespaceSelector{MatchNames: []string{"ns1"}},
+			TracerVersions:    map[string]string{"java": "1"},
+		},
+	}
+	assert.Equal(t, expectedTargets, targets)
+
+	expectedStatus := map[string]struct {
+		valid    metav1.ConditionStatus
+		accepted metav1.ConditionStatus
+		reason   string
+		target   string
+	}{
+		"first":           {metav1.ConditionTrue, metav1.ConditionTrue, AcceptedConditionReason, "ns1/first"},
+		"conflict":        {metav1.ConditionTrue, metav1.ConditionFalse, ConflictConditionReason, ""},
+		"disjoint":        {metav1.ConditionTrue, metav1.ConditionTrue, AcceptedConditionReason, "ns1/disjoint"},
+		"other-namespace": {metav1.ConditionTrue, metav1.ConditionFalse, ConflictConditionReason, ""},
+		"spec-conflict":   {metav1.ConditionTrue, metav1.ConditionFalse, ConflictConditionReason, ""},
+		"invalid":         {metav1.ConditionFalse, metav1.ConditionFalse, InvalidConditionReason, ""},
+	}
+	for _, instr := range instrumentations {
+		expected := expectedStatus[instr.Name]
+		assert.Equal(t, expected.valid, instr.Status.Valid, instr.Name)
+		assert.Equal(t, expected.accepted, instr.Status.Accepted, instr.Name)
+		assert.Equal(t, expected.target, instr.Status.Target, instr.Name)
+		assert.Equal(t, &now, instr.Status.LastUpdate, instr.Name)
+
+		accepted := meta.FindStatusCondition(instr.Status.Conditions, AcceptedConditionType)
+		require.NotNil(t, accepted, instr.Name)
+		assert.Equal(t, expected.reason, accepted.Reason, instr.Name)
+	}
+}
+
+func TestBuildTargetsKeepsLastUpdate(t *testing.T) {
+	logger := zap.New(zap.UseDevMode(true))
+	created := time.Now()
+	firstUpdate := metav1.NewTime(created.Add(time.Minute))
+
+	instrumentations := []v1alpha1.DatadogInstrumentation{newInstrumentation("ns", "instr", created, nil)}
+	BuildTargets(logger, instrumentations, nil, firstUpdate)
+	BuildTargets(logger, instrumentations, nil, metav1.NewTime(created.Add(time.Hour)))
+
+	assert.Equal(t, &firstUpdate, instrumentations[0].Status.LastUpdate)
+}
+
+func TestRejectAll(t *testing.T) {
+	logger := zap.New(zap.UseDevMode(true))
+	now := metav1.Now()
+	invalid := newInstrumentation("ns", "invalid", now.Time, nil)
+	invalid.Spec.DDTraceVersions = nil
+	instrumentations := []v1alpha1.DatadogInstrumentation{newInstrumentation("ns", "instr", now.Time, nil), invalid}
+	BuildTargets(logger, instrumentations, nil, now)
+
+	// The spec of an instrumentation is fixed while Single Step Instrumentation is disabled
+	instrumentations[1].Spec.DDTraceVersions = map[string]string{"java": "1"}
+	RejectAll(instrumentations, SSIDisabledConditionReason, "disabled", now)
+
+	for _, instr := range instrumentations {
+		assert.Equal(t, metav1.ConditionFalse, instr.Status.Accepted, instr.Name)
+		assert.Equal(t, metav1.ConditionTrue, instr.Status.Valid, instr.Name)
+		assert.True(t, meta.IsStatusConditionFalse(instr.Status.Conditions, AcceptedConditionType), instr.Name)
+		assert.True(t, meta.IsStatusConditionTrue(instr.Status.Conditions, ValidConditionType), instr.Name)
+		assert.Empty(t, instr.Status.Target, instr.Name)
+	}
+}
+
+func TestSelectorsOverlap(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *metav1.LabelSelector
+		b    *metav1.LabelSelector
+		want bool
+	}{
+		{
+			name: "nil selectors",
+			want: true,
+		},
+		{
+			name: "nil selector matches everything",
+			a:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}},
+			want: true,
+		},
+		{
+			name: "different label values",
+			a:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}},
+			b:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "b"}},
+			want: false,
+		},
+		{
+			name: "different label keys",
+			a:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}},
+			b:    &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}},
+			want: true,
+		},
+		{
+			name: "in and intersecting in",
+			a:    &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"a", "b"}}}},
+			b:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "b"}},
+			want: true,
+		},
+		{
+			name: "in and not in",
+			a:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}},
+			b:    &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"a", "b"}}}},
+			want: false,
+		},
+		{
+			name: "exists and does not exist",
+			a:    &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: metav1.LabelSelectorOpDoesNotExist}}},
+			b:    &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: metav1.LabelSelectorOpExists}}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, selectorsOverlap(tt.a, tt.b))
+		})
+	}
+}
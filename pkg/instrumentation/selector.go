@@ -0,0 +1,97 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package instrumentation
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// selectorsOverlap returns true if a pod could be matched by both selectors.
+// The check is conservative: the selectors are only reported as disjoint when
+// one of their requirements on a same key can never be satisfied together.
+func selectorsOverlap(a, b *metav1.LabelSelector) bool {
+	requirementsA := selectorRequirements(a)
+	requirementsB := selectorRequirements(b)
+
+	for _, reqA := range requirementsA {
+		for _, reqB := range requirementsB {
+			if reqA.Key != reqB.Key {
+				continue
+			}
+			if requirementsDisjoint(reqA, reqB) || requirementsDisjoint(reqB, reqA) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// selectorRequirements returns the requirements of a selector, with the
+// matchLabels converted to `In` requirements. A nil selector has no requirements.
+func selectorRequirements(selector *metav1.LabelSelector) []metav1.LabelSelectorRequirement {
+	if selector == nil {
+		return nil
+	}
+
+	requirements := make([]metav1.LabelSelectorRequirement, 0, len(selector.MatchLabels)+len(selector.MatchExpressions))
+	for key, value := range selector.MatchLabels {
+		requirements = append(requirements, metav1.LabelSelectorRequirement{
+			Key:      key,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{value},
+		})
+	}
+
+	return append(requirements, selector.MatchExpressions...)
+}
+
+func requirementsDisjoint(a, b metav1.LabelSelectorRequirement) bool {
+	switch a.Operator {
+	case metav1.LabelSelectorOpIn:
+		switch b.Operator {
+		case metav1.LabelSelectorOpIn:
+			return !intersects(a.Values, b.Values)
+		case metav1.LabelSelectorOpNotIn:
+			return isSubset(a.Values, b.Values)
+		case metav1.LabelSelectorOpDoesNotExist:
+			return true
+		}
+	case metav1.LabelSelectorOpExists:
+		return b.Operator == metav1.LabelSelectorOpDoesNotExist
+	}
+
+	return false
+}
+
+func intersects(a, b []string) bool {
+	for _, va := range a {
+		for _, vb := range b {
+			if va == vb {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isSubset(a, b []string) bool {
+	for _, va := range a {
+		found := false
+		for _, vb := range b {
+			if va == vb {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,76 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package instrumentation
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v1alpha1"
+)
+
+const (
+	// ValidConditionType is a type of condition for a DatadogInstrumentation
+	ValidConditionType = "Valid"
+	// AcceptedConditionType is a type of condition for a DatadogInstrumentation
+	AcceptedConditionType = "Accepted"
+
+	// ValidConditionReason is for DatadogInstrumentations with a valid spec
+	ValidConditionReason = "Valid"
+	// InvalidConditionReason is for DatadogInstrumentations with an invalid spec
+	InvalidConditionReason = "Invalid"
+	// AcceptedConditionReason is for DatadogInstrumentations merged into the Cluster Agent configuration
+	AcceptedConditionReason = "Accepted"
+	// ConflictConditionReason is for DatadogInstrumentations whose pod selector overlaps with an existing DatadogInstrumentation
+	ConflictConditionReason = "Conflict"
+	// SSIDisabledConditionReason is for DatadogInstrumentations that cannot be applied because Single Step Instrumentation is disabled
+	SSIDisabledConditionReason = "SingleStepInstrumentationDisabled"
+)
+
+// newStatus returns the status a DatadogInstrumentation starts from during a
+// reconcile: the existing conditions are kept so that transition times are
+// only updated when a condition status changes.
+func newStatus(instr *v1alpha1.DatadogInstrumentation) v1alpha1.DatadogInstrumentationStatus {
+	return v1alpha1.DatadogInstrumentationStatus{
+		Conditions: append([]metav1.Condition(nil), instr.Status.Conditions...),
+	}
+}
+
+func setCondition(status *v1alpha1.DatadogInstrumentationStatus, conditionType string, conditionStatus metav1.ConditionStatus, reason, message string, now metav1.Time) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	})
+
+	switch conditionType {
+	case ValidConditionType:
+		status.Valid = conditionStatus
+	case AcceptedConditionType:
+		status.Accepted = conditionStatus
+	}
+}
+
+// updateStatus sets the new status of a DatadogInstrumentation. LastUpdate is
+// only bumped when the status changes to avoid needless API server updates.
+func updateStatus(instr *v1alpha1.DatadogInstrumentation, newStatus v1alpha1.DatadogInstrumentationStatus, now metav1.Time) {
+	if newStatus.Valid == "" {
+		newStatus.Valid = metav1.ConditionUnknown
+	}
+	if newStatus.Accepted == "" {
+		newStatus.Accepted = metav1.ConditionUnknown
+	}
+
+	newStatus.LastUpdate = instr.Status.LastUpdate
+	if !equality.Semantic.DeepEqual(instr.Status, newStatus) {
+		newStatus.LastUpdate = &now
+	}
+
+	instr.Status = newStatus
+}
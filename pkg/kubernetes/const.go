@@ -29,8 +29,16 @@ const (
 type ObjectKind string
 
 const (
+	// AdminNetworkPoliciesKind is the AdminNetworkPolicies resource kind
+	AdminNetworkPoliciesKind = "adminnetworkpolicies"
 	// APIServiceKind is the APIService resource kind
 	APIServiceKind = "apiservices"
+	// CalicoGlobalNetworkPoliciesKind is the Calico GlobalNetworkPolicies resource kind
+	CalicoGlobalNetworkPoliciesKind = "globalnetworkpolicies"
+	// CertManagerCertificatesKind is the cert-manager Certificates resource kind
+	CertManagerCertificatesKind = "certificates"
+	// CertManagerIssuersKind is the cert-manager Issuers resource kind
+	CertManagerIssuersKind = "issuers"
 	// CiliumNetworkPoliciesKind is the CiliumNetworkPolicies resource kind
 	CiliumNetworkPoliciesKind = "ciliumnetworkpolicies"
 	// ClusterRolesKind is the ClusterRoles resource kind
@@ -13,6 +13,9 @@ import (
 	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	anpv1alpha1 "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+	calicov3 "github.com/DataDog/datadog-operator/pkg/calico/v3"
+	certmanagerv1 "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
 	ciliumv1 "github.com/DataDog/datadog-operator/pkg/cilium/v1"
 )
 
@@ -47,6 +50,14 @@ func ObjectFromKind(kind ObjectKind, platformInfo PlatformInfo) client.Object {
 		return &networkingv1.NetworkPolicy{}
 	case CiliumNetworkPoliciesKind:
 		return ciliumv1.EmptyCiliumUnstructuredPolicy()
+	case CalicoGlobalNetworkPoliciesKind:
+		return calicov3.EmptyCalicoUnstructuredPolicy()
+	case AdminNetworkPoliciesKind:
+		return anpv1alpha1.EmptyAdminNetworkPolicyUnstructuredPolicy()
+	case CertManagerIssuersKind:
+		return certmanagerv1.EmptyIssuerUnstructured()
+	case CertManagerCertificatesKind:
+		return certmanagerv1.EmptyCertificateUnstructured()
 	case NodeKind:
 		return &corev1.Node{}
 	}
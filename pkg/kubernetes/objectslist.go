@@ -13,6 +13,9 @@ import (
 	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	anpv1alpha1 "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+	calicov3 "github.com/DataDog/datadog-operator/pkg/calico/v3"
+	certmanagerv1 "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
 	ciliumv1 "github.com/DataDog/datadog-operator/pkg/cilium/v1"
 )
 
@@ -47,6 +50,14 @@ func ObjectListFromKind(kind ObjectKind, platformInfo PlatformInfo) client.Objec
 		return &networkingv1.NetworkPolicyList{}
 	case CiliumNetworkPoliciesKind:
 		return ciliumv1.EmptyCiliumUnstructuredListPolicy()
+	case CalicoGlobalNetworkPoliciesKind:
+		return calicov3.EmptyCalicoUnstructuredListPolicy()
+	case AdminNetworkPoliciesKind:
+		return anpv1alpha1.EmptyAdminNetworkPolicyUnstructuredListPolicy()
+	case CertManagerIssuersKind:
+		return certmanagerv1.EmptyIssuerUnstructuredList()
+	case CertManagerCertificatesKind:
+		return certmanagerv1.EmptyCertificateUnstructuredList()
 	}
 
 	return nil
@@ -6,6 +6,10 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/version"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anpv1alpha1 "github.com/DataDog/datadog-operator/pkg/adminnetworkpolicy/v1alpha1"
+	calicov3 "github.com/DataDog/datadog-operator/pkg/calico/v3"
+	certmanagerv1 "github.com/DataDog/datadog-operator/pkg/certmanager/v1"
 )
 
 type PlatformInfo struct {
@@ -83,7 +87,17 @@ func (platformInfo *PlatformInfo) CreatePDBObjectList() client.ObjectList {
 }
 
 func (platformInfo *PlatformInfo) GetAgentResourcesKind(withCiliumResources bool) []ObjectKind {
-	return getResourcesKind(withCiliumResources)
+	resources := getResourcesKind(withCiliumResources)
+	if platformInfo.IsResourceSupported(calicov3.GlobalNetworkPolicyKind) {
+		resources = append(resources, CalicoGlobalNetworkPoliciesKind)
+	}
+	if platformInfo.IsResourceSupported(anpv1alpha1.AdminNetworkPolicyKind) {
+		resources = append(resources, AdminNetworkPoliciesKind)
+	}
+	if platformInfo.IsResourceSupported(certmanagerv1.CertificateKind) {
+		resources = append(resources, CertManagerIssuersKind, CertManagerCertificatesKind)
+	}
+	return resources
 }
 
 // IsResourceSupported returns true if a Kubernetes resource is supported by the server
@@ -286,3 +286,20 @@ func containsObjectKind(list []ObjectKind, s ObjectKind) bool {
 	}
 	return false
 }
+
+func Test_GetAgentResourcesKind(t *testing.T) {
+	platformInfo := NewPlatformInfoFromVersionMaps(nil, map[string]string{}, map[string]string{})
+	assert.NotContains(t, platformInfo.GetAgentResourcesKind(false), ObjectKind(CalicoGlobalNetworkPoliciesKind))
+	assert.NotContains(t, platformInfo.GetAgentResourcesKind(false), ObjectKind(AdminNetworkPoliciesKind))
+	assert.NotContains(t, platformInfo.GetAgentResourcesKind(false), ObjectKind(CertManagerCertificatesKind))
+
+	platformInfo = NewPlatformInfoFromVersionMaps(nil, map[string]string{
+		"GlobalNetworkPolicy": "projectcalico.org/v3",
+		"AdminNetworkPolicy":  "policy.networking.k8s.io/v1alpha1",
+		"Certificate":         "cert-manager.io/v1",
+	}, map[string]string{})
+	assert.Contains(t, platformInfo.GetAgentResourcesKind(false), ObjectKind(CalicoGlobalNetworkPoliciesKind))
+	assert.Contains(t, platformInfo.GetAgentResourcesKind(false), ObjectKind(AdminNetworkPoliciesKind))
+	assert.Contains(t, platformInfo.GetAgentResourcesKind(false), ObjectKind(CertManagerIssuersKind))
+	assert.Contains(t, platformInfo.GetAgentResourcesKind(false), ObjectKind(CertManagerCertificatesKind))
+}
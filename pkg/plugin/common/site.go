@@ -0,0 +1,67 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultSite is the Datadog site used when none is configured
+const DefaultSite = "datadoghq.com"
+
+// SupportedSites lists the Datadog sites
+var SupportedSites = []string{
+	"datadoghq.com",
+	"us3.datadoghq.com",
+	"us5.datadoghq.com",
+	"datadoghq.eu",
+	"ap1.datadoghq.com",
+	"ap2.datadoghq.com",
+	"ddog-gov.com",
+}
+
+// siteAliases maps the short names of the Datadog sites to their domain
+var siteAliases = map[string]string{
+	"u":       "datadoghq.com",
+	"us":      "datadoghq.com",
+	"us1":     "datadoghq.com",
+	"us3":     "us3.datadoghq.com",
+	"us5":     "us5.datadoghq.com",
+	"e":       "datadoghq.eu",
+	"eu":      "datadoghq.eu",
+	"eu1":     "datadoghq.eu",
+	"ap1":     "ap1.datadoghq.com",
+	"ap2":     "ap2.datadoghq.com",
+	"gov":     "ddog-gov.com",
+	"us1-fed": "ddog-gov.com",
+}
+
+// ResolveSite returns the domain of a Datadog site, given its domain or its short name
+func ResolveSite(site string) (string, error) {
+	site = strings.ToLower(strings.TrimSpace(site))
+	if site == "" {
+		return DefaultSite, nil
+	}
+	if domain, found := siteAliases[site]; found {
+		return domain, nil
+	}
+	for _, supported := range SupportedSites {
+		if site == supported {
+			return site, nil
+		}
+	}
+	return "", fmt.Errorf("invalid datadog site %s, must be one of %s", site, strings.Join(SupportedSites, ", "))
+}
+
+// AppURL returns the URL of the Datadog web application of a site.
+// The sites hosted on a subdomain serve the application on it, the other ones on the app subdomain.
+func AppURL(site string) string {
+	if strings.Count(site, ".") > 1 {
+		return "https://" + site
+	}
+	return "https://app." + site
+}
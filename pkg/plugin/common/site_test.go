@@ -0,0 +1,47 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveSite(t *testing.T) {
+	tests := []struct {
+		site    string
+		want    string
+		wantErr bool
+	}{
+		{site: "", want: "datadoghq.com"},
+		{site: "us", want: "datadoghq.com"},
+		{site: "EU", want: "datadoghq.eu"},
+		{site: "us3", want: "us3.datadoghq.com"},
+		{site: "us5.datadoghq.com", want: "us5.datadoghq.com"},
+		{site: "ap1.datadoghq.com", want: "ap1.datadoghq.com"},
+		{site: "gov", want: "ddog-gov.com"},
+		{site: "datadoghq.io", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.site, func(t *testing.T) {
+			got, err := ResolveSite(tt.site)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAppURL(t *testing.T) {
+	assert.Equal(t, "https://app.datadoghq.com", AppURL("datadoghq.com"))
+	assert.Equal(t, "https://app.datadoghq.eu", AppURL("datadoghq.eu"))
+	assert.Equal(t, "https://us3.datadoghq.com", AppURL("us3.datadoghq.com"))
+	assert.Equal(t, "https://app.ddog-gov.com", AppURL("ddog-gov.com"))
+}
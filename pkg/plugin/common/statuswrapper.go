@@ -14,6 +14,7 @@ import (
 type StatusWrapper interface {
 	GetObjectMeta() metav1.Object
 	GetAgentStatus() *v2alpha1.DaemonSetStatus
+	GetAgentListStatus() []*v2alpha1.DaemonSetStatus
 	GetClusterAgentStatus() *v2alpha1.DeploymentStatus
 	GetClusterChecksRunnerStatus() *v2alpha1.DeploymentStatus
 	GetStatusCondition() []metav1.Condition
@@ -38,6 +39,12 @@ func (sw v2StatusWrapper) GetAgentStatus() *v2alpha1.DaemonSetStatus {
 	}
 	return nil
 }
+func (sw v2StatusWrapper) GetAgentListStatus() []*v2alpha1.DaemonSetStatus {
+	if sw.dda != nil {
+		return sw.dda.Status.AgentList
+	}
+	return nil
+}
 func (sw v2StatusWrapper) GetClusterAgentStatus() *v2alpha1.DeploymentStatus {
 	if sw.dda != nil {
 		return sw.dda.Status.ClusterAgent
@@ -0,0 +1,238 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package prometheusoperator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+const (
+	// ServiceMonitorKind is the kind of the Prometheus Operator ServiceMonitor resource
+	ServiceMonitorKind = "ServiceMonitor"
+	// PodMonitorKind is the kind of the Prometheus Operator PodMonitor resource
+	PodMonitorKind = "PodMonitor"
+)
+
+// ServiceMonitorGVK is the GroupVersionKind of the Prometheus Operator ServiceMonitor.
+var ServiceMonitorGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    ServiceMonitorKind,
+}
+
+// ServiceMonitorListGVK is the GroupVersionKind of the Prometheus Operator ServiceMonitor list.
+var ServiceMonitorListGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "ServiceMonitorList",
+}
+
+// PodMonitorListGVK is the GroupVersionKind of the Prometheus Operator PodMonitor list.
+var PodMonitorListGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PodMonitorList",
+}
+
+// BearerTokenResolver returns the Secret key the Agent reads the bearer token
+// of an endpoint from. The reference is the bearer token Secret of the
+// endpoint, in the namespace of the ServiceMonitor. It returns nil if the
+// token cannot be resolved.
+type BearerTokenResolver func(namespace string, ref *corev1.SecretKeySelector) *corev1.SecretKeySelector
+
+// EmptyServiceMonitorUnstructured returns a new unstructured.Unstructured for ServiceMonitor
+func EmptyServiceMonitorUnstructured() *unstructured.Unstructured {
+	sm := &unstructured.Unstructured{}
+	sm.SetGroupVersionKind(ServiceMonitorGVK)
+
+	return sm
+}
+
+// ServiceMonitor is the subset of the Prometheus Operator ServiceMonitor used
+// to generate endpoints checks. It avoids depending on the Prometheus Operator API.
+type ServiceMonitor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceMonitorSpec `json:"spec"`
+}
+
+// ServiceMonitorSpec is the subset of the ServiceMonitor spec used to generate endpoints checks.
+type ServiceMonitorSpec struct {
+	Selector          metav1.LabelSelector `json:"selector"`
+	NamespaceSelector NamespaceSelector    `json:"namespaceSelector,omitempty"`
+	Endpoints         []Endpoint           `json:"endpoints,omitempty"`
+}
+
+// NamespaceSelector selects the namespaces of the services of a ServiceMonitor.
+type NamespaceSelector struct {
+	Any        bool     `json:"any,omitempty"`
+	MatchNames []string `json:"matchNames,omitempty"`
+}
+
+// Endpoint is a scrapeable endpoint of the services of a ServiceMonitor.
+type Endpoint struct {
+	Port              string                    `json:"port,omitempty"`
+	TargetPort        *intstr.IntOrString       `json:"targetPort,omitempty"`
+	Path              string                    `json:"path,omitempty"`
+	Scheme            string                    `json:"scheme,omitempty"`
+	ScrapeTimeout     string                    `json:"scrapeTimeout,omitempty"`
+	BearerTokenSecret *corev1.SecretKeySelector `json:"bearerTokenSecret,omitempty"`
+}
+
+// ServiceMonitorFromUnstructured converts an unstructured ServiceMonitor.
+func ServiceMonitorFromUnstructured(obj *unstructured.Unstructured) (*ServiceMonitor, error) {
+	sm := &ServiceMonitor{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), sm); err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
+
+// SelectServices returns the services selected by the ServiceMonitor, sorted by namespace and name.
+func SelectServices(sm *ServiceMonitor, services []corev1.Service) ([]corev1.Service, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&sm.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []corev1.Service
+	for _, svc := range services {
+		if !sm.selectsNamespace(svc.Namespace) {
+			continue
+		}
+		if !selector.Matches(labels.Set(svc.Labels)) {
+			continue
+		}
+		selected = append(selected, svc)
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Namespace != selected[j].Namespace {
+			return selected[i].Namespace < selected[j].Namespace
+		}
+		return selected[i].Name < selected[j].Name
+	})
+
+	return selected, nil
+}
+
+// ServiceNamespaces returns the namespaces of the services selected by the
+// ServiceMonitor. The empty namespace stands for all the namespaces.
+func (sm *ServiceMonitor) ServiceNamespaces() []string {
+	if sm.Spec.NamespaceSelector.Any {
+		return []string{metav1.NamespaceAll}
+	}
+	if len(sm.Spec.NamespaceSelector.MatchNames) == 0 {
+		return []string{sm.Namespace}
+	}
+	return sm.Spec.NamespaceSelector.MatchNames
+}
+
+func (sm *ServiceMonitor) selectsNamespace(namespace string) bool {
+	if sm.Spec.NamespaceSelector.Any {
+		return true
+	}
+	if len(sm.Spec.NamespaceSelector.MatchNames) == 0 {
+		return namespace == sm.Namespace
+	}
+	for _, name := range sm.Spec.NamespaceSelector.MatchNames {
+		if name == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildConfigs returns the endpoints checks configurations equivalent to the
+// ServiceMonitor for the given services. The bearer token Secrets are resolved
+// with resolveToken, the endpoints are scraped without token if it returns nil.
+func BuildConfigs(sm *ServiceMonitor, services []corev1.Service, resolveToken BearerTokenResolver) []v2alpha1.PrometheusScrapeConfig {
+	var configs []v2alpha1.PrometheusScrapeConfig
+	for _, svc := range services {
+		for _, endpoint := range sm.Spec.Endpoints {
+			port, found := endpointPort(&svc, &endpoint)
+			if !found {
+				continue
+			}
+
+			config := v2alpha1.PrometheusScrapeConfig{
+				Autodiscovery: &v2alpha1.PrometheusScrapeAutodiscovery{
+					KubernetesEndpoints: []v2alpha1.PrometheusScrapeService{
+						{Name: svc.Name, Namespace: svc.Namespace},
+					},
+				},
+				Endpoint: &v2alpha1.PrometheusScrapeEndpoint{
+					Port: port,
+				},
+			}
+			if endpoint.Path != "" {
+				config.Endpoint.Path = apiutils.NewStringPointer(endpoint.Path)
+			}
+			if endpoint.Scheme != "" {
+				config.Endpoint.Scheme = apiutils.NewStringPointer(endpoint.Scheme)
+			}
+			if endpoint.BearerTokenSecret != nil && endpoint.BearerTokenSecret.Name != "" {
+				config.BearerTokenSecret = resolveToken(sm.Namespace, endpoint.BearerTokenSecret)
+			}
+			if timeout, err := time.ParseDuration(endpoint.ScrapeTimeout); err == nil && timeout > 0 {
+				config.Timeout = apiutils.NewInt32Pointer(int32(math.Ceil(timeout.Seconds())))
+			}
+
+			configs = append(configs, config)
+		}
+	}
+
+	return configs
+}
+
+// BearerTokenKey returns the key of a bearer token copied from another
+// namespace into a Secret of the DatadogAgent namespace. Namespaces and Secret
+// names cannot contain underscores, so the keys of different tokens are unique.
+func BearerTokenKey(namespace string, ref *corev1.SecretKeySelector) string {
+	return fmt.Sprintf("%s_%s_%s", namespace, ref.Name, ref.Key)
+}
+
+// endpointPort returns the port of the service endpoints matching the
+// ServiceMonitor endpoint. Endpoints checks resolve ports by the name of the
+// service port, so named target ports are translated to service port names.
+func endpointPort(svc *corev1.Service, endpoint *Endpoint) (intstr.IntOrString, bool) {
+	if endpoint.Port != "" {
+		for _, port := range svc.Spec.Ports {
+			if port.Name == endpoint.Port {
+				return intstr.FromString(port.Name), true
+			}
+		}
+		return intstr.IntOrString{}, false
+	}
+
+	if endpoint.TargetPort == nil {
+		return intstr.IntOrString{}, false
+	}
+	if endpoint.TargetPort.Type == intstr.Int {
+		return *endpoint.TargetPort, true
+	}
+	for _, port := range svc.Spec.Ports {
+		if port.TargetPort.Type == intstr.String && port.TargetPort.StrVal == endpoint.TargetPort.StrVal && port.Name != "" {
+			return intstr.FromString(port.Name), true
+		}
+	}
+	return intstr.IntOrString{}, false
+}
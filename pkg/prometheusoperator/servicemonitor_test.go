@@ -0,0 +1,191 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package prometheusoperator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+func testService(namespace, name string, labels map[string]string, ports ...corev1.ServicePort) corev1.Service {
+	return corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Spec:       corev1.ServiceSpec{Ports: ports},
+	}
+}
+
+func TestServiceMonitorFromUnstructured(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "ServiceMonitor",
+		"metadata":   map[string]interface{}{"name": "foo", "namespace": "bar"},
+		"spec": map[string]interface{}{
+			"selector":          map[string]interface{}{"matchLabels": map[string]interface{}{"app": "foo"}},
+			"namespaceSelector": map[string]interface{}{"any": true},
+			"endpoints": []interface{}{
+				map[string]interface{}{"port": "metrics", "path": "/prom", "interval": "30s"},
+			},
+		},
+	}}
+
+	sm, err := ServiceMonitorFromUnstructured(obj)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", sm.Name)
+	assert.Equal(t, map[string]string{"app": "foo"}, sm.Spec.Selector.MatchLabels)
+	assert.True(t, sm.Spec.NamespaceSelector.Any)
+	assert.Equal(t, []Endpoint{{Port: "metrics", Path: "/prom"}}, sm.Spec.Endpoints)
+}
+
+func TestSelectServices(t *testing.T) {
+	services := []corev1.Service{
+		testService("ns2", "b", map[string]string{"app": "foo"}),
+		testService("ns1", "a", map[string]string{"app": "foo"}),
+		testService("ns1", "c", map[string]string{"app": "bar"}),
+		testService("ns3", "d", map[string]string{"app": "foo"}),
+	}
+
+	tests := []struct {
+		name              string
+		namespaceSelector NamespaceSelector
+		want              []string
+	}{
+		{
+			name: "own namespace by default",
+			want: []string{"ns1/a"},
+		},
+		{
+			name:              "any namespace",
+			namespaceSelector: NamespaceSelector{Any: true},
+			want:              []string{"ns1/a", "ns2/b", "ns3/d"},
+		},
+		{
+			name:              "match names",
+			namespaceSelector: NamespaceSelector{MatchNames: []string{"ns2", "ns3"}},
+			want:              []string{"ns2/b", "ns3/d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := &ServiceMonitor{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "sm"},
+				Spec: ServiceMonitorSpec{
+					Selector:          metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+					NamespaceSelector: tt.namespaceSelector,
+				},
+			}
+			selected, err := SelectServices(sm, services)
+			require.NoError(t, err)
+
+			var got []string
+			for _, svc := range selected {
+				got = append(got, svc.Namespace+"/"+svc.Name)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestServiceNamespaces(t *testing.T) {
+	tests := []struct {
+		name              string
+		namespaceSelector NamespaceSelector
+		want              []string
+	}{
+		{
+			name: "own namespace by default",
+			want: []string{"ns1"},
+		},
+		{
+			name:              "any namespace",
+			namespaceSelector: NamespaceSelector{Any: true, MatchNames: []string{"ns2"}},
+			want:              []string{""},
+		},
+		{
+			name:              "match names",
+			namespaceSelector: NamespaceSelector{MatchNames: []string{"ns2", "ns3"}},
+			want:              []string{"ns2", "ns3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := &ServiceMonitor{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "sm"},
+				Spec:       ServiceMonitorSpec{NamespaceSelector: tt.namespaceSelector},
+			}
+			assert.Equal(t, tt.want, sm.ServiceNamespaces())
+		})
+	}
+}
+
+func TestBuildConfigs(t *testing.T) {
+	svc := testService("ns1", "a", nil,
+		corev1.ServicePort{Name: "metrics", Port: 80, TargetPort: intstr.FromString("http-metrics")},
+		corev1.ServicePort{Name: "web", Port: 8080, TargetPort: intstr.FromInt(8080)},
+	)
+	targetPortName := intstr.FromString("http-metrics")
+	targetPortNumber := intstr.FromInt(9090)
+	secret := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "token"}, Key: "t"}
+
+	sm := &ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "datadog", Name: "sm"},
+		Spec: ServiceMonitorSpec{
+			Endpoints: []Endpoint{
+				{Port: "web", Path: "/prom", Scheme: "https", ScrapeTimeout: "1500ms", BearerTokenSecret: secret},
+				{TargetPort: &targetPortName},
+				{TargetPort: &targetPortNumber},
+				{Port: "unknown"},
+			},
+		},
+	}
+	services := []v2alpha1.PrometheusScrapeService{{Name: "a", Namespace: "ns1"}}
+	autodiscovery := &v2alpha1.PrometheusScrapeAutodiscovery{KubernetesEndpoints: services}
+
+	want := []v2alpha1.PrometheusScrapeConfig{
+		{
+			Autodiscovery: autodiscovery,
+			Endpoint: &v2alpha1.PrometheusScrapeEndpoint{
+				Port:   intstr.FromString("web"),
+				Path:   apiutils.NewStringPointer("/prom"),
+				Scheme: apiutils.NewStringPointer("https"),
+			},
+			BearerTokenSecret: secret,
+			Timeout:           apiutils.NewInt32Pointer(2),
+		},
+		{
+			Autodiscovery: autodiscovery,
+			Endpoint:      &v2alpha1.PrometheusScrapeEndpoint{Port: intstr.FromString("metrics")},
+		},
+		{
+			Autodiscovery: autodiscovery,
+			Endpoint:      &v2alpha1.PrometheusScrapeEndpoint{Port: intstr.FromInt(9090)},
+		},
+	}
+	sameRef := func(namespace string, ref *corev1.SecretKeySelector) *corev1.SecretKeySelector {
+		assert.Equal(t, "datadog", namespace)
+		return ref.DeepCopy()
+	}
+	assert.Equal(t, want, BuildConfigs(sm, []corev1.Service{svc}, sameRef))
+
+	// Endpoints are scraped without token if it cannot be resolved
+	noRef := func(string, *corev1.SecretKeySelector) *corev1.SecretKeySelector { return nil }
+	configs := BuildConfigs(sm, []corev1.Service{svc}, noRef)
+	require.Len(t, configs, 3)
+	assert.Nil(t, configs[0].BearerTokenSecret)
+}
+
+func TestBearerTokenKey(t *testing.T) {
+	ref := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "token.v1"}, Key: "bearer_token"}
+	assert.Equal(t, "monitoring_token.v1_bearer_token", BearerTokenKey("monitoring", ref))
+}
@@ -0,0 +1,150 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package remoteconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+const changePathSeparator = "."
+
+// FeatureChanges returns the feature fields the received configuration sets differently than the current one,
+// with their value in the received configuration. Fields are identified by their JSON path in the DatadogAgent
+// features, lists are compared as a whole.
+func FeatureChanges(current, received *v2alpha1.RemoteConfigConfiguration) ([]v2alpha1.RemoteConfigChange, error) {
+	currentFields, err := featureFields(current)
+	if err != nil {
+		return nil, err
+	}
+	receivedFields, err := featureFields(received)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []v2alpha1.RemoteConfigChange
+	if err := diffFields(nil, currentFields, receivedFields, &changes); err != nil {
+		return nil, err
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// ApplyChanges returns a copy of the configuration with the changes applied.
+func ApplyChanges(config *v2alpha1.RemoteConfigConfiguration, changes []v2alpha1.RemoteConfigChange) (*v2alpha1.RemoteConfigConfiguration, error) {
+	fields, err := featureFields(config)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range changes {
+		var value interface{}
+		if err := json.Unmarshal([]byte(change.Value), &value); err != nil {
+			return nil, fmt.Errorf("invalid value of %s: %w", change.Path, err)
+		}
+		setField(fields, strings.Split(change.Path, changePathSeparator), value)
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	features := &v2alpha1.DatadogFeatures{}
+	if err := json.Unmarshal(data, features); err != nil {
+		return nil, err
+	}
+	return &v2alpha1.RemoteConfigConfiguration{Features: features}, nil
+}
+
+// featureFields returns the features of the configuration as a JSON object.
+func featureFields(config *v2alpha1.RemoteConfigConfiguration) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if config == nil || config.Features == nil {
+		return fields, nil
+	}
+	data, err := json.Marshal(config.Features)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func diffFields(path []string, current, received map[string]interface{}, changes *[]v2alpha1.RemoteConfigChange) error {
+	keys := map[string]struct{}{}
+	for key := range current {
+		keys[key] = struct{}{}
+	}
+	for key := range received {
+		keys[key] = struct{}{}
+	}
+
+	for key := range keys {
+		fieldPath := append(append([]string{}, path...), key)
+		currentValue, receivedValue := current[key], received[key]
+		currentObject, currentIsObject := currentValue.(map[string]interface{})
+		receivedObject, receivedIsObject := receivedValue.(map[string]interface{})
+		if currentIsObject || receivedIsObject {
+			// an object removed or added is compared field by field with an empty one
+			if currentObject == nil && currentValue == nil {
+				currentObject, currentIsObject = map[string]interface{}{}, true
+			}
+			if receivedObject == nil && receivedValue == nil {
+				receivedObject, receivedIsObject = map[string]interface{}{}, true
+			}
+		}
+		if currentIsObject && receivedIsObject {
+			if err := diffFields(fieldPath, currentObject, receivedObject, changes); err != nil {
+				return err
+			}
+			continue
+		}
+		if reflect.DeepEqual(currentValue, receivedValue) {
+			continue
+		}
+		value, err := json.Marshal(receivedValue)
+		if err != nil {
+			return err
+		}
+		*changes = append(*changes, v2alpha1.RemoteConfigChange{
+			Path:  strings.Join(fieldPath, changePathSeparator),
+			Value: string(value),
+		})
+	}
+	return nil
+}
+
+// setField sets the value at the path in the fields, a nil value removes the field and the objects left empty.
+func setField(fields map[string]interface{}, path []string, value interface{}) {
+	key := path[0]
+	if len(path) == 1 {
+		if value == nil {
+			delete(fields, key)
+			return
+		}
+		fields[key] = value
+		return
+	}
+	child, ok := fields[key].(map[string]interface{})
+	if !ok {
+		if value == nil {
+			return
+		}
+		child = map[string]interface{}{}
+		fields[key] = child
+	}
+	setField(child, path[1:], value)
+	if len(child) == 0 {
+		delete(fields, key)
+	}
+}
@@ -0,0 +1,115 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package remoteconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+func TestFeatureChanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     *v2alpha1.RemoteConfigConfiguration
+		received    *v2alpha1.RemoteConfigConfiguration
+		wantChanges []v2alpha1.RemoteConfigChange
+	}{
+		{
+			name:     "nothing applied",
+			received: &v2alpha1.RemoteConfigConfiguration{},
+		},
+		{
+			name: "same configuration",
+			current: &v2alpha1.RemoteConfigConfiguration{Features: &v2alpha1.DatadogFeatures{
+				CWS: &v2alpha1.CWSFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+			}},
+			received: &v2alpha1.RemoteConfigConfiguration{Features: &v2alpha1.DatadogFeatures{
+				CWS: &v2alpha1.CWSFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+			}},
+		},
+		{
+			name: "fields set, changed and removed",
+			current: &v2alpha1.RemoteConfigConfiguration{Features: &v2alpha1.DatadogFeatures{
+				CWS: &v2alpha1.CWSFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+				SBOM: &v2alpha1.SBOMFeatureConfig{
+					Enabled:        apiutils.NewBoolPointer(false),
+					ContainerImage: &v2alpha1.SBOMContainerImageConfig{Analyzers: []string{"os"}},
+				},
+			}},
+			received: &v2alpha1.RemoteConfigConfiguration{Features: &v2alpha1.DatadogFeatures{
+				SBOM: &v2alpha1.SBOMFeatureConfig{
+					Enabled: apiutils.NewBoolPointer(true),
+					ContainerImage: &v2alpha1.SBOMContainerImageConfig{
+						Enabled:   apiutils.NewBoolPointer(true),
+						Analyzers: []string{"os", "languages"},
+					},
+				},
+			}},
+			wantChanges: []v2alpha1.RemoteConfigChange{
+				{Path: "cws.enabled", Value: "null"},
+				{Path: "sbom.containerImage.analyzers", Value: `["os","languages"]`},
+				{Path: "sbom.containerImage.enabled", Value: "true"},
+				{Path: "sbom.enabled", Value: "true"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := FeatureChanges(tt.current, tt.received)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanges, changes)
+
+			applied, err := ApplyChanges(tt.current, changes)
+			require.NoError(t, err)
+			assert.Empty(t, ChangedFeatures(applied, tt.received))
+		})
+	}
+}
+
+func TestApplyChanges(t *testing.T) {
+	current := &v2alpha1.RemoteConfigConfiguration{Features: &v2alpha1.DatadogFeatures{
+		CWS: &v2alpha1.CWSFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+	}}
+
+	applied, err := ApplyChanges(current, []v2alpha1.RemoteConfigChange{
+		{Path: "cws.enabled", Value: "null"},
+		{Path: "sbom.host.enabled", Value: "true"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &v2alpha1.RemoteConfigConfiguration{Features: &v2alpha1.DatadogFeatures{
+		SBOM: &v2alpha1.SBOMFeatureConfig{Host: &v2alpha1.SBOMHostConfig{Enabled: apiutils.NewBoolPointer(true)}},
+	}}, applied)
+	assert.True(t, *current.Features.CWS.Enabled, "the configuration must not be modified")
+
+	_, err = ApplyChanges(current, []v2alpha1.RemoteConfigChange{{Path: "cws.enabled", Value: "yes"}})
+	assert.Error(t, err)
+}
+
+func Test_baseConfiguration(t *testing.T) {
+	status := &v2alpha1.DatadogAgentStatus{
+		RemoteConfigConfiguration: &v2alpha1.RemoteConfigConfiguration{Features: &v2alpha1.DatadogFeatures{
+			CWS: &v2alpha1.CWSFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+		}},
+		RemoteConfigProposal: &v2alpha1.RemoteConfigProposal{
+			ConfigID: "id",
+			Changes:  []v2alpha1.RemoteConfigChange{{Path: "sbom.enabled", Value: "true"}},
+		},
+	}
+
+	assert.Equal(t, &v2alpha1.RemoteConfigConfiguration{Features: &v2alpha1.DatadogFeatures{
+		CWS:  &v2alpha1.CWSFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+		SBOM: &v2alpha1.SBOMFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+	}}, baseConfiguration(status))
+
+	status.RemoteConfigProposal = nil
+	assert.Equal(t, status.RemoteConfigConfiguration, baseConfiguration(status))
+	assert.Equal(t, &v2alpha1.RemoteConfigConfiguration{}, baseConfiguration(&v2alpha1.DatadogAgentStatus{}))
+}
@@ -0,0 +1,146 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package remoteconfig
+
+import (
+	"reflect"
+	"strings"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+var (
+	apiPkgPaths = map[string]bool{
+		reflect.TypeOf(v2alpha1.DatadogFeatures{}).PkgPath():   true,
+		reflect.TypeOf(apicommon.USMFeatureConfig{}).PkgPath(): true,
+	}
+	boolPtr = reflect.TypeOf((*bool)(nil))
+)
+
+// MergeFeatures merges the features configured in src into dst. Pointers to API structs are merged
+// field by field. The booleans and the enums set in src overwrite the ones of dst, the string lists
+// set in src are added to the ones of dst. Any other field is not configured remotely.
+// Enums are the string types of the API, validated by a kubebuilder Enum marker.
+func MergeFeatures(dst, src *v2alpha1.DatadogFeatures) {
+	if src == nil {
+		return
+	}
+	mergeStruct(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src.DeepCopy()).Elem())
+}
+
+// MergeConfiguration merges the features of a Remote Configuration configuration into the DatadogAgent spec.
+// The Remote Configuration policy can't be configured remotely.
+func MergeConfiguration(spec *v2alpha1.DatadogAgentSpec, config *v2alpha1.RemoteConfigConfiguration) {
+	if config == nil || config.Features == nil {
+		return
+	}
+	features := config.Features.DeepCopy()
+	features.RemoteConfiguration = nil
+
+	if spec.Features == nil {
+		spec.Features = &v2alpha1.DatadogFeatures{}
+	}
+	MergeFeatures(spec.Features, features)
+}
+
+// ValidateConfiguration returns an error if the DatadogAgent spec is not valid once the configuration is merged into it.
+func ValidateConfiguration(dda *v2alpha1.DatadogAgent, config *v2alpha1.RemoteConfigConfiguration) error {
+	spec := dda.Spec.DeepCopy()
+	MergeConfiguration(spec, config)
+	return v2alpha1.IsValidDatadogAgent(spec)
+}
+
+func mergeStruct(dst, src reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		srcField, dstField := src.Field(i), dst.Field(i)
+		if !dstField.CanSet() || srcField.IsZero() {
+			continue
+		}
+		switch t := srcField.Type(); {
+		case isAPIStruct(t):
+			mergeStruct(dstField, srcField)
+		case t.Kind() == reflect.Ptr && isAPIStruct(t.Elem()):
+			if dstField.IsNil() {
+				dstField.Set(reflect.New(t.Elem()))
+			}
+			mergeStruct(dstField.Elem(), srcField.Elem())
+		case t == boolPtr, isAPIEnum(t), t.Kind() == reflect.Ptr && isAPIEnum(t.Elem()):
+			dstField.Set(srcField)
+		case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String:
+			dstField.Set(mergeStringSlice(dstField, srcField))
+		}
+	}
+}
+
+// mergeStringSlice returns the items of dst followed by the items of src that are not in dst.
+func mergeStringSlice(dst, src reflect.Value) reflect.Value {
+	seen := make(map[string]struct{}, dst.Len()+src.Len())
+	merged := reflect.MakeSlice(dst.Type(), 0, dst.Len()+src.Len())
+	for _, items := range []reflect.Value{dst, src} {
+		for i := 0; i < items.Len(); i++ {
+			item := items.Index(i)
+			if _, found := seen[item.String()]; found {
+				continue
+			}
+			seen[item.String()] = struct{}{}
+			merged = reflect.Append(merged, item)
+		}
+	}
+	return merged
+}
+
+func isAPIStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && apiPkgPaths[t.PkgPath()]
+}
+
+// isAPIEnum returns true for the string types defined by the API. They hold the values validated by a
+// kubebuilder Enum marker, except RemoteConfigFeature which is only used by the Remote Configuration policy.
+func isAPIEnum(t reflect.Type) bool {
+	return t.Kind() == reflect.String && apiPkgPaths[t.PkgPath()]
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name
+}
+
+// remoteConfigFeature is a DatadogFeatures field that can be configured by Remote Configuration.
+type remoteConfigFeature struct {
+	name  v2alpha1.RemoteConfigFeature
+	index int
+}
+
+func (f remoteConfigFeature) value(features *v2alpha1.DatadogFeatures) reflect.Value {
+	return reflect.ValueOf(features).Elem().Field(f.index)
+}
+
+func (f remoteConfigFeature) get(features *v2alpha1.DatadogFeatures) any {
+	return f.value(features).Interface()
+}
+
+func (f remoteConfigFeature) isNil(features *v2alpha1.DatadogFeatures) bool {
+	return f.value(features).IsZero()
+}
+
+func (f remoteConfigFeature) clear(features *v2alpha1.DatadogFeatures) {
+	v := f.value(features)
+	v.Set(reflect.Zero(v.Type()))
+}
+
+// remoteConfigFeatures lists the DatadogFeatures fields, named after their JSON name.
+var remoteConfigFeatures = func() []remoteConfigFeature {
+	t := reflect.TypeOf(v2alpha1.DatadogFeatures{})
+	features := make([]remoteConfigFeature, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		if name == "" || name == "-" {
+			continue
+		}
+		features = append(features, remoteConfigFeature{name: v2alpha1.RemoteConfigFeature(name), index: i})
+	}
+	return features
+}()
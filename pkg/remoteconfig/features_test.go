@@ -0,0 +1,174 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package remoteconfig
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-agent/pkg/remoteconfig/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+func TestMergeFeatures(t *testing.T) {
+	dst := &v2alpha1.DatadogFeatures{
+		APM: &v2alpha1.APMFeatureConfig{
+			Enabled: apiutils.NewBoolPointer(true),
+			SingleStepInstrumentation: &v2alpha1.SingleStepInstrumentation{
+				Enabled:           apiutils.NewBoolPointer(false),
+				EnabledNamespaces: []string{"foo"},
+			},
+		},
+		NPM: &apicommon.NPMFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+	}
+	src := &v2alpha1.DatadogFeatures{
+		APM: &v2alpha1.APMFeatureConfig{
+			SingleStepInstrumentation: &v2alpha1.SingleStepInstrumentation{
+				Enabled: apiutils.NewBoolPointer(true),
+			},
+		},
+		NPM: &apicommon.NPMFeatureConfig{Enabled: apiutils.NewBoolPointer(false)},
+		LogCollection: &apicommon.LogCollectionFeatureConfig{
+			Enabled:             apiutils.NewBoolPointer(true),
+			ContainerCollectAll: apiutils.NewBoolPointer(true),
+		},
+		AdmissionController: &v2alpha1.AdmissionControllerFeatureConfig{
+			AgentCommunicationMode: apiutils.NewPointer(v2alpha1.AgentCommunicationModeSocket),
+		},
+	}
+
+	MergeFeatures(dst, src)
+
+	assert.Equal(t, &v2alpha1.DatadogFeatures{
+		APM: &v2alpha1.APMFeatureConfig{
+			Enabled: apiutils.NewBoolPointer(true),
+			SingleStepInstrumentation: &v2alpha1.SingleStepInstrumentation{
+				Enabled:           apiutils.NewBoolPointer(true),
+				EnabledNamespaces: []string{"foo"},
+			},
+		},
+		NPM:                 &apicommon.NPMFeatureConfig{Enabled: apiutils.NewBoolPointer(false)},
+		LogCollection:       src.LogCollection,
+		AdmissionController: src.AdmissionController,
+	}, dst)
+
+	// src is not shared with dst
+	src.LogCollection.ContainerCollectAll = apiutils.NewBoolPointer(false)
+	assert.True(t, *dst.LogCollection.ContainerCollectAll)
+}
+
+func TestMergeFeaturesIgnoresOtherFields(t *testing.T) {
+	dst := &v2alpha1.DatadogFeatures{
+		PrometheusScrape: &v2alpha1.PrometheusScrapeFeatureConfig{
+			PrometheusOperator: &v2alpha1.PrometheusOperatorConfig{
+				ServiceMonitorSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "foo"}},
+			},
+		},
+	}
+	want := dst.DeepCopy()
+	want.LogCollection = &apicommon.LogCollectionFeatureConfig{}
+	want.AdmissionController = &v2alpha1.AdmissionControllerFeatureConfig{}
+
+	MergeFeatures(dst, &v2alpha1.DatadogFeatures{
+		PrometheusScrape: &v2alpha1.PrometheusScrapeFeatureConfig{
+			PrometheusOperator: &v2alpha1.PrometheusOperatorConfig{
+				ServiceMonitorSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "bar"}},
+			},
+		},
+		LogCollection:       &apicommon.LogCollectionFeatureConfig{OpenFilesLimit: apiutils.NewInt32Pointer(100)},
+		AdmissionController: &v2alpha1.AdmissionControllerFeatureConfig{ServiceName: apiutils.NewStringPointer("foo")},
+	})
+
+	assert.Equal(t, want, dst)
+}
+
+func TestMergeFeaturesSSINamespaces(t *testing.T) {
+	tests := []struct {
+		name           string
+		dst            []string
+		src            []string
+		wantNamespaces []string
+	}{
+		{
+			name:           "namespaces set remotely",
+			src:            []string{"foo", "bar"},
+			wantNamespaces: []string{"foo", "bar"},
+		},
+		{
+			name:           "namespaces added to the spec ones",
+			dst:            []string{"foo"},
+			src:            []string{"bar", "foo"},
+			wantNamespaces: []string{"foo", "bar"},
+		},
+		{
+			name:           "no namespaces set remotely",
+			dst:            []string{"foo"},
+			wantNamespaces: []string{"foo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := &v2alpha1.DatadogFeatures{
+				APM: &v2alpha1.APMFeatureConfig{
+					SingleStepInstrumentation: &v2alpha1.SingleStepInstrumentation{EnabledNamespaces: tt.dst},
+				},
+			}
+			src := &v2alpha1.DatadogFeatures{
+				APM: &v2alpha1.APMFeatureConfig{
+					SingleStepInstrumentation: &v2alpha1.SingleStepInstrumentation{
+						Enabled:           apiutils.NewBoolPointer(true),
+						EnabledNamespaces: tt.src,
+					},
+				},
+			}
+
+			MergeFeatures(dst, src)
+
+			assert.True(t, *dst.APM.SingleStepInstrumentation.Enabled)
+			assert.Equal(t, tt.wantNamespaces, dst.APM.SingleStepInstrumentation.EnabledNamespaces)
+			if len(tt.src) > 0 {
+				// src is not shared with dst
+				src.APM.SingleStepInstrumentation.EnabledNamespaces[0] = "baz"
+				assert.NotContains(t, dst.APM.SingleStepInstrumentation.EnabledNamespaces, "baz")
+			}
+		})
+	}
+}
+
+func Test_parseReceivedUpdates(t *testing.T) {
+	r := NewRemoteConfigUpdater(nil, nil, logf.Log)
+	updates := map[string]state.RawConfig{
+		"order": {
+			Metadata: state.Metadata{ID: "configuration_order"},
+			Config:   []byte(`{"order": ["first", "second"]}`),
+		},
+		"first": {
+			Metadata: state.Metadata{ID: "first"},
+			Config:   []byte(`{"config": {"sbom": {"enabled": true}}, "features": {"npm": {"enabled": true}, "logCollection": {"containerCollectAll": true}}}`),
+		},
+		"second": {
+			Metadata: state.Metadata{ID: "second"},
+			Config:   []byte(`{"system_probe": {"runtime_security_config": {"enabled": true}}, "features": {"npm": {"enabled": false}, "sbom": {"enabled": false}}}`),
+		},
+	}
+
+	cfg, err := r.parseReceivedUpdates(updates, func(string, state.ApplyStatus) {})
+	require.NoError(t, err)
+
+	assert.Equal(t, "first|second", cfg.ID)
+	assert.Equal(t, &v2alpha1.DatadogFeatures{
+		SBOM:          &v2alpha1.SBOMFeatureConfig{Enabled: apiutils.NewBoolPointer(false)},
+		CWS:           &v2alpha1.CWSFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+		NPM:           &apicommon.NPMFeatureConfig{Enabled: apiutils.NewBoolPointer(false)},
+		LogCollection: &apicommon.LogCollectionFeatureConfig{ContainerCollectAll: apiutils.NewBoolPointer(true)},
+	}, cfg.Features)
+}
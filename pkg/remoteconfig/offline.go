@@ -0,0 +1,261 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package remoteconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/remoteconfig/state"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OfflineBundleFileName is the default name of the bundle file in a directory, and of the bundle key in a ConfigMap
+	OfflineBundleFileName = "bundle.json"
+
+	offlineHTTPTimeout = 10 * time.Second
+)
+
+// OfflineBundle is a signed Remote Configuration bundle: the TUF repository update the Datadog
+// backend would send to the Operator, serialized in JSON.
+type OfflineBundle struct {
+	// Roots contains, in order, the TUF roots to update to
+	Roots [][]byte `json:"roots,omitempty"`
+	// Targets is the signed TUF targets file
+	Targets []byte `json:"targets"`
+	// TargetFiles stores the raw config files by their TUF path
+	TargetFiles map[string][]byte `json:"target_files,omitempty"`
+	// ClientConfigs lists the TUF paths of the config files to apply. When not set, all the target files are applied.
+	ClientConfigs []string `json:"client_configs,omitempty"`
+}
+
+func (b OfflineBundle) update() state.Update {
+	clientConfigs := b.ClientConfigs
+	if clientConfigs == nil {
+		for path := range b.TargetFiles {
+			clientConfigs = append(clientConfigs, path)
+		}
+	}
+	return state.Update{
+		TUFRoots:      b.Roots,
+		TUFTargets:    b.Targets,
+		TargetFiles:   b.TargetFiles,
+		ClientConfigs: clientConfigs,
+	}
+}
+
+// bundleReader reads an offline bundle from its source.
+type bundleReader interface {
+	read(ctx context.Context) ([]byte, error)
+}
+
+// newBundleReader returns the bundle reader of an offline source. Supported sources are:
+// `configmap://<namespace>/<name>[?key=<key>]`, `file://<directory or file>` and `http(s)://<endpoint>`.
+func newBundleReader(source string, client kubeclient.Reader) (bundleReader, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Remote Configuration offline source %q: %w", source, err)
+	}
+
+	switch u.Scheme {
+	case "configmap":
+		name := strings.Trim(u.Path, "/")
+		if u.Host == "" || name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid Remote Configuration offline source %q: expected configmap://<namespace>/<name>", source)
+		}
+		key := u.Query().Get("key")
+		if key == "" {
+			key = OfflineBundleFileName
+		}
+		return &configMapBundleReader{client: client, key: key, name: types.NamespacedName{Namespace: u.Host, Name: name}}, nil
+	case "file":
+		if u.Path == "" {
+			return nil, fmt.Errorf("invalid Remote Configuration offline source %q: expected file://<path>", source)
+		}
+		return &fileBundleReader{path: u.Path}, nil
+	case "http", "https":
+		return &httpBundleReader{url: u.String(), client: &http.Client{Timeout: offlineHTTPTimeout}}, nil
+	default:
+		return nil, fmt.Errorf("invalid Remote Configuration offline source %q: unsupported scheme %q", source, u.Scheme)
+	}
+}
+
+type configMapBundleReader struct {
+	client kubeclient.Reader
+	name   types.NamespacedName
+	key    string
+}
+
+func (c *configMapBundleReader) read(ctx context.Context) ([]byte, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.client.Get(ctx, c.name, cm); err != nil {
+		return nil, fmt.Errorf("unable to get ConfigMap %s: %w", c.name, err)
+	}
+	if data, found := cm.Data[c.key]; found {
+		return []byte(data), nil
+	}
+	if data, found := cm.BinaryData[c.key]; found {
+		return data, nil
+	}
+	return nil, fmt.Errorf("key %q not found in ConfigMap %s", c.key, c.name)
+}
+
+type fileBundleReader struct {
+	path string
+}
+
+func (f *fileBundleReader) read(_ context.Context) ([]byte, error) {
+	path := f.path
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, OfflineBundleFileName)
+	}
+	return os.ReadFile(path)
+}
+
+type httpBundleReader struct {
+	url    string
+	client *http.Client
+}
+
+func (h *httpBundleReader) read(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, h.url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// offlineClient is a Remote Configuration client reading signed bundles from an offline source.
+// The bundles are validated with a TUF repository, and the configurations are passed to the
+// product callbacks the same way the Remote Configuration client does.
+type offlineClient struct {
+	reader        bundleReader
+	repository    *state.Repository
+	subscriptions map[string]func(map[string]state.RawConfig, func(string, state.ApplyStatus))
+	lastBundle    []byte
+	logger        logr.Logger
+	cancel        context.CancelFunc
+}
+
+func newOfflineClient(reader bundleReader, repository *state.Repository, logger logr.Logger) *offlineClient {
+	return &offlineClient{
+		reader:        reader,
+		repository:    repository,
+		subscriptions: map[string]func(map[string]state.RawConfig, func(string, state.ApplyStatus)){},
+		logger:        logger,
+	}
+}
+
+// Subscribe registers the callback called with the configurations of the product.
+func (c *offlineClient) Subscribe(product string, fn func(map[string]state.RawConfig, func(string, state.ApplyStatus))) {
+	c.subscriptions[product] = fn
+}
+
+// Start polls the offline source until the client is closed.
+func (c *offlineClient) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			if err := c.poll(ctx); err != nil {
+				c.logger.Error(err, "Failed to read Remote Configuration offline bundle")
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Close stops polling the offline source.
+func (c *offlineClient) Close() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+// poll reads the bundle and, when it changed, validates it and calls the callbacks of the updated products.
+func (c *offlineClient) poll(ctx context.Context) error {
+	data, err := c.reader.read(ctx)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(data, c.lastBundle) {
+		return nil
+	}
+
+	var bundle OfflineBundle
+	if err = json.Unmarshal(data, &bundle); err != nil {
+		return fmt.Errorf("unable to parse bundle: %w", err)
+	}
+	products, err := c.repository.Update(bundle.update())
+	if err != nil {
+		return fmt.Errorf("invalid bundle: %w", err)
+	}
+	c.lastBundle = data
+
+	for _, product := range products {
+		if fn, found := c.subscriptions[product]; found {
+			fn(c.repository.GetConfigs(product), c.repository.UpdateApplyStatus)
+		}
+	}
+	return nil
+}
+
+// SetupOffline starts reading the Remote Configuration from an offline source instead of the Datadog backend.
+// The bundles must be signed with the TUF config root set in DD_REMOTE_CONFIGURATION_CONFIG_ROOT.
+func (r *RemoteConfigUpdater) SetupOffline(source string) error {
+	configRoot := os.Getenv("DD_REMOTE_CONFIGURATION_CONFIG_ROOT")
+	if configRoot == "" {
+		return errors.New("DD_REMOTE_CONFIGURATION_CONFIG_ROOT must be set to validate the Remote Configuration offline bundles")
+	}
+	repository, err := state.NewRepository([]byte(configRoot))
+	if err != nil {
+		return fmt.Errorf("invalid Remote Configuration config root: %w", err)
+	}
+
+	reader, err := newBundleReader(source, r.kubeClient)
+	if err != nil {
+		return err
+	}
+
+	if r.offlineClient == nil {
+		r.logger.Info("Starting Remote Configuration offline client", "source", source)
+		r.offlineClient = newOfflineClient(reader, repository, r.logger)
+		r.offlineClient.Subscribe(state.ProductAgentConfig, r.agentConfigUpdateCallback)
+		r.offlineClient.Subscribe(state.ProductOrchestratorK8sCRDs, r.crdConfigUpdateCallback)
+		r.offlineClient.Start()
+	}
+
+	return nil
+}
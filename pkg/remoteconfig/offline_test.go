@@ -0,0 +1,238 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package remoteconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/remoteconfig/state"
+	"github.com/DataDog/go-tuf/data"
+	"github.com/DataDog/go-tuf/pkg/keys"
+	"github.com/DataDog/go-tuf/sign"
+	"github.com/DataDog/go-tuf/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// testSigner signs TUF roots and targets for the offline bundles of the tests
+type testSigner struct {
+	key  keys.Signer
+	root []byte
+}
+
+func newTestSigner(t *testing.T) testSigner {
+	key, err := keys.GenerateEd25519Key()
+	require.NoError(t, err)
+
+	root := data.NewRoot()
+	root.Version = 1
+	root.Expires = time.Now().Add(24 * time.Hour)
+	root.AddKey(key.PublicData())
+	role := &data.Role{KeyIDs: key.PublicData().IDs(), Threshold: 1}
+	for _, name := range []string{"root", "targets", "timestamp", "snapshot"} {
+		root.Roles[name] = role
+	}
+	signedRoot, err := sign.Marshal(&root, key)
+	require.NoError(t, err)
+	rootBytes, err := json.Marshal(signedRoot)
+	require.NoError(t, err)
+
+	return testSigner{key: key, root: rootBytes}
+}
+
+func (s testSigner) bundle(t *testing.T, version int64, files map[string][]byte) []byte {
+	targets := data.NewTargets()
+	targets.Version = version
+	targets.Expires = time.Now().Add(24 * time.Hour)
+	custom := json.RawMessage(`{"opaque_backend_state": ""}`)
+	targets.Custom = &custom
+	for path, content := range files {
+		meta, err := util.GenerateTargetFileMeta(bytes.NewReader(content), "sha256")
+		require.NoError(t, err)
+		fileCustom := json.RawMessage(`{"v": 1}`)
+		meta.Custom = &fileCustom
+		targets.Targets[path] = meta
+	}
+	signedTargets, err := sign.Marshal(targets, s.key)
+	require.NoError(t, err)
+	targetsBytes, err := json.Marshal(signedTargets)
+	require.NoError(t, err)
+
+	bundle, err := json.Marshal(OfflineBundle{Targets: targetsBytes, TargetFiles: files})
+	require.NoError(t, err)
+	return bundle
+}
+
+type staticBundleReader struct {
+	data []byte
+}
+
+func (s *staticBundleReader) read(context.Context) ([]byte, error) {
+	return s.data, nil
+}
+
+func Test_offlineClient_poll(t *testing.T) {
+	signer := newTestSigner(t)
+	repository, err := state.NewRepository(signer.root)
+	require.NoError(t, err)
+
+	reader := &staticBundleReader{}
+	client := newOfflineClient(reader, repository, logf.Log)
+	var received []map[string]state.RawConfig
+	client.Subscribe(state.ProductAgentConfig, func(configs map[string]state.RawConfig, applyStatus func(string, state.ApplyStatus)) {
+		received = append(received, configs)
+	})
+
+	configPath := "datadog/2/AGENT_CONFIG/npm/config"
+	reader.data = signer.bundle(t, 1, map[string][]byte{configPath: []byte(`{"features": {"npm": {"enabled": true}}}`)})
+	require.NoError(t, client.poll(context.Background()))
+	require.Len(t, received, 1)
+	require.Contains(t, received[0], configPath)
+	assert.Equal(t, "npm", received[0][configPath].Metadata.ID)
+	assert.JSONEq(t, `{"features": {"npm": {"enabled": true}}}`, string(received[0][configPath].Config))
+
+	// unchanged bundle
+	require.NoError(t, client.poll(context.Background()))
+	assert.Len(t, received, 1)
+
+	// bundle signed with another key
+	reader.data = newTestSigner(t).bundle(t, 2, map[string][]byte{configPath: []byte(`{"features": {"npm": {"enabled": false}}}`)})
+	assert.ErrorContains(t, client.poll(context.Background()), "invalid bundle")
+	assert.Len(t, received, 1)
+
+	// tampered config file
+	var bundle OfflineBundle
+	require.NoError(t, json.Unmarshal(signer.bundle(t, 3, map[string][]byte{configPath: []byte(`{}`)}), &bundle))
+	bundle.TargetFiles[configPath] = []byte(`{"features": {"npm": {"enabled": false}}}`)
+	reader.data, err = json.Marshal(bundle)
+	require.NoError(t, err)
+	assert.ErrorContains(t, client.poll(context.Background()), "invalid bundle")
+	assert.Len(t, received, 1)
+}
+
+func Test_newBundleReader(t *testing.T) {
+	tests := []struct {
+		source  string
+		want    bundleReader
+		wantErr bool
+	}{
+		{
+			source: "configmap://datadog/rc-bundle",
+			want:   &configMapBundleReader{key: OfflineBundleFileName},
+		},
+		{
+			source: "configmap://datadog/rc-bundle?key=custom.json",
+			want:   &configMapBundleReader{key: "custom.json"},
+		},
+		{
+			source:  "configmap://datadog",
+			wantErr: true,
+		},
+		{
+			source: "file:///etc/datadog/rc",
+			want:   &fileBundleReader{path: "/etc/datadog/rc"},
+		},
+		{
+			source: "http://localhost:8080/bundle.json",
+			want:   &httpBundleReader{url: "http://localhost:8080/bundle.json"},
+		},
+		{
+			source:  "s3://bucket/bundle.json",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			reader, err := newBundleReader(tt.source, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tt.want, reader)
+			switch want := tt.want.(type) {
+			case *configMapBundleReader:
+				assert.Equal(t, want.key, reader.(*configMapBundleReader).key)
+			case *fileBundleReader:
+				assert.Equal(t, want.path, reader.(*fileBundleReader).path)
+			case *httpBundleReader:
+				assert.Equal(t, want.url, reader.(*httpBundleReader).url)
+			}
+		})
+	}
+}
+
+func Test_bundleReaders(t *testing.T) {
+	ctx := context.Background()
+	content := []byte(`{"targets": ""}`)
+
+	t.Run("configmap", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "datadog", Name: "rc-bundle"},
+			Data:       map[string]string{OfflineBundleFileName: string(content)},
+		}
+		reader, err := newBundleReader("configmap://datadog/rc-bundle", fake.NewClientBuilder().WithObjects(cm).Build())
+		require.NoError(t, err)
+		got, err := reader.read(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, content, got)
+
+		reader, err = newBundleReader("configmap://datadog/rc-bundle?key=missing", fake.NewClientBuilder().WithObjects(cm).Build())
+		require.NoError(t, err)
+		_, err = reader.read(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, OfflineBundleFileName), content, 0o600))
+		reader, err := newBundleReader("file://"+dir, nil)
+		require.NoError(t, err)
+		got, err := reader.read(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, content, got)
+	})
+
+	t.Run("http", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/bundle.json" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(content)
+		}))
+		defer server.Close()
+
+		reader, err := newBundleReader(server.URL+"/bundle.json", nil)
+		require.NoError(t, err)
+		got, err := reader.read(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, content, got)
+
+		reader, err = newBundleReader(server.URL+"/missing", nil)
+		require.NoError(t, err)
+		_, err = reader.read(ctx)
+		assert.Error(t, err)
+	})
+}
+
+func TestSetupOfflineRequiresConfigRoot(t *testing.T) {
+	t.Setenv("DD_REMOTE_CONFIGURATION_CONFIG_ROOT", "")
+	err := NewRemoteConfigUpdater(nil, nil, logf.Log).SetupOffline("file:///etc/datadog/rc")
+	assert.ErrorContains(t, err, "DD_REMOTE_CONFIGURATION_CONFIG_ROOT")
+}
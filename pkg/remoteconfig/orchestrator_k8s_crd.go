@@ -6,8 +6,6 @@ import (
 	"fmt"
 
 	"github.com/DataDog/datadog-agent/pkg/remoteconfig/state"
-	apiequality "k8s.io/apimachinery/pkg/api/equality"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 )
@@ -89,42 +87,26 @@ func (r *RemoteConfigUpdater) crdUpdateInstanceStatus(dda v2alpha1.DatadogAgent,
 	}
 
 	newddaStatus := dda.Status.DeepCopy()
-	if newddaStatus.RemoteConfigConfiguration == nil {
-		newddaStatus.RemoteConfigConfiguration = &v2alpha1.RemoteConfigConfiguration{}
+	rcConfig := baseConfiguration(newddaStatus)
+	if rcConfig.Features == nil {
+		rcConfig.Features = &v2alpha1.DatadogFeatures{}
 	}
 
-	if newddaStatus.RemoteConfigConfiguration.Features == nil {
-		newddaStatus.RemoteConfigConfiguration.Features = &v2alpha1.DatadogFeatures{}
-	}
-
-	if newddaStatus.RemoteConfigConfiguration.Features.OrchestratorExplorer == nil {
-		newddaStatus.RemoteConfigConfiguration.Features.OrchestratorExplorer = &v2alpha1.OrchestratorExplorerFeatureConfig{}
+	if rcConfig.Features.OrchestratorExplorer == nil {
+		rcConfig.Features.OrchestratorExplorer = &v2alpha1.OrchestratorExplorerFeatureConfig{}
 	}
 
 	// Orchestrator Explorer
-	newddaStatus.RemoteConfigConfiguration.Features.OrchestratorExplorer.CustomResources = []string{}
+	rcConfig.Features.OrchestratorExplorer.CustomResources = []string{}
 	if cfg.CRDs != nil {
 		// Overwrite custom resources by the new ones
 		if cfg.CRDs.Crds != nil {
-			newddaStatus.RemoteConfigConfiguration.Features.OrchestratorExplorer.CustomResources = *cfg.CRDs.Crds
-		}
-		newddaStatus.RemoteConfigConfiguration.Features.OrchestratorExplorer.CustomResources = removeDuplicateStr(newddaStatus.RemoteConfigConfiguration.Features.OrchestratorExplorer.CustomResources)
-	}
-
-	if !apiequality.Semantic.DeepEqual(&dda.Status, newddaStatus) {
-		ddaUpdate := dda.DeepCopy()
-		ddaUpdate.Status = *newddaStatus
-		if err := r.kubeClient.Status().Update(context.TODO(), ddaUpdate); err != nil {
-			if apierrors.IsConflict(err) {
-				r.logger.Info("unable to update DatadogAgent CRD status due to update conflict")
-				return nil
-			}
-			r.logger.Error(err, "unable to update DatadogAgent status")
-			return err
+			rcConfig.Features.OrchestratorExplorer.CustomResources = *cfg.CRDs.Crds
 		}
+		rcConfig.Features.OrchestratorExplorer.CustomResources = removeDuplicateStr(rcConfig.Features.OrchestratorExplorer.CustomResources)
 	}
 
-	return nil
+	return r.applyConfiguration(dda, newddaStatus, rcConfig, cfg.ID)
 }
 
 func removeDuplicateStr(s []string) []string {
@@ -0,0 +1,230 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package remoteconfig
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+)
+
+const (
+	// DefaultHistoryLimit is the default number of applied configurations kept in the DatadogAgent status
+	DefaultHistoryLimit = 10
+
+	// AuditLoggerName is the name of the operator logger recording the applied configurations
+	AuditLoggerName = "remoteconfig-audit"
+
+	// Event reasons
+	appliedEventReason  = "RemoteConfigApplied"
+	proposedEventReason = "RemoteConfigProposed"
+	rejectedEventReason = "RemoteConfigRejected"
+)
+
+// DefaultAllowedFeatures lists the features Remote Configuration can configure when the DatadogAgent
+// doesn't set the allowed features.
+var DefaultAllowedFeatures = []v2alpha1.RemoteConfigFeature{
+	v2alpha1.RemoteConfigFeatureSBOM,
+	v2alpha1.RemoteConfigFeatureCWS,
+	v2alpha1.RemoteConfigFeatureUSM,
+	v2alpha1.RemoteConfigFeatureCSPM,
+	v2alpha1.RemoteConfigFeatureOrchestratorExplorer,
+}
+
+// policyResult describes how a configuration received from RemoteConfig was handled.
+type policyResult struct {
+	// rejected lists the features the policy does not allow Remote Configuration to configure
+	rejected []v2alpha1.RemoteConfigFeature
+	// changed lists the features changed by the configuration
+	changed []v2alpha1.RemoteConfigFeature
+	// proposed is true if the configuration is waiting for approval
+	proposed bool
+	// invalid is the validation error of the DatadogAgent spec with the configuration, the configuration is not applied
+	invalid error
+	// applied is the history entry of the configuration when it is applied
+	applied *v2alpha1.RemoteConfigHistoryEntry
+}
+
+// applyPolicy applies the Remote Configuration policy of the DatadogAgent to the received configuration.
+// Features the policy does not allow are removed from the received configuration. The remaining configuration is
+// either applied to newStatus, or staged in newStatus as a proposal when the approval mode is Manual.
+// A configuration that makes the DatadogAgent spec invalid is not applied.
+func applyPolicy(dda *v2alpha1.DatadogAgent, newStatus *v2alpha1.DatadogAgentStatus, received *v2alpha1.RemoteConfigConfiguration, configID string, now metav1.Time) policyResult {
+	result := policyResult{
+		rejected: FilterDisallowedFeatures(dda, received),
+	}
+
+	if err := ValidateConfiguration(dda, received); err != nil {
+		result.invalid = err
+		return result
+	}
+
+	result.changed = ChangedFeatures(newStatus.RemoteConfigConfiguration, received)
+	if len(result.changed) == 0 {
+		// the configuration is already applied, any pending proposal is outdated
+		newStatus.RemoteConfigProposal = nil
+		return result
+	}
+
+	if approvalMode(dda) == v2alpha1.RemoteConfigApprovalModeManual {
+		// only the changes are stored, the proposal is applied on top of the applied configuration once approved
+		changes, err := FeatureChanges(newStatus.RemoteConfigConfiguration, received)
+		if err != nil {
+			result.invalid = err
+			return result
+		}
+		newStatus.RemoteConfigProposal = &v2alpha1.RemoteConfigProposal{
+			ConfigID:   configID,
+			ReceivedAt: now,
+			Changes:    changes,
+		}
+		result.proposed = true
+		return result
+	}
+
+	result.applied = &v2alpha1.RemoteConfigHistoryEntry{
+		ConfigID:     configID,
+		AppliedAt:    now,
+		ApprovalMode: v2alpha1.RemoteConfigApprovalModeAutomatic,
+		Features:     result.changed,
+	}
+	newStatus.RemoteConfigConfiguration = received
+	newStatus.RemoteConfigHistory = AppendHistory(dda, newStatus.RemoteConfigHistory, *result.applied)
+
+	return result
+}
+
+// baseConfiguration returns a copy of the configuration a newly received configuration should be merged into:
+// the applied configuration with the changes of the pending proposal if any.
+func baseConfiguration(status *v2alpha1.DatadogAgentStatus) *v2alpha1.RemoteConfigConfiguration {
+	base := &v2alpha1.RemoteConfigConfiguration{}
+	if status.RemoteConfigConfiguration != nil {
+		base = status.RemoteConfigConfiguration.DeepCopy()
+	}
+	if status.RemoteConfigProposal != nil {
+		// the proposal changes were computed from the applied configuration, they always apply to it
+		if proposed, err := ApplyChanges(base, status.RemoteConfigProposal.Changes); err == nil {
+			return proposed
+		}
+	}
+	return base
+}
+
+// IsFeatureAllowed returns true if the Remote Configuration policy of the DatadogAgent allows the feature to be configured.
+func IsFeatureAllowed(dda *v2alpha1.DatadogAgent, feature v2alpha1.RemoteConfigFeature) bool {
+	if feature == v2alpha1.RemoteConfigFeatureRemoteConfiguration {
+		return false
+	}
+	allowedFeatures := DefaultAllowedFeatures
+	if rc := remoteConfigurationConfig(dda); rc != nil && rc.AllowedFeatures != nil {
+		allowedFeatures = rc.AllowedFeatures
+	}
+	for _, allowed := range allowedFeatures {
+		if allowed == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangedFeatures returns the features configured differently by the two configurations.
+func ChangedFeatures(current, received *v2alpha1.RemoteConfigConfiguration) []v2alpha1.RemoteConfigFeature {
+	currentFeatures, receivedFeatures := &v2alpha1.DatadogFeatures{}, &v2alpha1.DatadogFeatures{}
+	if current != nil && current.Features != nil {
+		currentFeatures = current.Features
+	}
+	if received != nil && received.Features != nil {
+		receivedFeatures = received.Features
+	}
+
+	var changed []v2alpha1.RemoteConfigFeature
+	for _, f := range remoteConfigFeatures {
+		if !apiequality.Semantic.DeepEqual(f.get(currentFeatures), f.get(receivedFeatures)) {
+			changed = append(changed, f.name)
+		}
+	}
+	return changed
+}
+
+// AppendHistory appends an entry to the Remote Configuration history, dropping the oldest entries
+// to keep at most the history limit of the DatadogAgent. The history is bounded, see LogApplied for the audit trail.
+func AppendHistory(dda *v2alpha1.DatadogAgent, history []v2alpha1.RemoteConfigHistoryEntry, entry v2alpha1.RemoteConfigHistoryEntry) []v2alpha1.RemoteConfigHistoryEntry {
+	history = append(history, entry)
+	if limit := historyLimit(dda); len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	return history
+}
+
+// LogApplied records a configuration applied to the DatadogAgent with the audit logger of the operator.
+// The operator logs are the audit trail of Remote Configuration: unlike the status history and the Kubernetes
+// events, they are neither bounded nor expired once collected.
+func LogApplied(logger logr.Logger, dda *v2alpha1.DatadogAgent, entry v2alpha1.RemoteConfigHistoryEntry) {
+	logger.WithName(AuditLoggerName).Info("Remote Configuration applied",
+		"datadogagent", dda.Namespace+"/"+dda.Name,
+		"configID", entry.ConfigID,
+		"approvalMode", entry.ApprovalMode,
+		"features", JoinFeatures(entry.Features),
+		"appliedAt", entry.AppliedAt.UTC().Format(time.RFC3339),
+	)
+}
+
+// FilterDisallowedFeatures removes the features the policy does not allow from the configuration,
+// and returns the ones that were set. It is applied to the received configurations, and to the applied
+// configuration on every reconcile as the allowed features may have changed since it was applied.
+func FilterDisallowedFeatures(dda *v2alpha1.DatadogAgent, config *v2alpha1.RemoteConfigConfiguration) []v2alpha1.RemoteConfigFeature {
+	if config.Features == nil {
+		return nil
+	}
+	var rejected []v2alpha1.RemoteConfigFeature
+	for _, f := range remoteConfigFeatures {
+		if IsFeatureAllowed(dda, f.name) {
+			continue
+		}
+		if !f.isNil(config.Features) {
+			rejected = append(rejected, f.name)
+		}
+		f.clear(config.Features)
+	}
+	return rejected
+}
+
+func historyLimit(dda *v2alpha1.DatadogAgent) int {
+	rc := remoteConfigurationConfig(dda)
+	if rc == nil || rc.HistoryLimit == nil || *rc.HistoryLimit < 1 {
+		return DefaultHistoryLimit
+	}
+	return int(*rc.HistoryLimit)
+}
+
+func approvalMode(dda *v2alpha1.DatadogAgent) v2alpha1.RemoteConfigApprovalMode {
+	rc := remoteConfigurationConfig(dda)
+	if rc == nil || rc.ApprovalMode == nil {
+		return v2alpha1.RemoteConfigApprovalModeAutomatic
+	}
+	return *rc.ApprovalMode
+}
+
+func remoteConfigurationConfig(dda *v2alpha1.DatadogAgent) *v2alpha1.RemoteConfigurationFeatureConfig {
+	if dda.Spec.Features == nil {
+		return nil
+	}
+	return dda.Spec.Features.RemoteConfiguration
+}
+
+// JoinFeatures returns a comma separated list of features.
+func JoinFeatures(features []v2alpha1.RemoteConfigFeature) string {
+	names := make([]string, 0, len(features))
+	for _, f := range features {
+		names = append(names, string(f))
+	}
+	return strings.Join(names, ", ")
+}
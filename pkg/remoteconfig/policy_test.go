@@ -0,0 +1,233 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package remoteconfig
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
+	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/api/utils"
+)
+
+func Test_applyPolicy(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+	manual := v2alpha1.RemoteConfigApprovalModeManual
+
+	newDDA := func(rc *v2alpha1.RemoteConfigurationFeatureConfig, status v2alpha1.DatadogAgentStatus) *v2alpha1.DatadogAgent {
+		return &v2alpha1.DatadogAgent{
+			Spec: v2alpha1.DatadogAgentSpec{
+				Features: &v2alpha1.DatadogFeatures{RemoteConfiguration: rc},
+			},
+			Status: status,
+		}
+	}
+	received := func() *v2alpha1.RemoteConfigConfiguration {
+		return &v2alpha1.RemoteConfigConfiguration{
+			Features: &v2alpha1.DatadogFeatures{
+				CWS: &v2alpha1.CWSFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+				USM: &apicommon.USMFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		dda        *v2alpha1.DatadogAgent
+		wantResult policyResult
+		wantStatus v2alpha1.DatadogAgentStatus
+	}{
+		{
+			name: "no policy, configuration applied",
+			dda:  newDDA(nil, v2alpha1.DatadogAgentStatus{}),
+			wantResult: policyResult{
+				changed: []v2alpha1.RemoteConfigFeature{v2alpha1.RemoteConfigFeatureCWS, v2alpha1.RemoteConfigFeatureUSM},
+				applied: &v2alpha1.RemoteConfigHistoryEntry{
+					ConfigID:     "id",
+					AppliedAt:    now,
+					ApprovalMode: v2alpha1.RemoteConfigApprovalModeAutomatic,
+					Features:     []v2alpha1.RemoteConfigFeature{v2alpha1.RemoteConfigFeatureCWS, v2alpha1.RemoteConfigFeatureUSM},
+				},
+			},
+			wantStatus: v2alpha1.DatadogAgentStatus{
+				RemoteConfigConfiguration: received(),
+				RemoteConfigHistory: []v2alpha1.RemoteConfigHistoryEntry{
+					{
+						ConfigID:     "id",
+						AppliedAt:    now,
+						ApprovalMode: v2alpha1.RemoteConfigApprovalModeAutomatic,
+						Features:     []v2alpha1.RemoteConfigFeature{v2alpha1.RemoteConfigFeatureCWS, v2alpha1.RemoteConfigFeatureUSM},
+					},
+				},
+			},
+		},
+		{
+			name: "disallowed feature rejected",
+			dda: newDDA(&v2alpha1.RemoteConfigurationFeatureConfig{
+				AllowedFeatures: []v2alpha1.RemoteConfigFeature{v2alpha1.RemoteConfigFeatureUSM},
+			}, v2alpha1.DatadogAgentStatus{}),
+			wantResult: policyResult{
+				rejected: []v2alpha1.RemoteConfigFeature{v2alpha1.RemoteConfigFeatureCWS},
+				changed:  []v2alpha1.RemoteConfigFeature{v2alpha1.RemoteConfigFeatureUSM},
+				applied: &v2alpha1.RemoteConfigHistoryEntry{
+					ConfigID:     "id",
+					AppliedAt:    now,
+					ApprovalMode: v2alpha1.RemoteConfigApprovalModeAutomatic,
+					Features:     []v2alpha1.RemoteConfigFeature{v2alpha1.RemoteConfigFeatureUSM},
+				},
+			},
+			wantStatus: v2alpha1.DatadogAgentStatus{
+				RemoteConfigConfiguration: &v2alpha1.RemoteConfigConfiguration{
+					Features: &v2alpha1.DatadogFeatures{
+						USM: &apicommon.USMFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+					},
+				},
+				RemoteConfigHistory: []v2alpha1.RemoteConfigHistoryEntry{
+					{
+						ConfigID:     "id",
+						AppliedAt:    now,
+						ApprovalMode: v2alpha1.RemoteConfigApprovalModeAutomatic,
+						Features:     []v2alpha1.RemoteConfigFeature{v2alpha1.RemoteConfigFeatureUSM},
+					},
+				},
+			},
+		},
+		{
+			name: "all features rejected, nothing applied",
+			dda: newDDA(&v2alpha1.RemoteConfigurationFeatureConfig{
+				AllowedFeatures: []v2alpha1.RemoteConfigFeature{v2alpha1.RemoteConfigFeatureSBOM},
+			}, v2alpha1.DatadogAgentStatus{}),
+			wantResult: policyResult{
+				rejected: []v2alpha1.RemoteConfigFeature{v2alpha1.RemoteConfigFeatureCWS, v2alpha1.RemoteConfigFeatureUSM},
+			},
+			wantStatus: v2alpha1.DatadogAgentStatus{},
+		},
+		{
+			name: "manual approval, configuration proposed",
+			dda: newDDA(&v2alpha1.RemoteConfigurationFeatureConfig{
+				ApprovalMode: &manual,
+			}, v2alpha1.DatadogAgentStatus{}),
+			wantResult: policyResult{
+				changed:  []v2alpha1.RemoteConfigFeature{v2alpha1.RemoteConfigFeatureCWS, v2alpha1.RemoteConfigFeatureUSM},
+				proposed: true,
+			},
+			wantStatus: v2alpha1.DatadogAgentStatus{
+				RemoteConfigProposal: &v2alpha1.RemoteConfigProposal{
+					ConfigID:   "id",
+					ReceivedAt: now,
+					Changes: []v2alpha1.RemoteConfigChange{
+						{Path: "cws.enabled", Value: "true"},
+						{Path: "usm.enabled", Value: "true"},
+					},
+				},
+			},
+		},
+		{
+			name: "manual approval, configuration already applied clears the proposal",
+			dda: newDDA(&v2alpha1.RemoteConfigurationFeatureConfig{
+				ApprovalMode: &manual,
+			}, v2alpha1.DatadogAgentStatus{
+				RemoteConfigConfiguration: received(),
+				RemoteConfigProposal: &v2alpha1.RemoteConfigProposal{
+					ConfigID: "previous",
+					Changes:  []v2alpha1.RemoteConfigChange{{Path: "cws.enabled", Value: "false"}},
+				},
+			}),
+			wantResult: policyResult{},
+			wantStatus: v2alpha1.DatadogAgentStatus{
+				RemoteConfigConfiguration: received(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newStatus := tt.dda.Status.DeepCopy()
+			result := applyPolicy(tt.dda, newStatus, received(), "id", now)
+			assert.Equal(t, tt.wantResult, result)
+			assert.Equal(t, tt.wantStatus, *newStatus)
+		})
+	}
+}
+
+func TestAppendHistory(t *testing.T) {
+	tests := []struct {
+		name         string
+		historyLimit *int32
+		wantLen      int
+	}{
+		{
+			name:    "default limit",
+			wantLen: DefaultHistoryLimit,
+		},
+		{
+			name:         "configured limit",
+			historyLimit: apiutils.NewInt32Pointer(50),
+			wantLen:      50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dda := &v2alpha1.DatadogAgent{
+				Spec: v2alpha1.DatadogAgentSpec{
+					Features: &v2alpha1.DatadogFeatures{
+						RemoteConfiguration: &v2alpha1.RemoteConfigurationFeatureConfig{HistoryLimit: tt.historyLimit},
+					},
+				},
+			}
+			var history []v2alpha1.RemoteConfigHistoryEntry
+			for i := 0; i < tt.wantLen+2; i++ {
+				history = AppendHistory(dda, history, v2alpha1.RemoteConfigHistoryEntry{ConfigID: fmt.Sprintf("%d", i)})
+			}
+			assert.Len(t, history, tt.wantLen)
+			assert.Equal(t, "2", history[0].ConfigID)
+			assert.Equal(t, fmt.Sprintf("%d", tt.wantLen+1), history[tt.wantLen-1].ConfigID)
+		})
+	}
+}
+
+func TestIsFeatureAllowed(t *testing.T) {
+	dda := &v2alpha1.DatadogAgent{}
+	assert.True(t, IsFeatureAllowed(dda, v2alpha1.RemoteConfigFeatureSBOM))
+	assert.True(t, IsFeatureAllowed(dda, v2alpha1.RemoteConfigFeatureOrchestratorExplorer))
+	assert.False(t, IsFeatureAllowed(dda, "npm"), "only the default features are allowed when no allowed features are set")
+
+	dda.Spec.Features = &v2alpha1.DatadogFeatures{
+		RemoteConfiguration: &v2alpha1.RemoteConfigurationFeatureConfig{
+			AllowedFeatures: []v2alpha1.RemoteConfigFeature{"npm", v2alpha1.RemoteConfigFeatureRemoteConfiguration},
+		},
+	}
+	assert.True(t, IsFeatureAllowed(dda, "npm"))
+	assert.False(t, IsFeatureAllowed(dda, v2alpha1.RemoteConfigFeatureSBOM))
+	assert.False(t, IsFeatureAllowed(dda, v2alpha1.RemoteConfigFeatureRemoteConfiguration))
+}
+
+func Test_applyPolicyInvalidSpec(t *testing.T) {
+	dda := &v2alpha1.DatadogAgent{
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				EventCollection: &v2alpha1.EventCollectionFeatureConfig{
+					Filter: &v2alpha1.EventFilter{Namespace: apiutils.NewStringPointer("foo"), ExcludedNamespaces: []string{"bar"}},
+				},
+			},
+		},
+	}
+	received := &v2alpha1.RemoteConfigConfiguration{
+		Features: &v2alpha1.DatadogFeatures{
+			CWS: &v2alpha1.CWSFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+		},
+	}
+
+	newStatus := dda.Status.DeepCopy()
+	result := applyPolicy(dda, newStatus, received, "id", metav1.NewTime(time.Now()))
+	assert.Error(t, result.invalid)
+	assert.Empty(t, result.changed)
+	assert.Equal(t, dda.Status, *newStatus)
+}
@@ -23,10 +23,14 @@ import (
 	"github.com/cenkalti/backoff"
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	kubeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	"github.com/DataDog/datadog-operator/pkg/config"
 	"github.com/DataDog/datadog-operator/pkg/constants"
@@ -40,12 +44,15 @@ const (
 )
 
 type RemoteConfigUpdater struct {
-	kubeClient  kubeclient.Client
-	rcClient    *client.Client
-	rcService   *service.CoreAgentService
-	serviceConf RcServiceConfiguration
-	logger      logr.Logger
-	mu          sync.RWMutex
+	kubeClient kubeclient.Client
+	recorder   record.EventRecorder
+	rcClient   *client.Client
+	rcService  *service.CoreAgentService
+	// offlineClient replaces rcClient and rcService when the configurations are read from an offline source
+	offlineClient *offlineClient
+	serviceConf   RcServiceConfiguration
+	logger        logr.Logger
+	mu            sync.RWMutex
 }
 
 type RcServiceConfiguration struct {
@@ -72,6 +79,8 @@ type DatadogAgentRemoteConfig struct {
 	CoreAgent     *CoreAgentFeaturesConfig     `json:"config,omitempty"`
 	SystemProbe   *SystemProbeFeaturesConfig   `json:"system_probe,omitempty"`
 	SecurityAgent *SecurityAgentFeaturesConfig `json:"security_agent,omitempty"`
+	// Features configures any DatadogAgent feature, using the DatadogAgent API format
+	Features *v2alpha1.DatadogFeatures `json:"features,omitempty"`
 }
 
 // GetID returns the ID of the configuration
@@ -351,6 +360,7 @@ func (r *RemoteConfigUpdater) parseReceivedUpdates(updates map[string]state.RawC
 				return DatadogAgentRemoteConfig{}, fmt.Errorf("could not unmarshal configuration %s", c.Metadata.ID)
 			} else {
 				configData.ID = c.Metadata.ID
+				configData.Features = configData.datadogFeatures()
 				configByID[configData.ID] = configData
 			}
 		}
@@ -375,80 +385,50 @@ func mergeConfigs(dst, src *DatadogAgentRemoteConfig) {
 		dst.ID = src.ID
 	}
 
-	// CoreAgent
-	if src.CoreAgent != nil {
-		if dst.CoreAgent == nil {
-			dst.CoreAgent = &CoreAgentFeaturesConfig{}
-		}
-		if src.CoreAgent.SBOM != nil {
-			if dst.CoreAgent.SBOM == nil {
-				dst.CoreAgent.SBOM = &SbomConfig{}
-			}
-			if src.CoreAgent.SBOM.Enabled != nil {
-				dst.CoreAgent.SBOM.Enabled = src.CoreAgent.SBOM.Enabled
-			}
-			// Merging SbomConfig's Host
-			if src.CoreAgent.SBOM.Host != nil {
-				if dst.CoreAgent.SBOM.Host == nil {
-					dst.CoreAgent.SBOM.Host = &FeatureEnabledConfig{}
-				}
-				if src.CoreAgent.SBOM.Host.Enabled != nil {
-					dst.CoreAgent.SBOM.Host.Enabled = src.CoreAgent.SBOM.Host.Enabled
-				}
-			}
-			// Merging SbomConfig's ContainerImage
-			if src.CoreAgent.SBOM.ContainerImage != nil {
-				if dst.CoreAgent.SBOM.ContainerImage == nil {
-					dst.CoreAgent.SBOM.ContainerImage = &FeatureEnabledConfig{}
-				}
-				if src.CoreAgent.SBOM.ContainerImage.Enabled != nil {
-					dst.CoreAgent.SBOM.ContainerImage.Enabled = src.CoreAgent.SBOM.ContainerImage.Enabled
-				}
-			}
+	if src.Features != nil {
+		if dst.Features == nil {
+			dst.Features = &v2alpha1.DatadogFeatures{}
 		}
+		MergeFeatures(dst.Features, src.Features)
 	}
+}
+
+// datadogFeatures returns the features configured by the configuration. The SBOM, CWS, USM and CSPM
+// settings of the agent configurations take precedence over the ones of Features.
+func (d DatadogAgentRemoteConfig) datadogFeatures() *v2alpha1.DatadogFeatures {
+	features := &v2alpha1.DatadogFeatures{}
+	MergeFeatures(features, d.Features)
 
-	// SystemProbe
-	if src.SystemProbe != nil {
-		if dst.SystemProbe == nil {
-			dst.SystemProbe = &SystemProbeFeaturesConfig{}
+	// SBOM
+	if d.CoreAgent != nil && d.CoreAgent.SBOM != nil {
+		sbom := &v2alpha1.SBOMFeatureConfig{
+			Enabled: d.CoreAgent.SBOM.Enabled,
 		}
-		// Merging USM
-		if src.SystemProbe.USM != nil {
-			if dst.SystemProbe.USM == nil {
-				dst.SystemProbe.USM = &FeatureEnabledConfig{}
-			}
-			if src.SystemProbe.USM.Enabled != nil {
-				dst.SystemProbe.USM.Enabled = src.SystemProbe.USM.Enabled
-			}
+		if d.CoreAgent.SBOM.Host != nil {
+			sbom.Host = &v2alpha1.SBOMHostConfig{Enabled: d.CoreAgent.SBOM.Host.Enabled}
 		}
-		// Merging CWS
-		if src.SystemProbe.CWS != nil {
-			if dst.SystemProbe.CWS == nil {
-				dst.SystemProbe.CWS = &FeatureEnabledConfig{}
-			}
-			if src.SystemProbe.CWS.Enabled != nil {
-				dst.SystemProbe.CWS.Enabled = src.SystemProbe.CWS.Enabled
-			}
+		if d.CoreAgent.SBOM.ContainerImage != nil {
+			sbom.ContainerImage = &v2alpha1.SBOMContainerImageConfig{Enabled: d.CoreAgent.SBOM.ContainerImage.Enabled}
 		}
+		MergeFeatures(features, &v2alpha1.DatadogFeatures{SBOM: sbom})
+	}
 
+	// CWS
+	if d.SystemProbe != nil && d.SystemProbe.CWS != nil {
+		MergeFeatures(features, &v2alpha1.DatadogFeatures{CWS: &v2alpha1.CWSFeatureConfig{Enabled: d.SystemProbe.CWS.Enabled}})
 	}
 
-	// SecurityAgent
-	if src.SecurityAgent != nil {
-		if dst.SecurityAgent == nil {
-			dst.SecurityAgent = &SecurityAgentFeaturesConfig{}
-		}
-		if src.SecurityAgent.CSPM != nil {
-			if dst.SecurityAgent.CSPM == nil {
-				dst.SecurityAgent.CSPM = &FeatureEnabledConfig{}
-			}
-			if src.SecurityAgent.CSPM.Enabled != nil {
-				dst.SecurityAgent.CSPM.Enabled = src.SecurityAgent.CSPM.Enabled
-			}
-		}
+	// USM
+	if d.SystemProbe != nil && d.SystemProbe.USM != nil {
+		MergeFeatures(features, &v2alpha1.DatadogFeatures{USM: &apicommon.USMFeatureConfig{Enabled: d.SystemProbe.USM.Enabled}})
 	}
 
+	// CSPM
+	if d.SecurityAgent != nil && d.SecurityAgent.CSPM != nil {
+		MergeFeatures(features, &v2alpha1.DatadogFeatures{CSPM: &v2alpha1.CSPMFeatureConfig{Enabled: d.SecurityAgent.CSPM.Enabled}})
+	}
+
+	return features
 }
 
 func (r *RemoteConfigUpdater) updateInstanceStatus(dda v2alpha1.DatadogAgent, config DatadogProductRemoteConfig) error {
@@ -458,95 +438,32 @@ func (r *RemoteConfigUpdater) updateInstanceStatus(dda v2alpha1.DatadogAgent, co
 	}
 
 	newddaStatus := dda.Status.DeepCopy()
-	if newddaStatus.RemoteConfigConfiguration == nil {
-		newddaStatus.RemoteConfigConfiguration = &v2alpha1.RemoteConfigConfiguration{}
-	}
-
-	if newddaStatus.RemoteConfigConfiguration.Features == nil {
-		newddaStatus.RemoteConfigConfiguration.Features = &v2alpha1.DatadogFeatures{}
-	}
-
-	// CWS
-	if cfg.SystemProbe != nil && cfg.SystemProbe.CWS != nil {
-		if newddaStatus.RemoteConfigConfiguration.Features.CWS == nil {
-			newddaStatus.RemoteConfigConfiguration.Features.CWS = &v2alpha1.CWSFeatureConfig{}
+	rcConfig := baseConfiguration(newddaStatus)
+
+	// The received configuration replaces the previous one, except for the Orchestrator Explorer
+	// custom resources that are configured by the orchestrator CRD product.
+	features := &v2alpha1.DatadogFeatures{}
+	if rcConfig.Features != nil && rcConfig.Features.OrchestratorExplorer != nil && rcConfig.Features.OrchestratorExplorer.CustomResources != nil {
+		features.OrchestratorExplorer = &v2alpha1.OrchestratorExplorerFeatureConfig{
+			CustomResources: rcConfig.Features.OrchestratorExplorer.CustomResources,
 		}
-		if newddaStatus.RemoteConfigConfiguration.Features.CWS.Enabled == nil {
-			newddaStatus.RemoteConfigConfiguration.Features.CWS.Enabled = new(bool)
-		}
-		newddaStatus.RemoteConfigConfiguration.Features.CWS.Enabled = cfg.SystemProbe.CWS.Enabled
-	} else {
-		newddaStatus.RemoteConfigConfiguration.Features.CWS = nil
-	}
-
-	// CSPM
-	if cfg.SecurityAgent != nil && cfg.SecurityAgent.CSPM != nil {
-		if newddaStatus.RemoteConfigConfiguration.Features.CSPM == nil {
-			newddaStatus.RemoteConfigConfiguration.Features.CSPM = &v2alpha1.CSPMFeatureConfig{}
-		}
-		if newddaStatus.RemoteConfigConfiguration.Features.CSPM.Enabled == nil {
-			newddaStatus.RemoteConfigConfiguration.Features.CSPM.Enabled = new(bool)
-		}
-		newddaStatus.RemoteConfigConfiguration.Features.CSPM.Enabled = cfg.SecurityAgent.CSPM.Enabled
-	} else {
-		newddaStatus.RemoteConfigConfiguration.Features.CSPM = nil
 	}
+	MergeFeatures(features, cfg.Features)
+	rcConfig.Features = features
 
-	// SBOM
-	if cfg.CoreAgent != nil && cfg.CoreAgent.SBOM != nil {
-		if newddaStatus.RemoteConfigConfiguration.Features.SBOM == nil {
-			newddaStatus.RemoteConfigConfiguration.Features.SBOM = &v2alpha1.SBOMFeatureConfig{}
-		}
-		if newddaStatus.RemoteConfigConfiguration.Features.SBOM.Enabled == nil {
-			newddaStatus.RemoteConfigConfiguration.Features.SBOM.Enabled = new(bool)
-		}
-		newddaStatus.RemoteConfigConfiguration.Features.SBOM.Enabled = cfg.CoreAgent.SBOM.Enabled
-
-		// SBOM HOST
-		if cfg.CoreAgent.SBOM.Host != nil {
-			if newddaStatus.RemoteConfigConfiguration.Features.SBOM.Host == nil {
-				newddaStatus.RemoteConfigConfiguration.Features.SBOM.Host = &v2alpha1.SBOMHostConfig{}
-			}
-			if newddaStatus.RemoteConfigConfiguration.Features.SBOM.Host.Enabled == nil {
-				newddaStatus.RemoteConfigConfiguration.Features.SBOM.Host.Enabled = new(bool)
-			}
-			newddaStatus.RemoteConfigConfiguration.Features.SBOM.Host.Enabled = cfg.CoreAgent.SBOM.Host.Enabled
-		} else {
-			newddaStatus.RemoteConfigConfiguration.Features.SBOM.Host = nil
-		}
-
-		// SBOM CONTAINER IMAGE
-		if cfg.CoreAgent.SBOM.ContainerImage != nil {
-			if newddaStatus.RemoteConfigConfiguration.Features.SBOM.ContainerImage == nil {
-				newddaStatus.RemoteConfigConfiguration.Features.SBOM.ContainerImage = &v2alpha1.SBOMContainerImageConfig{}
-			}
-			if newddaStatus.RemoteConfigConfiguration.Features.SBOM.ContainerImage.Enabled == nil {
-				newddaStatus.RemoteConfigConfiguration.Features.SBOM.ContainerImage.Enabled = new(bool)
-			}
-			newddaStatus.RemoteConfigConfiguration.Features.SBOM.ContainerImage.Enabled = cfg.CoreAgent.SBOM.ContainerImage.Enabled
-		} else {
-			newddaStatus.RemoteConfigConfiguration.Features.SBOM.ContainerImage = nil
-		}
+	return r.applyConfiguration(dda, newddaStatus, rcConfig, cfg.ID)
+}
 
-	} else {
-		newddaStatus.RemoteConfigConfiguration.Features.SBOM = nil
-	}
-
-	// USM
-	if cfg.SystemProbe != nil && cfg.SystemProbe.USM != nil {
-		if newddaStatus.RemoteConfigConfiguration.Features.USM == nil {
-			newddaStatus.RemoteConfigConfiguration.Features.USM = &v2alpha1.USMFeatureConfig{}
-		}
-		if newddaStatus.RemoteConfigConfiguration.Features.USM.Enabled == nil {
-			newddaStatus.RemoteConfigConfiguration.Features.USM.Enabled = new(bool)
-		}
-		newddaStatus.RemoteConfigConfiguration.Features.USM.Enabled = cfg.SystemProbe.USM.Enabled
-	} else {
-		newddaStatus.RemoteConfigConfiguration.Features.USM = nil
+// applyConfiguration applies the Remote Configuration policy to the received configuration, updates the
+// DatadogAgent status accordingly and records the outcome as Kubernetes events.
+func (r *RemoteConfigUpdater) applyConfiguration(dda v2alpha1.DatadogAgent, newddaStatus *v2alpha1.DatadogAgentStatus, rcConfig *v2alpha1.RemoteConfigConfiguration, configID string) error {
+	result := applyPolicy(&dda, newddaStatus, rcConfig, configID, metav1.NewTime(time.Now()))
+	if result.invalid != nil {
+		r.logger.Info("Remote Configuration rejected, it makes the DatadogAgent spec invalid", "configID", configID, "error", result.invalid.Error())
 	}
 
+	ddaUpdate := dda.DeepCopy()
 	if !apiequality.Semantic.DeepEqual(&dda.Status, newddaStatus) {
-		ddaUpdate := dda.DeepCopy()
 		ddaUpdate.Status = *newddaStatus
 		if err := r.kubeClient.Status().Update(context.TODO(), ddaUpdate); err != nil {
 			if apierrors.IsConflict(err) {
@@ -558,9 +475,35 @@ func (r *RemoteConfigUpdater) updateInstanceStatus(dda v2alpha1.DatadogAgent, co
 		}
 	}
 
+	if result.applied != nil {
+		LogApplied(r.logger, ddaUpdate, *result.applied)
+	}
+	r.recordPolicyResult(ddaUpdate, result, configID)
+
 	return nil
 }
 
+func (r *RemoteConfigUpdater) recordPolicyResult(dda *v2alpha1.DatadogAgent, result policyResult, configID string) {
+	if r.recorder == nil {
+		return
+	}
+	if len(result.rejected) > 0 {
+		r.recorder.Eventf(dda, corev1.EventTypeWarning, rejectedEventReason, "Remote Configuration %q is not allowed to configure features: %s", configID, JoinFeatures(result.rejected))
+	}
+	if result.invalid != nil {
+		r.recorder.Eventf(dda, corev1.EventTypeWarning, rejectedEventReason, "Remote Configuration %q rejected, it makes the DatadogAgent spec invalid: %v", configID, result.invalid)
+		return
+	}
+	if len(result.changed) == 0 {
+		return
+	}
+	if result.proposed {
+		r.recorder.Eventf(dda, corev1.EventTypeNormal, proposedEventReason, "Remote Configuration %q is waiting for approval, it changes features: %s", configID, JoinFeatures(result.changed))
+		return
+	}
+	r.recorder.Eventf(dda, corev1.EventTypeNormal, appliedEventReason, "Remote Configuration %q applied, it changes features: %s", configID, JoinFeatures(result.changed))
+}
+
 func (r *RemoteConfigUpdater) Stop() error {
 	if r.rcService != nil {
 		err := r.rcService.Stop()
@@ -571,14 +514,19 @@ func (r *RemoteConfigUpdater) Stop() error {
 	if r.rcClient != nil {
 		r.rcClient.Close()
 	}
+	if r.offlineClient != nil {
+		r.offlineClient.Close()
+	}
 	r.rcService = nil
 	r.rcClient = nil
+	r.offlineClient = nil
 	return nil
 }
 
-func NewRemoteConfigUpdater(client kubeclient.Client, logger logr.Logger) *RemoteConfigUpdater {
+func NewRemoteConfigUpdater(client kubeclient.Client, recorder record.EventRecorder, logger logr.Logger) *RemoteConfigUpdater {
 	return &RemoteConfigUpdater{
 		kubeClient: client,
+		recorder:   recorder,
 		logger:     logger,
 	}
 }
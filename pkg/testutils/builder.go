@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	apicommon "github.com/DataDog/datadog-operator/api/datadoghq/common"
 	"github.com/DataDog/datadog-operator/api/datadoghq/v2alpha1"
 	apiutils "github.com/DataDog/datadog-operator/api/utils"
 	"github.com/DataDog/datadog-operator/internal/controller/datadogagent/defaults"
@@ -88,8 +89,8 @@ func (builder *DatadogAgentBuilder) WithEnvVars(envs []corev1.EnvVar) *DatadogAg
 // Dogstatsd
 func (builder *DatadogAgentBuilder) initDogstatsd() {
 	if builder.datadogAgent.Spec.Features.Dogstatsd == nil {
-		builder.datadogAgent.Spec.Features.Dogstatsd = &v2alpha1.DogstatsdFeatureConfig{}
-		builder.datadogAgent.Spec.Features.Dogstatsd.UnixDomainSocketConfig = &v2alpha1.UnixDomainSocketConfig{}
+		builder.datadogAgent.Spec.Features.Dogstatsd = &apicommon.DogstatsdFeatureConfig{}
+		builder.datadogAgent.Spec.Features.Dogstatsd.UnixDomainSocketConfig = &apicommon.UnixDomainSocketConfig{}
 	}
 }
 
@@ -132,7 +133,7 @@ func (builder *DatadogAgentBuilder) WithDogstatsdUnixDomainSocketConfigPath(cust
 
 func (builder *DatadogAgentBuilder) WithDogstatsdMapperProfiles(customMapperProfilesConf string) *DatadogAgentBuilder {
 	builder.initDogstatsd()
-	builder.datadogAgent.Spec.Features.Dogstatsd.MapperProfiles = &v2alpha1.CustomConfig{ConfigData: apiutils.NewStringPointer(customMapperProfilesConf)}
+	builder.datadogAgent.Spec.Features.Dogstatsd.MapperProfiles = &apicommon.CustomConfig{ConfigData: apiutils.NewStringPointer(customMapperProfilesConf)}
 	return builder
 }
 
@@ -140,7 +141,7 @@ func (builder *DatadogAgentBuilder) WithDogstatsdMapperProfiles(customMapperProf
 
 func (builder *DatadogAgentBuilder) initLiveContainer() {
 	if builder.datadogAgent.Spec.Features.LiveContainerCollection == nil {
-		builder.datadogAgent.Spec.Features.LiveContainerCollection = &v2alpha1.LiveContainerCollectionFeatureConfig{}
+		builder.datadogAgent.Spec.Features.LiveContainerCollection = &apicommon.LiveContainerCollectionFeatureConfig{}
 	}
 }
 
@@ -153,7 +154,7 @@ func (builder *DatadogAgentBuilder) WithLiveContainerCollectionEnabled(enabled b
 // Live Processes
 func (builder *DatadogAgentBuilder) initLiveProcesses() {
 	if builder.datadogAgent.Spec.Features.LiveProcessCollection == nil {
-		builder.datadogAgent.Spec.Features.LiveProcessCollection = &v2alpha1.LiveProcessCollectionFeatureConfig{}
+		builder.datadogAgent.Spec.Features.LiveProcessCollection = &apicommon.LiveProcessCollectionFeatureConfig{}
 	}
 }
 
@@ -236,7 +237,7 @@ func (builder *DatadogAgentBuilder) WithAdmissionControllerServiceName(name stri
 
 func (builder *DatadogAgentBuilder) WithAdmissionControllerAgentCommunicationMode(comMode string) *DatadogAgentBuilder {
 	builder.initAdmissionController()
-	builder.datadogAgent.Spec.Features.AdmissionController.AgentCommunicationMode = apiutils.NewStringPointer(comMode)
+	builder.datadogAgent.Spec.Features.AdmissionController.AgentCommunicationMode = apiutils.NewPointer(v2alpha1.AgentCommunicationMode(comMode))
 	return builder
 }
 
@@ -367,7 +368,7 @@ func (builder *DatadogAgentBuilder) WithSidecarInjectionProfiles(envKey, envValu
 // Process Discovery
 func (builder *DatadogAgentBuilder) initProcessDiscovery() {
 	if builder.datadogAgent.Spec.Features.ProcessDiscovery == nil {
-		builder.datadogAgent.Spec.Features.ProcessDiscovery = &v2alpha1.ProcessDiscoveryFeatureConfig{}
+		builder.datadogAgent.Spec.Features.ProcessDiscovery = &apicommon.ProcessDiscoveryFeatureConfig{}
 	}
 }
 
@@ -391,7 +392,7 @@ func (builder *DatadogAgentBuilder) WithOTelCollectorEnabled(enabled bool) *Data
 }
 
 func (builder *DatadogAgentBuilder) WithOTelCollectorConfig() *DatadogAgentBuilder {
-	builder.datadogAgent.Spec.Features.OtelCollector.Conf = &v2alpha1.CustomConfig{}
+	builder.datadogAgent.Spec.Features.OtelCollector.Conf = &apicommon.CustomConfig{}
 	builder.datadogAgent.Spec.Features.OtelCollector.Conf.ConfigData =
 		apiutils.NewStringPointer(defaultconfig.DefaultOtelCollectorConfig)
 	return builder
@@ -422,8 +423,8 @@ func (builder *DatadogAgentBuilder) WithOTelCollectorCoreConfigExtensionURL(url
 }
 
 func (builder *DatadogAgentBuilder) WithOTelCollectorConfigMap() *DatadogAgentBuilder {
-	builder.datadogAgent.Spec.Features.OtelCollector.Conf = &v2alpha1.CustomConfig{}
-	builder.datadogAgent.Spec.Features.OtelCollector.Conf.ConfigMap = &v2alpha1.ConfigMapConfig{
+	builder.datadogAgent.Spec.Features.OtelCollector.Conf = &apicommon.CustomConfig{}
+	builder.datadogAgent.Spec.Features.OtelCollector.Conf.ConfigMap = &apicommon.ConfigMapConfig{
 		Name: "user-provided-config-map",
 	}
 	return builder
@@ -448,7 +449,7 @@ func (builder *DatadogAgentBuilder) WithOTelCollectorPorts(grpcPort int32, httpP
 // Log Collection
 func (builder *DatadogAgentBuilder) initLogCollection() {
 	if builder.datadogAgent.Spec.Features.LogCollection == nil {
-		builder.datadogAgent.Spec.Features.LogCollection = &v2alpha1.LogCollectionFeatureConfig{}
+		builder.datadogAgent.Spec.Features.LogCollection = &apicommon.LogCollectionFeatureConfig{}
 	}
 }
 
@@ -485,6 +486,18 @@ func (builder *DatadogAgentBuilder) WithLogCollectionPaths(podLogs, containerLog
 	return builder
 }
 
+func (builder *DatadogAgentBuilder) WithLogCollectionProcessingRules(rules ...apicommon.LogsProcessingRule) *DatadogAgentBuilder {
+	builder.initLogCollection()
+	builder.datadogAgent.Spec.Features.LogCollection.ProcessingRules = rules
+	return builder
+}
+
+func (builder *DatadogAgentBuilder) WithLogCollectionAutoMultiLineDetection(config *apicommon.AutoMultiLineDetectionConfig) *DatadogAgentBuilder {
+	builder.initLogCollection()
+	builder.datadogAgent.Spec.Features.LogCollection.AutoMultiLineDetection = config
+	return builder
+}
+
 // Event Collection
 func (builder *DatadogAgentBuilder) initEventCollection() {
 	if builder.datadogAgent.Spec.Features.EventCollection == nil {
@@ -507,6 +520,14 @@ func (builder *DatadogAgentBuilder) WithEventCollectionUnbundleEvents(enabled bo
 	return builder
 }
 
+func (builder *DatadogAgentBuilder) WithEventCollectionFilter(filter *v2alpha1.EventFilter, maxEventsPerRun *int32) *DatadogAgentBuilder {
+	builder.initEventCollection()
+	builder.datadogAgent.Spec.Features.EventCollection.Filter = filter
+	builder.datadogAgent.Spec.Features.EventCollection.MaxEventsPerRun = maxEventsPerRun
+
+	return builder
+}
+
 // Remote Config
 func (builder *DatadogAgentBuilder) initRemoteConfig() {
 	if builder.datadogAgent.Spec.Features.RemoteConfiguration == nil {
@@ -536,7 +557,7 @@ func (builder *DatadogAgentBuilder) WithKSMEnabled(enabled bool) *DatadogAgentBu
 
 func (builder *DatadogAgentBuilder) WithKSMCustomConf(customData string) *DatadogAgentBuilder {
 	builder.initKSM()
-	builder.datadogAgent.Spec.Features.KubeStateMetricsCore.Conf = &v2alpha1.CustomConfig{
+	builder.datadogAgent.Spec.Features.KubeStateMetricsCore.Conf = &apicommon.CustomConfig{
 		ConfigData: apiutils.NewStringPointer(customData),
 	}
 	return builder
@@ -576,7 +597,7 @@ func (builder *DatadogAgentBuilder) WithOrchestratorExplorerDDUrl(ddUrl string)
 
 func (builder *DatadogAgentBuilder) WithOrchestratorExplorerCustomConfigData(customConfigData string) *DatadogAgentBuilder {
 	builder.initOE()
-	builder.datadogAgent.Spec.Features.OrchestratorExplorer.Conf = &v2alpha1.CustomConfig{
+	builder.datadogAgent.Spec.Features.OrchestratorExplorer.Conf = &apicommon.CustomConfig{
 		ConfigData: &customConfigData,
 	}
 	return builder
@@ -634,6 +655,20 @@ func (builder *DatadogAgentBuilder) WithPrometheusScrapeVersion(version int) *Da
 	return builder
 }
 
+func (builder *DatadogAgentBuilder) WithPrometheusScrapeConfigs(configs []v2alpha1.PrometheusScrapeConfig) *DatadogAgentBuilder {
+	builder.initPrometheusScrape()
+	builder.datadogAgent.Spec.Features.PrometheusScrape.Configs = configs
+	return builder
+}
+
+func (builder *DatadogAgentBuilder) WithPrometheusOperatorEnabled(enabled bool) *DatadogAgentBuilder {
+	builder.initPrometheusScrape()
+	builder.datadogAgent.Spec.Features.PrometheusScrape.PrometheusOperator = &v2alpha1.PrometheusOperatorConfig{
+		Enabled: apiutils.NewBoolPointer(enabled),
+	}
+	return builder
+}
+
 // APM
 
 func (builder *DatadogAgentBuilder) initAPM() {
@@ -658,7 +693,7 @@ func (builder *DatadogAgentBuilder) WithErrorTrackingStandalone(enabled bool) *D
 
 func (builder *DatadogAgentBuilder) WithAPMHostPortEnabled(enabled bool, port *int32) *DatadogAgentBuilder {
 	builder.initAPM()
-	builder.datadogAgent.Spec.Features.APM.HostPortConfig = &v2alpha1.HostPortConfig{
+	builder.datadogAgent.Spec.Features.APM.HostPortConfig = &apicommon.HostPortConfig{
 		Enabled: apiutils.NewBoolPointer(enabled),
 	}
 	if port != nil {
@@ -669,7 +704,7 @@ func (builder *DatadogAgentBuilder) WithAPMHostPortEnabled(enabled bool, port *i
 
 func (builder *DatadogAgentBuilder) WithAPMUDSEnabled(enabled bool, apmSocketHostPath string) *DatadogAgentBuilder {
 	builder.initAPM()
-	builder.datadogAgent.Spec.Features.APM.UnixDomainSocketConfig = &v2alpha1.UnixDomainSocketConfig{
+	builder.datadogAgent.Spec.Features.APM.UnixDomainSocketConfig = &apicommon.UnixDomainSocketConfig{
 		Enabled: apiutils.NewBoolPointer(enabled),
 		Path:    apiutils.NewStringPointer(apmSocketHostPath),
 	}
@@ -729,7 +764,7 @@ func (builder *DatadogAgentBuilder) WithOTLPGRPCSettings(enabled bool, hostPortE
 	builder.initOTLP()
 	builder.datadogAgent.Spec.Features.OTLP.Receiver.Protocols.GRPC = &v2alpha1.OTLPGRPCConfig{
 		Enabled: apiutils.NewBoolPointer(enabled),
-		HostPortConfig: &v2alpha1.HostPortConfig{
+		HostPortConfig: &apicommon.HostPortConfig{
 			Enabled: apiutils.NewBoolPointer(hostPortEnabled),
 			Port:    apiutils.NewInt32Pointer(customHostPort),
 		},
@@ -742,7 +777,7 @@ func (builder *DatadogAgentBuilder) WithOTLPHTTPSettings(enabled bool, hostPortE
 	builder.initOTLP()
 	builder.datadogAgent.Spec.Features.OTLP.Receiver.Protocols.HTTP = &v2alpha1.OTLPHTTPConfig{
 		Enabled: apiutils.NewBoolPointer(enabled),
-		HostPortConfig: &v2alpha1.HostPortConfig{
+		HostPortConfig: &apicommon.HostPortConfig{
 			Enabled: apiutils.NewBoolPointer(hostPortEnabled),
 			Port:    apiutils.NewInt32Pointer(customHostPort),
 		},
@@ -755,7 +790,7 @@ func (builder *DatadogAgentBuilder) WithOTLPHTTPSettings(enabled bool, hostPortE
 
 func (builder *DatadogAgentBuilder) initNPM() {
 	if builder.datadogAgent.Spec.Features.NPM == nil {
-		builder.datadogAgent.Spec.Features.NPM = &v2alpha1.NPMFeatureConfig{}
+		builder.datadogAgent.Spec.Features.NPM = &apicommon.NPMFeatureConfig{}
 	}
 }
 
@@ -809,7 +844,7 @@ func (builder *DatadogAgentBuilder) WithCWSInstrumentationEnabled(enabled bool)
 
 func (builder *DatadogAgentBuilder) WithCWSInstrumentationMode(mode string) *DatadogAgentBuilder {
 	builder.initCWSInstrumentation()
-	builder.datadogAgent.Spec.Features.AdmissionController.CWSInstrumentation.Mode = apiutils.NewStringPointer(mode)
+	builder.datadogAgent.Spec.Features.AdmissionController.CWSInstrumentation.Mode = apiutils.NewPointer(v2alpha1.CWSInstrumentationMode(mode))
 	return builder
 }
 
@@ -817,7 +852,7 @@ func (builder *DatadogAgentBuilder) WithCWSInstrumentationMode(mode string) *Dat
 
 func (builder *DatadogAgentBuilder) initOOMKill() {
 	if builder.datadogAgent.Spec.Features.OOMKill == nil {
-		builder.datadogAgent.Spec.Features.OOMKill = &v2alpha1.OOMKillFeatureConfig{}
+		builder.datadogAgent.Spec.Features.OOMKill = &apicommon.OOMKillFeatureConfig{}
 	}
 }
 
@@ -853,6 +888,18 @@ func (builder *DatadogAgentBuilder) WithHelmCheckValuesAsTags(valuesAsTags map[s
 	return builder
 }
 
+func (builder *DatadogAgentBuilder) WithHelmCheckNamespaces(include, exclude []string) *DatadogAgentBuilder {
+	builder.initHelmCheck()
+	builder.datadogAgent.Spec.Features.HelmCheck.Namespaces = &v2alpha1.HelmCheckFilter{Include: include, Exclude: exclude}
+	return builder
+}
+
+func (builder *DatadogAgentBuilder) WithHelmCheckStorageDriver(storageDriver v2alpha1.HelmStorageDriver) *DatadogAgentBuilder {
+	builder.initHelmCheck()
+	builder.datadogAgent.Spec.Features.HelmCheck.StorageDriver = &storageDriver
+	return builder
+}
+
 // Global Kubelet
 
 func (builder *DatadogAgentBuilder) WithGlobalKubeletConfig(hostCAPath, agentCAPath string, tlsVerify bool, podResourcesSocketDir string) *DatadogAgentBuilder {
@@ -922,6 +969,27 @@ func (builder *DatadogAgentBuilder) WithChecksTagCardinality(cardinality string)
 	return builder
 }
 
+// Global AdditionalEndpoints
+
+func (builder *DatadogAgentBuilder) WithGlobalAdditionalEndpoints(additionalEndpoints *v2alpha1.AdditionalEndpointsConfig) *DatadogAgentBuilder {
+	builder.datadogAgent.Spec.Global.AdditionalEndpoints = additionalEndpoints
+	return builder
+}
+
+// Global Proxy
+
+func (builder *DatadogAgentBuilder) WithGlobalProxy(proxy *v2alpha1.ProxyConfig) *DatadogAgentBuilder {
+	builder.datadogAgent.Spec.Global.Proxy = proxy
+	return builder
+}
+
+// Global ClusterAgentTLS
+
+func (builder *DatadogAgentBuilder) WithGlobalClusterAgentTLS(clusterAgentTLS *v2alpha1.ClusterAgentTLSConfig) *DatadogAgentBuilder {
+	builder.datadogAgent.Spec.Global.ClusterAgentTLS = clusterAgentTLS
+	return builder
+}
+
 // Global SecretBackend
 
 func (builder *DatadogAgentBuilder) WithGlobalSecretBackendGlobalPerms(command string, args string, timeout int32) *DatadogAgentBuilder {
@@ -976,7 +1044,7 @@ func (builder *DatadogAgentBuilder) WithFIPS(fipsConfig v2alpha1.FIPSConfig) *Da
 
 func (builder *DatadogAgentBuilder) initGPUMonitoring() {
 	if builder.datadogAgent.Spec.Features.GPU == nil {
-		builder.datadogAgent.Spec.Features.GPU = &v2alpha1.GPUFeatureConfig{}
+		builder.datadogAgent.Spec.Features.GPU = &apicommon.GPUFeatureConfig{}
 	}
 }
 
@@ -0,0 +1,74 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// NewManagerClient is a client.NewClientFunc creating the manager client with NewClient
+func NewManagerClient(config *rest.Config, options client.Options) (client.Client, error) {
+	c, err := client.NewWithWatch(config, options)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(c), nil
+}
+
+// NewClient returns a Kubernetes client recording a span for each write to the API server
+func NewClient(c client.WithWatch) client.WithWatch {
+	return interceptor.NewClient(c, interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			ctx, span := startWriteSpan(ctx, c, "create", obj)
+			err := c.Create(ctx, obj, opts...)
+			EndSpan(span, err)
+			return err
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			ctx, span := startWriteSpan(ctx, c, "update", obj)
+			err := c.Update(ctx, obj, opts...)
+			EndSpan(span, err)
+			return err
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			ctx, span := startWriteSpan(ctx, c, "patch", obj)
+			err := c.Patch(ctx, obj, patch, opts...)
+			EndSpan(span, err)
+			return err
+		},
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			ctx, span := startWriteSpan(ctx, c, "delete", obj)
+			err := c.Delete(ctx, obj, opts...)
+			EndSpan(span, err)
+			return err
+		},
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			ctx, span := startWriteSpan(ctx, c, "update_"+subResourceName, obj)
+			err := c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			EndSpan(span, err)
+			return err
+		},
+		SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			ctx, span := startWriteSpan(ctx, c, "patch_"+subResourceName, obj)
+			err := c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			EndSpan(span, err)
+			return err
+		},
+	})
+}
+
+func startWriteSpan(ctx context.Context, c client.Client, verb string, obj client.Object) (context.Context, trace.Span) {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := c.GroupVersionKindFor(obj); err == nil {
+		kind = gvk.Kind
+	}
+	return StartSpan(ctx, "kubernetes."+verb, ObjectAttributes(kind, obj)...)
+}
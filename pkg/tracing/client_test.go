@@ -0,0 +1,48 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewClient(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previousProvider)
+
+	c := NewClient(fake.NewClientBuilder().Build())
+	ctx, parent := StartSpan(context.Background(), "DatadogAgent.Reconcile")
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"}}
+	require.NoError(t, c.Create(ctx, cm))
+	// Creating the ConfigMap twice fails
+	require.Error(t, c.Create(ctx, cm.DeepCopy()))
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	create := spans[0]
+	assert.Equal(t, "kubernetes.create", create.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), create.Parent().SpanID())
+	assert.ElementsMatch(t, RequestAttributes("ConfigMap", "foo", "bar"), create.Attributes())
+	assert.Equal(t, codes.Unset, create.Status().Code)
+
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
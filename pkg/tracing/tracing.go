@@ -0,0 +1,121 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/DataDog/datadog-operator/pkg/version"
+)
+
+const (
+	serviceName = "datadog-operator"
+	tracerName  = "github.com/DataDog/datadog-operator"
+
+	// Attribute keys of the custom resource identity
+	kindAttributeKey      = attribute.Key("k8s.object.kind")
+	namespaceAttributeKey = attribute.Key("k8s.namespace.name")
+	nameAttributeKey      = attribute.Key("k8s.object.name")
+)
+
+// Attribute keys of the DatadogAgent reconcile stages
+const (
+	FeatureAttributeKey  = attribute.Key("datadog.feature.id")
+	ProviderAttributeKey = attribute.Key("datadog.provider")
+	ProfileAttributeKey  = attribute.Key("datadog.profile.name")
+)
+
+// Options contains the OTLP trace export options
+type Options struct {
+	// Enabled enables the OTLP trace export
+	Enabled bool
+	// Endpoint is the `host:port` address of the OTLP gRPC receiver
+	Endpoint string
+	// Insecure disables TLS when connecting to the OTLP receiver, for instance with a local collector
+	Insecure bool
+	// SampleRatio is the ratio of the reconcile traces sampled, between 0 and 1
+	SampleRatio float64
+}
+
+// Setup configures the global tracer provider to export the traces to an OTLP receiver.
+// It returns a function flushing the pending spans, to call before the process exits.
+// When tracing is disabled, the spans are not recorded.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if !opts.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+	if opts.SampleRatio < 0 || opts.SampleRatio > 1 {
+		return nil, fmt.Errorf("invalid trace sample ratio %v, must be between 0 and 1", opts.SampleRatio)
+	}
+
+	exporterOpts := []otlptracegrpc.Option{}
+	if opts.Endpoint != "" {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithEndpoint(opts.Endpoint))
+	}
+	if opts.Insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the OTLP trace exporter: %w", err)
+	}
+
+	res := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version.Version),
+	)
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span, child of the span of the context if any
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records the error, if any, and ends the span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ObjectAttributes returns the span attributes identifying a Kubernetes object
+func ObjectAttributes(kind string, obj client.Object) []attribute.KeyValue {
+	return RequestAttributes(kind, obj.GetNamespace(), obj.GetName())
+}
+
+// RequestAttributes returns the span attributes identifying the custom resource of a reconcile request
+func RequestAttributes(kind, namespace, name string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		kindAttributeKey.String(kind),
+		namespaceAttributeKey.String(namespace),
+		nameAttributeKey.String(name),
+	}
+}